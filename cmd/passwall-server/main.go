@@ -20,6 +20,15 @@ func main() {
 		return
 	}
 	applyWorkDir()
+
+	// passwall-server migrate <status|up|down> runs migrations and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		code := runMigrate(ctx, os.Args[2:])
+		stop()
+		os.Exit(code)
+	}
+
 	logStartupInfo()
 
 	// Create application context with signal handling
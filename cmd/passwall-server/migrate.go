@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/passwall/passwall-server/internal/config"
+	"github.com/passwall/passwall-server/internal/core"
+	"github.com/passwall/passwall-server/pkg/constants"
+	"github.com/passwall/passwall-server/pkg/database"
+	"github.com/passwall/passwall-server/pkg/database/migrate"
+)
+
+const migrateUsage = `Usage: passwall-server migrate <status|up|down> [flags]
+
+Commands:
+  status   show applied and pending migrations
+  up       apply pending migrations
+  down     roll back the last -steps migrations
+
+Flags:
+`
+
+// runMigrate runs the migrate subcommand and returns the process exit code
+func runMigrate(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	scope := fs.String("scope", "all", "schemas to migrate: all, public or users")
+	schema := fs.String("schema", "", "migrate a single schema (overrides -scope)")
+	steps := fs.Int("steps", 1, "number of migrations to roll back (down only)")
+	concurrency := fs.Int("concurrency", 0, "user schemas migrated at once (default from config)")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), migrateUsage)
+		fs.PrintDefaults()
+	}
+
+	if len(args) == 0 {
+		fs.Usage()
+		return 2
+	}
+	command := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	switch command {
+	case "status", "up", "down":
+	default:
+		fs.Usage()
+		return 2
+	}
+	if *scope != "all" && *scope != "public" && *scope != "users" {
+		fmt.Fprintf(os.Stderr, "invalid -scope %q (allowed: all, public, users)\n", *scope)
+		return 2
+	}
+	if command == "down" && *steps <= 0 {
+		fmt.Fprintln(os.Stderr, "-steps must be positive")
+		return 2
+	}
+
+	cfg, err := config.Load(config.LoaderOptions{
+		ConfigFile: constants.ConfigFilePath,
+		EnvPrefix:  constants.EnvPrefix,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		return 1
+	}
+	if *concurrency > 0 {
+		cfg.Database.MigrationConcurrency = *concurrency
+	}
+
+	db, err := core.InitDatabase(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	// Versioned SQL migrations are PostgreSQL-only; SQLite is always built by AutoMigrate
+	if database.DialectOf(db.DB()).IsSQLite() {
+		if command != "up" {
+			fmt.Fprintln(os.Stderr, "sqlite: versioned migrations are not used; the schema is created by AutoMigrate at startup")
+			return 2
+		}
+		if _, err := core.MigrateDatabase(ctx, db, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return 1
+		}
+		fmt.Println("sqlite: schema up to date")
+		return 0
+	}
+
+	m, err := core.NewMigrator(db, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	// Resolve target schemas; public always runs before user schemas
+	var schemas []string
+	if *schema != "" {
+		schemas = []string{*schema}
+	} else {
+		if *scope != "users" {
+			schemas = append(schemas, migrate.PublicSchema)
+		}
+		if *scope != "public" {
+			users, err := core.ListUserSchemas(ctx, db.DB())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				return 1
+			}
+			schemas = append(schemas, users...)
+		}
+	}
+
+	// Down rolls back user schemas first so public changes they depend on stay in place
+	if command == "down" && len(schemas) > 0 && schemas[0] == migrate.PublicSchema {
+		schemas = append(schemas[1:], migrate.PublicSchema)
+	}
+
+	var results []migrate.SchemaResult
+	switch command {
+	case "status":
+		results = m.StatusSchemas(ctx, schemas)
+	case "up":
+		// Public goes first (with the legacy baseline and AutoMigrate); user schemas only if it succeeds
+		if len(schemas) > 0 && schemas[0] == migrate.PublicSchema {
+			n, err := core.MigrateDatabase(ctx, db, m)
+			results = append(results, migrate.SchemaResult{Schema: migrate.PublicSchema, Count: n, Err: err})
+			schemas = schemas[1:]
+			if err != nil {
+				results = append(results, skippedResults(schemas)...)
+				break
+			}
+		}
+		results = append(results, m.UpSchemas(ctx, schemas)...)
+	case "down":
+		// Public goes last; it is only rolled back if every user schema succeeded
+		n := len(schemas)
+		if n > 0 && schemas[n-1] == migrate.PublicSchema {
+			results = m.DownSchemas(ctx, schemas[:n-1], *steps)
+			if len(core.FailedSchemas(results)) > 0 {
+				results = append(results, skippedResults(schemas[n-1:])...)
+				break
+			}
+			schemas = schemas[n-1:]
+		}
+		results = append(results, m.DownSchemas(ctx, schemas, *steps)...)
+	}
+
+	printMigrateResults(command, results)
+
+	if failed := core.FailedSchemas(results); len(failed) > 0 {
+		fmt.Fprintf(os.Stderr, "%d of %d schema(s) failed\n", len(failed), len(results))
+		return 1
+	}
+	return 0
+}
+
+var errSkipped = errors.New("skipped after earlier failure")
+
+// skippedResults reports schemas that were not migrated because an earlier step failed
+func skippedResults(schemas []string) []migrate.SchemaResult {
+	results := make([]migrate.SchemaResult, len(schemas))
+	for i, s := range schemas {
+		results[i] = migrate.SchemaResult{Schema: s, Err: errSkipped}
+	}
+	return results
+}
+
+// printMigrateResults prints one line per schema
+func printMigrateResults(command string, results []migrate.SchemaResult) {
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			fmt.Printf("%-40s FAILED  %v\n", r.Schema, r.Err)
+		case command == "status":
+			state := "up to date"
+			if !r.Status.UpToDate() {
+				pending := make([]string, 0, len(r.Status.Pending))
+				for _, mig := range r.Status.Pending {
+					pending = append(pending, mig.String())
+				}
+				state = "pending: " + strings.Join(pending, ", ")
+			}
+			fmt.Printf("%-40s %03d/%03d %s\n", r.Schema, r.Status.Current, r.Status.Latest, state)
+		case command == "up":
+			fmt.Printf("%-40s OK      %d applied\n", r.Schema, r.Count)
+		default:
+			fmt.Printf("%-40s OK      %d rolled back\n", r.Schema, r.Count)
+		}
+	}
+}
@@ -8,12 +8,18 @@ import (
 	"github.com/passwall/passwall-server/internal/repository"
 )
 
+// CollectionTreeReader resolves a collection's position in the collection tree
+type CollectionTreeReader interface {
+	// ListAncestorIDs returns the collection followed by its parent, grandparent, ... up to the root
+	ListAncestorIDs(ctx context.Context, collectionID uint) ([]uint, error)
+}
+
 type CollectionUserAccessReader interface {
-	GetByCollectionAndOrgUser(ctx context.Context, collectionID, orgUserID uint) (*domain.CollectionUser, error)
+	ListByOrgUserAndCollections(ctx context.Context, orgUserID uint, collectionIDs []uint) ([]*domain.CollectionUser, error)
 }
 
 type CollectionTeamAccessReader interface {
-	ListByCollection(ctx context.Context, collectionID uint) ([]*domain.CollectionTeam, error)
+	ListByCollections(ctx context.Context, collectionIDs []uint) ([]*domain.CollectionTeam, error)
 }
 
 type TeamMembershipReader interface {
@@ -27,15 +33,23 @@ type CollectionAccess struct {
 	HidePasswords bool
 }
 
+// ComputeCollectionAccess returns the member's access to a collection. Grants are
+// inherited down the collection tree: the nearest collection, starting with the
+// collection itself, on which the member holds a direct or team grant decides the
+// access, so a grant on a child overrides what it would inherit. Grants at that
+// level are merged, with hide_passwords winning. The whole chain is evaluated with
+// one ancestor lookup and one grant query per grant type, regardless of depth.
 func ComputeCollectionAccess(
 	ctx context.Context,
 	orgUser *domain.OrganizationUser,
 	collectionID uint,
+	collectionRepo CollectionTreeReader,
 	collectionUserRepo CollectionUserAccessReader,
 	collectionTeamRepo CollectionTeamAccessReader,
 	teamUserRepo TeamMembershipReader,
 ) (*CollectionAccess, error) {
-	if orgUser == nil {
+	// Suspended members keep their grants but cannot use them until restored.
+	if orgUser == nil || orgUser.IsSuspended() {
 		return &CollectionAccess{}, repository.ErrForbidden
 	}
 
@@ -48,44 +62,119 @@ func ComputeCollectionAccess(
 		}, nil
 	}
 
-	result := &CollectionAccess{}
+	chain, err := collectionRepo.ListAncestorIDs(ctx, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load collection ancestors: %w", err)
+	}
+	if len(chain) == 0 {
+		chain = []uint{collectionID}
+	}
+
+	grants := make(map[uint][]CollectionAccess, len(chain))
 
-	direct, err := collectionUserRepo.GetByCollectionAndOrgUser(ctx, collectionID, orgUser.ID)
-	if err == nil && direct != nil {
-		result.CanRead = result.CanRead || direct.CanRead
-		result.CanWrite = result.CanWrite || direct.CanWrite
-		result.CanAdmin = result.CanAdmin || direct.CanAdmin
-		result.HidePasswords = result.HidePasswords || direct.HidePasswords
-	} else if err != nil && err != repository.ErrNotFound {
+	direct, err := collectionUserRepo.ListByOrgUserAndCollections(ctx, orgUser.ID, chain)
+	if err != nil && err != repository.ErrNotFound {
 		return nil, fmt.Errorf("failed to load direct collection access: %w", err)
 	}
+	for _, cu := range direct {
+		grants[cu.CollectionID] = append(grants[cu.CollectionID], CollectionAccess{
+			CanRead:       cu.CanRead,
+			CanWrite:      cu.CanWrite,
+			CanAdmin:      cu.CanAdmin,
+			HidePasswords: cu.HidePasswords,
+		})
+	}
 
 	teamUsers, err := teamUserRepo.ListByOrgUser(ctx, orgUser.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load team membership: %w", err)
 	}
-	if len(teamUsers) == 0 {
-		return result, nil
+	if len(teamUsers) > 0 {
+		teamIDs := make(map[uint]struct{}, len(teamUsers))
+		for _, tu := range teamUsers {
+			teamIDs[tu.TeamID] = struct{}{}
+		}
+
+		teamAccess, err := collectionTeamRepo.ListByCollections(ctx, chain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load team collection access: %w", err)
+		}
+		for _, ta := range teamAccess {
+			if _, ok := teamIDs[ta.TeamID]; !ok {
+				continue
+			}
+			grants[ta.CollectionID] = append(grants[ta.CollectionID], CollectionAccess{
+				CanRead:       ta.CanRead,
+				CanWrite:      ta.CanWrite,
+				CanAdmin:      ta.CanAdmin,
+				HidePasswords: ta.HidePasswords,
+			})
+		}
 	}
 
-	teamIDs := make(map[uint]struct{}, len(teamUsers))
-	for _, tu := range teamUsers {
-		teamIDs[tu.TeamID] = struct{}{}
+	result := &CollectionAccess{}
+	for _, id := range chain {
+		level := grants[id]
+		if len(level) == 0 {
+			continue
+		}
+		for _, g := range level {
+			result.CanRead = result.CanRead || g.CanRead
+			result.CanWrite = result.CanWrite || g.CanWrite
+			result.CanAdmin = result.CanAdmin || g.CanAdmin
+			result.HidePasswords = result.HidePasswords || g.HidePasswords
+		}
+		break
 	}
 
-	teamAccess, err := collectionTeamRepo.ListByCollection(ctx, collectionID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load team collection access: %w", err)
+	return result, nil
+}
+
+// ComputeItemAccess returns the member's access to an item that belongs to several
+// collections: the union of the access granted through each of them. Passwords are
+// hidden only when every collection granting read access hides them. An item in no
+// collection grants nothing beyond admin and access_all.
+func ComputeItemAccess(
+	ctx context.Context,
+	orgUser *domain.OrganizationUser,
+	collectionIDs []uint,
+	collectionRepo CollectionTreeReader,
+	collectionUserRepo CollectionUserAccessReader,
+	collectionTeamRepo CollectionTeamAccessReader,
+	teamUserRepo TeamMembershipReader,
+) (*CollectionAccess, error) {
+	if orgUser == nil || orgUser.IsSuspended() {
+		return &CollectionAccess{}, repository.ErrForbidden
 	}
-	for _, ta := range teamAccess {
-		if _, ok := teamIDs[ta.TeamID]; !ok {
+	if orgUser.IsAdmin() || orgUser.AccessAll {
+		return &CollectionAccess{
+			CanRead:  true,
+			CanWrite: true,
+			CanAdmin: true,
+		}, nil
+	}
+
+	result := &CollectionAccess{}
+	revealed := false
+	seen := make(map[uint]struct{}, len(collectionIDs))
+	for _, collectionID := range collectionIDs {
+		if _, ok := seen[collectionID]; ok {
 			continue
 		}
-		result.CanRead = result.CanRead || ta.CanRead
-		result.CanWrite = result.CanWrite || ta.CanWrite
-		result.CanAdmin = result.CanAdmin || ta.CanAdmin
-		result.HidePasswords = result.HidePasswords || ta.HidePasswords
+		seen[collectionID] = struct{}{}
+
+		access, err := ComputeCollectionAccess(ctx, orgUser, collectionID, collectionRepo, collectionUserRepo, collectionTeamRepo, teamUserRepo)
+		if err != nil {
+			return nil, err
+		}
+		result.CanRead = result.CanRead || access.CanRead
+		result.CanWrite = result.CanWrite || access.CanWrite
+		result.CanAdmin = result.CanAdmin || access.CanAdmin
+		if access.CanRead && !access.HidePasswords {
+			revealed = true
+		}
 	}
+	result.HidePasswords = result.CanRead && !revealed
 
 	return result, nil
 }
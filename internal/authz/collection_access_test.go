@@ -6,7 +6,6 @@ import (
 	"testing"
 
 	"github.com/passwall/passwall-server/internal/domain"
-	"github.com/passwall/passwall-server/internal/repository"
 )
 
 type fakeCollectionUserRepo struct {
@@ -14,14 +13,17 @@ type fakeCollectionUserRepo struct {
 	err   error
 }
 
-func (f *fakeCollectionUserRepo) GetByCollectionAndOrgUser(_ context.Context, _, _ uint) (*domain.CollectionUser, error) {
+// ListByOrgUserAndCollections returns the grant as if held on the first collection asked for
+func (f *fakeCollectionUserRepo) ListByOrgUserAndCollections(_ context.Context, _ uint, collectionIDs []uint) ([]*domain.CollectionUser, error) {
 	if f.err != nil {
 		return nil, f.err
 	}
 	if f.grant == nil {
-		return nil, repository.ErrNotFound
+		return nil, nil
 	}
-	return f.grant, nil
+	grant := *f.grant
+	grant.CollectionID = collectionIDs[0]
+	return []*domain.CollectionUser{&grant}, nil
 }
 
 type fakeCollectionTeamRepo struct {
@@ -29,11 +31,25 @@ type fakeCollectionTeamRepo struct {
 	err    error
 }
 
-func (f *fakeCollectionTeamRepo) ListByCollection(_ context.Context, _ uint) ([]*domain.CollectionTeam, error) {
+// ListByCollections returns the grants as if held on the first collection asked for
+func (f *fakeCollectionTeamRepo) ListByCollections(_ context.Context, collectionIDs []uint) ([]*domain.CollectionTeam, error) {
 	if f.err != nil {
 		return nil, f.err
 	}
-	return f.grants, nil
+	grants := make([]*domain.CollectionTeam, len(f.grants))
+	for i, g := range f.grants {
+		grant := *g
+		grant.CollectionID = collectionIDs[0]
+		grants[i] = &grant
+	}
+	return grants, nil
+}
+
+// flatTree treats every collection as a root
+type flatTree struct{}
+
+func (flatTree) ListAncestorIDs(_ context.Context, collectionID uint) ([]uint, error) {
+	return []uint{collectionID}, nil
 }
 
 type fakeTeamUserRepo struct {
@@ -61,6 +77,7 @@ func TestComputeCollectionAccess_AdminAndAccessAll(t *testing.T) {
 			ctx,
 			orgUser,
 			1,
+			flatTree{},
 			&fakeCollectionUserRepo{},
 			&fakeCollectionTeamRepo{},
 			&fakeTeamUserRepo{},
@@ -81,6 +98,7 @@ func TestComputeCollectionAccess_AdminAndAccessAll(t *testing.T) {
 			ctx,
 			orgUser,
 			1,
+			flatTree{},
 			&fakeCollectionUserRepo{},
 			&fakeCollectionTeamRepo{},
 			&fakeTeamUserRepo{},
@@ -105,6 +123,7 @@ func TestComputeCollectionAccess_MergesDirectAndTeamGrants(t *testing.T) {
 		context.Background(),
 		orgUser,
 		100,
+		flatTree{},
 		&fakeCollectionUserRepo{
 			grant: &domain.CollectionUser{
 				CanRead:  true,
@@ -141,7 +160,7 @@ func TestComputeCollectionAccess_HidePasswords(t *testing.T) {
 	t.Run("direct grant sets hide_passwords", func(t *testing.T) {
 		t.Parallel()
 		access, err := ComputeCollectionAccess(
-			context.Background(), orgUser, 100,
+			context.Background(), orgUser, 100, flatTree{},
 			&fakeCollectionUserRepo{grant: &domain.CollectionUser{CanRead: true, HidePasswords: true}},
 			&fakeCollectionTeamRepo{},
 			&fakeTeamUserRepo{},
@@ -157,7 +176,7 @@ func TestComputeCollectionAccess_HidePasswords(t *testing.T) {
 	t.Run("team grant sets hide_passwords", func(t *testing.T) {
 		t.Parallel()
 		access, err := ComputeCollectionAccess(
-			context.Background(), orgUser, 100,
+			context.Background(), orgUser, 100, flatTree{},
 			&fakeCollectionUserRepo{},
 			&fakeCollectionTeamRepo{grants: []*domain.CollectionTeam{
 				{TeamID: 10, CanRead: true, HidePasswords: true},
@@ -175,7 +194,7 @@ func TestComputeCollectionAccess_HidePasswords(t *testing.T) {
 	t.Run("restrictive wins across grants", func(t *testing.T) {
 		t.Parallel()
 		access, err := ComputeCollectionAccess(
-			context.Background(), orgUser, 100,
+			context.Background(), orgUser, 100, flatTree{},
 			&fakeCollectionUserRepo{grant: &domain.CollectionUser{CanRead: true, HidePasswords: false}},
 			&fakeCollectionTeamRepo{grants: []*domain.CollectionTeam{
 				{TeamID: 10, CanRead: true, HidePasswords: true},
@@ -194,7 +213,7 @@ func TestComputeCollectionAccess_HidePasswords(t *testing.T) {
 		t.Parallel()
 		admin := &domain.OrganizationUser{Role: domain.OrgRoleAdmin}
 		access, err := ComputeCollectionAccess(
-			context.Background(), admin, 100,
+			context.Background(), admin, 100, flatTree{},
 			&fakeCollectionUserRepo{grant: &domain.CollectionUser{CanRead: true, HidePasswords: true}},
 			&fakeCollectionTeamRepo{},
 			&fakeTeamUserRepo{},
@@ -218,6 +237,7 @@ func TestComputeCollectionAccess_ErrorPropagation(t *testing.T) {
 		context.Background(),
 		orgUser,
 		1,
+		flatTree{},
 		&fakeCollectionUserRepo{err: testErr},
 		&fakeCollectionTeamRepo{},
 		&fakeTeamUserRepo{},
@@ -226,3 +246,141 @@ func TestComputeCollectionAccess_ErrorPropagation(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+type fakeGrantsByCollection map[uint]*domain.CollectionUser
+
+func (f fakeGrantsByCollection) ListByOrgUserAndCollections(_ context.Context, _ uint, collectionIDs []uint) ([]*domain.CollectionUser, error) {
+	grants := make([]*domain.CollectionUser, 0)
+	for _, id := range collectionIDs {
+		if grant, ok := f[id]; ok {
+			g := *grant
+			g.CollectionID = id
+			grants = append(grants, &g)
+		}
+	}
+	return grants, nil
+}
+
+func TestComputeItemAccess_UnionAcrossCollections(t *testing.T) {
+	t.Parallel()
+
+	orgUser := &domain.OrganizationUser{ID: 99, Role: domain.OrgRoleMember}
+	grants := fakeGrantsByCollection{
+		1: {CanRead: true, HidePasswords: true},
+		2: {CanRead: true, CanWrite: true},
+		3: {CanRead: true, HidePasswords: true},
+	}
+
+	tests := []struct {
+		name          string
+		collectionIDs []uint
+		wantRead      bool
+		wantWrite     bool
+		wantHidden    bool
+	}{
+		{name: "no collections", collectionIDs: nil},
+		{name: "no grant", collectionIDs: []uint{4}},
+		{name: "single hidden", collectionIDs: []uint{1}, wantRead: true, wantHidden: true},
+		{name: "all hidden", collectionIDs: []uint{1, 3}, wantRead: true, wantHidden: true},
+		{name: "write from one collection", collectionIDs: []uint{1, 2}, wantRead: true, wantWrite: true},
+		{name: "ungranted collection ignored", collectionIDs: []uint{4, 1}, wantRead: true, wantHidden: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			access, err := ComputeItemAccess(
+				context.Background(), orgUser, tt.collectionIDs, flatTree{},
+				grants, &fakeCollectionTeamRepo{}, &fakeTeamUserRepo{},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if access.CanRead != tt.wantRead || access.CanWrite != tt.wantWrite || access.HidePasswords != tt.wantHidden {
+				t.Fatalf("got %#v", access)
+			}
+		})
+	}
+
+	t.Run("admin needs no collection", func(t *testing.T) {
+		t.Parallel()
+		admin := &domain.OrganizationUser{Role: domain.OrgRoleAdmin}
+		access, err := ComputeItemAccess(context.Background(), admin, nil, flatTree{}, grants, &fakeCollectionTeamRepo{}, &fakeTeamUserRepo{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !access.CanRead || !access.CanWrite || access.HidePasswords {
+			t.Fatalf("expected full access, got %#v", access)
+		}
+	})
+}
+
+// parentTree maps each collection to its parent; roots are absent
+type parentTree map[uint]uint
+
+func (p parentTree) ListAncestorIDs(_ context.Context, collectionID uint) ([]uint, error) {
+	chain := []uint{collectionID}
+	for {
+		parent, ok := p[chain[len(chain)-1]]
+		if !ok {
+			return chain, nil
+		}
+		chain = append(chain, parent)
+	}
+}
+
+type fakeTeamGrantsByCollection map[uint]*domain.CollectionTeam
+
+func (f fakeTeamGrantsByCollection) ListByCollections(_ context.Context, collectionIDs []uint) ([]*domain.CollectionTeam, error) {
+	grants := make([]*domain.CollectionTeam, 0)
+	for _, id := range collectionIDs {
+		if grant, ok := f[id]; ok {
+			g := *grant
+			g.CollectionID = id
+			grants = append(grants, &g)
+		}
+	}
+	return grants, nil
+}
+
+func TestComputeCollectionAccess_InheritsFromAncestors(t *testing.T) {
+	t.Parallel()
+
+	// 1 -> 2 -> 3 -> 4, and 5 -> 6
+	tree := parentTree{2: 1, 3: 2, 4: 3, 6: 5}
+	orgUser := &domain.OrganizationUser{ID: 99, Role: domain.OrgRoleMember}
+	direct := fakeGrantsByCollection{
+		1: {CanRead: true, CanWrite: true},
+		3: {CanRead: true, HidePasswords: true},
+	}
+	teams := fakeTeamGrantsByCollection{
+		5: {TeamID: 7, CanRead: true, CanAdmin: true},
+	}
+	memberships := &fakeTeamUserRepo{memberships: []*domain.TeamUser{{TeamID: 7}}}
+
+	tests := []struct {
+		name         string
+		collectionID uint
+		want         CollectionAccess
+	}{
+		{name: "grant on root", collectionID: 1, want: CollectionAccess{CanRead: true, CanWrite: true}},
+		{name: "inherited from parent", collectionID: 2, want: CollectionAccess{CanRead: true, CanWrite: true}},
+		{name: "child grant overrides inherited", collectionID: 3, want: CollectionAccess{CanRead: true, HidePasswords: true}},
+		{name: "override is inherited further down", collectionID: 4, want: CollectionAccess{CanRead: true, HidePasswords: true}},
+		{name: "team grant inherited", collectionID: 6, want: CollectionAccess{CanRead: true, CanAdmin: true}},
+		{name: "unrelated root", collectionID: 8, want: CollectionAccess{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			access, err := ComputeCollectionAccess(context.Background(), orgUser, tt.collectionID, tree, direct, teams, memberships)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *access != tt.want {
+				t.Fatalf("got %#v, want %#v", access, tt.want)
+			}
+		})
+	}
+}
@@ -2,43 +2,97 @@ package authz
 
 import "github.com/passwall/passwall-server/internal/domain"
 
-func CanViewBilling(role domain.OrganizationRole) bool {
-	return role == domain.OrgRoleOwner ||
-		role == domain.OrgRoleAdmin ||
-		role == domain.OrgRoleManager ||
-		role == domain.OrgRoleBilling
+// EffectivePermissions returns the union of the member's role preset, custom role and
+// direct grants. Owners hold every permission; suspended or missing members hold none.
+func EffectivePermissions(orgUser *domain.OrganizationUser) []domain.OrgPermission {
+	perms := make([]domain.OrgPermission, 0)
+	if orgUser == nil || orgUser.IsSuspended() {
+		return perms
+	}
+
+	granted := make(map[domain.OrgPermission]bool)
+	if orgUser.Role == domain.OrgRoleOwner {
+		for _, p := range domain.AllOrgPermissions() {
+			granted[p] = true
+		}
+	}
+	for _, p := range domain.OrgRolePresets[orgUser.Role] {
+		granted[p] = true
+	}
+	if orgUser.CustomRole != nil {
+		for _, p := range orgUser.CustomRole.PermissionList() {
+			granted[p] = true
+		}
+	}
+	for _, p := range domain.ParseOrgPermissions(orgUser.Permissions) {
+		granted[p] = true
+	}
+
+	// Keep catalog order for stable responses
+	for _, p := range domain.AllOrgPermissions() {
+		if granted[p] {
+			perms = append(perms, p)
+		}
+	}
+	return perms
+}
+
+// HasPermission reports whether the member holds the permission
+func HasPermission(orgUser *domain.OrganizationUser, perm domain.OrgPermission) bool {
+	for _, p := range EffectivePermissions(orgUser) {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// CanGrant reports whether the member holds every permission in perms, so that
+// handing them out would not escalate beyond the granter's own access
+func CanGrant(granter *domain.OrganizationUser, perms []domain.OrgPermission) bool {
+	held := make(map[domain.OrgPermission]bool)
+	for _, p := range EffectivePermissions(granter) {
+		held[p] = true
+	}
+	for _, p := range perms {
+		if !held[p] {
+			return false
+		}
+	}
+	return true
+}
+
+// The helpers below evaluate the member's effective permissions, so custom roles
+// and direct grants count as well as the built-in role presets.
+
+func CanViewBilling(orgUser *domain.OrganizationUser) bool {
+	return HasPermission(orgUser, domain.OrgPermViewBilling)
 }
 
-func CanManageBilling(role domain.OrganizationRole) bool {
-	return role == domain.OrgRoleOwner ||
-		role == domain.OrgRoleAdmin ||
-		role == domain.OrgRoleBilling
+func CanManageBilling(orgUser *domain.OrganizationUser) bool {
+	return HasPermission(orgUser, domain.OrgPermManageBilling)
 }
 
-func CanAccessOrganizationSettings(role domain.OrganizationRole) bool {
-	return role == domain.OrgRoleOwner ||
-		role == domain.OrgRoleAdmin ||
-		role == domain.OrgRoleManager
+func CanAccessOrganizationSettings(orgUser *domain.OrganizationUser) bool {
+	return HasPermission(orgUser, domain.OrgPermViewSettings)
 }
 
-func CanViewManagementOverview(role domain.OrganizationRole) bool {
-	return CanAccessOrganizationSettings(role)
+func CanViewManagementOverview(orgUser *domain.OrganizationUser) bool {
+	return CanAccessOrganizationSettings(orgUser)
 }
 
-func CanViewMemberDirectory(role domain.OrganizationRole) bool {
-	return role == domain.OrgRoleOwner || role == domain.OrgRoleAdmin
+func CanViewMemberDirectory(orgUser *domain.OrganizationUser) bool {
+	return HasPermission(orgUser, domain.OrgPermViewMembers)
 }
 
-func CanViewSecurityAndAudit(role domain.OrganizationRole) bool {
-	return role == domain.OrgRoleOwner || role == domain.OrgRoleAdmin
+func CanViewSecurityAndAudit(orgUser *domain.OrganizationUser) bool {
+	return HasPermission(orgUser, domain.OrgPermViewAudit)
 }
 
-func CanManagePolicies(role domain.OrganizationRole) bool {
-	return role == domain.OrgRoleOwner || role == domain.OrgRoleAdmin
+func CanManagePolicies(orgUser *domain.OrganizationUser) bool {
+	return HasPermission(orgUser, domain.OrgPermManagePolicies)
 }
 
-func CanViewPolicies(role domain.OrganizationRole) bool {
-	return role == domain.OrgRoleOwner ||
-		role == domain.OrgRoleAdmin ||
-		role == domain.OrgRoleManager
+func CanViewPolicies(orgUser *domain.OrganizationUser) bool {
+	return HasPermission(orgUser, domain.OrgPermViewPolicies)
 }
@@ -25,7 +25,7 @@ func TestCanViewBilling(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := CanViewBilling(tt.role); got != tt.want {
+			if got := CanViewBilling(&domain.OrganizationUser{Role: tt.role}); got != tt.want {
 				t.Fatalf("CanViewBilling(%q) = %v, want %v", tt.role, got, tt.want)
 			}
 		})
@@ -51,7 +51,7 @@ func TestCanManageBilling(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			if got := CanManageBilling(tt.role); got != tt.want {
+			if got := CanManageBilling(&domain.OrganizationUser{Role: tt.role}); got != tt.want {
 				t.Fatalf("CanManageBilling(%q) = %v, want %v", tt.role, got, tt.want)
 			}
 		})
@@ -105,18 +105,73 @@ func TestOverviewVisibilityMatrix(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			if got := CanViewManagementOverview(tt.role); got != tt.want.management {
+			if got := CanViewManagementOverview(&domain.OrganizationUser{Role: tt.role}); got != tt.want.management {
 				t.Fatalf("CanViewManagementOverview(%q) = %v, want %v", tt.role, got, tt.want.management)
 			}
-			if got := CanViewMemberDirectory(tt.role); got != tt.want.members {
+			if got := CanViewMemberDirectory(&domain.OrganizationUser{Role: tt.role}); got != tt.want.members {
 				t.Fatalf("CanViewMemberDirectory(%q) = %v, want %v", tt.role, got, tt.want.members)
 			}
-			if got := CanViewSecurityAndAudit(tt.role); got != tt.want.security {
+			if got := CanViewSecurityAndAudit(&domain.OrganizationUser{Role: tt.role}); got != tt.want.security {
 				t.Fatalf("CanViewSecurityAndAudit(%q) = %v, want %v", tt.role, got, tt.want.security)
 			}
-			if got := CanAccessOrganizationSettings(tt.role); got != tt.want.settings {
+			if got := CanAccessOrganizationSettings(&domain.OrganizationUser{Role: tt.role}); got != tt.want.settings {
 				t.Fatalf("CanAccessOrganizationSettings(%q) = %v, want %v", tt.role, got, tt.want.settings)
 			}
 		})
 	}
 }
+
+func TestHasPermission(t *testing.T) {
+	t.Parallel()
+
+	auditor := &domain.OrganizationCustomRole{Permissions: "view_audit,manage_scim"}
+
+	tests := []struct {
+		name    string
+		orgUser *domain.OrganizationUser
+		perm    domain.OrgPermission
+		want    bool
+	}{
+		{name: "owner holds everything", orgUser: &domain.OrganizationUser{Role: domain.OrgRoleOwner}, perm: domain.OrgPermApproveDevices, want: true},
+		{name: "admin preset", orgUser: &domain.OrganizationUser{Role: domain.OrgRoleAdmin}, perm: domain.OrgPermManageSSO, want: true},
+		{name: "manager preset", orgUser: &domain.OrganizationUser{Role: domain.OrgRoleManager}, perm: domain.OrgPermManageCollections, want: true},
+		{name: "manager lacks sso", orgUser: &domain.OrganizationUser{Role: domain.OrgRoleManager}, perm: domain.OrgPermManageSSO, want: false},
+		{name: "custom role", orgUser: &domain.OrganizationUser{Role: domain.OrgRoleMember, CustomRole: auditor}, perm: domain.OrgPermManageSCIM, want: true},
+		{name: "direct grant", orgUser: &domain.OrganizationUser{Role: domain.OrgRoleMember, Permissions: "approve_devices"}, perm: domain.OrgPermApproveDevices, want: true},
+		{name: "unknown grant ignored", orgUser: &domain.OrganizationUser{Role: domain.OrgRoleMember, Permissions: "root"}, perm: domain.OrgPermission("root"), want: false},
+		{name: "suspended admin", orgUser: &domain.OrganizationUser{Role: domain.OrgRoleAdmin, Status: domain.OrgUserStatusSuspended}, perm: domain.OrgPermManageMembers, want: false},
+		{name: "nil member", orgUser: nil, perm: domain.OrgPermViewMembers, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := HasPermission(tt.orgUser, tt.perm); got != tt.want {
+				t.Fatalf("HasPermission(%s) = %v, want %v", tt.perm, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanGrant(t *testing.T) {
+	t.Parallel()
+
+	delegate := &domain.OrganizationUser{
+		Role:       domain.OrgRoleMember,
+		CustomRole: &domain.OrganizationCustomRole{Permissions: "manage_members,view_members"},
+	}
+
+	if !CanGrant(delegate, []domain.OrgPermission{domain.OrgPermViewMembers}) {
+		t.Fatal("delegate should grant permissions it holds")
+	}
+	if CanGrant(delegate, []domain.OrgPermission{domain.OrgPermViewMembers, domain.OrgPermManageSSO}) {
+		t.Fatal("delegate must not grant permissions it lacks")
+	}
+	if CanGrant(delegate, domain.OrgRolePresets[domain.OrgRoleAdmin]) {
+		t.Fatal("delegate must not grant the admin preset")
+	}
+	if !CanGrant(&domain.OrganizationUser{Role: domain.OrgRoleAdmin}, domain.OrgRolePresets[domain.OrgRoleAdmin]) {
+		t.Fatal("admin should grant the admin preset")
+	}
+}
@@ -63,32 +63,58 @@ func (w *BreachMonitorWorker) Stop() {
 }
 
 func (w *BreachMonitorWorker) check(ctx context.Context) {
+	if enrolled, err := w.breachMonitorSvc.AutoEnrollMembers(ctx); err != nil {
+		logger.Errorf("Breach monitor: auto-enroll failed: %v", err)
+	} else if enrolled > 0 {
+		logger.Infof("Breach monitor: enrolled %d member emails", enrolled)
+	}
+
 	emails, err := w.breachMonitorRepo.ListAllMonitoredEmails(ctx)
 	if err != nil {
 		logger.Errorf("Breach monitor: failed to list emails: %v", err)
 		return
 	}
+	domains, err := w.breachMonitorRepo.ListAllMonitoredDomains(ctx)
+	if err != nil {
+		logger.Errorf("Breach monitor: failed to list domains: %v", err)
+		return
+	}
 
-	if len(emails) == 0 {
+	// Domain searches and address checks both run per organization
+	var orgIDs []uint
+	seen := make(map[uint]bool)
+	for _, d := range domains {
+		if !seen[d.OrganizationID] {
+			seen[d.OrganizationID] = true
+			orgIDs = append(orgIDs, d.OrganizationID)
+		}
+	}
+	for _, email := range emails {
+		if !seen[email.OrganizationID] {
+			seen[email.OrganizationID] = true
+			orgIDs = append(orgIDs, email.OrganizationID)
+		}
+	}
+
+	if len(orgIDs) == 0 {
 		return
 	}
 
 	totalNew := 0
-	for _, email := range emails {
-		canUse, err := w.featureSvc.CanUseBreachMonitoring(ctx, email.OrganizationID)
+	for _, orgID := range orgIDs {
+		canUse, err := w.featureSvc.CanUseBreachMonitoring(ctx, orgID)
 		if err != nil || !canUse {
 			continue
 		}
 
-		newBreaches, err := w.breachMonitorSvc.CheckSingleEmail(ctx, email)
+		newBreaches, err := w.breachMonitorSvc.CheckOrganization(ctx, orgID)
 		if err != nil {
-			logger.Errorf("Breach monitor: check failed for email ID %d: %v", email.ID, err)
-			continue
+			logger.Errorf("Breach monitor: check failed for org %d: %v", orgID, err)
 		}
 		totalNew += newBreaches
 	}
 
 	if totalNew > 0 {
-		logger.Infof("Breach monitor: found %d new breaches across %d emails", totalNew, len(emails))
+		logger.Infof("Breach monitor: found %d new breaches across %d organizations", totalNew, len(orgIDs))
 	}
 }
@@ -0,0 +1,66 @@
+package cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+// CollectionAccessWorker revokes temporary collection access once the
+// duration approved for an access request has passed
+type CollectionAccessWorker struct {
+	accessRequestService service.CollectionAccessRequestService
+	logger               interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	}
+	interval time.Duration
+}
+
+// NewCollectionAccessWorker creates a new collection access worker
+func NewCollectionAccessWorker(
+	accessRequestService service.CollectionAccessRequestService,
+	logger interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	},
+	interval time.Duration,
+) *CollectionAccessWorker {
+	if interval == 0 {
+		interval = 5 * time.Minute // Default to every 5 minutes
+	}
+
+	return &CollectionAccessWorker{
+		accessRequestService: accessRequestService,
+		logger:               logger,
+		interval:             interval,
+	}
+}
+
+// Run starts the collection access worker
+func (w *CollectionAccessWorker) Run(ctx context.Context) {
+	w.logger.Info("collection access worker started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	w.process(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.process(ctx)
+		case <-ctx.Done():
+			w.logger.Info("collection access worker stopped")
+			return
+		}
+	}
+}
+
+func (w *CollectionAccessWorker) process(ctx context.Context) {
+	if err := w.accessRequestService.ProcessExpiredGrants(ctx); err != nil {
+		w.logger.Error("failed to process expired collection access", "error", err)
+	}
+}
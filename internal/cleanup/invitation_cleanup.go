@@ -0,0 +1,71 @@
+package cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+// InvitationCleanup removes expired invitations and the pending organization
+// memberships created for them
+type InvitationCleanup struct {
+	orgService service.OrganizationService
+	logger     interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	}
+	interval time.Duration
+}
+
+// NewInvitationCleanup creates a new invitation cleanup worker
+func NewInvitationCleanup(
+	orgService service.OrganizationService,
+	logger interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	},
+	interval time.Duration,
+) *InvitationCleanup {
+	if interval == 0 {
+		interval = 1 * time.Hour // Default to hourly
+	}
+
+	return &InvitationCleanup{
+		orgService: orgService,
+		logger:     logger,
+		interval:   interval,
+	}
+}
+
+// Run starts the invitation cleanup worker
+func (w *InvitationCleanup) Run(ctx context.Context) {
+	w.logger.Info("invitation cleanup started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	w.cleanup(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.cleanup(ctx)
+		case <-ctx.Done():
+			w.logger.Info("invitation cleanup stopped")
+			return
+		}
+	}
+}
+
+func (w *InvitationCleanup) cleanup(ctx context.Context) {
+	removed, err := w.orgService.RemoveExpiredInvitations(ctx)
+	if err != nil {
+		w.logger.Error("failed to remove expired invitations", "error", err)
+		return
+	}
+	if removed > 0 {
+		w.logger.Info("expired invitations removed", "count", removed)
+	}
+}
@@ -0,0 +1,75 @@
+package cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+// ItemShareExpiryWorker warns owners and recipients before an item share expires
+// and revokes shares once they have expired
+type ItemShareExpiryWorker struct {
+	itemShareService service.ItemShareService
+	logger           interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	}
+	interval     time.Duration
+	noticeWindow time.Duration
+}
+
+// NewItemShareExpiryWorker creates a new item share expiry worker. Expiry notices
+// go out noticeWindow before a share expires; zero disables them.
+func NewItemShareExpiryWorker(
+	itemShareService service.ItemShareService,
+	logger interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	},
+	interval time.Duration,
+	noticeWindow time.Duration,
+) *ItemShareExpiryWorker {
+	if interval == 0 {
+		interval = 15 * time.Minute // Default to every 15 minutes
+	}
+
+	return &ItemShareExpiryWorker{
+		itemShareService: itemShareService,
+		logger:           logger,
+		interval:         interval,
+		noticeWindow:     noticeWindow,
+	}
+}
+
+// Run starts the item share expiry worker
+func (w *ItemShareExpiryWorker) Run(ctx context.Context) {
+	w.logger.Info("item share expiry worker started", "interval", w.interval, "notice_window", w.noticeWindow)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	w.process(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.process(ctx)
+		case <-ctx.Done():
+			w.logger.Info("item share expiry worker stopped")
+			return
+		}
+	}
+}
+
+func (w *ItemShareExpiryWorker) process(ctx context.Context) {
+	if w.noticeWindow > 0 {
+		if err := w.itemShareService.NotifyExpiringShares(ctx, w.noticeWindow); err != nil {
+			w.logger.Error("failed to send share expiry notices", "error", err)
+		}
+	}
+	if err := w.itemShareService.RevokeExpiredShares(ctx); err != nil {
+		w.logger.Error("failed to revoke expired shares", "error", err)
+	}
+}
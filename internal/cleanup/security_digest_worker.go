@@ -0,0 +1,67 @@
+package cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+// SecurityDigestWorker emails the weekly security digest to organization admins.
+// It runs more often than weekly; the service skips organizations whose digest
+// went out less than a week ago.
+type SecurityDigestWorker struct {
+	digestService service.SecurityDigestService
+	logger        interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	}
+	interval time.Duration
+}
+
+// NewSecurityDigestWorker creates a new security digest worker
+func NewSecurityDigestWorker(
+	digestService service.SecurityDigestService,
+	logger interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	},
+	interval time.Duration,
+) *SecurityDigestWorker {
+	if interval == 0 {
+		interval = 1 * time.Hour // Default to every hour
+	}
+
+	return &SecurityDigestWorker{
+		digestService: digestService,
+		logger:        logger,
+		interval:      interval,
+	}
+}
+
+// Run starts the security digest worker
+func (w *SecurityDigestWorker) Run(ctx context.Context) {
+	w.logger.Info("security digest worker started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	w.process(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.process(ctx)
+		case <-ctx.Done():
+			w.logger.Info("security digest worker stopped")
+			return
+		}
+	}
+}
+
+func (w *SecurityDigestWorker) process(ctx context.Context) {
+	if _, err := w.digestService.SendWeeklyDigests(ctx); err != nil {
+		w.logger.Error("failed to send weekly security digests", "error", err)
+	}
+}
@@ -5,20 +5,23 @@ import (
 	"time"
 
 	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/pkg/blobstore"
 	"github.com/passwall/passwall-server/pkg/logger"
 )
 
-// SendCleanup handles periodic cleanup of expired sends
+// SendCleanup handles periodic cleanup of expired sends and their file blobs
 type SendCleanup struct {
 	sendRepo repository.SendRepository
+	blobs    blobstore.Store
 	interval time.Duration
 	stopChan chan struct{}
 }
 
 // NewSendCleanup creates a new send cleanup service
-func NewSendCleanup(sendRepo repository.SendRepository, interval time.Duration) *SendCleanup {
+func NewSendCleanup(sendRepo repository.SendRepository, blobs blobstore.Store, interval time.Duration) *SendCleanup {
 	return &SendCleanup{
 		sendRepo: sendRepo,
+		blobs:    blobs,
 		interval: interval,
 		stopChan: make(chan struct{}),
 	}
@@ -53,6 +56,8 @@ func (sc *SendCleanup) Stop() {
 }
 
 func (sc *SendCleanup) cleanup(ctx context.Context) {
+	sc.cleanupFiles(ctx)
+
 	deletedCount, err := sc.sendRepo.DeleteExpired(ctx)
 	if err != nil {
 		logger.Errorf("Failed to cleanup expired sends: %v", err)
@@ -63,3 +68,32 @@ func (sc *SendCleanup) cleanup(ctx context.Context) {
 		logger.Infof("Successfully cleaned up %d expired sends", deletedCount)
 	}
 }
+
+// cleanupFiles deletes each expired file send's blob before its row,
+// so a failed blob deletion is retried on the next run instead of being orphaned
+func (sc *SendCleanup) cleanupFiles(ctx context.Context) {
+	sends, err := sc.sendRepo.ListExpiredFiles(ctx)
+	if err != nil {
+		logger.Errorf("Failed to list expired file sends: %v", err)
+		return
+	}
+
+	deleted := 0
+	for _, send := range sends {
+		if sc.blobs != nil {
+			if err := sc.blobs.Delete(ctx, send.BlobKey()); err != nil {
+				logger.Errorf("Failed to delete blob for send %s: %v", send.UUID, err)
+				continue
+			}
+		}
+		if err := sc.sendRepo.Delete(ctx, send.ID); err != nil {
+			logger.Errorf("Failed to delete expired file send %s: %v", send.UUID, err)
+			continue
+		}
+		deleted++
+	}
+
+	if deleted > 0 {
+		logger.Infof("Successfully cleaned up %d expired file sends", deleted)
+	}
+}
@@ -9,8 +9,9 @@ import (
 
 // SubscriptionWorker handles background tasks for subscription lifecycle management
 type SubscriptionWorker struct {
-	subService service.SubscriptionService
-	logger     interface {
+	subService     service.SubscriptionService
+	dunningService service.DunningService
+	logger         interface {
 		Info(msg string, args ...interface{})
 		Error(msg string, args ...interface{})
 	}
@@ -20,6 +21,7 @@ type SubscriptionWorker struct {
 // NewSubscriptionWorker creates a new subscription worker
 func NewSubscriptionWorker(
 	subService service.SubscriptionService,
+	dunningService service.DunningService,
 	logger interface {
 		Info(msg string, args ...interface{})
 		Error(msg string, args ...interface{})
@@ -31,9 +33,10 @@ func NewSubscriptionWorker(
 	}
 
 	return &SubscriptionWorker{
-		subService: subService,
-		logger:     logger,
-		interval:   interval,
+		subService:     subService,
+		dunningService: dunningService,
+		logger:         logger,
+		interval:       interval,
 	}
 }
 
@@ -45,11 +48,13 @@ func (w *SubscriptionWorker) Run(ctx context.Context) {
 	defer ticker.Stop()
 
 	// Run immediately on start
+	w.processDunning(ctx)
 	w.processExpiredSubscriptions(ctx)
 
 	for {
 		select {
 		case <-ticker.C:
+			w.processDunning(ctx)
 			w.processExpiredSubscriptions(ctx)
 		case <-ctx.Done():
 			w.logger.Info("subscription worker stopped")
@@ -58,6 +63,20 @@ func (w *SubscriptionWorker) Run(ctx context.Context) {
 	}
 }
 
+// processDunning advances past_due subscriptions through the dunning schedule
+func (w *SubscriptionWorker) processDunning(ctx context.Context) {
+	if w.dunningService == nil {
+		return
+	}
+
+	if err := w.dunningService.ProcessPastDue(ctx); err != nil {
+		w.logger.Error("failed to process past due subscriptions", "error", err)
+		return
+	}
+
+	w.logger.Info("dunning check completed")
+}
+
 // processExpiredSubscriptions checks and expires subscriptions that should be expired
 func (w *SubscriptionWorker) processExpiredSubscriptions(ctx context.Context) {
 	w.logger.Info("checking for expired subscriptions")
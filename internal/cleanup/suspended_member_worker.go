@@ -0,0 +1,69 @@
+package cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+// SuspendedMemberWorker removes organization members that stayed suspended
+// longer than their organization allows, warning admins beforehand
+type SuspendedMemberWorker struct {
+	suspensionService service.MemberSuspensionService
+	logger            interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	}
+	interval time.Duration
+}
+
+// NewSuspendedMemberWorker creates a new suspended member worker
+func NewSuspendedMemberWorker(
+	suspensionService service.MemberSuspensionService,
+	logger interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	},
+	interval time.Duration,
+) *SuspendedMemberWorker {
+	if interval == 0 {
+		interval = 24 * time.Hour // Default to daily
+	}
+
+	return &SuspendedMemberWorker{
+		suspensionService: suspensionService,
+		logger:            logger,
+		interval:          interval,
+	}
+}
+
+// Run starts the suspended member worker
+func (w *SuspendedMemberWorker) Run(ctx context.Context) {
+	w.logger.Info("suspended member worker started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	// Run immediately on start
+	w.process(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.process(ctx)
+		case <-ctx.Done():
+			w.logger.Info("suspended member worker stopped")
+			return
+		}
+	}
+}
+
+func (w *SuspendedMemberWorker) process(ctx context.Context) {
+	if err := w.suspensionService.ProcessSuspendedRemovals(ctx); err != nil {
+		w.logger.Error("failed to process suspended members", "error", err)
+		return
+	}
+
+	w.logger.Info("suspended member check completed")
+}
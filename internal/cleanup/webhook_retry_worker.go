@@ -0,0 +1,58 @@
+package cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+// WebhookRetryWorker retries failed webhook inbox events once their backoff has elapsed
+type WebhookRetryWorker struct {
+	webhookInbox service.WebhookInboxService
+	logger       interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	}
+	interval time.Duration
+}
+
+// NewWebhookRetryWorker creates a new webhook retry worker
+func NewWebhookRetryWorker(
+	webhookInbox service.WebhookInboxService,
+	logger interface {
+		Info(msg string, args ...interface{})
+		Error(msg string, args ...interface{})
+	},
+	interval time.Duration,
+) *WebhookRetryWorker {
+	if interval == 0 {
+		interval = time.Minute // Default to 1 minute
+	}
+
+	return &WebhookRetryWorker{
+		webhookInbox: webhookInbox,
+		logger:       logger,
+		interval:     interval,
+	}
+}
+
+// Run starts the webhook retry worker
+func (w *WebhookRetryWorker) Run(ctx context.Context) {
+	w.logger.Info("webhook retry worker started", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.webhookInbox.ProcessDue(ctx); err != nil && ctx.Err() == nil {
+				w.logger.Error("failed to retry webhook events", "error", err)
+			}
+		case <-ctx.Done():
+			w.logger.Info("webhook retry worker stopped")
+			return
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/passwall/passwall-server/pkg/database"
 	"github.com/passwall/passwall-server/pkg/logger"
 	"github.com/spf13/viper"
 )
@@ -21,6 +22,36 @@ type Config struct {
 	RevenueCat RevenueCatConfig `mapstructure:"revenuecat"`
 	AI         AIConfig         `mapstructure:"ai"`
 	HIBP       HIBPConfig       `mapstructure:"hibp"`
+	License    LicenseConfig    `mapstructure:"license"`
+	Dunning    DunningConfig    `mapstructure:"dunning"`
+	Storage    StorageConfig    `mapstructure:"storage"`
+	Sharing    SharingConfig    `mapstructure:"sharing"`
+}
+
+// StorageConfig contains configuration for client-encrypted file storage (file sends).
+type StorageConfig struct {
+	Path          string `mapstructure:"path"`             // Directory where encrypted blobs are stored
+	SendFileMaxMB int    `mapstructure:"send_file_max_mb"` // File send size limit for plans that do not set one
+}
+
+// SharingConfig contains configuration for item sharing.
+type SharingConfig struct {
+	ExpiryNoticeHours int `mapstructure:"expiry_notice_hours"` // Owner and recipient are emailed this many hours before a share expires
+}
+
+// DunningConfig contains the follow-up schedule for past_due subscriptions.
+// All values are days since the first failed payment.
+type DunningConfig struct {
+	ReminderDays    []int `mapstructure:"reminder_days"`     // Days on which billing contacts get a reminder email
+	GraceDays       int   `mapstructure:"grace_days"`        // Vault becomes read-only after this many days
+	ExpireAfterDays int   `mapstructure:"expire_after_days"` // Subscription expires after this many days
+}
+
+// LicenseConfig contains configuration for offline signed license files.
+type LicenseConfig struct {
+	PublicKey string `mapstructure:"public_key"` // Ed25519 public key (hex or base64) used to verify license files
+	File      string `mapstructure:"file"`       // Path of the installed license file
+	GraceDays int    `mapstructure:"grace_days"` // Days entitlements are kept after license expiry
 }
 
 // HIBPConfig contains configuration for HIBP breach monitoring.
@@ -52,6 +83,9 @@ type ServerConfig struct {
 
 // DatabaseConfig contains database-related configuration
 type DatabaseConfig struct {
+	// Driver selects the database: postgres (default) or sqlite for single-node self-hosting
+	Driver   string `mapstructure:"driver"`
+	Path     string `mapstructure:"path"` // SQLite database file
 	Name     string `mapstructure:"name"`
 	Username string `mapstructure:"username"`
 	Password string `mapstructure:"password"`
@@ -59,6 +93,8 @@ type DatabaseConfig struct {
 	Port     string `mapstructure:"port"`
 	LogMode  bool   `mapstructure:"log_mode"`
 	SSLMode  string `mapstructure:"ssl_mode"`
+	// MigrationConcurrency bounds how many user schemas are migrated at once
+	MigrationConcurrency int `mapstructure:"migration_concurrency"`
 }
 
 // EmailConfig contains email-related configuration
@@ -121,6 +157,7 @@ type PlanFeatures struct {
 	Policies         bool `mapstructure:"policies"`          // Organization policies enabled
 	SecurityInsights bool `mapstructure:"security_insights"` // Security Insights dashboard (score, weak, reused, 2FA)
 	BreachMonitoring bool `mapstructure:"breach_monitoring"` // Dark web / breach monitoring enabled
+	SendFileMaxMB    *int `mapstructure:"send_file_max_mb"`  // File send size limit in MB (nil = storage default, 0 = file sends disabled)
 }
 
 // RevenueCatConfig contains RevenueCat in-app purchase configuration
@@ -254,6 +291,18 @@ func (c *Config) Validate() error {
 	}
 
 	// Database validation
+	dialect, err := database.ParseDialect(c.Database.Driver)
+	if err != nil {
+		return fmt.Errorf("database.driver is invalid: %w", err)
+	}
+	c.Database.Driver = string(dialect)
+	if dialect.IsSQLite() {
+		if strings.TrimSpace(c.Database.Path) == "" {
+			return fmt.Errorf("database.path is required for the sqlite driver")
+		}
+		return nil
+	}
+
 	if c.Database.Host == "" {
 		return fmt.Errorf("database.host is required")
 	}
@@ -299,6 +348,8 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.recaptcha_threshold", 0.5)
 
 	// Database defaults
+	v.SetDefault("database.driver", "postgres")
+	v.SetDefault("database.path", "passwall.db")
 	v.SetDefault("database.name", "passwall")
 	v.SetDefault("database.username", "postgres")
 	v.SetDefault("database.password", "password")
@@ -306,6 +357,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.port", "5432")
 	v.SetDefault("database.log_mode", false)
 	v.SetDefault("database.ssl_mode", "disable")
+	v.SetDefault("database.migration_concurrency", 4)
 
 	// Email defaults
 	v.SetDefault("email.host", "smtp.passwall.io")
@@ -336,6 +388,23 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("hibp.check_interval_hours", 24)
 	v.SetDefault("hibp.rate_limit_ms", 1600)
 	v.SetDefault("hibp.max_retries", 3)
+
+	// License defaults
+	v.SetDefault("license.public_key", "")
+	v.SetDefault("license.file", "./config/license.json")
+	v.SetDefault("license.grace_days", 14)
+
+	// Dunning defaults (past_due follow-up)
+	v.SetDefault("dunning.reminder_days", []int{1, 3, 7, 12})
+	v.SetDefault("dunning.grace_days", 14)
+	v.SetDefault("dunning.expire_after_days", 30)
+
+	// Storage defaults
+	v.SetDefault("storage.path", "./data/blobs")
+	v.SetDefault("storage.send_file_max_mb", 100)
+
+	// Sharing defaults
+	v.SetDefault("sharing.expiry_notice_hours", 24)
 }
 
 // bindEnvVariables binds environment variables for backwards compatibility
@@ -362,6 +431,8 @@ func bindEnvVariables(v *viper.Viper) {
 	bind("server.recaptcha_threshold", "PW_RECAPTCHA_THRESHOLD", "RECAPTCHA_THRESHOLD")
 
 	// Database bindings
+	bind("database.driver", "PW_DB_DRIVER")
+	bind("database.path", "PW_DB_PATH")
 	bind("database.name", "PW_DB_NAME", "POSTGRES_DB")
 	bind("database.username", "PW_DB_USERNAME", "POSTGRES_USER")
 	bind("database.password", "PW_DB_PASSWORD", "POSTGRES_PASSWORD")
@@ -369,6 +440,7 @@ func bindEnvVariables(v *viper.Viper) {
 	bind("database.port", "PW_DB_PORT", "POSTGRES_PORT")
 	bind("database.log_mode", "PW_DB_LOG_MODE")
 	bind("database.ssl_mode", "PW_DB_SSL_MODE")
+	bind("database.migration_concurrency", "PW_DB_MIGRATION_CONCURRENCY")
 
 	// Email bindings
 	bind("email.host", "PW_EMAIL_HOST")
@@ -402,6 +474,23 @@ func bindEnvVariables(v *viper.Viper) {
 	bind("hibp.check_interval_hours", "PW_HIBP_CHECK_INTERVAL_HOURS")
 	bind("hibp.rate_limit_ms", "PW_HIBP_RATE_LIMIT_MS")
 	bind("hibp.max_retries", "PW_HIBP_MAX_RETRIES")
+
+	// License bindings (offline license files)
+	bind("license.public_key", "PW_LICENSE_PUBLIC_KEY")
+	bind("license.file", "PW_LICENSE_FILE")
+	bind("license.grace_days", "PW_LICENSE_GRACE_DAYS")
+
+	// Dunning bindings
+	bind("dunning.reminder_days", "PW_DUNNING_REMINDER_DAYS")
+	bind("dunning.grace_days", "PW_DUNNING_GRACE_DAYS")
+	bind("dunning.expire_after_days", "PW_DUNNING_EXPIRE_AFTER_DAYS")
+
+	// Storage bindings
+	bind("storage.path", "PW_STORAGE_PATH")
+	bind("storage.send_file_max_mb", "PW_STORAGE_SEND_FILE_MAX_MB")
+
+	// Sharing bindings
+	bind("sharing.expiry_notice_hours", "PW_SHARING_EXPIRY_NOTICE_HOURS")
 }
 
 // createDefaultConfigFile creates a config file with default values
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -9,12 +10,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/passwall/passwall-server/internal/cleanup"
 	"github.com/passwall/passwall-server/internal/config"
+	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/email"
 	httpHandler "github.com/passwall/passwall-server/internal/handler/http"
 	"github.com/passwall/passwall-server/internal/repository/gormrepo"
 	"github.com/passwall/passwall-server/internal/service"
+	"github.com/passwall/passwall-server/pkg/blobstore"
 	"github.com/passwall/passwall-server/pkg/constants"
 	"github.com/passwall/passwall-server/pkg/database"
+	"github.com/passwall/passwall-server/pkg/database/migrate"
 	"github.com/passwall/passwall-server/pkg/hibp"
 	"github.com/passwall/passwall-server/pkg/logger"
 	stripeClient "github.com/passwall/passwall-server/pkg/stripe"
@@ -22,16 +26,23 @@ import (
 
 // App represents the application
 type App struct {
-	config              *config.Config
-	db                  database.Database
-	server              *http.Server
-	tokenCleanup        *cleanup.TokenCleanup
-	activityCleanup     *cleanup.ActivityCleanup
-	logCleanup          *cleanup.LogCleanup
-	sendCleanup         *cleanup.SendCleanup
-	breachMonitorWorker *cleanup.BreachMonitorWorker
-	subscriptionWorker  *cleanup.SubscriptionWorker
-	emailSender         email.Sender
+	config                 *config.Config
+	db                     database.Database
+	server                 *http.Server
+	tokenCleanup           *cleanup.TokenCleanup
+	activityCleanup        *cleanup.ActivityCleanup
+	logCleanup             *cleanup.LogCleanup
+	sendCleanup            *cleanup.SendCleanup
+	breachMonitorWorker    *cleanup.BreachMonitorWorker
+	subscriptionWorker     *cleanup.SubscriptionWorker
+	suspendedMemberWorker  *cleanup.SuspendedMemberWorker
+	collectionAccessWorker *cleanup.CollectionAccessWorker
+	itemShareExpiryWorker  *cleanup.ItemShareExpiryWorker
+	securityDigestWorker   *cleanup.SecurityDigestWorker
+	invitationCleanup      *cleanup.InvitationCleanup
+	webhookRetryWorker     *cleanup.WebhookRetryWorker
+	migrator               *migrate.Migrator
+	emailSender            email.Sender
 }
 
 // New creates a new application instance with the given context
@@ -51,8 +62,12 @@ func New(ctx context.Context) (*App, error) {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 
-	// Auto migrate - creates all tables with their final structure
-	if err := AutoMigrate(db); err != nil {
+	// Versioned migrations for the public schema (AutoMigrate creates the final structure)
+	migrator, err := NewMigrator(db, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := MigrateDatabase(ctx, db, migrator); err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
@@ -62,8 +77,9 @@ func New(ctx context.Context) (*App, error) {
 	}
 
 	return &App{
-		config: cfg,
-		db:     db,
+		config:   cfg,
+		db:       db,
+		migrator: migrator,
 	}, nil
 }
 
@@ -93,6 +109,8 @@ func (a *App) Run(ctx context.Context) error {
 	accountDeletionTokenRepo := gormrepo.NewAccountDeletionTokenRepository(a.db.DB())
 	userActivityRepo := gormrepo.NewUserActivityRepository(a.db.DB())
 	excludedDomainRepo := gormrepo.NewExcludedDomainRepository(a.db.DB())
+	orgExcludedDomainRepo := gormrepo.NewOrganizationExcludedDomainRepository(a.db.DB())
+	orgCustomRoleRepo := gormrepo.NewOrganizationCustomRoleRepository(a.db.DB())
 	compatTelemetryRepo := gormrepo.NewCompatTelemetryRepository(a.db.DB())
 	verdictRepo := gormrepo.NewTelemetryAIVerdictRepository(a.db.DB())
 	preferencesRepo := gormrepo.NewPreferencesRepository(a.db.DB())
@@ -106,6 +124,7 @@ func (a *App) Run(ctx context.Context) error {
 	collectionRepo := gormrepo.NewCollectionRepository(a.db.DB())
 	collectionUserRepo := gormrepo.NewCollectionUserRepository(a.db.DB())
 	collectionTeamRepo := gormrepo.NewCollectionTeamRepository(a.db.DB())
+	collectionAccessRequestRepo := gormrepo.NewCollectionAccessRequestRepository(a.db.DB())
 	orgItemRepo := gormrepo.NewOrganizationItemRepository(a.db.DB())
 	orgFolderRepo := gormrepo.NewOrganizationFolderRepository(a.db.DB())
 	// Item share repo (personal sharing)
@@ -114,6 +133,7 @@ func (a *App) Run(ctx context.Context) error {
 	emergencyAccessRepo := gormrepo.NewEmergencyAccessRepository(a.db.DB())
 	// Send repo
 	sendRepo := gormrepo.NewSendRepository(a.db.DB())
+	sendRecipientRepo := gormrepo.NewSendRecipientRepository(a.db.DB())
 
 	// NOTE: Legacy repos removed - all item types now use ItemRepository with type field
 
@@ -151,7 +171,7 @@ func (a *App) Run(ctx context.Context) error {
 
 	// Initialize services
 	userActivityService := service.NewUserActivityService(userActivityRepo, serviceLogger)
-	excludedDomainService := service.NewExcludedDomainService(excludedDomainRepo, serviceLogger)
+	excludedDomainService := service.NewExcludedDomainService(excludedDomainRepo, orgExcludedDomainRepo, orgUserRepo, serviceLogger)
 	compatTelemetryService := service.NewCompatTelemetryService(compatTelemetryRepo, serviceLogger)
 	preferencesService := service.NewPreferencesService(preferencesRepo, serviceLogger)
 	verificationService := service.NewVerificationService(verificationRepo, userRepo, serviceLogger)
@@ -164,7 +184,7 @@ func (a *App) Run(ctx context.Context) error {
 	orgPolicyRepo := gormrepo.NewOrganizationPolicyRepository(a.db.DB())
 
 	// Organization policy service (created early so failedLoginTracker can use it in authService)
-	organizationPolicyService := service.NewOrganizationPolicyService(orgPolicyRepo, orgUserRepo, subscriptionRepo, serviceLogger)
+	organizationPolicyService := service.NewOrganizationPolicyService(orgPolicyRepo, orgUserRepo, subscriptionRepo, orgExcludedDomainRepo, serviceLogger)
 	failedLoginTracker := service.NewFailedLoginTracker(organizationPolicyService)
 
 	userService := service.NewUserService(
@@ -183,22 +203,16 @@ func (a *App) Run(ctx context.Context) error {
 	authService := service.NewAuthService(userRepo, tokenRepo, verificationRepo, accountDeletionTokenRepo, orgRepo, orgUserRepo, orgFolderRepo, invitationRepo, subscriptionRepo, orgPolicyRepo, failedLoginTracker, userActivityService, userService, emailSender, emailBuilder, authConfig, serviceLogger)
 	userNotificationPreferencesService := service.NewUserNotificationPreferencesService(preferencesRepo, serviceLogger)
 	userAppearancePreferencesService := service.NewUserAppearancePreferencesService(preferencesRepo, serviceLogger)
-	invitationService := service.NewInvitationService(invitationRepo, userRepo, orgRepo, emailSender, emailBuilder, serviceLogger)
+	invitationService := service.NewInvitationService(invitationRepo, userRepo, orgRepo, preferencesRepo, emailSender, emailBuilder, serviceLogger)
 
 	// Modern flexible items service (handles all item types)
 	itemService := service.NewItemService(itemRepo, serviceLogger)
-	itemShareService := service.NewItemShareService(
-		itemShareRepo,
-		orgItemRepo,
-		userRepo,
-		emailSender,
-		emailBuilder,
-		serviceLogger,
-	)
-
 	// Initialize Stripe client
 	stripeClientInstance := stripeClient.NewClient(a.config.Stripe.SecretKey, a.config.Stripe.WebhookSecret)
 
+	// Seat service - reconciles membership with billed seats (seat management mode)
+	seatService := service.NewSeatService(stripeClientInstance, subscriptionRepo, orgRepo, orgUserRepo, preferencesRepo, userActivityService, serviceLogger)
+
 	// Create a placeholder payment service for organizationService (will be updated later)
 	var paymentService service.PaymentService
 
@@ -213,18 +227,26 @@ func (a *App) Run(ctx context.Context) error {
 		collectionUserRepo,
 		collectionTeamRepo,
 		orgPolicyRepo,
+		orgCustomRoleRepo,
 		paymentService,
 		invitationService,
+		seatService,
 		subscriptionRepo,
 		planRepo,
 		serviceLogger,
 	)
 
 	// Subscription service (needs organizationService, stripe client, email service optional, logger)
-	subscriptionService := service.NewSubscriptionService(subscriptionRepo, planRepo, orgRepo, organizationService, nil, stripeClientInstance, serviceLogger)
+	dunningSchedule := domain.NewDunningSchedule(a.config.Dunning.ReminderDays, a.config.Dunning.GraceDays, a.config.Dunning.ExpireAfterDays)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo, planRepo, orgRepo, organizationService, nil, stripeClientInstance, dunningSchedule, serviceLogger)
+	dunningService := service.NewDunningService(dunningSchedule, subscriptionRepo, subscriptionService, orgRepo, orgUserRepo, emailSender, emailBuilder, userActivityService, serviceLogger)
+
+	// Promotion service - promo codes, redemption audit trail and admin trial extensions
+	promotionRepo := gormrepo.NewPromotionRepository(a.db.DB())
+	promotionService := service.NewPromotionService(promotionRepo, stripeClientInstance, subscriptionService, orgRepo, userActivityService, serviceLogger)
 
 	// Payment service - handles org subscriptions via Stripe webhooks
-	paymentService = service.NewPaymentService(stripeClientInstance, orgRepo, orgUserRepo, userRepo, subscriptionService, planRepo, userActivityService, a.config, serviceLogger)
+	paymentService = service.NewPaymentService(stripeClientInstance, orgRepo, orgUserRepo, userRepo, subscriptionService, planRepo, promotionService, userActivityService, a.config, serviceLogger)
 
 	// RevenueCat service - handles mobile in-app purchases via webhooks (org-level subscriptions)
 	revenueCatService := service.NewRevenueCatService(userRepo, orgRepo, subscriptionService, planRepo, userActivityService, a.config, serviceLogger)
@@ -265,24 +287,59 @@ func (a *App) Run(ctx context.Context) error {
 		serviceLogger,
 	)
 
-	// Send service
-	sendService := service.NewSendService(sendRepo, userRepo, orgUserRepo, orgPolicyRepo, emailSender, emailBuilder, serviceLogger)
-
 	// HIBP clients
 	hibpClient := hibp.NewClient(a.config.HIBP.APIKey, a.config.HIBP.RateLimitMs, a.config.HIBP.MaxRetries)
 	pwnedPasswordsClient := hibp.NewPwnedPasswordsClient(0, 0) // defaults: 60 min TTL, 10k entries
 
 	// Feature service (used for plan-based feature gating)
-	featureService := service.NewFeatureService(organizationService, subscriptionRepo, orgItemRepo)
+	// Offline license (self-hosted entitlements). Invalid or missing licenses never block startup.
+	licenseService := service.NewLicenseService(a.config.License, orgRepo, serviceLogger)
+	if err := licenseService.Load(ctx); err != nil && !errors.Is(err, service.ErrLicensingNotConfigured) {
+		serviceLogger.Error("failed to load license file", "path", a.config.License.File, "error", err)
+	}
 
-	// Breach monitoring
+	featureService := service.NewFeatureService(organizationService, subscriptionRepo, orgItemRepo, licenseService)
+
+	// Send service (file sends store client-encrypted blobs on disk)
+	sendBlobs, err := blobstore.NewFS(a.config.Storage.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize blob storage: %w", err)
+	}
+	sendService := service.NewSendService(sendRepo, sendRecipientRepo, userRepo, orgUserRepo, orgPolicyRepo, featureService, service.SendFileOptions{
+		Store:        sendBlobs,
+		DefaultMaxMB: a.config.Storage.SendFileMaxMB,
+	}, emailSender, emailBuilder, serviceLogger)
+
+	// In-app notifications
+	notificationService := service.NewNotificationService(gormrepo.NewNotificationRepository(a.db.DB()), serviceLogger)
+
+	// Breach monitoring (new breaches flag the owner's vault items for rotation)
+	rotationRecommendationService := service.NewRotationRecommendationService(
+		gormrepo.NewRotationRecommendationRepository(a.db.DB()), userRepo, orgUserRepo, itemRepo, orgItemRepo,
+		organizationItemService, notificationService, emailSender, emailBuilder, serviceLogger,
+	)
 	breachMonitorRepo := gormrepo.NewBreachMonitorRepository(a.db.DB())
-	breachMonitorService := service.NewBreachMonitorService(breachMonitorRepo, hibpClient, featureService, orgUserRepo)
+	breachMonitorService := service.NewBreachMonitorService(breachMonitorRepo, hibpClient, featureService, orgUserRepo, preferencesRepo, rotationRecommendationService)
+
+	// Vault health reports (Security Insights)
+	vaultHealthRepo := gormrepo.NewVaultHealthRepository(a.db.DB())
+	vaultHealthService := service.NewVaultHealthService(vaultHealthRepo, orgItemRepo, organizationItemService, collectionRepo, orgUserRepo, preferencesRepo, featureService, serviceLogger)
 
 	// Organization policy enforcement services
 	policyEnforcementService := service.NewPolicyEnforcementService(organizationPolicyService)
 	policyFirewallService := service.NewPolicyFirewallService(organizationPolicyService)
 
+	itemShareService := service.NewItemShareService(
+		itemShareRepo,
+		orgItemRepo,
+		userRepo,
+		orgUserRepo,
+		policyEnforcementService,
+		emailSender,
+		emailBuilder,
+		serviceLogger,
+	)
+
 	// Organization settings service (uses existing preferences repo)
 	organizationSettingsService := service.NewOrganizationSettingsService(preferencesRepo, orgUserRepo, serviceLogger)
 
@@ -309,7 +366,7 @@ func (a *App) Run(ctx context.Context) error {
 	// SCIM service
 	scimService := service.NewSCIMService(
 		scimTokenRepo, userRepo, orgUserRepo, teamRepo, teamUserRepo,
-		serviceLogger, serverBaseURL,
+		seatService, serviceLogger, serverBaseURL,
 	)
 
 	// Initialize handlers
@@ -333,14 +390,80 @@ func (a *App) Run(ctx context.Context) error {
 	aiTelemetryHandler := httpHandler.NewAITelemetryHandler(aiTelemetryAnalysisService)
 	// Organization handlers
 	organizationHandler := httpHandler.NewOrganizationHandler(organizationService, organizationPolicyService, subscriptionRepo, userActivityService)
+	memberSuspensionService := service.NewMemberSuspensionService(
+		orgRepo,
+		orgUserRepo,
+		preferencesRepo,
+		keyEscrowRepo,
+		tokenRepo,
+		seatService,
+		organizationService,
+		emailSender,
+		emailBuilder,
+		userActivityService,
+		serviceLogger,
+	)
+	memberSuspensionHandler := httpHandler.NewMemberSuspensionHandler(memberSuspensionService, userActivityService)
+	organizationRoleService := service.NewOrganizationRoleService(orgCustomRoleRepo, orgUserRepo, serviceLogger)
+	organizationRoleHandler := httpHandler.NewOrganizationRoleHandler(organizationRoleService)
 	teamHandler := httpHandler.NewTeamHandler(teamService, userActivityService, organizationService)
 	collectionHandler := httpHandler.NewCollectionHandler(collectionService, userActivityService, organizationService)
+	collectionAccessRequestService := service.NewCollectionAccessRequestService(
+		collectionAccessRequestRepo,
+		collectionRepo,
+		collectionUserRepo,
+		collectionTeamRepo,
+		teamUserRepo,
+		orgRepo,
+		orgUserRepo,
+		emailSender,
+		emailBuilder,
+		userActivityService,
+		serviceLogger,
+	)
+	collectionAccessRequestHandler := httpHandler.NewCollectionAccessRequestHandler(collectionAccessRequestService, userActivityService)
 	organizationItemHandler := httpHandler.NewOrganizationItemHandler(organizationItemService, userActivityService, policyEnforcementService)
 	organizationFolderHandler := httpHandler.NewOrganizationFolderHandler(organizationFolderService)
 
+	// Vault export (personal + accessible organization items, still encrypted)
+	vaultExportService := service.NewVaultExportService(
+		userRepo,
+		itemRepo,
+		orgUserRepo,
+		orgItemRepo,
+		orgFolderRepo,
+		collectionRepo,
+		organizationItemService,
+		policyEnforcementService,
+		serviceLogger,
+	)
+	vaultExportHandler := httpHandler.NewVaultExportHandler(vaultExportService, userActivityService)
+
+	// Bulk item operations (import, move, delete, restore, favorite)
+	itemBulkService := service.NewItemBulkService(
+		itemRepo,
+		orgItemRepo,
+		orgRepo,
+		orgUserRepo,
+		collectionRepo,
+		organizationItemService,
+		featureService,
+		serviceLogger,
+	)
+	itemBulkHandler := httpHandler.NewItemBulkHandler(itemBulkService, userActivityService, policyEnforcementService)
+
 	// Payment handlers
-	paymentHandler := httpHandler.NewPaymentHandler(paymentService, subscriptionService, orgRepo, orgUserRepo)
-	webhookHandler := httpHandler.NewWebhookHandler(paymentService, revenueCatService)
+	paymentHandler := httpHandler.NewPaymentHandler(paymentService, subscriptionService, seatService, orgRepo, orgUserRepo)
+	webhookEventRepo := gormrepo.NewWebhookEventRepository(a.db.DB())
+	webhookInboxService := service.NewWebhookInboxService(
+		webhookEventRepo,
+		map[domain.WebhookProvider]service.WebhookProcessor{
+			domain.WebhookProviderStripe:     paymentService,
+			domain.WebhookProviderRevenueCat: revenueCatService,
+		},
+		serviceLogger,
+	)
+	webhookHandler := httpHandler.NewWebhookHandler(webhookInboxService)
 
 	// Support handler
 	supportHandler := httpHandler.NewSupportHandler(emailSender, serviceLogger)
@@ -356,6 +479,7 @@ func (a *App) Run(ctx context.Context) error {
 		userActivityService,
 		serviceLogger,
 	)
+	adminPromotionsHandler := httpHandler.NewAdminPromotionsHandler(promotionService)
 	adminMailHandler := httpHandler.NewAdminMailHandler(emailSender, userRepo, serviceLogger)
 	adminLogsHandler := httpHandler.NewAdminLogsHandler()
 
@@ -380,9 +504,22 @@ func (a *App) Run(ctx context.Context) error {
 	// Breach monitor handler
 	breachMonitorHandler := httpHandler.NewBreachMonitorHandler(breachMonitorService)
 
+	// Vault health handler
+	vaultHealthHandler := httpHandler.NewVaultHealthHandler(vaultHealthService)
+
+	// Notification and rotation recommendation handlers
+	notificationHandler := httpHandler.NewNotificationHandler(notificationService)
+	rotationRecommendationHandler := httpHandler.NewRotationRecommendationHandler(rotationRecommendationService)
+
 	// Compromised password check handler (batch HIBP Pwned Passwords)
 	compromisedCheckHandler := httpHandler.NewCompromisedCheckHandler(pwnedPasswordsClient)
 
+	// Admin license handler
+	adminLicenseHandler := httpHandler.NewAdminLicenseHandler(licenseService, userActivityService)
+
+	// Admin webhook inbox handler
+	adminWebhooksHandler := httpHandler.NewAdminWebhooksHandler(webhookInboxService)
+
 	// Icons handler (public favicon service with protection)
 	iconsHandler := httpHandler.NewIconsHandler(serviceLogger)
 
@@ -392,12 +529,17 @@ func (a *App) Run(ctx context.Context) error {
 		authService,
 		policyFirewallService,
 		orgRepo,
+		teamRepo,
+		collectionRepo,
+		orgItemRepo,
 		authHandler,
 		twoFactorHandler,
 		activityHandler,
 		organizationActivityHandler,
 		itemHandler,
 		itemShareHandler,
+		itemBulkHandler,
+		vaultExportHandler,
 		excludedDomainHandler,
 		userHandler,
 		userNotificationPreferencesHandler,
@@ -405,10 +547,14 @@ func (a *App) Run(ctx context.Context) error {
 		userPreferencesHandler,
 		invitationHandler,
 		organizationHandler,
+		memberSuspensionHandler,
+		organizationRoleHandler,
+		organizationService,
 		organizationPolicyHandler,
 		organizationSettingsHandler,
 		teamHandler,
 		collectionHandler,
+		collectionAccessRequestHandler,
 		organizationItemHandler,
 		organizationFolderHandler,
 		emergencyAccessHandler,
@@ -418,14 +564,20 @@ func (a *App) Run(ctx context.Context) error {
 		supportHandler,
 		plansHandler,
 		adminSubscriptionsHandler,
+		adminPromotionsHandler,
 		adminMailHandler,
 		adminLogsHandler,
+		adminLicenseHandler,
+		adminWebhooksHandler,
 		iconsHandler,
 		ssoHandler,
 		scimHandler,
 		scimService,
 		keyEscrowHandler,
 		breachMonitorHandler,
+		vaultHealthHandler,
+		notificationHandler,
+		rotationRecommendationHandler,
 		compromisedCheckHandler,
 		compatTelemetryHandler,
 		aiTelemetryHandler,
@@ -451,7 +603,7 @@ func (a *App) Run(ctx context.Context) error {
 	a.logCleanup = cleanup.NewLogCleanup(adminLogsHandler.LogPaths(), 15*24*time.Hour)
 
 	// Initialize send cleanup service (runs every 6 hours)
-	a.sendCleanup = cleanup.NewSendCleanup(sendRepo, 6*time.Hour)
+	a.sendCleanup = cleanup.NewSendCleanup(sendRepo, sendBlobs, 6*time.Hour)
 
 	// Initialize breach monitor worker (runs at configured interval, default 24h)
 	breachCheckInterval := time.Duration(a.config.HIBP.CheckIntervalHours) * time.Hour
@@ -466,7 +618,30 @@ func (a *App) Run(ctx context.Context) error {
 	)
 
 	// Initialize subscription expiry worker (runs every 6 hours)
-	a.subscriptionWorker = cleanup.NewSubscriptionWorker(subscriptionService, serviceLogger, 6*time.Hour)
+	a.subscriptionWorker = cleanup.NewSubscriptionWorker(subscriptionService, dunningService, serviceLogger, 6*time.Hour)
+
+	// Initialize suspended member worker (runs daily)
+	a.suspendedMemberWorker = cleanup.NewSuspendedMemberWorker(memberSuspensionService, serviceLogger, 24*time.Hour)
+
+	// Initialize collection access worker (revokes expired temporary grants every 5 minutes)
+	a.collectionAccessWorker = cleanup.NewCollectionAccessWorker(collectionAccessRequestService, serviceLogger, 5*time.Minute)
+
+	// Initialize item share expiry worker (runs every 15 minutes)
+	shareExpiryNotice := time.Duration(a.config.Sharing.ExpiryNoticeHours) * time.Hour
+	a.itemShareExpiryWorker = cleanup.NewItemShareExpiryWorker(itemShareService, serviceLogger, 15*time.Minute, shareExpiryNotice)
+
+	// Initialize weekly security digest worker (checks for due organizations every hour)
+	securityDigestService := service.NewSecurityDigestService(
+		preferencesRepo, orgRepo, orgUserRepo, authService, breachMonitorRepo, userActivityRepo,
+		orgPolicyRepo, itemShareRepo, userNotificationPreferencesService, emailSender, emailBuilder, serviceLogger,
+	)
+	a.securityDigestWorker = cleanup.NewSecurityDigestWorker(securityDigestService, serviceLogger, 1*time.Hour)
+
+	// Initialize invitation cleanup (runs every hour)
+	a.invitationCleanup = cleanup.NewInvitationCleanup(organizationService, serviceLogger, 1*time.Hour)
+
+	// Initialize webhook retry worker (runs every minute)
+	a.webhookRetryWorker = cleanup.NewWebhookRetryWorker(webhookInboxService, serviceLogger, time.Minute)
 
 	// Start cleanup services in background (using application context)
 	go a.tokenCleanup.Start(ctx)
@@ -475,6 +650,15 @@ func (a *App) Run(ctx context.Context) error {
 	go a.sendCleanup.Start(ctx)
 	go a.breachMonitorWorker.Start(ctx)
 	go a.subscriptionWorker.Run(ctx)
+	go a.suspendedMemberWorker.Run(ctx)
+	go a.collectionAccessWorker.Run(ctx)
+	go a.itemShareExpiryWorker.Run(ctx)
+	go a.securityDigestWorker.Run(ctx)
+	go a.invitationCleanup.Run(ctx)
+	go a.webhookRetryWorker.Run(ctx)
+
+	// User schemas are migrated in the background; failed schemas resume on the next start
+	go a.migrateUserSchemas(ctx)
 
 	// Start server in a goroutine
 	serverErrChan := make(chan error, 1)
@@ -540,3 +724,23 @@ func (a *App) gracefulShutdown() error {
 	logger.Infof("Graceful shutdown completed")
 	return nil
 }
+
+// migrateUserSchemas applies pending migrations to every user schema and logs failures
+func (a *App) migrateUserSchemas(ctx context.Context) {
+	results, err := MigrateUserSchemas(ctx, a.db.DB(), a.migrator)
+	if err != nil {
+		logger.Errorf("migrate: %v", err)
+		return
+	}
+
+	applied := 0
+	for _, r := range results {
+		applied += r.Count
+	}
+	failed := FailedSchemas(results)
+	if len(failed) > 0 {
+		logger.Errorf("migrate: %d of %d user schema(s) failed; run `passwall-server migrate up -scope users` to retry", len(failed), len(results))
+		return
+	}
+	logger.Infof("migrate: %d user schema(s) up to date (%d migration(s) applied)", len(results), applied)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/pkg/database"
 	"github.com/passwall/passwall-server/pkg/database/postgres"
+	"github.com/passwall/passwall-server/pkg/database/sqlite"
 	"github.com/passwall/passwall-server/pkg/logger"
 )
 
@@ -14,6 +15,8 @@ import (
 func InitDatabase(cfg *config.Config) (database.Database, error) {
 	// Convert config to database.Config
 	dbCfg := &database.Config{
+		Driver:       cfg.Database.Driver,
+		Path:         cfg.Database.Path,
 		Host:         cfg.Database.Host,
 		Port:         cfg.Database.Port,
 		Username:     cfg.Database.Username,
@@ -25,8 +28,18 @@ func InitDatabase(cfg *config.Config) (database.Database, error) {
 		LogMode:      cfg.Database.LogMode,
 	}
 
+	dialect, err := database.ParseDialect(cfg.Database.Driver)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create database connection
-	db, err := postgres.New(dbCfg)
+	var db database.Database
+	if dialect.IsSQLite() {
+		db, err = sqlite.New(dbCfg)
+	} else {
+		db, err = postgres.New(dbCfg)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -36,7 +49,7 @@ func InitDatabase(cfg *config.Config) (database.Database, error) {
 
 // AutoMigrate runs database migrations
 // This creates all tables from scratch with their FINAL structure
-// For production updates of existing databases, use SQL migration files in /migrations/
+// Existing databases get their SQL migrations first (see MigrateDatabase)
 func AutoMigrate(db database.Database) error {
 	// Create Item table first (used by personal vault)
 	if err := db.AutoMigrate(&domain.Item{}); err != nil {
@@ -59,6 +72,7 @@ func AutoMigrate(db database.Database) error {
 	// User-related tables
 	if err := db.AutoMigrate(
 		&domain.ExcludedDomain{},
+		&domain.OrganizationExcludedDomain{},
 		&domain.Preference{},
 		&domain.Invitation{},
 		&domain.CompatTelemetryEvent{},
@@ -72,6 +86,9 @@ func AutoMigrate(db database.Database) error {
 		&domain.Plan{},
 		&domain.Subscription{},
 		&domain.WebhookEvent{},
+		&domain.PromoCode{},
+		&domain.PromoRedemption{},
+		&domain.TrialExtension{},
 		// Note: Invoices are fetched directly from Stripe (no DB table needed)
 	); err != nil {
 		return fmt.Errorf("failed to migrate subscription tables: %w", err)
@@ -80,14 +97,17 @@ func AutoMigrate(db database.Database) error {
 	// Organization tables
 	if err := db.AutoMigrate(
 		&domain.Organization{},
+		&domain.OrganizationCustomRole{},
 		&domain.OrganizationUser{},
 		&domain.Team{},
 		&domain.TeamUser{},
 		&domain.Collection{},
 		&domain.CollectionUser{},
 		&domain.CollectionTeam{},
+		&domain.CollectionAccessRequest{},
 		&domain.OrganizationFolder{},
 		&domain.OrganizationItem{},
+		&domain.OrganizationItemCollection{},
 		&domain.ItemShare{},
 	); err != nil {
 		return fmt.Errorf("failed to migrate organization tables: %w", err)
@@ -104,6 +124,8 @@ func AutoMigrate(db database.Database) error {
 	if err := db.AutoMigrate(
 		&domain.EmergencyAccess{},
 		&domain.Send{},
+		&domain.SendRecipient{},
+		&domain.SendAccessEvent{},
 	); err != nil {
 		return fmt.Errorf("failed to migrate emergency access / send tables: %w", err)
 	}
@@ -112,10 +134,25 @@ func AutoMigrate(db database.Database) error {
 	if err := db.AutoMigrate(
 		&domain.MonitoredEmail{},
 		&domain.BreachRecord{},
+		&domain.MonitoredDomain{},
+		&domain.RotationRecommendation{},
 	); err != nil {
 		return fmt.Errorf("failed to migrate breach monitoring tables: %w", err)
 	}
 
+	// In-app notifications
+	if err := db.AutoMigrate(&domain.Notification{}); err != nil {
+		return fmt.Errorf("failed to migrate notifications: %w", err)
+	}
+
+	// Vault health report tables
+	if err := db.AutoMigrate(
+		&domain.ItemHealthSignal{},
+		&domain.VaultHealthSnapshot{},
+	); err != nil {
+		return fmt.Errorf("failed to migrate vault health tables: %w", err)
+	}
+
 	// SSO & SCIM tables (Enterprise features)
 	if err := db.AutoMigrate(
 		&domain.SSOConnection{},
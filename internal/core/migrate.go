@@ -0,0 +1,108 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/passwall/passwall-server/internal/config"
+	"github.com/passwall/passwall-server/pkg/database"
+	"github.com/passwall/passwall-server/pkg/database/migrate"
+	"github.com/passwall/passwall-server/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// legacyMigrationVersion is the last public migration that was applied by hand
+// before the migration runner existed. Databases created before the runner have
+// no schema_migrations table and are stamped up to this version.
+const legacyMigrationVersion = 5
+
+// NewMigrator creates a migrator with the embedded public and user schema migrations
+func NewMigrator(db database.Database, cfg *config.Config) (*migrate.Migrator, error) {
+	public, err := migrate.PublicMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load public migrations: %w", err)
+	}
+	user, err := migrate.UserMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user migrations: %w", err)
+	}
+	return migrate.New(db.DB(), public, user, cfg.Database.MigrationConcurrency), nil
+}
+
+// MigrateDatabase brings the public schema up to date and returns how many SQL migrations ran.
+// A fresh database is created by AutoMigrate and stamped with every migration;
+// an existing database runs its pending SQL migrations first, then AutoMigrate.
+// SQLite has no versioned migrations (they are PostgreSQL SQL): AutoMigrate alone is used and m may be nil.
+func MigrateDatabase(ctx context.Context, db database.Database, m *migrate.Migrator) (int, error) {
+	if database.DialectOf(db.DB()).IsSQLite() {
+		return 0, AutoMigrate(db)
+	}
+
+	tracked, err := m.HasVersionTable(ctx, migrate.PublicSchema)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check migration state: %w", err)
+	}
+
+	if !tracked {
+		if !db.DB().Migrator().HasTable("users") {
+			// Fresh install: AutoMigrate creates the final structure
+			if err := AutoMigrate(db); err != nil {
+				return 0, err
+			}
+			return 0, m.Stamp(ctx, migrate.PublicSchema, math.MaxInt64)
+		}
+
+		logger.Infof("migrate: existing database without schema_migrations, stamping baseline %03d", legacyMigrationVersion)
+		if err := m.Stamp(ctx, migrate.PublicSchema, legacyMigrationVersion); err != nil {
+			return 0, err
+		}
+	}
+
+	count, err := m.Up(ctx, migrate.PublicSchema)
+	if err != nil {
+		return count, err
+	}
+
+	return count, AutoMigrate(db)
+}
+
+// MigrateUserSchemas applies pending migrations to every user schema and returns the per-schema results
+// On SQLite the vault tables are created at their final structure at signup, so there is nothing to do.
+func MigrateUserSchemas(ctx context.Context, db *gorm.DB, m *migrate.Migrator) ([]migrate.SchemaResult, error) {
+	if database.DialectOf(db).IsSQLite() {
+		return nil, nil
+	}
+	schemas, err := ListUserSchemas(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return m.UpSchemas(ctx, schemas), nil
+}
+
+// ListUserSchemas returns the vault schemas of all users that exist in the database
+func ListUserSchemas(ctx context.Context, db *gorm.DB) ([]string, error) {
+	var schemas []string
+	err := db.WithContext(ctx).Raw(`
+		SELECT DISTINCT u.schema
+		FROM users u
+		JOIN information_schema.schemata s ON s.schema_name = u.schema
+		WHERE u.schema <> '' AND u.schema <> ?
+		ORDER BY u.schema
+	`, migrate.PublicSchema).Scan(&schemas).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user schemas: %w", err)
+	}
+	return schemas, nil
+}
+
+// FailedSchemas returns the results that ended with an error
+func FailedSchemas(results []migrate.SchemaResult) []migrate.SchemaResult {
+	var failed []migrate.SchemaResult
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
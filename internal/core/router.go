@@ -18,12 +18,17 @@ func SetupRouter(
 	authService service.AuthService,
 	firewallService service.PolicyFirewallService,
 	orgRepo repository.OrganizationRepository,
+	teamRepo repository.TeamRepository,
+	collectionRepo repository.CollectionRepository,
+	orgItemRepo repository.OrganizationItemRepository,
 	authHandler *httpHandler.AuthHandler,
 	twoFactorHandler *httpHandler.TwoFactorHandler,
 	activityHandler *httpHandler.ActivityHandler,
 	organizationActivityHandler *httpHandler.OrganizationActivityHandler,
 	itemHandler *httpHandler.ItemHandler,
 	itemShareHandler *httpHandler.ItemShareHandler,
+	itemBulkHandler *httpHandler.ItemBulkHandler,
+	vaultExportHandler *httpHandler.VaultExportHandler,
 	excludedDomainHandler *httpHandler.ExcludedDomainHandler,
 	userHandler *httpHandler.UserHandler,
 	userNotificationPreferencesHandler *httpHandler.UserNotificationPreferencesHandler,
@@ -31,10 +36,14 @@ func SetupRouter(
 	userPreferencesHandler *httpHandler.UserPreferencesHandler,
 	invitationHandler *httpHandler.InvitationHandler,
 	organizationHandler *httpHandler.OrganizationHandler,
+	memberSuspensionHandler *httpHandler.MemberSuspensionHandler,
+	organizationRoleHandler *httpHandler.OrganizationRoleHandler,
+	organizationService service.OrganizationService,
 	organizationPolicyHandler *httpHandler.OrganizationPolicyHandler,
 	organizationSettingsHandler *httpHandler.OrganizationSettingsHandler,
 	teamHandler *httpHandler.TeamHandler,
 	collectionHandler *httpHandler.CollectionHandler,
+	collectionAccessRequestHandler *httpHandler.CollectionAccessRequestHandler,
 	organizationItemHandler *httpHandler.OrganizationItemHandler,
 	organizationFolderHandler *httpHandler.OrganizationFolderHandler,
 	emergencyAccessHandler *httpHandler.EmergencyAccessHandler,
@@ -44,14 +53,20 @@ func SetupRouter(
 	supportHandler *httpHandler.SupportHandler,
 	plansHandler *httpHandler.PlansHandler,
 	adminSubscriptionsHandler *httpHandler.AdminSubscriptionsHandler,
+	adminPromotionsHandler *httpHandler.AdminPromotionsHandler,
 	adminMailHandler *httpHandler.AdminMailHandler,
 	adminLogsHandler *httpHandler.AdminLogsHandler,
+	adminLicenseHandler *httpHandler.AdminLicenseHandler,
+	adminWebhooksHandler *httpHandler.AdminWebhooksHandler,
 	iconsHandler *httpHandler.IconsHandler,
 	ssoHandler *httpHandler.SSOHandler,
 	scimHandler *httpHandler.SCIMHandler,
 	scimService service.SCIMService,
 	keyEscrowHandler *httpHandler.KeyEscrowHandler,
 	breachMonitorHandler *httpHandler.BreachMonitorHandler,
+	vaultHealthHandler *httpHandler.VaultHealthHandler,
+	notificationHandler *httpHandler.NotificationHandler,
+	rotationRecommendationHandler *httpHandler.RotationRecommendationHandler,
 	compromisedCheckHandler *httpHandler.CompromisedCheckHandler,
 	compatTelemetryHandler *httpHandler.CompatTelemetryHandler,
 	aiTelemetryHandler *httpHandler.AITelemetryHandler,
@@ -136,6 +151,8 @@ func SetupRouter(
 	recoveryDeleteRequestLimiter := httpHandler.NewRateLimiter(300*time.Second, 2)
 	// Recovery delete confirm: 6 requests per 10 minutes per IP
 	recoveryDeleteConfirmLimiter := httpHandler.NewRateLimiter(100*time.Second, 6)
+	// Send recipient codes: 6 requests per 10 minutes per IP (each request may send an email)
+	sendRecipientCodeLimiter := httpHandler.NewRateLimiter(100*time.Second, 6)
 
 	// Create reCAPTCHA middleware (optional - only applies if token is sent)
 	recaptchaMiddleware := httpHandler.OptionalRecaptchaMiddleware(
@@ -198,6 +215,16 @@ func SetupRouter(
 	{
 		publicSendsGroup.GET("/:access_id", sendHandler.Access)
 		publicSendsGroup.POST("/:access_id/password", sendHandler.VerifyPassword)
+		publicSendsGroup.GET("/:access_id/file", sendHandler.DownloadFile)
+		publicSendsGroup.POST("/:access_id/file", sendHandler.DownloadFile)
+		publicSendsGroup.POST("/:access_id/recipient/code",
+			httpHandler.RateLimitMiddleware(sendRecipientCodeLimiter),
+			sendHandler.RequestRecipientCode,
+		)
+		publicSendsGroup.POST("/:access_id/recipient/verify",
+			httpHandler.RateLimitMiddleware(authRateLimiter),
+			sendHandler.VerifyRecipient,
+		)
 	}
 
 	// Compatibility telemetry ingest — requires authentication so only
@@ -238,14 +265,26 @@ func SetupRouter(
 		apiGroup.PUT("/items/:id", itemHandler.Update)
 		apiGroup.DELETE("/items/:id", itemHandler.Delete)
 
+		// Bulk item operations (one transaction, per-item results)
+		apiGroup.POST("/items/bulk", itemBulkHandler.Create)
+		apiGroup.POST("/items/bulk/move", itemBulkHandler.Move)
+		apiGroup.POST("/items/bulk/delete", itemBulkHandler.Delete)
+		apiGroup.POST("/items/bulk/restore", itemBulkHandler.Restore)
+		apiGroup.POST("/items/bulk/favorite", itemBulkHandler.Favorite)
+
+		// Encrypted vault export (honors disable_personal_export)
+		apiGroup.GET("/export", vaultExportHandler.Export)
+
 		// Personal item sharing (zero-knowledge)
 		apiGroup.POST("/item-shares", itemShareHandler.Create)
 		apiGroup.GET("/item-shares", itemShareHandler.ListOwned)
 		apiGroup.GET("/item-shares/received", itemShareHandler.ListReceived)
+		apiGroup.GET("/item-shares/pending", itemShareHandler.ListPending)
 		apiGroup.GET("/item-shares/:uuid", itemShareHandler.GetByUUID)
 		apiGroup.PUT("/item-shares/:uuid/item", itemShareHandler.UpdateSharedItem)
 		apiGroup.PATCH("/item-shares/:uuid/permissions", itemShareHandler.UpdatePermissions)
 		apiGroup.POST("/item-shares/:uuid/re-share", itemShareHandler.ReShare)
+		apiGroup.POST("/item-shares/:uuid/complete", itemShareHandler.CompletePending)
 		apiGroup.DELETE("/item-shares/:id", itemShareHandler.Revoke)
 
 		// Emergency Access
@@ -271,9 +310,19 @@ func SetupRouter(
 			sendsGroup.GET("/:uuid", sendHandler.GetByUUID)
 			sendsGroup.PUT("/:uuid", sendHandler.Update)
 			sendsGroup.DELETE("/:uuid", sendHandler.Delete)
+			sendsGroup.PUT("/:uuid/file", sendHandler.UploadFile)
 			sendsGroup.POST("/:uuid/notify", sendHandler.Notify)
 		}
 
+		// In-app notifications
+		apiGroup.GET("/notifications", notificationHandler.List)
+		apiGroup.PATCH("/notifications/:id/read", notificationHandler.MarkRead)
+		apiGroup.POST("/notifications/read-all", notificationHandler.MarkAllRead)
+
+		// Items flagged for password rotation after a breach of the user's email
+		apiGroup.GET("/rotation-recommendations", rotationRecommendationHandler.List)
+		apiGroup.POST("/rotation-recommendations/:id/resolve", rotationRecommendationHandler.Resolve)
+
 		// Excluded Domains API (for "Turn off Passwall for this site")
 		apiGroup.GET("/excluded-domains", excludedDomainHandler.List)
 		apiGroup.POST("/excluded-domains", excludedDomainHandler.Create)
@@ -320,6 +369,7 @@ func SetupRouter(
 		usersGroup.Use(httpHandler.RequireAdminMiddleware())
 		{
 			usersGroup.GET("", userHandler.List)
+			usersGroup.GET("/export", userHandler.Export)
 			usersGroup.GET("/:id", userHandler.GetByID)
 			usersGroup.POST("", userHandler.Create)
 			usersGroup.PUT("/:id", userHandler.Update)
@@ -360,6 +410,13 @@ func SetupRouter(
 			adminGroup.GET("/subscriptions", adminSubscriptionsHandler.List)
 			adminGroup.POST("/organizations/:id/subscription/grant", adminSubscriptionsHandler.GrantManual)
 			adminGroup.POST("/organizations/:id/subscription/revoke", adminSubscriptionsHandler.RevokeManual)
+			adminGroup.GET("/organizations/:id/trial-extensions", adminPromotionsHandler.ListTrialExtensions)
+			adminGroup.POST("/organizations/:id/trial-extensions", adminPromotionsHandler.GrantTrialExtension)
+
+			adminGroup.GET("/promo-codes", adminPromotionsHandler.ListPromoCodes)
+			adminGroup.POST("/promo-codes", adminPromotionsHandler.CreatePromoCode)
+			adminGroup.POST("/promo-codes/:id/deactivate", adminPromotionsHandler.DeactivatePromoCode)
+			adminGroup.GET("/promo-codes/:id/redemptions", adminPromotionsHandler.ListRedemptions)
 			// Mail (admin broadcast)
 			adminGroup.POST("/mail", adminMailHandler.CreateJob)
 			adminGroup.GET("/mail/:jobId", adminMailHandler.GetJob)
@@ -368,6 +425,15 @@ func SetupRouter(
 			adminGroup.GET("/logs/download", adminLogsHandler.Download)
 			adminGroup.GET("/logs/download-bundle", adminLogsHandler.DownloadBundle)
 			adminGroup.POST("/logs/clear", adminLogsHandler.Clear)
+
+			adminGroup.GET("/license", adminLicenseHandler.Status)
+			adminGroup.PUT("/license", adminLicenseHandler.Upload)
+
+			// Webhook inbox (Stripe & RevenueCat)
+			adminGroup.GET("/webhook-events", adminWebhooksHandler.List)
+			adminGroup.GET("/webhook-events/:id", adminWebhooksHandler.Get)
+			adminGroup.POST("/webhook-events/:id/replay", adminWebhooksHandler.Replay)
+
 			adminGroup.GET("/telemetry/compat", compatTelemetryHandler.ListAdmin)
 			adminGroup.GET("/telemetry/compat/summary", compatTelemetryHandler.ListSummaryAdmin)
 			adminGroup.POST("/telemetry/compat/cleanup", compatTelemetryHandler.CleanupAdmin)
@@ -391,10 +457,12 @@ func SetupRouter(
 
 		// Organizations CRUD
 		// OrgPublicIDResolverMiddleware resolves the short public_id in :id to a numeric org ID,
-		// then FirewallMiddleware checks IP-based access for that org.
+		// then FirewallMiddleware checks IP-based access for that org and
+		// SuspendedMemberMiddleware rejects suspended members.
 		orgsGroup := apiGroup.Group("/organizations")
 		orgsGroup.Use(httpHandler.OrgPublicIDResolverMiddleware(orgRepo))
 		orgsGroup.Use(httpHandler.FirewallMiddleware(firewallService))
+		orgsGroup.Use(httpHandler.SuspendedMemberMiddleware(organizationService))
 		{
 			orgsGroup.POST("", organizationHandler.Create)
 			orgsGroup.GET("", organizationHandler.List)
@@ -420,6 +488,22 @@ func SetupRouter(
 			orgsGroup.PUT("/:id/members/:userId", organizationHandler.UpdateMemberRole)
 			orgsGroup.DELETE("/:id/members/:userId", organizationHandler.RemoveMember)
 			orgsGroup.POST("/:id/members/:userId/confirm", organizationHandler.ConfirmProvisionedMember)
+			orgsGroup.POST("/:id/members/:userId/suspend", memberSuspensionHandler.Suspend)
+			orgsGroup.POST("/:id/members/:userId/restore", memberSuspensionHandler.Restore)
+
+			// Custom roles and permissions
+			orgsGroup.GET("/:id/permissions", organizationRoleHandler.GetMyPermissions)
+			orgsGroup.GET("/:id/roles/permissions", organizationRoleHandler.GetCatalog)
+			orgsGroup.GET("/:id/roles", organizationRoleHandler.List)
+			orgsGroup.POST("/:id/roles", organizationRoleHandler.Create)
+			orgsGroup.PUT("/:id/roles/:roleId", organizationRoleHandler.Update)
+			orgsGroup.DELETE("/:id/roles/:roleId", organizationRoleHandler.Delete)
+
+			// Pending invitations (identified by the invited member's organization user ID)
+			orgsGroup.GET("/:id/invitations", organizationHandler.ListInvitations)
+			orgsGroup.POST("/:id/invitations/bulk", organizationHandler.BulkInvite)
+			orgsGroup.POST("/:id/invitations/:userId/resend", organizationHandler.ResendInvitation)
+			orgsGroup.DELETE("/:id/invitations/:userId", organizationHandler.RevokeInvitation)
 
 			// Teams nested under organization
 			orgsGroup.POST("/:id/teams", teamHandler.Create)
@@ -429,6 +513,12 @@ func SetupRouter(
 			orgsGroup.POST("/:id/collections", collectionHandler.Create)
 			orgsGroup.GET("/:id/collections", collectionHandler.List)
 
+			// Temporary collection access requests
+			orgsGroup.GET("/:id/access-requests", collectionAccessRequestHandler.List)
+			orgsGroup.POST("/:id/access-requests/:requestId/approve", collectionAccessRequestHandler.Approve)
+			orgsGroup.POST("/:id/access-requests/:requestId/deny", collectionAccessRequestHandler.Deny)
+			orgsGroup.POST("/:id/access-requests/:requestId/cancel", collectionAccessRequestHandler.Cancel)
+
 			// Organization settings (preferences)
 			orgsGroup.GET("/:id/settings", organizationSettingsHandler.ListSettings)
 			orgsGroup.PUT("/:id/settings", organizationSettingsHandler.UpsertSettings)
@@ -439,6 +529,11 @@ func SetupRouter(
 			orgsGroup.GET("/:id/policies/:policyType", organizationPolicyHandler.GetPolicy)
 			orgsGroup.PUT("/:id/policies/:policyType", organizationPolicyHandler.UpdatePolicy)
 
+			// Organization-wide excluded domains (autofill blocklist)
+			orgsGroup.GET("/:id/excluded-domains", excludedDomainHandler.ListOrganization)
+			orgsGroup.POST("/:id/excluded-domains", excludedDomainHandler.CreateOrganization)
+			orgsGroup.DELETE("/:id/excluded-domains/:domainId", excludedDomainHandler.DeleteOrganization)
+
 			// 2FA compliance (org admin dashboard)
 			orgsGroup.GET("/:id/2fa-compliance", twoFactorHandler.Compliance)
 
@@ -470,11 +565,27 @@ func SetupRouter(
 				breachMonitorGroup.GET("/breaches", breachMonitorHandler.ListBreaches)
 				breachMonitorGroup.PATCH("/breaches/:breachId/dismiss", breachMonitorHandler.DismissBreach)
 				breachMonitorGroup.GET("/summary", breachMonitorHandler.GetSummary)
+				breachMonitorGroup.POST("/domains", breachMonitorHandler.AddDomain)
+				breachMonitorGroup.GET("/domains", breachMonitorHandler.ListDomains)
+				breachMonitorGroup.DELETE("/domains/:domainId", breachMonitorHandler.RemoveDomain)
+				breachMonitorGroup.POST("/enroll-members", breachMonitorHandler.EnrollMembers)
+				breachMonitorGroup.GET("/my-breaches", breachMonitorHandler.ListMyBreaches)
+				breachMonitorGroup.PATCH("/my-breaches/:breachId/dismiss", breachMonitorHandler.DismissMyBreach)
+			}
+
+			// Vault health reports (Security Insights)
+			vaultHealthGroup := orgsGroup.Group("/:id/vault-health")
+			{
+				vaultHealthGroup.POST("/signals", vaultHealthHandler.SubmitSignals)
+				vaultHealthGroup.GET("/report", vaultHealthHandler.GetReport)
+				vaultHealthGroup.GET("/collections/:collectionId/report", vaultHealthHandler.GetCollectionReport)
 			}
 
 			// Payment & Billing routes
 			orgsGroup.POST("/:id/checkout", paymentHandler.CreateCheckoutSession)
 			orgsGroup.GET("/:id/billing", paymentHandler.GetBillingInfo)
+			orgsGroup.POST("/:id/billing/promo-code/validate", paymentHandler.ValidatePromoCode)
+			orgsGroup.GET("/:id/subscription/seats", paymentHandler.GetSeatStatus)
 			orgsGroup.POST("/:id/subscription/seats/preview", paymentHandler.PreviewSeatChange)
 			orgsGroup.POST("/:id/subscription/seats", paymentHandler.UpdateSubscriptionSeats)
 			orgsGroup.POST("/:id/subscription/change/preview", paymentHandler.PreviewPlanChange)
@@ -487,8 +598,14 @@ func SetupRouter(
 		// Invitation acceptance (not nested)
 		apiGroup.POST("/org-invitations/:id/accept", organizationHandler.AcceptInvitation)
 
+		// Teams, collections and organization items are addressed by their own ID.
+		// OrgResourceMiddleware resolves their organization so that
+		// SuspendedMemberMiddleware rejects suspended members here too.
+
 		// Teams (direct access by ID)
 		teamsGroup := apiGroup.Group("/teams")
+		teamsGroup.Use(httpHandler.OrgResourceMiddleware(httpHandler.TeamOrgResolver(teamRepo)))
+		teamsGroup.Use(httpHandler.SuspendedMemberMiddleware(organizationService))
 		{
 			teamsGroup.GET("/:id", teamHandler.GetByID)
 			teamsGroup.PUT("/:id", teamHandler.Update)
@@ -503,10 +620,14 @@ func SetupRouter(
 
 		// Collections (direct access by ID)
 		collectionsGroup := apiGroup.Group("/collections")
+		collectionsGroup.Use(httpHandler.OrgResourceMiddleware(httpHandler.CollectionOrgResolver(collectionRepo)))
+		collectionsGroup.Use(httpHandler.SuspendedMemberMiddleware(organizationService))
 		{
 			collectionsGroup.GET("/:id", collectionHandler.GetByID)
 			collectionsGroup.PUT("/:id", collectionHandler.Update)
 			collectionsGroup.DELETE("/:id", collectionHandler.Delete)
+			collectionsGroup.POST("/:id/move", collectionHandler.Move)
+			collectionsGroup.POST("/:id/access-requests", collectionAccessRequestHandler.Create)
 
 			// User access management
 			collectionsGroup.PUT("/:id/users/:orgUserId", collectionHandler.GrantUserAccess)
@@ -524,6 +645,8 @@ func SetupRouter(
 
 		// Organization Items (direct access)
 		orgItemsGroup := apiGroup.Group("/org-items")
+		orgItemsGroup.Use(httpHandler.OrgResourceMiddleware(httpHandler.OrganizationItemOrgResolver(orgItemRepo)))
+		orgItemsGroup.Use(httpHandler.SuspendedMemberMiddleware(organizationService))
 		{
 			orgItemsGroup.GET("/:id", organizationItemHandler.GetByID)
 			orgItemsGroup.GET("/:id/autofill-secret", organizationItemHandler.AutofillSecret)
@@ -533,6 +656,13 @@ func SetupRouter(
 
 		// Create organization item (under organization)
 		orgsGroup.POST("/:id/items", organizationItemHandler.Create)
+
+		// Bulk organization item operations
+		orgsGroup.POST("/:id/items/bulk", itemBulkHandler.CreateOrg)
+		orgsGroup.POST("/:id/items/bulk/move", itemBulkHandler.MoveOrg)
+		orgsGroup.POST("/:id/items/bulk/delete", itemBulkHandler.DeleteOrg)
+		orgsGroup.POST("/:id/items/bulk/restore", itemBulkHandler.RestoreOrg)
+		orgsGroup.POST("/:id/items/bulk/favorite", itemBulkHandler.FavoriteOrg)
 	}
 
 	return router
@@ -9,7 +9,7 @@ import (
 // AccountDeletionToken stores a single-use token for auth-free recovery delete flow.
 type AccountDeletionToken struct {
 	ID        uint      `gorm:"primary_key" json:"id"`
-	UUID      uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();uniqueIndex;not null" json:"uuid"`
+	UUID      uuid.UUID `gorm:"type:uuid;uniqueIndex;not null" json:"uuid"`
 	UserID    uint      `gorm:"not null;index" json:"user_id"`
 	TokenHash string    `gorm:"type:varchar(64);not null" json:"-"`
 	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
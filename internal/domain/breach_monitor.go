@@ -7,11 +7,20 @@ import (
 	"time"
 )
 
+// Sources of a monitored email
+const (
+	MonitoredEmailSourceManual = "manual" // added by hand
+	MonitoredEmailSourceDomain = "domain" // found by a domain search
+	MonitoredEmailSourceMember = "member" // enrolled from the member list
+)
+
 // MonitoredEmail represents an email address being monitored for data breaches.
 type MonitoredEmail struct {
 	ID             uint       `gorm:"primaryKey" json:"id"`
 	OrganizationID uint       `gorm:"not null;index" json:"organization_id"`
 	Email          string     `gorm:"type:varchar(320);not null" json:"email"`
+	UserID         *uint      `gorm:"index" json:"user_id,omitempty"` // member owning the address, if any
+	Source         string     `gorm:"type:varchar(20);not null;default:'manual'" json:"source"`
 	LastCheckedAt  *time.Time `json:"last_checked_at"`
 	BreachCount    int        `gorm:"default:0" json:"breach_count"`
 	CreatedAt      time.Time  `json:"created_at"`
@@ -24,6 +33,22 @@ func (MonitoredEmail) TableName() string {
 	return "monitored_emails"
 }
 
+// MonitoredDomain is a verified organization domain searched as a whole.
+// Every breached address HIBP reports for it becomes a MonitoredEmail.
+type MonitoredDomain struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	OrganizationID uint       `gorm:"not null;uniqueIndex:idx_monitored_domain_org_domain" json:"organization_id"`
+	Domain         string     `gorm:"type:varchar(253);not null;uniqueIndex:idx_monitored_domain_org_domain" json:"domain"`
+	LastCheckedAt  *time.Time `json:"last_checked_at"`
+	BreachedEmails int        `gorm:"default:0" json:"breached_emails"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (MonitoredDomain) TableName() string {
+	return "monitored_domains"
+}
+
 // StringSliceJSON stores a JSON array of strings (e.g. data classes).
 type StringSliceJSON []string
 
@@ -32,9 +57,9 @@ func (s *StringSliceJSON) Scan(value interface{}) error {
 		*s = nil
 		return nil
 	}
-	bytes, ok := value.([]byte)
+	bytes, ok := jsonBytes(value)
 	if !ok {
-		return fmt.Errorf("StringSliceJSON.Scan: expected []byte, got %T", value)
+		return fmt.Errorf("StringSliceJSON.Scan: expected []byte or string, got %T", value)
 	}
 	return json.Unmarshal(bytes, s)
 }
@@ -75,6 +100,8 @@ func (BreachRecord) TableName() string {
 type MonitoredEmailDTO struct {
 	ID            uint              `json:"id"`
 	Email         string            `json:"email"`
+	UserID        *uint             `json:"user_id,omitempty"`
+	Source        string            `json:"source"`
 	LastCheckedAt *time.Time        `json:"last_checked_at"`
 	BreachCount   int               `json:"breach_count"`
 	CreatedAt     time.Time         `json:"created_at"`
@@ -98,6 +125,15 @@ type BreachRecordDTO struct {
 	DiscoveredAt time.Time       `json:"discovered_at"`
 }
 
+// MonitoredDomainDTO is the API response for a monitored domain.
+type MonitoredDomainDTO struct {
+	ID             uint       `json:"id"`
+	Domain         string     `json:"domain"`
+	LastCheckedAt  *time.Time `json:"last_checked_at"`
+	BreachedEmails int        `json:"breached_emails"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
 // BreachMonitorSummaryDTO is returned by the summary endpoint.
 type BreachMonitorSummaryDTO struct {
 	MonitoredEmails int        `json:"monitored_emails"`
@@ -112,6 +148,8 @@ func ToMonitoredEmailDTO(m *MonitoredEmail) *MonitoredEmailDTO {
 	dto := &MonitoredEmailDTO{
 		ID:            m.ID,
 		Email:         m.Email,
+		UserID:        m.UserID,
+		Source:        m.Source,
 		LastCheckedAt: m.LastCheckedAt,
 		BreachCount:   m.BreachCount,
 		CreatedAt:     m.CreatedAt,
@@ -122,6 +160,16 @@ func ToMonitoredEmailDTO(m *MonitoredEmail) *MonitoredEmailDTO {
 	return dto
 }
 
+func ToMonitoredDomainDTO(d *MonitoredDomain) *MonitoredDomainDTO {
+	return &MonitoredDomainDTO{
+		ID:             d.ID,
+		Domain:         d.Domain,
+		LastCheckedAt:  d.LastCheckedAt,
+		BreachedEmails: d.BreachedEmails,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
 func ToBreachRecordDTO(b *BreachRecord) *BreachRecordDTO {
 	return &BreachRecordDTO{
 		ID:           b.ID,
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +18,9 @@ type Collection struct {
 
 	OrganizationID uint `json:"organization_id" gorm:"not null;index;constraint:OnDelete:CASCADE"`
 
+	// Parent collection; access granted on an ancestor is inherited unless overridden
+	ParentID *uint `json:"parent_id,omitempty" gorm:"index"`
+
 	// Collection details
 	Name        string `json:"name" gorm:"type:varchar(255);not null"`
 	Description string `json:"description,omitempty" gorm:"type:text"`
@@ -38,9 +43,13 @@ type Collection struct {
 	Organization *Organization      `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
 	UserAccess   []CollectionUser   `json:"user_access,omitempty" gorm:"foreignKey:CollectionID"`
 	TeamAccess   []CollectionTeam   `json:"team_access,omitempty" gorm:"foreignKey:CollectionID"`
-	Items        []OrganizationItem `json:"items,omitempty" gorm:"foreignKey:CollectionID"`
+	Items        []OrganizationItem `json:"items,omitempty" gorm:"-"`    // Linked via organization_item_collections
+	Children     []*Collection      `json:"children,omitempty" gorm:"-"` // Filled when building a tree
 }
 
+// MaxCollectionDepth is the deepest nesting allowed, counting the root as level 1
+const MaxCollectionDepth = 8
+
 // TableName specifies the table name
 func (Collection) TableName() string {
 	return "collections"
@@ -61,6 +70,9 @@ type CollectionUser struct {
 	CanAdmin      bool `json:"can_admin" gorm:"default:false"`
 	HidePasswords bool `json:"hide_passwords" gorm:"default:false"` // Can view metadata but not passwords
 
+	// Temporary grants from an approved access request; nil means permanent
+	ExpiresAt *time.Time `json:"expires_at,omitempty" gorm:"index"`
+
 	// Associations
 	Collection       *Collection       `json:"collection,omitempty" gorm:"foreignKey:CollectionID"`
 	OrganizationUser *OrganizationUser `json:"organization_user,omitempty" gorm:"foreignKey:OrganizationUserID"`
@@ -106,6 +118,7 @@ type CollectionDTO struct {
 	IsPrivate      bool      `json:"is_private"`
 	ExternalID     *string   `json:"external_id,omitempty"`
 	IsDefault      bool      `json:"is_default"`
+	ParentID       *uint     `json:"parent_id,omitempty"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 
@@ -113,21 +126,24 @@ type CollectionDTO struct {
 	ItemCount *int `json:"item_count,omitempty"`
 	UserCount *int `json:"user_count,omitempty"`
 	TeamCount *int `json:"team_count,omitempty"`
+
+	Children []*CollectionDTO `json:"children,omitempty"`
 }
 
 // CollectionUserDTO for API responses
 type CollectionUserDTO struct {
-	ID                 uint      `json:"id"`
-	CollectionID       uint      `json:"collection_id"`
-	OrganizationUserID uint      `json:"organization_user_id"`
-	UserID             uint      `json:"user_id"`
-	UserEmail          string    `json:"user_email"`
-	UserName           string    `json:"user_name"`
-	CanRead            bool      `json:"can_read"`
-	CanWrite           bool      `json:"can_write"`
-	CanAdmin           bool      `json:"can_admin"`
-	HidePasswords      bool      `json:"hide_passwords"`
-	CreatedAt          time.Time `json:"created_at"`
+	ID                 uint       `json:"id"`
+	CollectionID       uint       `json:"collection_id"`
+	OrganizationUserID uint       `json:"organization_user_id"`
+	UserID             uint       `json:"user_id"`
+	UserEmail          string     `json:"user_email"`
+	UserName           string     `json:"user_name"`
+	CanRead            bool       `json:"can_read"`
+	CanWrite           bool       `json:"can_write"`
+	CanAdmin           bool       `json:"can_admin"`
+	HidePasswords      bool       `json:"hide_passwords"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
 }
 
 // CollectionTeamDTO for API responses
@@ -145,6 +161,7 @@ type CollectionTeamDTO struct {
 
 // CreateCollectionRequest for API requests
 type CreateCollectionRequest struct {
+	ParentID    *uint   `json:"parent_id,omitempty"`
 	Name        string  `json:"name" validate:"required,max=255"`
 	Description string  `json:"description,omitempty" validate:"max=1000"`
 	IsPrivate   bool    `json:"is_private"`
@@ -158,6 +175,11 @@ type UpdateCollectionRequest struct {
 	IsPrivate   *bool   `json:"is_private,omitempty"`
 }
 
+// MoveCollectionRequest moves a collection and its subtree under another parent
+type MoveCollectionRequest struct {
+	ParentID *uint `json:"parent_id"` // NULL to move to the top level
+}
+
 // GrantCollectionAccessRequest for granting access to users/teams
 type GrantCollectionAccessRequest struct {
 	CanRead       bool `json:"can_read"`
@@ -172,7 +194,7 @@ func ToCollectionDTO(c *Collection) *CollectionDTO {
 		return nil
 	}
 
-	return &CollectionDTO{
+	dto := &CollectionDTO{
 		ID:             c.ID,
 		UUID:           c.UUID,
 		OrganizationID: c.OrganizationID,
@@ -181,12 +203,66 @@ func ToCollectionDTO(c *Collection) *CollectionDTO {
 		IsPrivate:      c.IsPrivate,
 		ExternalID:     c.ExternalID,
 		IsDefault:      c.IsDefault,
+		ParentID:       c.ParentID,
 		CreatedAt:      c.CreatedAt,
 		UpdatedAt:      c.UpdatedAt,
 		ItemCount:      c.ItemCount,
 		UserCount:      c.UserCount,
 		TeamCount:      c.TeamCount,
 	}
+	for _, child := range c.Children {
+		dto.Children = append(dto.Children, ToCollectionDTO(child))
+	}
+	return dto
+}
+
+// BuildCollectionTree links collections to their children and returns the roots.
+// A collection whose parent is not in the list becomes a root, so a member who can
+// only see part of a tree gets it rooted at the highest collection they can see.
+// Siblings are sorted by name.
+func BuildCollectionTree(collections []*Collection) []*Collection {
+	byID := make(map[uint]*Collection, len(collections))
+	for _, c := range collections {
+		c.Children = nil
+		byID[c.ID] = c
+	}
+
+	roots := make([]*Collection, 0)
+	for _, c := range collections {
+		if c.ParentID != nil {
+			if parent, ok := byID[*c.ParentID]; ok && parent != c {
+				parent.Children = append(parent.Children, c)
+				continue
+			}
+		}
+		roots = append(roots, c)
+	}
+
+	var sortLevel func(level []*Collection)
+	sortLevel = func(level []*Collection) {
+		sort.SliceStable(level, func(i, j int) bool {
+			return strings.ToLower(level[i].Name) < strings.ToLower(level[j].Name)
+		})
+		for _, c := range level {
+			sortLevel(c.Children)
+		}
+	}
+	sortLevel(roots)
+	return roots
+}
+
+// FlattenCollectionTree lists a tree depth-first, parents before their children
+func FlattenCollectionTree(roots []*Collection) []*Collection {
+	flat := make([]*Collection, 0, len(roots))
+	var walk func(level []*Collection)
+	walk = func(level []*Collection) {
+		for _, c := range level {
+			flat = append(flat, c)
+			walk(c.Children)
+		}
+	}
+	walk(roots)
+	return flat
 }
 
 // ToCollectionUserDTO converts CollectionUser to DTO
@@ -203,6 +279,7 @@ func ToCollectionUserDTO(cu *CollectionUser) *CollectionUserDTO {
 		CanWrite:           cu.CanWrite,
 		CanAdmin:           cu.CanAdmin,
 		HidePasswords:      cu.HidePasswords,
+		ExpiresAt:          cu.ExpiresAt,
 		CreatedAt:          cu.CreatedAt,
 	}
 
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CollectionAccessRequestStatus is the lifecycle state of a just-in-time access request
+type CollectionAccessRequestStatus string
+
+const (
+	AccessRequestStatusPending   CollectionAccessRequestStatus = "pending"
+	AccessRequestStatusApproved  CollectionAccessRequestStatus = "approved"
+	AccessRequestStatusDenied    CollectionAccessRequestStatus = "denied"
+	AccessRequestStatusCancelled CollectionAccessRequestStatus = "cancelled"
+	AccessRequestStatusExpired   CollectionAccessRequestStatus = "expired"
+)
+
+// MaxAccessRequestHours caps how long temporary collection access can last (one week)
+const MaxAccessRequestHours = 168
+
+// CollectionAccessRequest is a member's request for temporary access to a collection.
+// Once approved, a CollectionUser grant with ExpiresAt is created and removed again
+// by the access request worker when it expires.
+type CollectionAccessRequest struct {
+	ID        uint      `gorm:"primary_key" json:"id"`
+	UUID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"uuid"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID     uint `json:"organization_id" gorm:"not null;index"`
+	CollectionID       uint `json:"collection_id" gorm:"not null;index"`
+	OrganizationUserID uint `json:"organization_user_id" gorm:"not null;index"` // Requester
+
+	Justification string                        `json:"justification" gorm:"type:text;not null"`
+	CanWrite      bool                          `json:"can_write" gorm:"not null;default:false"`
+	DurationHours int                           `json:"duration_hours" gorm:"not null"` // Requested, replaced by the approved duration
+	Status        CollectionAccessRequestStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+
+	// Review
+	ReviewedByOrgUserID *uint      `json:"reviewed_by_org_user_id,omitempty"`
+	ReviewedAt          *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote          string     `json:"review_note,omitempty" gorm:"type:text"`
+
+	// Set on approval; the grant is revoked once it passes
+	ExpiresAt *time.Time `json:"expires_at,omitempty" gorm:"index"`
+
+	// Associations
+	Collection       *Collection       `json:"collection,omitempty" gorm:"foreignKey:CollectionID"`
+	OrganizationUser *OrganizationUser `json:"organization_user,omitempty" gorm:"foreignKey:OrganizationUserID"`
+	ReviewedBy       *OrganizationUser `json:"reviewed_by,omitempty" gorm:"foreignKey:ReviewedByOrgUserID"`
+}
+
+// TableName specifies the table name
+func (CollectionAccessRequest) TableName() string {
+	return "collection_access_requests"
+}
+
+// IsPending reports whether the request still awaits a decision
+func (r *CollectionAccessRequest) IsPending() bool {
+	return r.Status == AccessRequestStatusPending
+}
+
+// CreateCollectionAccessRequest is the payload for requesting temporary access
+type CreateCollectionAccessRequest struct {
+	Justification string `json:"justification" binding:"required,max=1000"`
+	DurationHours int    `json:"duration_hours" binding:"required,min=1"`
+	CanWrite      bool   `json:"can_write"`
+}
+
+// ReviewCollectionAccessRequest is the payload for approving or denying a request.
+// DurationHours lets the approver shorten or extend the requested duration.
+type ReviewCollectionAccessRequest struct {
+	DurationHours *int   `json:"duration_hours,omitempty"`
+	Note          string `json:"note,omitempty" binding:"max=1000"`
+}
+
+// CollectionAccessRequestDTO for API responses
+type CollectionAccessRequestDTO struct {
+	ID                  uint                          `json:"id"`
+	UUID                uuid.UUID                     `json:"uuid"`
+	OrganizationID      uint                          `json:"organization_id"`
+	CollectionID        uint                          `json:"collection_id"`
+	CollectionName      string                        `json:"collection_name,omitempty"`
+	OrganizationUserID  uint                          `json:"organization_user_id"`
+	RequesterEmail      string                        `json:"requester_email,omitempty"`
+	RequesterName       string                        `json:"requester_name,omitempty"`
+	Justification       string                        `json:"justification"`
+	CanWrite            bool                          `json:"can_write"`
+	DurationHours       int                           `json:"duration_hours"`
+	Status              CollectionAccessRequestStatus `json:"status"`
+	ReviewedByOrgUserID *uint                         `json:"reviewed_by_org_user_id,omitempty"`
+	ReviewerEmail       string                        `json:"reviewer_email,omitempty"`
+	ReviewedAt          *time.Time                    `json:"reviewed_at,omitempty"`
+	ReviewNote          string                        `json:"review_note,omitempty"`
+	ExpiresAt           *time.Time                    `json:"expires_at,omitempty"`
+	CreatedAt           time.Time                     `json:"created_at"`
+}
+
+// ToCollectionAccessRequestDTO converts CollectionAccessRequest to DTO
+func ToCollectionAccessRequestDTO(r *CollectionAccessRequest) *CollectionAccessRequestDTO {
+	if r == nil {
+		return nil
+	}
+
+	dto := &CollectionAccessRequestDTO{
+		ID:                  r.ID,
+		UUID:                r.UUID,
+		OrganizationID:      r.OrganizationID,
+		CollectionID:        r.CollectionID,
+		OrganizationUserID:  r.OrganizationUserID,
+		Justification:       r.Justification,
+		CanWrite:            r.CanWrite,
+		DurationHours:       r.DurationHours,
+		Status:              r.Status,
+		ReviewedByOrgUserID: r.ReviewedByOrgUserID,
+		ReviewedAt:          r.ReviewedAt,
+		ReviewNote:          r.ReviewNote,
+		ExpiresAt:           r.ExpiresAt,
+		CreatedAt:           r.CreatedAt,
+	}
+
+	if r.Collection != nil {
+		dto.CollectionName = r.Collection.Name
+	}
+	if r.OrganizationUser != nil && r.OrganizationUser.User != nil {
+		dto.RequesterEmail = r.OrganizationUser.User.Email
+		dto.RequesterName = r.OrganizationUser.User.Name
+	}
+	if r.ReviewedBy != nil && r.ReviewedBy.User != nil {
+		dto.ReviewerEmail = r.ReviewedBy.User.Email
+	}
+
+	return dto
+}
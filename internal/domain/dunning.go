@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// Dunning defaults, used when the configured schedule is empty or invalid
+const (
+	DefaultDunningGraceDays       = 14
+	DefaultDunningExpireAfterDays = 30
+)
+
+// DefaultDunningReminderDays are the days (since the first failed payment)
+// on which billing contacts are reminded to update their payment method
+var DefaultDunningReminderDays = []int{1, 3, 7, 12}
+
+// DunningStage represents where a past_due subscription is in the dunning schedule
+type DunningStage string
+
+const (
+	DunningStageNone     DunningStage = ""          // Not past_due
+	DunningStageReminder DunningStage = "reminder"  // Within grace period, reminders are being sent
+	DunningStageReadOnly DunningStage = "read_only" // Grace period over, vault is read-only
+	DunningStageExpired  DunningStage = "expired"   // Final expiry reached
+)
+
+// DunningSchedule describes the follow-up for past_due subscriptions.
+// All values are days since the first failed payment.
+type DunningSchedule struct {
+	ReminderDays    []int
+	GraceDays       int
+	ExpireAfterDays int
+}
+
+// NewDunningSchedule builds a normalized schedule: reminders are sorted,
+// de-duplicated and limited to the grace period; expiry never precedes the
+// read-only downgrade.
+func NewDunningSchedule(reminderDays []int, graceDays, expireAfterDays int) DunningSchedule {
+	if graceDays <= 0 {
+		graceDays = DefaultDunningGraceDays
+	}
+	if expireAfterDays <= 0 {
+		expireAfterDays = DefaultDunningExpireAfterDays
+	}
+	if expireAfterDays < graceDays {
+		expireAfterDays = graceDays
+	}
+	if len(reminderDays) == 0 {
+		reminderDays = DefaultDunningReminderDays
+	}
+
+	days := make([]int, 0, len(reminderDays))
+	seen := make(map[int]bool, len(reminderDays))
+	for _, d := range reminderDays {
+		if d < 0 || d >= graceDays || seen[d] {
+			continue
+		}
+		seen[d] = true
+		days = append(days, d)
+	}
+	sort.Ints(days)
+
+	return DunningSchedule{
+		ReminderDays:    days,
+		GraceDays:       graceDays,
+		ExpireAfterDays: expireAfterDays,
+	}
+}
+
+// PastDueSince returns when the subscription became past_due.
+// Subscriptions that went past_due before dunning was tracked are derived from their grace period.
+func (d DunningSchedule) PastDueSince(sub *Subscription) time.Time {
+	if sub.PastDueSince != nil {
+		return *sub.PastDueSince
+	}
+	if sub.GracePeriodEndsAt != nil {
+		return sub.GracePeriodEndsAt.AddDate(0, 0, -d.GraceDays)
+	}
+	return sub.UpdatedAt
+}
+
+// ReadOnlyAt returns when the vault is downgraded to read-only.
+// An explicit grace period (e.g. set by the app store) takes precedence.
+func (d DunningSchedule) ReadOnlyAt(sub *Subscription) time.Time {
+	if sub.GracePeriodEndsAt != nil {
+		return *sub.GracePeriodEndsAt
+	}
+	return d.PastDueSince(sub).AddDate(0, 0, d.GraceDays)
+}
+
+// ExpiresAt returns when the subscription is finally expired
+func (d DunningSchedule) ExpiresAt(sub *Subscription) time.Time {
+	expiresAt := d.PastDueSince(sub).AddDate(0, 0, d.ExpireAfterDays)
+	if readOnlyAt := d.ReadOnlyAt(sub); expiresAt.Before(readOnlyAt) {
+		return readOnlyAt
+	}
+	return expiresAt
+}
+
+// RemindersDue returns how many reminders should have been sent by now
+func (d DunningSchedule) RemindersDue(sub *Subscription, now time.Time) int {
+	since := d.PastDueSince(sub)
+	due := 0
+	for _, day := range d.ReminderDays {
+		if now.Before(since.AddDate(0, 0, day)) {
+			break
+		}
+		due++
+	}
+	return due
+}
+
+// NextReminderAt returns when the next reminder is scheduled, or nil if none is left
+func (d DunningSchedule) NextReminderAt(sub *Subscription) *time.Time {
+	if sub.DunningRemindersSent >= len(d.ReminderDays) {
+		return nil
+	}
+	t := d.PastDueSince(sub).AddDate(0, 0, d.ReminderDays[sub.DunningRemindersSent])
+	if !t.Before(d.ReadOnlyAt(sub)) {
+		return nil
+	}
+	return &t
+}
+
+// StageAt returns the dunning stage the subscription should be in at the given time
+func (d DunningSchedule) StageAt(sub *Subscription, now time.Time) DunningStage {
+	if sub == nil || sub.State != SubStatePastDue {
+		return DunningStageNone
+	}
+	if !now.Before(d.ExpiresAt(sub)) {
+		return DunningStageExpired
+	}
+	if !now.Before(d.ReadOnlyAt(sub)) {
+		return DunningStageReadOnly
+	}
+	return DunningStageReminder
+}
+
+// ── DTOs ────────────────────────────────────────────────────
+
+// DunningStatusDTO shows org admins where a past_due subscription is in the dunning schedule
+type DunningStatusDTO struct {
+	Stage          DunningStage `json:"stage"`
+	PastDueSince   time.Time    `json:"past_due_since"`
+	RemindersSent  int          `json:"reminders_sent"`
+	NextReminderAt *time.Time   `json:"next_reminder_at,omitempty"`
+	ReadOnly       bool         `json:"read_only"`
+	ReadOnlyAt     time.Time    `json:"read_only_at"`
+	ExpiresAt      time.Time    `json:"expires_at"`
+}
+
+// ToDunningStatusDTO returns the dunning status of a subscription, or nil if it is not past_due
+func ToDunningStatusDTO(sub *Subscription, schedule DunningSchedule, now time.Time) *DunningStatusDTO {
+	stage := schedule.StageAt(sub, now)
+	if stage == DunningStageNone {
+		return nil
+	}
+	return &DunningStatusDTO{
+		Stage:          stage,
+		PastDueSince:   schedule.PastDueSince(sub),
+		RemindersSent:  sub.DunningRemindersSent,
+		NextReminderAt: schedule.NextReminderAt(sub),
+		ReadOnly:       sub.IsReadOnly(),
+		ReadOnlyAt:     schedule.ReadOnlyAt(sub),
+		ExpiresAt:      schedule.ExpiresAt(sub),
+	}
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewDunningSchedule(t *testing.T) {
+	d := NewDunningSchedule([]int{7, 1, 7, 20, -1}, 14, 10)
+
+	if len(d.ReminderDays) != 2 || d.ReminderDays[0] != 1 || d.ReminderDays[1] != 7 {
+		t.Errorf("ReminderDays = %v, want [1 7]", d.ReminderDays)
+	}
+	if d.ExpireAfterDays != 14 {
+		t.Errorf("ExpireAfterDays = %d, want expiry clamped to grace period (14)", d.ExpireAfterDays)
+	}
+
+	d = NewDunningSchedule(nil, 0, 0)
+	if d.GraceDays != DefaultDunningGraceDays || d.ExpireAfterDays != DefaultDunningExpireAfterDays || len(d.ReminderDays) == 0 {
+		t.Errorf("expected defaults, got %+v", d)
+	}
+}
+
+func TestDunningSchedule_StageAt(t *testing.T) {
+	d := NewDunningSchedule([]int{1, 3, 7}, 14, 30)
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sub := &Subscription{State: SubStatePastDue, PastDueSince: &since}
+
+	tests := []struct {
+		name      string
+		days      int
+		stage     DunningStage
+		reminders int
+	}{
+		{"just failed", 0, DunningStageReminder, 0},
+		{"first reminder", 1, DunningStageReminder, 1},
+		{"after third reminder", 8, DunningStageReminder, 3},
+		{"grace ended", 14, DunningStageReadOnly, 3},
+		{"final expiry", 30, DunningStageExpired, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := since.AddDate(0, 0, tt.days)
+			if got := d.StageAt(sub, now); got != tt.stage {
+				t.Errorf("StageAt() = %q, want %q", got, tt.stage)
+			}
+			if got := d.RemindersDue(sub, now); got != tt.reminders {
+				t.Errorf("RemindersDue() = %d, want %d", got, tt.reminders)
+			}
+		})
+	}
+
+	active := &Subscription{State: SubStateActive}
+	if got := d.StageAt(active, since); got != DunningStageNone {
+		t.Errorf("StageAt(active) = %q, want none", got)
+	}
+}
+
+func TestDunningSchedule_ExplicitGracePeriod(t *testing.T) {
+	d := NewDunningSchedule([]int{1}, 14, 30)
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	grace := since.AddDate(0, 0, 3)
+	sub := &Subscription{State: SubStatePastDue, PastDueSince: &since, GracePeriodEndsAt: &grace}
+
+	if got := d.ReadOnlyAt(sub); !got.Equal(grace) {
+		t.Errorf("ReadOnlyAt() = %v, want %v", got, grace)
+	}
+	if got := d.ExpiresAt(sub); !got.Equal(since.AddDate(0, 0, 30)) {
+		t.Errorf("ExpiresAt() = %v, want 30 days after past_due", got)
+	}
+}
+
+func TestSubscription_CanWriteReadOnly(t *testing.T) {
+	now := time.Now()
+	sub := &Subscription{State: SubStatePastDue}
+	if !sub.CanWrite() {
+		t.Errorf("past_due subscription within grace period should allow writes")
+	}
+
+	sub.ReadOnlySince = &now
+	if sub.CanWrite() {
+		t.Errorf("read-only past_due subscription should not allow writes")
+	}
+
+	sub.ClearDunning()
+	sub.State = SubStateActive
+	if !sub.CanWrite() || sub.ReadOnlySince != nil {
+		t.Errorf("ClearDunning() should restore write access")
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -57,3 +58,93 @@ func ToExcludedDomainDTOs(eds []*ExcludedDomain) []*ExcludedDomainDTO {
 type CreateExcludedDomainRequest struct {
 	Domain string `json:"domain" validate:"required"`
 }
+
+// OrganizationExcludedDomain is an admin-managed autofill blocklist entry that applies to every
+// member of the organization. Domain is either a hostname ("admin.example.com") or a wildcard
+// pattern ("*.corp.example.com") that matches any subdomain but not the apex itself.
+type OrganizationExcludedDomain struct {
+	ID        uint      `gorm:"primary_key" json:"id"`
+	UUID      uuid.UUID `gorm:"type:uuid;type:varchar(100);" json:"uuid"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID    uint   `json:"organization_id" gorm:"not null;uniqueIndex:idx_org_excluded_domains_org_domain"`
+	Domain            string `json:"domain" gorm:"type:varchar(255);not null;uniqueIndex:idx_org_excluded_domains_org_domain"`
+	IncludeSubdomains bool   `json:"include_subdomains" gorm:"not null;default:false"`
+	Reason            string `json:"reason" gorm:"type:varchar(255)"`
+	CreatedByUserID   *uint  `json:"created_by_user_id,omitempty"`
+}
+
+func (OrganizationExcludedDomain) TableName() string {
+	return "organization_excluded_domains"
+}
+
+// Matches reports whether the normalized hostname is covered by this entry
+func (ed *OrganizationExcludedDomain) Matches(host string) bool {
+	if base, ok := strings.CutPrefix(ed.Domain, "*."); ok {
+		return strings.HasSuffix(host, "."+base)
+	}
+	if host == ed.Domain {
+		return true
+	}
+	return ed.IncludeSubdomains && strings.HasSuffix(host, "."+ed.Domain)
+}
+
+// OrganizationExcludedDomainDTO for API responses
+type OrganizationExcludedDomainDTO struct {
+	ID                uint      `json:"id"`
+	UUID              uuid.UUID `json:"uuid"`
+	OrganizationID    uint      `json:"organization_id"`
+	Domain            string    `json:"domain"`
+	IncludeSubdomains bool      `json:"include_subdomains"`
+	Reason            string    `json:"reason,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func ToOrganizationExcludedDomainDTO(ed *OrganizationExcludedDomain) *OrganizationExcludedDomainDTO {
+	if ed == nil {
+		return nil
+	}
+
+	return &OrganizationExcludedDomainDTO{
+		ID:                ed.ID,
+		UUID:              ed.UUID,
+		OrganizationID:    ed.OrganizationID,
+		Domain:            ed.Domain,
+		IncludeSubdomains: ed.IncludeSubdomains,
+		Reason:            ed.Reason,
+		CreatedAt:         ed.CreatedAt,
+	}
+}
+
+func ToOrganizationExcludedDomainDTOs(eds []*OrganizationExcludedDomain) []*OrganizationExcludedDomainDTO {
+	dtos := make([]*OrganizationExcludedDomainDTO, len(eds))
+	for i, ed := range eds {
+		dtos[i] = ToOrganizationExcludedDomainDTO(ed)
+	}
+	return dtos
+}
+
+// CreateOrganizationExcludedDomainRequest for API requests
+type CreateOrganizationExcludedDomainRequest struct {
+	Domain            string `json:"domain" validate:"required"`
+	IncludeSubdomains bool   `json:"include_subdomains"`
+	Reason            string `json:"reason" validate:"max=255"`
+}
+
+// ExcludedDomainSource tells whether a match came from the user's own list or an organization
+type ExcludedDomainSource string
+
+const (
+	ExcludedDomainSourceUser         ExcludedDomainSource = "user"
+	ExcludedDomainSourceOrganization ExcludedDomainSource = "organization"
+)
+
+// ExcludedDomainCheck is the merged result of the personal and organization blocklists
+type ExcludedDomainCheck struct {
+	IsExcluded     bool                 `json:"is_excluded"`
+	Source         ExcludedDomainSource `json:"source,omitempty"`
+	MatchedDomain  string               `json:"matched_domain,omitempty"`
+	OrganizationID uint                 `json:"organization_id,omitempty"`
+	Reason         string               `json:"reason,omitempty"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func TestOrganizationExcludedDomain_Matches(t *testing.T) {
+	tests := []struct {
+		name              string
+		pattern           string
+		includeSubdomains bool
+		host              string
+		want              bool
+	}{
+		{"exact", "admin.example.com", false, "admin.example.com", true},
+		{"exact ignores subdomain", "example.com", false, "admin.example.com", false},
+		{"include subdomains apex", "example.com", true, "example.com", true},
+		{"include subdomains child", "example.com", true, "a.b.example.com", true},
+		{"suffix is not a subdomain", "example.com", true, "badexample.com", false},
+		{"wildcard child", "*.corp.example.com", false, "vpn.corp.example.com", true},
+		{"wildcard deep child", "*.corp.example.com", false, "a.vpn.corp.example.com", true},
+		{"wildcard skips apex", "*.corp.example.com", false, "corp.example.com", false},
+		{"wildcard other domain", "*.corp.example.com", false, "corp.example.org", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ed := &OrganizationExcludedDomain{Domain: tt.pattern, IncludeSubdomains: tt.includeSubdomains}
+			if got := ed.Matches(tt.host); got != tt.want {
+				t.Errorf("Matches(%q) with %q = %v, want %v", tt.host, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,21 @@
 package domain
 
 import (
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// DefaultInvitationExpiryDays is used when an organization has no invitation_expiry_days setting
+const DefaultInvitationExpiryDays = 7
+
+// MaxBulkInviteRows limits the number of rows in one bulk invite request
+const MaxBulkInviteRows = 500
+
 // Invitation represents a user invitation
 type Invitation struct {
 	ID        uint      `gorm:"primary_key" json:"id"`
@@ -68,3 +78,114 @@ func (r *CreateInvitationRequest) Validate() error {
 	}
 	return nil
 }
+
+// OrganizationInvitationDTO describes a pending organization invitation
+type OrganizationInvitationDTO struct {
+	OrganizationUserID uint             `json:"organization_user_id"`
+	Email              string           `json:"email"`
+	Role               OrganizationRole `json:"role"`
+	AccessAll          bool             `json:"access_all"`
+	InvitedAt          *time.Time       `json:"invited_at,omitempty"`
+	ExpiresAt          *time.Time       `json:"expires_at,omitempty"`
+	IsExpired          bool             `json:"is_expired"`
+}
+
+// BulkInviteRow is one invitee of a bulk invite
+type BulkInviteRow struct {
+	Email           string           `json:"email"`
+	Role            OrganizationRole `json:"role"`
+	EncryptedOrgKey string           `json:"encrypted_org_key"`
+	AccessAll       bool             `json:"access_all"`
+	// Teams are team IDs or names; the default team is always assigned
+	Teams []string `json:"teams,omitempty"`
+}
+
+// BulkInviteRequest for API requests
+type BulkInviteRequest struct {
+	Invitations []BulkInviteRow `json:"invitations" binding:"required"`
+}
+
+// Bulk invite row statuses
+const (
+	BulkInviteStatusInvited = "invited"
+	BulkInviteStatusFailed  = "failed"
+	BulkInviteStatusSkipped = "skipped"
+)
+
+// BulkInviteResult reports the outcome of one row (1-based)
+type BulkInviteResult struct {
+	Row                int    `json:"row"`
+	Email              string `json:"email"`
+	Status             string `json:"status"`
+	OrganizationUserID uint   `json:"organization_user_id,omitempty"`
+	Error              string `json:"error,omitempty"`
+}
+
+// BulkInviteResponse for API responses
+type BulkInviteResponse struct {
+	Results []BulkInviteResult `json:"results"`
+	Invited int                `json:"invited"`
+	Failed  int                `json:"failed"`
+	Skipped int                `json:"skipped"`
+}
+
+// ParseBulkInviteCSV reads bulk invite rows from CSV. The header row names the
+// columns: email (required), role, encrypted_org_key, access_all and teams,
+// where teams are separated by ";".
+func ParseBulkInviteCSV(r io.Reader) ([]BulkInviteRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv is empty")
+		}
+		return nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, errors.New("csv must have an email column")
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []BulkInviteRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+		if len(rows) >= MaxBulkInviteRows {
+			return nil, fmt.Errorf("at most %d invitations can be sent at once", MaxBulkInviteRows)
+		}
+
+		row := BulkInviteRow{
+			Email:           field(record, "email"),
+			Role:            OrganizationRole(strings.ToLower(field(record, "role"))),
+			EncryptedOrgKey: field(record, "encrypted_org_key"),
+		}
+		if v := field(record, "access_all"); v != "" {
+			row.AccessAll, _ = strconv.ParseBool(v)
+		}
+		for _, team := range strings.Split(field(record, "teams"), ";") {
+			if team = strings.TrimSpace(team); team != "" {
+				row.Teams = append(row.Teams, team)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
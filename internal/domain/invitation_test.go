@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBulkInviteCSV(t *testing.T) {
+	input := "\ufeffEmail,Role,encrypted_org_key,access_all,teams\n" +
+		"alice@example.com,Admin,4.key-a,true,Engineering; 12\n" +
+		"bob@example.com,,4.key-b,,\n" +
+		"\n" +
+		"carol@example.com\n"
+
+	rows, err := ParseBulkInviteCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+
+	alice := rows[0]
+	if alice.Email != "alice@example.com" || alice.Role != OrgRoleAdmin || alice.EncryptedOrgKey != "4.key-a" || !alice.AccessAll {
+		t.Errorf("unexpected first row: %+v", alice)
+	}
+	if len(alice.Teams) != 2 || alice.Teams[0] != "Engineering" || alice.Teams[1] != "12" {
+		t.Errorf("unexpected teams: %v", alice.Teams)
+	}
+	if rows[1].Role != "" || rows[1].AccessAll || len(rows[1].Teams) != 0 {
+		t.Errorf("unexpected second row: %+v", rows[1])
+	}
+	if rows[2].Email != "carol@example.com" || rows[2].EncryptedOrgKey != "" {
+		t.Errorf("short rows should leave missing columns empty: %+v", rows[2])
+	}
+}
+
+func TestParseBulkInviteCSV_Errors(t *testing.T) {
+	if _, err := ParseBulkInviteCSV(strings.NewReader("")); err == nil {
+		t.Error("expected error for empty csv")
+	}
+	if _, err := ParseBulkInviteCSV(strings.NewReader("name,role\nalice,member\n")); err == nil {
+		t.Error("expected error without email column")
+	}
+
+	var b strings.Builder
+	b.WriteString("email\n")
+	for i := 0; i <= MaxBulkInviteRows; i++ {
+		b.WriteString("user@example.com\n")
+	}
+	if _, err := ParseBulkInviteCSV(strings.NewReader(b.String())); err == nil {
+		t.Error("expected error above the row limit")
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -157,18 +158,20 @@ func (m *ItemMetadata) Scan(value interface{}) error {
 		return nil
 	}
 
-	bytes, ok := value.([]byte)
+	bytes, ok := jsonBytes(value)
 	if !ok {
-		return fmt.Errorf("failed to scan ItemMetadata: expected []byte, got %T", value)
+		return fmt.Errorf("failed to scan ItemMetadata: expected []byte or string, got %T", value)
 	}
 
 	return json.Unmarshal(bytes, m)
 }
 
-// Value implements driver.Valuer for ItemMetadata (JSONB)
-func (m ItemMetadata) Value() (interface{}, error) {
-	if m.Name == "" {
-		return "{}", nil
+// Value implements driver.Valuer for ItemMetadata (JSONB).
+// The JSON is returned as a string so SQLite stores it as TEXT for json_extract.
+func (m ItemMetadata) Value() (driver.Value, error) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
 	}
-	return json.Marshal(m)
+	return string(b), nil
 }
@@ -0,0 +1,67 @@
+package domain
+
+import "github.com/google/uuid"
+
+// MaxBulkItems bounds how many items a single bulk request may create or change
+const MaxBulkItems = 5000
+
+// BulkItemAction is a bulk operation on items
+type BulkItemAction string
+
+const (
+	BulkItemActionCreate   BulkItemAction = "create"
+	BulkItemActionMove     BulkItemAction = "move"
+	BulkItemActionDelete   BulkItemAction = "delete"
+	BulkItemActionRestore  BulkItemAction = "restore"
+	BulkItemActionFavorite BulkItemAction = "favorite"
+)
+
+// BulkItemResult is the outcome of a bulk operation for one entry of the request
+type BulkItemResult struct {
+	Index   int        `json:"index"`          // position in the request
+	ID      uint       `json:"id,omitempty"`   // item ID (created or changed)
+	UUID    *uuid.UUID `json:"uuid,omitempty"` // set for created items
+	Success bool       `json:"success"`
+	Error   string     `json:"error,omitempty"`
+}
+
+// ── DTOs ────────────────────────────────────────────────────
+
+// BulkItemResponse reports per-item results of a bulk request
+type BulkItemResponse struct {
+	Results   []*BulkItemResult `json:"results"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+}
+
+// NewBulkItemResponse counts the results
+func NewBulkItemResponse(results []*BulkItemResult) *BulkItemResponse {
+	resp := &BulkItemResponse{Results: results}
+	for _, r := range results {
+		if r.Success {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+	return resp
+}
+
+// BulkItemIDsRequest selects existing items for delete/restore
+type BulkItemIDsRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1"`
+}
+
+// BulkMoveItemsRequest moves items to a folder (personal and org) and/or a collection (org only)
+type BulkMoveItemsRequest struct {
+	IDs          []uint `json:"ids" binding:"required,min=1"`
+	FolderID     *uint  `json:"folder_id"`
+	ClearFolder  bool   `json:"clear_folder"`
+	CollectionID *uint  `json:"collection_id,omitempty"`
+}
+
+// BulkFavoriteItemsRequest sets or clears the favorite flag
+type BulkFavoriteItemsRequest struct {
+	IDs        []uint `json:"ids" binding:"required,min=1"`
+	IsFavorite bool   `json:"is_favorite"`
+}
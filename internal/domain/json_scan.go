@@ -0,0 +1,14 @@
+package domain
+
+// jsonBytes returns the raw JSON of a scanned column value.
+// PostgreSQL drivers return JSONB as []byte; SQLite returns TEXT columns as string.
+func jsonBytes(value interface{}) ([]byte, bool) {
+	switch v := value.(type) {
+	case []byte:
+		return v, true
+	case string:
+		return []byte(v), true
+	default:
+		return nil, false
+	}
+}
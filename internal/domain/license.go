@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultLicenseGraceDays is used when neither the license nor the server config
+// specify a grace period.
+const DefaultLicenseGraceDays = 14
+
+// LicenseState represents the lifecycle state of an offline license
+type LicenseState string
+
+const (
+	LicenseStateNone    LicenseState = "none"    // No license installed
+	LicenseStateActive  LicenseState = "active"  // Valid and not expired
+	LicenseStateGrace   LicenseState = "grace"   // Expired but within grace period (entitlements kept)
+	LicenseStateExpired LicenseState = "expired" // Expired and past grace period (entitlements dropped)
+	LicenseStateInvalid LicenseState = "invalid" // Present but failed verification
+)
+
+// License is the signed payload of an offline license file.
+// It is issued by PassWall for self-hosted deployments that are not billed
+// through Stripe or RevenueCat.
+type License struct {
+	LicenseID string    `json:"license_id"`
+	Licensee  string    `json:"licensee"`
+	Email     string    `json:"email,omitempty"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// GraceDays overrides the server default grace period when > 0
+	GraceDays int `json:"grace_days,omitempty"`
+
+	// Organizations restricts the license to these organization public IDs.
+	// Empty means the license applies to every organization on the server.
+	Organizations []string `json:"organizations,omitempty"`
+
+	// Entitlements
+	PlanCode       string       `json:"plan_code"`
+	Seats          int          `json:"seats"`
+	MaxCollections *int         `json:"max_collections,omitempty"`
+	MaxItems       *int         `json:"max_items,omitempty"`
+	Features       PlanFeatures `json:"features"`
+}
+
+// Validate checks that the license payload contains the required fields
+func (l *License) Validate() error {
+	if strings.TrimSpace(l.LicenseID) == "" {
+		return fmt.Errorf("license_id is required")
+	}
+	if strings.TrimSpace(l.PlanCode) == "" {
+		return fmt.Errorf("plan_code is required")
+	}
+	if l.Seats <= 0 {
+		return fmt.Errorf("seats must be greater than 0")
+	}
+	if l.ExpiresAt.IsZero() {
+		return fmt.Errorf("expires_at is required")
+	}
+	if !l.IssuedAt.IsZero() && l.ExpiresAt.Before(l.IssuedAt) {
+		return fmt.Errorf("expires_at must be after issued_at")
+	}
+	return nil
+}
+
+// GraceEndsAt returns the end of the grace period, using defaultGraceDays
+// when the license does not carry its own value.
+func (l *License) GraceEndsAt(defaultGraceDays int) time.Time {
+	days := l.GraceDays
+	if days <= 0 {
+		days = defaultGraceDays
+	}
+	if days < 0 {
+		days = 0
+	}
+	return l.ExpiresAt.AddDate(0, 0, days)
+}
+
+// StateAt returns the license state at the given time
+func (l *License) StateAt(now time.Time, defaultGraceDays int) LicenseState {
+	if now.Before(l.ExpiresAt) {
+		return LicenseStateActive
+	}
+	if now.Before(l.GraceEndsAt(defaultGraceDays)) {
+		return LicenseStateGrace
+	}
+	return LicenseStateExpired
+}
+
+// AppliesTo checks if the license covers the given organization
+func (l *License) AppliesTo(org *Organization) bool {
+	if org == nil {
+		return false
+	}
+	if len(l.Organizations) == 0 {
+		return true
+	}
+	for _, publicID := range l.Organizations {
+		if strings.EqualFold(strings.TrimSpace(publicID), org.PublicID) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToSubscription synthesizes an in-memory subscription (never persisted) that
+// carries the license entitlements, so feature gating can treat licensed and
+// billed organizations the same way.
+func (l *License) ToSubscription(orgID uint, now time.Time, defaultGraceDays int) *Subscription {
+	seats := l.Seats
+	issuedAt := l.IssuedAt
+	renewAt := l.ExpiresAt
+
+	state := SubStateActive
+	var graceEndsAt *time.Time
+	if l.StateAt(now, defaultGraceDays) != LicenseStateActive {
+		state = SubStatePastDue
+		g := l.GraceEndsAt(defaultGraceDays)
+		graceEndsAt = &g
+	}
+
+	return &Subscription{
+		UUID:              uuid.NewSHA1(uuid.NameSpaceOID, []byte("license:"+l.LicenseID)),
+		OrganizationID:    orgID,
+		State:             state,
+		StartedAt:         &issuedAt,
+		RenewAt:           &renewAt,
+		GracePeriodEndsAt: graceEndsAt,
+		SeatsPurchased:    &seats,
+		Plan: &Plan{
+			Code:           l.PlanCode,
+			Name:           l.PlanCode,
+			MaxUsers:       &seats,
+			MaxCollections: l.MaxCollections,
+			MaxItems:       l.MaxItems,
+			Features:       l.Features,
+			IsActive:       true,
+		},
+	}
+}
+
+// LicenseStatusDTO is returned by the admin license status endpoint
+type LicenseStatusDTO struct {
+	State         LicenseState  `json:"state"`
+	Configured    bool          `json:"configured"` // Server has a license public key configured
+	LicenseID     string        `json:"license_id,omitempty"`
+	Licensee      string        `json:"licensee,omitempty"`
+	Email         string        `json:"email,omitempty"`
+	PlanCode      string        `json:"plan_code,omitempty"`
+	Seats         int           `json:"seats,omitempty"`
+	Organizations []string      `json:"organizations,omitempty"`
+	Features      *PlanFeatures `json:"features,omitempty"`
+	IssuedAt      *time.Time    `json:"issued_at,omitempty"`
+	ExpiresAt     *time.Time    `json:"expires_at,omitempty"`
+	GraceEndsAt   *time.Time    `json:"grace_ends_at,omitempty"`
+	DaysRemaining *int          `json:"days_remaining,omitempty"`
+	Error         string        `json:"error,omitempty"`
+}
+
+// ToLicenseStatusDTO builds the status DTO for a verified license
+func ToLicenseStatusDTO(l *License, now time.Time, defaultGraceDays int) *LicenseStatusDTO {
+	if l == nil {
+		return &LicenseStatusDTO{State: LicenseStateNone, Configured: true}
+	}
+
+	issuedAt := l.IssuedAt
+	expiresAt := l.ExpiresAt
+	graceEndsAt := l.GraceEndsAt(defaultGraceDays)
+	features := l.Features
+	daysRemaining := int(expiresAt.Sub(now).Hours() / 24)
+	if daysRemaining < 0 {
+		daysRemaining = 0
+	}
+
+	dto := &LicenseStatusDTO{
+		State:         l.StateAt(now, defaultGraceDays),
+		Configured:    true,
+		LicenseID:     l.LicenseID,
+		Licensee:      l.Licensee,
+		Email:         l.Email,
+		PlanCode:      l.PlanCode,
+		Seats:         l.Seats,
+		Organizations: l.Organizations,
+		Features:      &features,
+		ExpiresAt:     &expiresAt,
+		GraceEndsAt:   &graceEndsAt,
+		DaysRemaining: &daysRemaining,
+	}
+	if !issuedAt.IsZero() {
+		dto.IssuedAt = &issuedAt
+	}
+	return dto
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Notification types
+const (
+	NotificationTypeBreachRotation = "breach_rotation"
+)
+
+// Notification is an in-app notification shown to a single user.
+type Notification struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"-"`
+	Type      string     `gorm:"type:varchar(50);not null" json:"type"`
+	Title     string     `gorm:"type:varchar(255);not null" json:"title"`
+	Message   string     `gorm:"type:text" json:"message"`
+	Link      string     `gorm:"type:varchar(512)" json:"link,omitempty"` // Frontend path to open
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// IsRead reports whether the user has read the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
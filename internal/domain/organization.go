@@ -101,6 +101,9 @@ type Organization struct {
 	DeletedAt           *time.Time         `json:"deleted_at,omitempty" gorm:"index"`
 	ScheduledDeletionAt *time.Time         `json:"scheduled_deletion_at,omitempty"`
 
+	// SecurityDigestSentAt records when the last weekly security digest went out
+	SecurityDigestSentAt *time.Time `json:"-"`
+
 	// Billing & Stripe Integration
 	StripeCustomerID *string `json:"stripe_customer_id,omitempty" gorm:"type:varchar(255);index"`
 
@@ -171,20 +174,30 @@ type OrganizationUser struct {
 	EncryptedOrgKey string `json:"-" gorm:"type:text;not null"`
 
 	// Permissions
-	AccessAll   bool   `json:"access_all" gorm:"default:false"` // Access all collections
-	Permissions string `json:"permissions,omitempty" gorm:"type:text"`
+	// CustomRoleID adds an organization-defined role on top of Role; Permissions holds
+	// comma separated OrgPermission values granted directly to this member.
+	AccessAll    bool   `json:"access_all" gorm:"default:false"` // Access all collections
+	Permissions  string `json:"permissions,omitempty" gorm:"type:text"`
+	CustomRoleID *uint  `json:"custom_role_id,omitempty" gorm:"index"`
 
 	// Status
 	Status     OrganizationUserStatus `json:"status" gorm:"type:varchar(20);default:'invited'"`
 	InvitedAt  *time.Time             `json:"invited_at,omitempty"`
 	AcceptedAt *time.Time             `json:"accepted_at,omitempty"`
 
+	// Suspension keeps the membership and its data but blocks access.
+	// RemovalNoticeSentAt records the admin warning sent before automatic removal.
+	SuspendedAt         *time.Time `json:"suspended_at,omitempty"`
+	SuspensionReason    string     `json:"suspension_reason,omitempty" gorm:"type:varchar(255)"`
+	RemovalNoticeSentAt *time.Time `json:"-"`
+
 	// External ID for LDAP/AD sync
 	ExternalID *string `json:"external_id,omitempty" gorm:"type:varchar(255);index"`
 
 	// Associations
-	Organization *Organization `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
-	User         *User         `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Organization *Organization           `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
+	User         *User                   `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	CustomRole   *OrganizationCustomRole `json:"custom_role,omitempty" gorm:"foreignKey:CustomRoleID"`
 }
 
 // TableName specifies the table name
@@ -202,6 +215,11 @@ func (ou *OrganizationUser) IsAdmin() bool {
 	return ou.Role == OrgRoleOwner || ou.Role == OrgRoleAdmin
 }
 
+// IsSuspended checks if the membership is suspended
+func (ou *OrganizationUser) IsSuspended() bool {
+	return ou.Status == OrgUserStatusSuspended
+}
+
 // CanManageUsers checks if the user can manage other users
 func (ou *OrganizationUser) CanManageUsers() bool {
 	return ou.Role == OrgRoleOwner || ou.Role == OrgRoleAdmin
@@ -251,18 +269,23 @@ type OrganizationDTO struct {
 
 // OrganizationUserDTO for API responses
 type OrganizationUserDTO struct {
-	ID             uint                   `json:"id"`
-	UUID           uuid.UUID              `json:"uuid"`
-	OrganizationID uint                   `json:"organization_id"`
-	UserID         uint                   `json:"user_id"`
-	UserEmail      string                 `json:"user_email"`
-	UserName       string                 `json:"user_name"`
-	Role           OrganizationRole       `json:"role"`
-	AccessAll      bool                   `json:"access_all"`
-	Status         OrganizationUserStatus `json:"status"`
-	InvitedAt      *time.Time             `json:"invited_at,omitempty"`
-	AcceptedAt     *time.Time             `json:"accepted_at,omitempty"`
-	CreatedAt      time.Time              `json:"created_at"`
+	ID               uint                   `json:"id"`
+	UUID             uuid.UUID              `json:"uuid"`
+	OrganizationID   uint                   `json:"organization_id"`
+	UserID           uint                   `json:"user_id"`
+	UserEmail        string                 `json:"user_email"`
+	UserName         string                 `json:"user_name"`
+	Role             OrganizationRole       `json:"role"`
+	AccessAll        bool                   `json:"access_all"`
+	Status           OrganizationUserStatus `json:"status"`
+	InvitedAt        *time.Time             `json:"invited_at,omitempty"`
+	AcceptedAt       *time.Time             `json:"accepted_at,omitempty"`
+	SuspendedAt      *time.Time             `json:"suspended_at,omitempty"`
+	SuspensionReason string                 `json:"suspension_reason,omitempty"`
+	CustomRoleID     *uint                  `json:"custom_role_id,omitempty"`
+	CustomRoleName   string                 `json:"custom_role_name,omitempty"`
+	Permissions      []OrgPermission        `json:"permissions"`
+	CreatedAt        time.Time              `json:"created_at"`
 }
 
 // CreateOrganizationRequest for API requests
@@ -350,9 +373,18 @@ type InviteUserToOrgRequest struct {
 }
 
 // UpdateOrgUserRoleRequest for updating user role
+// CustomRoleID replaces the member's custom role (0 removes it); Permissions replaces
+// the member's direct grants.
 type UpdateOrgUserRoleRequest struct {
-	Role      OrganizationRole `json:"role" binding:"required,oneof=owner admin manager member"`
-	AccessAll *bool            `json:"access_all,omitempty"`
+	Role         OrganizationRole `json:"role" binding:"required,oneof=owner admin manager member"`
+	AccessAll    *bool            `json:"access_all,omitempty"`
+	CustomRoleID *uint            `json:"custom_role_id,omitempty"`
+	Permissions  *[]OrgPermission `json:"permissions,omitempty"`
+}
+
+// SuspendOrgUserRequest for API requests
+type SuspendOrgUserRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
 }
 
 // ToOrganizationDTO converts Organization to DTO
@@ -397,16 +429,20 @@ func ToOrganizationUserDTO(ou *OrganizationUser) *OrganizationUserDTO {
 	}
 
 	dto := &OrganizationUserDTO{
-		ID:             ou.ID,
-		UUID:           ou.UUID,
-		OrganizationID: ou.OrganizationID,
-		UserID:         ou.UserID,
-		Role:           ou.Role,
-		AccessAll:      ou.AccessAll,
-		Status:         ou.Status,
-		InvitedAt:      ou.InvitedAt,
-		AcceptedAt:     ou.AcceptedAt,
-		CreatedAt:      ou.CreatedAt,
+		ID:               ou.ID,
+		UUID:             ou.UUID,
+		OrganizationID:   ou.OrganizationID,
+		UserID:           ou.UserID,
+		Role:             ou.Role,
+		AccessAll:        ou.AccessAll,
+		Status:           ou.Status,
+		InvitedAt:        ou.InvitedAt,
+		AcceptedAt:       ou.AcceptedAt,
+		SuspendedAt:      ou.SuspendedAt,
+		SuspensionReason: ou.SuspensionReason,
+		CustomRoleID:     ou.CustomRoleID,
+		Permissions:      ParseOrgPermissions(ou.Permissions),
+		CreatedAt:        ou.CreatedAt,
 	}
 
 	// Add user info if loaded
@@ -414,6 +450,9 @@ func ToOrganizationUserDTO(ou *OrganizationUser) *OrganizationUserDTO {
 		dto.UserEmail = ou.User.Email
 		dto.UserName = ou.User.Name
 	}
+	if ou.CustomRole != nil {
+		dto.CustomRoleName = ou.CustomRole.Name
+	}
 
 	return dto
 }
@@ -430,6 +469,13 @@ type BillingInfo struct {
 
 	// Invoices
 	Invoices []*InvoiceDTO `json:"invoices,omitempty"`
+
+	// Promotions
+	Discount        *DiscountDTO         `json:"discount,omitempty"`
+	TrialExtensions []*TrialExtensionDTO `json:"trial_extensions,omitempty"`
+
+	// Dunning status while the subscription is past_due
+	Dunning *DunningStatusDTO `json:"dunning,omitempty"`
 }
 
 // SeatChangePreview shows the cost impact of changing seat count before the
@@ -442,6 +488,7 @@ type SeatChangePreview struct {
 	Currency          string `json:"currency"`            // e.g. "usd"
 	NextBillingDate   string `json:"next_billing_date"`   // ISO-8601
 	NextBillingAmount int64  `json:"next_billing_amount"` // cents – full amount at next renewal
+	DiscountAmount    int64  `json:"discount_amount"`     // cents – discounts applied to the preview invoice
 }
 
 // PlanChangePreview shows the cost impact of switching to a different plan.
@@ -453,6 +500,10 @@ type PlanChangePreview struct {
 	NextBillingDate   string `json:"next_billing_date"`
 	NextBillingAmount int64  `json:"next_billing_amount"`
 	ImmediateCharge   bool   `json:"immediate_charge"` // true if user will be charged now
+
+	// Promo code applied to the preview (if requested)
+	Discount       *DiscountDTO `json:"discount,omitempty"`
+	DiscountAmount int64        `json:"discount_amount"` // cents
 }
 
 // PlanChangeResult represents the result of an inline plan change.
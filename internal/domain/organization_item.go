@@ -18,9 +18,12 @@ type OrganizationItem struct {
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
 
-	// Organization and collection
-	OrganizationID uint  `json:"organization_id" gorm:"not null;index;constraint:OnDelete:CASCADE"`
-	CollectionID   *uint `json:"collection_id,omitempty" gorm:"index;constraint:OnDelete:SET NULL"` // NULL means org-wide item
+	// Organization and collections
+	OrganizationID uint `json:"organization_id" gorm:"not null;index;constraint:OnDelete:CASCADE"`
+	// CollectionIDs lists every collection the item belongs to. It is stored in
+	// organization_item_collections and loaded by the repository; an empty list
+	// leaves the item visible to its creator and org admins only.
+	CollectionIDs []uint `json:"collection_ids" gorm:"-"`
 
 	// Sync
 	Revision    int64 `json:"revision" gorm:"not null;default:0"`
@@ -47,7 +50,6 @@ type OrganizationItem struct {
 
 	// Associations
 	Organization *Organization `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
-	Collection   *Collection   `json:"collection,omitempty" gorm:"foreignKey:CollectionID"`
 	CreatedBy    *User         `json:"created_by,omitempty" gorm:"foreignKey:CreatedByUserID"`
 }
 
@@ -56,6 +58,43 @@ func (OrganizationItem) TableName() string {
 	return "organization_items"
 }
 
+// InCollection reports whether the item belongs to the collection
+func (oi *OrganizationItem) InCollection(collectionID uint) bool {
+	for _, id := range oi.CollectionIDs {
+		if id == collectionID {
+			return true
+		}
+	}
+	return false
+}
+
+// PrimaryCollectionID returns the first collection the item belongs to, or nil
+func (oi *OrganizationItem) PrimaryCollectionID() *uint {
+	if len(oi.CollectionIDs) == 0 {
+		return nil
+	}
+	id := oi.CollectionIDs[0]
+	return &id
+}
+
+// OrganizationItemCollection assigns an organization item to a collection.
+// Items shared across collections have one row per collection, so moving or
+// adding collections never touches the encrypted item data.
+type OrganizationItemCollection struct {
+	OrganizationItemID uint      `gorm:"primaryKey;autoIncrement:false" json:"organization_item_id"`
+	CollectionID       uint      `gorm:"primaryKey;autoIncrement:false;index" json:"collection_id"`
+	CreatedAt          time.Time `json:"created_at"`
+
+	// Associations
+	OrganizationItem *OrganizationItem `json:"-" gorm:"foreignKey:OrganizationItemID;constraint:OnDelete:CASCADE"`
+	Collection       *Collection       `json:"-" gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE"`
+}
+
+// TableName specifies the table name
+func (OrganizationItemCollection) TableName() string {
+	return "organization_item_collections"
+}
+
 // IsDeleted checks if item is soft deleted
 func (oi *OrganizationItem) IsDeleted() bool {
 	return oi.DeletedAt != nil
@@ -85,7 +124,8 @@ type OrganizationItemDTO struct {
 	SupportID          int64        `json:"support_id"`
 	SupportIDFormatted string       `json:"support_id_formatted"`
 	OrganizationID     uint         `json:"organization_id"`
-	CollectionID       *uint        `json:"collection_id,omitempty"`
+	CollectionID       *uint        `json:"collection_id,omitempty"` // First of CollectionIDs, kept for older clients
+	CollectionIDs      []uint       `json:"collection_ids"`
 	ItemType           ItemType     `json:"item_type"`
 	Data               string       `json:"data"` // Still encrypted (empty when hide_passwords=true)
 	Metadata           ItemMetadata `json:"metadata"`
@@ -106,27 +146,29 @@ type OrganizationItemDTO struct {
 
 // CreateOrganizationItemRequest for API requests
 type CreateOrganizationItemRequest struct {
-	CollectionID *uint        `json:"collection_id,omitempty"`
-	ItemType     ItemType     `json:"item_type" validate:"required"`
-	Data         string       `json:"data" validate:"required"` // Encrypted with Org Key
-	Metadata     ItemMetadata `json:"metadata" validate:"required"`
-	IsFavorite   bool         `json:"is_favorite"`
-	FolderID     *uint        `json:"folder_id,omitempty"`
-	Reprompt     bool         `json:"reprompt"`
-	AutoFill     *bool        `json:"auto_fill,omitempty"`
-	AutoLogin    *bool        `json:"auto_login,omitempty"`
+	CollectionID  *uint        `json:"collection_id,omitempty"`
+	CollectionIDs []uint       `json:"collection_ids,omitempty"`
+	ItemType      ItemType     `json:"item_type" validate:"required"`
+	Data          string       `json:"data" validate:"required"` // Encrypted with Org Key
+	Metadata      ItemMetadata `json:"metadata" validate:"required"`
+	IsFavorite    bool         `json:"is_favorite"`
+	FolderID      *uint        `json:"folder_id,omitempty"`
+	Reprompt      bool         `json:"reprompt"`
+	AutoFill      *bool        `json:"auto_fill,omitempty"`
+	AutoLogin     *bool        `json:"auto_login,omitempty"`
 }
 
 // UpdateOrganizationItemRequest for API requests
 type UpdateOrganizationItemRequest struct {
-	CollectionID *uint         `json:"collection_id,omitempty"`
-	Data         *string       `json:"data,omitempty"`
-	Metadata     *ItemMetadata `json:"metadata,omitempty"`
-	IsFavorite   *bool         `json:"is_favorite,omitempty"`
-	FolderID     *uint         `json:"folder_id,omitempty"`
-	Reprompt     *bool         `json:"reprompt,omitempty"`
-	AutoFill     *bool         `json:"auto_fill,omitempty"`
-	AutoLogin    *bool         `json:"auto_login,omitempty"`
+	CollectionID  *uint         `json:"collection_id,omitempty"`
+	CollectionIDs *[]uint       `json:"collection_ids,omitempty"`
+	Data          *string       `json:"data,omitempty"`
+	Metadata      *ItemMetadata `json:"metadata,omitempty"`
+	IsFavorite    *bool         `json:"is_favorite,omitempty"`
+	FolderID      *uint         `json:"folder_id,omitempty"`
+	Reprompt      *bool         `json:"reprompt,omitempty"`
+	AutoFill      *bool         `json:"auto_fill,omitempty"`
+	AutoLogin     *bool         `json:"auto_login,omitempty"`
 }
 
 // MoveItemToCollectionRequest for moving items between collections
@@ -146,7 +188,8 @@ func ToOrganizationItemDTO(oi *OrganizationItem) *OrganizationItemDTO {
 		SupportID:          oi.SupportID,
 		SupportIDFormatted: oi.FormatSupportID(),
 		OrganizationID:     oi.OrganizationID,
-		CollectionID:       oi.CollectionID,
+		CollectionID:       oi.PrimaryCollectionID(),
+		CollectionIDs:      oi.CollectionIDs,
 		ItemType:           oi.ItemType,
 		Data:               oi.Data,
 		Metadata:           oi.Metadata,
@@ -197,6 +240,9 @@ type ItemShare struct {
 	// Share target (either user or team)
 	SharedWithUserID *uint `json:"shared_with_user_id,omitempty" gorm:"index;constraint:OnDelete:CASCADE"`
 	SharedWithTeamID *uint `json:"shared_with_team_id,omitempty" gorm:"index;constraint:OnDelete:CASCADE"`
+	// Recipient without an account yet. The share stays pending, without an
+	// EncryptedKey, until the owner wraps the item key for the recipient.
+	PendingEmail string `json:"pending_email,omitempty" gorm:"type:varchar(255);index"`
 
 	// Permissions
 	CanView  bool `json:"can_view" gorm:"default:true"`
@@ -207,7 +253,11 @@ type ItemShare struct {
 	EncryptedKey string `json:"-" gorm:"type:text;not null"`
 
 	// Expiration
-	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" gorm:"index"`
+	// Set once owner and recipient were told the share is about to expire
+	ExpiryNoticeSentAt *time.Time `json:"-"`
+	// Set when the share expired and its EncryptedKey was removed
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
 
 	// Associations
 	Owner          *User `json:"owner,omitempty" gorm:"foreignKey:OwnerID"`
@@ -220,9 +270,15 @@ func (ItemShare) TableName() string {
 	return "item_shares"
 }
 
-// IsExpired checks if the share has expired
+// IsPending reports whether the share still waits for the owner to wrap the item key
+// for a recipient who had no account when it was created
+func (is *ItemShare) IsPending() bool {
+	return is.SharedWithUserID == nil && is.SharedWithTeamID == nil && is.PendingEmail != ""
+}
+
+// IsExpired checks if the share has expired or was revoked on expiry
 func (is *ItemShare) IsExpired() bool {
-	return is.ExpiresAt != nil && is.ExpiresAt.Before(time.Now())
+	return is.RevokedAt != nil || (is.ExpiresAt != nil && is.ExpiresAt.Before(time.Now()))
 }
 
 // ItemShareDTO for API responses
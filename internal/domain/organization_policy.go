@@ -52,6 +52,10 @@ const (
 	PolicyRequireDeviceApproval PolicyType = "require_device_approval"
 )
 
+// PolicyExcludedDomains is not a configurable policy. It carries the organization's
+// autofill blocklist in the active policy summary so clients fetch it together with policies.
+const PolicyExcludedDomains PolicyType = "excluded_domains"
+
 // PolicyTier defines the minimum plan tier required for a policy
 type PolicyTier string
 
@@ -70,9 +74,9 @@ func (d *PolicyData) Scan(value interface{}) error {
 		return nil
 	}
 
-	bytes, ok := value.([]byte)
+	bytes, ok := jsonBytes(value)
 	if !ok {
-		return fmt.Errorf("failed to scan PolicyData: expected []byte, got %T", value)
+		return fmt.Errorf("failed to scan PolicyData: expected []byte or string, got %T", value)
 	}
 
 	return json.Unmarshal(bytes, d)
@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrgPermission is a single grant that can be given to organization members,
+// either through a built-in role preset, a custom role, or directly.
+type OrgPermission string
+
+const (
+	OrgPermManageMembers     OrgPermission = "manage_members"
+	OrgPermViewMembers       OrgPermission = "view_members"
+	OrgPermManageTeams       OrgPermission = "manage_teams"
+	OrgPermManageCollections OrgPermission = "manage_collections"
+	OrgPermViewPolicies      OrgPermission = "view_policies"
+	OrgPermManagePolicies    OrgPermission = "manage_policies"
+	OrgPermViewSettings      OrgPermission = "view_settings"
+	OrgPermManageSettings    OrgPermission = "manage_settings"
+	OrgPermViewBilling       OrgPermission = "view_billing"
+	OrgPermManageBilling     OrgPermission = "manage_billing"
+	OrgPermViewAudit         OrgPermission = "view_audit"
+	OrgPermManageSSO         OrgPermission = "manage_sso"
+	OrgPermManageSCIM        OrgPermission = "manage_scim"
+	OrgPermApproveDevices    OrgPermission = "approve_devices"
+)
+
+// OrgPermissionInfo describes a permission for role editors
+type OrgPermissionInfo struct {
+	Permission  OrgPermission `json:"permission"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+}
+
+// OrgPermissionCatalog lists every permission that can be granted, in display order
+var OrgPermissionCatalog = []OrgPermissionInfo{
+	{OrgPermManageMembers, "Manage members", "Invite, remove, suspend and change roles of members"},
+	{OrgPermViewMembers, "View members", "View the member directory"},
+	{OrgPermManageTeams, "Manage teams", "Create teams and manage team membership"},
+	{OrgPermManageCollections, "Manage collections", "Create collections and manage collection access"},
+	{OrgPermViewPolicies, "View policies", "View organization policies"},
+	{OrgPermManagePolicies, "Manage policies", "Change organization policies and excluded domains"},
+	{OrgPermViewSettings, "View settings", "View organization settings and the management overview"},
+	{OrgPermManageSettings, "Manage settings", "Change organization details and settings"},
+	{OrgPermViewBilling, "View billing", "View subscription, invoices and seats"},
+	{OrgPermManageBilling, "Manage billing", "Change the subscription and payment methods"},
+	{OrgPermViewAudit, "View audit", "View security reports and the audit log"},
+	{OrgPermManageSSO, "Manage SSO", "Configure single sign-on and key escrow"},
+	{OrgPermManageSCIM, "Manage SCIM", "Configure SCIM provisioning and tokens"},
+	{OrgPermApproveDevices, "Approve devices", "Approve new devices of members"},
+}
+
+// AllOrgPermissions returns every permission in the catalog
+func AllOrgPermissions() []OrgPermission {
+	perms := make([]OrgPermission, len(OrgPermissionCatalog))
+	for i, info := range OrgPermissionCatalog {
+		perms[i] = info.Permission
+	}
+	return perms
+}
+
+// IsValidOrgPermission checks if the permission is in the catalog
+func IsValidOrgPermission(p OrgPermission) bool {
+	for _, info := range OrgPermissionCatalog {
+		if info.Permission == p {
+			return true
+		}
+	}
+	return false
+}
+
+// OrgRolePresets holds the permissions granted by each built-in role.
+// Owners are not listed: they always hold every permission.
+var OrgRolePresets = map[OrganizationRole][]OrgPermission{
+	OrgRoleAdmin: AllOrgPermissions(),
+	OrgRoleManager: {
+		OrgPermManageTeams,
+		OrgPermManageCollections,
+		OrgPermViewPolicies,
+		OrgPermViewSettings,
+		OrgPermViewBilling,
+	},
+	OrgRoleBilling: {
+		OrgPermViewBilling,
+		OrgPermManageBilling,
+	},
+	OrgRoleMember: {},
+}
+
+// ParseOrgPermissions reads a comma separated permission list, dropping unknown
+// entries and duplicates
+func ParseOrgPermissions(s string) []OrgPermission {
+	perms := make([]OrgPermission, 0)
+	seen := make(map[OrgPermission]bool)
+	for _, part := range strings.Split(s, ",") {
+		p := OrgPermission(strings.TrimSpace(part))
+		if p == "" || seen[p] || !IsValidOrgPermission(p) {
+			continue
+		}
+		seen[p] = true
+		perms = append(perms, p)
+	}
+	return perms
+}
+
+// JoinOrgPermissions stores a permission list as sorted, comma separated text
+func JoinOrgPermissions(perms []OrgPermission) string {
+	out := make([]string, 0, len(perms))
+	seen := make(map[OrgPermission]bool)
+	for _, p := range perms {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		out = append(out, string(p))
+	}
+	sort.Strings(out)
+	return strings.Join(out, ",")
+}
+
+// OrganizationCustomRole is an admin-defined set of permissions assigned to members
+// on top of their built-in role
+type OrganizationCustomRole struct {
+	ID        uint      `gorm:"primary_key" json:"id"`
+	UUID      uuid.UUID `gorm:"type:uuid;not null" json:"uuid"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID uint   `json:"organization_id" gorm:"not null;uniqueIndex:idx_org_custom_roles_org_name;constraint:OnDelete:CASCADE"`
+	Name           string `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_org_custom_roles_org_name"`
+	Description    string `json:"description" gorm:"type:varchar(255)"`
+
+	// Comma separated OrgPermission values
+	Permissions string `json:"permissions" gorm:"type:text;not null;default:''"`
+
+	CreatedByUserID *uint `json:"created_by_user_id,omitempty"`
+}
+
+func (OrganizationCustomRole) TableName() string {
+	return "organization_custom_roles"
+}
+
+// PermissionList returns the role's permissions
+func (r *OrganizationCustomRole) PermissionList() []OrgPermission {
+	return ParseOrgPermissions(r.Permissions)
+}
+
+// OrganizationCustomRoleDTO for API responses
+type OrganizationCustomRoleDTO struct {
+	ID             uint            `json:"id"`
+	UUID           uuid.UUID       `json:"uuid"`
+	OrganizationID uint            `json:"organization_id"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description,omitempty"`
+	Permissions    []OrgPermission `json:"permissions"`
+	MemberCount    int             `json:"member_count"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+func ToOrganizationCustomRoleDTO(r *OrganizationCustomRole) *OrganizationCustomRoleDTO {
+	if r == nil {
+		return nil
+	}
+
+	return &OrganizationCustomRoleDTO{
+		ID:             r.ID,
+		UUID:           r.UUID,
+		OrganizationID: r.OrganizationID,
+		Name:           r.Name,
+		Description:    r.Description,
+		Permissions:    r.PermissionList(),
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
+
+// OrgRolePresetDTO describes a built-in role and the permissions it grants
+type OrgRolePresetDTO struct {
+	Role        OrganizationRole `json:"role"`
+	Permissions []OrgPermission  `json:"permissions"`
+}
+
+// OrgPermissionCatalogDTO is returned to role editors
+type OrgPermissionCatalogDTO struct {
+	Permissions []OrgPermissionInfo `json:"permissions"`
+	Presets     []OrgRolePresetDTO  `json:"presets"`
+}
+
+// MyOrgPermissionsDTO reports the requesting member's effective permissions
+type MyOrgPermissionsDTO struct {
+	Role         OrganizationRole `json:"role"`
+	CustomRoleID *uint            `json:"custom_role_id,omitempty"`
+	Permissions  []OrgPermission  `json:"permissions"`
+}
+
+// CreateOrganizationCustomRoleRequest for API requests
+type CreateOrganizationCustomRoleRequest struct {
+	Name        string          `json:"name" binding:"required,max=100"`
+	Description string          `json:"description" binding:"max=255"`
+	Permissions []OrgPermission `json:"permissions"`
+}
+
+// UpdateOrganizationCustomRoleRequest for API requests
+type UpdateOrganizationCustomRoleRequest struct {
+	Name        *string          `json:"name,omitempty" binding:"omitempty,max=100"`
+	Description *string          `json:"description,omitempty" binding:"omitempty,max=255"`
+	Permissions *[]OrgPermission `json:"permissions,omitempty"`
+}
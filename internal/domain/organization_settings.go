@@ -28,6 +28,7 @@ const (
 	OrgSettingSectionSecurity           = "security"
 	OrgSettingKeyAllowed2FAMethods      = "allowed_2fa_methods"
 	OrgSettingKeyBreachDetection        = "breach_detection_enabled"
+	OrgSettingKeyBreachAutoEnroll       = "breach_monitor_auto_enroll"
 	OrgSettingKeyVaultHealthReports     = "vault_health_reports_enabled"
 	OrgSettingKeyEmergencyAccessEnabled = "emergency_access_enabled"
 
@@ -48,6 +49,7 @@ const (
 	OrgSettingSectionBilling        = "billing"
 	OrgSettingKeyBillingContact     = "billing_contact"
 	OrgSettingKeySeatManagementMode = "seat_management_mode"
+	OrgSettingKeySeatAutoMaxSeats   = "seat_auto_max_seats"
 	OrgSettingKeyInvoicePONumber    = "invoice_po_number"
 )
 
@@ -83,6 +85,7 @@ func AllOrgSettingsDefinitions() []OrgSettingsDefinition {
 		// Security
 		{Section: OrgSettingSectionSecurity, Key: OrgSettingKeyAllowed2FAMethods, Name: "Allowed 2FA Methods", Description: "JSON array of allowed 2FA methods (totp, webauthn, duo)", Type: "json", DefaultValue: "[\"totp\",\"webauthn\"]", Tier: "business"},
 		{Section: OrgSettingSectionSecurity, Key: OrgSettingKeyBreachDetection, Name: "Password Breach Detection", Description: "Check member passwords against breach databases", Type: "boolean", DefaultValue: "false", Tier: "team"},
+		{Section: OrgSettingSectionSecurity, Key: OrgSettingKeyBreachAutoEnroll, Name: "Breach Monitoring Auto-enroll", Description: "Monitor every member's email address for data breaches", Type: "boolean", DefaultValue: "false", Tier: "team"},
 		{Section: OrgSettingSectionSecurity, Key: OrgSettingKeyVaultHealthReports, Name: "Vault Health Reports", Description: "Enable weak, reused, and old password reporting", Type: "boolean", DefaultValue: "false", Tier: "team"},
 		{Section: OrgSettingSectionSecurity, Key: OrgSettingKeyEmergencyAccessEnabled, Name: "Emergency Access", Description: "Allow trusted contacts to access vaults after waiting period", Type: "boolean", DefaultValue: "false", Tier: "business"},
 
@@ -99,7 +102,8 @@ func AllOrgSettingsDefinitions() []OrgSettingsDefinition {
 
 		// Billing
 		{Section: OrgSettingSectionBilling, Key: OrgSettingKeyBillingContact, Name: "Billing Contact", Description: "JSON with billing contact details", Type: "json", DefaultValue: "{}", Tier: "all"},
-		{Section: OrgSettingSectionBilling, Key: OrgSettingKeySeatManagementMode, Name: "Seat Management", Description: "Seat addition mode: auto or manual_approval", Type: "string", DefaultValue: "auto", Tier: "business"},
+		{Section: OrgSettingSectionBilling, Key: OrgSettingKeySeatManagementMode, Name: "Seat Management", Description: "Seat mode: auto (billed seats follow membership) or manual (block members over purchased seats)", Type: "string", DefaultValue: "auto", Tier: "business"},
+		{Section: OrgSettingSectionBilling, Key: OrgSettingKeySeatAutoMaxSeats, Name: "Automatic Seat Cap", Description: "Maximum seats automatic seat management may purchase (0 = plan limit). Owner only", Type: "number", DefaultValue: "0", Tier: "business"},
 		{Section: OrgSettingSectionBilling, Key: OrgSettingKeyInvoicePONumber, Name: "Invoice PO Number", Description: "Purchase order number for invoices", Type: "string", DefaultValue: "", Tier: "business"},
 	}
 }
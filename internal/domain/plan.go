@@ -25,6 +25,7 @@ type PlanFeatures struct {
 	Policies         bool `json:"policies"`          // Organization policies enabled
 	SecurityInsights bool `json:"security_insights"` // Security Insights / Watchtower-style dashboard (score, weak, reused, 2FA)
 	BreachMonitoring bool `json:"breach_monitoring"` // Dark web / breach monitoring (HIBP, compromised passwords)
+	SendFileMaxMB    *int `json:"send_file_max_mb"`  // File send size limit in MB (null = server default, 0 = disabled)
 }
 
 // Scan implements sql.Scanner for PlanFeatures (JSONB)
@@ -33,9 +34,9 @@ func (f *PlanFeatures) Scan(value interface{}) error {
 		return nil
 	}
 
-	bytes, ok := value.([]byte)
+	bytes, ok := jsonBytes(value)
 	if !ok {
-		return fmt.Errorf("failed to scan PlanFeatures: expected []byte, got %T", value)
+		return fmt.Errorf("failed to scan PlanFeatures: expected []byte or string, got %T", value)
 	}
 
 	return json.Unmarshal(bytes, f)
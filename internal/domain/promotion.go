@@ -0,0 +1,293 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DiscountType represents how a promo code reduces the price
+type DiscountType string
+
+const (
+	DiscountTypePercent DiscountType = "percent" // PercentOff of the invoice total
+	DiscountTypeAmount  DiscountType = "amount"  // AmountOffCents subtracted from the invoice total
+)
+
+// DiscountDuration mirrors Stripe coupon durations
+type DiscountDuration string
+
+const (
+	DiscountDurationOnce      DiscountDuration = "once"      // First invoice only
+	DiscountDurationRepeating DiscountDuration = "repeating" // DurationInMonths months
+	DiscountDurationForever   DiscountDuration = "forever"   // Every invoice
+)
+
+// PromoRedemptionStatus represents the state of a promo code redemption
+type PromoRedemptionStatus string
+
+const (
+	PromoRedemptionPending  PromoRedemptionStatus = "pending"  // Attached to a checkout session that hasn't completed yet
+	PromoRedemptionRedeemed PromoRedemptionStatus = "redeemed" // Applied to a subscription
+	PromoRedemptionReleased PromoRedemptionStatus = "released" // Reserved for a plan change that Stripe rejected
+)
+
+// PromoRedemptionSource tells where a promo code was applied
+type PromoRedemptionSource string
+
+const (
+	PromoRedemptionSourceCheckout   PromoRedemptionSource = "checkout"
+	PromoRedemptionSourcePlanChange PromoRedemptionSource = "plan_change"
+)
+
+// PromoCode is a customer-facing coupon code backed by a Stripe coupon
+type PromoCode struct {
+	ID        uint      `gorm:"primary_key" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Code        string `json:"code" gorm:"type:varchar(64);not null;uniqueIndex"` // Stored upper-case
+	Description string `json:"description" gorm:"type:varchar(255)"`
+
+	DiscountType     DiscountType     `json:"discount_type" gorm:"type:varchar(20);not null"`
+	PercentOff       *float64         `json:"percent_off,omitempty"`
+	AmountOffCents   *int64           `json:"amount_off_cents,omitempty"`
+	Currency         string           `json:"currency,omitempty" gorm:"type:varchar(3)"`
+	Duration         DiscountDuration `json:"duration" gorm:"type:varchar(20);not null;default:'once'"`
+	DurationInMonths *int             `json:"duration_in_months,omitempty"`
+
+	// Redemption limits
+	MaxRedemptions *int       `json:"max_redemptions,omitempty"` // nil = unlimited
+	TimesRedeemed  int        `json:"times_redeemed" gorm:"not null;default:0"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+
+	// Optional restriction to base plans (e.g. ["team","business"]); empty = all plans
+	AppliesToPlans StringSliceJSON `json:"applies_to_plans" gorm:"type:jsonb;default:'[]'"`
+
+	IsActive        bool    `json:"is_active" gorm:"not null;default:true"`
+	StripeCouponID  *string `json:"stripe_coupon_id,omitempty" gorm:"type:varchar(255)"`
+	CreatedByUserID *uint   `json:"created_by_user_id,omitempty"`
+}
+
+// TableName specifies the table name
+func (PromoCode) TableName() string {
+	return "promo_codes"
+}
+
+// NormalizePromoCode normalizes user input for lookups
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsRedeemable checks whether the code can be applied at the given time
+func (p *PromoCode) IsRedeemable(now time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+	if p.ExpiresAt != nil && !now.Before(*p.ExpiresAt) {
+		return false
+	}
+	if p.MaxRedemptions != nil && p.TimesRedeemed >= *p.MaxRedemptions {
+		return false
+	}
+	return true
+}
+
+// AppliesToPlan checks if the code can be used for the given base plan (e.g. "team")
+func (p *PromoCode) AppliesToPlan(plan string) bool {
+	if len(p.AppliesToPlans) == 0 {
+		return true
+	}
+	base := strings.Split(plan, "-")[0]
+	for _, allowed := range p.AppliesToPlans {
+		if strings.EqualFold(allowed, base) {
+			return true
+		}
+	}
+	return false
+}
+
+// DiscountEndsAt returns when a discount redeemed at redeemedAt stops applying.
+// periodEnd is the end of the first billing period and is used for "once" discounts.
+// Returns nil for "forever" discounts.
+func (p *PromoCode) DiscountEndsAt(redeemedAt time.Time, periodEnd *time.Time) *time.Time {
+	switch p.Duration {
+	case DiscountDurationRepeating:
+		months := 1
+		if p.DurationInMonths != nil && *p.DurationInMonths > 0 {
+			months = *p.DurationInMonths
+		}
+		t := redeemedAt.AddDate(0, months, 0)
+		return &t
+	case DiscountDurationOnce:
+		if periodEnd != nil {
+			t := *periodEnd
+			return &t
+		}
+		t := redeemedAt.AddDate(0, 1, 0)
+		return &t
+	default:
+		return nil
+	}
+}
+
+// Summary returns a short human readable description (e.g. "20% off for 3 months")
+func (p *PromoCode) Summary() string {
+	var off string
+	if p.DiscountType == DiscountTypePercent && p.PercentOff != nil {
+		off = fmt.Sprintf("%g%% off", *p.PercentOff)
+	} else if p.AmountOffCents != nil {
+		off = fmt.Sprintf("%.2f %s off", float64(*p.AmountOffCents)/100, strings.ToUpper(p.Currency))
+	}
+
+	switch p.Duration {
+	case DiscountDurationForever:
+		return off + " forever"
+	case DiscountDurationRepeating:
+		months := 1
+		if p.DurationInMonths != nil {
+			months = *p.DurationInMonths
+		}
+		return fmt.Sprintf("%s for %d months", off, months)
+	default:
+		return off + " on the first invoice"
+	}
+}
+
+// PromoRedemption is the audit trail of promo code usage
+type PromoRedemption struct {
+	ID        uint      `gorm:"primary_key" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	PromoCodeID    uint                  `json:"promo_code_id" gorm:"not null;index"`
+	OrganizationID uint                  `json:"organization_id" gorm:"not null;index"`
+	UserID         uint                  `json:"user_id" gorm:"not null"`
+	Source         PromoRedemptionSource `json:"source" gorm:"type:varchar(20);not null"`
+	Status         PromoRedemptionStatus `json:"status" gorm:"type:varchar(20);not null;index"`
+	PlanCode       string                `json:"plan_code" gorm:"type:varchar(50)"`
+
+	StripeCouponID        *string `json:"stripe_coupon_id,omitempty" gorm:"type:varchar(255)"`
+	StripeCheckoutSession *string `json:"stripe_checkout_session,omitempty" gorm:"type:varchar(255);index"`
+
+	RedeemedAt *time.Time `json:"redeemed_at,omitempty"`
+	EndsAt     *time.Time `json:"ends_at,omitempty"` // nil = forever (or not redeemed yet)
+
+	PromoCode *PromoCode `json:"promo_code,omitempty" gorm:"foreignKey:PromoCodeID"`
+}
+
+// TableName specifies the table name
+func (PromoRedemption) TableName() string {
+	return "promo_redemptions"
+}
+
+// TrialExtension is an admin-granted extension of an organization's trial.
+// Extensions granted before checkout are added to the checkout trial period;
+// extensions for trialing Stripe subscriptions move the trial end immediately.
+type TrialExtension struct {
+	ID        uint      `gorm:"primary_key" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID  uint       `json:"organization_id" gorm:"not null;index"`
+	Days            int        `json:"days" gorm:"not null"`
+	Reason          string     `json:"reason" gorm:"type:text"`
+	GrantedByUserID uint       `json:"granted_by_user_id" gorm:"not null"`
+	AppliedAt       *time.Time `json:"applied_at,omitempty"` // nil = pending until next checkout
+	TrialEndsAt     *time.Time `json:"trial_ends_at,omitempty"`
+}
+
+// TableName specifies the table name
+func (TrialExtension) TableName() string {
+	return "trial_extensions"
+}
+
+// IsPending checks if the extension has not been applied yet
+func (t *TrialExtension) IsPending() bool {
+	return t.AppliedAt == nil
+}
+
+// ── DTOs ────────────────────────────────────────────────────
+
+// DiscountDTO describes a discount applied (or to be applied) to a subscription
+type DiscountDTO struct {
+	Code             string           `json:"code"`
+	Summary          string           `json:"summary"`
+	DiscountType     DiscountType     `json:"discount_type"`
+	PercentOff       *float64         `json:"percent_off,omitempty"`
+	AmountOffCents   *int64           `json:"amount_off_cents,omitempty"`
+	Currency         string           `json:"currency,omitempty"`
+	Duration         DiscountDuration `json:"duration"`
+	DurationInMonths *int             `json:"duration_in_months,omitempty"`
+	RedeemedAt       *time.Time       `json:"redeemed_at,omitempty"`
+	EndsAt           *time.Time       `json:"ends_at,omitempty"`
+}
+
+// ToDiscountDTO converts a promo code (and optional redemption) to a DiscountDTO
+func ToDiscountDTO(p *PromoCode, r *PromoRedemption) *DiscountDTO {
+	if p == nil {
+		return nil
+	}
+	dto := &DiscountDTO{
+		Code:             p.Code,
+		Summary:          p.Summary(),
+		DiscountType:     p.DiscountType,
+		PercentOff:       p.PercentOff,
+		AmountOffCents:   p.AmountOffCents,
+		Currency:         p.Currency,
+		Duration:         p.Duration,
+		DurationInMonths: p.DurationInMonths,
+	}
+	if r != nil {
+		dto.RedeemedAt = r.RedeemedAt
+		dto.EndsAt = r.EndsAt
+	}
+	return dto
+}
+
+// TrialExtensionDTO is the API representation of a trial extension
+type TrialExtensionDTO struct {
+	ID          uint       `json:"id"`
+	Days        int        `json:"days"`
+	Reason      string     `json:"reason,omitempty"`
+	Pending     bool       `json:"pending"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+	TrialEndsAt *time.Time `json:"trial_ends_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ToTrialExtensionDTO converts a trial extension to DTO
+func ToTrialExtensionDTO(t *TrialExtension) *TrialExtensionDTO {
+	if t == nil {
+		return nil
+	}
+	return &TrialExtensionDTO{
+		ID:          t.ID,
+		Days:        t.Days,
+		Reason:      t.Reason,
+		Pending:     t.IsPending(),
+		AppliedAt:   t.AppliedAt,
+		TrialEndsAt: t.TrialEndsAt,
+		CreatedAt:   t.CreatedAt,
+	}
+}
+
+// CreatePromoCodeRequest is the admin request to create a promo code
+type CreatePromoCodeRequest struct {
+	Code             string           `json:"code" binding:"required"`
+	Description      string           `json:"description"`
+	PercentOff       *float64         `json:"percent_off"`
+	AmountOffCents   *int64           `json:"amount_off_cents"`
+	Currency         string           `json:"currency"`
+	Duration         DiscountDuration `json:"duration"`
+	DurationInMonths *int             `json:"duration_in_months"`
+	MaxRedemptions   *int             `json:"max_redemptions"`
+	ExpiresAt        *time.Time       `json:"expires_at"`
+	AppliesToPlans   []string         `json:"applies_to_plans"`
+}
+
+// GrantTrialExtensionRequest is the admin request to extend an organization's trial
+type GrantTrialExtensionRequest struct {
+	Days   int    `json:"days" binding:"required"`
+	Reason string `json:"reason"`
+}
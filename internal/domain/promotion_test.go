@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPromoCode_IsRedeemable(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	two := 2
+
+	tests := []struct {
+		name  string
+		promo PromoCode
+		want  bool
+	}{
+		{"active", PromoCode{IsActive: true}, true},
+		{"inactive", PromoCode{IsActive: false}, false},
+		{"expired", PromoCode{IsActive: true, ExpiresAt: &past}, false},
+		{"not yet expired", PromoCode{IsActive: true, ExpiresAt: &future}, true},
+		{"redemptions left", PromoCode{IsActive: true, MaxRedemptions: &two, TimesRedeemed: 1}, true},
+		{"fully redeemed", PromoCode{IsActive: true, MaxRedemptions: &two, TimesRedeemed: 2}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.promo.IsRedeemable(now); got != tt.want {
+				t.Errorf("IsRedeemable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromoCode_AppliesToPlan(t *testing.T) {
+	promo := PromoCode{AppliesToPlans: StringSliceJSON{"team", "business"}}
+
+	if !promo.AppliesToPlan("team") || !promo.AppliesToPlan("business-yearly") {
+		t.Errorf("expected code to apply to team and business plans")
+	}
+	if promo.AppliesToPlan("family") {
+		t.Errorf("expected code not to apply to family plan")
+	}
+	if !(&PromoCode{}).AppliesToPlan("family") {
+		t.Errorf("expected unrestricted code to apply to every plan")
+	}
+}
+
+func TestPromoCode_DiscountEndsAt(t *testing.T) {
+	redeemedAt := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)
+	three := 3
+
+	forever := PromoCode{Duration: DiscountDurationForever}
+	if got := forever.DiscountEndsAt(redeemedAt, &periodEnd); got != nil {
+		t.Errorf("forever discount should not end, got %v", got)
+	}
+
+	repeating := PromoCode{Duration: DiscountDurationRepeating, DurationInMonths: &three}
+	if got := repeating.DiscountEndsAt(redeemedAt, &periodEnd); got == nil || !got.Equal(redeemedAt.AddDate(0, 3, 0)) {
+		t.Errorf("repeating discount end = %v, want %v", got, redeemedAt.AddDate(0, 3, 0))
+	}
+
+	once := PromoCode{Duration: DiscountDurationOnce}
+	if got := once.DiscountEndsAt(redeemedAt, &periodEnd); got == nil || !got.Equal(periodEnd) {
+		t.Errorf("once discount end = %v, want %v", got, periodEnd)
+	}
+}
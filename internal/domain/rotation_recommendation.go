@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// RotationRecommendation flags a vault item whose site appeared in a data breach
+// of its owner's email address. It stays open until the user resolves it,
+// typically after changing the password.
+type RotationRecommendation struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	UserID         uint       `gorm:"not null;uniqueIndex:idx_rotation_user_item_breach" json:"-"`
+	OrganizationID *uint      `gorm:"index" json:"organization_id,omitempty"` // nil for personal items
+	ItemUUID       string     `gorm:"type:varchar(36);not null;uniqueIndex:idx_rotation_user_item_breach" json:"item_uuid"`
+	ItemName       string     `gorm:"type:varchar(255)" json:"item_name"`
+	URIHint        string     `gorm:"type:varchar(255)" json:"uri_hint"`
+	BreachRecordID uint       `gorm:"not null;index" json:"breach_record_id"`
+	BreachName     string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_rotation_user_item_breach" json:"breach_name"`
+	BreachDomain   string     `gorm:"type:varchar(255)" json:"breach_domain"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func (RotationRecommendation) TableName() string {
+	return "rotation_recommendations"
+}
@@ -0,0 +1,84 @@
+package domain
+
+import "strings"
+
+// SeatManagementMode controls how membership changes affect billed seats
+// (organization setting billing.seat_management_mode).
+type SeatManagementMode string
+
+const (
+	// SeatModeAutomatic grows and shrinks the Stripe seat quantity with membership,
+	// up to the cap set by the organization owner.
+	SeatModeAutomatic SeatManagementMode = "auto"
+	// SeatModeManual blocks new members once every purchased seat is taken.
+	SeatModeManual SeatManagementMode = "manual"
+)
+
+// ParseSeatManagementMode parses a stored setting value; unknown or empty values use the default (auto).
+func ParseSeatManagementMode(value string) SeatManagementMode {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case string(SeatModeManual), "manual_approval":
+		return SeatModeManual
+	default:
+		return SeatModeAutomatic
+	}
+}
+
+// IsValidSeatManagementMode reports whether value is an accepted setting value
+func IsValidSeatManagementMode(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case string(SeatModeAutomatic), string(SeatModeManual), "manual_approval":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSeatBasedPlan reports whether a plan code is billed per seat (family/team/business).
+// Pro/free are not seat-based in our pricing model.
+func IsSeatBasedPlan(planCode string) bool {
+	switch strings.Split(planCode, "-")[0] {
+	case "family", "team", "business":
+		return true
+	default:
+		return false
+	}
+}
+
+// OccupiesSeat reports whether the membership counts against purchased seats.
+// Pending invitations and suspended members do not take a seat.
+func (ou *OrganizationUser) OccupiesSeat() bool {
+	switch ou.Status {
+	case OrgUserStatusAccepted, OrgUserStatusConfirmed, OrgUserStatusProvisioned:
+		return true
+	default:
+		return false
+	}
+}
+
+// CountOccupiedSeats returns how many memberships take a seat
+func CountOccupiedSeats(members []*OrganizationUser) int {
+	n := 0
+	for _, m := range members {
+		if m != nil && m.OccupiesSeat() {
+			n++
+		}
+	}
+	return n
+}
+
+// ── DTOs ────────────────────────────────────────────────────
+
+// SeatStatusDTO describes seat usage and what adding a member would cost
+type SeatStatusDTO struct {
+	Mode               SeatManagementMode `json:"mode"`
+	SeatsPurchased     int                `json:"seats_purchased"`
+	SeatsUsed          int                `json:"seats_used"`
+	PendingInvitations int                `json:"pending_invitations"`
+	// SeatCap is the most seats automatic mode may buy (owner cap or plan limit); nil means unlimited
+	SeatCap *int `json:"seat_cap,omitempty"`
+	// AutoAdjust is true when membership changes update the Stripe quantity
+	AutoAdjust bool `json:"auto_adjust"`
+	// NextSeatPreview is the proration for one more seat (only when the next member needs a new seat)
+	NextSeatPreview *SeatChangePreview `json:"next_seat_preview,omitempty"`
+}
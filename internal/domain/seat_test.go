@@ -0,0 +1,51 @@
+package domain
+
+import "testing"
+
+func TestParseSeatManagementMode(t *testing.T) {
+	tests := []struct {
+		value string
+		want  SeatManagementMode
+	}{
+		{"", SeatModeAutomatic},
+		{"auto", SeatModeAutomatic},
+		{"manual", SeatModeManual},
+		{" Manual_Approval ", SeatModeManual},
+		{"unknown", SeatModeAutomatic},
+	}
+
+	for _, tt := range tests {
+		if got := ParseSeatManagementMode(tt.value); got != tt.want {
+			t.Errorf("ParseSeatManagementMode(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCountOccupiedSeats(t *testing.T) {
+	members := []*OrganizationUser{
+		{Status: OrgUserStatusAccepted},
+		{Status: OrgUserStatusConfirmed},
+		{Status: OrgUserStatusProvisioned},
+		{Status: OrgUserStatusInvited},
+		{Status: OrgUserStatusSuspended},
+		nil,
+	}
+
+	if got := CountOccupiedSeats(members); got != 3 {
+		t.Errorf("CountOccupiedSeats() = %d, want 3", got)
+	}
+}
+
+func TestIsSeatBasedPlan(t *testing.T) {
+	for code, want := range map[string]bool{
+		"team-monthly":    true,
+		"business-yearly": true,
+		"family":          true,
+		"pro-monthly":     false,
+		"free":            false,
+	} {
+		if got := IsSeatBasedPlan(code); got != want {
+			t.Errorf("IsSeatBasedPlan(%q) = %v, want %v", code, got, want)
+		}
+	}
+}
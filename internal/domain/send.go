@@ -11,8 +11,14 @@ type SendType string
 
 const (
 	SendTypeText SendType = "text"
+	SendTypeFile SendType = "file"
 )
 
+// IsValid reports whether t is a known send type
+func (t SendType) IsValid() bool {
+	return t == SendTypeText || t == SendTypeFile
+}
+
 // Send represents a secure, shareable piece of data with expiration and access controls.
 // Zero-knowledge: data is encrypted client-side, the decryption key lives only in the URL fragment.
 type Send struct {
@@ -31,10 +37,15 @@ type Send struct {
 	Name string   `json:"name" gorm:"type:varchar(255);not null"`
 	Type SendType `json:"type" gorm:"type:varchar(20);not null;default:'text'"`
 
-	// Encrypted text (EncString format, encrypted with client-generated SendKey)
+	// Encrypted text (EncString format, encrypted with client-generated SendKey).
+	// For file sends this is the encrypted file name; the encrypted content is stored as a blob.
 	Data  string  `json:"data" gorm:"type:text;not null"`
 	Notes *string `json:"notes,omitempty" gorm:"type:text"`
 
+	// File sends: size of the encrypted content declared at creation, and whether it was uploaded
+	FileSize     int64 `json:"file_size" gorm:"not null;default:0"`
+	FileUploaded bool  `json:"file_uploaded" gorm:"not null;default:false"`
+
 	// Optional bcrypt hash for extra password protection
 	Password *string `json:"-" gorm:"type:varchar(255)"`
 
@@ -49,6 +60,8 @@ type Send struct {
 
 	// Associations
 	Creator *User `json:"creator,omitempty" gorm:"foreignKey:CreatorID"`
+	// Recipients restrict access to verified email addresses (set only when creating)
+	Recipients []SendRecipient `json:"-" gorm:"foreignKey:SendID"`
 }
 
 func (Send) TableName() string {
@@ -71,6 +84,16 @@ func (s *Send) IsAccessLimitReached() bool {
 	return false
 }
 
+// IsFile checks if the send carries an encrypted file
+func (s *Send) IsFile() bool {
+	return s.Type == SendTypeFile
+}
+
+// BlobKey returns the storage key of the encrypted file
+func (s *Send) BlobKey() string {
+	return s.UUID.String()
+}
+
 // HasPassword checks if the send is password-protected
 func (s *Send) HasPassword() bool {
 	return s.Password != nil && *s.Password != ""
@@ -87,6 +110,8 @@ type SendDTO struct {
 	Type           SendType   `json:"type"`
 	Data           string     `json:"data"`
 	Notes          *string    `json:"notes,omitempty"`
+	FileSize       int64      `json:"file_size,omitempty"`
+	FileUploaded   bool       `json:"file_uploaded,omitempty"`
 	HasPassword    bool       `json:"has_password"`
 	MaxAccessCount *int       `json:"max_access_count,omitempty"`
 	AccessCount    int        `json:"access_count"`
@@ -96,6 +121,10 @@ type SendDTO struct {
 	HideEmail      bool       `json:"hide_email"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Detail view only
+	Recipients   []*SendRecipientDTO   `json:"recipients,omitempty"`
+	AccessEvents []*SendAccessEventDTO `json:"access_events,omitempty"`
 }
 
 func ToSendDTO(s *Send) *SendDTO {
@@ -112,6 +141,8 @@ func ToSendDTO(s *Send) *SendDTO {
 		Type:           s.Type,
 		Data:           s.Data,
 		Notes:          s.Notes,
+		FileSize:       s.FileSize,
+		FileUploaded:   s.FileUploaded,
 		HasPassword:    s.HasPassword(),
 		MaxAccessCount: s.MaxAccessCount,
 		AccessCount:    s.AccessCount,
@@ -131,9 +162,14 @@ type SendAccessDTO struct {
 	Type           SendType   `json:"type"`
 	Data           string     `json:"data"`
 	Notes          *string    `json:"notes,omitempty"`
+	FileSize       int64      `json:"file_size,omitempty"` // File sends: download via /api/sends/access/:access_id/file
 	CreatorEmail   string     `json:"creator_email,omitempty"`
 	HasPassword    bool       `json:"has_password"`
 	ExpirationDate *time.Time `json:"expiration_date,omitempty"`
+
+	// Recipient-restricted sends: Data is withheld until the recipient verifies a one-time code
+	RequiresVerification bool   `json:"requires_verification,omitempty"`
+	AccessToken          string `json:"access_token,omitempty"` // Issued after verification; send as X-Send-Access-Token to download files
 }
 
 // CreateSendRequest for creating a new send
@@ -143,11 +179,13 @@ type CreateSendRequest struct {
 	Type           SendType   `json:"type" validate:"required"`
 	Data           string     `json:"data" validate:"required"`
 	Notes          *string    `json:"notes,omitempty"`
+	FileSize       int64      `json:"file_size,omitempty"` // Required for file sends
 	Password       *string    `json:"password,omitempty"`
 	MaxAccessCount *int       `json:"max_access_count,omitempty"`
 	ExpirationDate *time.Time `json:"expiration_date,omitempty"`
 	DeletionDate   *time.Time `json:"deletion_date,omitempty"`
 	HideEmail      bool       `json:"hide_email"`
+	Recipients     []string   `json:"recipients,omitempty"` // Restrict access to these email addresses
 }
 
 // UpdateSendRequest for updating an existing send
@@ -161,4 +199,5 @@ type UpdateSendRequest struct {
 	DeletionDate   *time.Time `json:"deletion_date,omitempty"`
 	Disabled       *bool      `json:"disabled,omitempty"`
 	HideEmail      *bool      `json:"hide_email,omitempty"`
+	Recipients     *[]string  `json:"recipients,omitempty"` // Replaces the recipient list; empty removes the restriction
 }
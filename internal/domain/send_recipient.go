@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"time"
+)
+
+// MaxSendRecipients limits how many email addresses a send can be restricted to
+const MaxSendRecipients = 50
+
+// SendAccessEventType describes one access attempt on a recipient-restricted send
+type SendAccessEventType string
+
+const (
+	SendAccessCodeSent         SendAccessEventType = "code_sent"
+	SendAccessUnknownRecipient SendAccessEventType = "unknown_recipient"
+	SendAccessInvalidCode      SendAccessEventType = "invalid_code"
+	SendAccessWrongPassword    SendAccessEventType = "wrong_password"
+	SendAccessGranted          SendAccessEventType = "granted"
+	SendAccessDownloaded       SendAccessEventType = "downloaded"
+)
+
+// SendRecipient is an email address allowed to open a restricted send.
+// The recipient proves ownership with a one-time code and then receives a short-lived access token.
+// Only SHA-256 hashes of the code and token are stored.
+type SendRecipient struct {
+	ID        uint      `gorm:"primary_key" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	SendID uint   `json:"send_id" gorm:"not null;uniqueIndex:idx_send_recipients_send_email;constraint:OnDelete:CASCADE"`
+	Email  string `json:"email" gorm:"type:varchar(255);not null;uniqueIndex:idx_send_recipients_send_email"`
+
+	CodeHash      *string    `json:"-" gorm:"type:varchar(64)"`
+	CodeSentAt    *time.Time `json:"-"`
+	CodeExpiresAt *time.Time `json:"-"`
+	CodeAttempts  int        `json:"-" gorm:"not null;default:0"`
+
+	TokenHash      *string    `json:"-" gorm:"type:varchar(64);index"`
+	TokenExpiresAt *time.Time `json:"-"`
+
+	VerifiedAt   *time.Time `json:"verified_at,omitempty"`
+	AccessCount  int        `json:"access_count" gorm:"not null;default:0"`
+	LastAccessAt *time.Time `json:"last_access_at,omitempty"`
+}
+
+func (SendRecipient) TableName() string {
+	return "send_recipients"
+}
+
+// HasValidCode checks if a one-time code is pending and not expired
+func (r *SendRecipient) HasValidCode() bool {
+	return r.CodeHash != nil && r.CodeExpiresAt != nil && r.CodeExpiresAt.After(time.Now())
+}
+
+// HasValidToken checks if the access token issued after verification is still valid
+func (r *SendRecipient) HasValidToken() bool {
+	return r.TokenHash != nil && r.TokenExpiresAt != nil && r.TokenExpiresAt.After(time.Now())
+}
+
+// SendAccessEvent records one access attempt on a recipient-restricted send
+type SendAccessEvent struct {
+	ID        uint      `gorm:"primary_key" json:"id"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+
+	SendID      uint                `json:"send_id" gorm:"not null;index;constraint:OnDelete:CASCADE"`
+	RecipientID *uint               `json:"recipient_id,omitempty" gorm:"index"`
+	Email       string              `json:"email" gorm:"type:varchar(255);not null"`
+	Event       SendAccessEventType `json:"event" gorm:"type:varchar(30);not null"`
+	IPAddress   string              `json:"ip_address" gorm:"type:varchar(45)"`
+	UserAgent   string              `json:"user_agent" gorm:"type:varchar(512)"`
+}
+
+func (SendAccessEvent) TableName() string {
+	return "send_access_events"
+}
+
+// SendAccessRequest carries the credentials and client details of a public send access
+type SendAccessRequest struct {
+	Password    string
+	Email       string
+	Code        string
+	AccessToken string
+	IPAddress   string
+	UserAgent   string
+}
+
+// SendRecipientDTO is the creator's view of a recipient
+type SendRecipientDTO struct {
+	Email        string     `json:"email"`
+	VerifiedAt   *time.Time `json:"verified_at,omitempty"`
+	AccessCount  int        `json:"access_count"`
+	LastAccessAt *time.Time `json:"last_access_at,omitempty"`
+}
+
+func ToSendRecipientDTO(r *SendRecipient) *SendRecipientDTO {
+	if r == nil {
+		return nil
+	}
+	return &SendRecipientDTO{
+		Email:        r.Email,
+		VerifiedAt:   r.VerifiedAt,
+		AccessCount:  r.AccessCount,
+		LastAccessAt: r.LastAccessAt,
+	}
+}
+
+// SendAccessEventDTO is the creator's view of an access attempt
+type SendAccessEventDTO struct {
+	Email     string              `json:"email"`
+	Event     SendAccessEventType `json:"event"`
+	IPAddress string              `json:"ip_address,omitempty"`
+	UserAgent string              `json:"user_agent,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+func ToSendAccessEventDTO(e *SendAccessEvent) *SendAccessEventDTO {
+	if e == nil {
+		return nil
+	}
+	return &SendAccessEventDTO{
+		Email:     e.Email,
+		Event:     e.Event,
+		IPAddress: e.IPAddress,
+		UserAgent: e.UserAgent,
+		CreatedAt: e.CreatedAt,
+	}
+}
@@ -42,9 +42,9 @@ func (c *SAMLConfig) Scan(value interface{}) error {
 	if value == nil {
 		return nil
 	}
-	bytes, ok := value.([]byte)
+	bytes, ok := jsonBytes(value)
 	if !ok {
-		return fmt.Errorf("failed to scan SAMLConfig: expected []byte, got %T", value)
+		return fmt.Errorf("failed to scan SAMLConfig: expected []byte or string, got %T", value)
 	}
 	return json.Unmarshal(bytes, c)
 }
@@ -76,9 +76,9 @@ func (c *OIDCConfig) Scan(value interface{}) error {
 	if value == nil {
 		return nil
 	}
-	bytes, ok := value.([]byte)
+	bytes, ok := jsonBytes(value)
 	if !ok {
-		return fmt.Errorf("failed to scan OIDCConfig: expected []byte, got %T", value)
+		return fmt.Errorf("failed to scan OIDCConfig: expected []byte or string, got %T", value)
 	}
 	return json.Unmarshal(bytes, c)
 }
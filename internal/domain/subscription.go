@@ -42,6 +42,11 @@ type Subscription struct {
 	GracePeriodEndsAt *time.Time `json:"grace_period_ends_at,omitempty"`
 	TrialEndsAt       *time.Time `json:"trial_ends_at,omitempty"`
 
+	// Dunning (past_due follow-up)
+	PastDueSince         *time.Time `json:"past_due_since,omitempty"`
+	ReadOnlySince        *time.Time `json:"read_only_since,omitempty"`
+	DunningRemindersSent int        `json:"dunning_reminders_sent" gorm:"not null;default:0"`
+
 	// Stripe integration
 	StripeSubscriptionID *string `json:"stripe_subscription_id,omitempty" gorm:"type:varchar(255);uniqueIndex"`
 
@@ -66,7 +71,20 @@ func (s *Subscription) IsActive() bool {
 
 // CanWrite checks if subscription allows write operations
 func (s *Subscription) CanWrite() bool {
-	return s.State != SubStateExpired
+	return s.State != SubStateExpired && !s.IsReadOnly()
+}
+
+// IsReadOnly checks if a past_due subscription was downgraded to read-only by dunning
+func (s *Subscription) IsReadOnly() bool {
+	return s.State == SubStatePastDue && s.ReadOnlySince != nil
+}
+
+// ClearDunning resets the dunning state (payment recovered or subscription replaced)
+func (s *Subscription) ClearDunning() {
+	s.GracePeriodEndsAt = nil
+	s.PastDueSince = nil
+	s.ReadOnlySince = nil
+	s.DunningRemindersSent = 0
 }
 
 // IsInGracePeriod checks if subscription is in grace period after payment failure
@@ -84,15 +102,11 @@ func (s *Subscription) IsExpired() bool {
 	return s.State == SubStateExpired
 }
 
-// ShouldExpire checks if subscription should be expired (grace period ended or cancel period ended)
+// ShouldExpire checks if a canceled subscription's period has ended.
+// Past-due subscriptions are expired by the dunning schedule (see DunningSchedule).
 func (s *Subscription) ShouldExpire() bool {
 	now := time.Now()
 
-	// Past due with expired grace period
-	if s.State == SubStatePastDue && s.GracePeriodEndsAt != nil && now.After(*s.GracePeriodEndsAt) {
-		return true
-	}
-
 	// Canceled with expired period end
 	if s.State == SubStateCanceled && s.RenewAt != nil && now.After(*s.RenewAt) {
 		return true
@@ -76,6 +76,8 @@ type User struct {
 
 	// Stats (runtime calculated, not stored in DB)
 	ItemCount *int `json:"item_count,omitempty" gorm:"-"`
+	// LastSignInAt is only populated by directory queries, from the latest signin activity
+	LastSignInAt *time.Time `json:"-" gorm:"-"`
 }
 
 // TableName specifies the table name for User
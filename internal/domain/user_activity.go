@@ -15,15 +15,18 @@ const (
 	ActivityTypeAccountCreated ActivityType = "account_created"
 	ActivityTypeVaultUnlock    ActivityType = "vault_unlock"
 	ActivityTypeVaultLock      ActivityType = "vault_lock"
+	ActivityTypeVaultExported  ActivityType = "vault_exported"
 	ActivityTypeItemCreated    ActivityType = "item_created"
 	ActivityTypeItemUpdated    ActivityType = "item_updated"
 	ActivityTypeItemDeleted    ActivityType = "item_deleted"
+	ActivityTypeItemsBulk      ActivityType = "items_bulk"
 	ActivityTypeFailedSignIn   ActivityType = "failed_signin"
 
 	// Admin / Audit activities (admin-only visibility in UI)
 	ActivityTypeAdminUserCreated ActivityType = "admin_user_created"
 	ActivityTypeAdminUserUpdated ActivityType = "admin_user_updated"
 	ActivityTypeAdminUserDeleted ActivityType = "admin_user_deleted"
+	ActivityTypeAdminUsersExport ActivityType = "admin_users_exported"
 
 	// Billing & Subscription Activities
 	ActivityTypeCheckoutCreated         ActivityType = "checkout_created"
@@ -35,6 +38,14 @@ const (
 	ActivityTypeInvoicePaymentFailed    ActivityType = "invoice_payment_failed"
 	ActivityTypeOrganizationUpgraded    ActivityType = "organization_upgraded"
 	ActivityTypeOrganizationDowngraded  ActivityType = "organization_downgraded"
+	ActivityTypePromoCodeRedeemed       ActivityType = "promo_code_redeemed"
+	ActivityTypeTrialExtended           ActivityType = "trial_extended"
+	ActivityTypeDunningReminderSent     ActivityType = "dunning_reminder_sent"
+	ActivityTypeDunningReadOnly         ActivityType = "dunning_read_only"
+	ActivityTypeDunningExpired          ActivityType = "dunning_expired"
+	ActivityTypeSeatsAdjusted           ActivityType = "seats_adjusted"
+	ActivityTypeSeatLimitReached        ActivityType = "seat_limit_reached"
+	ActivityTypeSeatReleased            ActivityType = "seat_released"
 
 	// Organization & structure
 	ActivityTypeOrganizationCreated ActivityType = "organization_created"
@@ -49,17 +60,29 @@ const (
 	ActivityTypeFolderUpdated       ActivityType = "folder_updated"
 	ActivityTypeFolderDeleted       ActivityType = "folder_deleted"
 
+	// Temporary collection access requests
+	ActivityTypeCollectionAccessRequested ActivityType = "collection_access_requested"
+	ActivityTypeCollectionAccessApproved  ActivityType = "collection_access_approved"
+	ActivityTypeCollectionAccessDenied    ActivityType = "collection_access_denied"
+	ActivityTypeCollectionAccessCancelled ActivityType = "collection_access_cancelled"
+	ActivityTypeCollectionAccessExpired   ActivityType = "collection_access_expired"
+
 	// Members & invitations
 	ActivityTypeMemberInvited      ActivityType = "member_invited"
 	ActivityTypeMemberJoined       ActivityType = "member_joined"
 	ActivityTypeMemberRemoved      ActivityType = "member_removed"
 	ActivityTypeMemberRoleChanged  ActivityType = "member_role_changed"
+	ActivityTypeMemberSuspended    ActivityType = "member_suspended"
+	ActivityTypeMemberRestored     ActivityType = "member_restored"
 	ActivityTypeInvitationSent     ActivityType = "invitation_sent"
 	ActivityTypeInvitationAccepted ActivityType = "invitation_accepted"
 
 	// Secure Send
 	ActivityTypeSendCreated  ActivityType = "send_created"
 	ActivityTypeSendAccessed ActivityType = "send_accessed"
+
+	// Licensing
+	ActivityTypeLicenseUpdated ActivityType = "license_updated"
 )
 
 // UserActivity represents user activity log for audit trail
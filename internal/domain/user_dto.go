@@ -48,6 +48,7 @@ func ToUserDTO(user *User) *UserDTO {
 		ItemCount:     user.ItemCount,
 		KdfType:       user.KdfType,
 		KdfIterations: user.KdfIterations,
+		LastSignInAt:  user.LastSignInAt,
 	}
 }
 
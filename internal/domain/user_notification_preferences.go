@@ -16,6 +16,9 @@ type UserNotificationPreferences struct {
 	// Security emails are mandatory and should not be disabled.
 	SecurityEmails bool `json:"security_emails" gorm:"not null;default:true"`
 
+	// SecurityDigestEmails lets organization admins unsubscribe from the weekly security digest.
+	SecurityDigestEmails bool `json:"security_digest_emails" gorm:"not null;default:true"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -26,10 +29,11 @@ func (UserNotificationPreferences) TableName() string {
 
 // UserNotificationPreferencesDTO is the API response shape.
 type UserNotificationPreferencesDTO struct {
-	CommunicationEmails bool `json:"communication_emails"`
-	MarketingEmails     bool `json:"marketing_emails"`
-	SocialEmails        bool `json:"social_emails"`
-	SecurityEmails      bool `json:"security_emails"`
+	CommunicationEmails  bool `json:"communication_emails"`
+	MarketingEmails      bool `json:"marketing_emails"`
+	SocialEmails         bool `json:"social_emails"`
+	SecurityEmails       bool `json:"security_emails"`
+	SecurityDigestEmails bool `json:"security_digest_emails"`
 }
 
 func ToUserNotificationPreferencesDTO(p *UserNotificationPreferences) *UserNotificationPreferencesDTO {
@@ -38,18 +42,20 @@ func ToUserNotificationPreferencesDTO(p *UserNotificationPreferences) *UserNotif
 	}
 
 	return &UserNotificationPreferencesDTO{
-		CommunicationEmails: p.CommunicationEmails,
-		MarketingEmails:     p.MarketingEmails,
-		SocialEmails:        p.SocialEmails,
-		SecurityEmails:      p.SecurityEmails,
+		CommunicationEmails:  p.CommunicationEmails,
+		MarketingEmails:      p.MarketingEmails,
+		SocialEmails:         p.SocialEmails,
+		SecurityEmails:       p.SecurityEmails,
+		SecurityDigestEmails: p.SecurityDigestEmails,
 	}
 }
 
 // UpdateUserNotificationPreferencesRequest supports partial updates.
 // (Booleans are pointers so "unset" can be distinguished from false.)
 type UpdateUserNotificationPreferencesRequest struct {
-	CommunicationEmails *bool `json:"communication_emails"`
-	MarketingEmails     *bool `json:"marketing_emails"`
-	SocialEmails        *bool `json:"social_emails"`
-	SecurityEmails      *bool `json:"security_emails"`
+	CommunicationEmails  *bool `json:"communication_emails"`
+	MarketingEmails      *bool `json:"marketing_emails"`
+	SocialEmails         *bool `json:"social_emails"`
+	SecurityEmails       *bool `json:"security_emails"`
+	SecurityDigestEmails *bool `json:"security_digest_emails"`
 }
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VaultExportVersion is the current version of the vault export bundle format.
+// Bump it whenever the bundle layout changes so clients can pick the right importer.
+const VaultExportVersion = 1
+
+// VaultExportMode describes which key protects the exported user key
+type VaultExportMode string
+
+const (
+	// VaultExportModeAccount keeps the user key wrapped by the account master key
+	// (decrypt with the master password and the account KDF settings)
+	VaultExportModeAccount VaultExportMode = "account"
+	// VaultExportModePassword re-wraps the user key under a key derived from an export password
+	// (decrypt with the export password and the KDF settings in the bundle)
+	VaultExportModePassword VaultExportMode = "password"
+)
+
+// VaultExport is the versioned export bundle. Everything except names and
+// metadata stays ciphertext: items are encrypted with the user key (personal)
+// or the organization key, and each organization key is wrapped for the user.
+type VaultExport struct {
+	Version       int                        `json:"version"`
+	ExportedAt    time.Time                  `json:"exported_at"`
+	Encryption    VaultExportEncryption      `json:"encryption"`
+	Items         []*VaultExportItem         `json:"items"`
+	Organizations []*VaultExportOrganization `json:"organizations"`
+}
+
+// VaultExportEncryption tells the client how to recover the user key
+type VaultExportEncryption struct {
+	Mode           VaultExportMode `json:"mode"`
+	KdfType        KdfType         `json:"kdf_type"`
+	KdfIterations  int             `json:"kdf_iterations"`
+	KdfMemory      *int            `json:"kdf_memory,omitempty"`
+	KdfParallelism *int            `json:"kdf_parallelism,omitempty"`
+	KdfSalt        string          `json:"kdf_salt"`
+	// EncryptedUserKey is the user key wrapped by the master key or the export password key (EncString)
+	EncryptedUserKey string `json:"encrypted_user_key"`
+	// EncryptedPrivateKey is the RSA private key wrapped by the user key; it unwraps organization keys
+	EncryptedPrivateKey *string `json:"encrypted_private_key,omitempty"`
+}
+
+// VaultExportItem is a personal vault item (Data encrypted with the user key)
+type VaultExportItem struct {
+	UUID       uuid.UUID    `json:"uuid"`
+	ItemType   ItemType     `json:"item_type"`
+	Data       string       `json:"data"`
+	ItemKeyEnc *string      `json:"item_key_enc,omitempty"`
+	Metadata   ItemMetadata `json:"metadata"`
+	IsFavorite bool         `json:"is_favorite"`
+	FolderID   *uint        `json:"folder_id,omitempty"`
+	Reprompt   bool         `json:"reprompt"`
+	AutoFill   bool         `json:"auto_fill"`
+	AutoLogin  bool         `json:"auto_login"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+	ArchivedAt *time.Time   `json:"archived_at,omitempty"`
+}
+
+// VaultExportOrganization groups the folders and items of one organization
+type VaultExportOrganization struct {
+	Organization VaultExportOrganizationInfo `json:"organization"`
+	Folders      []*OrganizationFolderDTO    `json:"folders"`
+	Items        []*VaultExportOrgItem       `json:"items"`
+}
+
+// VaultExportOrganizationInfo identifies an organization and carries its key wrapped for the user
+type VaultExportOrganizationInfo struct {
+	ID         uint      `json:"id"`
+	UUID       uuid.UUID `json:"uuid"`
+	Name       string    `json:"name"`
+	IsPersonal bool      `json:"is_personal"`
+	Role       string    `json:"role"`
+	// EncryptedOrgKey is the organization key wrapped for the exporting user
+	EncryptedOrgKey string `json:"encrypted_org_key"`
+}
+
+// VaultExportOrgItem is an organization item (Data encrypted with the organization key)
+type VaultExportOrgItem struct {
+	UUID          uuid.UUID    `json:"uuid"`
+	CollectionID  *uint        `json:"collection_id,omitempty"`
+	CollectionIDs []uint       `json:"collection_ids,omitempty"`
+	ItemType      ItemType     `json:"item_type"`
+	Data          string       `json:"data"`
+	Metadata      ItemMetadata `json:"metadata"`
+	IsFavorite    bool         `json:"is_favorite"`
+	FolderID      *uint        `json:"folder_id,omitempty"`
+	Reprompt      bool         `json:"reprompt"`
+	AutoFill      bool         `json:"auto_fill"`
+	AutoLogin     bool         `json:"auto_login"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+	ArchivedAt    *time.Time   `json:"archived_at,omitempty"`
+}
+
+// ToVaultExportItem converts a personal item for export
+func ToVaultExportItem(item *Item) *VaultExportItem {
+	return &VaultExportItem{
+		UUID:       item.UUID,
+		ItemType:   item.ItemType,
+		Data:       item.Data,
+		ItemKeyEnc: item.ItemKeyEnc,
+		Metadata:   item.Metadata,
+		IsFavorite: item.IsFavorite,
+		FolderID:   item.FolderID,
+		Reprompt:   item.Reprompt,
+		AutoFill:   item.AutoFill,
+		AutoLogin:  item.AutoLogin,
+		CreatedAt:  item.CreatedAt,
+		UpdatedAt:  item.UpdatedAt,
+		ArchivedAt: item.ArchivedAt,
+	}
+}
+
+// ToVaultExportOrgItem converts an organization item for export
+func ToVaultExportOrgItem(item *OrganizationItem) *VaultExportOrgItem {
+	return &VaultExportOrgItem{
+		UUID:          item.UUID,
+		CollectionID:  item.PrimaryCollectionID(),
+		CollectionIDs: item.CollectionIDs,
+		ItemType:      item.ItemType,
+		Data:          item.Data,
+		Metadata:      item.Metadata,
+		IsFavorite:    item.IsFavorite,
+		FolderID:      item.FolderID,
+		Reprompt:      item.Reprompt,
+		AutoFill:      item.AutoFill,
+		AutoLogin:     item.AutoLogin,
+		CreatedAt:     item.CreatedAt,
+		UpdatedAt:     item.UpdatedAt,
+		ArchivedAt:    item.ArchivedAt,
+	}
+}
+
+// ── DTOs ────────────────────────────────────────────────────
+
+// VaultExportPasswordKey is supplied by the client for password-protected exports.
+// The client derives the export key from the export password and wraps the user
+// key with it; the password itself never reaches the server.
+type VaultExportPasswordKey struct {
+	KdfType          KdfType `json:"kdf_type"`
+	KdfIterations    int     `json:"kdf_iterations"`
+	KdfMemory        *int    `json:"kdf_memory,omitempty"`
+	KdfParallelism   *int    `json:"kdf_parallelism,omitempty"`
+	KdfSalt          string  `json:"kdf_salt"`
+	EncryptedUserKey string  `json:"encrypted_user_key"`
+}
+
+// Minimum KDF cost accepted for export password keys
+const (
+	minExportPBKDF2Iterations = 100000
+	minExportArgon2Iterations = 2
+)
+
+// Validate checks that the password key is complete and uses a sane KDF cost
+func (k *VaultExportPasswordKey) Validate() error {
+	switch k.KdfType {
+	case KdfTypePBKDF2:
+		if k.KdfIterations < minExportPBKDF2Iterations {
+			return errors.New("kdf_iterations is too low for PBKDF2")
+		}
+	case KdfTypeArgon2id:
+		if k.KdfIterations < minExportArgon2Iterations {
+			return errors.New("kdf_iterations is too low for Argon2id")
+		}
+		if k.KdfMemory == nil || *k.KdfMemory <= 0 || k.KdfParallelism == nil || *k.KdfParallelism <= 0 {
+			return errors.New("kdf_memory and kdf_parallelism are required for Argon2id")
+		}
+	default:
+		return errors.New("unsupported kdf_type")
+	}
+
+	salt, err := hex.DecodeString(k.KdfSalt)
+	if err != nil || len(salt) < 16 {
+		return errors.New("kdf_salt must be at least 16 hex-encoded bytes")
+	}
+
+	if !strings.HasPrefix(k.EncryptedUserKey, "2.") || strings.Count(k.EncryptedUserKey, "|") != 2 {
+		return errors.New("encrypted_user_key must be an EncString")
+	}
+	return nil
+}
+
+// VaultExportSummary describes what an export contained (recorded in the activity log)
+type VaultExportSummary struct {
+	Mode              VaultExportMode `json:"mode"`
+	PersonalItems     int             `json:"personal_items"`
+	Organizations     int             `json:"organizations"`
+	OrganizationItems int             `json:"organization_items"`
+	HiddenItems       int             `json:"hidden_items"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVaultExportPasswordKeyValidate(t *testing.T) {
+	salt := strings.Repeat("ab", 32)
+	mem, par := 64, 4
+	zero := 0
+
+	tests := []struct {
+		name    string
+		key     VaultExportPasswordKey
+		wantErr bool
+	}{
+		{"pbkdf2", VaultExportPasswordKey{KdfType: KdfTypePBKDF2, KdfIterations: 600000, KdfSalt: salt, EncryptedUserKey: "2.iv|ct|mac"}, false},
+		{"argon2id", VaultExportPasswordKey{KdfType: KdfTypeArgon2id, KdfIterations: 3, KdfMemory: &mem, KdfParallelism: &par, KdfSalt: salt, EncryptedUserKey: "2.iv|ct|mac"}, false},
+		{"weak pbkdf2", VaultExportPasswordKey{KdfType: KdfTypePBKDF2, KdfIterations: 1000, KdfSalt: salt, EncryptedUserKey: "2.iv|ct|mac"}, true},
+		{"argon2id without memory", VaultExportPasswordKey{KdfType: KdfTypeArgon2id, KdfIterations: 3, KdfMemory: &zero, KdfParallelism: &par, KdfSalt: salt, EncryptedUserKey: "2.iv|ct|mac"}, true},
+		{"unknown kdf", VaultExportPasswordKey{KdfType: 7, KdfIterations: 600000, KdfSalt: salt, EncryptedUserKey: "2.iv|ct|mac"}, true},
+		{"short salt", VaultExportPasswordKey{KdfType: KdfTypePBKDF2, KdfIterations: 600000, KdfSalt: "abcd", EncryptedUserKey: "2.iv|ct|mac"}, true},
+		{"plaintext key", VaultExportPasswordKey{KdfType: KdfTypePBKDF2, KdfIterations: 600000, KdfSalt: salt, EncryptedUserKey: "secret"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.key.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	// WeakPasswordScore is the highest strength score (zxcvbn scale, 0-4) still reported as weak
+	WeakPasswordScore = 2
+	// StalePasswordDays is how long a password may go unchanged before it is reported as stale
+	StalePasswordDays = 365
+	// VaultHealthTrendDays is the default window of daily snapshots returned with a report
+	VaultHealthTrendDays = 90
+)
+
+// ItemHealthSignal holds the security signals a client computed for one organization
+// item. The server never sees the password: PasswordHash is a keyed hash computed by
+// the client with key material derived from the organization key, so equal hashes
+// mean a password is reused within the organization and nothing more.
+type ItemHealthSignal struct {
+	ID        uint      `gorm:"primary_key" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrganizationID     uint `json:"organization_id" gorm:"not null;index"`
+	OrganizationItemID uint `json:"organization_item_id" gorm:"not null;uniqueIndex"`
+	ReportedByUserID   uint `json:"reported_by_user_id" gorm:"not null"`
+
+	PasswordHash       string     `json:"-" gorm:"type:varchar(128);not null;index"`
+	StrengthScore      int        `json:"strength_score" gorm:"not null;default:0"`
+	Exposed            bool       `json:"exposed" gorm:"not null;default:false"`
+	TwoFactorAvailable bool       `json:"two_factor_available" gorm:"not null;default:false"`
+	TwoFactorEnabled   bool       `json:"two_factor_enabled" gorm:"not null;default:false"`
+	PasswordChangedAt  *time.Time `json:"password_changed_at,omitempty"`
+}
+
+// TableName specifies the table name
+func (ItemHealthSignal) TableName() string {
+	return "item_health_signals"
+}
+
+// IsWeak reports whether the password scored at or below WeakPasswordScore
+func (s *ItemHealthSignal) IsWeak() bool {
+	return s.StrengthScore <= WeakPasswordScore
+}
+
+// VaultHealthSnapshot is the health of an organization, or of one of its collections,
+// on a given day. Snapshots are refreshed whenever signals are submitted and give
+// reports their trend. CollectionID 0 is the organization-wide snapshot.
+type VaultHealthSnapshot struct {
+	ID        uint      `gorm:"primary_key" json:"-"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+
+	OrganizationID uint      `json:"-" gorm:"not null;uniqueIndex:idx_vault_health_snapshot"`
+	CollectionID   uint      `json:"-" gorm:"not null;default:0;uniqueIndex:idx_vault_health_snapshot"`
+	Date           time.Time `json:"date" gorm:"type:date;not null;uniqueIndex:idx_vault_health_snapshot"`
+
+	VaultHealthCounts
+}
+
+// TableName specifies the table name
+func (VaultHealthSnapshot) TableName() string {
+	return "vault_health_snapshots"
+}
+
+// VaultHealthCounts are the aggregated counts of a health report
+type VaultHealthCounts struct {
+	TotalItems    int `json:"total_items" gorm:"not null;default:0"`
+	ReportedItems int `json:"reported_items" gorm:"not null;default:0"`
+	Weak          int `json:"weak" gorm:"not null;default:0"`
+	Reused        int `json:"reused" gorm:"not null;default:0"`
+	Exposed       int `json:"exposed" gorm:"not null;default:0"`
+	Stale         int `json:"stale" gorm:"not null;default:0"`
+	Missing2FA    int `json:"missing_2fa" gorm:"column:missing_2fa;not null;default:0"` // 2FA available but not enabled
+}
+
+// ItemHealthSignalInput is one item's signals in a submission
+type ItemHealthSignalInput struct {
+	ItemUUID           string     `json:"item_uuid" binding:"required,uuid"`
+	PasswordHash       string     `json:"password_hash" binding:"required,max=128"`
+	StrengthScore      int        `json:"strength_score" binding:"min=0,max=4"`
+	Exposed            bool       `json:"exposed"`
+	TwoFactorAvailable bool       `json:"two_factor_available"`
+	TwoFactorEnabled   bool       `json:"two_factor_enabled"`
+	PasswordChangedAt  *time.Time `json:"password_changed_at,omitempty"`
+}
+
+// SubmitItemHealthSignalsRequest is the payload for submitting item signals
+type SubmitItemHealthSignalsRequest struct {
+	Items []ItemHealthSignalInput `json:"items" binding:"required,min=1,max=1000,dive"`
+}
+
+// VaultHealthIssueDTO lists an item with at least one health issue
+type VaultHealthIssueDTO struct {
+	ItemUUID      string   `json:"item_uuid"`
+	CollectionIDs []uint   `json:"collection_ids"`
+	Issues        []string `json:"issues"` // weak, reused, exposed, stale, missing_2fa
+}
+
+// VaultHealthReportDTO is the health report of an organization or collection
+type VaultHealthReportDTO struct {
+	OrganizationID uint  `json:"organization_id"`
+	CollectionID   *uint `json:"collection_id,omitempty"`
+	VaultHealthCounts
+	Items       []VaultHealthIssueDTO `json:"items"`
+	Trend       []VaultHealthSnapshot `json:"trend"`
+	GeneratedAt time.Time             `json:"generated_at"`
+}
@@ -7,16 +7,52 @@ import (
 	"time"
 )
 
-// WebhookEvent represents a Stripe webhook event for idempotency
+// WebhookProvider identifies the billing provider that sent a webhook
+type WebhookProvider string
+
+const (
+	WebhookProviderStripe     WebhookProvider = "stripe"
+	WebhookProviderRevenueCat WebhookProvider = "revenuecat"
+)
+
+// WebhookEventStatus represents the processing state of an inbox event
+type WebhookEventStatus string
+
+const (
+	WebhookStatusPending    WebhookEventStatus = "pending"    // Received, not processed yet
+	WebhookStatusProcessing WebhookEventStatus = "processing" // Claimed by a processor (lease until NextAttemptAt)
+	WebhookStatusProcessed  WebhookEventStatus = "processed"  // Processed successfully
+	WebhookStatusFailed     WebhookEventStatus = "failed"     // Failed, will be retried at NextAttemptAt
+	WebhookStatusDead       WebhookEventStatus = "dead"       // Retries exhausted, needs a manual replay
+)
+
+// Webhook retry policy
+const (
+	WebhookMaxAttempts     = 8
+	WebhookRetryBaseDelay  = time.Minute
+	WebhookRetryMaxDelay   = 6 * time.Hour
+	WebhookProcessingLease = 5 * time.Minute
+	webhookErrorMaxLength  = 2000
+)
+
+// WebhookEvent is an inbox record of a verified Stripe or RevenueCat webhook.
+// (provider, event_id) is unique, so redelivered events are recognized and processed once.
 type WebhookEvent struct {
 	ID        uint      `gorm:"primary_key" json:"id"`
 	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Provider  WebhookProvider `json:"provider" gorm:"type:varchar(20);not null;default:'stripe';uniqueIndex:idx_webhook_events_provider_event"`
+	EventID   string          `json:"event_id" gorm:"type:varchar(255);not null;uniqueIndex:idx_webhook_events_provider_event"`
+	EventType string          `json:"event_type" gorm:"type:varchar(100);not null;index"`
+	Payload   WebhookPayload  `json:"payload,omitempty" gorm:"type:jsonb;not null"`
 
-	StripeEventID string         `json:"stripe_event_id" gorm:"type:varchar(255);uniqueIndex;not null"`
-	EventType     string         `json:"event_type" gorm:"type:varchar(100);not null"`
-	Payload       WebhookPayload `json:"payload" gorm:"type:jsonb;not null"`
-	ProcessedAt   *time.Time     `json:"processed_at,omitempty"`
-	Error         *string        `json:"error,omitempty" gorm:"type:text"`
+	Status        WebhookEventStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	Attempts      int                `json:"attempts" gorm:"not null;default:0"`
+	LastAttemptAt *time.Time         `json:"last_attempt_at,omitempty"`
+	NextAttemptAt *time.Time         `json:"next_attempt_at,omitempty" gorm:"index"`
+	ProcessedAt   *time.Time         `json:"processed_at,omitempty"`
+	Error         *string            `json:"error,omitempty" gorm:"type:text"`
 }
 
 // TableName specifies the table name
@@ -24,6 +60,21 @@ func (WebhookEvent) TableName() string {
 	return "webhook_events"
 }
 
+// WebhookRetryDelay returns the backoff before the next attempt after the given number of attempts
+func WebhookRetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	delay := WebhookRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= WebhookRetryMaxDelay {
+			return WebhookRetryMaxDelay
+		}
+	}
+	return delay
+}
+
 // WebhookPayload represents the webhook payload
 type WebhookPayload json.RawMessage
 
@@ -34,9 +85,9 @@ func (p *WebhookPayload) Scan(value interface{}) error {
 		return nil
 	}
 
-	bytes, ok := value.([]byte)
+	bytes, ok := jsonBytes(value)
 	if !ok {
-		return fmt.Errorf("failed to scan WebhookPayload: expected []byte, got %T", value)
+		return fmt.Errorf("failed to scan WebhookPayload: expected []byte or string, got %T", value)
 	}
 
 	*p = bytes
@@ -53,7 +104,7 @@ func (p WebhookPayload) Value() (driver.Value, error) {
 
 // IsProcessed checks if webhook event has been processed
 func (w *WebhookEvent) IsProcessed() bool {
-	return w.ProcessedAt != nil
+	return w.Status == WebhookStatusProcessed
 }
 
 // HasError checks if webhook processing failed
@@ -62,14 +113,65 @@ func (w *WebhookEvent) HasError() bool {
 }
 
 // MarkProcessed marks the webhook as successfully processed
-func (w *WebhookEvent) MarkProcessed() {
-	now := time.Now()
+func (w *WebhookEvent) MarkProcessed(now time.Time) {
+	w.Status = WebhookStatusProcessed
 	w.ProcessedAt = &now
+	w.NextAttemptAt = nil
 	w.Error = nil
 }
 
-// MarkFailed marks the webhook as failed with an error
-func (w *WebhookEvent) MarkFailed(err error) {
+// MarkFailed records a failed attempt and schedules the next retry with backoff.
+// After WebhookMaxAttempts the event is dead and only a manual replay processes it again.
+func (w *WebhookEvent) MarkFailed(err error, now time.Time) {
 	errStr := err.Error()
+	if len(errStr) > webhookErrorMaxLength {
+		errStr = errStr[:webhookErrorMaxLength]
+	}
 	w.Error = &errStr
+
+	if w.Attempts >= WebhookMaxAttempts {
+		w.Status = WebhookStatusDead
+		w.NextAttemptAt = nil
+		return
+	}
+	w.Status = WebhookStatusFailed
+	next := now.Add(WebhookRetryDelay(w.Attempts))
+	w.NextAttemptAt = &next
+}
+
+// ── DTOs ────────────────────────────────────────────────────
+
+// WebhookEventDTO is the admin list representation of an inbox event (without payload)
+type WebhookEventDTO struct {
+	ID            uint               `json:"id"`
+	Provider      WebhookProvider    `json:"provider"`
+	EventID       string             `json:"event_id"`
+	EventType     string             `json:"event_type"`
+	Status        WebhookEventStatus `json:"status"`
+	Attempts      int                `json:"attempts"`
+	LastAttemptAt *time.Time         `json:"last_attempt_at,omitempty"`
+	NextAttemptAt *time.Time         `json:"next_attempt_at,omitempty"`
+	ProcessedAt   *time.Time         `json:"processed_at,omitempty"`
+	Error         *string            `json:"error,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+// ToWebhookEventDTO converts a webhook event to its list DTO
+func ToWebhookEventDTO(w *WebhookEvent) *WebhookEventDTO {
+	if w == nil {
+		return nil
+	}
+	return &WebhookEventDTO{
+		ID:            w.ID,
+		Provider:      w.Provider,
+		EventID:       w.EventID,
+		EventType:     w.EventType,
+		Status:        w.Status,
+		Attempts:      w.Attempts,
+		LastAttemptAt: w.LastAttemptAt,
+		NextAttemptAt: w.NextAttemptAt,
+		ProcessedAt:   w.ProcessedAt,
+		Error:         w.Error,
+		CreatedAt:     w.CreatedAt,
+	}
 }
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Minute},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{4, 8 * time.Minute},
+		{20, WebhookRetryMaxDelay},
+	}
+
+	for _, tt := range tests {
+		if got := WebhookRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("WebhookRetryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookEvent_MarkFailed(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	event := &WebhookEvent{Status: WebhookStatusProcessing, Attempts: 2}
+	event.MarkFailed(errors.New("boom"), now)
+	if event.Status != WebhookStatusFailed {
+		t.Errorf("Status = %q, want %q", event.Status, WebhookStatusFailed)
+	}
+	if event.NextAttemptAt == nil || !event.NextAttemptAt.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("NextAttemptAt = %v, want %v", event.NextAttemptAt, now.Add(2*time.Minute))
+	}
+
+	event.Attempts = WebhookMaxAttempts
+	event.MarkFailed(errors.New("boom"), now)
+	if event.Status != WebhookStatusDead || event.NextAttemptAt != nil {
+		t.Errorf("expected dead event without retry, got status %q next %v", event.Status, event.NextAttemptAt)
+	}
+
+	event.MarkProcessed(now)
+	if !event.IsProcessed() || event.HasError() || event.ProcessedAt == nil {
+		t.Errorf("expected processed event without error, got %+v", event)
+	}
+}
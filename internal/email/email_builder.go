@@ -67,7 +67,7 @@ func (b *EmailBuilder) BuildVerificationEmail(to, name, code, signupSource strin
 }
 
 // BuildInvitationEmail builds an invitation email message
-func (b *EmailBuilder) BuildInvitationEmail(to, inviterName, code, role string) (*EmailMessage, error) {
+func (b *EmailBuilder) BuildInvitationEmail(to, inviterName, code, role string, expiry time.Duration) (*EmailMessage, error) {
 	if to == "" {
 		return nil, fmt.Errorf("recipient email is required")
 	}
@@ -77,7 +77,7 @@ func (b *EmailBuilder) BuildInvitationEmail(to, inviterName, code, role string)
 	}
 
 	// Build template data
-	data, err := BuildInvitationEmail(b.frontendURL, to, inviterName, code, role)
+	data, err := BuildInvitationEmail(b.frontendURL, to, inviterName, code, role, expiry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build template data: %w", err)
 	}
@@ -97,7 +97,7 @@ func (b *EmailBuilder) BuildInvitationEmail(to, inviterName, code, role string)
 }
 
 // BuildInvitationWithOrgEmail builds an invitation email with organization info
-func (b *EmailBuilder) BuildInvitationWithOrgEmail(to, inviterName, code, role, orgName string) (*EmailMessage, error) {
+func (b *EmailBuilder) BuildInvitationWithOrgEmail(to, inviterName, code, role, orgName string, expiry time.Duration) (*EmailMessage, error) {
 	if to == "" {
 		return nil, fmt.Errorf("recipient email is required")
 	}
@@ -107,7 +107,7 @@ func (b *EmailBuilder) BuildInvitationWithOrgEmail(to, inviterName, code, role,
 	}
 
 	// Build template data
-	data, err := BuildInvitationEmailWithOrg(b.frontendURL, to, inviterName, code, role, orgName)
+	data, err := BuildInvitationEmailWithOrg(b.frontendURL, to, inviterName, code, role, orgName, expiry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build template data: %w", err)
 	}
@@ -330,6 +330,36 @@ func (b *EmailBuilder) BuildSecureSendNotifyEmail(to, senderName, sendName, send
 	}, nil
 }
 
+// BuildSecureSendAccessCodeEmail builds the one-time code email for a recipient-restricted send
+func (b *EmailBuilder) BuildSecureSendAccessCodeEmail(to, senderName, sendName, code string, expiry time.Duration) (*EmailMessage, error) {
+	if to == "" {
+		return nil, fmt.Errorf("recipient email is required")
+	}
+	if code == "" {
+		return nil, fmt.Errorf("access code is required")
+	}
+
+	data := &TemplateData{
+		Code:           code,
+		ExpiryTime:     fmt.Sprintf("%d minutes", int(expiry.Minutes())),
+		SendSenderName: senderName,
+		SendName:       sendName,
+		Year:           currentYear(),
+	}
+
+	htmlBody, err := b.templateManager.Render(TemplateSendAccessCode, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render send access code template: %w", err)
+	}
+
+	return &EmailMessage{
+		To:      to,
+		From:    b.defaultFrom,
+		Subject: fmt.Sprintf("Your Secure Send access code: %s", code),
+		Body:    htmlBody,
+	}, nil
+}
+
 // BuildRecoveryDeleteRequestEmail builds the account deletion confirmation email
 func (b *EmailBuilder) BuildRecoveryDeleteRequestEmail(to, deleteURL string) (*EmailMessage, error) {
 	if to == "" {
@@ -383,6 +413,154 @@ func (b *EmailBuilder) BuildRecoveryDeleteCompleteEmail(to string) (*EmailMessag
 	}, nil
 }
 
+// BuildDunningEmail builds a past_due follow-up email for an organization's billing contacts.
+// stage is one of "reminder", "read_only" or "expired".
+func (b *EmailBuilder) BuildDunningEmail(to string, orgID uint, orgName, stage string, readOnlyAt, expiresAt time.Time) (*EmailMessage, error) {
+	if to == "" {
+		return nil, fmt.Errorf("recipient email is required")
+	}
+
+	data := &TemplateData{
+		OrganizationName: orgName,
+		DunningStage:     stage,
+		BillingURL:       fmt.Sprintf("%s/organizations/%d/billing", b.frontendURL, orgID),
+		ReadOnlyDate:     readOnlyAt.Format("January 2, 2006"),
+		ExpiryDate:       expiresAt.Format("January 2, 2006"),
+		Year:             currentYear(),
+	}
+
+	htmlBody, err := b.templateManager.Render(TemplateDunningNotice, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render dunning-notice template: %w", err)
+	}
+
+	var subject string
+	switch stage {
+	case "expired":
+		subject = fmt.Sprintf("Your Passwall subscription for %s has expired", orgName)
+	case "read_only":
+		subject = fmt.Sprintf("Action required: %s is now read-only", orgName)
+	default:
+		subject = fmt.Sprintf("Action required: payment failed for %s", orgName)
+	}
+
+	return &EmailMessage{
+		To:      to,
+		From:    b.defaultFrom,
+		Subject: subject,
+		Body:    htmlBody,
+	}, nil
+}
+
+// BuildSuspendedRemovalEmail warns an organization admin that a suspended member will be removed.
+func (b *EmailBuilder) BuildSuspendedRemovalEmail(to string, orgID uint, orgName, memberEmail string, suspendedAt, removalAt time.Time) (*EmailMessage, error) {
+	if to == "" {
+		return nil, fmt.Errorf("recipient email is required")
+	}
+
+	data := &TemplateData{
+		OrganizationName: orgName,
+		UserEmail:        memberEmail,
+		MembersURL:       fmt.Sprintf("%s/organizations/%d/members", b.frontendURL, orgID),
+		SuspendedDate:    suspendedAt.Format("January 2, 2006"),
+		RemovalDate:      removalAt.Format("January 2, 2006"),
+		Year:             currentYear(),
+	}
+
+	htmlBody, err := b.templateManager.Render(TemplateSuspendedRemoval, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render suspended-removal template: %w", err)
+	}
+
+	return &EmailMessage{
+		To:      to,
+		From:    b.defaultFrom,
+		Subject: fmt.Sprintf("%s will be removed from %s", memberEmail, orgName),
+		Body:    htmlBody,
+	}, nil
+}
+
+// BuildCollectionAccessEmail builds a notification about a temporary collection access request.
+// stage is one of "requested" (to reviewers), "approved", "denied" or "expired".
+func (b *EmailBuilder) BuildCollectionAccessEmail(to string, orgID uint, orgName, collectionName, stage, requesterEmail, justification, reviewNote string, expiresAt *time.Time) (*EmailMessage, error) {
+	if to == "" {
+		return nil, fmt.Errorf("recipient email is required")
+	}
+
+	data := &TemplateData{
+		OrganizationName:  orgName,
+		CollectionName:    collectionName,
+		AccessStage:       stage,
+		UserEmail:         requesterEmail,
+		Justification:     justification,
+		ReviewNote:        reviewNote,
+		AccessRequestsURL: fmt.Sprintf("%s/organizations/%d/access-requests", b.frontendURL, orgID),
+		Year:              currentYear(),
+	}
+	if expiresAt != nil {
+		data.ExpiryDate = expiresAt.UTC().Format("January 2, 2006 15:04 MST")
+	}
+
+	htmlBody, err := b.templateManager.Render(TemplateCollectionAccess, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render collection-access template: %w", err)
+	}
+
+	var subject string
+	switch stage {
+	case "requested":
+		subject = fmt.Sprintf("%s requested access to %s", requesterEmail, collectionName)
+	case "approved":
+		subject = fmt.Sprintf("Your access to %s was approved", collectionName)
+	case "denied":
+		subject = fmt.Sprintf("Your access to %s was denied", collectionName)
+	default:
+		subject = fmt.Sprintf("Temporary access to %s has ended", collectionName)
+	}
+
+	return &EmailMessage{
+		To:      to,
+		From:    b.defaultFrom,
+		Subject: subject,
+		Body:    htmlBody,
+	}, nil
+}
+
+// BuildShareExpiringEmail builds the notice sent to the owner and the recipient of an
+// item share shortly before it expires. audience is "owner" or "recipient".
+func (b *EmailBuilder) BuildShareExpiringEmail(to, audience, ownerName, recipientEmail, itemName string, expiresAt time.Time) (*EmailMessage, error) {
+	if to == "" {
+		return nil, fmt.Errorf("recipient email is required")
+	}
+
+	data := &TemplateData{
+		ShareAudience:    audience,
+		ShareInviterName: ownerName,
+		ShareRecipient:   recipientEmail,
+		ShareItemName:    itemName,
+		ShareSignInURL:   fmt.Sprintf("%s/sign-in?redirect=/shares", b.frontendURL),
+		ExpiryDate:       expiresAt.UTC().Format("January 2, 2006 15:04 MST"),
+		Year:             currentYear(),
+	}
+
+	htmlBody, err := b.templateManager.Render(TemplateShareExpiring, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render share-expiring template: %w", err)
+	}
+
+	subject := fmt.Sprintf("Your access to %s expires soon", itemName)
+	if audience == "owner" {
+		subject = fmt.Sprintf("Your share of %s expires soon", itemName)
+	}
+
+	return &EmailMessage{
+		To:      to,
+		From:    b.defaultFrom,
+		Subject: subject,
+		Body:    htmlBody,
+	}, nil
+}
+
 // BuildCustomEmail builds a custom email with provided subject and body
 func (b *EmailBuilder) BuildCustomEmail(to, subject, htmlBody string) (*EmailMessage, error) {
 	if to == "" {
@@ -404,3 +582,68 @@ func (b *EmailBuilder) BuildCustomEmail(to, subject, htmlBody string) (*EmailMes
 		Body:    htmlBody,
 	}, nil
 }
+
+// BuildSecurityDigestEmail builds the weekly security digest for an organization admin.
+func (b *EmailBuilder) BuildSecurityDigestEmail(to string, orgID uint, orgName string, digest *SecurityDigest) (*EmailMessage, error) {
+	if to == "" {
+		return nil, fmt.Errorf("recipient email is required")
+	}
+	if digest == nil {
+		return nil, fmt.Errorf("digest is required")
+	}
+
+	data := &TemplateData{
+		OrganizationName:        orgName,
+		Digest:                  digest,
+		SecurityDashboardURL:    fmt.Sprintf("%s/organizations/%d", b.frontendURL, orgID),
+		NotificationSettingsURL: fmt.Sprintf("%s/settings/notifications", b.frontendURL),
+		Year:                    currentYear(),
+	}
+
+	htmlBody, err := b.templateManager.Render(TemplateSecurityDigest, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render security-digest template: %w", err)
+	}
+
+	return &EmailMessage{
+		To:      to,
+		From:    b.defaultFrom,
+		Subject: fmt.Sprintf("Weekly security digest for %s", orgName),
+		Body:    htmlBody,
+	}, nil
+}
+
+// BuildBreachRotationEmail tells a user which vault items to rotate after their email address was found in breaches.
+func (b *EmailBuilder) BuildBreachRotationEmail(to string, breachedEmail string, breachNames, items []string) (*EmailMessage, error) {
+	if to == "" {
+		return nil, fmt.Errorf("recipient email is required")
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("at least one item is required")
+	}
+
+	data := &TemplateData{
+		UserEmail:   breachedEmail,
+		BreachNames: breachNames,
+		RotateItems: items,
+		VaultURL:    fmt.Sprintf("%s/vault", b.frontendURL),
+		Year:        currentYear(),
+	}
+
+	htmlBody, err := b.templateManager.Render(TemplateBreachRotation, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render breach-rotation template: %w", err)
+	}
+
+	subject := "A password to change after a data breach"
+	if len(items) > 1 {
+		subject = fmt.Sprintf("%d passwords to change after a data breach", len(items))
+	}
+
+	return &EmailMessage{
+		To:      to,
+		From:    b.defaultFrom,
+		Subject: subject,
+		Body:    htmlBody,
+	}, nil
+}
@@ -21,8 +21,15 @@ const (
 	TemplateEmergencyRecoveryReq   TemplateType = "emergency-recovery-request"
 	TemplateEmergencyRecoveryOK    TemplateType = "emergency-recovery-approved"
 	TemplateSendNotify             TemplateType = "send-notify"
+	TemplateSendAccessCode         TemplateType = "send-access-code"
 	TemplateRecoveryDeleteRequest  TemplateType = "recover-delete-request"
 	TemplateRecoveryDeleteComplete TemplateType = "recover-delete-complete"
+	TemplateDunningNotice          TemplateType = "dunning-notice"
+	TemplateSuspendedRemoval       TemplateType = "suspended-removal"
+	TemplateCollectionAccess       TemplateType = "collection-access"
+	TemplateShareExpiring          TemplateType = "share-expiring"
+	TemplateSecurityDigest         TemplateType = "security-digest"
+	TemplateBreachRotation         TemplateType = "breach-rotation"
 )
 
 // TemplateData holds data for email templates
@@ -42,6 +49,7 @@ type TemplateData struct {
 	ShareSignupURL   string
 	ShareSignInURL   string
 	ShareRecipient   string
+	ShareAudience    string // owner or recipient, for share expiry notices
 	// Emergency access fields
 	GrantorName  string
 	GranteeName  string
@@ -53,6 +61,51 @@ type TemplateData struct {
 	// Recover-delete fields
 	DeleteURL string
 	UserEmail string
+	// Dunning fields
+	DunningStage string // reminder, read_only or expired
+	BillingURL   string
+	ReadOnlyDate string
+	ExpiryDate   string
+	// Suspended member removal fields
+	MembersURL    string
+	SuspendedDate string
+	RemovalDate   string
+	// Collection access request fields
+	AccessStage       string // requested, approved, denied or expired
+	CollectionName    string
+	Justification     string
+	ReviewNote        string
+	AccessRequestsURL string
+	// Weekly security digest fields
+	Digest                  *SecurityDigest
+	SecurityDashboardURL    string
+	NotificationSettingsURL string
+	// Breach rotation alert fields
+	BreachNames []string
+	RotateItems []string // "Item name (site)" for items whose password should be changed
+	VaultURL    string
+}
+
+// SecurityDigest summarizes an organization's security events for the weekly admin digest
+type SecurityDigest struct {
+	PeriodStart string
+	PeriodEnd   string
+
+	NewMembers []string // Emails of members who joined during the period
+
+	TwoFactorTotal     int
+	TwoFactorCompliant int
+	TwoFactorMissing   []string // Emails of members without 2FA
+
+	NewBreaches []string // "Breach (email)" for breaches discovered during the period
+
+	FailedLogins         int
+	FailedLoginsPrevious int
+	FailedLoginSpike     bool
+
+	PolicyChanges []string // "Policy name enabled/disabled/updated"
+
+	ExpiringShares int // Shares expiring during the coming week
 }
 
 // TemplateManager handles email template rendering
@@ -125,6 +178,12 @@ func NewTemplateManager() (*TemplateManager, error) {
 	}
 	tm.templates[TemplateSendNotify] = sendNotifyTmpl
 
+	sendAccessCodeTmpl, err := template.New("send-access-code").Parse(secureSendAccessCodeEmailTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse send access code template: %w", err)
+	}
+	tm.templates[TemplateSendAccessCode] = sendAccessCodeTmpl
+
 	recoverDeleteRequestTmpl, err := template.New("recover-delete-request").Parse(recoverDeleteRequestEmailTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse recover-delete-request template: %w", err)
@@ -137,6 +196,42 @@ func NewTemplateManager() (*TemplateManager, error) {
 	}
 	tm.templates[TemplateRecoveryDeleteComplete] = recoverDeleteCompleteTmpl
 
+	dunningNoticeTmpl, err := template.New("dunning-notice").Parse(dunningNoticeEmailTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse dunning-notice template: %w", err)
+	}
+	tm.templates[TemplateDunningNotice] = dunningNoticeTmpl
+
+	suspendedRemovalTmpl, err := template.New("suspended-removal").Parse(suspendedRemovalEmailTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse suspended-removal template: %w", err)
+	}
+	tm.templates[TemplateSuspendedRemoval] = suspendedRemovalTmpl
+
+	collectionAccessTmpl, err := template.New("collection-access").Parse(collectionAccessEmailTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse collection-access template: %w", err)
+	}
+	tm.templates[TemplateCollectionAccess] = collectionAccessTmpl
+
+	shareExpiringTmpl, err := template.New("share-expiring").Parse(shareExpiringEmailTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse share-expiring template: %w", err)
+	}
+	tm.templates[TemplateShareExpiring] = shareExpiringTmpl
+
+	securityDigestTmpl, err := template.New("security-digest").Parse(securityDigestEmailTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse security-digest template: %w", err)
+	}
+	tm.templates[TemplateSecurityDigest] = securityDigestTmpl
+
+	breachRotationTmpl, err := template.New("breach-rotation").Parse(breachRotationEmailTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse breach-rotation template: %w", err)
+	}
+	tm.templates[TemplateBreachRotation] = breachRotationTmpl
+
 	return tm, nil
 }
 
@@ -295,7 +390,7 @@ const verificationEmailTemplate = `<!DOCTYPE html>
 </html>`
 
 // BuildInvitationEmail builds an invitation email
-func BuildInvitationEmail(frontendURL, to, inviterName, code, role string) (*TemplateData, error) {
+func BuildInvitationEmail(frontendURL, to, inviterName, code, role string, expiry time.Duration) (*TemplateData, error) {
 	if frontendURL == "" {
 		return nil, fmt.Errorf("frontend URL is required for invitation emails")
 	}
@@ -306,14 +401,14 @@ func BuildInvitationEmail(frontendURL, to, inviterName, code, role string) (*Tem
 		InviterName:   inviterName,
 		Code:          code,
 		Role:          role,
-		ExpiryTime:    "7 days",
+		ExpiryTime:    formatInvitationExpiry(expiry),
 		Year:          time.Now().Year(),
 		InvitationURL: invitationURL,
 	}, nil
 }
 
 // BuildInvitationEmailWithOrg builds an invitation email with organization info
-func BuildInvitationEmailWithOrg(frontendURL, to, inviterName, code, role, orgName string) (*TemplateData, error) {
+func BuildInvitationEmailWithOrg(frontendURL, to, inviterName, code, role, orgName string, expiry time.Duration) (*TemplateData, error) {
 	if frontendURL == "" {
 		return nil, fmt.Errorf("frontend URL is required for invitation emails")
 	}
@@ -324,13 +419,26 @@ func BuildInvitationEmailWithOrg(frontendURL, to, inviterName, code, role, orgNa
 		InviterName:      inviterName,
 		Code:             code,
 		Role:             role,
-		ExpiryTime:       "7 days",
+		ExpiryTime:       formatInvitationExpiry(expiry),
 		Year:             time.Now().Year(),
 		InvitationURL:    invitationURL,
 		OrganizationName: orgName,
 	}, nil
 }
 
+// formatInvitationExpiry renders an invitation lifetime in whole days (7 days when unset)
+func formatInvitationExpiry(expiry time.Duration) string {
+	days := int(expiry.Hours() / 24)
+	switch {
+	case expiry <= 0:
+		return "7 days"
+	case days <= 1:
+		return "1 day"
+	default:
+		return fmt.Sprintf("%d days", days)
+	}
+}
+
 // BuildShareInviteEmail builds a personal share invite email (for non-registered recipients)
 func BuildShareInviteEmail(frontendURL, to, inviterName, itemName string) (*TemplateData, error) {
 	if frontendURL == "" {
@@ -512,7 +620,7 @@ const shareInviteEmailTemplate = `<!DOCTYPE html>
                             </p>
                             <div style="background-color: #fef3c7; border-left: 4px solid #f59e0b; padding: 16px; margin: 20px 0; border-radius: 4px;">
                                 <p style="margin: 0; font-size: 14px; color: #92400e;">
-                                    <strong>⚠️ Note:</strong> Once you sign up, ask {{.ShareInviterName}} to re-share the item.
+                                    <strong>⚠️ Note:</strong> The item appears in your shares once you have signed up and {{.ShareInviterName}} has confirmed the share.
                                 </p>
                             </div>
                         </td>
@@ -637,6 +745,31 @@ const secureSendNotifyEmailTemplate = `<!DOCTYPE html>
 <p style="margin:0;font-size:12px;color:#a0aec0;text-align:center;">© {{.Year}} Passwall. All rights reserved.</p>
 </td></tr></table></td></tr></table></body></html>`
 
+// secureSendAccessCodeEmailTemplate is the HTML template for recipient verification codes of restricted sends
+const secureSendAccessCodeEmailTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="UTF-8"><meta name="viewport" content="width=device-width, initial-scale=1.0"><title>Your Secure Send access code</title></head>
+<body style="margin:0;padding:0;font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,'Helvetica Neue',Arial,sans-serif;background-color:#f5f5f5;">
+<table width="100%" cellpadding="0" cellspacing="0" style="background-color:#f5f5f5;padding:40px 20px;"><tr><td align="center">
+<table width="600" cellpadding="0" cellspacing="0" style="background-color:#fff;border-radius:8px;box-shadow:0 2px 4px rgba(0,0,0,0.1);">
+<tr><td style="padding:40px 40px 20px;text-align:center;border-bottom:1px solid #e0e0e0;"><h1 style="margin:0;font-size:32px;font-weight:700;color:#1a1a1a;"><span style="color:#3b82f6;">Pass</span>wall</h1></td></tr>
+<tr><td style="padding:40px;">
+<h2 style="margin:0 0 20px;font-size:24px;font-weight:600;color:#1a1a1a;">🔒 Your access code</h2>
+<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;">{{if .SendSenderName}}<strong>{{.SendSenderName}}</strong> shared{{else}}Someone shared{{end}} a Secure Send{{if .SendName}} (<strong>{{.SendName}}</strong>){{end}} that can only be opened by its recipients.</p>
+<p style="margin:0 0 20px;font-size:16px;line-height:1.6;color:#4a5568;">Enter this code to confirm your email address:</p>
+<div style="background-color:#f7fafc;border:2px dashed #3b82f6;border-radius:8px;padding:20px;text-align:center;margin:0 0 20px;">
+<span style="font-size:32px;font-weight:700;letter-spacing:8px;color:#1a1a1a;font-family:'Courier New',monospace;">{{.Code}}</span>
+</div>
+<p style="margin:0 0 20px;font-size:14px;line-height:1.6;color:#718096;text-align:center;">This code expires in {{.ExpiryTime}}.</p>
+<div style="background-color:#fef3c7;border-left:4px solid #f59e0b;padding:16px;margin:20px 0;border-radius:4px;">
+<p style="margin:0;font-size:14px;color:#92400e;"><strong>⚠️ Note:</strong> If you did not try to open a Secure Send, you can safely ignore this email. Never share this code.</p>
+</div>
+</td></tr>
+<tr><td style="padding:30px 40px;background-color:#f7fafc;border-top:1px solid #e0e0e0;border-radius:0 0 8px 8px;">
+<p style="margin:0 0 10px;font-size:14px;color:#718096;text-align:center;">This is an automated message, please do not reply.</p>
+<p style="margin:0;font-size:12px;color:#a0aec0;text-align:center;">© {{.Year}} Passwall. All rights reserved.</p>
+</td></tr></table></td></tr></table></body></html>`
+
 // emergencyInviteEmailTemplate is the HTML template for emergency access invitations
 const emergencyInviteEmailTemplate = `<!DOCTYPE html>
 <html lang="en">
@@ -778,3 +911,164 @@ const recoverDeleteCompleteEmailTemplate = `<!DOCTYPE html>
 <p style="margin:0 0 10px;font-size:14px;color:#718096;text-align:center;">This is an automated message, please do not reply.</p>
 <p style="margin:0;font-size:12px;color:#a0aec0;text-align:center;">© {{.Year}} Passwall. All rights reserved.</p>
 </td></tr></table></td></tr></table></body></html>`
+
+// dunningNoticeEmailTemplate notifies billing contacts about a failed payment and its consequences
+const dunningNoticeEmailTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="UTF-8"><meta name="viewport" content="width=device-width, initial-scale=1.0"><title>Payment Required</title></head>
+<body style="margin:0;padding:0;font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,'Helvetica Neue',Arial,sans-serif;background-color:#f5f5f5;">
+<table width="100%" cellpadding="0" cellspacing="0" style="background-color:#f5f5f5;padding:40px 20px;"><tr><td align="center">
+<table width="600" cellpadding="0" cellspacing="0" style="background-color:#fff;border-radius:8px;box-shadow:0 2px 4px rgba(0,0,0,0.1);">
+<tr><td style="padding:40px 40px 20px;text-align:center;border-bottom:1px solid #e0e0e0;"><h1 style="margin:0;font-size:32px;font-weight:700;color:#1a1a1a;"><span style="color:#3b82f6;">Pass</span>wall</h1></td></tr>
+<tr><td style="padding:40px;">
+{{if eq .DunningStage "expired"}}<h2 style="margin:0 0 20px;font-size:24px;font-weight:600;color:#e53e3e;">Your subscription has expired</h2>
+<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;">We were unable to collect payment for <strong>{{.OrganizationName}}</strong>, and the subscription has now expired.</p>
+<p style="margin:0 0 20px;font-size:16px;line-height:1.6;color:#4a5568;">Your data is still stored safely. Renew your subscription to restore full access.</p>
+{{else if eq .DunningStage "read_only"}}<h2 style="margin:0 0 20px;font-size:24px;font-weight:600;color:#e53e3e;">Your vault is now read-only</h2>
+<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;">The grace period for <strong>{{.OrganizationName}}</strong> has ended. Members can still view and export their items, but no changes can be made.</p>
+<p style="margin:0 0 20px;font-size:16px;line-height:1.6;color:#4a5568;">Update your payment method before <strong>{{.ExpiryDate}}</strong> to avoid losing your subscription.</p>
+{{else}}<h2 style="margin:0 0 20px;font-size:24px;font-weight:600;color:#1a1a1a;">Payment failed</h2>
+<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;">We were unable to collect payment for <strong>{{.OrganizationName}}</strong>.</p>
+<p style="margin:0 0 20px;font-size:16px;line-height:1.6;color:#4a5568;">Please update your payment method. If payment is not received by <strong>{{.ReadOnlyDate}}</strong>, the vault will become read-only.</p>
+{{end}}<table width="100%" cellpadding="0" cellspacing="0" style="margin:24px 0;"><tr><td align="center">
+<a href="{{.BillingURL}}" style="display:inline-block;padding:14px 32px;background-color:#3b82f6;color:#fff;text-decoration:none;border-radius:6px;font-weight:600;font-size:16px;">Update Payment Method</a>
+</td></tr></table>
+<p style="margin:0 0 10px;font-size:14px;line-height:1.6;color:#718096;text-align:center;">Or copy and paste this link into your browser:</p>
+<p style="margin:0 0 20px;font-size:13px;line-height:1.6;color:#3b82f6;text-align:center;word-break:break-all;">{{.BillingURL}}</p>
+</td></tr>
+<tr><td style="padding:30px 40px;background-color:#f7fafc;border-top:1px solid #e0e0e0;border-radius:0 0 8px 8px;">
+<p style="margin:0 0 10px;font-size:14px;color:#718096;text-align:center;">You are receiving this email as a billing contact of {{.OrganizationName}}.</p>
+<p style="margin:0;font-size:12px;color:#a0aec0;text-align:center;">© {{.Year}} Passwall. All rights reserved.</p>
+</td></tr></table></td></tr></table></body></html>`
+
+// suspendedRemovalEmailTemplate warns organization admins that a suspended member is about to be removed
+const suspendedRemovalEmailTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="UTF-8"><meta name="viewport" content="width=device-width, initial-scale=1.0"><title>Suspended Member Removal</title></head>
+<body style="margin:0;padding:0;font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,'Helvetica Neue',Arial,sans-serif;background-color:#f5f5f5;">
+<table width="100%" cellpadding="0" cellspacing="0" style="background-color:#f5f5f5;padding:40px 20px;"><tr><td align="center">
+<table width="600" cellpadding="0" cellspacing="0" style="background-color:#fff;border-radius:8px;box-shadow:0 2px 4px rgba(0,0,0,0.1);">
+<tr><td style="padding:40px 40px 20px;text-align:center;border-bottom:1px solid #e0e0e0;"><h1 style="margin:0;font-size:32px;font-weight:700;color:#1a1a1a;"><span style="color:#3b82f6;">Pass</span>wall</h1></td></tr>
+<tr><td style="padding:40px;">
+<h2 style="margin:0 0 20px;font-size:24px;font-weight:600;color:#1a1a1a;">A suspended member will be removed</h2>
+<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;"><strong>{{.UserEmail}}</strong> has been suspended from <strong>{{.OrganizationName}}</strong> since {{.SuspendedDate}}.</p>
+<p style="margin:0 0 20px;font-size:16px;line-height:1.6;color:#4a5568;">Following your organization's settings, the member will be removed on <strong>{{.RemovalDate}}</strong>. Restore the member before then to keep their access.</p>
+<table width="100%" cellpadding="0" cellspacing="0" style="margin:24px 0;"><tr><td align="center">
+<a href="{{.MembersURL}}" style="display:inline-block;padding:14px 32px;background-color:#3b82f6;color:#fff;text-decoration:none;border-radius:6px;font-weight:600;font-size:16px;">Review Members</a>
+</td></tr></table>
+<p style="margin:0 0 10px;font-size:14px;line-height:1.6;color:#718096;text-align:center;">Or copy and paste this link into your browser:</p>
+<p style="margin:0 0 20px;font-size:13px;line-height:1.6;color:#3b82f6;text-align:center;word-break:break-all;">{{.MembersURL}}</p>
+</td></tr>
+<tr><td style="padding:30px 40px;background-color:#f7fafc;border-top:1px solid #e0e0e0;border-radius:0 0 8px 8px;">
+<p style="margin:0 0 10px;font-size:14px;color:#718096;text-align:center;">You are receiving this email as an administrator of {{.OrganizationName}}.</p>
+<p style="margin:0;font-size:12px;color:#a0aec0;text-align:center;">© {{.Year}} Passwall. All rights reserved.</p>
+</td></tr></table></td></tr></table></body></html>`
+
+// collectionAccessEmailTemplate covers every step of a temporary collection access request
+const collectionAccessEmailTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="UTF-8"><meta name="viewport" content="width=device-width, initial-scale=1.0"><title>Collection Access Request</title></head>
+<body style="margin:0;padding:0;font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,'Helvetica Neue',Arial,sans-serif;background-color:#f5f5f5;">
+<table width="100%" cellpadding="0" cellspacing="0" style="background-color:#f5f5f5;padding:40px 20px;"><tr><td align="center">
+<table width="600" cellpadding="0" cellspacing="0" style="background-color:#fff;border-radius:8px;box-shadow:0 2px 4px rgba(0,0,0,0.1);">
+<tr><td style="padding:40px 40px 20px;text-align:center;border-bottom:1px solid #e0e0e0;"><h1 style="margin:0;font-size:32px;font-weight:700;color:#1a1a1a;"><span style="color:#3b82f6;">Pass</span>wall</h1></td></tr>
+<tr><td style="padding:40px;">
+{{if eq .AccessStage "requested"}}<h2 style="margin:0 0 20px;font-size:24px;font-weight:600;color:#1a1a1a;">Access requested</h2>
+<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;"><strong>{{.UserEmail}}</strong> is asking for temporary access to the <strong>{{.CollectionName}}</strong> collection in <strong>{{.OrganizationName}}</strong>.</p>
+<p style="margin:0 0 20px;font-size:16px;line-height:1.6;color:#4a5568;">Justification: <em>{{.Justification}}</em></p>
+{{else if eq .AccessStage "approved"}}<h2 style="margin:0 0 20px;font-size:24px;font-weight:600;color:#1a1a1a;">Access approved</h2>
+<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;">Your request for access to the <strong>{{.CollectionName}}</strong> collection in <strong>{{.OrganizationName}}</strong> was approved.</p>
+<p style="margin:0 0 20px;font-size:16px;line-height:1.6;color:#4a5568;">Your access ends on <strong>{{.ExpiryDate}}</strong>.</p>
+{{else if eq .AccessStage "denied"}}<h2 style="margin:0 0 20px;font-size:24px;font-weight:600;color:#e53e3e;">Access denied</h2>
+<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;">Your request for access to the <strong>{{.CollectionName}}</strong> collection in <strong>{{.OrganizationName}}</strong> was denied.</p>
+{{else}}<h2 style="margin:0 0 20px;font-size:24px;font-weight:600;color:#1a1a1a;">Temporary access ended</h2>
+<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;">The temporary access of <strong>{{.UserEmail}}</strong> to the <strong>{{.CollectionName}}</strong> collection in <strong>{{.OrganizationName}}</strong> expired on {{.ExpiryDate}} and has been removed.</p>
+{{end}}{{if .ReviewNote}}<p style="margin:0 0 20px;font-size:16px;line-height:1.6;color:#4a5568;">Note from the reviewer: <em>{{.ReviewNote}}</em></p>
+{{end}}<table width="100%" cellpadding="0" cellspacing="0" style="margin:24px 0;"><tr><td align="center">
+<a href="{{.AccessRequestsURL}}" style="display:inline-block;padding:14px 32px;background-color:#3b82f6;color:#fff;text-decoration:none;border-radius:6px;font-weight:600;font-size:16px;">View Access Requests</a>
+</td></tr></table>
+<p style="margin:0 0 10px;font-size:14px;line-height:1.6;color:#718096;text-align:center;">Or copy and paste this link into your browser:</p>
+<p style="margin:0 0 20px;font-size:13px;line-height:1.6;color:#3b82f6;text-align:center;word-break:break-all;">{{.AccessRequestsURL}}</p>
+</td></tr>
+<tr><td style="padding:30px 40px;background-color:#f7fafc;border-top:1px solid #e0e0e0;border-radius:0 0 8px 8px;">
+<p style="margin:0 0 10px;font-size:14px;color:#718096;text-align:center;">You are receiving this email as a member of {{.OrganizationName}}.</p>
+<p style="margin:0;font-size:12px;color:#a0aec0;text-align:center;">© {{.Year}} Passwall. All rights reserved.</p>
+</td></tr></table></td></tr></table></body></html>`
+
+const shareExpiringEmailTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="UTF-8"><meta name="viewport" content="width=device-width, initial-scale=1.0"><title>Share Expiring</title></head>
+<body style="margin:0;padding:0;font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,'Helvetica Neue',Arial,sans-serif;background-color:#f5f5f5;">
+<table width="100%" cellpadding="0" cellspacing="0" style="background-color:#f5f5f5;padding:40px 20px;"><tr><td align="center">
+<table width="600" cellpadding="0" cellspacing="0" style="background-color:#fff;border-radius:8px;box-shadow:0 2px 4px rgba(0,0,0,0.1);">
+<tr><td style="padding:40px 40px 20px;text-align:center;border-bottom:1px solid #e0e0e0;"><h1 style="margin:0;font-size:32px;font-weight:700;color:#1a1a1a;"><span style="color:#3b82f6;">Pass</span>wall</h1></td></tr>
+<tr><td style="padding:40px;">
+<h2 style="margin:0 0 20px;font-size:24px;font-weight:600;color:#1a1a1a;">A share is about to expire</h2>
+{{if eq .ShareAudience "owner"}}<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;">Your share of <strong>{{.ShareItemName}}</strong> with <strong>{{.ShareRecipient}}</strong> expires on <strong>{{.ExpiryDate}}</strong>.</p>
+<p style="margin:0 0 20px;font-size:16px;line-height:1.6;color:#4a5568;">Extend the expiry date before then if they still need access. Once it expires, the share is revoked and has to be created again.</p>
+{{else}}<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;"><strong>{{.ShareItemName}}</strong>, shared with you by <strong>{{.ShareInviterName}}</strong>, expires on <strong>{{.ExpiryDate}}</strong>.</p>
+<p style="margin:0 0 20px;font-size:16px;line-height:1.6;color:#4a5568;">After that you will no longer be able to open it. Ask {{.ShareInviterName}} to extend the share if you still need it.</p>
+{{end}}<table width="100%" cellpadding="0" cellspacing="0" style="margin:24px 0;"><tr><td align="center">
+<a href="{{.ShareSignInURL}}" style="display:inline-block;padding:14px 32px;background-color:#3b82f6;color:#fff;text-decoration:none;border-radius:6px;font-weight:600;font-size:16px;">View Shares</a>
+</td></tr></table>
+<p style="margin:0 0 10px;font-size:14px;line-height:1.6;color:#718096;text-align:center;">Or copy and paste this link into your browser:</p>
+<p style="margin:0 0 20px;font-size:13px;line-height:1.6;color:#3b82f6;text-align:center;word-break:break-all;">{{.ShareSignInURL}}</p>
+</td></tr>
+<tr><td style="padding:30px 40px;background-color:#f7fafc;border-top:1px solid #e0e0e0;border-radius:0 0 8px 8px;">
+<p style="margin:0;font-size:12px;color:#a0aec0;text-align:center;">© {{.Year}} Passwall. All rights reserved.</p>
+</td></tr></table></td></tr></table></body></html>`
+
+// securityDigestEmailTemplate is the weekly security summary sent to organization admins
+const securityDigestEmailTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="UTF-8"><meta name="viewport" content="width=device-width, initial-scale=1.0"><title>Weekly Security Digest</title></head>
+<body style="margin:0;padding:0;font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,'Helvetica Neue',Arial,sans-serif;background-color:#f5f5f5;">
+<table width="100%" cellpadding="0" cellspacing="0" style="background-color:#f5f5f5;padding:40px 20px;"><tr><td align="center">
+<table width="600" cellpadding="0" cellspacing="0" style="background-color:#fff;border-radius:8px;box-shadow:0 2px 4px rgba(0,0,0,0.1);">
+<tr><td style="padding:40px 40px 20px;text-align:center;border-bottom:1px solid #e0e0e0;"><h1 style="margin:0;font-size:32px;font-weight:700;color:#1a1a1a;"><span style="color:#3b82f6;">Pass</span>wall</h1></td></tr>
+<tr><td style="padding:40px;">
+<h2 style="margin:0 0 8px;font-size:24px;font-weight:600;color:#1a1a1a;">Weekly security digest</h2>
+<p style="margin:0 0 24px;font-size:14px;color:#718096;">{{.OrganizationName}} &middot; {{.Digest.PeriodStart}} &ndash; {{.Digest.PeriodEnd}}</p>
+{{with .Digest}}<h3 style="margin:0 0 8px;font-size:16px;font-weight:600;color:#1a1a1a;">New members</h3>
+{{if .NewMembers}}<ul style="margin:0 0 20px;padding-left:20px;font-size:15px;line-height:1.6;color:#4a5568;">{{range .NewMembers}}<li>{{.}}</li>{{end}}</ul>{{else}}<p style="margin:0 0 20px;font-size:15px;color:#4a5568;">No new members this week.</p>{{end}}
+<h3 style="margin:0 0 8px;font-size:16px;font-weight:600;color:#1a1a1a;">Two-factor authentication</h3>
+<p style="margin:0 0 8px;font-size:15px;line-height:1.6;color:#4a5568;"><strong>{{.TwoFactorCompliant}}</strong> of <strong>{{.TwoFactorTotal}}</strong> members use two-factor authentication.</p>
+{{if .TwoFactorMissing}}<ul style="margin:0 0 20px;padding-left:20px;font-size:15px;line-height:1.6;color:#4a5568;">{{range .TwoFactorMissing}}<li>{{.}}</li>{{end}}</ul>{{else}}<p style="margin:0 0 20px;"></p>{{end}}
+<h3 style="margin:0 0 8px;font-size:16px;font-weight:600;color:#1a1a1a;">New breaches</h3>
+{{if .NewBreaches}}<ul style="margin:0 0 20px;padding-left:20px;font-size:15px;line-height:1.6;color:#e53e3e;">{{range .NewBreaches}}<li>{{.}}</li>{{end}}</ul>{{else}}<p style="margin:0 0 20px;font-size:15px;color:#4a5568;">No new breaches were found for monitored emails.</p>{{end}}
+<h3 style="margin:0 0 8px;font-size:16px;font-weight:600;color:#1a1a1a;">Failed sign-ins</h3>
+<p style="margin:0 0 20px;font-size:15px;line-height:1.6;color:{{if .FailedLoginSpike}}#e53e3e{{else}}#4a5568{{end}};"><strong>{{.FailedLogins}}</strong> failed sign-ins this week, compared with {{.FailedLoginsPrevious}} the week before.{{if .FailedLoginSpike}} This is an unusual increase.{{end}}</p>
+<h3 style="margin:0 0 8px;font-size:16px;font-weight:600;color:#1a1a1a;">Policy changes</h3>
+{{if .PolicyChanges}}<ul style="margin:0 0 20px;padding-left:20px;font-size:15px;line-height:1.6;color:#4a5568;">{{range .PolicyChanges}}<li>{{.}}</li>{{end}}</ul>{{else}}<p style="margin:0 0 20px;font-size:15px;color:#4a5568;">No policies were changed.</p>{{end}}
+<h3 style="margin:0 0 8px;font-size:16px;font-weight:600;color:#1a1a1a;">Expiring shares</h3>
+<p style="margin:0 0 20px;font-size:15px;line-height:1.6;color:#4a5568;"><strong>{{.ExpiringShares}}</strong> item shares expire in the coming week.</p>
+{{end}}<table width="100%" cellpadding="0" cellspacing="0" style="margin:24px 0;"><tr><td align="center">
+<a href="{{.SecurityDashboardURL}}" style="display:inline-block;padding:14px 32px;background-color:#3b82f6;color:#fff;text-decoration:none;border-radius:6px;font-weight:600;font-size:16px;">Open Organization</a>
+</td></tr></table>
+</td></tr>
+<tr><td style="padding:30px 40px;background-color:#f7fafc;border-top:1px solid #e0e0e0;border-radius:0 0 8px 8px;">
+<p style="margin:0 0 10px;font-size:14px;color:#718096;text-align:center;">You are receiving this email as an admin of {{.OrganizationName}}. <a href="{{.NotificationSettingsURL}}" style="color:#3b82f6;">Unsubscribe from the weekly digest</a>.</p>
+<p style="margin:0;font-size:12px;color:#a0aec0;text-align:center;">© {{.Year}} Passwall. All rights reserved.</p>
+</td></tr></table></td></tr></table></body></html>`
+
+// breachRotationEmailTemplate lists the vault items at risk after a breach of the user's email address
+const breachRotationEmailTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="UTF-8"><meta name="viewport" content="width=device-width, initial-scale=1.0"><title>Passwords to Change</title></head>
+<body style="margin:0;padding:0;font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Roboto,'Helvetica Neue',Arial,sans-serif;background-color:#f5f5f5;">
+<table width="100%" cellpadding="0" cellspacing="0" style="background-color:#f5f5f5;padding:40px 20px;"><tr><td align="center">
+<table width="600" cellpadding="0" cellspacing="0" style="background-color:#fff;border-radius:8px;box-shadow:0 2px 4px rgba(0,0,0,0.1);">
+<tr><td style="padding:40px 40px 20px;text-align:center;border-bottom:1px solid #e0e0e0;"><h1 style="margin:0;font-size:32px;font-weight:700;color:#1a1a1a;"><span style="color:#3b82f6;">Pass</span>wall</h1></td></tr>
+<tr><td style="padding:40px;">
+<h2 style="margin:0 0 20px;font-size:24px;font-weight:600;color:#e53e3e;">Change these passwords</h2>
+<p style="margin:0 0 16px;font-size:16px;line-height:1.6;color:#4a5568;">Your email address <strong>{{.UserEmail}}</strong> was found in a data breach of {{range $i, $b := .BreachNames}}{{if $i}}, {{end}}<strong>{{$b}}</strong>{{end}}.</p>
+<p style="margin:0 0 8px;font-size:16px;line-height:1.6;color:#4a5568;">These items in your vault are for the breached sites and are now marked as rotation recommended:</p>
+<ul style="margin:0 0 20px;padding-left:20px;font-size:15px;line-height:1.6;color:#4a5568;">{{range .RotateItems}}<li>{{.}}</li>{{end}}</ul>
+<p style="margin:0 0 20px;font-size:16px;line-height:1.6;color:#4a5568;">Change each password, and the password of any other account where you used the same one.</p>
+<table width="100%" cellpadding="0" cellspacing="0" style="margin:24px 0;"><tr><td align="center">
+<a href="{{.VaultURL}}" style="display:inline-block;padding:14px 32px;background-color:#3b82f6;color:#fff;text-decoration:none;border-radius:6px;font-weight:600;font-size:16px;">Open Vault</a>
+</td></tr></table>
+</td></tr>
+<tr><td style="padding:30px 40px;background-color:#f7fafc;border-top:1px solid #e0e0e0;border-radius:0 0 8px 8px;">
+<p style="margin:0;font-size:12px;color:#a0aec0;text-align:center;">© {{.Year}} Passwall. All rights reserved.</p>
+</td></tr></table></td></tr></table></body></html>`
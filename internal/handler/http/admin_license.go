@@ -0,0 +1,102 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+// licenseMaxSize bounds uploaded license files (they are a few KB at most)
+const licenseMaxSize = 64 * 1024
+
+type AdminLicenseHandler struct {
+	licenseService service.LicenseService
+	activityLogger *service.ActivityLogger
+}
+
+func NewAdminLicenseHandler(licenseService service.LicenseService, userActivityService service.UserActivityService) *AdminLicenseHandler {
+	return &AdminLicenseHandler{
+		licenseService: licenseService,
+		activityLogger: service.NewActivityLogger(userActivityService),
+	}
+}
+
+// Status returns the installed license status (admin-only).
+// GET /api/admin/license
+func (h *AdminLicenseHandler) Status(c *gin.Context) {
+	c.JSON(http.StatusOK, h.licenseService.Status(c.Request.Context()))
+}
+
+// Upload verifies and installs a new license file (admin-only).
+// The license can be sent as the raw request body or as a multipart "license" file.
+//
+// PUT /api/admin/license
+func (h *AdminLicenseHandler) Upload(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	data, err := readLicenseBody(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status, err := h.licenseService.Upload(ctx, data)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrLicensingNotConfigured):
+			c.JSON(http.StatusConflict, gin.H{"error": "licensing is not configured on this server"})
+		case errors.Is(err, service.ErrLicenseExpired):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "license has expired"})
+		case errors.Is(err, service.ErrInvalidLicense):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid license file", "details": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to install license"})
+		}
+		return
+	}
+
+	// Audit log (admin action)
+	if actorID, actorErr := GetUserID(c); actorErr == nil && h.activityLogger != nil {
+		h.activityLogger.LogCustomActivity(ctx, actorID, domain.ActivityTypeLicenseUpdated, GetIPAddress(c), GetUserAgent(c), service.ActivityDetails{
+			service.ActivityFieldPlan:   status.PlanCode,
+			service.ActivityFieldStatus: status.State,
+			"license_id":                status.LicenseID,
+			"licensee":                  status.Licensee,
+			"seats":                     status.Seats,
+			"expires_at":                status.ExpiresAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+func readLicenseBody(c *gin.Context) ([]byte, error) {
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, _, err := c.Request.FormFile("license")
+		if err != nil {
+			return nil, errors.New("license file is required")
+		}
+		defer file.Close()
+		return readLicenseData(file)
+	}
+	return readLicenseData(c.Request.Body)
+}
+
+func readLicenseData(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, licenseMaxSize+1))
+	if err != nil {
+		return nil, errors.New("failed to read license file")
+	}
+	if len(data) == 0 {
+		return nil, errors.New("license file is required")
+	}
+	if len(data) > licenseMaxSize {
+		return nil, errors.New("license file too large")
+	}
+	return data, nil
+}
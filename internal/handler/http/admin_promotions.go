@@ -0,0 +1,160 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+type AdminPromotionsHandler struct {
+	promotionService service.PromotionService
+}
+
+func NewAdminPromotionsHandler(promotionService service.PromotionService) *AdminPromotionsHandler {
+	return &AdminPromotionsHandler{promotionService: promotionService}
+}
+
+// ListPromoCodes lists all promo codes (admin-only).
+// GET /api/admin/promo-codes
+func (h *AdminPromotionsHandler) ListPromoCodes(c *gin.Context) {
+	codes, err := h.promotionService.ListPromoCodes(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list promo codes"})
+		return
+	}
+	c.JSON(http.StatusOK, codes)
+}
+
+// CreatePromoCode creates a promo code backed by a Stripe coupon (admin-only).
+// POST /api/admin/promo-codes
+func (h *AdminPromotionsHandler) CreatePromoCode(c *gin.Context) {
+	adminID, err := GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req domain.CreatePromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	promo, err := h.promotionService.CreatePromoCode(c.Request.Context(), adminID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPromoCodeInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrAlreadyExists):
+			c.JSON(http.StatusConflict, gin.H{"error": "promo code already exists"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create promo code", "details": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, promo)
+}
+
+// DeactivatePromoCode stops a promo code from being redeemed (admin-only).
+// POST /api/admin/promo-codes/:id/deactivate
+func (h *AdminPromotionsHandler) DeactivatePromoCode(c *gin.Context) {
+	id, ok := GetUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	promo, err := h.promotionService.DeactivatePromoCode(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "promo code not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to deactivate promo code"})
+		return
+	}
+
+	c.JSON(http.StatusOK, promo)
+}
+
+// ListRedemptions returns the redemption audit trail for a promo code (admin-only).
+// GET /api/admin/promo-codes/:id/redemptions
+func (h *AdminPromotionsHandler) ListRedemptions(c *gin.Context) {
+	id, ok := GetUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	redemptions, err := h.promotionService.ListRedemptions(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "promo code not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list redemptions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, redemptions)
+}
+
+// ListTrialExtensions lists trial extensions granted to an organization (admin-only).
+// GET /api/admin/organizations/:id/trial-extensions
+func (h *AdminPromotionsHandler) ListTrialExtensions(c *gin.Context) {
+	orgID, ok := GetUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	exts, err := h.promotionService.ListTrialExtensions(c.Request.Context(), orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list trial extensions"})
+		return
+	}
+
+	dtos := make([]*domain.TrialExtensionDTO, 0, len(exts))
+	for _, ext := range exts {
+		dtos = append(dtos, domain.ToTrialExtensionDTO(ext))
+	}
+	c.JSON(http.StatusOK, dtos)
+}
+
+// GrantTrialExtension extends an organization's trial (admin-only).
+// POST /api/admin/organizations/:id/trial-extensions
+func (h *AdminPromotionsHandler) GrantTrialExtension(c *gin.Context) {
+	orgID, ok := GetUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	adminID, err := GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req domain.GrantTrialExtensionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	ext, err := h.promotionService.GrantTrialExtension(c.Request.Context(), orgID, adminID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTrialExtension):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "organization not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to extend trial", "details": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.ToTrialExtensionDTO(ext))
+}
@@ -477,7 +477,7 @@ func (h *AdminSubscriptionsHandler) GrantManual(c *gin.Context) {
 	sub.CancelAt = nil
 	sub.RenewAt = &endsAt
 	sub.EndedAt = nil
-	sub.GracePeriodEndsAt = nil
+	sub.ClearDunning()
 	sub.TrialEndsAt = nil
 	sub.StripeSubscriptionID = nil
 	sub.SeatsPurchased = requestedUsers
@@ -0,0 +1,98 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+type AdminWebhooksHandler struct {
+	webhookInbox service.WebhookInboxService
+}
+
+func NewAdminWebhooksHandler(webhookInbox service.WebhookInboxService) *AdminWebhooksHandler {
+	return &AdminWebhooksHandler{webhookInbox: webhookInbox}
+}
+
+type AdminWebhookEventListResponse struct {
+	Items    []*domain.WebhookEventDTO `json:"items"`
+	Total    int64                     `json:"total"`
+	Filtered int64                     `json:"filtered"`
+}
+
+// List lists webhook inbox events (admin-only).
+// Query: provider, status, event_type, q (event ID), limit, offset
+// GET /api/admin/webhook-events
+func (h *AdminWebhooksHandler) List(c *gin.Context) {
+	filter := repository.WebhookEventFilter{
+		Provider:  domain.WebhookProvider(strings.ToLower(strings.TrimSpace(c.Query("provider")))),
+		Status:    domain.WebhookEventStatus(strings.ToLower(strings.TrimSpace(c.Query("status")))),
+		EventType: strings.TrimSpace(c.Query("event_type")),
+		Search:    strings.TrimSpace(c.Query("q")),
+		Limit:     clampTelemetryQueryInt(c.Query("limit"), 50, 1, 500),
+		Offset:    clampTelemetryQueryInt(c.Query("offset"), 0, 0, 10_000_000),
+	}
+
+	items, total, filtered, err := h.webhookInbox.List(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list webhook events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, AdminWebhookEventListResponse{
+		Items:    items,
+		Total:    total,
+		Filtered: filtered,
+	})
+}
+
+// Get returns a webhook inbox event including its payload (admin-only).
+// GET /api/admin/webhook-events/:id
+func (h *AdminWebhooksHandler) Get(c *gin.Context) {
+	id, ok := GetUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	event, err := h.webhookInbox.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "webhook event not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get webhook event"})
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// Replay processes a webhook inbox event again (admin-only).
+// The response contains the event with the outcome of the replay.
+// POST /api/admin/webhook-events/:id/replay
+func (h *AdminWebhooksHandler) Replay(c *gin.Context) {
+	id, ok := GetUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	event, err := h.webhookInbox.Replay(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "webhook event not found"})
+		case errors.Is(err, service.ErrWebhookEventBusy):
+			c.JSON(http.StatusConflict, gin.H{"error": "webhook event is being processed, try again later"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to replay webhook event"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
@@ -153,14 +153,134 @@ func (h *BreachMonitorHandler) GetSummary(c *gin.Context) {
 	c.JSON(http.StatusOK, summary)
 }
 
+type addDomainRequest struct {
+	Domain string `json:"domain" binding:"required,fqdn"`
+}
+
+// AddDomain handles POST /api/organizations/:id/breach-monitor/domains
+func (h *BreachMonitorHandler) AddDomain(c *gin.Context) {
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+	userID := GetCurrentUserID(c)
+
+	var req addDomainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "valid domain is required"})
+		return
+	}
+
+	dto, err := h.service.AddDomain(c.Request.Context(), orgID, userID, req.Domain)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto)
+}
+
+// ListDomains handles GET /api/organizations/:id/breach-monitor/domains
+func (h *BreachMonitorHandler) ListDomains(c *gin.Context) {
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+	userID := GetCurrentUserID(c)
+
+	domains, err := h.service.ListDomains(c.Request.Context(), orgID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, domains)
+}
+
+// RemoveDomain handles DELETE /api/organizations/:id/breach-monitor/domains/:domainId
+func (h *BreachMonitorHandler) RemoveDomain(c *gin.Context) {
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+	userID := GetCurrentUserID(c)
+	domainID, ok := GetUintParam(c, "domainId")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveDomain(c.Request.Context(), orgID, userID, domainID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "domain removed from monitoring"})
+}
+
+// EnrollMembers handles POST /api/organizations/:id/breach-monitor/enroll-members
+func (h *BreachMonitorHandler) EnrollMembers(c *gin.Context) {
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+	userID := GetCurrentUserID(c)
+
+	enrolled, err := h.service.EnrollMembers(c.Request.Context(), orgID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"enrolled": enrolled})
+}
+
+// ListMyBreaches handles GET /api/organizations/:id/breach-monitor/my-breaches
+func (h *BreachMonitorHandler) ListMyBreaches(c *gin.Context) {
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+	userID := GetCurrentUserID(c)
+
+	breaches, err := h.service.ListMyBreaches(c.Request.Context(), orgID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, breaches)
+}
+
+// DismissMyBreach handles PATCH /api/organizations/:id/breach-monitor/my-breaches/:breachId/dismiss
+func (h *BreachMonitorHandler) DismissMyBreach(c *gin.Context) {
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+	userID := GetCurrentUserID(c)
+	breachID, ok := GetUintParam(c, "breachId")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DismissMyBreach(c.Request.Context(), orgID, userID, breachID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "breach dismissed"})
+}
+
 func (h *BreachMonitorHandler) handleError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, repository.ErrNotFound):
 		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
 	case errors.Is(err, repository.ErrAlreadyExists):
-		c.JSON(http.StatusConflict, gin.H{"error": "email is already being monitored"})
+		c.JSON(http.StatusConflict, gin.H{"error": "already being monitored"})
 	case errors.Is(err, repository.ErrForbidden):
 		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	case errors.Is(err, service.ErrDomainNotVerified):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 	case errors.Is(err, service.ErrFeatureNotAvailable):
 		c.JSON(http.StatusForbidden, gin.H{"error": "breach monitoring is not available on your current plan"})
 	case errors.Is(err, service.ErrSubscriptionExpired):
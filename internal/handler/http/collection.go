@@ -83,10 +83,11 @@ func (h *CollectionHandler) Create(c *gin.Context) {
 
 // List godoc
 // @Summary List collections
-// @Description List collections in an organization
+// @Description List collections in an organization as a tree of root collections with nested children
 // @Tags collections
 // @Produce json
 // @Param orgId path int true "Organization ID"
+// @Param flat query bool false "Return a flat list, parents before their children"
 // @Success 200 {array} domain.CollectionDTO
 // @Failure 400 {object} map[string]string
 // @Failure 403 {object} map[string]string
@@ -110,6 +111,13 @@ func (h *CollectionHandler) List(c *gin.Context) {
 		return
 	}
 
+	if c.Query("flat") == "true" {
+		collections = domain.FlattenCollectionTree(collections)
+		for _, col := range collections {
+			col.Children = nil
+		}
+	}
+
 	dtos := make([]*domain.CollectionDTO, len(collections))
 	for i, col := range collections {
 		dtos[i] = domain.ToCollectionDTO(col)
@@ -234,6 +242,50 @@ func (h *CollectionHandler) Delete(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// Move godoc
+// @Summary Move collection
+// @Description Move a collection and its children under another parent, or to the top level when parent_id is null
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param request body domain.MoveCollectionRequest true "New parent"
+// @Success 200 {object} domain.CollectionDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /collections/{id}/move [post]
+func (h *CollectionHandler) Move(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	id, ok := GetUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req domain.MoveCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	collection, err := h.service.Move(ctx, id, userID, &req)
+	if err != nil {
+		if errors.Is(err, repository.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to move collection", "details": err.Error()})
+		return
+	}
+
+	if h.activityLogger != nil {
+		orgName := h.orgName(ctx, collection.OrganizationID, userID)
+		h.activityLogger.LogCollectionUpdated(ctx, userID, c.ClientIP(), c.GetHeader("User-Agent"), collection.OrganizationID, orgName, collection.ID, collection.Name)
+	}
+	c.JSON(http.StatusOK, domain.ToCollectionDTO(collection))
+}
+
 // GrantUserAccess godoc
 // @Summary Grant user access to collection
 // @Description Grant or update user access to a collection
@@ -0,0 +1,261 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+type CollectionAccessRequestHandler struct {
+	service        service.CollectionAccessRequestService
+	activityLogger *service.ActivityLogger
+}
+
+func NewCollectionAccessRequestHandler(svc service.CollectionAccessRequestService, activityService service.UserActivityService) *CollectionAccessRequestHandler {
+	return &CollectionAccessRequestHandler{
+		service:        svc,
+		activityLogger: service.NewActivityLogger(activityService),
+	}
+}
+
+// Create godoc
+// @Summary Request temporary collection access
+// @Description Ask the collection's managers for temporary access, with a justification and a duration in hours
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Collection ID"
+// @Param request body domain.CreateCollectionAccessRequest true "Access request"
+// @Success 201 {object} domain.CollectionAccessRequestDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /collections/{id}/access-requests [post]
+func (h *CollectionAccessRequestHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	collectionID, ok := GetUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req domain.CreateCollectionAccessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	request, err := h.service.Create(ctx, collectionID, userID, &req)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "collection not found"})
+			return
+		}
+		h.writeError(c, "failed to request access", err)
+		return
+	}
+
+	h.log(c, domain.ActivityTypeCollectionAccessRequested, request)
+	c.JSON(http.StatusCreated, domain.ToCollectionAccessRequestDTO(request))
+}
+
+// List godoc
+// @Summary List collection access requests
+// @Description List the caller's own access requests and the requests they can review
+// @Tags collections
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param status query string false "Filter by status (pending, approved, denied, cancelled, expired)"
+// @Success 200 {array} domain.CollectionAccessRequestDTO
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/access-requests [get]
+func (h *CollectionAccessRequestHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	var status *domain.CollectionAccessRequestStatus
+	if raw := c.Query("status"); raw != "" {
+		s := domain.CollectionAccessRequestStatus(raw)
+		status = &s
+	}
+
+	requests, err := h.service.List(ctx, orgID, userID, status)
+	if err != nil {
+		h.writeError(c, "failed to list access requests", err)
+		return
+	}
+
+	dtos := make([]*domain.CollectionAccessRequestDTO, len(requests))
+	for i, r := range requests {
+		dtos[i] = domain.ToCollectionAccessRequestDTO(r)
+	}
+	c.JSON(http.StatusOK, dtos)
+}
+
+// Approve godoc
+// @Summary Approve collection access request
+// @Description Grant the requested access until the approved duration has passed
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param requestId path int true "Access request ID"
+// @Param request body domain.ReviewCollectionAccessRequest false "Optional duration override and note"
+// @Success 200 {object} domain.CollectionAccessRequestDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /organizations/{id}/access-requests/{requestId}/approve [post]
+func (h *CollectionAccessRequestHandler) Approve(c *gin.Context) {
+	h.review(c, true)
+}
+
+// Deny godoc
+// @Summary Deny collection access request
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param requestId path int true "Access request ID"
+// @Param request body domain.ReviewCollectionAccessRequest false "Optional note"
+// @Success 200 {object} domain.CollectionAccessRequestDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /organizations/{id}/access-requests/{requestId}/deny [post]
+func (h *CollectionAccessRequestHandler) Deny(c *gin.Context) {
+	h.review(c, false)
+}
+
+func (h *CollectionAccessRequestHandler) review(c *gin.Context, approve bool) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	requestID, ok := GetUintParam(c, "requestId")
+	if !ok {
+		return
+	}
+
+	var req domain.ReviewCollectionAccessRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+			return
+		}
+	}
+
+	if approve {
+		request, err := h.service.Approve(ctx, orgID, requestID, userID, &req)
+		if err != nil {
+			h.writeError(c, "failed to approve access request", err)
+			return
+		}
+		h.log(c, domain.ActivityTypeCollectionAccessApproved, request)
+		c.JSON(http.StatusOK, domain.ToCollectionAccessRequestDTO(request))
+		return
+	}
+
+	request, err := h.service.Deny(ctx, orgID, requestID, userID, &req)
+	if err != nil {
+		h.writeError(c, "failed to deny access request", err)
+		return
+	}
+	h.log(c, domain.ActivityTypeCollectionAccessDenied, request)
+	c.JSON(http.StatusOK, domain.ToCollectionAccessRequestDTO(request))
+}
+
+// Cancel godoc
+// @Summary Cancel collection access request
+// @Description Withdraw one of the caller's pending access requests
+// @Tags collections
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param requestId path int true "Access request ID"
+// @Success 200 {object} domain.CollectionAccessRequestDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /organizations/{id}/access-requests/{requestId}/cancel [post]
+func (h *CollectionAccessRequestHandler) Cancel(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	requestID, ok := GetUintParam(c, "requestId")
+	if !ok {
+		return
+	}
+
+	request, err := h.service.Cancel(ctx, orgID, requestID, userID)
+	if err != nil {
+		h.writeError(c, "failed to cancel access request", err)
+		return
+	}
+
+	h.log(c, domain.ActivityTypeCollectionAccessCancelled, request)
+	c.JSON(http.StatusOK, domain.ToCollectionAccessRequestDTO(request))
+}
+
+func (h *CollectionAccessRequestHandler) log(c *gin.Context, activityType domain.ActivityType, request *domain.CollectionAccessRequest) {
+	details := service.ActivityDetails{
+		service.ActivityFieldOrganizationID:  request.OrganizationID,
+		service.ActivityFieldCollectionID:    request.CollectionID,
+		service.ActivityFieldAccessRequestID: request.ID,
+		service.ActivityFieldStatus:          request.Status,
+	}
+	if request.Collection != nil {
+		details[service.ActivityFieldCollectionName] = request.Collection.Name
+	}
+	if request.OrganizationUser != nil {
+		details[service.ActivityFieldUserID] = request.OrganizationUser.UserID
+	}
+	if request.ExpiresAt != nil {
+		details[service.ActivityFieldExpiresAt] = request.ExpiresAt
+	}
+	if request.Status == domain.AccessRequestStatusPending {
+		details[service.ActivityFieldReason] = request.Justification
+	} else if request.ReviewNote != "" {
+		details[service.ActivityFieldReason] = request.ReviewNote
+	}
+
+	h.activityLogger.LogCustomActivity(c.Request.Context(), GetCurrentUserID(c), activityType, GetIPAddress(c), GetUserAgent(c), details)
+}
+
+func (h *CollectionAccessRequestHandler) writeError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "access request not found"})
+	case errors.Is(err, service.ErrAccessRequestDuplicate),
+		errors.Is(err, service.ErrAccessRequestNotPending),
+		errors.Is(err, service.ErrPermanentGrantExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrInvalidInput),
+		errors.Is(err, service.ErrInvalidAccessDuration),
+		errors.Is(err, service.ErrAccessAlreadyGranted),
+		errors.Is(err, service.ErrCannotReviewOwnRequest):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	}
+}
@@ -1,11 +1,13 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
 	"github.com/passwall/passwall-server/internal/service"
 )
 
@@ -135,11 +137,98 @@ func (h *ExcludedDomainHandler) Check(c *gin.Context) {
 		return
 	}
 
-	isExcluded, err := h.service.IsExcluded(c.Request.Context(), userID, domain)
+	check, err := h.service.Check(c.Request.Context(), userID, domain)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check domain"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"is_excluded": isExcluded})
+	c.JSON(http.StatusOK, check)
+}
+
+// ListOrganization returns the organization-wide excluded domains
+// GET /api/organizations/:id/excluded-domains
+func (h *ExcludedDomainHandler) ListOrganization(c *gin.Context) {
+	userID := GetCurrentUserID(c)
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	domains, err := h.service.ListForOrganization(c.Request.Context(), orgID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get excluded domains"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"excluded_domains": domain.ToOrganizationExcludedDomainDTOs(domains),
+	})
+}
+
+// CreateOrganization adds an organization-wide excluded domain (org admin)
+// POST /api/organizations/:id/excluded-domains
+func (h *ExcludedDomainHandler) CreateOrganization(c *gin.Context) {
+	userID := GetCurrentUserID(c)
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateOrganizationExcludedDomainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	excludedDomain, err := h.service.CreateForOrganization(c.Request.Context(), orgID, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		case errors.Is(err, repository.ErrAlreadyExists):
+			c.JSON(http.StatusConflict, gin.H{"error": "domain already excluded"})
+		case errors.Is(err, repository.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create excluded domain"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, domain.ToOrganizationExcludedDomainDTO(excludedDomain))
+}
+
+// DeleteOrganization removes an organization-wide excluded domain (org admin)
+// DELETE /api/organizations/:id/excluded-domains/:domainId
+func (h *ExcludedDomainHandler) DeleteOrganization(c *gin.Context) {
+	userID := GetCurrentUserID(c)
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("domainId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.service.DeleteForOrganization(c.Request.Context(), orgID, userID, uint(id)); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "excluded domain not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete excluded domain"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "excluded domain deleted"})
 }
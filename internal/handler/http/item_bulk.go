@@ -0,0 +1,358 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/internal/service"
+	"github.com/passwall/passwall-server/pkg/database"
+)
+
+type ItemBulkHandler struct {
+	service           service.ItemBulkService
+	policyEnforcement service.PolicyEnforcementService
+	activityLogger    *service.ActivityLogger
+}
+
+// NewItemBulkHandler creates a new bulk item handler
+func NewItemBulkHandler(
+	svc service.ItemBulkService,
+	activityService service.UserActivityService,
+	policyEnforcement service.PolicyEnforcementService,
+) *ItemBulkHandler {
+	return &ItemBulkHandler{
+		service:           svc,
+		policyEnforcement: policyEnforcement,
+		activityLogger:    service.NewActivityLogger(activityService),
+	}
+}
+
+// ── Personal vault ──────────────────────────────────────────
+
+// Create godoc
+// @Summary Bulk create items
+// @Description Create many personal items in one transaction (e.g. import). Returns a result per item.
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param request body service.BulkCreateItemsRequest true "Items"
+// @Success 200 {object} domain.BulkItemResponse
+// @Failure 400 {object} map[string]string
+// @Failure 402 {object} map[string]string
+// @Router /items/bulk [post]
+func (h *ItemBulkHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	var req service.BulkCreateItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	resp, err := h.service.CreatePersonal(ctx, userID, database.GetSchema(ctx), req.Items)
+	h.respond(c, 0, domain.BulkItemActionCreate, resp, err)
+}
+
+// Move godoc
+// @Summary Bulk move items
+// @Description Move personal items to a folder (or clear their folder)
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param request body domain.BulkMoveItemsRequest true "Items and destination"
+// @Success 200 {object} domain.BulkItemResponse
+// @Failure 400 {object} map[string]string
+// @Router /items/bulk/move [post]
+func (h *ItemBulkHandler) Move(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req domain.BulkMoveItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	resp, err := h.service.MovePersonal(ctx, database.GetSchema(ctx), &req)
+	h.respond(c, 0, domain.BulkItemActionMove, resp, err)
+}
+
+// Delete godoc
+// @Summary Bulk delete items
+// @Description Move personal items to trash
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param request body domain.BulkItemIDsRequest true "Item IDs"
+// @Success 200 {object} domain.BulkItemResponse
+// @Failure 400 {object} map[string]string
+// @Router /items/bulk/delete [post]
+func (h *ItemBulkHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req domain.BulkItemIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	resp, err := h.service.DeletePersonal(ctx, database.GetSchema(ctx), req.IDs)
+	h.respond(c, 0, domain.BulkItemActionDelete, resp, err)
+}
+
+// Restore godoc
+// @Summary Bulk restore items
+// @Description Restore personal items from trash
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param request body domain.BulkItemIDsRequest true "Item IDs"
+// @Success 200 {object} domain.BulkItemResponse
+// @Failure 400 {object} map[string]string
+// @Router /items/bulk/restore [post]
+func (h *ItemBulkHandler) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req domain.BulkItemIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	resp, err := h.service.RestorePersonal(ctx, database.GetSchema(ctx), req.IDs)
+	h.respond(c, 0, domain.BulkItemActionRestore, resp, err)
+}
+
+// Favorite godoc
+// @Summary Bulk favorite items
+// @Description Set or clear the favorite flag on personal items
+// @Tags items
+// @Accept json
+// @Produce json
+// @Param request body domain.BulkFavoriteItemsRequest true "Item IDs and flag"
+// @Success 200 {object} domain.BulkItemResponse
+// @Failure 400 {object} map[string]string
+// @Router /items/bulk/favorite [post]
+func (h *ItemBulkHandler) Favorite(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req domain.BulkFavoriteItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	resp, err := h.service.FavoritePersonal(ctx, database.GetSchema(ctx), &req)
+	h.respond(c, 0, domain.BulkItemActionFavorite, resp, err)
+}
+
+// ── Organization vault ──────────────────────────────────────
+
+// CreateOrg godoc
+// @Summary Bulk create organization items
+// @Description Create many organization items (encrypted with org key) in one transaction. Returns a result per item.
+// @Tags organization-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body service.BulkCreateOrgItemsRequest true "Items"
+// @Success 200 {object} domain.BulkItemResponse
+// @Failure 400 {object} map[string]string
+// @Failure 402 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/items/bulk [post]
+func (h *ItemBulkHandler) CreateOrg(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	var req service.BulkCreateOrgItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	if h.policyEnforcement != nil && hasCardItem(req.Items) {
+		if err := h.policyEnforcement.CheckCardTypeAllowed(ctx, orgID); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	resp, err := h.service.CreateOrg(ctx, orgID, userID, req.Items)
+	h.respond(c, orgID, domain.BulkItemActionCreate, resp, err)
+}
+
+// MoveOrg godoc
+// @Summary Bulk move organization items
+// @Description Move organization items to a collection and/or folder
+// @Tags organization-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body domain.BulkMoveItemsRequest true "Items and destination"
+// @Success 200 {object} domain.BulkItemResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/items/bulk/move [post]
+func (h *ItemBulkHandler) MoveOrg(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.BulkMoveItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	resp, err := h.service.MoveOrg(ctx, orgID, userID, &req)
+	h.respond(c, orgID, domain.BulkItemActionMove, resp, err)
+}
+
+// DeleteOrg godoc
+// @Summary Bulk delete organization items
+// @Description Move organization items to trash
+// @Tags organization-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body domain.BulkItemIDsRequest true "Item IDs"
+// @Success 200 {object} domain.BulkItemResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/items/bulk/delete [post]
+func (h *ItemBulkHandler) DeleteOrg(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.BulkItemIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	resp, err := h.service.DeleteOrg(ctx, orgID, userID, req.IDs)
+	h.respond(c, orgID, domain.BulkItemActionDelete, resp, err)
+}
+
+// RestoreOrg godoc
+// @Summary Bulk restore organization items
+// @Description Restore organization items from trash
+// @Tags organization-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body domain.BulkItemIDsRequest true "Item IDs"
+// @Success 200 {object} domain.BulkItemResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/items/bulk/restore [post]
+func (h *ItemBulkHandler) RestoreOrg(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.BulkItemIDsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	resp, err := h.service.RestoreOrg(ctx, orgID, userID, req.IDs)
+	h.respond(c, orgID, domain.BulkItemActionRestore, resp, err)
+}
+
+// FavoriteOrg godoc
+// @Summary Bulk favorite organization items
+// @Description Set or clear the favorite flag on organization items
+// @Tags organization-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body domain.BulkFavoriteItemsRequest true "Item IDs and flag"
+// @Success 200 {object} domain.BulkItemResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/items/bulk/favorite [post]
+func (h *ItemBulkHandler) FavoriteOrg(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.BulkFavoriteItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	resp, err := h.service.FavoriteOrg(ctx, orgID, userID, &req)
+	h.respond(c, orgID, domain.BulkItemActionFavorite, resp, err)
+}
+
+// respond writes the bulk result (or maps the error) and logs one activity for the batch
+func (h *ItemBulkHandler) respond(c *gin.Context, orgID uint, action domain.BulkItemAction, resp *domain.BulkItemResponse, err error) {
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBulkEmpty),
+			errors.Is(err, service.ErrBulkTooManyItems),
+			errors.Is(err, service.ErrInvalidBulkRequest):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrPlanLimitReached):
+			c.JSON(http.StatusPaymentRequired, gin.H{"error": "item limit of your plan would be exceeded"})
+		case errors.Is(err, repository.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process items"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+
+	// Log activity (no secrets)
+	if h.activityLogger != nil && resp.Succeeded > 0 {
+		details := service.ActivityDetails{
+			"action":    string(action),
+			"succeeded": resp.Succeeded,
+			"failed":    resp.Failed,
+		}
+		if orgID != 0 {
+			details[service.ActivityFieldOrganizationID] = orgID
+		}
+		h.activityLogger.LogCustomActivity(c.Request.Context(), GetCurrentUserID(c), domain.ActivityTypeItemsBulk, GetIPAddress(c), GetUserAgent(c), details)
+	}
+}
+
+func hasCardItem(items []*service.CreateOrgItemRequest) bool {
+	for _, item := range items {
+		if item != nil && item.ItemType == domain.ItemTypeCard {
+			return true
+		}
+	}
+	return false
+}
@@ -79,11 +79,12 @@ func (h *ItemShareHandler) Create(c *gin.Context) {
 		ExpiresAt:        req.ExpiresAt,
 	})
 	if err != nil {
-		if errors.Is(err, service.ErrShareInviteSent) {
-			c.JSON(http.StatusAccepted, gin.H{
-				"invitation_sent": true,
-				"message":         "Recipient is not registered. Signup email sent.",
-			})
+		if errors.Is(err, service.ErrExternalSharingDisabled) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrSharePendingExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
 		if errors.Is(err, repository.ErrInvalidInput) {
@@ -98,6 +99,10 @@ func (h *ItemShareHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if result.Share.IsPending() {
+		c.JSON(http.StatusAccepted, pendingShareResponse(result))
+		return
+	}
 	c.JSON(http.StatusCreated, buildItemShareResponse(result, true))
 }
 
@@ -296,11 +301,12 @@ func (h *ItemShareHandler) ReShare(c *gin.Context) {
 		ExpiresAt:        req.ExpiresAt,
 	})
 	if err != nil {
-		if errors.Is(err, service.ErrShareInviteSent) {
-			c.JSON(http.StatusAccepted, gin.H{
-				"invitation_sent": true,
-				"message":         "Recipient is not registered. Signup email sent.",
-			})
+		if errors.Is(err, service.ErrExternalSharingDisabled) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrSharePendingExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
 		if errors.Is(err, repository.ErrForbidden) {
@@ -319,9 +325,88 @@ func (h *ItemShareHandler) ReShare(c *gin.Context) {
 		return
 	}
 
+	if result.Share.IsPending() {
+		c.JSON(http.StatusAccepted, pendingShareResponse(result))
+		return
+	}
 	c.JSON(http.StatusCreated, buildItemShareResponse(result, false))
 }
 
+// ListPending handles GET /api/item-shares/pending
+// Shares to emails that had no account yet. ready_to_complete is set once the recipient
+// has signed up and published a public key; the client then wraps the item key with
+// recipient_public_key and calls CompletePending.
+func (h *ItemShareHandler) ListPending(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	shares, err := h.service.ListPending(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list pending shares"})
+		return
+	}
+
+	response := make([]gin.H, 0, len(shares))
+	for _, share := range shares {
+		resp := buildItemShareResponse(share, false)
+		ready := share.Recipient != nil && share.Recipient.RSAPublicKey != nil && *share.Recipient.RSAPublicKey != ""
+		resp["ready_to_complete"] = ready
+		if ready {
+			resp["recipient_user_id"] = share.Recipient.ID
+			resp["recipient_public_key"] = *share.Recipient.RSAPublicKey
+		}
+		response = append(response, resp)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"shares": response})
+}
+
+type completePendingShareRequest struct {
+	EncryptedKey string `json:"encrypted_key" binding:"required"`
+}
+
+// CompletePending handles POST /api/item-shares/:uuid/complete
+func (h *ItemShareHandler) CompletePending(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	shareUUID, ok := GetStringParam(c, "uuid")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "share uuid is required"})
+		return
+	}
+
+	var req completePendingShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	result, err := h.service.CompletePending(ctx, userID, shareUUID, req.EncryptedKey)
+	if err != nil {
+		if errors.Is(err, service.ErrExternalSharingDisabled) || errors.Is(err, repository.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "share not found"})
+			return
+		}
+		if errors.Is(err, service.ErrShareNotPending) || errors.Is(err, service.ErrShareRecipientNotReady) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to complete share"})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildItemShareResponse(result, false))
+}
+
 // Revoke handles DELETE /api/item-shares/:id
 func (h *ItemShareHandler) Revoke(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -381,9 +466,20 @@ func buildItemShareResponse(result *service.ItemShareWithItem, includeEncryptedK
 		resp["shared_with_email"] = share.SharedWithUser.Email
 		resp["shared_with_name"] = share.SharedWithUser.Name
 	}
+	if share.IsPending() {
+		resp["pending"] = true
+		resp["shared_with_email"] = share.PendingEmail
+	}
 	if includeEncryptedKey {
 		resp["encrypted_key"] = share.EncryptedKey
 	}
 
 	return resp
 }
+
+func pendingShareResponse(result *service.ItemShareWithItem) gin.H {
+	resp := buildItemShareResponse(result, false)
+	resp["invitation_sent"] = true
+	resp["message"] = "Recipient is not registered. Signup email sent; complete the share once they have signed up."
+	return resp
+}
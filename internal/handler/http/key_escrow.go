@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/service"
 	"github.com/passwall/passwall-server/pkg/logger"
@@ -115,7 +116,7 @@ func (h *KeyEscrowHandler) Revoke(c *gin.Context) {
 
 	// Allow: admin revoking any user, or user revoking themselves
 	if callerID != targetUserID {
-		if !h.ensureOrgAdmin(c, ctx, callerID, orgID) {
+		if !h.ensureOrgPermission(c, ctx, callerID, orgID, domain.OrgPermManageSSO) {
 			return
 		}
 	} else {
@@ -143,14 +144,14 @@ func (h *KeyEscrowHandler) ensureOrgMember(c *gin.Context, ctx context.Context,
 	return true
 }
 
-func (h *KeyEscrowHandler) ensureOrgAdmin(c *gin.Context, ctx context.Context, userID, orgID uint) bool {
+func (h *KeyEscrowHandler) ensureOrgPermission(c *gin.Context, ctx context.Context, userID, orgID uint, perm domain.OrgPermission) bool {
 	membership, err := h.orgService.GetMembership(ctx, userID, orgID)
 	if err != nil || membership == nil {
 		c.JSON(http.StatusForbidden, gin.H{"error": "organization access denied"})
 		return false
 	}
-	if !membership.IsAdmin() {
-		c.JSON(http.StatusForbidden, gin.H{"error": "organization admin access required"})
+	if !authz.HasPermission(membership, perm) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "organization permission required: " + string(perm)})
 		return false
 	}
 	return true
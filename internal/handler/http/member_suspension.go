@@ -0,0 +1,131 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+type MemberSuspensionHandler struct {
+	service        service.MemberSuspensionService
+	activityLogger *service.ActivityLogger
+}
+
+func NewMemberSuspensionHandler(svc service.MemberSuspensionService, activityService service.UserActivityService) *MemberSuspensionHandler {
+	return &MemberSuspensionHandler{
+		service:        svc,
+		activityLogger: service.NewActivityLogger(activityService),
+	}
+}
+
+// Suspend godoc
+// @Summary Suspend organization member
+// @Description Suspend a member: access, escrowed key and sessions are revoked while their data is kept
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param userId path int true "Organization User ID"
+// @Param request body domain.SuspendOrgUserRequest false "Suspension reason"
+// @Success 200 {object} domain.OrganizationUserDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/members/{userId}/suspend [post]
+func (h *MemberSuspensionHandler) Suspend(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	orgUserID, ok := GetUintParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	var req domain.SuspendOrgUserRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+			return
+		}
+	}
+
+	orgUser, err := h.service.Suspend(ctx, orgID, orgUserID, userID, &req)
+	if err != nil {
+		h.writeError(c, "failed to suspend member", err)
+		return
+	}
+
+	h.activityLogger.LogCustomActivity(ctx, userID, domain.ActivityTypeMemberSuspended, GetIPAddress(c), GetUserAgent(c), service.ActivityDetails{
+		service.ActivityFieldOrganizationID: orgID,
+		service.ActivityFieldUserID:         orgUser.UserID,
+		service.ActivityFieldReason:         orgUser.SuspensionReason,
+	})
+
+	c.JSON(http.StatusOK, domain.ToOrganizationUserDTO(orgUser))
+}
+
+// Restore godoc
+// @Summary Restore suspended organization member
+// @Description Reactivate a suspended member. The member takes a seat again.
+// @Tags organizations
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param userId path int true "Organization User ID"
+// @Success 200 {object} domain.OrganizationUserDTO
+// @Failure 400 {object} map[string]string
+// @Failure 402 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/members/{userId}/restore [post]
+func (h *MemberSuspensionHandler) Restore(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	orgUserID, ok := GetUintParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	orgUser, err := h.service.Restore(ctx, orgID, orgUserID, userID)
+	if err != nil {
+		h.writeError(c, "failed to restore member", err)
+		return
+	}
+
+	h.activityLogger.LogCustomActivity(ctx, userID, domain.ActivityTypeMemberRestored, GetIPAddress(c), GetUserAgent(c), service.ActivityDetails{
+		service.ActivityFieldOrganizationID: orgID,
+		service.ActivityFieldUserID:         orgUser.UserID,
+	})
+
+	c.JSON(http.StatusOK, domain.ToOrganizationUserDTO(orgUser))
+}
+
+func (h *MemberSuspensionHandler) writeError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "member not found"})
+	case isSeatError(err):
+		c.JSON(http.StatusPaymentRequired, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCannotSuspendOwner),
+		errors.Is(err, service.ErrCannotSuspendSelf),
+		errors.Is(err, service.ErrMemberNotSuspendable),
+		errors.Is(err, service.ErrMemberNotSuspended):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": msg})
+	}
+}
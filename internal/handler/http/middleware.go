@@ -169,7 +169,7 @@ func CORSMiddleware(cfg *config.ServerConfig) gin.HandlerFunc {
 		// When no Origin header is present (server-to-server, mobile apps,
 		// curl, etc.) no CORS headers are needed.
 
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-User-Schema, X-Recaptcha-Token")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-User-Schema, X-Recaptcha-Token, X-Export-Key")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 
 		if c.Request.Method == "OPTIONS" {
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/pkg/constants"
+)
+
+// OrgResourceResolver returns the ID of the organization that owns a resource
+type OrgResourceResolver func(ctx context.Context, id uint) (uint, error)
+
+// OrgResourceMiddleware reads the numeric ":id" route parameter of a team, collection
+// or organization item, resolves the organization that owns it and stores that in the
+// gin context, so org-scoped middleware also applies outside /organizations.
+// Invalid or unknown IDs pass through; the handlers report them.
+func OrgResourceMiddleware(resolve OrgResourceResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		orgID, err := resolve(c.Request.Context(), uint(id))
+		if err == nil {
+			c.Set(constants.ContextKeyOrgID, orgID)
+		}
+		c.Next()
+	}
+}
+
+// TeamOrgResolver resolves the organization of a team
+func TeamOrgResolver(repo interface {
+	GetByID(ctx context.Context, id uint) (*domain.Team, error)
+}) OrgResourceResolver {
+	return func(ctx context.Context, id uint) (uint, error) {
+		team, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return team.OrganizationID, nil
+	}
+}
+
+// CollectionOrgResolver resolves the organization of a collection
+func CollectionOrgResolver(repo interface {
+	GetByID(ctx context.Context, id uint) (*domain.Collection, error)
+}) OrgResourceResolver {
+	return func(ctx context.Context, id uint) (uint, error) {
+		collection, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return collection.OrganizationID, nil
+	}
+}
+
+// OrganizationItemOrgResolver resolves the organization of an organization item
+func OrganizationItemOrgResolver(repo interface {
+	GetByID(ctx context.Context, id uint) (*domain.OrganizationItem, error)
+}) OrgResourceResolver {
+	return func(ctx context.Context, id uint) (uint, error) {
+		item, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return item.OrganizationID, nil
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/passwall/passwall-server/internal/domain"
@@ -432,6 +433,14 @@ func (h *OrganizationHandler) AcceptInvitation(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to accept this invitation"})
 			return
 		}
+		if isSeatError(err) {
+			c.JSON(http.StatusPaymentRequired, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrInvitationExpired) {
+			c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to accept invitation", "details": err.Error()})
 		return
 	}
@@ -486,3 +495,194 @@ func (h *OrganizationHandler) ConfirmProvisionedMember(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "provisioned member confirmed successfully"})
 }
+
+// ListInvitations godoc
+// @Summary List pending invitations
+// @Description List the organization's pending invitations with their expiry
+// @Tags organizations
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {array} domain.OrganizationInvitationDTO
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/invitations [get]
+func (h *OrganizationHandler) ListInvitations(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.service.ListInvitations(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list invitations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// ResendInvitation godoc
+// @Summary Resend invitation
+// @Description Email a pending invitation again with a new link and a fresh expiry
+// @Tags organizations
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param userId path int true "Organization User ID"
+// @Success 200 {object} domain.OrganizationInvitationDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/invitations/{userId}/resend [post]
+func (h *OrganizationHandler) ResendInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	orgUserID, ok := GetUintParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	invitation, err := h.service.ResendInvitation(ctx, orgID, orgUserID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to resend invitation", "details": err.Error()})
+		return
+	}
+
+	if h.activityLogger != nil {
+		h.activityLogger.LogInvitationSent(ctx, userID, GetIPAddress(c), GetUserAgent(c), invitation.Email)
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke invitation
+// @Description Withdraw a pending invitation; its link stops working immediately
+// @Tags organizations
+// @Param id path int true "Organization ID"
+// @Param userId path int true "Organization User ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/invitations/{userId} [delete]
+func (h *OrganizationHandler) RevokeInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	orgUserID, ok := GetUintParam(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeInvitation(ctx, orgID, orgUserID, userID); err != nil {
+		if errors.Is(err, repository.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to revoke invitation", "details": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// bulkInviteMaxBody caps CSV uploads (500 rows with wrapped keys fit comfortably)
+const bulkInviteMaxBody = 4 << 20
+
+// BulkInvite godoc
+// @Summary Bulk invite users
+// @Description Invite many users at once from JSON, a text/csv body or a multipart "file" upload.
+// @Description CSV columns: email, role, encrypted_org_key, access_all, teams (separated by ";").
+// @Tags organizations
+// @Accept json
+// @Accept text/csv
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body domain.BulkInviteRequest false "Invitations"
+// @Success 200 {object} domain.BulkInviteResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/invitations/bulk [post]
+func (h *OrganizationHandler) BulkInvite(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, bulkInviteMaxBody)
+
+	var rows []domain.BulkInviteRow
+	switch contentType := c.ContentType(); {
+	case strings.HasPrefix(contentType, "multipart/"):
+		file, _, err := c.Request.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "csv file is required"})
+			return
+		}
+		defer file.Close()
+		if rows, err = domain.ParseBulkInviteCSV(file); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	case contentType == "text/csv":
+		var err error
+		if rows, err = domain.ParseBulkInviteCSV(c.Request.Body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	default:
+		var req domain.BulkInviteRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+			return
+		}
+		rows = req.Invitations
+	}
+
+	resp, err := h.service.BulkInvite(ctx, orgID, userID, rows)
+	if err != nil {
+		if errors.Is(err, repository.ErrForbidden) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+			return
+		}
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process invitations"})
+		return
+	}
+
+	if h.activityLogger != nil {
+		for _, r := range resp.Results {
+			if r.Status == domain.BulkInviteStatusInvited {
+				h.activityLogger.LogInvitationSent(ctx, userID, GetIPAddress(c), GetUserAgent(c), r.Email)
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -1,8 +1,11 @@
 package http
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -85,8 +88,9 @@ func (h *OrganizationItemHandler) Create(c *gin.Context) {
 			service.ActivityFieldItemID:         item.ID,
 			service.ActivityFieldItemType:       strconv.FormatInt(int64(item.ItemType), 10),
 		}
-		if item.CollectionID != nil {
-			details[service.ActivityFieldCollectionID] = *item.CollectionID
+		if cid := item.PrimaryCollectionID(); cid != nil {
+			details[service.ActivityFieldCollectionID] = *cid
+			details[service.ActivityFieldCollectionIDs] = item.CollectionIDs
 		}
 		_ = h.activityLogger.LogActivity(ctx, userID, domain.ActivityTypeItemCreated, ipAddress, userAgent, details)
 	}
@@ -165,25 +169,7 @@ func (h *OrganizationItemHandler) ListByOrganization(c *gin.Context) {
 		return
 	}
 
-	dtos := make([]*domain.OrganizationItemDTO, len(items))
-	accessCache := make(map[uint]*bool)
-	for i, item := range items {
-		dtos[i] = domain.ToOrganizationItemDTO(item)
-		if item.CollectionID != nil {
-			cid := *item.CollectionID
-			if _, ok := accessCache[cid]; !ok {
-				access, err := h.service.GetCollectionAccess(ctx, orgID, userID, cid)
-				hide := err == nil && access.HidePasswords
-				accessCache[cid] = &hide
-			}
-			if accessCache[cid] != nil && *accessCache[cid] {
-				dtos[i].HidePasswords = true
-				dtos[i].Data = ""
-			}
-		}
-	}
-
-	c.JSON(http.StatusOK, dtos)
+	c.JSON(http.StatusOK, h.toRedactedDTOs(ctx, userID, items))
 }
 
 // ListByCollection godoc
@@ -214,22 +200,7 @@ func (h *OrganizationItemHandler) ListByCollection(c *gin.Context) {
 		return
 	}
 
-	dtos := make([]*domain.OrganizationItemDTO, len(items))
-	var hidePasswords *bool
-	for i, item := range items {
-		dtos[i] = domain.ToOrganizationItemDTO(item)
-		if hidePasswords == nil && item.CollectionID != nil {
-			access, err := h.service.GetCollectionAccess(ctx, item.OrganizationID, userID, *item.CollectionID)
-			hide := err == nil && access.HidePasswords
-			hidePasswords = &hide
-		}
-		if hidePasswords != nil && *hidePasswords {
-			dtos[i].HidePasswords = true
-			dtos[i].Data = ""
-		}
-	}
-
-	c.JSON(http.StatusOK, dtos)
+	c.JSON(http.StatusOK, h.toRedactedDTOs(ctx, userID, items))
 }
 
 // GetByID godoc
@@ -265,16 +236,7 @@ func (h *OrganizationItemHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	dto := domain.ToOrganizationItemDTO(item)
-	if item.CollectionID != nil {
-		access, err := h.service.GetCollectionAccess(ctx, item.OrganizationID, userID, *item.CollectionID)
-		if err == nil && access.HidePasswords {
-			dto.HidePasswords = true
-			dto.Data = ""
-		}
-	}
-
-	c.JSON(http.StatusOK, dto)
+	c.JSON(http.StatusOK, h.toRedactedDTOs(ctx, userID, []*domain.OrganizationItem{item})[0])
 }
 
 // Update godoc
@@ -325,8 +287,9 @@ func (h *OrganizationItemHandler) Update(c *gin.Context) {
 			service.ActivityFieldItemID:         item.ID,
 			service.ActivityFieldItemType:       strconv.FormatInt(int64(item.ItemType), 10),
 		}
-		if item.CollectionID != nil {
-			details[service.ActivityFieldCollectionID] = *item.CollectionID
+		if cid := item.PrimaryCollectionID(); cid != nil {
+			details[service.ActivityFieldCollectionID] = *cid
+			details[service.ActivityFieldCollectionIDs] = item.CollectionIDs
 		}
 		_ = h.activityLogger.LogActivity(ctx, userID, domain.ActivityTypeItemUpdated, ipAddress, userAgent, details)
 	}
@@ -370,8 +333,9 @@ func (h *OrganizationItemHandler) Delete(c *gin.Context) {
 			service.ActivityFieldItemID:         item.ID,
 			service.ActivityFieldItemType:       strconv.FormatInt(int64(item.ItemType), 10),
 		}
-		if item.CollectionID != nil {
-			details[service.ActivityFieldCollectionID] = *item.CollectionID
+		if cid := item.PrimaryCollectionID(); cid != nil {
+			details[service.ActivityFieldCollectionID] = *cid
+			details[service.ActivityFieldCollectionIDs] = item.CollectionIDs
 		}
 		_ = h.activityLogger.LogActivity(ctx, userID, domain.ActivityTypeItemDeleted, ipAddress, userAgent, details)
 	}
@@ -415,3 +379,36 @@ func (h *OrganizationItemHandler) AutofillSecret(c *gin.Context) {
 		"data": item.Data,
 	})
 }
+
+// toRedactedDTOs converts items to DTOs, dropping the encrypted data of items the user
+// only reaches through hide_passwords collections. Items sharing the same collections
+// share one access lookup.
+func (h *OrganizationItemHandler) toRedactedDTOs(ctx context.Context, userID uint, items []*domain.OrganizationItem) []*domain.OrganizationItemDTO {
+	dtos := make([]*domain.OrganizationItemDTO, len(items))
+	hiddenBySet := make(map[string]bool)
+	for i, item := range items {
+		dtos[i] = domain.ToOrganizationItemDTO(item)
+		if len(item.CollectionIDs) == 0 {
+			continue
+		}
+
+		key := collectionSetKey(item.OrganizationID, item.CollectionIDs)
+		hide, ok := hiddenBySet[key]
+		if !ok {
+			access, err := h.service.GetItemAccess(ctx, item, userID)
+			hide = err == nil && access.HidePasswords
+			hiddenBySet[key] = hide
+		}
+		if hide {
+			dtos[i].HidePasswords = true
+			dtos[i].Data = ""
+		}
+	}
+	return dtos
+}
+
+func collectionSetKey(orgID uint, collectionIDs []uint) string {
+	ids := append([]uint(nil), collectionIDs...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return fmt.Sprint(orgID, ids)
+}
@@ -57,6 +57,10 @@ func (s *stubOrganizationItemService) GetCollectionAccess(ctx context.Context, o
 	return &authz.CollectionAccess{CanRead: true}, nil
 }
 
+func (s *stubOrganizationItemService) GetItemAccess(ctx context.Context, item *domain.OrganizationItem, userID uint) (*authz.CollectionAccess, error) {
+	return &authz.CollectionAccess{CanRead: true}, nil
+}
+
 func (s *stubOrganizationItemService) GetAutofillSecret(ctx context.Context, itemID, userID uint) (*domain.OrganizationItem, error) {
 	return nil, repository.ErrNotFound
 }
@@ -82,11 +86,11 @@ func (s *stubPolicyEnforcementService) CheckExternalSharingAllowed(ctx context.C
 	return nil
 }
 
-func (s *stubPolicyEnforcementService) CheckPersonalExportAllowed(ctx context.Context, orgID uint, role domain.OrganizationRole) error {
+func (s *stubPolicyEnforcementService) CheckPersonalExportAllowed(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser) error {
 	return nil
 }
 
-func (s *stubPolicyEnforcementService) CheckSendAllowed(ctx context.Context, orgID uint, role domain.OrganizationRole) error {
+func (s *stubPolicyEnforcementService) CheckSendAllowed(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser) error {
 	return nil
 }
 
@@ -99,7 +103,7 @@ func (s *stubPolicyEnforcementService) CheckCardTypeAllowed(ctx context.Context,
 	return s.checkCardErr
 }
 
-func (s *stubPolicyEnforcementService) CheckPersonalVaultAllowed(ctx context.Context, orgID uint, role domain.OrganizationRole) error {
+func (s *stubPolicyEnforcementService) CheckPersonalVaultAllowed(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser) error {
 	return nil
 }
 
@@ -0,0 +1,214 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+type OrganizationRoleHandler struct {
+	service service.OrganizationRoleService
+}
+
+func NewOrganizationRoleHandler(svc service.OrganizationRoleService) *OrganizationRoleHandler {
+	return &OrganizationRoleHandler{service: svc}
+}
+
+// GetCatalog godoc
+// @Summary Get permission catalog
+// @Description List every grantable permission and the permissions of the built-in roles
+// @Tags organizations
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} domain.OrgPermissionCatalogDTO
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/roles/permissions [get]
+func (h *OrganizationRoleHandler) GetCatalog(c *gin.Context) {
+	userID := GetCurrentUserID(c)
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	catalog, err := h.service.GetCatalog(c.Request.Context(), orgID, userID)
+	if err != nil {
+		h.respondError(c, err, "failed to get permission catalog")
+		return
+	}
+
+	c.JSON(http.StatusOK, catalog)
+}
+
+// GetMyPermissions godoc
+// @Summary Get my organization permissions
+// @Description Get the requesting member's effective permissions in the organization
+// @Tags organizations
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} domain.MyOrgPermissionsDTO
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/permissions [get]
+func (h *OrganizationRoleHandler) GetMyPermissions(c *gin.Context) {
+	userID := GetCurrentUserID(c)
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	perms, err := h.service.GetMyPermissions(c.Request.Context(), orgID, userID)
+	if err != nil {
+		h.respondError(c, err, "failed to get permissions")
+		return
+	}
+
+	c.JSON(http.StatusOK, perms)
+}
+
+// List godoc
+// @Summary List custom roles
+// @Description List the organization's custom roles
+// @Tags organizations
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {array} domain.OrganizationCustomRoleDTO
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/roles [get]
+func (h *OrganizationRoleHandler) List(c *gin.Context) {
+	userID := GetCurrentUserID(c)
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	roles, err := h.service.List(c.Request.Context(), orgID, userID)
+	if err != nil {
+		h.respondError(c, err, "failed to list roles")
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// Create godoc
+// @Summary Create custom role
+// @Description Create a custom role; only permissions the requester holds can be granted
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body domain.CreateOrganizationCustomRoleRequest true "Role details"
+// @Success 201 {object} domain.OrganizationCustomRoleDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /organizations/{id}/roles [post]
+func (h *OrganizationRoleHandler) Create(c *gin.Context) {
+	userID := GetCurrentUserID(c)
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CreateOrganizationCustomRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	role, err := h.service.Create(c.Request.Context(), orgID, userID, &req)
+	if err != nil {
+		h.respondError(c, err, "failed to create role")
+		return
+	}
+
+	c.JSON(http.StatusCreated, role)
+}
+
+// Update godoc
+// @Summary Update custom role
+// @Description Update a custom role's name, description or permissions
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param roleId path int true "Custom role ID"
+// @Param request body domain.UpdateOrganizationCustomRoleRequest true "Role changes"
+// @Success 200 {object} domain.OrganizationCustomRoleDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /organizations/{id}/roles/{roleId} [put]
+func (h *OrganizationRoleHandler) Update(c *gin.Context) {
+	userID := GetCurrentUserID(c)
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+	roleID, ok := GetUintParam(c, "roleId")
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateOrganizationCustomRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	role, err := h.service.Update(c.Request.Context(), orgID, userID, roleID, &req)
+	if err != nil {
+		h.respondError(c, err, "failed to update role")
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// Delete godoc
+// @Summary Delete custom role
+// @Description Delete a custom role; members holding it keep their built-in role
+// @Tags organizations
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param roleId path int true "Custom role ID"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /organizations/{id}/roles/{roleId} [delete]
+func (h *OrganizationRoleHandler) Delete(c *gin.Context) {
+	userID := GetCurrentUserID(c)
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+	roleID, ok := GetUintParam(c, "roleId")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), orgID, userID, roleID); err != nil {
+		h.respondError(c, err, "failed to delete role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "role deleted"})
+}
+
+func (h *OrganizationRoleHandler) respondError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "role not found"})
+	case errors.Is(err, repository.ErrAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "a role with this name already exists"})
+	case errors.Is(err, repository.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
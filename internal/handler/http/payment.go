@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 type PaymentHandler struct {
 	service             service.PaymentService
 	subscriptionService service.SubscriptionService
+	seatService         service.SeatService
 	orgRepo             repository.OrganizationRepository
 	orgUserRepo         interface {
 		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
@@ -24,6 +26,7 @@ type PaymentHandler struct {
 func NewPaymentHandler(
 	service service.PaymentService,
 	subscriptionService service.SubscriptionService,
+	seatService service.SeatService,
 	orgRepo repository.OrganizationRepository,
 	orgUserRepo interface {
 		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
@@ -32,6 +35,7 @@ func NewPaymentHandler(
 	return &PaymentHandler{
 		service:             service,
 		subscriptionService: subscriptionService,
+		seatService:         seatService,
 		orgRepo:             orgRepo,
 		orgUserRepo:         orgUserRepo,
 	}
@@ -106,8 +110,12 @@ func (h *PaymentHandler) CreateCheckoutSession(c *gin.Context) {
 	userAgent := c.Request.UserAgent()
 
 	// Create checkout session
-	checkoutURL, err := h.service.CreateCheckoutSession(ctx, orgID, userID, req.Plan, req.BillingCycle, req.Seats, ipAddress, userAgent)
+	checkoutURL, err := h.service.CreateCheckoutSession(ctx, orgID, userID, req.Plan, req.BillingCycle, req.Seats, req.PromoCode, ipAddress, userAgent)
 	if err != nil {
+		if isPromoCodeError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create checkout session", "details": err.Error()})
 		return
 	}
@@ -259,6 +267,37 @@ func (h *PaymentHandler) SyncSubscription(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Subscription synced successfully"})
 }
 
+// GetSeatStatus godoc
+// @Summary Get seat usage
+// @Description Returns the seat management mode, seat usage and the prorated cost of the next seat (automatic mode)
+// @Tags payments
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} domain.SeatStatusDTO
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations/{id}/subscription/seats [get]
+func (h *PaymentHandler) GetSeatStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	if _, ok := h.requireBillingManager(c, ctx, orgID); !ok {
+		return
+	}
+
+	status, err := h.seatService.Status(ctx, orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get seat status"})
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
 // PreviewSeatChange godoc
 // @Summary Preview user license change cost
 // @Description Returns the prorated cost impact of changing user count without applying changes
@@ -407,12 +446,16 @@ func (h *PaymentHandler) PreviewPlanChange(c *gin.Context) {
 		return
 	}
 
-	preview, err := h.service.PreviewPlanChange(ctx, orgID, userID, req.Plan, req.BillingCycle, req.Seats)
+	preview, err := h.service.PreviewPlanChange(ctx, orgID, userID, req.Plan, req.BillingCycle, req.Seats, req.PromoCode)
 	if err != nil {
 		if strings.Contains(err.Error(), "forbidden") {
 			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 			return
 		}
+		if isPromoCodeError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -463,12 +506,16 @@ func (h *PaymentHandler) ChangePlan(c *gin.Context) {
 	ipAddress := c.ClientIP()
 	userAgent := c.Request.UserAgent()
 
-	result, err := h.service.ChangePlan(ctx, orgID, userID, req.Plan, req.BillingCycle, req.Seats, ipAddress, userAgent)
+	result, err := h.service.ChangePlan(ctx, orgID, userID, req.Plan, req.BillingCycle, req.Seats, req.PromoCode, ipAddress, userAgent)
 	if err != nil {
 		if strings.Contains(err.Error(), "forbidden") {
 			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 			return
 		}
+		if isPromoCodeError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		if isExternalProviderError(err) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
@@ -480,11 +527,65 @@ func (h *PaymentHandler) ChangePlan(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// ValidatePromoCode godoc
+// @Summary Validate promo code
+// @Description Checks a promo code for the organization and plan and returns the discount it grants
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param request body ValidatePromoCodeRequest true "Promo code"
+// @Success 200 {object} domain.DiscountDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /organizations/{id}/billing/promo-code/validate [post]
+func (h *PaymentHandler) ValidatePromoCode(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := h.requireBillingManager(c, ctx, orgID)
+	if !ok {
+		return
+	}
+
+	var req ValidatePromoCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	discount, err := h.service.ValidatePromoCode(ctx, orgID, userID, req.Code, req.Plan)
+	if err != nil {
+		if strings.Contains(err.Error(), "forbidden") {
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+		if isPromoCodeError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to validate promo code"})
+		return
+	}
+
+	c.JSON(http.StatusOK, discount)
+}
+
 // Request/Response types
 type CreateCheckoutRequest struct {
 	Plan         string `json:"plan" binding:"required"`          // pro, family, team, business
 	BillingCycle string `json:"billing_cycle" binding:"required"` // monthly, yearly
 	Seats        int    `json:"seats" binding:"required"`         // user count (quantity). Use 1 for non-per-user plans.
+	PromoCode    string `json:"promo_code,omitempty"`             // optional coupon code
+}
+
+type ValidatePromoCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+	Plan string `json:"plan"` // optional base plan to check plan restrictions
 }
 
 type CreateCheckoutResponse struct {
@@ -506,6 +607,20 @@ func isExternalProviderError(err error) bool {
 		strings.Contains(msg, "Play Store") || strings.Contains(msg, "directly"))
 }
 
+// isSeatError checks if the error is a seat management rejection (no free seat or seat cap reached).
+// These are returned as 402 so clients can point admins to billing.
+func isSeatError(err error) bool {
+	return errors.Is(err, service.ErrSeatLimitReached) || errors.Is(err, service.ErrSeatCapReached)
+}
+
+// isPromoCodeError checks if the error is a user-facing promo code validation error
+func isPromoCodeError(err error) bool {
+	return errors.Is(err, service.ErrPromoCodeNotFound) ||
+		errors.Is(err, service.ErrPromoCodeNotRedeemable) ||
+		errors.Is(err, service.ErrPromoCodeNotApplicable) ||
+		errors.Is(err, service.ErrPromoCodeAlreadyRedeemed)
+}
+
 func (h *PaymentHandler) requireBillingViewer(c *gin.Context, ctx context.Context, orgID uint) (uint, bool) {
 	userID, err := GetUserID(c)
 	if err != nil {
@@ -517,7 +632,7 @@ func (h *PaymentHandler) requireBillingViewer(c *gin.Context, ctx context.Contex
 		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return 0, false
 	}
-	if authz.CanViewBilling(orgUser.Role) {
+	if authz.HasPermission(orgUser, domain.OrgPermViewBilling) {
 		return userID, true
 	}
 	c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
@@ -535,7 +650,7 @@ func (h *PaymentHandler) requireBillingManager(c *gin.Context, ctx context.Conte
 		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return 0, false
 	}
-	if authz.CanManageBilling(orgUser.Role) {
+	if authz.HasPermission(orgUser, domain.OrgPermManageBilling) {
 		return userID, true
 	}
 	c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
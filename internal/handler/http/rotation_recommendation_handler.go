@@ -0,0 +1,66 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+// RotationRecommendationHandler handles the "rotate recommended" flags raised by breach monitoring.
+type RotationRecommendationHandler struct {
+	service service.RotationRecommendationService
+}
+
+// NewRotationRecommendationHandler creates a new rotation recommendation handler.
+func NewRotationRecommendationHandler(svc service.RotationRecommendationService) *RotationRecommendationHandler {
+	return &RotationRecommendationHandler{service: svc}
+}
+
+// List handles GET /api/rotation-recommendations
+func (h *RotationRecommendationHandler) List(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	recs, err := h.service.List(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recs)
+}
+
+// Resolve handles POST /api/rotation-recommendations/:id/resolve
+func (h *RotationRecommendationHandler) Resolve(c *gin.Context) {
+	userID, err := GetUserID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	recID, ok := GetUintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Resolve(c.Request.Context(), userID, recID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "rotation recommendation resolved"})
+}
+
+func (h *RotationRecommendationHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "rotation recommendation not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/service"
 )
@@ -41,7 +42,7 @@ func (h *SCIMHandler) CreateToken(c *gin.Context) {
 		return
 	}
 	userID := GetCurrentUserID(c)
-	if !h.ensureOrgAdmin(c, ctx, userID, orgID) {
+	if !h.ensureOrgPermission(c, ctx, userID, orgID, domain.OrgPermManageSCIM) {
 		return
 	}
 
@@ -69,7 +70,7 @@ func (h *SCIMHandler) ListTokens(c *gin.Context) {
 		return
 	}
 	userID := GetCurrentUserID(c)
-	if !h.ensureOrgAdmin(c, ctx, userID, orgID) {
+	if !h.ensureOrgPermission(c, ctx, userID, orgID, domain.OrgPermManageSCIM) {
 		return
 	}
 
@@ -91,7 +92,7 @@ func (h *SCIMHandler) RevokeToken(c *gin.Context) {
 		return
 	}
 	userID := GetCurrentUserID(c)
-	if !h.ensureOrgAdmin(c, ctx, userID, orgID) {
+	if !h.ensureOrgPermission(c, ctx, userID, orgID, domain.OrgPermManageSCIM) {
 		return
 	}
 
@@ -273,6 +274,10 @@ func (h *SCIMHandler) CreateUser(c *gin.Context) {
 			scimError(c, http.StatusConflict, "User already exists in organization")
 			return
 		}
+		if isSeatError(err) {
+			scimError(c, http.StatusPaymentRequired, err.Error())
+			return
+		}
 		if errors.Is(err, service.ErrSCIMProvisioningBlocked) {
 			scimError(c, http.StatusNotImplemented, err.Error())
 			return
@@ -302,6 +307,10 @@ func (h *SCIMHandler) UpdateUser(c *gin.Context) {
 			scimError(c, http.StatusNotFound, "User not found")
 			return
 		}
+		if isSeatError(err) {
+			scimError(c, http.StatusPaymentRequired, err.Error())
+			return
+		}
 		scimError(c, http.StatusInternalServerError, "failed to update user")
 		return
 	}
@@ -327,6 +336,10 @@ func (h *SCIMHandler) PatchUser(c *gin.Context) {
 			scimError(c, http.StatusNotFound, "User not found")
 			return
 		}
+		if isSeatError(err) {
+			scimError(c, http.StatusPaymentRequired, err.Error())
+			return
+		}
 		scimError(c, http.StatusInternalServerError, "failed to patch user")
 		return
 	}
@@ -488,14 +501,14 @@ func scimError(c *gin.Context, status int, detail string) {
 	})
 }
 
-func (h *SCIMHandler) ensureOrgAdmin(c *gin.Context, ctx context.Context, userID, orgID uint) bool {
+func (h *SCIMHandler) ensureOrgPermission(c *gin.Context, ctx context.Context, userID, orgID uint, perm domain.OrgPermission) bool {
 	membership, err := h.orgService.GetMembership(ctx, userID, orgID)
 	if err != nil || membership == nil {
 		c.JSON(http.StatusForbidden, gin.H{"error": "organization access denied"})
 		return false
 	}
-	if !membership.IsAdmin() {
-		c.JSON(http.StatusForbidden, gin.H{"error": "organization admin access required"})
+	if !authz.HasPermission(membership, perm) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "organization permission required: " + string(perm)})
 		return false
 	}
 	return true
@@ -2,7 +2,9 @@ package http
 
 import (
 	"errors"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +13,12 @@ import (
 	"github.com/passwall/passwall-server/internal/service"
 )
 
+// sendAccessTokenHeader carries the token issued to a verified recipient of a restricted send
+const sendAccessTokenHeader = "X-Send-Access-Token"
+
+// sendFileTransferTimeout replaces the server read/write timeout for file uploads and downloads
+const sendFileTransferTimeout = 30 * time.Minute
+
 type SendHandler struct {
 	service service.SendService
 }
@@ -25,11 +33,13 @@ type createSendRequest struct {
 	Type           string     `json:"type" binding:"required"`
 	Data           string     `json:"data" binding:"required"`
 	Notes          *string    `json:"notes,omitempty"`
+	FileSize       int64      `json:"file_size,omitempty"`
 	Password       *string    `json:"password,omitempty"`
 	MaxAccessCount *int       `json:"max_access_count,omitempty"`
 	ExpirationDate *time.Time `json:"expiration_date,omitempty"`
 	DeletionDate   *time.Time `json:"deletion_date,omitempty"`
 	HideEmail      bool       `json:"hide_email"`
+	Recipients     []string   `json:"recipients,omitempty"`
 }
 
 type updateSendRequest struct {
@@ -42,6 +52,7 @@ type updateSendRequest struct {
 	DeletionDate   *time.Time `json:"deletion_date,omitempty"`
 	Disabled       *bool      `json:"disabled,omitempty"`
 	HideEmail      *bool      `json:"hide_email,omitempty"`
+	Recipients     *[]string  `json:"recipients,omitempty"`
 }
 
 // Create handles POST /api/sends
@@ -61,15 +72,25 @@ func (h *SendHandler) Create(c *gin.Context) {
 		Type:           domain.SendType(req.Type),
 		Data:           req.Data,
 		Notes:          req.Notes,
+		FileSize:       req.FileSize,
 		Password:       req.Password,
 		MaxAccessCount: req.MaxAccessCount,
 		ExpirationDate: req.ExpirationDate,
 		DeletionDate:   req.DeletionDate,
 		HideEmail:      req.HideEmail,
+		Recipients:     req.Recipients,
 	})
 	if err != nil {
 		if errors.Is(err, repository.ErrInvalidInput) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid send data"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid send data", "details": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrSendFileTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file exceeds the size limit of your plan"})
+			return
+		}
+		if errors.Is(err, service.ErrFeatureNotAvailable) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "file sends are not available on your current plan"})
 			return
 		}
 		if errors.Is(err, repository.ErrForbidden) {
@@ -83,6 +104,46 @@ func (h *SendHandler) Create(c *gin.Context) {
 	c.JSON(http.StatusCreated, domain.ToSendDTO(send))
 }
 
+// UploadFile handles PUT /api/sends/:uuid/file
+// The body is the client-encrypted file content (application/octet-stream) of exactly file_size bytes.
+func (h *SendHandler) UploadFile(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	sendUUID, ok := GetStringParam(c, "uuid")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "uuid is required"})
+		return
+	}
+
+	extendTransferDeadline(c)
+
+	send, err := h.service.UploadFile(ctx, userID, sendUUID, c.Request.Body)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "send not found"})
+		case errors.Is(err, repository.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		case errors.Is(err, repository.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "send is not a file send"})
+		case errors.Is(err, service.ErrSendFileAlreadyUploaded):
+			c.JSON(http.StatusConflict, gin.H{"error": "file already uploaded"})
+		case errors.Is(err, service.ErrSendFileSizeMismatch):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "uploaded file size does not match file_size"})
+		case errors.Is(err, service.ErrSendFileTooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file exceeds the size limit of your plan"})
+		case errors.Is(err, service.ErrFeatureNotAvailable):
+			c.JSON(http.StatusForbidden, gin.H{"error": "file sends are not available on your current plan"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to upload file"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, domain.ToSendDTO(send))
+}
+
 // List handles GET /api/sends
 func (h *SendHandler) List(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -103,6 +164,7 @@ func (h *SendHandler) List(c *gin.Context) {
 }
 
 // GetByUUID handles GET /api/sends/:uuid
+// The detail view includes recipients and their access attempts.
 func (h *SendHandler) GetByUUID(c *gin.Context) {
 	ctx := c.Request.Context()
 	userID := GetCurrentUserID(c)
@@ -113,7 +175,7 @@ func (h *SendHandler) GetByUUID(c *gin.Context) {
 		return
 	}
 
-	send, err := h.service.GetByUUID(ctx, userID, sendUUID)
+	dto, err := h.service.GetDetail(ctx, userID, sendUUID)
 	if err != nil {
 		if errors.Is(err, repository.ErrForbidden) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
@@ -127,7 +189,7 @@ func (h *SendHandler) GetByUUID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, domain.ToSendDTO(send))
+	c.JSON(http.StatusOK, dto)
 }
 
 // Update handles PUT /api/sends/:uuid
@@ -157,8 +219,13 @@ func (h *SendHandler) Update(c *gin.Context) {
 		DeletionDate:   req.DeletionDate,
 		Disabled:       req.Disabled,
 		HideEmail:      req.HideEmail,
+		Recipients:     req.Recipients,
 	})
 	if err != nil {
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid send data", "details": err.Error()})
+			return
+		}
 		if errors.Is(err, repository.ErrForbidden) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
 			return
@@ -244,6 +311,10 @@ func (h *SendHandler) VerifyPassword(c *gin.Context) {
 
 	dto, err := h.service.VerifySendPassword(ctx, accessID, req.Password)
 	if err != nil {
+		if errors.Is(err, service.ErrSendVerificationRequired) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "recipient verification required"})
+			return
+		}
 		if errors.Is(err, repository.ErrForbidden) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "incorrect password"})
 			return
@@ -259,6 +330,158 @@ func (h *SendHandler) VerifyPassword(c *gin.Context) {
 	c.JSON(http.StatusOK, dto)
 }
 
+// DownloadFile handles GET and POST /api/sends/access/:access_id/file (public — no auth required)
+// Password-protected sends use POST with {"password": "..."}; recipient-restricted sends need the
+// X-Send-Access-Token header. Every download counts against max_access_count.
+func (h *SendHandler) DownloadFile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	accessID, ok := GetStringParam(c, "access_id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "access_id is required"})
+		return
+	}
+
+	var req struct {
+		Password string `json:"password"`
+	}
+	if c.Request.Method == http.MethodPost {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+
+	send, content, err := h.service.OpenFile(ctx, accessID, &domain.SendAccessRequest{
+		Password:    req.Password,
+		AccessToken: c.GetHeader(sendAccessTokenHeader),
+		IPAddress:   GetIPAddress(c),
+		UserAgent:   GetUserAgent(c),
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrSendVerificationRequired) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "recipient verification required"})
+			return
+		}
+		if errors.Is(err, repository.ErrForbidden) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "incorrect password"})
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "send not found or expired"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to download file"})
+		return
+	}
+	defer content.Close()
+
+	extendTransferDeadline(c)
+
+	// The file name is encrypted (in data); the content is opaque ciphertext
+	c.Header("Content-Type", "application/octet-stream")
+	c.Header("Content-Length", strconv.FormatInt(send.FileSize, 10))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, content); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// RequestRecipientCode handles POST /api/sends/access/:access_id/recipient/code (public — no auth required)
+// The response does not reveal whether the email is a recipient.
+func (h *SendHandler) RequestRecipientCode(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	accessID, ok := GetStringParam(c, "access_id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "access_id is required"})
+		return
+	}
+
+	var req struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "valid email is required"})
+		return
+	}
+
+	err := h.service.RequestRecipientCode(ctx, accessID, &domain.SendAccessRequest{
+		Email:     req.Email,
+		IPAddress: GetIPAddress(c),
+		UserAgent: GetUserAgent(c),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "send not found or expired"})
+			return
+		}
+		if errors.Is(err, repository.ErrInvalidInput) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "send is not restricted to recipients"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send access code"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "if the email is a recipient of this send, an access code was sent"})
+}
+
+// VerifyRecipient handles POST /api/sends/access/:access_id/recipient/verify (public — no auth required)
+func (h *SendHandler) VerifyRecipient(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	accessID, ok := GetStringParam(c, "access_id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "access_id is required"})
+		return
+	}
+
+	var req struct {
+		Email    string `json:"email" binding:"required,email"`
+		Code     string `json:"code" binding:"required"`
+		Password string `json:"password"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "email and code are required"})
+		return
+	}
+
+	dto, err := h.service.VerifyRecipient(ctx, accessID, &domain.SendAccessRequest{
+		Email:     req.Email,
+		Code:      req.Code,
+		Password:  req.Password,
+		IPAddress: GetIPAddress(c),
+		UserAgent: GetUserAgent(c),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCodeInvalid), errors.Is(err, service.ErrCodeExpired):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired code"})
+		case errors.Is(err, repository.ErrForbidden):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "incorrect password"})
+		case errors.Is(err, repository.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "send is not restricted to recipients"})
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "send not found or expired"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to verify recipient"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto)
+}
+
+// extendTransferDeadline lifts the server timeouts for a long file transfer
+func extendTransferDeadline(c *gin.Context) {
+	rc := http.NewResponseController(c.Writer)
+	deadline := time.Now().Add(sendFileTransferTimeout)
+	_ = rc.SetReadDeadline(deadline)
+	_ = rc.SetWriteDeadline(deadline)
+}
+
 // Notify handles POST /api/sends/:uuid/notify (authenticated - sends email to recipient)
 func (h *SendHandler) Notify(c *gin.Context) {
 	ctx := c.Request.Context()
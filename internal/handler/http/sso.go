@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/service"
 	"github.com/passwall/passwall-server/pkg/logger"
@@ -42,7 +43,7 @@ func (h *SSOHandler) CreateConnection(c *gin.Context) {
 	if !ok {
 		return
 	}
-	if !h.ensureOrgAdmin(c, ctx, userID, orgID) {
+	if !h.ensureOrgPermission(c, ctx, userID, orgID, domain.OrgPermManageSSO) {
 		return
 	}
 
@@ -77,7 +78,7 @@ func (h *SSOHandler) ListConnections(c *gin.Context) {
 		return
 	}
 	userID := GetCurrentUserID(c)
-	if !h.ensureOrgAdmin(c, ctx, userID, orgID) {
+	if !h.ensureOrgPermission(c, ctx, userID, orgID, domain.OrgPermManageSSO) {
 		return
 	}
 
@@ -105,7 +106,7 @@ func (h *SSOHandler) GetConnection(c *gin.Context) {
 		return
 	}
 	userID := GetCurrentUserID(c)
-	if !h.ensureOrgAdmin(c, ctx, userID, orgID) {
+	if !h.ensureOrgPermission(c, ctx, userID, orgID, domain.OrgPermManageSSO) {
 		return
 	}
 	connID, ok := GetUintParam(c, "connId")
@@ -146,7 +147,7 @@ func (h *SSOHandler) UpdateConnection(c *gin.Context) {
 	if !ok {
 		return
 	}
-	if !h.ensureOrgAdmin(c, ctx, userID, orgID) {
+	if !h.ensureOrgPermission(c, ctx, userID, orgID, domain.OrgPermManageSSO) {
 		return
 	}
 
@@ -193,7 +194,7 @@ func (h *SSOHandler) DeleteConnection(c *gin.Context) {
 	if !ok {
 		return
 	}
-	if !h.ensureOrgAdmin(c, ctx, userID, orgID) {
+	if !h.ensureOrgPermission(c, ctx, userID, orgID, domain.OrgPermManageSSO) {
 		return
 	}
 	conn, err := h.ssoService.GetConnection(ctx, connID)
@@ -229,7 +230,7 @@ func (h *SSOHandler) ActivateConnection(c *gin.Context) {
 	if !ok {
 		return
 	}
-	if !h.ensureOrgAdmin(c, ctx, userID, orgID) {
+	if !h.ensureOrgPermission(c, ctx, userID, orgID, domain.OrgPermManageSSO) {
 		return
 	}
 
@@ -469,16 +470,16 @@ func buildVaultCallbackURL(base string) *url.URL {
 	return parsed
 }
 
-func (h *SSOHandler) ensureOrgAdmin(c *gin.Context, ctx context.Context, userID, orgID uint) bool {
+func (h *SSOHandler) ensureOrgPermission(c *gin.Context, ctx context.Context, userID, orgID uint, perm domain.OrgPermission) bool {
 	membership, err := h.orgService.GetMembership(ctx, userID, orgID)
 	if err != nil || membership == nil {
 		logger.Warnf("SSO org access denied: user_id=%d org_id=%d err=%v", userID, orgID, err)
 		c.JSON(http.StatusForbidden, gin.H{"error": "organization access denied"})
 		return false
 	}
-	if !membership.IsAdmin() {
-		logger.Warnf("SSO org admin required: user_id=%d org_id=%d role=%s", userID, orgID, membership.Role)
-		c.JSON(http.StatusForbidden, gin.H{"error": "organization admin access required"})
+	if !authz.HasPermission(membership, perm) {
+		logger.Warnf("SSO org permission required: user_id=%d org_id=%d role=%s permission=%s", userID, orgID, membership.Role, perm)
+		c.JSON(http.StatusForbidden, gin.H{"error": "organization permission required: " + string(perm)})
 		return false
 	}
 	return true
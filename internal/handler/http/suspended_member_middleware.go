@@ -0,0 +1,42 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/pkg/constants"
+)
+
+// OrgMembershipReader loads a user's membership in an organization
+type OrgMembershipReader interface {
+	GetMembership(ctx context.Context, userID uint, orgID uint) (*domain.OrganizationUser, error)
+}
+
+// SuspendedMemberMiddleware blocks suspended members from org-scoped routes.
+// Membership checks stay with the handlers; this only rejects suspended members.
+func SuspendedMemberMiddleware(memberships OrgMembershipReader) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		val, exists := c.Get(constants.ContextKeyOrgID)
+		if !exists {
+			c.Next()
+			return
+		}
+		orgID, ok := val.(uint)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid organization context"})
+			c.Abort()
+			return
+		}
+
+		membership, err := memberships.GetMembership(c.Request.Context(), GetCurrentUserID(c), orgID)
+		if err == nil && membership.IsSuspended() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "your membership in this organization is suspended"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
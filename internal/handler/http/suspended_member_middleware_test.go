@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/pkg/constants"
+)
+
+type stubMemberships map[uint]*domain.OrganizationUser
+
+func (s stubMemberships) GetMembership(_ context.Context, _ uint, orgID uint) (*domain.OrganizationUser, error) {
+	if m, ok := s[orgID]; ok {
+		return m, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+// A suspension only locks the member out of that organization: their session keeps
+// working for personal routes and for other organizations.
+func TestSuspendedMemberMiddleware_ScopedToOrganization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	memberships := stubMemberships{
+		1: {OrganizationID: 1, UserID: 42, Status: domain.OrgUserStatusSuspended},
+		2: {OrganizationID: 2, UserID: 42, Status: domain.OrgUserStatusConfirmed},
+	}
+
+	serve := func(orgID uint) int {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Set(constants.ContextKeyUserID, uint(42))
+			if orgID != 0 {
+				c.Set(constants.ContextKeyOrgID, orgID)
+			}
+			c.Next()
+		})
+		r.Use(SuspendedMemberMiddleware(memberships))
+		r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rr.Code
+	}
+
+	if code := serve(1); code != http.StatusForbidden {
+		t.Fatalf("suspended organization: expected 403, got %d", code)
+	}
+	if code := serve(2); code != http.StatusOK {
+		t.Fatalf("other organization: expected 200, got %d", code)
+	}
+	if code := serve(0); code != http.StatusOK {
+		t.Fatalf("personal route: expected 200, got %d", code)
+	}
+}
+
+// Routes addressed by a collection, team or item ID resolve its organization first,
+// so a suspended member is kept out of them as well.
+func TestSuspendedMemberMiddleware_ResourceRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	memberships := stubMemberships{
+		1: {OrganizationID: 1, UserID: 42, Status: domain.OrgUserStatusSuspended},
+		2: {OrganizationID: 2, UserID: 42, Status: domain.OrgUserStatusConfirmed},
+	}
+	collectionOrgs := map[uint]uint{10: 1, 20: 2}
+	resolve := func(_ context.Context, id uint) (uint, error) {
+		if orgID, ok := collectionOrgs[id]; ok {
+			return orgID, nil
+		}
+		return 0, repository.ErrNotFound
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(constants.ContextKeyUserID, uint(42))
+		c.Next()
+	})
+	group := r.Group("/collections")
+	group.Use(OrgResourceMiddleware(resolve))
+	group.Use(SuspendedMemberMiddleware(memberships))
+	group.GET("/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	serve := func(path string) int {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr.Code
+	}
+
+	if code := serve("/collections/10"); code != http.StatusForbidden {
+		t.Fatalf("collection of suspended organization: expected 403, got %d", code)
+	}
+	if code := serve("/collections/20"); code != http.StatusOK {
+		t.Fatalf("collection of other organization: expected 200, got %d", code)
+	}
+	if code := serve("/collections/99"); code != http.StatusOK {
+		t.Fatalf("unknown collection: expected the handler to answer, got %d", code)
+	}
+}
@@ -3,8 +3,11 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/passwall/passwall-server/internal/domain"
@@ -27,23 +30,150 @@ func NewUserHandler(userService service.UserService, activityService service.Use
 	}
 }
 
+// List returns a page of the admin user directory
+// GET /api/users?q=&verified=&two_factor=&signup_source=&role=&last_sign_in_from=&last_sign_in_to=&never_signed_in=&organization_id=&sort=&order=&limit=&offset=
 func (h *UserHandler) List(c *gin.Context) {
-	ctx := c.Request.Context()
+	filter, err := parseUserDirectoryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid filter", "details": err.Error()})
+		return
+	}
 
-	users, err := h.service.List(ctx)
+	users, result, err := h.service.ListDirectory(c.Request.Context(), filter)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch users"})
 		return
 	}
 
-	dtos := domain.ToUserDTOs(users)
-	for i, u := range users {
-		last, err := h.activityService.GetLastSignIn(ctx, u.ID)
-		if err == nil && last != nil {
-			dtos[i].LastSignInAt = &last.CreatedAt
+	c.JSON(http.StatusOK, gin.H{
+		"users":    domain.ToUserDTOs(users),
+		"total":    result.Total,
+		"filtered": result.Filtered,
+		"limit":    filter.Limit,
+		"offset":   filter.Offset,
+	})
+}
+
+// Export streams every user matching the directory filters as CSV
+// GET /api/users/export
+func (h *UserHandler) Export(c *gin.Context) {
+	filter, err := parseUserDirectoryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid filter", "details": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("passwall-users-%s.csv", time.Now().UTC().Format("20060102"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	count, err := h.service.ExportDirectoryCSV(c.Request.Context(), filter, c.Writer)
+	if err != nil {
+		// Headers are already sent; abort so the client sees a truncated download
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	if actorID, actorErr := GetUserID(c); actorErr == nil && h.activityLogger != nil {
+		ipAddress := GetIPAddress(c)
+		userAgent := GetUserAgent(c)
+		go func() {
+			_ = h.activityLogger.LogActivity(context.Background(), actorID, domain.ActivityTypeAdminUsersExport, ipAddress, userAgent, service.ActivityDetails{
+				"count": count,
+			})
+		}()
+	}
+}
+
+// parseUserDirectoryFilter reads directory filters from the query string.
+// Dates accept RFC 3339 or YYYY-MM-DD; a date-only upper bound includes that whole day.
+func parseUserDirectoryFilter(c *gin.Context) (repository.UserDirectoryFilter, error) {
+	filter := repository.UserDirectoryFilter{
+		Search:       strings.TrimSpace(c.Query("q")),
+		SignupSource: strings.TrimSpace(c.Query("signup_source")),
+		Role:         strings.TrimSpace(c.Query("role")),
+		Sort:         c.DefaultQuery("sort", "created_at"),
+		Order:        c.DefaultQuery("order", "desc"),
+	}
+
+	var err error
+	if filter.IsVerified, err = parseOptionalBoolQuery(c, "verified"); err != nil {
+		return filter, err
+	}
+	if filter.TwoFactorEnabled, err = parseOptionalBoolQuery(c, "two_factor"); err != nil {
+		return filter, err
+	}
+	if never, err := parseOptionalBoolQuery(c, "never_signed_in"); err != nil {
+		return filter, err
+	} else if never != nil {
+		filter.NeverSignedIn = *never
+	}
+
+	if v := c.Query("last_sign_in_from"); v != "" {
+		from, _, err := parseDirectoryDate(v)
+		if err != nil {
+			return filter, fmt.Errorf("last_sign_in_from: %w", err)
+		}
+		filter.LastSignInFrom = &from
+	}
+	if v := c.Query("last_sign_in_to"); v != "" {
+		to, dateOnly, err := parseDirectoryDate(v)
+		if err != nil {
+			return filter, fmt.Errorf("last_sign_in_to: %w", err)
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.LastSignInTo = &to
+	}
+
+	if v := c.Query("organization_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return filter, fmt.Errorf("organization_id must be a number")
+		}
+		orgID := uint(id)
+		filter.OrganizationID = &orgID
+	}
+
+	if v := c.Query("limit"); v != "" {
+		if filter.Limit, err = strconv.Atoi(v); err != nil || filter.Limit < 0 {
+			return filter, fmt.Errorf("limit must be a positive number")
 		}
 	}
-	c.JSON(http.StatusOK, dtos)
+	if v := c.Query("offset"); v != "" {
+		if filter.Offset, err = strconv.Atoi(v); err != nil || filter.Offset < 0 {
+			return filter, fmt.Errorf("offset must be a positive number")
+		}
+	}
+
+	return filter, nil
+}
+
+func parseOptionalBoolQuery(c *gin.Context, key string) (*bool, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be true or false", key)
+	}
+	return &b, nil
+}
+
+func parseDirectoryDate(v string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("expected RFC 3339 or YYYY-MM-DD")
+	}
+	return t, true, nil
 }
 
 func (h *UserHandler) Create(c *gin.Context) {
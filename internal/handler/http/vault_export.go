@@ -0,0 +1,126 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+// exportKeyHeader carries the optional export password key as base64-encoded JSON
+// (domain.VaultExportPasswordKey). A header keeps it out of URLs and access logs.
+const exportKeyHeader = "X-Export-Key"
+
+type VaultExportHandler struct {
+	service        service.VaultExportService
+	activityLogger *service.ActivityLogger
+}
+
+// NewVaultExportHandler creates a new vault export handler
+func NewVaultExportHandler(svc service.VaultExportService, activityService service.UserActivityService) *VaultExportHandler {
+	return &VaultExportHandler{
+		service:        svc,
+		activityLogger: service.NewActivityLogger(activityService),
+	}
+}
+
+// Export godoc
+// @Summary Export vault
+// @Description Stream personal items, folders and accessible organization items as an encrypted, versioned JSON bundle.
+// @Description Send X-Export-Key (base64 JSON with kdf settings and the user key wrapped by the export password key) for a password-protected export.
+// @Tags export
+// @Produce json
+// @Success 200 {object} domain.VaultExport
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /export [get]
+func (h *VaultExportHandler) Export(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := GetCurrentUserID(c)
+
+	passwordKey, err := parseExportKeyHeader(c.GetHeader(exportKeyHeader))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Check the policy first so a blocked export gets a JSON error instead of a partial download
+	if err := h.service.CheckAllowed(ctx, userID); err != nil {
+		h.respondError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("passwall-export-%s.json", time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Cache-Control", "no-store")
+
+	summary, err := h.service.Export(ctx, userID, passwordKey, c.Writer)
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Header("Content-Disposition", "")
+			h.respondError(c, err)
+			return
+		}
+		// Headers are already sent; abort so the client sees a truncated download
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	if h.activityLogger != nil {
+		h.activityLogger.LogCustomActivity(ctx, userID, domain.ActivityTypeVaultExported, GetIPAddress(c), GetUserAgent(c), service.ActivityDetails{
+			"mode":               summary.Mode,
+			"personal_items":     summary.PersonalItems,
+			"organizations":      summary.Organizations,
+			"organization_items": summary.OrganizationItems,
+			"hidden_items":       summary.HiddenItems,
+		})
+	}
+}
+
+func (h *VaultExportHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrVaultExportDisabled):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidExportPassword):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export vault"})
+	}
+}
+
+// parseExportKeyHeader decodes the optional export password key header
+func parseExportKeyHeader(value string) (*domain.VaultExportPasswordKey, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		raw, err = base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s header: not base64", exportKeyHeader)
+		}
+	}
+
+	var key domain.VaultExportPasswordKey
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return nil, fmt.Errorf("invalid %s header: not JSON", exportKeyHeader)
+	}
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s header: %v", exportKeyHeader, err)
+	}
+	return &key, nil
+}
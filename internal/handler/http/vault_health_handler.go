@@ -0,0 +1,101 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/internal/service"
+)
+
+// VaultHealthHandler handles vault health report HTTP endpoints.
+type VaultHealthHandler struct {
+	service service.VaultHealthService
+}
+
+// NewVaultHealthHandler creates a new vault health handler.
+func NewVaultHealthHandler(svc service.VaultHealthService) *VaultHealthHandler {
+	return &VaultHealthHandler{service: svc}
+}
+
+// SubmitSignals handles POST /api/organizations/:id/vault-health/signals
+func (h *VaultHealthHandler) SubmitSignals(c *gin.Context) {
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+	userID := GetCurrentUserID(c)
+
+	var req domain.SubmitItemHealthSignalsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	accepted, err := h.service.SubmitSignals(c.Request.Context(), orgID, userID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"accepted": accepted})
+}
+
+// GetReport handles GET /api/organizations/:id/vault-health/report
+func (h *VaultHealthHandler) GetReport(c *gin.Context) {
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+	userID := GetCurrentUserID(c)
+
+	report, err := h.service.GetOrganizationReport(c.Request.Context(), orgID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetCollectionReport handles GET /api/organizations/:id/vault-health/collections/:collectionId/report
+func (h *VaultHealthHandler) GetCollectionReport(c *gin.Context) {
+	orgID, ok := GetResolvedOrgID(c)
+	if !ok {
+		return
+	}
+	userID := GetCurrentUserID(c)
+	collectionID, ok := GetUintParam(c, "collectionId")
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetCollectionReport(c.Request.Context(), orgID, collectionID, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *VaultHealthHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	case errors.Is(err, repository.ErrInvalidInput):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	case errors.Is(err, service.ErrFeatureNotAvailable):
+		c.JSON(http.StatusForbidden, gin.H{"error": "security insights are not available on your current plan"})
+	case errors.Is(err, service.ErrVaultHealthReportsDisabled):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrSubscriptionExpired):
+		c.JSON(http.StatusForbidden, gin.H{"error": "subscription expired"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -7,18 +7,17 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/service"
 )
 
 type WebhookHandler struct {
-	paymentService    service.PaymentService
-	revenueCatService service.RevenueCatService
+	webhookInbox service.WebhookInboxService
 }
 
-func NewWebhookHandler(paymentService service.PaymentService, revenueCatService service.RevenueCatService) *WebhookHandler {
+func NewWebhookHandler(webhookInbox service.WebhookInboxService) *WebhookHandler {
 	return &WebhookHandler{
-		paymentService:    paymentService,
-		revenueCatService: revenueCatService,
+		webhookInbox: webhookInbox,
 	}
 }
 
@@ -49,11 +48,12 @@ func (h *WebhookHandler) HandleStripeWebhook(c *gin.Context) {
 		return
 	}
 
-	// Process webhook
-	if err := h.paymentService.HandleWebhook(ctx, payload, signature); err != nil {
+	// Store and process webhook
+	if err := h.webhookInbox.Receive(ctx, domain.WebhookProviderStripe, payload, signature); err != nil {
 		// Important:
-		// - Signature is verified inside HandleWebhook.
-		// - If processing fails (DB/Stripe transient), return 500 so Stripe can retry.
+		// - Signature is verified before the event is stored.
+		// - Once stored, failed processing is retried by the webhook inbox.
+		// - If the event could not be stored, return 500 so Stripe can retry.
 		if errors.Is(err, service.ErrInvalidStripeWebhookSignature) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid Stripe signature", "received": false})
 			return
@@ -98,14 +98,14 @@ func (h *WebhookHandler) HandleRevenueCatWebhook(c *gin.Context) {
 		}
 	}
 
-	// Process webhook
-	if err := h.revenueCatService.HandleWebhook(ctx, payload, authToken); err != nil {
+	// Store and process webhook
+	if err := h.webhookInbox.Receive(ctx, domain.WebhookProviderRevenueCat, payload, authToken); err != nil {
 		// Check for signature verification failure
 		if errors.Is(err, service.ErrInvalidRevenueCatSignature) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid RevenueCat signature", "received": false})
 			return
 		}
-		// Return 500 for transient errors so RevenueCat will retry
+		// Return 500 when the event could not be stored so RevenueCat will retry
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "received": false})
 		return
 	}
@@ -13,9 +13,19 @@ type BreachMonitorRepository interface {
 	GetEmailByID(ctx context.Context, id uint) (*domain.MonitoredEmail, error)
 	GetEmailByOrgAndAddress(ctx context.Context, orgID uint, email string) (*domain.MonitoredEmail, error)
 	ListEmailsByOrganization(ctx context.Context, orgID uint) ([]*domain.MonitoredEmail, error)
+	// ListEmailsForMember returns the emails linked to the user or matching their address
+	ListEmailsForMember(ctx context.Context, orgID, userID uint, email string) ([]*domain.MonitoredEmail, error)
 	UpdateEmail(ctx context.Context, email *domain.MonitoredEmail) error
 	DeleteEmail(ctx context.Context, id uint) error
 
+	// MonitoredDomain CRUD
+	CreateDomain(ctx context.Context, d *domain.MonitoredDomain) error
+	GetDomainByID(ctx context.Context, id uint) (*domain.MonitoredDomain, error)
+	GetDomainByOrgAndName(ctx context.Context, orgID uint, name string) (*domain.MonitoredDomain, error)
+	ListDomainsByOrganization(ctx context.Context, orgID uint) ([]*domain.MonitoredDomain, error)
+	UpdateDomain(ctx context.Context, d *domain.MonitoredDomain) error
+	DeleteDomain(ctx context.Context, id uint) error
+
 	// BreachRecord CRUD
 	CreateBreachRecord(ctx context.Context, record *domain.BreachRecord) error
 	GetBreachRecordByID(ctx context.Context, id uint) (*domain.BreachRecord, error)
@@ -29,4 +39,5 @@ type BreachMonitorRepository interface {
 
 	// Bulk operations for background worker
 	ListAllMonitoredEmails(ctx context.Context) ([]*domain.MonitoredEmail, error)
+	ListAllMonitoredDomains(ctx context.Context) ([]*domain.MonitoredDomain, error)
 }
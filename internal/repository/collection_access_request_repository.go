@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+)
+
+// CollectionAccessRequestFilter narrows the access requests returned for an organization
+type CollectionAccessRequestFilter struct {
+	Status             *domain.CollectionAccessRequestStatus
+	OrganizationUserID *uint
+}
+
+// CollectionAccessRequestRepository defines the interface for just-in-time collection access requests
+type CollectionAccessRequestRepository interface {
+	// Create stores a new access request
+	Create(ctx context.Context, req *domain.CollectionAccessRequest) error
+
+	// GetByID returns an access request of the organization with its collection, requester and reviewer
+	GetByID(ctx context.Context, orgID, id uint) (*domain.CollectionAccessRequest, error)
+
+	// GetPending returns the member's pending request for a collection, if any
+	GetPending(ctx context.Context, collectionID, orgUserID uint) (*domain.CollectionAccessRequest, error)
+
+	// ListByOrganization returns the organization's requests, newest first
+	ListByOrganization(ctx context.Context, orgID uint, filter CollectionAccessRequestFilter) ([]*domain.CollectionAccessRequest, error)
+
+	// ListExpired returns approved requests whose access ended before the given time
+	ListExpired(ctx context.Context, before time.Time) ([]*domain.CollectionAccessRequest, error)
+
+	// Update saves changes to an access request
+	Update(ctx context.Context, req *domain.CollectionAccessRequest) error
+}
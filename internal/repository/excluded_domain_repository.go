@@ -23,3 +23,21 @@ type ExcludedDomainRepository interface {
 	// DeleteByDomain removes an excluded domain by domain name (only if it belongs to the user)
 	DeleteByDomain(ctx context.Context, userID uint, domain string) error
 }
+
+// OrganizationExcludedDomainRepository defines the interface for organization-wide excluded domains
+type OrganizationExcludedDomainRepository interface {
+	// Create adds a new excluded domain for an organization
+	Create(ctx context.Context, excludedDomain *domain.OrganizationExcludedDomain) error
+
+	// ListByOrganization returns all excluded domains of an organization
+	ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationExcludedDomain, error)
+
+	// ListByOrganizations returns the excluded domains of several organizations
+	ListByOrganizations(ctx context.Context, orgIDs []uint) ([]*domain.OrganizationExcludedDomain, error)
+
+	// GetByOrgAndDomain returns the entry with the exact domain pattern
+	GetByOrgAndDomain(ctx context.Context, orgID uint, domain string) (*domain.OrganizationExcludedDomain, error)
+
+	// Delete removes an excluded domain by ID (only if it belongs to the organization)
+	Delete(ctx context.Context, orgID, id uint) error
+}
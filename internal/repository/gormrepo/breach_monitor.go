@@ -61,6 +61,16 @@ func (r *breachMonitorRepository) ListEmailsByOrganization(ctx context.Context,
 	return emails, err
 }
 
+func (r *breachMonitorRepository) ListEmailsForMember(ctx context.Context, orgID, userID uint, email string) ([]*domain.MonitoredEmail, error) {
+	var emails []*domain.MonitoredEmail
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND (user_id = ? OR LOWER(email) = LOWER(?))", orgID, userID, email).
+		Preload("BreachRecords").
+		Order("created_at DESC").
+		Find(&emails).Error
+	return emails, err
+}
+
 func (r *breachMonitorRepository) UpdateEmail(ctx context.Context, email *domain.MonitoredEmail) error {
 	return r.db.WithContext(ctx).Save(email).Error
 }
@@ -69,6 +79,55 @@ func (r *breachMonitorRepository) DeleteEmail(ctx context.Context, id uint) erro
 	return r.db.WithContext(ctx).Delete(&domain.MonitoredEmail{}, id).Error
 }
 
+// ── MonitoredDomain ─────────────────────────────────────────
+
+func (r *breachMonitorRepository) CreateDomain(ctx context.Context, d *domain.MonitoredDomain) error {
+	return r.db.WithContext(ctx).Create(d).Error
+}
+
+func (r *breachMonitorRepository) GetDomainByID(ctx context.Context, id uint) (*domain.MonitoredDomain, error) {
+	var d domain.MonitoredDomain
+	err := r.db.WithContext(ctx).First(&d, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *breachMonitorRepository) GetDomainByOrgAndName(ctx context.Context, orgID uint, name string) (*domain.MonitoredDomain, error) {
+	var d domain.MonitoredDomain
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND domain = ?", orgID, name).
+		First(&d).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *breachMonitorRepository) ListDomainsByOrganization(ctx context.Context, orgID uint) ([]*domain.MonitoredDomain, error) {
+	var domains []*domain.MonitoredDomain
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ?", orgID).
+		Order("domain").
+		Find(&domains).Error
+	return domains, err
+}
+
+func (r *breachMonitorRepository) UpdateDomain(ctx context.Context, d *domain.MonitoredDomain) error {
+	return r.db.WithContext(ctx).Save(d).Error
+}
+
+func (r *breachMonitorRepository) DeleteDomain(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.MonitoredDomain{}, id).Error
+}
+
 // ── BreachRecord ────────────────────────────────────────────
 
 func (r *breachMonitorRepository) CreateBreachRecord(ctx context.Context, record *domain.BreachRecord) error {
@@ -173,3 +232,11 @@ func (r *breachMonitorRepository) ListAllMonitoredEmails(ctx context.Context) ([
 		Find(&emails).Error
 	return emails, err
 }
+
+func (r *breachMonitorRepository) ListAllMonitoredDomains(ctx context.Context) ([]*domain.MonitoredDomain, error) {
+	var domains []*domain.MonitoredDomain
+	err := r.db.WithContext(ctx).
+		Order("organization_id, id").
+		Find(&domains).Error
+	return domains, err
+}
@@ -61,11 +61,16 @@ func (r *collectionRepository) GetByUUID(ctx context.Context, uuidStr string) (*
 	return &collection, nil
 }
 
-func (r *collectionRepository) GetByName(ctx context.Context, orgID uint, name string) (*domain.Collection, error) {
+func (r *collectionRepository) GetByName(ctx context.Context, orgID uint, parentID *uint, name string) (*domain.Collection, error) {
 	var collection domain.Collection
-	err := r.db.WithContext(ctx).
-		Where("organization_id = ? AND name = ? AND deleted_at IS NULL", orgID, name).
-		First(&collection).Error
+	query := r.db.WithContext(ctx).
+		Where("organization_id = ? AND name = ? AND deleted_at IS NULL", orgID, name)
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+	err := query.First(&collection).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -125,33 +130,46 @@ func (r *collectionRepository) ListForUser(ctx context.Context, orgID, userID ui
 		return nil, err
 	}
 
-	// Use Raw SQL with UNION for better performance
+	// Start from the collections granted directly or through a team, then walk down
+	// to their descendants, which inherit the grant
 	query := `
-		SELECT DISTINCT collections.*
-		FROM collections
-		WHERE collections.organization_id = ? 
-		  AND collections.deleted_at IS NULL
-		  AND (
-			  -- Direct user access
-			  EXISTS (
-				  SELECT 1 FROM collection_users 
-				  WHERE collection_users.collection_id = collections.id 
-				    AND collection_users.organization_user_id = ?
+		WITH RECURSIVE visible(id, depth) AS (
+			SELECT collections.id, 0
+			FROM collections
+			WHERE collections.organization_id = ?
+			  AND collections.deleted_at IS NULL
+			  AND (
+				  -- Direct user access
+				  EXISTS (
+					  SELECT 1 FROM collection_users
+					  WHERE collection_users.collection_id = collections.id
+					    AND collection_users.organization_user_id = ?
+					    AND (collection_users.expires_at IS NULL OR collection_users.expires_at > ?)
+				  )
+				  OR
+				  -- Team access
+				  EXISTS (
+					  SELECT 1 FROM collection_teams
+					  INNER JOIN team_users ON team_users.team_id = collection_teams.team_id
+					  WHERE collection_teams.collection_id = collections.id
+					    AND team_users.organization_user_id = ?
+				  )
 			  )
-			  OR
-			  -- Team access
-			  EXISTS (
-				  SELECT 1 FROM collection_teams
-				  INNER JOIN team_users ON team_users.team_id = collection_teams.team_id
-				  WHERE collection_teams.collection_id = collections.id
-				    AND team_users.organization_user_id = ?
-			  )
-		  )
+			UNION ALL
+			SELECT children.id, visible.depth + 1
+			FROM collections children
+			INNER JOIN visible ON children.parent_id = visible.id
+			WHERE children.deleted_at IS NULL
+			  AND visible.depth < ?
+		)
+		SELECT collections.*
+		FROM collections
+		WHERE collections.id IN (SELECT id FROM visible)
 		ORDER BY collections.name ASC
 	`
 
 	err = r.db.WithContext(ctx).
-		Raw(query, orgID, orgUserID, orgUserID).
+		Raw(query, orgID, orgUserID, time.Now(), orgUserID, domain.MaxCollectionDepth).
 		Scan(&collections).Error
 
 	if err != nil {
@@ -184,11 +202,77 @@ func (r *collectionRepository) SoftDelete(ctx context.Context, id uint) error {
 		Update("deleted_at", now).Error
 }
 
+func (r *collectionRepository) SoftDeleteMany(ctx context.Context, ids []uint, fallbackCollectionID uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	now := time.Now()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := removeItemCollections(tx, ids, fallbackCollectionID); err != nil {
+			return err
+		}
+		return tx.Model(&domain.Collection{}).
+			Where("id IN ?", ids).
+			Update("deleted_at", now).Error
+	})
+}
+
+// ListAncestorIDs walks up parent_id links. The walk is capped at MaxCollectionDepth
+// so a corrupted parent chain cannot loop forever.
+func (r *collectionRepository) ListAncestorIDs(ctx context.Context, collectionID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Raw(`
+		WITH RECURSIVE chain(id, parent_id, depth) AS (
+			SELECT id, parent_id, 0
+			FROM collections
+			WHERE id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT parents.id, parents.parent_id, chain.depth + 1
+			FROM collections parents
+			INNER JOIN chain ON parents.id = chain.parent_id
+			WHERE parents.deleted_at IS NULL
+			  AND chain.depth < ?
+		)
+		SELECT id FROM chain ORDER BY depth ASC
+	`, collectionID, domain.MaxCollectionDepth).Scan(&ids).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *collectionRepository) ListSubtree(ctx context.Context, collectionID uint) ([]*domain.Collection, error) {
+	var collections []*domain.Collection
+	err := r.db.WithContext(ctx).Raw(`
+		WITH RECURSIVE subtree(id, depth) AS (
+			SELECT id, 0
+			FROM collections
+			WHERE id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT children.id, subtree.depth + 1
+			FROM collections children
+			INNER JOIN subtree ON children.parent_id = subtree.id
+			WHERE children.deleted_at IS NULL
+			  AND subtree.depth < ?
+		)
+		SELECT collections.*
+		FROM collections
+		INNER JOIN subtree ON subtree.id = collections.id
+		ORDER BY subtree.depth ASC, collections.name ASC
+	`, collectionID, domain.MaxCollectionDepth).Scan(&collections).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
 func (r *collectionRepository) GetItemCount(ctx context.Context, collectionID uint) (int, error) {
 	var count int64
 	err := r.db.WithContext(ctx).
 		Model(&domain.OrganizationItem{}).
-		Where("collection_id = ? AND deleted_at IS NULL", collectionID).
+		Where("id IN (?) AND deleted_at IS NULL", itemIDsInCollection(r.db, collectionID)).
 		Count(&count).Error
 
 	return int(count), err
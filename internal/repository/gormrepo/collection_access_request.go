@@ -0,0 +1,106 @@
+package gormrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"gorm.io/gorm"
+)
+
+type collectionAccessRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewCollectionAccessRequestRepository creates a new collection access request repository
+func NewCollectionAccessRequestRepository(db *gorm.DB) repository.CollectionAccessRequestRepository {
+	return &collectionAccessRequestRepository{db: db}
+}
+
+// withDetails preloads what notifications and DTOs need
+func (r *collectionAccessRequestRepository) withDetails(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Preload("Collection").
+		Preload("OrganizationUser.User").
+		Preload("ReviewedBy.User")
+}
+
+func (r *collectionAccessRequestRepository) Create(ctx context.Context, req *domain.CollectionAccessRequest) error {
+	if req.UUID == uuid.Nil {
+		req.UUID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(req).Error
+}
+
+func (r *collectionAccessRequestRepository) GetByID(ctx context.Context, orgID, id uint) (*domain.CollectionAccessRequest, error) {
+	var req domain.CollectionAccessRequest
+
+	err := r.withDetails(ctx).
+		Where("id = ? AND organization_id = ?", id, orgID).
+		First(&req).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (r *collectionAccessRequestRepository) GetPending(ctx context.Context, collectionID, orgUserID uint) (*domain.CollectionAccessRequest, error) {
+	var req domain.CollectionAccessRequest
+
+	err := r.db.WithContext(ctx).
+		Where("collection_id = ? AND organization_user_id = ? AND status = ?", collectionID, orgUserID, domain.AccessRequestStatusPending).
+		First(&req).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+func (r *collectionAccessRequestRepository) ListByOrganization(ctx context.Context, orgID uint, filter repository.CollectionAccessRequestFilter) ([]*domain.CollectionAccessRequest, error) {
+	var reqs []*domain.CollectionAccessRequest
+
+	query := r.withDetails(ctx).Where("organization_id = ?", orgID)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.OrganizationUserID != nil {
+		query = query.Where("organization_user_id = ?", *filter.OrganizationUserID)
+	}
+
+	if err := query.Order("created_at DESC").Find(&reqs).Error; err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+func (r *collectionAccessRequestRepository) ListExpired(ctx context.Context, before time.Time) ([]*domain.CollectionAccessRequest, error) {
+	var reqs []*domain.CollectionAccessRequest
+
+	err := r.withDetails(ctx).
+		Where("status = ? AND expires_at <= ?", domain.AccessRequestStatusApproved, before).
+		Order("expires_at ASC").
+		Find(&reqs).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return reqs, nil
+}
+
+func (r *collectionAccessRequestRepository) Update(ctx context.Context, req *domain.CollectionAccessRequest) error {
+	// Associations are read-only here
+	return r.db.WithContext(ctx).Omit("Collection", "OrganizationUser", "ReviewedBy").Save(req).Error
+}
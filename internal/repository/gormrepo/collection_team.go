@@ -84,6 +84,21 @@ func (r *collectionTeamRepository) ListByTeam(ctx context.Context, teamID uint)
 	return cts, nil
 }
 
+func (r *collectionTeamRepository) ListByCollections(ctx context.Context, collectionIDs []uint) ([]*domain.CollectionTeam, error) {
+	var cts []*domain.CollectionTeam
+	if len(collectionIDs) == 0 {
+		return cts, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("collection_id IN ?", collectionIDs).
+		Find(&cts).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return cts, nil
+}
+
 func (r *collectionTeamRepository) Update(ctx context.Context, ct *domain.CollectionTeam) error {
 	// Clear associations
 	ct.Collection = nil
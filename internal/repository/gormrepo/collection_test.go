@@ -0,0 +1,108 @@
+package gormrepo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/pkg/database/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCollectionRepository_Tree(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *gorm.DB) {
+		ctx := context.Background()
+		require.NoError(t, db.AutoMigrate(
+			&domain.Role{}, &domain.User{}, &domain.Organization{}, &domain.OrganizationUser{},
+			&domain.Team{}, &domain.TeamUser{}, &domain.Collection{},
+			&domain.CollectionUser{}, &domain.CollectionTeam{},
+			&domain.OrganizationItem{}, &domain.OrganizationItemCollection{},
+		))
+
+		tag := uuid.NewString()[:8]
+		role := &domain.Role{Name: "tree_" + tag}
+		require.NoError(t, db.Create(role).Error)
+		user := &domain.User{
+			UUID:               uuid.New(),
+			Name:               "Tree",
+			Email:              tag + "@example.com",
+			Schema:             "user_" + tag,
+			RoleID:             role.ID,
+			MasterPasswordHash: "hash",
+			ProtectedUserKey:   "2.key",
+			KdfSalt:            "salt",
+		}
+		require.NoError(t, db.Create(user).Error)
+		org := &domain.Organization{UUID: uuid.New(), Name: "Tree " + tag, BillingEmail: "billing@example.com"}
+		require.NoError(t, db.Create(org).Error)
+		orgUser := &domain.OrganizationUser{
+			UUID:            uuid.New(),
+			OrganizationID:  org.ID,
+			UserID:          user.ID,
+			Role:            domain.OrgRoleMember,
+			EncryptedOrgKey: "4.key",
+			Status:          domain.OrgUserStatusAccepted,
+		}
+		require.NoError(t, db.Create(orgUser).Error)
+
+		repo := NewCollectionRepository(db)
+		newCollection := func(name string, parent *domain.Collection) *domain.Collection {
+			c := &domain.Collection{OrganizationID: org.ID, Name: name}
+			if parent != nil {
+				c.ParentID = &parent.ID
+			}
+			require.NoError(t, repo.Create(ctx, c))
+			return c
+		}
+		engineering := newCollection("Engineering", nil)
+		prod := newCollection("Prod", engineering)
+		databases := newCollection("Databases", prod)
+		newCollection("Staging", engineering)
+		newCollection("Finance", nil)
+
+		t.Cleanup(func() {
+			db.Where("organization_user_id = ?", orgUser.ID).Delete(&domain.CollectionUser{})
+			db.Unscoped().Where("organization_id = ?", org.ID).Delete(&domain.Collection{})
+			db.Unscoped().Delete(orgUser)
+			db.Unscoped().Delete(org)
+			db.Unscoped().Delete(user)
+			db.Delete(role)
+		})
+
+		ancestors, err := repo.ListAncestorIDs(ctx, databases.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{databases.ID, prod.ID, engineering.ID}, ancestors)
+
+		names := func(collections []*domain.Collection) []string {
+			out := make([]string, 0, len(collections))
+			for _, c := range collections {
+				out = append(out, c.Name)
+			}
+			return out
+		}
+
+		subtree, err := repo.ListSubtree(ctx, engineering.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Engineering", "Prod", "Staging", "Databases"}, names(subtree))
+
+		got, err := repo.GetByName(ctx, org.ID, &engineering.ID, "Prod")
+		require.NoError(t, err)
+		assert.Equal(t, prod.ID, got.ID)
+		_, err = repo.GetByName(ctx, org.ID, nil, "Prod")
+		assert.Error(t, err)
+
+		// A grant on Prod makes its descendants visible, but not its parent or siblings
+		require.NoError(t, db.Create(&domain.CollectionUser{CollectionID: prod.ID, OrganizationUserID: orgUser.ID, CanRead: true}).Error)
+		visible, err := repo.ListForUser(ctx, org.ID, user.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"Prod", "Databases"}, names(visible))
+
+		require.NoError(t, repo.SoftDeleteMany(ctx, []uint{prod.ID, databases.ID}, engineering.ID))
+		subtree, err = repo.ListSubtree(ctx, engineering.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Engineering", "Staging"}, names(subtree))
+	})
+}
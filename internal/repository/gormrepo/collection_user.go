@@ -3,6 +3,7 @@ package gormrepo
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
@@ -87,6 +88,22 @@ func (r *collectionUserRepository) ListByOrgUser(ctx context.Context, orgUserID
 	return cus, nil
 }
 
+func (r *collectionUserRepository) ListByOrgUserAndCollections(ctx context.Context, orgUserID uint, collectionIDs []uint) ([]*domain.CollectionUser, error) {
+	var cus []*domain.CollectionUser
+	if len(collectionIDs) == 0 {
+		return cus, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("organization_user_id = ? AND collection_id IN ?", orgUserID, collectionIDs).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
+		Find(&cus).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return cus, nil
+}
+
 func (r *collectionUserRepository) Update(ctx context.Context, cu *domain.CollectionUser) error {
 	// Clear associations
 	cu.Collection = nil
@@ -89,6 +89,34 @@ func (r *invitationRepository) GetByCreator(ctx context.Context, createdBy uint)
 	return invitations, nil
 }
 
+func (r *invitationRepository) GetLatestByOrgAndEmail(ctx context.Context, orgID uint, email string) (*domain.Invitation, error) {
+	var invitation domain.Invitation
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND LOWER(email) = LOWER(?) AND used_at IS NULL", orgID, email).
+		Order("created_at DESC").
+		First(&invitation).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *invitationRepository) ListExpired(ctx context.Context, before time.Time) ([]*domain.Invitation, error) {
+	var invitations []*domain.Invitation
+	err := r.db.WithContext(ctx).
+		Where("used_at IS NULL AND expires_at < ?", before).
+		Order("expires_at ASC").
+		Find(&invitations).Error
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
 func (r *invitationRepository) Update(ctx context.Context, invitation *domain.Invitation) error {
 	return r.db.WithContext(ctx).Save(invitation).Error
 }
@@ -103,6 +131,12 @@ func (r *invitationRepository) DeleteByEmail(ctx context.Context, email string)
 		Delete(&domain.Invitation{}).Error
 }
 
+func (r *invitationRepository) DeleteByOrgAndEmail(ctx context.Context, orgID uint, email string) error {
+	return r.db.WithContext(ctx).
+		Where("organization_id = ? AND LOWER(email) = LOWER(?) AND used_at IS NULL", orgID, email).
+		Delete(&domain.Invitation{}).Error
+}
+
 func (r *invitationRepository) DeleteExpired(ctx context.Context) error {
 	return r.db.WithContext(ctx).
 		Where("expires_at < ? OR used_at IS NOT NULL", time.Now()).
@@ -13,6 +13,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// itemsTable is the personal vault table in every user schema
+const itemsTable = "items"
+
 type itemRepository struct {
 	db *gorm.DB
 }
@@ -23,15 +26,7 @@ func NewItemRepository(db *gorm.DB) repository.ItemRepository {
 }
 
 func (r *itemRepository) Create(ctx context.Context, schema string, item *domain.Item) error {
-	// Use a transaction + SET LOCAL so search_path never leaks to pooled connections.
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := database.ValidateSchemaName(schema); err != nil {
-			return err
-		}
-		safeSchema := database.SanitizeIdentifier(schema)
-		if err := tx.Exec(fmt.Sprintf("SET LOCAL search_path TO %s", safeSchema)).Error; err != nil {
-			return err
-		}
+	return inUserSchema(ctx, r.db, schema, itemsTable, func(tx *gorm.DB) error {
 		return tx.Create(item).Error
 	})
 }
@@ -39,14 +34,7 @@ func (r *itemRepository) Create(ctx context.Context, schema string, item *domain
 func (r *itemRepository) FindByID(ctx context.Context, schema string, id uint) (*domain.Item, error) {
 	var item domain.Item
 
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := database.ValidateSchemaName(schema); err != nil {
-			return err
-		}
-		safeSchema := database.SanitizeIdentifier(schema)
-		if err := tx.Exec(fmt.Sprintf("SET LOCAL search_path TO %s", safeSchema)).Error; err != nil {
-			return err
-		}
+	err := inUserSchema(ctx, r.db, schema, itemsTable, func(tx *gorm.DB) error {
 		return tx.Where("id = ? AND deleted_at IS NULL", id).First(&item).Error
 	})
 
@@ -63,14 +51,7 @@ func (r *itemRepository) FindByID(ctx context.Context, schema string, id uint) (
 func (r *itemRepository) FindByUUID(ctx context.Context, schema string, uuidStr string) (*domain.Item, error) {
 	var item domain.Item
 
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := database.ValidateSchemaName(schema); err != nil {
-			return err
-		}
-		safeSchema := database.SanitizeIdentifier(schema)
-		if err := tx.Exec(fmt.Sprintf("SET LOCAL search_path TO %s", safeSchema)).Error; err != nil {
-			return err
-		}
+	err := inUserSchema(ctx, r.db, schema, itemsTable, func(tx *gorm.DB) error {
 		return tx.Where("uuid = ? AND deleted_at IS NULL", uuidStr).First(&item).Error
 	})
 
@@ -87,14 +68,7 @@ func (r *itemRepository) FindByUUID(ctx context.Context, schema string, uuidStr
 func (r *itemRepository) FindBySupportID(ctx context.Context, schema string, supportID int64) (*domain.Item, error) {
 	var item domain.Item
 
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := database.ValidateSchemaName(schema); err != nil {
-			return err
-		}
-		safeSchema := database.SanitizeIdentifier(schema)
-		if err := tx.Exec(fmt.Sprintf("SET LOCAL search_path TO %s", safeSchema)).Error; err != nil {
-			return err
-		}
+	err := inUserSchema(ctx, r.db, schema, itemsTable, func(tx *gorm.DB) error {
 		return tx.Where("support_id = ? AND deleted_at IS NULL", supportID).First(&item).Error
 	})
 
@@ -112,15 +86,8 @@ func (r *itemRepository) FindAll(ctx context.Context, schema string, filter repo
 	var items []*domain.Item
 	var total int64
 
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Set schema using SET LOCAL so it cannot leak outside this transaction.
-		if err := database.ValidateSchemaName(schema); err != nil {
-			return err
-		}
-		safeSchema := database.SanitizeIdentifier(schema)
-		if err := tx.Exec(fmt.Sprintf("SET LOCAL search_path TO %s", safeSchema)).Error; err != nil {
-			return err
-		}
+	err := inUserSchema(ctx, r.db, schema, itemsTable, func(tx *gorm.DB) error {
+		dialect := database.DialectOf(tx)
 
 		// Base query
 		query := tx.Model(&domain.Item{}).Where("deleted_at IS NULL")
@@ -150,33 +117,24 @@ func (r *itemRepository) FindAll(ctx context.Context, schema string, filter repo
 		if filter.Search != "" {
 			searchPattern := "%" + filter.Search + "%"
 			query = query.Where(
-				"metadata->>'name' ILIKE ? OR metadata->>'uri_hint' ILIKE ?",
+				fmt.Sprintf("%s %s ? OR %s %s ?",
+					dialect.JSONText("metadata", "name"), dialect.ILike(),
+					dialect.JSONText("metadata", "uri_hint"), dialect.ILike()),
 				searchPattern,
 				searchPattern,
 			)
 		}
 
 		// Filter by uri_hint (domain only)
-		// Matches exact domain OR any subdomain of that domain.
-		if len(filter.URIHints) > 0 {
-			clauses := make([]string, 0, len(filter.URIHints))
-			args := make([]interface{}, 0, len(filter.URIHints)*2)
-			for _, hint := range filter.URIHints {
-				if hint == "" {
-					continue
-				}
-				clauses = append(clauses, "(metadata->>'uri_hint' = ? OR metadata->>'uri_hint' LIKE ?)")
-				args = append(args, hint, "%."+hint)
-			}
-			if len(clauses) > 0 {
-				query = query.Where(strings.Join(clauses, " OR "), args...)
-			}
+		if cond, args := uriHintCondition(dialect, filter.URIHints); cond != "" {
+			query = query.Where(cond, args...)
 		}
 
 		// Filter by tags
 		if len(filter.Tags) > 0 {
 			for _, tag := range filter.Tags {
-				query = query.Where("metadata->'tags' @> ?", fmt.Sprintf(`["%s"]`, tag))
+				cond, arg := dialect.JSONArrayContains("metadata", "tags", tag)
+				query = query.Where(cond, arg)
 			}
 		}
 
@@ -206,41 +164,39 @@ func (r *itemRepository) FindAll(ctx context.Context, schema string, filter repo
 	return items, total, nil
 }
 
+// uriHintCondition matches metadata.uri_hint against any of the hints.
+// Each hint matches the exact domain OR any subdomain of that domain.
+func uriHintCondition(dialect database.Dialect, hints []string) (string, []interface{}) {
+	clauses := make([]string, 0, len(hints))
+	args := make([]interface{}, 0, len(hints)*2)
+	for _, hint := range hints {
+		if hint == "" {
+			continue
+		}
+		uriHint := dialect.JSONText("metadata", "uri_hint")
+		clauses = append(clauses, fmt.Sprintf("(%s = ? OR %s LIKE ?)", uriHint, uriHint))
+		args = append(args, hint, "%."+hint)
+	}
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
 func (r *itemRepository) Update(ctx context.Context, schema string, item *domain.Item) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := database.ValidateSchemaName(schema); err != nil {
-			return err
-		}
-		safeSchema := database.SanitizeIdentifier(schema)
-		if err := tx.Exec(fmt.Sprintf("SET LOCAL search_path TO %s", safeSchema)).Error; err != nil {
-			return err
-		}
+	return inUserSchema(ctx, r.db, schema, itemsTable, func(tx *gorm.DB) error {
 		return tx.Save(item).Error
 	})
 }
 
 func (r *itemRepository) Delete(ctx context.Context, schema string, id uint) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := database.ValidateSchemaName(schema); err != nil {
-			return err
-		}
-		safeSchema := database.SanitizeIdentifier(schema)
-		if err := tx.Exec(fmt.Sprintf("SET LOCAL search_path TO %s", safeSchema)).Error; err != nil {
-			return err
-		}
+	return inUserSchema(ctx, r.db, schema, itemsTable, func(tx *gorm.DB) error {
 		return tx.Model(&domain.Item{}).Where("id = ?", id).Update("deleted_at", time.Now()).Error
 	})
 }
 
 func (r *itemRepository) HardDelete(ctx context.Context, schema string, id uint) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := database.ValidateSchemaName(schema); err != nil {
-			return err
-		}
-		safeSchema := database.SanitizeIdentifier(schema)
-		if err := tx.Exec(fmt.Sprintf("SET LOCAL search_path TO %s", safeSchema)).Error; err != nil {
-			return err
-		}
+	return inUserSchema(ctx, r.db, schema, itemsTable, func(tx *gorm.DB) error {
 		return tx.Unscoped().Delete(&domain.Item{}, id).Error
 	})
 }
@@ -0,0 +1,176 @@
+package gormrepo
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"gorm.io/gorm"
+)
+
+// bulkSavepoint is reused for every item of a batch; Postgres keeps the most recent one
+const bulkSavepoint = "bulk_item"
+
+// withSavepoint runs fn in a savepoint. A failing fn only rolls back its own changes and
+// is returned as itemErr; fatalErr means the transaction itself is no longer usable.
+func withSavepoint(tx *gorm.DB, fn func() error) (itemErr error, fatalErr error) {
+	if err := tx.SavePoint(bulkSavepoint).Error; err != nil {
+		return nil, err
+	}
+	if err := fn(); err != nil {
+		if rbErr := tx.RollbackTo(bulkSavepoint).Error; rbErr != nil {
+			return nil, rbErr
+		}
+		return err, nil
+	}
+	if err := tx.Exec("RELEASE SAVEPOINT " + bulkSavepoint).Error; err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (r *itemRepository) CreateBatch(ctx context.Context, schema string, items []*domain.Item) ([]error, error) {
+	itemErrs := make([]error, len(items))
+
+	err := inUserSchema(ctx, r.db, schema, itemsTable, func(tx *gorm.DB) error {
+		for i, item := range items {
+			itemErr, fatalErr := withSavepoint(tx, func() error {
+				return tx.Create(item).Error
+			})
+			if fatalErr != nil {
+				return fatalErr
+			}
+			itemErrs[i] = itemErr
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return itemErrs, nil
+}
+
+func (r *itemRepository) UpdateBatch(ctx context.Context, schema string, ids []uint, apply func(item *domain.Item) error) (map[uint]error, error) {
+	itemErrs := make(map[uint]error)
+
+	err := inUserSchema(ctx, r.db, schema, itemsTable, func(tx *gorm.DB) error {
+		var items []*domain.Item
+		if err := tx.Where("id IN ?", ids).Find(&items).Error; err != nil {
+			return err
+		}
+		found := make(map[uint]*domain.Item, len(items))
+		for _, item := range items {
+			found[item.ID] = item
+		}
+
+		for _, id := range ids {
+			item, ok := found[id]
+			if !ok {
+				itemErrs[id] = repository.ErrNotFound
+				continue
+			}
+			if err := apply(item); err != nil {
+				itemErrs[id] = err
+				continue
+			}
+			itemErr, fatalErr := withSavepoint(tx, func() error {
+				return tx.Save(item).Error
+			})
+			if fatalErr != nil {
+				return fatalErr
+			}
+			if itemErr != nil {
+				itemErrs[id] = itemErr
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return itemErrs, nil
+}
+
+func (r *organizationItemRepository) CreateBatch(ctx context.Context, items []*domain.OrganizationItem) ([]error, error) {
+	itemErrs := make([]error, len(items))
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, item := range items {
+			if item.UUID == uuid.Nil {
+				item.UUID = uuid.New()
+			}
+			// Note: support_id and revision are auto-generated by trigger
+			itemErr, fatalErr := withSavepoint(tx, func() error {
+				if err := tx.Create(item).Error; err != nil {
+					return err
+				}
+				return replaceItemCollections(tx, item.ID, item.CollectionIDs)
+			})
+			if fatalErr != nil {
+				return fatalErr
+			}
+			itemErrs[i] = itemErr
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return itemErrs, nil
+}
+
+func (r *organizationItemRepository) UpdateBatch(ctx context.Context, orgID uint, ids []uint, apply func(item *domain.OrganizationItem) ([]uint, error)) (map[uint]error, error) {
+	itemErrs := make(map[uint]error)
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var items []*domain.OrganizationItem
+		if err := tx.Where("organization_id = ? AND id IN ?", orgID, ids).Find(&items).Error; err != nil {
+			return err
+		}
+		if err := loadItemCollections(tx, items); err != nil {
+			return err
+		}
+		found := make(map[uint]*domain.OrganizationItem, len(items))
+		for _, item := range items {
+			found[item.ID] = item
+		}
+
+		for _, id := range ids {
+			item, ok := found[id]
+			if !ok {
+				itemErrs[id] = repository.ErrNotFound
+				continue
+			}
+			collectionIDs, err := apply(item)
+			if err != nil {
+				itemErrs[id] = err
+				continue
+			}
+			itemErr, fatalErr := withSavepoint(tx, func() error {
+				if err := tx.Save(item).Error; err != nil {
+					return err
+				}
+				if collectionIDs == nil {
+					return nil
+				}
+				if err := replaceItemCollections(tx, item.ID, collectionIDs); err != nil {
+					return err
+				}
+				item.CollectionIDs = collectionIDs
+				return nil
+			})
+			if fatalErr != nil {
+				return fatalErr
+			}
+			if itemErr != nil {
+				itemErrs[id] = itemErr
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return itemErrs, nil
+}
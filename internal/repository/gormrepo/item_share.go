@@ -152,3 +152,48 @@ func (r *itemShareRepository) DeleteExpired(ctx context.Context) (int64, error)
 
 	return result.RowsAffected, result.Error
 }
+
+func (r *itemShareRepository) ListExpiring(ctx context.Context, after, before time.Time) ([]*domain.ItemShare, error) {
+	var shares []*domain.ItemShare
+	err := r.db.WithContext(ctx).
+		Preload("Owner").
+		Preload("SharedWithUser").
+		Where("expires_at IS NOT NULL AND expires_at > ? AND expires_at <= ?", after, before).
+		Where("expiry_notice_sent_at IS NULL AND revoked_at IS NULL").
+		Order("expires_at ASC").
+		Find(&shares).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+func (r *itemShareRepository) ListExpiringByOrganization(ctx context.Context, orgID uint, after, before time.Time) ([]*domain.ItemShare, error) {
+	var shares []*domain.ItemShare
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ?", orgID).
+		Where("expires_at IS NOT NULL AND expires_at > ? AND expires_at <= ?", after, before).
+		Where("revoked_at IS NULL").
+		Order("expires_at ASC").
+		Find(&shares).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+func (r *itemShareRepository) ListExpired(ctx context.Context, now time.Time) ([]*domain.ItemShare, error) {
+	var shares []*domain.ItemShare
+	err := r.db.WithContext(ctx).
+		Where("expires_at IS NOT NULL AND expires_at <= ?", now).
+		Where("revoked_at IS NULL").
+		Order("expires_at ASC").
+		Find(&shares).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
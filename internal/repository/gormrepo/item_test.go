@@ -0,0 +1,199 @@
+package gormrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/pkg/database/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newTestVault creates a user vault with a unique schema name and drops it after the test
+func newTestVault(t *testing.T, db *gorm.DB) string {
+	t.Helper()
+	schema := fmt.Sprintf("test_%s", uuid.NewString()[:8])
+	users := NewUserRepository(db)
+
+	require.NoError(t, users.CreateSchema(schema))
+	require.NoError(t, users.MigrateUserSchema(schema))
+	t.Cleanup(func() {
+		_ = users.Delete(context.Background(), 0, schema)
+	})
+	return schema
+}
+
+func newTestItem(name, uriHint string, tags ...string) *domain.Item {
+	return &domain.Item{
+		UUID:     uuid.New(),
+		ItemType: domain.ItemTypePassword,
+		Data:     "2.encrypted|data|mac",
+		Metadata: domain.ItemMetadata{Name: name, URIHint: uriHint, Tags: tags},
+	}
+}
+
+func TestItemRepository_CRUD(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *gorm.DB) {
+		ctx := context.Background()
+		repo := NewItemRepository(db)
+		schema := newTestVault(t, db)
+
+		item := newTestItem("GitHub", "github.com", "work")
+		require.NoError(t, repo.Create(ctx, schema, item))
+		require.NotZero(t, item.ID)
+
+		got, err := repo.FindByID(ctx, schema, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, item.UUID, got.UUID)
+		assert.Equal(t, "GitHub", got.Metadata.Name)
+		assert.Equal(t, []string{"work"}, got.Metadata.Tags)
+		// support_id and revision are assigned by the database
+		assert.GreaterOrEqual(t, got.SupportID, int64(1000000000000000000))
+		assert.Positive(t, got.Revision)
+
+		bySupport, err := repo.FindBySupportID(ctx, schema, got.SupportID)
+		require.NoError(t, err)
+		assert.Equal(t, item.ID, bySupport.ID)
+
+		byUUID, err := repo.FindByUUID(ctx, schema, item.UUID.String())
+		require.NoError(t, err)
+		assert.Equal(t, item.ID, byUUID.ID)
+
+		// Updates bump the revision
+		got.IsFavorite = true
+		require.NoError(t, repo.Update(ctx, schema, got))
+		updated, err := repo.FindByID(ctx, schema, item.ID)
+		require.NoError(t, err)
+		assert.True(t, updated.IsFavorite)
+		assert.Greater(t, updated.Revision, got.Revision)
+
+		// Soft delete hides the item
+		require.NoError(t, repo.Delete(ctx, schema, item.ID))
+		_, err = repo.FindByID(ctx, schema, item.ID)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		require.NoError(t, repo.HardDelete(ctx, schema, item.ID))
+		count, err := NewUserRepository(db).GetItemCount(ctx, schema)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func TestItemRepository_FindAllFilters(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *gorm.DB) {
+		ctx := context.Background()
+		repo := NewItemRepository(db)
+		schema := newTestVault(t, db)
+
+		items := []*domain.Item{
+			newTestItem("GitHub", "github.com", "work", "dev"),
+			newTestItem("Gist", "gist.github.com", "dev"),
+			newTestItem("Bank", "mybank.com", "finance"),
+			newTestItem("Notes", "", "personal"),
+		}
+		for _, item := range items {
+			require.NoError(t, repo.Create(ctx, schema, item))
+		}
+
+		find := func(filter repository.ItemFilter) []string {
+			filter.Page, filter.PerPage = 1, 50
+			found, total, err := repo.FindAll(ctx, schema, filter)
+			require.NoError(t, err)
+			require.Equal(t, int64(len(found)), total)
+			names := make([]string, 0, len(found))
+			for _, item := range found {
+				names = append(names, item.Metadata.Name)
+			}
+			return names
+		}
+
+		// Case-insensitive search on name and uri_hint
+		assert.ElementsMatch(t, []string{"GitHub", "Gist"}, find(repository.ItemFilter{Search: "GITHUB"}))
+		assert.ElementsMatch(t, []string{"Bank"}, find(repository.ItemFilter{Search: "bank"}))
+
+		// uri_hint matches the domain and its subdomains
+		assert.ElementsMatch(t, []string{"GitHub", "Gist"}, find(repository.ItemFilter{URIHints: []string{"github.com"}}))
+		assert.ElementsMatch(t, []string{"Gist"}, find(repository.ItemFilter{URIHints: []string{"gist.github.com"}}))
+
+		// Every tag must be present
+		assert.ElementsMatch(t, []string{"GitHub", "Gist"}, find(repository.ItemFilter{Tags: []string{"dev"}}))
+		assert.ElementsMatch(t, []string{"GitHub"}, find(repository.ItemFilter{Tags: []string{"dev", "work"}}))
+
+		// Pagination keeps the total
+		page, total, err := repo.FindAll(ctx, schema, repository.ItemFilter{Page: 2, PerPage: 3})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), total)
+		assert.Len(t, page, 1)
+	})
+}
+
+func TestItemRepository_SchemaIsolation(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *gorm.DB) {
+		ctx := context.Background()
+		repo := NewItemRepository(db)
+		alice := newTestVault(t, db)
+		bob := newTestVault(t, db)
+
+		item := newTestItem("Alice only", "example.com")
+		require.NoError(t, repo.Create(ctx, alice, item))
+
+		_, total, err := repo.FindAll(ctx, bob, repository.ItemFilter{Page: 1, PerPage: 10})
+		require.NoError(t, err)
+		assert.Zero(t, total)
+
+		_, err = repo.FindByUUID(ctx, bob, item.UUID.String())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		assert.Error(t, repo.Create(ctx, "Invalid-Schema", newTestItem("x", "")))
+	})
+}
+
+func TestItemRepository_Batch(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *gorm.DB) {
+		ctx := context.Background()
+		repo := NewItemRepository(db)
+		schema := newTestVault(t, db)
+
+		// A duplicate UUID fails on its own; the rest of the batch is committed
+		dup := newTestItem("Duplicate", "")
+		batch := []*domain.Item{newTestItem("One", ""), dup, newTestItem("Two", "")}
+		require.NoError(t, repo.Create(ctx, schema, &domain.Item{
+			UUID: dup.UUID, ItemType: domain.ItemTypeSecureNote, Data: "x", Metadata: domain.ItemMetadata{Name: "Existing"},
+		}))
+
+		itemErrs, err := repo.CreateBatch(ctx, schema, batch)
+		require.NoError(t, err)
+		assert.NoError(t, itemErrs[0])
+		assert.Error(t, itemErrs[1])
+		assert.NoError(t, itemErrs[2])
+
+		count, err := NewUserRepository(db).GetItemCount(ctx, schema)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		// UpdateBatch reports missing IDs and errors from apply per item
+		now := time.Now()
+		skip := errors.New("skip")
+		updateErrs, err := repo.UpdateBatch(ctx, schema, []uint{batch[0].ID, batch[2].ID, 999999}, func(item *domain.Item) error {
+			if item.ID == batch[2].ID {
+				return skip
+			}
+			item.DeletedAt = &now
+			return nil
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, updateErrs, batch[0].ID)
+		assert.ErrorIs(t, updateErrs[batch[2].ID], skip)
+		assert.ErrorIs(t, updateErrs[999999], repository.ErrNotFound)
+
+		_, err = repo.FindByID(ctx, schema, batch[0].ID)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
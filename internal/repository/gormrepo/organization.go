@@ -251,7 +251,13 @@ func (r *organizationRepository) Delete(ctx context.Context, id uint) error {
 			return err
 		}
 
-		// Collection access tables (be robust even if FKs aren't cascading in older schemas)
+		// Collection access and item assignment tables (be robust even if FKs aren't cascading in older schemas)
+		if err := tx.Exec(`
+DELETE FROM organization_item_collections
+WHERE collection_id IN (SELECT id FROM collections WHERE organization_id = ?)
+`, id).Error; err != nil {
+			return err
+		}
 		if err := tx.Exec(`
 DELETE FROM collection_users
 WHERE collection_id IN (SELECT id FROM collections WHERE organization_id = ?)
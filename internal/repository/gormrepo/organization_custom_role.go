@@ -0,0 +1,121 @@
+package gormrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"gorm.io/gorm"
+)
+
+type organizationCustomRoleRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationCustomRoleRepository creates a new organization custom role repository
+func NewOrganizationCustomRoleRepository(db *gorm.DB) repository.OrganizationCustomRoleRepository {
+	return &organizationCustomRoleRepository{db: db}
+}
+
+func (r *organizationCustomRoleRepository) Create(ctx context.Context, role *domain.OrganizationCustomRole) error {
+	if role.UUID == uuid.Nil {
+		role.UUID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Create(role).Error
+}
+
+func (r *organizationCustomRoleRepository) GetByID(ctx context.Context, orgID, id uint) (*domain.OrganizationCustomRole, error) {
+	var role domain.OrganizationCustomRole
+
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND organization_id = ?", id, orgID).
+		First(&role).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (r *organizationCustomRoleRepository) GetByName(ctx context.Context, orgID uint, name string) (*domain.OrganizationCustomRole, error) {
+	var role domain.OrganizationCustomRole
+
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND LOWER(name) = LOWER(?)", orgID, name).
+		First(&role).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (r *organizationCustomRoleRepository) ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationCustomRole, error) {
+	var roles []*domain.OrganizationCustomRole
+
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ?", orgID).
+		Order("name ASC").
+		Find(&roles).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (r *organizationCustomRoleRepository) Update(ctx context.Context, role *domain.OrganizationCustomRole) error {
+	return r.db.WithContext(ctx).Save(role).Error
+}
+
+func (r *organizationCustomRoleRepository) Delete(ctx context.Context, orgID, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND organization_id = ?", id, orgID).
+			Delete(&domain.OrganizationCustomRole{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrNotFound
+		}
+
+		return tx.Model(&domain.OrganizationUser{}).
+			Where("organization_id = ? AND custom_role_id = ?", orgID, id).
+			Update("custom_role_id", nil).Error
+	})
+}
+
+func (r *organizationCustomRoleRepository) CountMembers(ctx context.Context, orgID uint) (map[uint]int, error) {
+	var rows []struct {
+		CustomRoleID uint
+		Count        int
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&domain.OrganizationUser{}).
+		Select("custom_role_id, COUNT(*) AS count").
+		Where("organization_id = ? AND custom_role_id IS NOT NULL", orgID).
+		Group("custom_role_id").
+		Scan(&rows).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.CustomRoleID] = row.Count
+	}
+	return counts, nil
+}
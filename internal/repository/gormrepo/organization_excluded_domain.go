@@ -0,0 +1,89 @@
+package gormrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"gorm.io/gorm"
+)
+
+type organizationExcludedDomainRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationExcludedDomainRepository creates a new organization excluded domain repository
+func NewOrganizationExcludedDomainRepository(db *gorm.DB) repository.OrganizationExcludedDomainRepository {
+	return &organizationExcludedDomainRepository{db: db}
+}
+
+func (r *organizationExcludedDomainRepository) Create(ctx context.Context, excludedDomain *domain.OrganizationExcludedDomain) error {
+	return r.db.WithContext(ctx).Create(excludedDomain).Error
+}
+
+func (r *organizationExcludedDomainRepository) ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationExcludedDomain, error) {
+	var domains []*domain.OrganizationExcludedDomain
+
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ?", orgID).
+		Order("domain ASC").
+		Find(&domains).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domains, nil
+}
+
+func (r *organizationExcludedDomainRepository) ListByOrganizations(ctx context.Context, orgIDs []uint) ([]*domain.OrganizationExcludedDomain, error) {
+	var domains []*domain.OrganizationExcludedDomain
+	if len(orgIDs) == 0 {
+		return domains, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Where("organization_id IN ?", orgIDs).
+		Order("organization_id ASC, domain ASC").
+		Find(&domains).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domains, nil
+}
+
+func (r *organizationExcludedDomainRepository) GetByOrgAndDomain(ctx context.Context, orgID uint, domainStr string) (*domain.OrganizationExcludedDomain, error) {
+	var ed domain.OrganizationExcludedDomain
+
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND domain = ?", orgID, domainStr).
+		First(&ed).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &ed, nil
+}
+
+func (r *organizationExcludedDomainRepository) Delete(ctx context.Context, orgID, id uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND organization_id = ?", id, orgID).
+		Delete(&domain.OrganizationExcludedDomain{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/pkg/database"
 	"gorm.io/gorm"
 )
 
@@ -29,14 +30,18 @@ func (r *organizationItemRepository) Create(ctx context.Context, item *domain.Or
 
 	// Note: support_id and revision are auto-generated by trigger
 
-	return r.db.WithContext(ctx).Create(item).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(item).Error; err != nil {
+			return err
+		}
+		return replaceItemCollections(tx, item.ID, item.CollectionIDs)
+	})
 }
 
 func (r *organizationItemRepository) GetByID(ctx context.Context, id uint) (*domain.OrganizationItem, error) {
 	var item domain.OrganizationItem
 	err := r.db.WithContext(ctx).
 		Preload("Organization").
-		Preload("Collection").
 		Preload("CreatedBy").
 		Where("id = ? AND deleted_at IS NULL", id).
 		First(&item).Error
@@ -47,6 +52,9 @@ func (r *organizationItemRepository) GetByID(ctx context.Context, id uint) (*dom
 		}
 		return nil, err
 	}
+	if err := loadItemCollections(r.db.WithContext(ctx), []*domain.OrganizationItem{&item}); err != nil {
+		return nil, err
+	}
 	return &item, nil
 }
 
@@ -54,7 +62,6 @@ func (r *organizationItemRepository) GetByUUID(ctx context.Context, uuidStr stri
 	var item domain.OrganizationItem
 	err := r.db.WithContext(ctx).
 		Preload("Organization").
-		Preload("Collection").
 		Preload("CreatedBy").
 		Where("uuid = ? AND deleted_at IS NULL", uuidStr).
 		First(&item).Error
@@ -65,6 +72,9 @@ func (r *organizationItemRepository) GetByUUID(ctx context.Context, uuidStr stri
 		}
 		return nil, err
 	}
+	if err := loadItemCollections(r.db.WithContext(ctx), []*domain.OrganizationItem{&item}); err != nil {
+		return nil, err
+	}
 	return &item, nil
 }
 
@@ -72,7 +82,6 @@ func (r *organizationItemRepository) GetBySupportID(ctx context.Context, support
 	var item domain.OrganizationItem
 	err := r.db.WithContext(ctx).
 		Preload("Organization").
-		Preload("Collection").
 		Preload("CreatedBy").
 		Where("support_id = ? AND deleted_at IS NULL", supportID).
 		First(&item).Error
@@ -83,6 +92,9 @@ func (r *organizationItemRepository) GetBySupportID(ctx context.Context, support
 		}
 		return nil, err
 	}
+	if err := loadItemCollections(r.db.WithContext(ctx), []*domain.OrganizationItem{&item}); err != nil {
+		return nil, err
+	}
 	return &item, nil
 }
 
@@ -98,7 +110,7 @@ func (r *organizationItemRepository) ListByOrganization(ctx context.Context, fil
 
 	// Apply collection filter
 	if filter.CollectionID != nil {
-		query = query.Where("collection_id = ?", *filter.CollectionID)
+		query = query.Where("id IN (?)", itemIDsInCollection(r.db, *filter.CollectionID))
 	}
 
 	// Apply item type filter
@@ -126,20 +138,30 @@ func (r *organizationItemRepository) ListByOrganization(ctx context.Context, fil
 		query = query.Where("auto_login = ?", *filter.AutoLogin)
 	}
 
+	dialect := database.DialectOf(r.db)
+
 	// Search in metadata
 	if filter.Search != "" {
 		searchPattern := "%" + filter.Search + "%"
 		query = query.Where(
-			"metadata->>'name' ILIKE ? OR metadata->>'uri_hint' ILIKE ?",
+			fmt.Sprintf("%s %s ? OR %s %s ?",
+				dialect.JSONText("metadata", "name"), dialect.ILike(),
+				dialect.JSONText("metadata", "uri_hint"), dialect.ILike()),
 			searchPattern,
 			searchPattern,
 		)
 	}
 
+	// Filter by uri_hint (domain only)
+	if cond, args := uriHintCondition(dialect, filter.URIHints); cond != "" {
+		query = query.Where(cond, args...)
+	}
+
 	// Filter by tags
 	if len(filter.Tags) > 0 {
 		for _, tag := range filter.Tags {
-			query = query.Where("metadata->'tags' @> ?", fmt.Sprintf(`["%s"]`, tag))
+			cond, arg := dialect.JSONArrayContains("metadata", "tags", tag)
+			query = query.Where(cond, arg)
 		}
 	}
 
@@ -166,12 +188,15 @@ func (r *organizationItemRepository) ListByOrganization(ctx context.Context, fil
 	query = query.Order("created_at DESC")
 
 	// Preload associations
-	query = query.Preload("Collection").Preload("CreatedBy")
+	query = query.Preload("CreatedBy")
 
 	// Execute query
 	if err := query.Find(&items).Error; err != nil {
 		return nil, 0, err
 	}
+	if err := loadItemCollections(r.db.WithContext(ctx), items); err != nil {
+		return nil, 0, err
+	}
 
 	return items, total, nil
 }
@@ -180,21 +205,37 @@ func (r *organizationItemRepository) ListByCollection(ctx context.Context, colle
 	var items []*domain.OrganizationItem
 	err := r.db.WithContext(ctx).
 		Preload("CreatedBy").
-		Where("collection_id = ? AND deleted_at IS NULL", collectionID).
+		Where("id IN (?) AND deleted_at IS NULL", itemIDsInCollection(r.db, collectionID)).
 		Order("created_at DESC").
 		Find(&items).Error
 
 	if err != nil {
 		return nil, err
 	}
+	if err := loadItemCollections(r.db.WithContext(ctx), items); err != nil {
+		return nil, err
+	}
 	return items, nil
 }
 
-func (r *organizationItemRepository) MoveItemsToCollection(ctx context.Context, fromCollectionID uint, toCollectionID uint) error {
-	return r.db.WithContext(ctx).
-		Model(&domain.OrganizationItem{}).
-		Where("collection_id = ? AND deleted_at IS NULL", fromCollectionID).
-		Update("collection_id", toCollectionID).Error
+// removeItemCollections detaches every item from the collections. Items that belonged to
+// no collection outside the set are assigned to the fallback so they do not become orphaned.
+func removeItemCollections(tx *gorm.DB, collectionIDs []uint, fallbackCollectionID uint) error {
+	if err := tx.Exec(`
+INSERT INTO organization_item_collections (organization_item_id, collection_id, created_at)
+SELECT DISTINCT oic.organization_item_id, ?, ?
+FROM organization_item_collections oic
+WHERE oic.collection_id IN ?
+  AND NOT EXISTS (
+    SELECT 1 FROM organization_item_collections other
+    WHERE other.organization_item_id = oic.organization_item_id
+      AND other.collection_id NOT IN ?
+  )
+`, fallbackCollectionID, time.Now(), collectionIDs, collectionIDs).Error; err != nil {
+		return err
+	}
+	return tx.Where("collection_id IN ?", collectionIDs).
+		Delete(&domain.OrganizationItemCollection{}).Error
 }
 
 func (r *organizationItemRepository) CountByOrganizationID(ctx context.Context, orgID uint) (int, error) {
@@ -206,10 +247,10 @@ func (r *organizationItemRepository) CountByOrganizationID(ctx context.Context,
 	return int(count), err
 }
 
+// Update saves the item row; collection assignments are changed with SetCollections
 func (r *organizationItemRepository) Update(ctx context.Context, item *domain.OrganizationItem) error {
 	// Clear associations
 	item.Organization = nil
-	item.Collection = nil
 	item.CreatedBy = nil
 
 	// Note: revision is auto-incremented by trigger
@@ -217,6 +258,13 @@ func (r *organizationItemRepository) Update(ctx context.Context, item *domain.Or
 	return r.db.WithContext(ctx).Save(item).Error
 }
 
+// SetCollections makes collectionIDs the item's only collection assignments
+func (r *organizationItemRepository) SetCollections(ctx context.Context, itemID uint, collectionIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceItemCollections(tx, itemID, collectionIDs)
+	})
+}
+
 func (r *organizationItemRepository) Delete(ctx context.Context, id uint) error {
 	// Hard delete
 	return r.db.WithContext(ctx).Unscoped().Delete(&domain.OrganizationItem{}, id).Error
@@ -233,3 +281,80 @@ func (r *organizationItemRepository) SoftDelete(ctx context.Context, id uint) er
 func (r *organizationItemRepository) HardDelete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Unscoped().Delete(&domain.OrganizationItem{}, id).Error
 }
+
+// itemIDsInCollection is a subquery selecting the IDs of the items in a collection
+func itemIDsInCollection(db *gorm.DB, collectionID uint) *gorm.DB {
+	return db.Model(&domain.OrganizationItemCollection{}).
+		Select("organization_item_id").
+		Where("collection_id = ?", collectionID)
+}
+
+// loadItemCollections fills CollectionIDs for the given items, oldest assignment first
+func loadItemCollections(db *gorm.DB, items []*domain.OrganizationItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	byID := make(map[uint]*domain.OrganizationItem, len(items))
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		item.CollectionIDs = []uint{}
+		byID[item.ID] = item
+		ids = append(ids, item.ID)
+	}
+
+	var links []domain.OrganizationItemCollection
+	if err := db.Where("organization_item_id IN ?", ids).
+		Order("created_at ASC, collection_id ASC").
+		Find(&links).Error; err != nil {
+		return err
+	}
+	for _, link := range links {
+		if item, ok := byID[link.OrganizationItemID]; ok {
+			item.CollectionIDs = append(item.CollectionIDs, link.CollectionID)
+		}
+	}
+	return nil
+}
+
+// replaceItemCollections makes collectionIDs the item's only collection assignments.
+// Existing rows are kept so their assignment order survives unrelated updates.
+func replaceItemCollections(tx *gorm.DB, itemID uint, collectionIDs []uint) error {
+	wanted := make(map[uint]struct{}, len(collectionIDs))
+	for _, id := range collectionIDs {
+		wanted[id] = struct{}{}
+	}
+
+	var existing []domain.OrganizationItemCollection
+	if err := tx.Where("organization_item_id = ?", itemID).Find(&existing).Error; err != nil {
+		return err
+	}
+	stale := make([]uint, 0)
+	for _, link := range existing {
+		if _, ok := wanted[link.CollectionID]; ok {
+			delete(wanted, link.CollectionID)
+			continue
+		}
+		stale = append(stale, link.CollectionID)
+	}
+
+	if len(stale) > 0 {
+		if err := tx.Where("organization_item_id = ? AND collection_id IN ?", itemID, stale).
+			Delete(&domain.OrganizationItemCollection{}).Error; err != nil {
+			return err
+		}
+	}
+
+	links := make([]domain.OrganizationItemCollection, 0, len(wanted))
+	for _, id := range collectionIDs {
+		if _, ok := wanted[id]; !ok {
+			continue
+		}
+		delete(wanted, id)
+		links = append(links, domain.OrganizationItemCollection{OrganizationItemID: itemID, CollectionID: id})
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	return tx.Create(&links).Error
+}
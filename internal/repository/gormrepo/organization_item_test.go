@@ -0,0 +1,137 @@
+package gormrepo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/pkg/database/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestOrganizationItemRepository_Collections(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *gorm.DB) {
+		ctx := context.Background()
+		require.NoError(t, db.AutoMigrate(
+			&domain.Role{}, &domain.User{}, &domain.Organization{}, &domain.Collection{},
+			&domain.OrganizationItem{}, &domain.OrganizationItemCollection{},
+		))
+
+		tag := uuid.NewString()[:8]
+		role := &domain.Role{Name: "items_" + tag}
+		require.NoError(t, db.Create(role).Error)
+		user := &domain.User{
+			UUID:               uuid.New(),
+			Name:               "Items",
+			Email:              tag + "@example.com",
+			Schema:             "user_" + tag,
+			RoleID:             role.ID,
+			MasterPasswordHash: "hash",
+			ProtectedUserKey:   "2.key",
+			KdfSalt:            "salt",
+		}
+		require.NoError(t, db.Create(user).Error)
+		org := &domain.Organization{UUID: uuid.New(), Name: "Items " + tag, BillingEmail: "billing@example.com"}
+		require.NoError(t, db.Create(org).Error)
+
+		newCollection := func(name string, isDefault bool) *domain.Collection {
+			c := &domain.Collection{UUID: uuid.New(), OrganizationID: org.ID, Name: name + " " + tag, IsDefault: isDefault}
+			require.NoError(t, db.Create(c).Error)
+			return c
+		}
+		general := newCollection("General", true)
+		engineering := newCollection("Engineering", false)
+		finance := newCollection("Finance", false)
+
+		repo := NewOrganizationItemRepository(db)
+		newItem := func(name, uriHint string, collectionIDs ...uint) *domain.OrganizationItem {
+			item := &domain.OrganizationItem{
+				UUID:            uuid.New(),
+				SupportID:       time.Now().UnixNano(),
+				OrganizationID:  org.ID,
+				CollectionIDs:   collectionIDs,
+				ItemType:        domain.ItemTypePassword,
+				Data:            "2.encrypted|data|mac",
+				Metadata:        domain.ItemMetadata{Name: name, URIHint: uriHint},
+				CreatedByUserID: user.ID,
+			}
+			require.NoError(t, repo.Create(ctx, item))
+			return item
+		}
+		shared := newItem("Shared", "gist.github.com", engineering.ID, finance.ID)
+		solo := newItem("Solo", "notgithub.com", engineering.ID)
+
+		t.Cleanup(func() {
+			db.Where("organization_item_id IN ?", []uint{shared.ID, solo.ID}).Delete(&domain.OrganizationItemCollection{})
+			db.Unscoped().Where("organization_id = ?", org.ID).Delete(&domain.OrganizationItem{})
+			db.Unscoped().Where("organization_id = ?", org.ID).Delete(&domain.Collection{})
+			db.Unscoped().Delete(org)
+			db.Unscoped().Delete(user)
+			db.Delete(role)
+		})
+
+		got, err := repo.GetByID(ctx, shared.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uint{engineering.ID, finance.ID}, got.CollectionIDs)
+
+		listCollection := func(collectionID uint) []string {
+			items, err := repo.ListByCollection(ctx, collectionID)
+			require.NoError(t, err)
+			names := make([]string, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.Metadata.Name)
+			}
+			return names
+		}
+		assert.ElementsMatch(t, []string{"Shared", "Solo"}, listCollection(engineering.ID))
+		assert.ElementsMatch(t, []string{"Shared"}, listCollection(finance.ID))
+
+		cid := finance.ID
+		items, total, err := repo.ListByOrganization(ctx, repository.OrganizationItemFilter{OrganizationID: org.ID, CollectionID: &cid})
+		require.NoError(t, err)
+		require.EqualValues(t, 1, total)
+		assert.ElementsMatch(t, []uint{engineering.ID, finance.ID}, items[0].CollectionIDs)
+
+		// uri_hint matches the domain and its subdomains only
+		items, _, err = repo.ListByOrganization(ctx, repository.OrganizationItemFilter{OrganizationID: org.ID, URIHints: []string{"github.com"}})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "Shared", items[0].Metadata.Name)
+
+		// Update only saves the item row, whatever CollectionIDs holds
+		got.CollectionIDs = nil
+		require.NoError(t, repo.Update(ctx, got))
+		got, err = repo.GetByID(ctx, shared.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uint{engineering.ID, finance.ID}, got.CollectionIDs)
+
+		// Reassigning collections leaves the encrypted data alone
+		require.NoError(t, repo.SetCollections(ctx, shared.ID, []uint{finance.ID, general.ID}))
+		got, err = repo.GetByID(ctx, shared.ID)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uint{finance.ID, general.ID}, got.CollectionIDs)
+		assert.Equal(t, "2.encrypted|data|mac", got.Data)
+		assert.ElementsMatch(t, []string{"Solo"}, listCollection(engineering.ID))
+
+		// Removing a collection only falls back to the default for items left without one
+		require.NoError(t, repo.SetCollections(ctx, shared.ID, []uint{engineering.ID, finance.ID}))
+		require.NoError(t, NewCollectionRepository(db).SoftDeleteMany(ctx, []uint{engineering.ID}, general.ID))
+
+		got, err = repo.GetByID(ctx, shared.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{finance.ID}, got.CollectionIDs)
+		got, err = repo.GetByID(ctx, solo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{general.ID}, got.CollectionIDs)
+		assert.Empty(t, listCollection(engineering.ID))
+
+		count, err := NewCollectionRepository(db).GetItemCount(ctx, general.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
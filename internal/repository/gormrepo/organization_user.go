@@ -33,6 +33,7 @@ func (r *organizationUserRepository) GetByID(ctx context.Context, id uint) (*dom
 	err := r.db.WithContext(ctx).
 		Preload("Organization").
 		Preload("User").
+		Preload("CustomRole").
 		Where("id = ?", id).
 		First(&orgUser).Error
 
@@ -50,6 +51,7 @@ func (r *organizationUserRepository) GetByUUID(ctx context.Context, uuidStr stri
 	err := r.db.WithContext(ctx).
 		Preload("Organization").
 		Preload("User").
+		Preload("CustomRole").
 		Where("uuid = ?", uuidStr).
 		First(&orgUser).Error
 
@@ -67,6 +69,7 @@ func (r *organizationUserRepository) GetByOrgAndUser(ctx context.Context, orgID,
 	err := r.db.WithContext(ctx).
 		Preload("Organization").
 		Preload("User").
+		Preload("CustomRole").
 		Where("organization_id = ? AND user_id = ?", orgID, userID).
 		First(&orgUser).Error
 
@@ -83,6 +86,7 @@ func (r *organizationUserRepository) ListByOrganization(ctx context.Context, org
 	var orgUsers []*domain.OrganizationUser
 	err := r.db.WithContext(ctx).
 		Preload("User").
+		Preload("CustomRole").
 		Where("organization_id = ?", orgID).
 		Order("created_at ASC").
 		Find(&orgUsers).Error
@@ -97,6 +101,7 @@ func (r *organizationUserRepository) ListByUser(ctx context.Context, userID uint
 	var orgUsers []*domain.OrganizationUser
 	err := r.db.WithContext(ctx).
 		Preload("Organization").
+		Preload("CustomRole").
 		Where("user_id = ?", userID).
 		Order("created_at ASC").
 		Find(&orgUsers).Error
@@ -111,6 +116,7 @@ func (r *organizationUserRepository) Update(ctx context.Context, orgUser *domain
 	// Clear associations to prevent GORM from trying to update them
 	orgUser.Organization = nil
 	orgUser.User = nil
+	orgUser.CustomRole = nil
 
 	return r.db.WithContext(ctx).Save(orgUser).Error
 }
@@ -147,3 +153,17 @@ func (r *organizationUserRepository) ListPendingInvitations(ctx context.Context,
 	}
 	return orgUsers, nil
 }
+
+func (r *organizationUserRepository) ListSuspended(ctx context.Context) ([]*domain.OrganizationUser, error) {
+	var orgUsers []*domain.OrganizationUser
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Where("status = ? AND suspended_at IS NOT NULL", domain.OrgUserStatusSuspended).
+		Order("organization_id ASC, suspended_at ASC").
+		Find(&orgUsers).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return orgUsers, nil
+}
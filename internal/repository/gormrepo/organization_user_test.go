@@ -0,0 +1,64 @@
+package gormrepo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/authz"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/pkg/database/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestOrganizationUserRepository_ListByUserLoadsCustomRole(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *gorm.DB) {
+		ctx := context.Background()
+		require.NoError(t, db.AutoMigrate(
+			&domain.Role{}, &domain.User{}, &domain.Organization{},
+			&domain.OrganizationCustomRole{}, &domain.OrganizationUser{},
+		))
+
+		tag := uuid.NewString()[:8]
+		role := &domain.Role{Name: "members_" + tag}
+		require.NoError(t, db.Create(role).Error)
+		user := &domain.User{
+			UUID:               uuid.New(),
+			Name:               "Auditor",
+			Email:              tag + "@example.com",
+			Schema:             "user_" + tag,
+			RoleID:             role.ID,
+			MasterPasswordHash: "hash",
+			ProtectedUserKey:   "2.key",
+			KdfSalt:            "salt",
+		}
+		require.NoError(t, db.Create(user).Error)
+		org := &domain.Organization{UUID: uuid.New(), Name: "Members " + tag, BillingEmail: "billing@example.com"}
+		require.NoError(t, db.Create(org).Error)
+		customRole := &domain.OrganizationCustomRole{
+			UUID:           uuid.New(),
+			OrganizationID: org.ID,
+			Name:           "Auditor",
+			Permissions:    string(domain.OrgPermViewAudit),
+		}
+		require.NoError(t, db.Create(customRole).Error)
+		orgUser := &domain.OrganizationUser{
+			UUID:            uuid.New(),
+			OrganizationID:  org.ID,
+			UserID:          user.ID,
+			Role:            domain.OrgRoleMember,
+			CustomRoleID:    &customRole.ID,
+			EncryptedOrgKey: "4.key",
+			Status:          domain.OrgUserStatusConfirmed,
+		}
+		require.NoError(t, db.Create(orgUser).Error)
+
+		memberships, err := NewOrganizationUserRepository(db).ListByUser(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, memberships, 1)
+		require.NotNil(t, memberships[0].CustomRole)
+		assert.True(t, authz.HasPermission(memberships[0], domain.OrgPermViewAudit))
+	})
+}
@@ -34,6 +34,22 @@ func (r *preferencesRepository) ListByOwner(ctx context.Context, ownerType strin
 	return prefs, nil
 }
 
+func (r *preferencesRepository) ListOwnerIDs(ctx context.Context, ownerType, section, key, value string) ([]uint, error) {
+	var ids []uint
+
+	err := r.db.WithContext(ctx).
+		Model(&domain.Preference{}).
+		Where("owner_type = ? AND section = ? AND key = ? AND value = ?",
+			strings.ToLower(ownerType), strings.ToLower(section), key, value).
+		Order("owner_id ASC").
+		Pluck("owner_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 func (r *preferencesRepository) UpsertMany(ctx context.Context, prefs []*domain.Preference) error {
 	if len(prefs) == 0 {
 		return nil
@@ -0,0 +1,176 @@
+package gormrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"gorm.io/gorm"
+)
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) repository.PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+// ── PromoCode ───────────────────────────────────────────────
+
+func (r *promotionRepository) CreatePromoCode(ctx context.Context, code *domain.PromoCode) error {
+	return r.db.WithContext(ctx).Create(code).Error
+}
+
+func (r *promotionRepository) GetPromoCodeByID(ctx context.Context, id uint) (*domain.PromoCode, error) {
+	var code domain.PromoCode
+	if err := r.db.WithContext(ctx).First(&code, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *promotionRepository) GetPromoCodeByCode(ctx context.Context, code string) (*domain.PromoCode, error) {
+	var promo domain.PromoCode
+	err := r.db.WithContext(ctx).
+		Where("code = ?", domain.NormalizePromoCode(code)).
+		First(&promo).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &promo, nil
+}
+
+func (r *promotionRepository) ListPromoCodes(ctx context.Context) ([]*domain.PromoCode, error) {
+	var codes []*domain.PromoCode
+	err := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Find(&codes).Error
+	return codes, err
+}
+
+func (r *promotionRepository) UpdatePromoCode(ctx context.Context, code *domain.PromoCode) error {
+	return r.db.WithContext(ctx).Save(code).Error
+}
+
+func (r *promotionRepository) IncrementTimesRedeemed(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.PromoCode{}).
+		Where("id = ? AND (max_redemptions IS NULL OR times_redeemed < max_redemptions)", id).
+		UpdateColumn("times_redeemed", gorm.Expr("times_redeemed + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *promotionRepository) DecrementTimesRedeemed(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.PromoCode{}).
+		Where("id = ? AND times_redeemed > 0", id).
+		UpdateColumn("times_redeemed", gorm.Expr("times_redeemed - 1")).Error
+}
+
+// ── Redemptions ─────────────────────────────────────────────
+
+func (r *promotionRepository) CreateRedemption(ctx context.Context, redemption *domain.PromoRedemption) error {
+	return r.db.WithContext(ctx).Create(redemption).Error
+}
+
+func (r *promotionRepository) UpdateRedemption(ctx context.Context, redemption *domain.PromoRedemption) error {
+	return r.db.WithContext(ctx).Omit("PromoCode").Save(redemption).Error
+}
+
+func (r *promotionRepository) GetRedemptionByCheckoutSession(ctx context.Context, sessionID string) (*domain.PromoRedemption, error) {
+	var redemption domain.PromoRedemption
+	err := r.db.WithContext(ctx).
+		Preload("PromoCode").
+		Where("stripe_checkout_session = ?", sessionID).
+		First(&redemption).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &redemption, nil
+}
+
+func (r *promotionRepository) GetLatestRedemptionByOrganization(ctx context.Context, orgID uint) (*domain.PromoRedemption, error) {
+	var redemption domain.PromoRedemption
+	err := r.db.WithContext(ctx).
+		Preload("PromoCode").
+		Where("organization_id = ? AND status = ?", orgID, domain.PromoRedemptionRedeemed).
+		Order("redeemed_at DESC").
+		First(&redemption).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &redemption, nil
+}
+
+func (r *promotionRepository) HasRedeemed(ctx context.Context, promoCodeID, orgID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.PromoRedemption{}).
+		Where("promo_code_id = ? AND organization_id = ? AND status = ?", promoCodeID, orgID, domain.PromoRedemptionRedeemed).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *promotionRepository) ListRedemptionsByPromoCode(ctx context.Context, promoCodeID uint) ([]*domain.PromoRedemption, error) {
+	var redemptions []*domain.PromoRedemption
+	err := r.db.WithContext(ctx).
+		Where("promo_code_id = ?", promoCodeID).
+		Order("created_at DESC").
+		Find(&redemptions).Error
+	return redemptions, err
+}
+
+func (r *promotionRepository) ListRedemptionsByOrganization(ctx context.Context, orgID uint) ([]*domain.PromoRedemption, error) {
+	var redemptions []*domain.PromoRedemption
+	err := r.db.WithContext(ctx).
+		Preload("PromoCode").
+		Where("organization_id = ?", orgID).
+		Order("created_at DESC").
+		Find(&redemptions).Error
+	return redemptions, err
+}
+
+// ── Trial extensions ────────────────────────────────────────
+
+func (r *promotionRepository) CreateTrialExtension(ctx context.Context, ext *domain.TrialExtension) error {
+	return r.db.WithContext(ctx).Create(ext).Error
+}
+
+func (r *promotionRepository) UpdateTrialExtension(ctx context.Context, ext *domain.TrialExtension) error {
+	return r.db.WithContext(ctx).Save(ext).Error
+}
+
+func (r *promotionRepository) ListTrialExtensionsByOrganization(ctx context.Context, orgID uint) ([]*domain.TrialExtension, error) {
+	var exts []*domain.TrialExtension
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ?", orgID).
+		Order("created_at DESC").
+		Find(&exts).Error
+	return exts, err
+}
+
+func (r *promotionRepository) ListPendingTrialExtensions(ctx context.Context, orgID uint) ([]*domain.TrialExtension, error) {
+	var exts []*domain.TrialExtension
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND applied_at IS NULL", orgID).
+		Order("created_at ASC").
+		Find(&exts).Error
+	return exts, err
+}
@@ -0,0 +1,55 @@
+package gormrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type rotationRecommendationRepository struct {
+	db *gorm.DB
+}
+
+// NewRotationRecommendationRepository creates a new rotation recommendation repository
+func NewRotationRecommendationRepository(db *gorm.DB) repository.RotationRecommendationRepository {
+	return &rotationRecommendationRepository{db: db}
+}
+
+func (r *rotationRecommendationRepository) Create(ctx context.Context, rec *domain.RotationRecommendation) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "item_uuid"}, {Name: "breach_name"}},
+			DoNothing: true,
+		}).
+		Create(rec)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *rotationRecommendationRepository) GetByID(ctx context.Context, id uint) (*domain.RotationRecommendation, error) {
+	var rec domain.RotationRecommendation
+	err := r.db.WithContext(ctx).First(&rec, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (r *rotationRecommendationRepository) ListOpenByUser(ctx context.Context, userID uint) ([]*domain.RotationRecommendation, error) {
+	var recs []*domain.RotationRecommendation
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND resolved_at IS NULL", userID).
+		Order("created_at DESC, id DESC").
+		Find(&recs).Error
+	return recs, err
+}
+
+func (r *rotationRecommendationRepository) Update(ctx context.Context, rec *domain.RotationRecommendation) error {
+	return r.db.WithContext(ctx).Save(rec).Error
+}
@@ -70,6 +70,7 @@ func SeedPlans(ctx context.Context, db *gorm.DB, planConfigs []config.PlanConfig
 					Policies:         pc.Features.Policies,
 					SecurityInsights: pc.Features.SecurityInsights,
 					BreachMonitoring: pc.Features.BreachMonitoring,
+					SendFileMaxMB:    pc.Features.SendFileMaxMB,
 				}
 				existing.IsActive = true
 
@@ -113,6 +114,7 @@ func SeedPlans(ctx context.Context, db *gorm.DB, planConfigs []config.PlanConfig
 					Policies:         pc.Features.Policies,
 					SecurityInsights: pc.Features.SecurityInsights,
 					BreachMonitoring: pc.Features.BreachMonitoring,
+					SendFileMaxMB:    pc.Features.SendFileMaxMB,
 				},
 				IsActive: true,
 			}
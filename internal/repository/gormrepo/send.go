@@ -72,11 +72,18 @@ func (r *sendRepository) ListByCreator(ctx context.Context, creatorID uint) ([]*
 
 func (r *sendRepository) Update(ctx context.Context, send *domain.Send) error {
 	send.Creator = nil
+	send.Recipients = nil
 	return r.db.WithContext(ctx).Save(send).Error
 }
 
+// Delete removes the send together with its recipients and access events
 func (r *sendRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&domain.Send{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := deleteSendDependents(tx, []uint{id}); err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Send{}, id).Error
+	})
 }
 
 func (r *sendRepository) SoftDelete(ctx context.Context, id uint) error {
@@ -94,10 +101,55 @@ func (r *sendRepository) IncrementAccessCount(ctx context.Context, id uint) erro
 		UpdateColumn("access_count", gorm.Expr("access_count + 1")).Error
 }
 
+func (r *sendRepository) TryIncrementAccessCount(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Send{}).
+		Where("id = ? AND (max_access_count IS NULL OR access_count < max_access_count)", id).
+		UpdateColumn("access_count", gorm.Expr("access_count + 1"))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *sendRepository) MarkFileUploaded(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.Send{}).
+		Where("id = ?", id).
+		Update("file_uploaded", true).Error
+}
+
+func (r *sendRepository) ListExpiredFiles(ctx context.Context) ([]*domain.Send, error) {
+	var sends []*domain.Send
+	err := r.db.WithContext(ctx).
+		Where("type = ? AND deletion_date <= ? AND deleted_at IS NULL", domain.SendTypeFile, time.Now()).
+		Find(&sends).Error
+	if err != nil {
+		return nil, err
+	}
+	return sends, nil
+}
+
 func (r *sendRepository) DeleteExpired(ctx context.Context) (int64, error) {
 	now := time.Now()
-	result := r.db.WithContext(ctx).
-		Where("deletion_date <= ? AND deleted_at IS NULL", now).
-		Delete(&domain.Send{})
-	return result.RowsAffected, result.Error
+	var deleted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		expired := tx.Model(&domain.Send{}).
+			Select("id").
+			Where("deletion_date <= ? AND deleted_at IS NULL AND type <> ?", now, domain.SendTypeFile)
+		if err := deleteSendDependents(tx, expired); err != nil {
+			return err
+		}
+
+		result := tx.Where("deletion_date <= ? AND deleted_at IS NULL AND type <> ?", now, domain.SendTypeFile).
+			Delete(&domain.Send{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
+
+// deleteSendDependents removes recipients and access events of the given send IDs (a slice or subquery)
+func deleteSendDependents(tx *gorm.DB, sendIDs interface{}) error {
+	if err := tx.Where("send_id IN (?)", sendIDs).Delete(&domain.SendAccessEvent{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("send_id IN (?)", sendIDs).Delete(&domain.SendRecipient{}).Error
 }
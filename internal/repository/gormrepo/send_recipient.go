@@ -0,0 +1,121 @@
+package gormrepo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"gorm.io/gorm"
+)
+
+type sendRecipientRepository struct {
+	db *gorm.DB
+}
+
+func NewSendRecipientRepository(db *gorm.DB) repository.SendRecipientRepository {
+	return &sendRecipientRepository{db: db}
+}
+
+func (r *sendRecipientRepository) ListBySend(ctx context.Context, sendID uint) ([]*domain.SendRecipient, error) {
+	var recipients []*domain.SendRecipient
+	err := r.db.WithContext(ctx).
+		Where("send_id = ?", sendID).
+		Order("email ASC").
+		Find(&recipients).Error
+	if err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+func (r *sendRecipientRepository) CountBySend(ctx context.Context, sendID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.SendRecipient{}).
+		Where("send_id = ?", sendID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *sendRecipientRepository) GetBySendAndEmail(ctx context.Context, sendID uint, email string) (*domain.SendRecipient, error) {
+	return r.first(ctx, "send_id = ? AND email = ?", sendID, email)
+}
+
+func (r *sendRecipientRepository) GetBySendAndTokenHash(ctx context.Context, sendID uint, tokenHash string) (*domain.SendRecipient, error) {
+	return r.first(ctx, "send_id = ? AND token_hash = ?", sendID, tokenHash)
+}
+
+func (r *sendRecipientRepository) first(ctx context.Context, query string, args ...interface{}) (*domain.SendRecipient, error) {
+	var recipient domain.SendRecipient
+	err := r.db.WithContext(ctx).Where(query, args...).First(&recipient).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	return &recipient, nil
+}
+
+func (r *sendRecipientRepository) ReplaceForSend(ctx context.Context, sendID uint, emails []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		remove := tx.Where("send_id = ?", sendID)
+		if len(emails) > 0 {
+			remove = remove.Where("email NOT IN ?", emails)
+		}
+		if err := remove.Delete(&domain.SendRecipient{}).Error; err != nil {
+			return err
+		}
+
+		var existing []string
+		if err := tx.Model(&domain.SendRecipient{}).
+			Where("send_id = ?", sendID).
+			Pluck("email", &existing).Error; err != nil {
+			return err
+		}
+		kept := make(map[string]struct{}, len(existing))
+		for _, email := range existing {
+			kept[email] = struct{}{}
+		}
+
+		for _, email := range emails {
+			if _, ok := kept[email]; ok {
+				continue
+			}
+			if err := tx.Create(&domain.SendRecipient{SendID: sendID, Email: email}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *sendRecipientRepository) TryIncrementCodeAttempts(ctx context.Context, id uint, maxAttempts int) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.SendRecipient{}).
+		Where("id = ? AND code_attempts < ?", id, maxAttempts).
+		UpdateColumn("code_attempts", gorm.Expr("code_attempts + 1"))
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *sendRecipientRepository) Update(ctx context.Context, recipient *domain.SendRecipient) error {
+	return r.db.WithContext(ctx).Save(recipient).Error
+}
+
+func (r *sendRecipientRepository) CreateEvent(ctx context.Context, event *domain.SendAccessEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *sendRecipientRepository) ListEventsBySend(ctx context.Context, sendID uint, limit int) ([]*domain.SendAccessEvent, error) {
+	var events []*domain.SendAccessEvent
+	err := r.db.WithContext(ctx).
+		Where("send_id = ?", sendID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
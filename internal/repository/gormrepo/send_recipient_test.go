@@ -0,0 +1,41 @@
+package gormrepo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/pkg/database/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSendRecipientRepository_TryIncrementCodeAttempts(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *gorm.DB) {
+		ctx := context.Background()
+		require.NoError(t, db.AutoMigrate(&domain.SendRecipient{}))
+
+		recipient := &domain.SendRecipient{SendID: 1, Email: uuid.NewString()[:8] + "@example.com", CodeAttempts: 3}
+		require.NoError(t, db.Create(recipient).Error)
+		t.Cleanup(func() { db.Delete(recipient) })
+
+		repo := NewSendRecipientRepository(db)
+		counted, err := repo.TryIncrementCodeAttempts(ctx, recipient.ID, 5)
+		require.NoError(t, err)
+		assert.True(t, counted)
+		counted, err = repo.TryIncrementCodeAttempts(ctx, recipient.ID, 5)
+		require.NoError(t, err)
+		assert.True(t, counted)
+
+		// The limit is checked by the update itself
+		counted, err = repo.TryIncrementCodeAttempts(ctx, recipient.ID, 5)
+		require.NoError(t, err)
+		assert.False(t, counted)
+
+		var stored domain.SendRecipient
+		require.NoError(t, db.First(&stored, recipient.ID).Error)
+		assert.Equal(t, 5, stored.CodeAttempts)
+	})
+}
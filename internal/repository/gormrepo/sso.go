@@ -3,6 +3,7 @@ package gormrepo
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
@@ -133,6 +134,6 @@ func (r *ssoStateRepository) Delete(ctx context.Context, id uint) error {
 }
 
 func (r *ssoStateRepository) DeleteExpired(ctx context.Context) (int64, error) {
-	result := r.db.WithContext(ctx).Where("expires_at < NOW()").Delete(&domain.SSOState{})
+	result := r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&domain.SSOState{})
 	return result.RowsAffected, result.Error
 }
@@ -2,12 +2,25 @@ package gormrepo
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/pkg/database"
 	"gorm.io/gorm"
 )
 
+// seatLockRetryInterval is how long WithSeatLock waits before trying a held lock again
+const seatLockRetryInterval = 50 * time.Millisecond
+
+// errSeatLockBusy signals that another instance holds the seat lock
+var errSeatLockBusy = errors.New("seat lock is held")
+
+// sqliteSeatLocks serializes seat changes on SQLite, which runs as a single instance
+var sqliteSeatLocks sync.Map // orgID -> *sync.Mutex
+
 type subscriptionRepository struct {
 	db *gorm.DB
 }
@@ -135,6 +148,45 @@ func (r *subscriptionRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&domain.Subscription{}, id).Error
 }
 
+// WithSeatLock runs fn while holding the organization's seat lock, so that server
+// instances cannot both claim the last seat. fn should only touch the database;
+// callers make Stripe calls outside the lock and reconcile afterwards.
+// On PostgreSQL this is a transaction-level advisory lock; fn writes through other
+// connections and the lock is released when the transaction ends. Waiting happens
+// outside the transaction so waiters do not hold pool connections.
+func (r *subscriptionRepository) WithSeatLock(ctx context.Context, orgID uint, fn func() error) error {
+	if database.DialectOf(r.db).IsSQLite() {
+		m, _ := sqliteSeatLocks.LoadOrStore(orgID, &sync.Mutex{})
+		mu := m.(*sync.Mutex)
+		mu.Lock()
+		defer mu.Unlock()
+		return fn()
+	}
+
+	key := fmt.Sprintf("passwall_seats:%d", orgID)
+	for {
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var locked bool
+			if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", key).Scan(&locked).Error; err != nil {
+				return fmt.Errorf("failed to lock seats: %w", err)
+			}
+			if !locked {
+				return errSeatLockBusy
+			}
+			return fn()
+		})
+		if !errors.Is(err, errSeatLockBusy) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(seatLockRetryInterval):
+		}
+	}
+}
+
 // ListByState retrieves subscriptions by state
 func (r *subscriptionRepository) ListByState(ctx context.Context, state domain.SubscriptionState) ([]*domain.Subscription, error) {
 	var subs []*domain.Subscription
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
 	"github.com/passwall/passwall-server/pkg/database"
+	"github.com/passwall/passwall-server/pkg/database/migrate"
 	"github.com/passwall/passwall-server/pkg/logger"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userRepository struct {
@@ -131,8 +135,139 @@ func (r *userRepository) List(ctx context.Context, filter repository.ListFilter)
 	return users, result, nil
 }
 
+// lastSignInExpr is the latest signin activity of the outer users row
+const lastSignInExpr = "(SELECT MAX(ua.created_at) FROM user_activities ua WHERE ua.user_id = users.id AND ua.activity_type = ?)"
+
+// userDirectorySortColumns maps API sort keys to ORDER BY expressions
+var userDirectorySortColumns = map[string]string{
+	"created_at":    "users.created_at",
+	"email":         "users.email",
+	"name":          "users.name",
+	"signup_source": "users.signup_source",
+	"last_sign_in":  lastSignInExpr,
+}
+
+func (r *userRepository) ListDirectory(ctx context.Context, filter repository.UserDirectoryFilter) ([]*domain.User, *repository.ListResult, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&domain.User{}).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	query := r.db.WithContext(ctx).Model(&domain.User{})
+
+	if filter.Search != "" {
+		prefix := escapeLike(strings.ToLower(filter.Search)) + "%"
+		query = query.Where(`(LOWER(users.email) LIKE ? ESCAPE '\' OR LOWER(users.name) LIKE ? ESCAPE '\')`, prefix, prefix)
+	}
+	if filter.IsVerified != nil {
+		query = query.Where("users.is_verified = ?", *filter.IsVerified)
+	}
+	if filter.TwoFactorEnabled != nil {
+		query = query.Where("users.two_factor_enabled = ?", *filter.TwoFactorEnabled)
+	}
+	if filter.SignupSource != "" {
+		query = query.Where("users.signup_source = ?", filter.SignupSource)
+	}
+	if filter.Role != "" {
+		query = query.Where("users.role_id IN (SELECT id FROM roles WHERE name = ?)", filter.Role)
+	}
+	if filter.NeverSignedIn {
+		query = query.Where("NOT EXISTS (SELECT 1 FROM user_activities ua WHERE ua.user_id = users.id AND ua.activity_type = ?)", domain.ActivityTypeSignIn)
+	}
+	if filter.LastSignInFrom != nil {
+		query = query.Where(lastSignInExpr+" >= ?", domain.ActivityTypeSignIn, *filter.LastSignInFrom)
+	}
+	if filter.LastSignInTo != nil {
+		query = query.Where(lastSignInExpr+" < ?", domain.ActivityTypeSignIn, *filter.LastSignInTo)
+	}
+	if filter.OrganizationID != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM organization_users ou WHERE ou.user_id = users.id AND ou.organization_id = ? AND ou.status <> ?)",
+			*filter.OrganizationID, domain.OrgUserStatusInvited)
+	}
+
+	var filtered int64
+	if err := query.Count(&filtered).Error; err != nil {
+		return nil, nil, err
+	}
+
+	// Sort keys are whitelisted; the id tie-breaker keeps pages stable
+	order := "DESC"
+	if database.ValidateOrderDirection(filter.Order) == nil {
+		order = strings.ToUpper(filter.Order)
+	}
+	sortExpr, ok := userDirectorySortColumns[filter.Sort]
+	if !ok {
+		sortExpr = userDirectorySortColumns["created_at"]
+	}
+	if sortExpr == lastSignInExpr {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  lastSignInExpr + " " + order + ", users.id " + order,
+			Vars: []interface{}{domain.ActivityTypeSignIn},
+		}})
+	} else {
+		query = query.Order(sortExpr + " " + order).Order("users.id " + order)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var users []*domain.User
+	if err := query.Preload("Role").Find(&users).Error; err != nil {
+		return nil, nil, err
+	}
+	if err := r.attachLastSignIn(ctx, users); err != nil {
+		return nil, nil, err
+	}
+
+	return users, &repository.ListResult{Total: total, Filtered: filtered}, nil
+}
+
+// attachLastSignIn loads the latest signin activity of each user in one query.
+// Activity IDs grow with time, so the highest signin ID per user is the latest one.
+func (r *userRepository) attachLastSignIn(ctx context.Context, users []*domain.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+
+	var activities []*domain.UserActivity
+	err := r.db.WithContext(ctx).
+		Where("id IN (?)", r.db.Model(&domain.UserActivity{}).
+			Select("MAX(id)").
+			Where("user_id IN ? AND activity_type = ?", ids, domain.ActivityTypeSignIn).
+			Group("user_id")).
+		Find(&activities).Error
+	if err != nil {
+		return err
+	}
+
+	byUser := make(map[uint]time.Time, len(activities))
+	for _, a := range activities {
+		byUser[a.UserID] = a.CreatedAt
+	}
+	for _, u := range users {
+		if at, ok := byUser[u.ID]; ok {
+			u.LastSignInAt = &at
+		}
+	}
+	return nil
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (r *userRepository) GetItemCount(ctx context.Context, schema string) (int, error) {
-	qualifiedTable, err := database.BuildQualifiedTableName(schema, "items")
+	qualifiedTable, err := database.DialectOf(r.db).QualifiedUserTableName(schema, itemsTable)
 	if err != nil {
 		return 0, err
 	}
@@ -165,6 +300,10 @@ func (r *userRepository) Delete(ctx context.Context, id uint, schema string) err
 		// Validate schema name to prevent SQL injection
 		if err := database.ValidateSchemaName(schema); err != nil {
 			logger.Errorf("user delete: invalid schema name %q: %v", schema, err)
+		} else if database.DialectOf(r.db).IsSQLite() {
+			if err := dropSQLiteVault(r.db.WithContext(ctx), schema); err != nil {
+				logger.Errorf("user delete: failed to drop vault of %q for user id %d: %v", schema, id, err)
+			}
 		} else {
 			// Safely quote the schema identifier
 			safeSchema := database.SanitizeIdentifier(schema)
@@ -189,6 +328,11 @@ func (r *userRepository) CreateSchema(schema string) error {
 			return fmt.Errorf("invalid schema name: %w", err)
 		}
 
+		// SQLite has no schemas; the vault tables are created by MigrateUserSchema
+		if database.DialectOf(r.db).IsSQLite() {
+			return nil
+		}
+
 		// Safely quote the schema identifier
 		safeSchema := database.SanitizeIdentifier(schema)
 		createSQL := fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", safeSchema)
@@ -208,97 +352,34 @@ func (r *userRepository) MigrateUserSchema(schema string) error {
 		return fmt.Errorf("invalid schema name: %w", err)
 	}
 
-	// Safely quote the schema identifier
-	safeSchema := database.SanitizeIdentifier(schema)
-
-	// Set the search path to the user's schema
-	setPathSQL := fmt.Sprintf("SET search_path TO %s", safeSchema)
-	if err := r.db.Exec(setPathSQL).Error; err != nil {
-		return fmt.Errorf("failed to set search path: %w", err)
-	}
-
-	// Create items table (modern flexible items)
-	if err := r.db.AutoMigrate(&domain.Item{}); err != nil {
-		_ = r.db.Exec("SET search_path TO public").Error
-		return fmt.Errorf("failed to create items table: %w", err)
-	}
-
-	// Create sequences for sync and support IDs
-	if err := r.db.Exec("CREATE SEQUENCE IF NOT EXISTS items_revision_seq").Error; err != nil {
-		_ = r.db.Exec("SET search_path TO public").Error
-		return fmt.Errorf("failed to create revision sequence: %w", err)
-	}
-
-	if err := r.db.Exec(`
-		CREATE SEQUENCE IF NOT EXISTS items_support_id_seq
-		START WITH 1000000000000000000
-		INCREMENT BY 1
-		NO MAXVALUE
-		CACHE 100
-	`).Error; err != nil {
-		_ = r.db.Exec("SET search_path TO public").Error
-		return fmt.Errorf("failed to create support_id sequence: %w", err)
-	}
-
-	// Create trigger for auto-updating revision and support_id
-	if err := r.db.Exec(`
-		CREATE OR REPLACE FUNCTION update_item_metadata()
-		RETURNS TRIGGER AS $$
-		BEGIN
-			IF TG_OP = 'INSERT' THEN
-				NEW.support_id = nextval('items_support_id_seq');
-			END IF;
-			
-			NEW.revision = nextval('items_revision_seq');
-			NEW.updated_at = NOW();
-			
-			RETURN NEW;
-		END;
-		$$ LANGUAGE plpgsql
-	`).Error; err != nil {
-		_ = r.db.Exec("SET search_path TO public").Error
-		return fmt.Errorf("failed to create trigger function: %w", err)
-	}
-
-	// Drop trigger first (separate exec)
-	if err := r.db.Exec("DROP TRIGGER IF EXISTS item_metadata_trigger ON items").Error; err != nil {
-		_ = r.db.Exec("SET search_path TO public").Error
-		return fmt.Errorf("failed to drop trigger: %w", err)
-	}
-
-	// Create trigger (separate exec)
-	if err := r.db.Exec(`
-		CREATE TRIGGER item_metadata_trigger
-			BEFORE INSERT OR UPDATE ON items
-			FOR EACH ROW
-			EXECUTE FUNCTION update_item_metadata()
-	`).Error; err != nil {
-		_ = r.db.Exec("SET search_path TO public").Error
-		return fmt.Errorf("failed to create trigger: %w", err)
-	}
-
-	// Create indexes for performance
-	indexes := []string{
-		"CREATE INDEX IF NOT EXISTS idx_items_support_id ON items(support_id)",
-		"CREATE INDEX IF NOT EXISTS idx_items_revision ON items(revision DESC)",
-		"CREATE INDEX IF NOT EXISTS idx_items_type ON items(item_type) WHERE deleted_at IS NULL",
-		"CREATE INDEX IF NOT EXISTS idx_items_favorite ON items(is_favorite) WHERE deleted_at IS NULL AND is_favorite = true",
-		"CREATE INDEX IF NOT EXISTS idx_items_autofill ON items(auto_fill) WHERE item_type = 1 AND auto_fill = true AND deleted_at IS NULL",
-		"CREATE INDEX IF NOT EXISTS idx_items_metadata_gin ON items USING gin(metadata jsonb_path_ops)",
-	}
-
-	for _, indexSQL := range indexes {
-		if err := r.db.Exec(indexSQL).Error; err != nil {
-			_ = r.db.Exec("SET search_path TO public").Error
-			return fmt.Errorf("failed to create index: %w", err)
-		}
+	// SQLite: prefixed tables at their final structure, no versioned migrations
+	if database.DialectOf(r.db).IsSQLite() {
+		return r.db.Transaction(func(tx *gorm.DB) error {
+			return createSQLiteVault(tx, schema)
+		})
 	}
 
-	// NOTE: Legacy tables removed - all item types now use the items table
+	// Create items table (modern flexible items) with its final structure
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL search_path TO " + database.SanitizeIdentifier(schema)).Error; err != nil {
+			return fmt.Errorf("failed to set search path: %w", err)
+		}
+		if err := tx.AutoMigrate(&domain.Item{}); err != nil {
+			return fmt.Errorf("failed to create items table: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
 
-	// Reset search path to public
-	if err := r.db.Exec("SET search_path TO public").Error; err != nil {
-		return fmt.Errorf("failed to reset search path: %w", err)
+	// Sequences, trigger and indexes come from the versioned user schema migrations
+	userMigrations, err := migrate.UserMigrations()
+	if err != nil {
+		return err
+	}
+	if _, err := migrate.New(r.db, nil, userMigrations, 1).Up(context.Background(), schema); err != nil {
+		return fmt.Errorf("failed to migrate user schema: %w", err)
 	}
 
 	return nil
@@ -122,6 +122,25 @@ func (r *userActivityRepository) ListByUserIDs(ctx context.Context, userIDs []ui
 	return activities, nil
 }
 
+func (r *userActivityRepository) CountByUserIDs(ctx context.Context, userIDs []uint, activityType domain.ActivityType, since, until time.Time) (int64, error) {
+	var count int64
+
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Model(&domain.UserActivity{}).
+		Where("user_id IN ? AND activity_type = ?", userIDs, activityType).
+		Where("created_at >= ? AND created_at < ?", since, until).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (r *userActivityRepository) DeleteByUserID(ctx context.Context, userID uint) error {
 	return r.db.WithContext(ctx).
 		Where("user_id = ?", userID).
@@ -0,0 +1,119 @@
+package gormrepo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/pkg/database/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestUserRepository_ListDirectory(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, db *gorm.DB) {
+		ctx := context.Background()
+		require.NoError(t, db.AutoMigrate(&domain.Role{}, &domain.User{}, &domain.UserActivity{}, &domain.Organization{}, &domain.OrganizationUser{}))
+
+		tag := uuid.NewString()[:8]
+		adminRole := &domain.Role{Name: "dir_admin_" + tag}
+		memberRole := &domain.Role{Name: "dir_member_" + tag}
+		require.NoError(t, db.Create(adminRole).Error)
+		require.NoError(t, db.Create(memberRole).Error)
+
+		newUser := func(name, email string, role *domain.Role, verified, twoFactor bool, source string) *domain.User {
+			u := &domain.User{
+				UUID:               uuid.New(),
+				Name:               name,
+				Email:              fmt.Sprintf("%s+%s", tag, email),
+				Schema:             "user_" + uuid.NewString()[:8],
+				SignupSource:       source,
+				RoleID:             role.ID,
+				IsVerified:         verified,
+				TwoFactorEnabled:   twoFactor,
+				MasterPasswordHash: "hash",
+				ProtectedUserKey:   "2.key",
+				KdfSalt:            "salt",
+			}
+			require.NoError(t, db.Create(u).Error)
+			t.Cleanup(func() { db.Delete(u) })
+			return u
+		}
+		alice := newUser("Alice "+tag, "alice@example.com", adminRole, true, true, "vault")
+		bob := newUser("Bob", "bob_100%@example.com", memberRole, true, false, "mobile")
+		carol := newUser("Carol", "carol@example.com", memberRole, false, false, "vault")
+
+		now := time.Now().UTC()
+		for _, a := range []*domain.UserActivity{
+			{UserID: alice.ID, ActivityType: domain.ActivityTypeSignIn, CreatedAt: now.Add(-48 * time.Hour)},
+			{UserID: alice.ID, ActivityType: domain.ActivityTypeSignIn, CreatedAt: now.Add(-time.Hour)},
+			{UserID: bob.ID, ActivityType: domain.ActivityTypeSignIn, CreatedAt: now.Add(-30 * 24 * time.Hour)},
+			{UserID: carol.ID, ActivityType: domain.ActivityTypeFailedSignIn, CreatedAt: now},
+		} {
+			require.NoError(t, db.Create(a).Error)
+		}
+		org := &domain.Organization{UUID: uuid.New(), Name: "Directory " + tag, BillingEmail: "billing@example.com"}
+		require.NoError(t, db.Create(org).Error)
+		require.NoError(t, db.Create(&domain.OrganizationUser{UUID: uuid.New(), OrganizationID: org.ID, UserID: bob.ID, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed}).Error)
+		require.NoError(t, db.Create(&domain.OrganizationUser{UUID: uuid.New(), OrganizationID: org.ID, UserID: carol.ID, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusInvited}).Error)
+		t.Cleanup(func() {
+			db.Where("user_id IN ?", []uint{alice.ID, bob.ID, carol.ID}).Delete(&domain.UserActivity{})
+			db.Where("organization_id = ?", org.ID).Delete(&domain.OrganizationUser{})
+			db.Unscoped().Delete(org)
+			db.Delete(adminRole)
+			db.Delete(memberRole)
+		})
+
+		repo := NewUserRepository(db)
+		emails := func(filter repository.UserDirectoryFilter) []string {
+			t.Helper()
+			users, _, err := repo.ListDirectory(ctx, filter)
+			require.NoError(t, err)
+			out := make([]string, len(users))
+			for i, u := range users {
+				out[i] = u.Email[len(tag)+1:]
+			}
+			return out
+		}
+		yes, no := true, false
+		orgID := org.ID
+		weekAgo := now.Add(-7 * 24 * time.Hour)
+
+		// Search is a case-insensitive prefix on email or name; the tag prefix scopes to this test
+		assert.Equal(t, []string{"alice@example.com", "bob_100%@example.com", "carol@example.com"},
+			emails(repository.UserDirectoryFilter{Search: tag, Sort: "email", Order: "asc"}))
+		assert.Equal(t, []string{"alice@example.com"}, emails(repository.UserDirectoryFilter{Search: "ALICE " + tag}))
+		assert.Empty(t, emails(repository.UserDirectoryFilter{Search: tag + "+bob_100%x"}), "LIKE wildcards match literally")
+
+		scoped := func(f repository.UserDirectoryFilter) repository.UserDirectoryFilter {
+			f.Search = tag
+			f.Sort, f.Order = "email", "asc"
+			return f
+		}
+		assert.Equal(t, []string{"alice@example.com", "bob_100%@example.com"}, emails(scoped(repository.UserDirectoryFilter{IsVerified: &yes})))
+		assert.Equal(t, []string{"alice@example.com"}, emails(scoped(repository.UserDirectoryFilter{TwoFactorEnabled: &yes})))
+		assert.Equal(t, []string{"bob_100%@example.com", "carol@example.com"}, emails(scoped(repository.UserDirectoryFilter{TwoFactorEnabled: &no})))
+		assert.Equal(t, []string{"bob_100%@example.com"}, emails(scoped(repository.UserDirectoryFilter{SignupSource: "mobile"})))
+		assert.Equal(t, []string{"alice@example.com"}, emails(scoped(repository.UserDirectoryFilter{Role: adminRole.Name})))
+		assert.Equal(t, []string{"alice@example.com"}, emails(scoped(repository.UserDirectoryFilter{LastSignInFrom: &weekAgo})))
+		assert.Equal(t, []string{"bob_100%@example.com"}, emails(scoped(repository.UserDirectoryFilter{LastSignInTo: &weekAgo})))
+		assert.Equal(t, []string{"carol@example.com"}, emails(scoped(repository.UserDirectoryFilter{NeverSignedIn: true})))
+		assert.Equal(t, []string{"bob_100%@example.com"}, emails(scoped(repository.UserDirectoryFilter{OrganizationID: &orgID})),
+			"pending invitations are not memberships")
+
+		users, result, err := repo.ListDirectory(ctx, repository.UserDirectoryFilter{Search: tag, Sort: "last_sign_in", Order: "desc", Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), result.Filtered)
+		assert.GreaterOrEqual(t, result.Total, int64(3))
+		require.Len(t, users, 1)
+		require.NotNil(t, users[0].Role)
+		assert.Equal(t, bob.ID, users[0].ID)
+		require.NotNil(t, users[0].LastSignInAt)
+		assert.WithinDuration(t, now.Add(-30*24*time.Hour), *users[0].LastSignInAt, time.Second)
+	})
+}
@@ -0,0 +1,111 @@
+package gormrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/passwall/passwall-server/pkg/database"
+	"gorm.io/gorm"
+)
+
+// inUserSchema runs fn in a transaction scoped to a table of a user's vault.
+// PostgreSQL selects the user's schema with SET LOCAL search_path, so it never leaks to
+// pooled connections; SQLite has no schemas and binds tx to the prefixed table instead.
+func inUserSchema(ctx context.Context, db *gorm.DB, schema, table string, fn func(tx *gorm.DB) error) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		scoped, err := scopeUserSchema(tx, schema, table)
+		if err != nil {
+			return err
+		}
+		return fn(scoped)
+	})
+}
+
+// scopeUserSchema points tx at a user's vault table; tx must be a transaction
+func scopeUserSchema(tx *gorm.DB, schema, table string) (*gorm.DB, error) {
+	dialect := database.DialectOf(tx)
+	name, err := dialect.UserTableName(schema, table)
+	if err != nil {
+		return nil, err
+	}
+	if dialect.IsSQLite() {
+		// A new session keeps the table for every statement built from it
+		return tx.Table(name).Session(&gorm.Session{}), nil
+	}
+	if err := tx.Exec("SET LOCAL search_path TO " + database.SanitizeIdentifier(schema)).Error; err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// sqliteVaultDDL creates the personal items table of one user on SQLite at its final
+// structure. It mirrors domain.Item and the PostgreSQL user migrations: index names carry
+// the table prefix (SQLite index names are database-wide) and triggers assign support_id
+// and bump revision in place of the PostgreSQL sequences. %[1]s is the quoted table,
+// %[2]s the bare table name used for index and trigger names.
+var sqliteVaultDDL = []string{
+	`CREATE TABLE IF NOT EXISTS %[1]s (
+		id integer PRIMARY KEY AUTOINCREMENT,
+		uuid uuid,
+		support_id integer NOT NULL,
+		created_at datetime,
+		updated_at datetime,
+		deleted_at datetime,
+		revision integer NOT NULL DEFAULT 0,
+		sync_version integer NOT NULL DEFAULT 1,
+		item_type integer NOT NULL,
+		data text NOT NULL,
+		item_key_enc text,
+		metadata jsonb NOT NULL,
+		is_favorite numeric DEFAULT false,
+		folder_id integer,
+		reprompt numeric DEFAULT false,
+		auto_fill numeric DEFAULT true,
+		auto_login numeric DEFAULT false,
+		archived_at datetime
+	)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS "idx_%[2]s_uuid" ON %[1]s (uuid)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS "idx_%[2]s_support_id" ON %[1]s (support_id)`,
+	`CREATE INDEX IF NOT EXISTS "idx_%[2]s_deleted_at" ON %[1]s (deleted_at)`,
+	`CREATE INDEX IF NOT EXISTS "idx_%[2]s_revision" ON %[1]s (revision DESC)`,
+	`CREATE INDEX IF NOT EXISTS "idx_%[2]s_type" ON %[1]s (item_type) WHERE deleted_at IS NULL`,
+	// The row is inserted with support_id 0 and renumbered right away, so 0 never collides
+	`CREATE TRIGGER IF NOT EXISTS "%[2]s_metadata_insert" AFTER INSERT ON %[1]s
+	BEGIN
+		UPDATE %[1]s SET
+			support_id = 1000000000000000000 + NEW.id - 1,
+			revision = (SELECT COALESCE(MAX(revision), 0) + 1 FROM %[1]s)
+		WHERE id = NEW.id;
+	END`,
+	// Only bump when the statement left revision alone (this also skips the insert trigger's update)
+	`CREATE TRIGGER IF NOT EXISTS "%[2]s_metadata_update" AFTER UPDATE ON %[1]s
+	WHEN NEW.revision = OLD.revision
+	BEGIN
+		UPDATE %[1]s SET revision = (SELECT COALESCE(MAX(revision), 0) + 1 FROM %[1]s)
+		WHERE id = NEW.id;
+	END`,
+}
+
+// createSQLiteVault creates (idempotently) the vault tables of a user on SQLite
+func createSQLiteVault(tx *gorm.DB, schema string) error {
+	name, err := database.DialectSQLite.UserTableName(schema, itemsTable)
+	if err != nil {
+		return err
+	}
+	quoted := database.SanitizeIdentifier(name)
+	for _, stmt := range sqliteVaultDDL {
+		if err := tx.Exec(fmt.Sprintf(stmt, quoted, name)).Error; err != nil {
+			return fmt.Errorf("failed to create vault table %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// dropSQLiteVault drops the vault tables of a user on SQLite (indexes and triggers go with them)
+func dropSQLiteVault(tx *gorm.DB, schema string) error {
+	name, err := database.DialectSQLite.UserTableName(schema, itemsTable)
+	if err != nil {
+		return err
+	}
+	return tx.Exec("DROP TABLE IF EXISTS " + database.SanitizeIdentifier(name)).Error
+}
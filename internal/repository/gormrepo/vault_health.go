@@ -0,0 +1,66 @@
+package gormrepo
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type vaultHealthRepository struct {
+	db *gorm.DB
+}
+
+// NewVaultHealthRepository creates a new vault health repository.
+func NewVaultHealthRepository(db *gorm.DB) repository.VaultHealthRepository {
+	return &vaultHealthRepository{db: db}
+}
+
+func (r *vaultHealthRepository) UpsertSignals(ctx context.Context, signals []*domain.ItemHealthSignal) error {
+	if len(signals) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "organization_item_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"reported_by_user_id", "password_hash", "strength_score", "exposed",
+				"two_factor_available", "two_factor_enabled", "password_changed_at", "updated_at",
+			}),
+		}).
+		Create(&signals).Error
+}
+
+func (r *vaultHealthRepository) ListSignalsByOrganization(ctx context.Context, orgID uint) ([]*domain.ItemHealthSignal, error) {
+	var signals []*domain.ItemHealthSignal
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ?", orgID).
+		Find(&signals).Error
+	return signals, err
+}
+
+func (r *vaultHealthRepository) UpsertSnapshots(ctx context.Context, snapshots []*domain.VaultHealthSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "organization_id"}, {Name: "collection_id"}, {Name: "date"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"total_items", "reported_items", "weak", "reused", "exposed", "stale", "missing_2fa", "updated_at",
+			}),
+		}).
+		Create(&snapshots).Error
+}
+
+func (r *vaultHealthRepository) ListSnapshots(ctx context.Context, orgID, collectionID uint, since time.Time) ([]*domain.VaultHealthSnapshot, error) {
+	var snapshots []*domain.VaultHealthSnapshot
+	err := r.db.WithContext(ctx).
+		Where("organization_id = ? AND collection_id = ? AND date >= ?", orgID, collectionID, since).
+		Order("date ASC").
+		Find(&snapshots).Error
+	return snapshots, err
+}
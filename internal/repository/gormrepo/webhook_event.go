@@ -2,10 +2,13 @@ package gormrepo
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type webhookEventRepository struct {
@@ -13,13 +16,22 @@ type webhookEventRepository struct {
 }
 
 // NewWebhookEventRepository creates a new webhook event repository
-func NewWebhookEventRepository(db *gorm.DB) *webhookEventRepository {
+func NewWebhookEventRepository(db *gorm.DB) repository.WebhookEventRepository {
 	return &webhookEventRepository{db: db}
 }
 
-// Create creates a new webhook event
+// Create stores a new webhook event; duplicates (same provider and event ID) are rejected
 func (r *webhookEventRepository) Create(ctx context.Context, event *domain.WebhookEvent) error {
-	return r.db.WithContext(ctx).Create(event).Error
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(event)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrAlreadyExists
+	}
+	return nil
 }
 
 // GetByID retrieves a webhook event by ID
@@ -27,80 +39,118 @@ func (r *webhookEventRepository) GetByID(ctx context.Context, id uint) (*domain.
 	var event domain.WebhookEvent
 	err := r.db.WithContext(ctx).First(&event, id).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
 		return nil, err
 	}
 	return &event, nil
 }
 
-// GetByStripeEventID retrieves a webhook event by Stripe event ID
-func (r *webhookEventRepository) GetByStripeEventID(ctx context.Context, stripeEventID string) (*domain.WebhookEvent, error) {
+// GetByEventID retrieves a webhook event by provider event ID
+func (r *webhookEventRepository) GetByEventID(ctx context.Context, provider domain.WebhookProvider, eventID string) (*domain.WebhookEvent, error) {
 	var event domain.WebhookEvent
-	err := r.db.WithContext(ctx).Where("stripe_event_id = ?", stripeEventID).First(&event).Error
+	err := r.db.WithContext(ctx).
+		Where("provider = ? AND event_id = ?", provider, eventID).
+		First(&event).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrNotFound
+		}
 		return nil, err
 	}
 	return &event, nil
 }
 
-// Exists checks if a webhook event exists by Stripe event ID
-func (r *webhookEventRepository) Exists(ctx context.Context, stripeEventID string) (bool, error) {
-	var count int64
-	err := r.db.WithContext(ctx).
-		Model(&domain.WebhookEvent{}).
-		Where("stripe_event_id = ?", stripeEventID).
-		Count(&count).Error
-	return count > 0, err
-}
-
-// MarkProcessed marks a webhook event as processed
-func (r *webhookEventRepository) MarkProcessed(ctx context.Context, stripeEventID string) error {
-	now := time.Now()
-	return r.db.WithContext(ctx).
-		Model(&domain.WebhookEvent{}).
-		Where("stripe_event_id = ?", stripeEventID).
-		Updates(map[string]interface{}{
-			"processed_at": now,
-			"error":        nil,
-		}).Error
+// Update updates a webhook event
+func (r *webhookEventRepository) Update(ctx context.Context, event *domain.WebhookEvent) error {
+	return r.db.WithContext(ctx).Save(event).Error
 }
 
-// MarkFailed marks a webhook event as failed with an error message
-func (r *webhookEventRepository) MarkFailed(ctx context.Context, stripeEventID string, errMsg string) error {
-	return r.db.WithContext(ctx).
+// Claim atomically takes a processing lease on a webhook event
+func (r *webhookEventRepository) Claim(ctx context.Context, id uint, now, leaseUntil time.Time, force bool) (bool, error) {
+	query := r.db.WithContext(ctx).
 		Model(&domain.WebhookEvent{}).
-		Where("stripe_event_id = ?", stripeEventID).
-		Update("error", errMsg).Error
-}
+		Where("id = ?", id)
+	if force {
+		query = query.Where("status <> ? OR next_attempt_at IS NULL OR next_attempt_at <= ?", domain.WebhookStatusProcessing, now)
+	} else {
+		query = query.Where("(status IN ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)) OR (status = ? AND next_attempt_at <= ?)",
+			[]domain.WebhookEventStatus{domain.WebhookStatusPending, domain.WebhookStatusFailed}, now,
+			domain.WebhookStatusProcessing, now,
+		)
+	}
 
-// Update updates a webhook event
-func (r *webhookEventRepository) Update(ctx context.Context, event *domain.WebhookEvent) error {
-	return r.db.WithContext(ctx).Save(event).Error
+	result := query.Updates(map[string]interface{}{
+		"status":          domain.WebhookStatusProcessing,
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_attempt_at": now,
+		"next_attempt_at": leaseUntil,
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
 }
 
-// ListUnprocessed retrieves unprocessed webhook events
-func (r *webhookEventRepository) ListUnprocessed(ctx context.Context) ([]*domain.WebhookEvent, error) {
+// ListDue retrieves events whose first attempt or retry is due (including expired processing leases)
+func (r *webhookEventRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookEvent, error) {
 	var events []*domain.WebhookEvent
 	err := r.db.WithContext(ctx).
-		Where("processed_at IS NULL AND error IS NULL").
+		Where("status IN ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)", []domain.WebhookEventStatus{
+			domain.WebhookStatusPending,
+			domain.WebhookStatusFailed,
+			domain.WebhookStatusProcessing,
+		}, now).
 		Order("created_at ASC").
+		Limit(limit).
 		Find(&events).Error
 	return events, err
 }
 
-// ListFailed retrieves failed webhook events
-func (r *webhookEventRepository) ListFailed(ctx context.Context) ([]*domain.WebhookEvent, error) {
-	var events []*domain.WebhookEvent
-	err := r.db.WithContext(ctx).
-		Where("error IS NOT NULL").
+// List retrieves webhook events for admin listings (payload omitted)
+func (r *webhookEventRepository) List(ctx context.Context, filter repository.WebhookEventFilter) ([]*domain.WebhookEvent, int64, int64, error) {
+	var (
+		events   []*domain.WebhookEvent
+		total    int64
+		filtered int64
+	)
+
+	if err := r.db.WithContext(ctx).Model(&domain.WebhookEvent{}).Count(&total).Error; err != nil {
+		return nil, 0, 0, err
+	}
+
+	query := r.db.WithContext(ctx).Model(&domain.WebhookEvent{})
+	if filter.Provider != "" {
+		query = query.Where("provider = ?", filter.Provider)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.EventType != "" {
+		query = query.Where("event_type = ?", filter.EventType)
+	}
+	if filter.Search != "" {
+		query = query.Where("event_id LIKE ?", "%"+filter.Search+"%")
+	}
+
+	if err := query.Count(&filtered).Error; err != nil {
+		return nil, 0, 0, err
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	err := query.
+		Omit("payload").
 		Order("created_at DESC").
 		Find(&events).Error
-	return events, err
-}
-
-// DeleteOld deletes webhook events older than the specified duration
-func (r *webhookEventRepository) DeleteOld(ctx context.Context, olderThan time.Duration) error {
-	cutoff := time.Now().Add(-olderThan)
-	return r.db.WithContext(ctx).
-		Where("created_at < ? AND processed_at IS NOT NULL", cutoff).
-		Delete(&domain.WebhookEvent{}).Error
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return events, total, filtered, nil
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/passwall/passwall-server/internal/domain"
 )
@@ -14,8 +15,14 @@ type InvitationRepository interface {
 	GetByID(ctx context.Context, id uint) (*domain.Invitation, error)
 	GetAllByEmail(ctx context.Context, email string) ([]*domain.Invitation, error)
 	GetByCreator(ctx context.Context, createdBy uint) ([]*domain.Invitation, error)
+	// GetLatestByOrgAndEmail returns the newest unused organization invitation, expired or not
+	GetLatestByOrgAndEmail(ctx context.Context, orgID uint, email string) (*domain.Invitation, error)
+	// ListExpired returns unused invitations that expired before the given time
+	ListExpired(ctx context.Context, before time.Time) ([]*domain.Invitation, error)
 	Update(ctx context.Context, invitation *domain.Invitation) error
 	Delete(ctx context.Context, id uint) error
 	DeleteByEmail(ctx context.Context, email string) error
+	// DeleteByOrgAndEmail deletes the unused organization invitations of an email
+	DeleteByOrgAndEmail(ctx context.Context, orgID uint, email string) error
 	DeleteExpired(ctx context.Context) error
 }
@@ -16,6 +16,13 @@ type ItemRepository interface {
 	Update(ctx context.Context, schema string, item *domain.Item) error
 	Delete(ctx context.Context, schema string, id uint) error
 	HardDelete(ctx context.Context, schema string, id uint) error
+
+	// Bulk operations run in one transaction per call. A failing item is rolled back
+	// to its own savepoint and reported per item; the rest of the batch commits.
+	CreateBatch(ctx context.Context, schema string, items []*domain.Item) ([]error, error)
+	// UpdateBatch loads the items (including soft-deleted ones) and saves each item apply accepts.
+	// Errors are keyed by item ID; IDs that do not exist report ErrNotFound.
+	UpdateBatch(ctx context.Context, schema string, ids []uint, apply func(item *domain.Item) error) (map[uint]error, error)
 }
 
 // ItemFilter - Filter options for listing items
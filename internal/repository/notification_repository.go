@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+)
+
+// NotificationRepository defines data access methods for in-app notifications.
+type NotificationRepository interface {
+	Create(ctx context.Context, n *domain.Notification) error
+	GetByID(ctx context.Context, id uint) (*domain.Notification, error)
+	// ListByUser returns the user's newest notifications first
+	ListByUser(ctx context.Context, userID uint, unreadOnly bool, limit int) ([]*domain.Notification, error)
+	Update(ctx context.Context, n *domain.Notification) error
+	MarkAllRead(ctx context.Context, userID uint, at time.Time) error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/passwall/passwall-server/internal/domain"
+)
+
+// OrganizationCustomRoleRepository defines the interface for organization-defined roles
+type OrganizationCustomRoleRepository interface {
+	// Create adds a new custom role
+	Create(ctx context.Context, role *domain.OrganizationCustomRole) error
+
+	// GetByID returns a custom role of the organization
+	GetByID(ctx context.Context, orgID, id uint) (*domain.OrganizationCustomRole, error)
+
+	// GetByName returns the organization's custom role with the given name
+	GetByName(ctx context.Context, orgID uint, name string) (*domain.OrganizationCustomRole, error)
+
+	// ListByOrganization returns all custom roles of an organization
+	ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationCustomRole, error)
+
+	// Update saves changes to a custom role
+	Update(ctx context.Context, role *domain.OrganizationCustomRole) error
+
+	// Delete removes a custom role and unassigns it from members
+	Delete(ctx context.Context, orgID, id uint) error
+
+	// CountMembers returns how many members hold each custom role of the organization
+	CountMembers(ctx context.Context, orgID uint) (map[uint]int, error)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/passwall/passwall-server/internal/domain"
+)
+
+// PromotionRepository defines data access methods for promo codes, redemptions and trial extensions.
+type PromotionRepository interface {
+	// PromoCode CRUD
+	CreatePromoCode(ctx context.Context, code *domain.PromoCode) error
+	GetPromoCodeByID(ctx context.Context, id uint) (*domain.PromoCode, error)
+	GetPromoCodeByCode(ctx context.Context, code string) (*domain.PromoCode, error)
+	ListPromoCodes(ctx context.Context) ([]*domain.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, code *domain.PromoCode) error
+	// IncrementTimesRedeemed atomically bumps times_redeemed while respecting max_redemptions.
+	// Returns false when the code has no redemptions left.
+	IncrementTimesRedeemed(ctx context.Context, id uint) (bool, error)
+	// DecrementTimesRedeemed gives back a redemption reserved with IncrementTimesRedeemed
+	DecrementTimesRedeemed(ctx context.Context, id uint) error
+
+	// Redemptions (audit trail)
+	CreateRedemption(ctx context.Context, redemption *domain.PromoRedemption) error
+	UpdateRedemption(ctx context.Context, redemption *domain.PromoRedemption) error
+	GetRedemptionByCheckoutSession(ctx context.Context, sessionID string) (*domain.PromoRedemption, error)
+	GetLatestRedemptionByOrganization(ctx context.Context, orgID uint) (*domain.PromoRedemption, error)
+	HasRedeemed(ctx context.Context, promoCodeID, orgID uint) (bool, error)
+	ListRedemptionsByPromoCode(ctx context.Context, promoCodeID uint) ([]*domain.PromoRedemption, error)
+	ListRedemptionsByOrganization(ctx context.Context, orgID uint) ([]*domain.PromoRedemption, error)
+
+	// Trial extensions
+	CreateTrialExtension(ctx context.Context, ext *domain.TrialExtension) error
+	UpdateTrialExtension(ctx context.Context, ext *domain.TrialExtension) error
+	ListTrialExtensionsByOrganization(ctx context.Context, orgID uint) ([]*domain.TrialExtension, error)
+	ListPendingTrialExtensions(ctx context.Context, orgID uint) ([]*domain.TrialExtension, error)
+}
@@ -27,6 +27,23 @@ type ListFilter struct {
 	Order  string
 }
 
+// UserDirectoryFilter filters the admin user directory. Nil pointers and empty strings mean "any".
+type UserDirectoryFilter struct {
+	Search           string // case-insensitive email or name prefix
+	IsVerified       *bool
+	TwoFactorEnabled *bool
+	SignupSource     string
+	Role             string
+	LastSignInFrom   *time.Time
+	LastSignInTo     *time.Time
+	NeverSignedIn    bool
+	OrganizationID   *uint
+	Sort             string
+	Order            string
+	Limit            int
+	Offset           int
+}
+
 // ListResult represents list query results with pagination info
 type ListResult struct {
 	Total    int64
@@ -43,6 +60,7 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	GetBySchema(ctx context.Context, schema string) (*domain.User, error)
 	List(ctx context.Context, filter ListFilter) ([]*domain.User, *ListResult, error)
+	ListDirectory(ctx context.Context, filter UserDirectoryFilter) ([]*domain.User, *ListResult, error)
 	GetItemCount(ctx context.Context, schema string) (int, error)
 	Create(ctx context.Context, user *domain.User) error
 	Update(ctx context.Context, user *domain.User) error
@@ -68,6 +86,8 @@ type UserAppearancePreferencesRepository interface {
 type PreferencesRepository interface {
 	ListByOwner(ctx context.Context, ownerType string, ownerID uint, section string) ([]*domain.Preference, error)
 	UpsertMany(ctx context.Context, prefs []*domain.Preference) error
+	// ListOwnerIDs returns the owners whose preference section/key holds the given value
+	ListOwnerIDs(ctx context.Context, ownerType, section, key, value string) ([]uint, error)
 }
 
 // TokenRepository defines token data access methods
@@ -152,6 +172,9 @@ type OrganizationUserRepository interface {
 	// Invitations
 	CountInvited(ctx context.Context, orgID uint) (int, error)
 	ListPendingInvitations(ctx context.Context, userEmail string) ([]*domain.OrganizationUser, error)
+
+	// Suspension
+	ListSuspended(ctx context.Context) ([]*domain.OrganizationUser, error)
 }
 
 // TeamRepository defines team data access methods
@@ -186,13 +209,25 @@ type CollectionRepository interface {
 	Create(ctx context.Context, collection *domain.Collection) error
 	GetByID(ctx context.Context, id uint) (*domain.Collection, error)
 	GetByUUID(ctx context.Context, uuid string) (*domain.Collection, error)
-	GetByName(ctx context.Context, orgID uint, name string) (*domain.Collection, error)
+	// GetByName looks a name up among the children of parentID (top level when nil)
+	GetByName(ctx context.Context, orgID uint, parentID *uint, name string) (*domain.Collection, error)
 	GetDefaultByOrganization(ctx context.Context, orgID uint) (*domain.Collection, error)
 	ListByOrganization(ctx context.Context, orgID uint) ([]*domain.Collection, error)
+	// ListForUser returns the collections the user is granted, directly or through a team,
+	// together with all of their descendants
 	ListForUser(ctx context.Context, orgID, userID uint) ([]*domain.Collection, error)
 	Update(ctx context.Context, collection *domain.Collection) error
 	Delete(ctx context.Context, id uint) error
 	SoftDelete(ctx context.Context, id uint) error
+	// SoftDeleteMany soft-deletes the collections and, in the same transaction, unassigns
+	// their items, moving items that would be left without a collection to the fallback
+	SoftDeleteMany(ctx context.Context, ids []uint, fallbackCollectionID uint) error
+
+	// Tree
+	// ListAncestorIDs returns the collection followed by its parent, grandparent, ... up to the root
+	ListAncestorIDs(ctx context.Context, collectionID uint) ([]uint, error)
+	// ListSubtree returns the collection and all of its descendants, parents before children
+	ListSubtree(ctx context.Context, collectionID uint) ([]*domain.Collection, error)
 
 	// Stats
 	GetItemCount(ctx context.Context, collectionID uint) (int, error)
@@ -207,6 +242,7 @@ type CollectionUserRepository interface {
 	GetByCollectionAndOrgUser(ctx context.Context, collectionID, orgUserID uint) (*domain.CollectionUser, error)
 	ListByCollection(ctx context.Context, collectionID uint) ([]*domain.CollectionUser, error)
 	ListByOrgUser(ctx context.Context, orgUserID uint) ([]*domain.CollectionUser, error)
+	ListByOrgUserAndCollections(ctx context.Context, orgUserID uint, collectionIDs []uint) ([]*domain.CollectionUser, error)
 	Update(ctx context.Context, cu *domain.CollectionUser) error
 	Delete(ctx context.Context, id uint) error
 	DeleteByCollectionAndOrgUser(ctx context.Context, collectionID, orgUserID uint) error
@@ -219,6 +255,7 @@ type CollectionTeamRepository interface {
 	GetByCollectionAndTeam(ctx context.Context, collectionID, teamID uint) (*domain.CollectionTeam, error)
 	ListByCollection(ctx context.Context, collectionID uint) ([]*domain.CollectionTeam, error)
 	ListByTeam(ctx context.Context, teamID uint) ([]*domain.CollectionTeam, error)
+	ListByCollections(ctx context.Context, collectionIDs []uint) ([]*domain.CollectionTeam, error)
 	Update(ctx context.Context, ct *domain.CollectionTeam) error
 	Delete(ctx context.Context, id uint) error
 	DeleteByCollectionAndTeam(ctx context.Context, collectionID, teamID uint) error
@@ -235,8 +272,10 @@ type OrganizationItemFilter struct {
 	AutoLogin      *bool
 	Search         string
 	Tags           []string
-	Page           int
-	PerPage        int
+	// URIHints filters by metadata.uri_hint like ItemFilter.URIHints
+	URIHints []string
+	Page     int
+	PerPage  int
 }
 
 // OrganizationItemRepository defines organization item data access methods
@@ -247,12 +286,20 @@ type OrganizationItemRepository interface {
 	GetBySupportID(ctx context.Context, supportID int64) (*domain.OrganizationItem, error)
 	ListByOrganization(ctx context.Context, filter OrganizationItemFilter) ([]*domain.OrganizationItem, int64, error)
 	ListByCollection(ctx context.Context, collectionID uint) ([]*domain.OrganizationItem, error)
-	MoveItemsToCollection(ctx context.Context, fromCollectionID uint, toCollectionID uint) error
 	CountByOrganizationID(ctx context.Context, orgID uint) (int, error)
+	// Update saves the item row and leaves its collection assignments alone
 	Update(ctx context.Context, item *domain.OrganizationItem) error
+	// SetCollections replaces the item's collection assignments
+	SetCollections(ctx context.Context, itemID uint, collectionIDs []uint) error
 	Delete(ctx context.Context, id uint) error
 	SoftDelete(ctx context.Context, id uint) error
 	HardDelete(ctx context.Context, id uint) error
+
+	// Bulk operations (see ItemRepository.CreateBatch / UpdateBatch)
+	CreateBatch(ctx context.Context, items []*domain.OrganizationItem) ([]error, error)
+	// The organization UpdateBatch's apply returns the item's new collection assignments,
+	// or nil to keep them
+	UpdateBatch(ctx context.Context, orgID uint, ids []uint, apply func(item *domain.OrganizationItem) ([]uint, error)) (map[uint]error, error)
 }
 
 // SSOConnectionRepository defines SSO connection data access methods
@@ -319,9 +366,30 @@ type SendRepository interface {
 	Delete(ctx context.Context, id uint) error
 	SoftDelete(ctx context.Context, id uint) error
 	IncrementAccessCount(ctx context.Context, id uint) error
+	// TryIncrementAccessCount counts one access unless MaxAccessCount is already reached
+	TryIncrementAccessCount(ctx context.Context, id uint) (bool, error)
+	MarkFileUploaded(ctx context.Context, id uint) error
+	// ListExpiredFiles returns file sends past their deletion date.
+	// DeleteExpired skips them so their blob can be removed before the row.
+	ListExpiredFiles(ctx context.Context) ([]*domain.Send, error)
 	DeleteExpired(ctx context.Context) (int64, error)
 }
 
+// SendRecipientRepository defines data access for recipient-restricted sends
+type SendRecipientRepository interface {
+	ListBySend(ctx context.Context, sendID uint) ([]*domain.SendRecipient, error)
+	CountBySend(ctx context.Context, sendID uint) (int64, error)
+	GetBySendAndEmail(ctx context.Context, sendID uint, email string) (*domain.SendRecipient, error)
+	GetBySendAndTokenHash(ctx context.Context, sendID uint, tokenHash string) (*domain.SendRecipient, error)
+	// ReplaceForSend sets the recipient list; recipients that stay keep their verification state
+	ReplaceForSend(ctx context.Context, sendID uint, emails []string) error
+	// TryIncrementCodeAttempts counts one code attempt unless maxAttempts is already reached
+	TryIncrementCodeAttempts(ctx context.Context, id uint, maxAttempts int) (bool, error)
+	Update(ctx context.Context, recipient *domain.SendRecipient) error
+	CreateEvent(ctx context.Context, event *domain.SendAccessEvent) error
+	ListEventsBySend(ctx context.Context, sendID uint, limit int) ([]*domain.SendAccessEvent, error)
+}
+
 // KeyEscrowRepository defines key escrow data access methods
 type KeyEscrowRepository interface {
 	Create(ctx context.Context, escrow *domain.KeyEscrow) error
@@ -353,6 +421,13 @@ type ItemShareRepository interface {
 	Delete(ctx context.Context, id uint) error
 	DeleteBySharedWithUser(ctx context.Context, userID uint) error
 	DeleteExpired(ctx context.Context) (int64, error)
+	// ListExpiring returns shares expiring between after and before whose expiry
+	// notice has not been sent yet
+	ListExpiring(ctx context.Context, after, before time.Time) ([]*domain.ItemShare, error)
+	// ListExpired returns shares past their expiry that still hold an EncryptedKey
+	ListExpired(ctx context.Context, now time.Time) ([]*domain.ItemShare, error)
+	// ListExpiringByOrganization returns the organization's unrevoked shares expiring between after and before
+	ListExpiringByOrganization(ctx context.Context, orgID uint, after, before time.Time) ([]*domain.ItemShare, error)
 }
 
 // CompatTelemetryRepository defines compatibility telemetry persistence methods.
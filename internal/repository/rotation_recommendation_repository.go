@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/passwall/passwall-server/internal/domain"
+)
+
+// RotationRecommendationRepository defines data access methods for breach-driven
+// password rotation recommendations.
+type RotationRecommendationRepository interface {
+	// Create stores the recommendation unless the user already has one for the same
+	// item and breach, and reports whether it was stored
+	Create(ctx context.Context, rec *domain.RotationRecommendation) (bool, error)
+	GetByID(ctx context.Context, id uint) (*domain.RotationRecommendation, error)
+	ListOpenByUser(ctx context.Context, userID uint) ([]*domain.RotationRecommendation, error)
+	Update(ctx context.Context, rec *domain.RotationRecommendation) error
+}
@@ -14,6 +14,8 @@ type UserActivityRepository interface {
 	GetLastActivity(ctx context.Context, userID uint, activityType domain.ActivityType) (*domain.UserActivity, error)
 	List(ctx context.Context, filter ActivityFilter) ([]*domain.UserActivity, int64, error)
 	ListByUserIDs(ctx context.Context, userIDs []uint, limit int, offset int) ([]*domain.UserActivity, error)
+	// CountByUserIDs counts the users' activities of a type created in [since, until)
+	CountByUserIDs(ctx context.Context, userIDs []uint, activityType domain.ActivityType, since, until time.Time) (int64, error)
 	DeleteByUserID(ctx context.Context, userID uint) error
 	DeleteOldActivities(ctx context.Context, olderThan time.Duration) (int64, error)
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+)
+
+// VaultHealthRepository defines data access methods for vault health reports.
+type VaultHealthRepository interface {
+	// UpsertSignals creates or replaces the signals of each item
+	UpsertSignals(ctx context.Context, signals []*domain.ItemHealthSignal) error
+	ListSignalsByOrganization(ctx context.Context, orgID uint) ([]*domain.ItemHealthSignal, error)
+
+	// UpsertSnapshots creates or replaces the snapshots for their organization, collection and day
+	UpsertSnapshots(ctx context.Context, snapshots []*domain.VaultHealthSnapshot) error
+	// ListSnapshots returns the snapshots of a collection (0 = organization-wide) since the given day, oldest first
+	ListSnapshots(ctx context.Context, orgID, collectionID uint, since time.Time) ([]*domain.VaultHealthSnapshot, error)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+)
+
+// WebhookEventFilter filters the webhook inbox for admin listings
+type WebhookEventFilter struct {
+	Provider  domain.WebhookProvider
+	Status    domain.WebhookEventStatus
+	EventType string
+	Search    string // Matches event_id
+	Limit     int
+	Offset    int
+}
+
+// WebhookEventRepository defines data access methods for the webhook inbox.
+type WebhookEventRepository interface {
+	// Create stores a new event. Returns ErrAlreadyExists if (provider, event_id) is already stored.
+	Create(ctx context.Context, event *domain.WebhookEvent) error
+	GetByID(ctx context.Context, id uint) (*domain.WebhookEvent, error)
+	GetByEventID(ctx context.Context, provider domain.WebhookProvider, eventID string) (*domain.WebhookEvent, error)
+	Update(ctx context.Context, event *domain.WebhookEvent) error
+
+	// Claim atomically marks an event as processing (lease until leaseUntil) and counts the attempt.
+	// Unless force is set, only pending/failed events (or processing events with an expired lease) are claimed.
+	// Returns false when another processor holds the event or it needs no processing.
+	Claim(ctx context.Context, id uint, now, leaseUntil time.Time, force bool) (bool, error)
+
+	// ListDue returns events whose (re)try is due
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.WebhookEvent, error)
+	// List returns events for admin listings with total and filtered counts
+	List(ctx context.Context, filter WebhookEventFilter) ([]*domain.WebhookEvent, int64, int64, error)
+}
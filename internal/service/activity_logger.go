@@ -26,12 +26,16 @@ const (
 	ActivityFieldCurrency          = "currency"
 	ActivityFieldStatus            = "status"
 	ActivityFieldCancelAtPeriodEnd = "cancel_at_period_end"
+	ActivityFieldPromoCode         = "promo_code"
 	ActivityFieldReason            = "reason"
 	ActivityFieldError             = "error"
 	ActivityFieldItemID            = "item_id"
 	ActivityFieldItemType          = "item_type"
 	ActivityFieldCollectionID      = "collection_id"
+	ActivityFieldCollectionIDs     = "collection_ids"
 	ActivityFieldCollectionName    = "collection_name"
+	ActivityFieldAccessRequestID   = "access_request_id"
+	ActivityFieldExpiresAt         = "expires_at"
 	ActivityFieldTeamID            = "team_id"
 	ActivityFieldTeamName          = "team_name"
 	ActivityFieldRole              = "role"
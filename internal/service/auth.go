@@ -15,6 +15,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/email"
 	"github.com/passwall/passwall-server/internal/repository"
@@ -1199,8 +1200,8 @@ func (s *authService) checkTwoFactorSetupRequired(ctx context.Context, user *dom
 		if m == nil || m.Status == domain.OrgUserStatusInvited {
 			continue
 		}
-		// Owners/admins are exempt from enforcement (they manage the policy).
-		if m.Role == domain.OrgRoleOwner || m.Role == domain.OrgRoleAdmin {
+		// Members who manage policies are exempt from enforcement.
+		if exemptFromPolicies(m) {
 			continue
 		}
 
@@ -1247,13 +1248,13 @@ func (s *authService) checkTwoFactorSetupRequired(ctx context.Context, user *dom
 }
 
 // GetTwoFactorCompliance returns 2FA adoption statistics for an organization.
-// Only org owners/admins can access this report.
+// Only members with view_audit can access this report.
 func (s *authService) GetTwoFactorCompliance(ctx context.Context, requesterUserID uint, orgID uint) (*domain.TwoFactorComplianceResponse, error) {
 	membership, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, requesterUserID)
 	if err != nil || membership == nil {
 		return nil, repository.ErrForbidden
 	}
-	if membership.Role != domain.OrgRoleOwner && membership.Role != domain.OrgRoleAdmin {
+	if !authz.HasPermission(membership, domain.OrgPermViewAudit) {
 		return nil, repository.ErrForbidden
 	}
 
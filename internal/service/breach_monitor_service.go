@@ -2,16 +2,32 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
 	"github.com/passwall/passwall-server/pkg/hibp"
 	"github.com/passwall/passwall-server/pkg/logger"
 )
 
+// ErrDomainNotVerified is returned when a domain search targets a domain the organization has not verified
+var ErrDomainNotVerified = errors.New("domain is not a verified domain of this organization")
+
+// HIBPClient is the Have I Been Pwned API used for breach monitoring.
+// *hibp.Client implements it; tests substitute a fake.
+type HIBPClient interface {
+	Enabled() bool
+	CheckBreachedAccount(email string) ([]hibp.Breach, error)
+	BreachedDomain(domain string) (map[string][]string, error)
+	GetBreach(name string) (*hibp.Breach, error)
+}
+
 // BreachMonitorService handles dark-web / breach monitoring logic.
 type BreachMonitorService interface {
 	AddEmail(ctx context.Context, orgID uint, userID uint, email string) (*domain.MonitoredEmailDTO, error)
@@ -22,26 +38,55 @@ type BreachMonitorService interface {
 	DismissBreach(ctx context.Context, orgID uint, userID uint, breachID uint) error
 	GetSummary(ctx context.Context, orgID uint, userID uint) (*domain.BreachMonitorSummaryDTO, error)
 
+	// Domain-wide monitoring (view_audit to list, manage_policies to change)
+	AddDomain(ctx context.Context, orgID uint, userID uint, domainName string) (*domain.MonitoredDomainDTO, error)
+	RemoveDomain(ctx context.Context, orgID uint, userID uint, domainID uint) error
+	ListDomains(ctx context.Context, orgID uint, userID uint) ([]*domain.MonitoredDomainDTO, error)
+	EnrollMembers(ctx context.Context, orgID uint, userID uint) (int, error)
+
+	// Member self-service
+	ListMyBreaches(ctx context.Context, orgID uint, userID uint) ([]*domain.BreachRecordDTO, error)
+	DismissMyBreach(ctx context.Context, orgID uint, userID uint, breachID uint) error
+
 	// For background worker
 	CheckSingleEmail(ctx context.Context, email *domain.MonitoredEmail) (int, error)
+	CheckOrganization(ctx context.Context, orgID uint) (int, error)
+	AutoEnrollMembers(ctx context.Context) (int, error)
 }
 
 type breachMonitorService struct {
 	repo        repository.BreachMonitorRepository
-	hibpClient  *hibp.Client
+	hibpClient  HIBPClient
 	featureSvc  FeatureService
 	orgUserRepo interface {
 		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+	}
+	prefRepo interface {
+		ListByOwner(ctx context.Context, ownerType string, ownerID uint, section string) ([]*domain.Preference, error)
+		ListOwnerIDs(ctx context.Context, ownerType, section, key, value string) ([]uint, error)
+	}
+	rotation interface {
+		FlagBreachedItems(ctx context.Context, monitored *domain.MonitoredEmail, records []*domain.BreachRecord) (int, error)
 	}
+	now func() time.Time
 }
 
 // NewBreachMonitorService creates a new breach monitoring service.
 func NewBreachMonitorService(
 	repo repository.BreachMonitorRepository,
-	hibpClient *hibp.Client,
+	hibpClient HIBPClient,
 	featureSvc FeatureService,
 	orgUserRepo interface {
 		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+	},
+	prefRepo interface {
+		ListByOwner(ctx context.Context, ownerType string, ownerID uint, section string) ([]*domain.Preference, error)
+		ListOwnerIDs(ctx context.Context, ownerType, section, key, value string) ([]uint, error)
+	},
+	rotation interface {
+		FlagBreachedItems(ctx context.Context, monitored *domain.MonitoredEmail, records []*domain.BreachRecord) (int, error)
 	},
 ) BreachMonitorService {
 	return &breachMonitorService{
@@ -49,6 +94,9 @@ func NewBreachMonitorService(
 		hibpClient:  hibpClient,
 		featureSvc:  featureSvc,
 		orgUserRepo: orgUserRepo,
+		prefRepo:    prefRepo,
+		rotation:    rotation,
+		now:         time.Now,
 	}
 }
 
@@ -69,6 +117,7 @@ func (s *breachMonitorService) AddEmail(ctx context.Context, orgID uint, userID
 	email := &domain.MonitoredEmail{
 		OrganizationID: orgID,
 		Email:          emailAddr,
+		Source:         domain.MonitoredEmailSourceManual,
 	}
 	if err := s.repo.CreateEmail(ctx, email); err != nil {
 		return nil, fmt.Errorf("failed to create monitored email: %w", err)
@@ -133,17 +182,8 @@ func (s *breachMonitorService) CheckEmails(ctx context.Context, orgID uint, user
 		return fmt.Errorf("breach monitoring is not configured (missing HIBP API key)")
 	}
 
-	emails, err := s.repo.ListEmailsByOrganization(ctx, orgID)
-	if err != nil {
-		return fmt.Errorf("failed to list emails: %w", err)
-	}
-
-	for _, email := range emails {
-		if _, checkErr := s.CheckSingleEmail(ctx, email); checkErr != nil {
-			logger.Errorf("breach check failed for email ID %d: %v", email.ID, checkErr)
-		}
-	}
-	return nil
+	_, err := s.CheckOrganization(ctx, orgID)
+	return err
 }
 
 func (s *breachMonitorService) ListBreaches(ctx context.Context, orgID uint, userID uint) ([]*domain.BreachRecordDTO, error) {
@@ -200,8 +240,252 @@ func (s *breachMonitorService) CheckSingleEmail(ctx context.Context, email *doma
 	if err != nil {
 		return 0, fmt.Errorf("HIBP check failed: %w", err)
 	}
+	return s.recordBreaches(ctx, email, breaches), nil
+}
+
+// CheckOrganization runs a domain search for each monitored domain of the
+// organization, then checks the remaining addresses one by one. Addresses on a
+// domain whose search succeeded are not queried again. Returns the count of new
+// breaches found.
+func (s *breachMonitorService) CheckOrganization(ctx context.Context, orgID uint) (int, error) {
+	domains, err := s.repo.ListDomainsByOrganization(ctx, orgID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	newCount := 0
+	covered := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		found, err := s.checkDomain(ctx, d)
+		if err != nil {
+			logger.Errorf("domain search failed for %s: %v", d.Domain, err)
+			continue
+		}
+		covered[d.Domain] = true
+		newCount += found
+	}
+
+	emails, err := s.repo.ListEmailsByOrganization(ctx, orgID)
+	if err != nil {
+		return newCount, fmt.Errorf("failed to list emails: %w", err)
+	}
+	for _, email := range emails {
+		if covered[emailDomain(email.Email)] {
+			continue
+		}
+		found, err := s.CheckSingleEmail(ctx, email)
+		if err != nil {
+			logger.Errorf("breach check failed for email ID %d: %v", email.ID, err)
+			continue
+		}
+		newCount += found
+	}
+	return newCount, nil
+}
+
+// AddDomain starts monitoring every address on one of the organization's
+// verified domains and runs the first domain search right away.
+func (s *breachMonitorService) AddDomain(ctx context.Context, orgID uint, userID uint, domainName string) (*domain.MonitoredDomainDTO, error) {
+	if err := s.checkPermission(ctx, orgID, userID, domain.OrgPermManagePolicies); err != nil {
+		return nil, err
+	}
+
+	domainName = strings.ToLower(strings.TrimSpace(domainName))
+	verified, err := s.verifiedDomains(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !verified[domainName] {
+		return nil, ErrDomainNotVerified
+	}
+
+	existing, err := s.repo.GetDomainByOrgAndName(ctx, orgID, domainName)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check existing domain: %w", err)
+	}
+	if existing != nil {
+		return nil, repository.ErrAlreadyExists
+	}
+
+	d := &domain.MonitoredDomain{
+		OrganizationID: orgID,
+		Domain:         domainName,
+	}
+	if err := s.repo.CreateDomain(ctx, d); err != nil {
+		return nil, fmt.Errorf("failed to create monitored domain: %w", err)
+	}
+
+	if s.hibpClient.Enabled() {
+		if _, err := s.checkDomain(ctx, d); err != nil {
+			logger.Errorf("initial domain search failed for %s: %v", domainName, err)
+		}
+	}
+	return domain.ToMonitoredDomainDTO(d), nil
+}
+
+// RemoveDomain stops the domain search. Addresses it found stay monitored and
+// are checked one by one from then on.
+func (s *breachMonitorService) RemoveDomain(ctx context.Context, orgID uint, userID uint, domainID uint) error {
+	if err := s.checkPermission(ctx, orgID, userID, domain.OrgPermManagePolicies); err != nil {
+		return err
+	}
+
+	d, err := s.repo.GetDomainByID(ctx, domainID)
+	if err != nil {
+		return err
+	}
+	if d.OrganizationID != orgID {
+		return repository.ErrForbidden
+	}
+	return s.repo.DeleteDomain(ctx, domainID)
+}
+
+func (s *breachMonitorService) ListDomains(ctx context.Context, orgID uint, userID uint) ([]*domain.MonitoredDomainDTO, error) {
+	if err := s.checkPermission(ctx, orgID, userID, domain.OrgPermViewAudit); err != nil {
+		return nil, err
+	}
+
+	domains, err := s.repo.ListDomainsByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	dtos := make([]*domain.MonitoredDomainDTO, 0, len(domains))
+	for _, d := range domains {
+		dtos = append(dtos, domain.ToMonitoredDomainDTO(d))
+	}
+	return dtos, nil
+}
+
+// EnrollMembers monitors the email address of every active member and returns
+// how many addresses were added. New addresses are checked on the next run.
+func (s *breachMonitorService) EnrollMembers(ctx context.Context, orgID uint, userID uint) (int, error) {
+	if err := s.checkPermission(ctx, orgID, userID, domain.OrgPermManagePolicies); err != nil {
+		return 0, err
+	}
+	return s.enrollMembers(ctx, orgID)
+}
+
+// AutoEnrollMembers enrolls the members of every organization that turned on
+// the auto-enroll setting. Returns how many addresses were added.
+func (s *breachMonitorService) AutoEnrollMembers(ctx context.Context) (int, error) {
+	orgIDs, err := s.prefRepo.ListOwnerIDs(ctx, domain.OrgSettingOwnerType, domain.OrgSettingSectionSecurity, domain.OrgSettingKeyBreachAutoEnroll, "true")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list auto-enroll organizations: %w", err)
+	}
+
+	total := 0
+	for _, orgID := range orgIDs {
+		if err := s.checkFeatureAccess(ctx, orgID); err != nil {
+			continue
+		}
+		added, err := s.enrollMembers(ctx, orgID)
+		if err != nil {
+			logger.Errorf("breach monitor auto-enroll failed for org %d: %v", orgID, err)
+			continue
+		}
+		total += added
+	}
+	return total, nil
+}
+
+// ListMyBreaches returns the breaches of the caller's own monitored addresses.
+func (s *breachMonitorService) ListMyBreaches(ctx context.Context, orgID uint, userID uint) ([]*domain.BreachRecordDTO, error) {
+	emails, err := s.memberEmails(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*domain.BreachRecordDTO, 0)
+	for _, e := range emails {
+		for i := range e.BreachRecords {
+			dtos = append(dtos, domain.ToBreachRecordDTO(&e.BreachRecords[i]))
+		}
+	}
+	sort.Slice(dtos, func(i, j int) bool { return dtos[i].BreachDate > dtos[j].BreachDate })
+	return dtos, nil
+}
+
+// DismissMyBreach dismisses a breach of one of the caller's own addresses.
+func (s *breachMonitorService) DismissMyBreach(ctx context.Context, orgID uint, userID uint, breachID uint) error {
+	emails, err := s.memberEmails(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	record, err := s.repo.GetBreachRecordByID(ctx, breachID)
+	if err != nil {
+		return err
+	}
+	for _, e := range emails {
+		if e.ID == record.MonitoredEmailID {
+			record.IsDismissed = true
+			return s.repo.UpdateBreachRecord(ctx, record)
+		}
+	}
+	return repository.ErrForbidden
+}
+
+// checkDomain runs a domain search, monitors every breached address it reports
+// and records their breaches. Returns the count of new breaches found.
+func (s *breachMonitorService) checkDomain(ctx context.Context, d *domain.MonitoredDomain) (int, error) {
+	aliases, err := s.hibpClient.BreachedDomain(d.Domain)
+	if err != nil {
+		return 0, fmt.Errorf("HIBP domain search failed: %w", err)
+	}
+
+	members, err := s.activeMemberEmails(ctx, d.OrganizationID)
+	if err != nil {
+		return 0, err
+	}
 
+	// The search only returns breach names, so look each one up once
+	details := make(map[string]hibp.Breach)
 	newCount := 0
+	for alias, names := range aliases {
+		addr := strings.ToLower(alias + "@" + d.Domain)
+		var owner *uint
+		if id, ok := members[addr]; ok {
+			owner = &id
+		}
+		email, _, err := s.monitorAddress(ctx, d.OrganizationID, addr, owner, domain.MonitoredEmailSourceDomain)
+		if err != nil {
+			logger.Errorf("failed to monitor %s: %v", addr, err)
+			continue
+		}
+
+		breaches := make([]hibp.Breach, 0, len(names))
+		for _, name := range names {
+			b, ok := details[name]
+			if !ok {
+				b = hibp.Breach{Name: name}
+				found, err := s.hibpClient.GetBreach(name)
+				if err != nil {
+					logger.Errorf("failed to fetch breach %s: %v", name, err)
+				} else if found != nil {
+					b = *found
+				}
+				details[name] = b
+			}
+			breaches = append(breaches, b)
+		}
+		newCount += s.recordBreaches(ctx, email, breaches)
+	}
+
+	now := s.now()
+	d.LastCheckedAt = &now
+	d.BreachedEmails = len(aliases)
+	if err := s.repo.UpdateDomain(ctx, d); err != nil {
+		logger.Errorf("failed to update monitored domain: %v", err)
+	}
+	return newCount, nil
+}
+
+// recordBreaches stores the breaches not yet recorded for the email, flags the
+// owner's vault items for the breached sites and updates the email's check
+// metadata. Returns the count of new breaches.
+func (s *breachMonitorService) recordBreaches(ctx context.Context, email *domain.MonitoredEmail, breaches []hibp.Breach) int {
+	var created []*domain.BreachRecord
 	for _, b := range breaches {
 		exists, err := s.repo.BreachExistsForEmail(ctx, email.ID, b.Name)
 		if err != nil {
@@ -224,24 +508,151 @@ func (s *breachMonitorService) CheckSingleEmail(ctx context.Context, email *doma
 			PwnCount:         b.PwnCount,
 			IsVerified:       b.IsVerified,
 			IsSensitive:      b.IsSensitive,
-			DiscoveredAt:     time.Now(),
+			DiscoveredAt:     s.now(),
 		}
 		if err := s.repo.CreateBreachRecord(ctx, record); err != nil {
 			logger.Errorf("failed to create breach record: %v", err)
 			continue
 		}
-		newCount++
+		created = append(created, record)
+	}
+
+	if len(created) > 0 {
+		if _, err := s.rotation.FlagBreachedItems(ctx, email, created); err != nil {
+			logger.Errorf("failed to flag breached vault items for email ID %d: %v", email.ID, err)
+		}
 	}
 
 	// Update email metadata
-	now := time.Now()
+	now := s.now()
 	email.LastCheckedAt = &now
 	email.BreachCount = len(breaches)
 	if err := s.repo.UpdateEmail(ctx, email); err != nil {
 		logger.Errorf("failed to update monitored email: %v", err)
 	}
 
-	return newCount, nil
+	return len(created)
+}
+
+// enrollMembers monitors each active member's address, linking it to the
+// member when it is already monitored. Returns how many addresses were added.
+func (s *breachMonitorService) enrollMembers(ctx context.Context, orgID uint) (int, error) {
+	members, err := s.activeMemberEmails(ctx, orgID)
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	for addr, userID := range members {
+		_, created, err := s.monitorAddress(ctx, orgID, addr, &userID, domain.MonitoredEmailSourceMember)
+		if err != nil {
+			return added, err
+		}
+		if created {
+			added++
+		}
+	}
+	return added, nil
+}
+
+// monitorAddress returns the monitored email for addr, creating it when
+// missing and linking it to its member when known.
+func (s *breachMonitorService) monitorAddress(ctx context.Context, orgID uint, addr string, userID *uint, source string) (*domain.MonitoredEmail, bool, error) {
+	email, err := s.repo.GetEmailByOrgAndAddress(ctx, orgID, addr)
+	if err == nil {
+		if email.UserID == nil && userID != nil {
+			email.UserID = userID
+			if err := s.repo.UpdateEmail(ctx, email); err != nil {
+				return nil, false, fmt.Errorf("failed to link monitored email: %w", err)
+			}
+		}
+		return email, false, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, false, fmt.Errorf("failed to check existing email: %w", err)
+	}
+
+	email = &domain.MonitoredEmail{
+		OrganizationID: orgID,
+		Email:          addr,
+		UserID:         userID,
+		Source:         source,
+	}
+	if err := s.repo.CreateEmail(ctx, email); err != nil {
+		return nil, false, fmt.Errorf("failed to create monitored email: %w", err)
+	}
+	return email, true, nil
+}
+
+// activeMemberEmails maps the lower-cased address of each active member to their user ID
+func (s *breachMonitorService) activeMemberEmails(ctx context.Context, orgID uint) (map[string]uint, error) {
+	members, err := s.orgUserRepo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	emails := make(map[string]uint, len(members))
+	for _, m := range members {
+		if !isActiveMember(m) || m.User == nil || m.User.Email == "" {
+			continue
+		}
+		emails[strings.ToLower(m.User.Email)] = m.UserID
+	}
+	return emails, nil
+}
+
+// memberEmails returns the caller's own monitored addresses
+func (s *breachMonitorService) memberEmails(ctx context.Context, orgID uint, userID uint) ([]*domain.MonitoredEmail, error) {
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil || !isActiveMember(orgUser) {
+		return nil, repository.ErrForbidden
+	}
+	if err := s.checkFeatureAccess(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	var addr string
+	if orgUser.User != nil {
+		addr = orgUser.User.Email
+	}
+	emails, err := s.repo.ListEmailsForMember(ctx, orgID, userID, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list emails: %w", err)
+	}
+	return emails, nil
+}
+
+// verifiedDomains returns the organization's claimed domains, lower-cased
+func (s *breachMonitorService) verifiedDomains(ctx context.Context, orgID uint) (map[string]bool, error) {
+	prefs, err := s.prefRepo.ListByOwner(ctx, domain.OrgSettingOwnerType, orgID, domain.OrgSettingSectionDomains)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load domain settings: %w", err)
+	}
+
+	verified := make(map[string]bool)
+	for _, p := range prefs {
+		if p.Key != domain.OrgSettingKeyClaimedDomains {
+			continue
+		}
+		var domains []string
+		if err := json.Unmarshal([]byte(p.Value), &domains); err != nil {
+			logger.Errorf("invalid claimed domains for org %d: %v", orgID, err)
+			continue
+		}
+		for _, d := range domains {
+			verified[strings.ToLower(strings.TrimSpace(d))] = true
+		}
+	}
+	return verified, nil
+}
+
+// emailDomain returns the lower-cased domain part of an address
+func emailDomain(addr string) string {
+	at := strings.LastIndex(addr, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(addr[at+1:])
 }
 
 func (s *breachMonitorService) checkFeatureAccess(ctx context.Context, orgID uint) error {
@@ -261,3 +672,13 @@ func (s *breachMonitorService) checkAccess(ctx context.Context, orgID uint, user
 	}
 	return s.checkFeatureAccess(ctx, orgID)
 }
+
+// checkPermission requires an active member holding perm: view_audit to see the
+// organization-wide configuration, manage_policies to change it
+func (s *breachMonitorService) checkPermission(ctx context.Context, orgID uint, userID uint, perm domain.OrgPermission) error {
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil || !isActiveMember(orgUser) || !authz.HasPermission(orgUser, perm) {
+		return repository.ErrForbidden
+	}
+	return s.checkFeatureAccess(ctx, orgID)
+}
@@ -0,0 +1,371 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/pkg/hibp"
+)
+
+// fakeHIBP answers from fixed breach data and counts API calls
+type fakeHIBP struct {
+	accounts map[string][]hibp.Breach
+	domains  map[string]map[string][]string
+	breaches map[string]*hibp.Breach
+	calls    map[string]int
+}
+
+func (f *fakeHIBP) Enabled() bool { return true }
+func (f *fakeHIBP) CheckBreachedAccount(email string) ([]hibp.Breach, error) {
+	f.calls["account:"+email]++
+	return f.accounts[email], nil
+}
+func (f *fakeHIBP) BreachedDomain(d string) (map[string][]string, error) {
+	f.calls["domain:"+d]++
+	return f.domains[d], nil
+}
+func (f *fakeHIBP) GetBreach(name string) (*hibp.Breach, error) {
+	f.calls["breach:"+name]++
+	return f.breaches[name], nil
+}
+
+// fakeBreachRepo keeps monitored emails, domains and breach records in memory
+type fakeBreachRepo struct {
+	repository.BreachMonitorRepository
+	emails  map[uint]*domain.MonitoredEmail
+	domains map[uint]*domain.MonitoredDomain
+	records map[uint]*domain.BreachRecord
+	nextID  uint
+}
+
+func newFakeBreachRepo() *fakeBreachRepo {
+	return &fakeBreachRepo{
+		emails:  map[uint]*domain.MonitoredEmail{},
+		domains: map[uint]*domain.MonitoredDomain{},
+		records: map[uint]*domain.BreachRecord{},
+	}
+}
+
+func (f *fakeBreachRepo) id() uint {
+	f.nextID++
+	return f.nextID
+}
+
+// withRecords returns a copy of the email with its breach records attached
+func (f *fakeBreachRepo) withRecords(e *domain.MonitoredEmail) *domain.MonitoredEmail {
+	out := *e
+	out.BreachRecords = nil
+	for _, r := range f.records {
+		if r.MonitoredEmailID == e.ID {
+			out.BreachRecords = append(out.BreachRecords, *r)
+		}
+	}
+	return &out
+}
+
+func (f *fakeBreachRepo) CreateEmail(_ context.Context, e *domain.MonitoredEmail) error {
+	e.ID = f.id()
+	f.emails[e.ID] = e
+	return nil
+}
+func (f *fakeBreachRepo) GetEmailByID(_ context.Context, id uint) (*domain.MonitoredEmail, error) {
+	if e, ok := f.emails[id]; ok {
+		return f.withRecords(e), nil
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeBreachRepo) GetEmailByOrgAndAddress(_ context.Context, orgID uint, addr string) (*domain.MonitoredEmail, error) {
+	for _, e := range f.emails {
+		if e.OrganizationID == orgID && e.Email == addr {
+			return e, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeBreachRepo) ListEmailsByOrganization(_ context.Context, orgID uint) ([]*domain.MonitoredEmail, error) {
+	var out []*domain.MonitoredEmail
+	for _, e := range f.emails {
+		if e.OrganizationID == orgID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+func (f *fakeBreachRepo) ListEmailsForMember(_ context.Context, orgID, userID uint, addr string) ([]*domain.MonitoredEmail, error) {
+	var out []*domain.MonitoredEmail
+	for _, e := range f.emails {
+		if e.OrganizationID == orgID && ((e.UserID != nil && *e.UserID == userID) || strings.EqualFold(e.Email, addr)) {
+			out = append(out, f.withRecords(e))
+		}
+	}
+	return out, nil
+}
+func (f *fakeBreachRepo) UpdateEmail(_ context.Context, e *domain.MonitoredEmail) error {
+	f.emails[e.ID] = e
+	return nil
+}
+func (f *fakeBreachRepo) CreateDomain(_ context.Context, d *domain.MonitoredDomain) error {
+	d.ID = f.id()
+	f.domains[d.ID] = d
+	return nil
+}
+func (f *fakeBreachRepo) GetDomainByID(_ context.Context, id uint) (*domain.MonitoredDomain, error) {
+	if d, ok := f.domains[id]; ok {
+		return d, nil
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeBreachRepo) GetDomainByOrgAndName(_ context.Context, orgID uint, name string) (*domain.MonitoredDomain, error) {
+	for _, d := range f.domains {
+		if d.OrganizationID == orgID && d.Domain == name {
+			return d, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeBreachRepo) ListDomainsByOrganization(_ context.Context, orgID uint) ([]*domain.MonitoredDomain, error) {
+	var out []*domain.MonitoredDomain
+	for _, d := range f.domains {
+		if d.OrganizationID == orgID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+func (f *fakeBreachRepo) UpdateDomain(_ context.Context, d *domain.MonitoredDomain) error {
+	f.domains[d.ID] = d
+	return nil
+}
+func (f *fakeBreachRepo) DeleteDomain(_ context.Context, id uint) error {
+	delete(f.domains, id)
+	return nil
+}
+func (f *fakeBreachRepo) CreateBreachRecord(_ context.Context, r *domain.BreachRecord) error {
+	r.ID = f.id()
+	f.records[r.ID] = r
+	return nil
+}
+func (f *fakeBreachRepo) GetBreachRecordByID(_ context.Context, id uint) (*domain.BreachRecord, error) {
+	if r, ok := f.records[id]; ok {
+		return r, nil
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeBreachRepo) BreachExistsForEmail(_ context.Context, emailID uint, name string) (bool, error) {
+	for _, r := range f.records {
+		if r.MonitoredEmailID == emailID && r.BreachName == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+func (f *fakeBreachRepo) UpdateBreachRecord(_ context.Context, r *domain.BreachRecord) error {
+	f.records[r.ID] = r
+	return nil
+}
+
+// recordFor returns the breach record of an address by breach name
+func (f *fakeBreachRepo) recordFor(t *testing.T, addr, name string) *domain.BreachRecord {
+	t.Helper()
+	for _, r := range f.records {
+		if f.emails[r.MonitoredEmailID].Email == addr && r.BreachName == name {
+			return r
+		}
+	}
+	t.Fatalf("no %s breach recorded for %s", name, addr)
+	return nil
+}
+
+// fakeBreachFeatures toggles the breach monitoring plan feature
+type fakeBreachFeatures struct {
+	FeatureService
+	enabled bool
+}
+
+func (f *fakeBreachFeatures) CanUseBreachMonitoring(context.Context, uint) (bool, error) {
+	return f.enabled, nil
+}
+
+// fakeRotation records which new breach records were handed over for item matching
+type fakeRotation struct {
+	flagged map[string][]string
+}
+
+func (f *fakeRotation) FlagBreachedItems(_ context.Context, monitored *domain.MonitoredEmail, records []*domain.BreachRecord) (int, error) {
+	for _, r := range records {
+		f.flagged[monitored.Email] = append(f.flagged[monitored.Email], r.BreachName)
+	}
+	return len(records), nil
+}
+
+type breachMonitorFixture struct {
+	svc      *breachMonitorService
+	repo     *fakeBreachRepo
+	hibp     *fakeHIBP
+	prefs    *fakeDigestPrefRepo
+	rotation *fakeRotation
+	members  *suspensionMemberRepo
+}
+
+// newBreachMonitorFixture sets up org 1, which verified acme.test, with an admin
+// (user 101, admin@acme.test) and members 102 (dev@acme.test) and 103 (sam@mail.test).
+// HIBP knows breaches for admin@ and dev@ on the domain, and for admin@ and sam@mail.test
+// when asked one address at a time.
+func newBreachMonitorFixture(t *testing.T) *breachMonitorFixture {
+	t.Helper()
+
+	f := &breachMonitorFixture{
+		repo:     newFakeBreachRepo(),
+		rotation: &fakeRotation{flagged: map[string][]string{}},
+		hibp: &fakeHIBP{
+			accounts: map[string][]hibp.Breach{
+				"sam@mail.test":   {{Name: "Canva", Domain: "canva.com", BreachDate: "2019-05-24"}},
+				"admin@acme.test": {{Name: "Adobe", Domain: "adobe.com", BreachDate: "2013-10-04"}},
+			},
+			domains: map[string]map[string][]string{
+				"acme.test": {"admin": {"Adobe"}, "dev": {"Adobe", "LinkedIn"}},
+			},
+			breaches: map[string]*hibp.Breach{
+				"Adobe":    {Name: "Adobe", Domain: "adobe.com", BreachDate: "2013-10-04"},
+				"LinkedIn": {Name: "LinkedIn", Domain: "linkedin.com", BreachDate: "2012-05-05"},
+			},
+			calls: map[string]int{},
+		},
+		prefs: &fakeDigestPrefRepo{prefs: map[uint][]*domain.Preference{
+			1: {{Key: domain.OrgSettingKeyClaimedDomains, Value: `["Acme.test"]`}},
+		}},
+	}
+
+	f.members = &suspensionMemberRepo{members: map[uint]*domain.OrganizationUser{
+		11: {ID: 11, OrganizationID: 1, UserID: 101, Role: domain.OrgRoleAdmin, Status: domain.OrgUserStatusConfirmed, User: &domain.User{Email: "admin@acme.test"}},
+		12: {ID: 12, OrganizationID: 1, UserID: 102, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed, User: &domain.User{Email: "Dev@acme.test"}},
+		13: {ID: 13, OrganizationID: 1, UserID: 103, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed, User: &domain.User{Email: "sam@mail.test"}},
+	}}
+
+	f.svc = NewBreachMonitorService(f.repo, f.hibp, &fakeBreachFeatures{enabled: true}, f.members, f.prefs, f.rotation).(*breachMonitorService)
+	f.svc.now = func() time.Time { return time.Date(2026, 7, 1, 9, 0, 0, 0, time.UTC) }
+	return f
+}
+
+// addCustomRoleMember adds a member of org 1 whose custom role holds perms
+func (f *breachMonitorFixture) addCustomRoleMember(id, userID uint, perms string) {
+	f.members.members[id] = &domain.OrganizationUser{
+		ID: id, OrganizationID: 1, UserID: userID, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed,
+		User: &domain.User{Email: "staff@mail.test"}, CustomRole: &domain.OrganizationCustomRole{Name: "Security", Permissions: perms},
+	}
+}
+
+func TestBreachMonitor_DomainPermissions(t *testing.T) {
+	ctx := context.Background()
+	f := newBreachMonitorFixture(t)
+
+	// view_audit lists the configuration but cannot change it
+	f.addCustomRoleMember(14, 104, string(domain.OrgPermViewAudit))
+	_, err := f.svc.ListDomains(ctx, 1, 104)
+	require.NoError(t, err)
+	_, err = f.svc.AddDomain(ctx, 1, 104, "acme.test")
+	assert.ErrorIs(t, err, repository.ErrForbidden)
+	_, err = f.svc.EnrollMembers(ctx, 1, 104)
+	assert.ErrorIs(t, err, repository.ErrForbidden)
+
+	// manage_policies changes it without being an admin
+	f.addCustomRoleMember(14, 104, string(domain.OrgPermManagePolicies))
+	dto, err := f.svc.AddDomain(ctx, 1, 104, "acme.test")
+	require.NoError(t, err)
+	require.NoError(t, f.svc.RemoveDomain(ctx, 1, 104, dto.ID))
+}
+
+func TestBreachMonitor_DomainSearch(t *testing.T) {
+	ctx := context.Background()
+	f := newBreachMonitorFixture(t)
+
+	_, err := f.svc.AddDomain(ctx, 1, 102, "acme.test")
+	assert.ErrorIs(t, err, repository.ErrForbidden, "members cannot add domains")
+	_, err = f.svc.AddDomain(ctx, 1, 101, "other.test")
+	assert.ErrorIs(t, err, ErrDomainNotVerified)
+
+	dto, err := f.svc.AddDomain(ctx, 1, 101, "ACME.test")
+	require.NoError(t, err)
+	assert.Equal(t, "acme.test", dto.Domain)
+	assert.Equal(t, 2, dto.BreachedEmails)
+	require.NotNil(t, dto.LastCheckedAt)
+	_, err = f.svc.AddDomain(ctx, 1, 101, "acme.test")
+	assert.ErrorIs(t, err, repository.ErrAlreadyExists)
+
+	// Every breached alias is monitored, linked to its member, with full breach details
+	dev, err := f.repo.GetEmailByOrgAndAddress(ctx, 1, "dev@acme.test")
+	require.NoError(t, err)
+	assert.Equal(t, domain.MonitoredEmailSourceDomain, dev.Source)
+	require.NotNil(t, dev.UserID)
+	assert.Equal(t, uint(102), *dev.UserID)
+	assert.Equal(t, 2, dev.BreachCount)
+	assert.Equal(t, "adobe.com", f.repo.recordFor(t, "admin@acme.test", "Adobe").BreachDomain)
+	assert.Equal(t, 1, f.hibp.calls["breach:Adobe"], "breach details are fetched once per search")
+
+	// Addresses on a searched domain are not queried one by one
+	_, err = f.svc.AddEmail(ctx, 1, 101, "sam@mail.test")
+	require.NoError(t, err)
+	found, err := f.svc.CheckOrganization(ctx, 1)
+	require.NoError(t, err)
+	assert.Zero(t, found)
+	assert.Equal(t, 2, f.hibp.calls["domain:acme.test"])
+	assert.Zero(t, f.hibp.calls["account:dev@acme.test"])
+	assert.Equal(t, 2, f.hibp.calls["account:sam@mail.test"])
+	assert.Len(t, f.repo.records, 4)
+
+	// Only newly recorded breaches are matched against vault items
+	assert.ElementsMatch(t, []string{"Adobe", "LinkedIn"}, f.rotation.flagged["dev@acme.test"])
+	assert.Equal(t, []string{"Canva"}, f.rotation.flagged["sam@mail.test"])
+}
+
+func TestBreachMonitor_MemberEnrollmentAndSelfService(t *testing.T) {
+	ctx := context.Background()
+	f := newBreachMonitorFixture(t)
+
+	_, err := f.svc.EnrollMembers(ctx, 1, 103)
+	assert.ErrorIs(t, err, repository.ErrForbidden)
+
+	_, err = f.svc.AddEmail(ctx, 1, 101, "admin@acme.test")
+	require.NoError(t, err)
+	enrolled, err := f.svc.EnrollMembers(ctx, 1, 101)
+	require.NoError(t, err)
+	assert.Equal(t, 2, enrolled)
+	admin, err := f.repo.GetEmailByOrgAndAddress(ctx, 1, "admin@acme.test")
+	require.NoError(t, err)
+	assert.Equal(t, domain.MonitoredEmailSourceManual, admin.Source)
+	require.NotNil(t, admin.UserID, "existing addresses are linked to their member")
+
+	_, err = f.svc.CheckOrganization(ctx, 1)
+	require.NoError(t, err)
+
+	// Members only see their own breaches
+	mine, err := f.svc.ListMyBreaches(ctx, 1, 103)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	assert.Equal(t, "Canva", mine[0].BreachName)
+
+	adminBreach := f.repo.recordFor(t, "admin@acme.test", "Adobe")
+	assert.ErrorIs(t, f.svc.DismissMyBreach(ctx, 1, 103, adminBreach.ID), repository.ErrForbidden)
+	require.NoError(t, f.svc.DismissMyBreach(ctx, 1, 103, mine[0].ID))
+	mine, err = f.svc.ListMyBreaches(ctx, 1, 103)
+	require.NoError(t, err)
+	assert.True(t, mine[0].IsDismissed)
+
+	// Auto-enroll picks up members of organizations that turned it on
+	f.repo = newFakeBreachRepo()
+	f.svc.repo = f.repo
+	enrolled, err = f.svc.AutoEnrollMembers(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, enrolled)
+	f.prefs.prefs[1] = append(f.prefs.prefs[1], &domain.Preference{Key: domain.OrgSettingKeyBreachAutoEnroll, Value: "true"})
+	enrolled, err = f.svc.AutoEnrollMembers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, enrolled)
+}
@@ -0,0 +1,496 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/authz"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+var (
+	ErrAccessRequestNotPending = errors.New("access request is no longer pending")
+	ErrAccessRequestDuplicate  = errors.New("a pending access request for this collection already exists")
+	ErrAccessAlreadyGranted    = errors.New("member already has the requested access to this collection")
+	ErrPermanentGrantExists    = errors.New("member has a permanent grant on this collection; change it instead")
+	ErrCannotReviewOwnRequest  = errors.New("you cannot review your own access request")
+	ErrInvalidAccessDuration   = fmt.Errorf("duration must be between 1 and %d hours", domain.MaxAccessRequestHours)
+)
+
+// CollectionAccessRequestService handles just-in-time access to collections. Members
+// request temporary access with a justification, collection managers (members with
+// admin access to the collection) approve or deny it, and approved grants are revoked
+// again once their duration has passed.
+type CollectionAccessRequestService interface {
+	Create(ctx context.Context, collectionID, userID uint, req *domain.CreateCollectionAccessRequest) (*domain.CollectionAccessRequest, error)
+	// List returns the member's own requests and those they can review
+	List(ctx context.Context, orgID, userID uint, status *domain.CollectionAccessRequestStatus) ([]*domain.CollectionAccessRequest, error)
+	Approve(ctx context.Context, orgID, requestID, userID uint, req *domain.ReviewCollectionAccessRequest) (*domain.CollectionAccessRequest, error)
+	Deny(ctx context.Context, orgID, requestID, userID uint, req *domain.ReviewCollectionAccessRequest) (*domain.CollectionAccessRequest, error)
+	// Cancel withdraws a pending request; only the requester can cancel it
+	Cancel(ctx context.Context, orgID, requestID, userID uint) (*domain.CollectionAccessRequest, error)
+	// ProcessExpiredGrants revokes temporary access whose approved duration has passed
+	ProcessExpiredGrants(ctx context.Context) error
+}
+
+type collectionAccessRequestService struct {
+	requestRepo    repository.CollectionAccessRequestRepository
+	collectionRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Collection, error)
+		ListAncestorIDs(ctx context.Context, collectionID uint) ([]uint, error)
+	}
+	collectionUserRepo interface {
+		GetByCollectionAndOrgUser(ctx context.Context, collectionID, orgUserID uint) (*domain.CollectionUser, error)
+		ListByOrgUserAndCollections(ctx context.Context, orgUserID uint, collectionIDs []uint) ([]*domain.CollectionUser, error)
+		Create(ctx context.Context, cu *domain.CollectionUser) error
+		Update(ctx context.Context, cu *domain.CollectionUser) error
+		Delete(ctx context.Context, id uint) error
+	}
+	collectionTeamRepo authz.CollectionTeamAccessReader
+	teamUserRepo       authz.TeamMembershipReader
+	orgRepo            interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	}
+	orgUserRepo interface {
+		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+	}
+	emailSender    email.Sender
+	emailBuilder   *email.EmailBuilder
+	activityLogger *ActivityLogger
+	logger         Logger
+	now            func() time.Time
+}
+
+// NewCollectionAccessRequestService creates a new collection access request service
+func NewCollectionAccessRequestService(
+	requestRepo repository.CollectionAccessRequestRepository,
+	collectionRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Collection, error)
+		ListAncestorIDs(ctx context.Context, collectionID uint) ([]uint, error)
+	},
+	collectionUserRepo interface {
+		GetByCollectionAndOrgUser(ctx context.Context, collectionID, orgUserID uint) (*domain.CollectionUser, error)
+		ListByOrgUserAndCollections(ctx context.Context, orgUserID uint, collectionIDs []uint) ([]*domain.CollectionUser, error)
+		Create(ctx context.Context, cu *domain.CollectionUser) error
+		Update(ctx context.Context, cu *domain.CollectionUser) error
+		Delete(ctx context.Context, id uint) error
+	},
+	collectionTeamRepo authz.CollectionTeamAccessReader,
+	teamUserRepo authz.TeamMembershipReader,
+	orgRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	},
+	orgUserRepo interface {
+		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+	},
+	emailSender email.Sender,
+	emailBuilder *email.EmailBuilder,
+	activityService UserActivityService,
+	logger Logger,
+) CollectionAccessRequestService {
+	return &collectionAccessRequestService{
+		requestRepo:        requestRepo,
+		collectionRepo:     collectionRepo,
+		collectionUserRepo: collectionUserRepo,
+		collectionTeamRepo: collectionTeamRepo,
+		teamUserRepo:       teamUserRepo,
+		orgRepo:            orgRepo,
+		orgUserRepo:        orgUserRepo,
+		emailSender:        emailSender,
+		emailBuilder:       emailBuilder,
+		activityLogger:     NewActivityLogger(activityService),
+		logger:             logger,
+		now:                time.Now,
+	}
+}
+
+func (s *collectionAccessRequestService) Create(ctx context.Context, collectionID, userID uint, req *domain.CreateCollectionAccessRequest) (*domain.CollectionAccessRequest, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, collection.OrganizationID, userID)
+	if err != nil || !isActiveMember(orgUser) {
+		return nil, repository.ErrForbidden
+	}
+
+	justification := strings.TrimSpace(req.Justification)
+	if justification == "" {
+		return nil, fmt.Errorf("%w: justification is required", repository.ErrInvalidInput)
+	}
+	if req.DurationHours < 1 || req.DurationHours > domain.MaxAccessRequestHours {
+		return nil, ErrInvalidAccessDuration
+	}
+
+	access, err := s.access(ctx, orgUser, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if access.CanRead && (!req.CanWrite || access.CanWrite) {
+		return nil, ErrAccessAlreadyGranted
+	}
+
+	if _, err := s.requestRepo.GetPending(ctx, collectionID, orgUser.ID); err == nil {
+		return nil, ErrAccessRequestDuplicate
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check pending requests: %w", err)
+	}
+
+	request := &domain.CollectionAccessRequest{
+		OrganizationID:     collection.OrganizationID,
+		CollectionID:       collectionID,
+		OrganizationUserID: orgUser.ID,
+		Justification:      justification,
+		CanWrite:           req.CanWrite,
+		DurationHours:      req.DurationHours,
+		Status:             domain.AccessRequestStatusPending,
+	}
+	if err := s.requestRepo.Create(ctx, request); err != nil {
+		return nil, fmt.Errorf("failed to create access request: %w", err)
+	}
+	request.Collection = collection
+	request.OrganizationUser = orgUser
+
+	sent := s.notifyReviewers(ctx, request)
+	s.logger.Info("collection access requested", "org_id", request.OrganizationID, "collection_id", collectionID, "org_user_id", orgUser.ID, "request_id", request.ID, "emails_sent", sent)
+	return request, nil
+}
+
+func (s *collectionAccessRequestService) List(ctx context.Context, orgID, userID uint, status *domain.CollectionAccessRequestStatus) ([]*domain.CollectionAccessRequest, error) {
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil || !isActiveMember(orgUser) {
+		return nil, repository.ErrForbidden
+	}
+
+	requests, err := s.requestRepo.ListByOrganization(ctx, orgID, repository.CollectionAccessRequestFilter{Status: status})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access requests: %w", err)
+	}
+
+	canReview := make(map[uint]bool)
+	visible := make([]*domain.CollectionAccessRequest, 0, len(requests))
+	for _, r := range requests {
+		if r.OrganizationUserID == orgUser.ID {
+			visible = append(visible, r)
+			continue
+		}
+		allowed, ok := canReview[r.CollectionID]
+		if !ok {
+			allowed, err = s.canReview(ctx, orgUser, r.CollectionID)
+			if err != nil {
+				return nil, err
+			}
+			canReview[r.CollectionID] = allowed
+		}
+		if allowed {
+			visible = append(visible, r)
+		}
+	}
+	return visible, nil
+}
+
+func (s *collectionAccessRequestService) Approve(ctx context.Context, orgID, requestID, userID uint, req *domain.ReviewCollectionAccessRequest) (*domain.CollectionAccessRequest, error) {
+	request, reviewer, err := s.loadForReview(ctx, orgID, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	hours := request.DurationHours
+	if req != nil && req.DurationHours != nil {
+		hours = *req.DurationHours
+	}
+	if hours < 1 || hours > domain.MaxAccessRequestHours {
+		return nil, ErrInvalidAccessDuration
+	}
+
+	now := s.now()
+	expiresAt := now.Add(time.Duration(hours) * time.Hour)
+	if err := s.grant(ctx, request, expiresAt); err != nil {
+		return nil, err
+	}
+
+	request.Status = domain.AccessRequestStatusApproved
+	request.DurationHours = hours
+	request.ExpiresAt = &expiresAt
+	markReviewed(request, reviewer, req, now)
+	if err := s.requestRepo.Update(ctx, request); err != nil {
+		return nil, fmt.Errorf("failed to approve access request: %w", err)
+	}
+
+	s.notifyRequester(ctx, request, "approved")
+	s.logger.Info("collection access approved", "org_id", orgID, "request_id", request.ID, "collection_id", request.CollectionID, "reviewed_by", reviewer.ID, "expires_at", expiresAt)
+	return request, nil
+}
+
+func (s *collectionAccessRequestService) Deny(ctx context.Context, orgID, requestID, userID uint, req *domain.ReviewCollectionAccessRequest) (*domain.CollectionAccessRequest, error) {
+	request, reviewer, err := s.loadForReview(ctx, orgID, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Status = domain.AccessRequestStatusDenied
+	markReviewed(request, reviewer, req, s.now())
+	if err := s.requestRepo.Update(ctx, request); err != nil {
+		return nil, fmt.Errorf("failed to deny access request: %w", err)
+	}
+
+	s.notifyRequester(ctx, request, "denied")
+	s.logger.Info("collection access denied", "org_id", orgID, "request_id", request.ID, "collection_id", request.CollectionID, "reviewed_by", reviewer.ID)
+	return request, nil
+}
+
+func (s *collectionAccessRequestService) Cancel(ctx context.Context, orgID, requestID, userID uint) (*domain.CollectionAccessRequest, error) {
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil {
+		return nil, repository.ErrForbidden
+	}
+	request, err := s.requestRepo.GetByID(ctx, orgID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.OrganizationUserID != orgUser.ID {
+		return nil, repository.ErrForbidden
+	}
+	if !request.IsPending() {
+		return nil, ErrAccessRequestNotPending
+	}
+
+	request.Status = domain.AccessRequestStatusCancelled
+	if err := s.requestRepo.Update(ctx, request); err != nil {
+		return nil, fmt.Errorf("failed to cancel access request: %w", err)
+	}
+	return request, nil
+}
+
+// ProcessExpiredGrants revokes temporary access whose approved duration has passed
+func (s *collectionAccessRequestService) ProcessExpiredGrants(ctx context.Context) error {
+	now := s.now()
+	expired, err := s.requestRepo.ListExpired(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to list expired access requests: %w", err)
+	}
+
+	for _, request := range expired {
+		if err := s.expire(ctx, request, now); err != nil {
+			// Log error but continue processing others
+			s.logger.Error("failed to expire collection access", "request_id", request.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (s *collectionAccessRequestService) expire(ctx context.Context, request *domain.CollectionAccessRequest, now time.Time) error {
+	// The grant may have been extended by a later request or made permanent by a manager
+	grant, err := s.collectionUserRepo.GetByCollectionAndOrgUser(ctx, request.CollectionID, request.OrganizationUserID)
+	switch {
+	case err == nil:
+		if grant.ExpiresAt != nil && !grant.ExpiresAt.After(now) {
+			if err := s.collectionUserRepo.Delete(ctx, grant.ID); err != nil {
+				return fmt.Errorf("failed to revoke grant: %w", err)
+			}
+		}
+	case !errors.Is(err, repository.ErrNotFound):
+		return fmt.Errorf("failed to load grant: %w", err)
+	}
+
+	request.Status = domain.AccessRequestStatusExpired
+	if err := s.requestRepo.Update(ctx, request); err != nil {
+		return fmt.Errorf("failed to update access request: %w", err)
+	}
+
+	if request.OrganizationUser != nil {
+		details := ActivityDetails{
+			ActivityFieldOrganizationID:  request.OrganizationID,
+			ActivityFieldCollectionID:    request.CollectionID,
+			ActivityFieldAccessRequestID: request.ID,
+			ActivityFieldExpiresAt:       request.ExpiresAt,
+		}
+		if request.Collection != nil {
+			details[ActivityFieldCollectionName] = request.Collection.Name
+		}
+		s.activityLogger.LogCustomActivity(ctx, request.OrganizationUser.UserID, domain.ActivityTypeCollectionAccessExpired, "system", "Collection Access Worker", details)
+	}
+
+	s.notifyRequester(ctx, request, "expired")
+	if request.ReviewedBy != nil && request.ReviewedBy.User != nil {
+		s.sendEmail(ctx, request.ReviewedBy.User.Email, request, "expired")
+	}
+	s.logger.Info("collection access expired", "org_id", request.OrganizationID, "request_id", request.ID, "collection_id", request.CollectionID)
+	return nil
+}
+
+// grant creates the temporary CollectionUser grant for an approved request, or widens
+// and extends an existing temporary one
+func (s *collectionAccessRequestService) grant(ctx context.Context, request *domain.CollectionAccessRequest, expiresAt time.Time) error {
+	existing, err := s.collectionUserRepo.GetByCollectionAndOrgUser(ctx, request.CollectionID, request.OrganizationUserID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("failed to load existing grant: %w", err)
+	}
+
+	if existing == nil {
+		grant := &domain.CollectionUser{
+			CollectionID:       request.CollectionID,
+			OrganizationUserID: request.OrganizationUserID,
+			CanRead:            true,
+			CanWrite:           request.CanWrite,
+			ExpiresAt:          &expiresAt,
+		}
+		if err := s.collectionUserRepo.Create(ctx, grant); err != nil {
+			return fmt.Errorf("failed to grant access: %w", err)
+		}
+		return nil
+	}
+
+	if existing.ExpiresAt == nil {
+		return ErrPermanentGrantExists
+	}
+	existing.CanRead = true
+	existing.CanWrite = existing.CanWrite || request.CanWrite
+	if existing.ExpiresAt.Before(expiresAt) {
+		existing.ExpiresAt = &expiresAt
+	}
+	if err := s.collectionUserRepo.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to grant access: %w", err)
+	}
+	return nil
+}
+
+// loadForReview loads a pending request and checks the user may review it
+func (s *collectionAccessRequestService) loadForReview(ctx context.Context, orgID, requestID, userID uint) (*domain.CollectionAccessRequest, *domain.OrganizationUser, error) {
+	reviewer, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil || !isActiveMember(reviewer) {
+		return nil, nil, repository.ErrForbidden
+	}
+
+	request, err := s.requestRepo.GetByID(ctx, orgID, requestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.OrganizationUserID == reviewer.ID {
+		return nil, nil, ErrCannotReviewOwnRequest
+	}
+
+	allowed, err := s.canReview(ctx, reviewer, request.CollectionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !allowed {
+		return nil, nil, repository.ErrForbidden
+	}
+	if !request.IsPending() {
+		return nil, nil, ErrAccessRequestNotPending
+	}
+	return request, reviewer, nil
+}
+
+// canReview reports whether the member may approve or deny requests for the collection:
+// members holding manage_collections review every collection, others need admin access on it
+func (s *collectionAccessRequestService) canReview(ctx context.Context, orgUser *domain.OrganizationUser, collectionID uint) (bool, error) {
+	if authz.HasPermission(orgUser, domain.OrgPermManageCollections) {
+		return true, nil
+	}
+	access, err := s.access(ctx, orgUser, collectionID)
+	if err != nil {
+		return false, err
+	}
+	return access.CanAdmin, nil
+}
+
+func (s *collectionAccessRequestService) access(ctx context.Context, orgUser *domain.OrganizationUser, collectionID uint) (*authz.CollectionAccess, error) {
+	return authz.ComputeCollectionAccess(
+		ctx,
+		orgUser,
+		collectionID,
+		s.collectionRepo,
+		s.collectionUserRepo,
+		s.collectionTeamRepo,
+		s.teamUserRepo,
+	)
+}
+
+func markReviewed(request *domain.CollectionAccessRequest, reviewer *domain.OrganizationUser, req *domain.ReviewCollectionAccessRequest, at time.Time) {
+	request.ReviewedByOrgUserID = &reviewer.ID
+	request.ReviewedBy = reviewer
+	request.ReviewedAt = &at
+	if req != nil {
+		request.ReviewNote = strings.TrimSpace(req.Note)
+	}
+}
+
+// isActiveMember reports whether the membership is accepted and not suspended
+func isActiveMember(orgUser *domain.OrganizationUser) bool {
+	return orgUser.Status == domain.OrgUserStatusAccepted || orgUser.Status == domain.OrgUserStatusConfirmed
+}
+
+// notifyReviewers emails every active member who can approve the request and returns how many emails were sent
+func (s *collectionAccessRequestService) notifyReviewers(ctx context.Context, request *domain.CollectionAccessRequest) int {
+	if s.emailSender == nil || s.emailBuilder == nil {
+		return 0
+	}
+
+	members, err := s.orgUserRepo.ListByOrganization(ctx, request.OrganizationID)
+	if err != nil {
+		s.logger.Warn("access request: failed to list reviewers", "org_id", request.OrganizationID, "error", err)
+		return 0
+	}
+
+	sent := 0
+	for _, m := range members {
+		if m.User == nil || m.ID == request.OrganizationUserID || !isActiveMember(m) {
+			continue
+		}
+		if allowed, err := s.canReview(ctx, m, request.CollectionID); err != nil || !allowed {
+			continue
+		}
+		if s.sendEmail(ctx, m.User.Email, request, "requested") {
+			sent++
+		}
+	}
+	return sent
+}
+
+func (s *collectionAccessRequestService) notifyRequester(ctx context.Context, request *domain.CollectionAccessRequest, stage string) {
+	if request.OrganizationUser == nil || request.OrganizationUser.User == nil {
+		return
+	}
+	s.sendEmail(ctx, request.OrganizationUser.User.Email, request, stage)
+}
+
+// sendEmail sends one access request notification and reports whether it went out
+func (s *collectionAccessRequestService) sendEmail(ctx context.Context, to string, request *domain.CollectionAccessRequest, stage string) bool {
+	if s.emailSender == nil || s.emailBuilder == nil {
+		return false
+	}
+
+	orgName := ""
+	if org, err := s.orgRepo.GetByID(ctx, request.OrganizationID); err == nil {
+		orgName = org.Name
+	}
+	collectionName := ""
+	if request.Collection != nil {
+		collectionName = request.Collection.Name
+	}
+	requesterEmail := ""
+	if request.OrganizationUser != nil && request.OrganizationUser.User != nil {
+		requesterEmail = request.OrganizationUser.User.Email
+	}
+
+	msg, err := s.emailBuilder.BuildCollectionAccessEmail(to, request.OrganizationID, orgName, collectionName, stage, requesterEmail, request.Justification, request.ReviewNote, request.ExpiresAt)
+	if err != nil {
+		s.logger.Error("access request: failed to build email", "request_id", request.ID, "error", err)
+		return false
+	}
+	if err := s.emailSender.Send(ctx, msg); err != nil {
+		s.logger.Warn("access request: failed to send email", "request_id", request.ID, "to", to, "error", err)
+		return false
+	}
+	return true
+}
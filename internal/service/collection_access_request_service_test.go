@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// fakeAccessRequestRepo keeps access requests by ID
+type fakeAccessRequestRepo struct {
+	requests map[uint]*domain.CollectionAccessRequest
+	nextID   uint
+}
+
+func (f *fakeAccessRequestRepo) Create(_ context.Context, r *domain.CollectionAccessRequest) error {
+	f.nextID++
+	r.ID = f.nextID
+	f.requests[r.ID] = r
+	return nil
+}
+func (f *fakeAccessRequestRepo) GetByID(_ context.Context, orgID, id uint) (*domain.CollectionAccessRequest, error) {
+	if r, ok := f.requests[id]; ok && r.OrganizationID == orgID {
+		return r, nil
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeAccessRequestRepo) GetPending(_ context.Context, collectionID, orgUserID uint) (*domain.CollectionAccessRequest, error) {
+	for _, r := range f.requests {
+		if r.CollectionID == collectionID && r.OrganizationUserID == orgUserID && r.IsPending() {
+			return r, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeAccessRequestRepo) ListByOrganization(_ context.Context, orgID uint, filter repository.CollectionAccessRequestFilter) ([]*domain.CollectionAccessRequest, error) {
+	var out []*domain.CollectionAccessRequest
+	for _, r := range f.requests {
+		if r.OrganizationID == orgID && (filter.Status == nil || r.Status == *filter.Status) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+func (f *fakeAccessRequestRepo) ListExpired(_ context.Context, before time.Time) ([]*domain.CollectionAccessRequest, error) {
+	var out []*domain.CollectionAccessRequest
+	for _, r := range f.requests {
+		if r.Status == domain.AccessRequestStatusApproved && r.ExpiresAt != nil && !r.ExpiresAt.After(before) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+func (f *fakeAccessRequestRepo) Update(_ context.Context, _ *domain.CollectionAccessRequest) error {
+	return nil
+}
+
+// fakeFlatCollectionRepo serves collections without parents
+type fakeFlatCollectionRepo struct {
+	collections map[uint]*domain.Collection
+}
+
+func (f *fakeFlatCollectionRepo) GetByID(_ context.Context, id uint) (*domain.Collection, error) {
+	if c, ok := f.collections[id]; ok {
+		return c, nil
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeFlatCollectionRepo) ListAncestorIDs(_ context.Context, id uint) ([]uint, error) {
+	return []uint{id}, nil
+}
+
+// fakeGrantRepo keeps direct collection grants and, like the real repository,
+// ignores expired ones when computing access
+type fakeGrantRepo struct {
+	grants map[uint]*domain.CollectionUser
+	nextID uint
+	now    func() time.Time
+}
+
+func (f *fakeGrantRepo) GetByCollectionAndOrgUser(_ context.Context, collectionID, orgUserID uint) (*domain.CollectionUser, error) {
+	for _, g := range f.grants {
+		if g.CollectionID == collectionID && g.OrganizationUserID == orgUserID {
+			return g, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeGrantRepo) ListByOrgUserAndCollections(_ context.Context, orgUserID uint, collectionIDs []uint) ([]*domain.CollectionUser, error) {
+	var out []*domain.CollectionUser
+	for _, g := range f.grants {
+		if g.OrganizationUserID != orgUserID || (g.ExpiresAt != nil && !g.ExpiresAt.After(f.now())) {
+			continue
+		}
+		for _, id := range collectionIDs {
+			if g.CollectionID == id {
+				out = append(out, g)
+			}
+		}
+	}
+	return out, nil
+}
+func (f *fakeGrantRepo) Create(_ context.Context, cu *domain.CollectionUser) error {
+	f.nextID++
+	cu.ID = f.nextID
+	f.grants[cu.ID] = cu
+	return nil
+}
+func (f *fakeGrantRepo) Update(_ context.Context, _ *domain.CollectionUser) error { return nil }
+func (f *fakeGrantRepo) Delete(_ context.Context, id uint) error {
+	delete(f.grants, id)
+	return nil
+}
+
+type noTeamGrants struct{}
+
+func (noTeamGrants) ListByCollections(context.Context, []uint) ([]*domain.CollectionTeam, error) {
+	return nil, nil
+}
+func (noTeamGrants) ListByOrgUser(context.Context, uint) ([]*domain.TeamUser, error) {
+	return nil, nil
+}
+
+type accessRequestFixture struct {
+	svc      *collectionAccessRequestService
+	requests *fakeAccessRequestRepo
+	grants   *fakeGrantRepo
+	sender   *fakeEmailSender
+	activity *fakeActivityService
+	members  *suspensionMemberRepo
+	clock    time.Time
+}
+
+// newAccessRequestFixture sets up org 1 with collection 5. Member 12 (user 102)
+// manages the collection; member 13 (user 103) has no access to it.
+func newAccessRequestFixture(t *testing.T) *accessRequestFixture {
+	t.Helper()
+
+	f := &accessRequestFixture{
+		requests: &fakeAccessRequestRepo{requests: map[uint]*domain.CollectionAccessRequest{}},
+		sender:   &fakeEmailSender{},
+		activity: &fakeActivityService{},
+		clock:    time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+	f.grants = &fakeGrantRepo{grants: map[uint]*domain.CollectionUser{}, now: func() time.Time { return f.clock }}
+	require.NoError(t, f.grants.Create(context.Background(), &domain.CollectionUser{CollectionID: 5, OrganizationUserID: 12, CanRead: true, CanWrite: true, CanAdmin: true}))
+
+	orgs := &fakeOrgRepo{orgs: map[uint]*domain.Organization{}}
+	orgs.add(&domain.Organization{ID: 1, Name: "Acme"})
+	collections := &fakeFlatCollectionRepo{collections: map[uint]*domain.Collection{
+		5: {ID: 5, OrganizationID: 1, Name: "Production"},
+	}}
+	f.members = &suspensionMemberRepo{members: map[uint]*domain.OrganizationUser{
+		12: {ID: 12, OrganizationID: 1, UserID: 102, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed, User: &domain.User{Email: "lead@acme.test"}},
+		13: {ID: 13, OrganizationID: 1, UserID: 103, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed, User: &domain.User{Email: "oncall@acme.test"}},
+	}}
+	builder, err := email.NewEmailBuilder("https://vault.example.com", "noreply@example.com")
+	require.NoError(t, err)
+
+	f.svc = NewCollectionAccessRequestService(
+		f.requests, collections, f.grants, noTeamGrants{}, noTeamGrants{}, orgs, f.members,
+		f.sender, builder, f.activity, noopLogger{},
+	).(*collectionAccessRequestService)
+	f.svc.now = func() time.Time { return f.clock }
+	return f
+}
+
+func TestCollectionAccessRequest_ApproveAndExpire(t *testing.T) {
+	ctx := context.Background()
+	f := newAccessRequestFixture(t)
+
+	request, err := f.svc.Create(ctx, 5, 103, &domain.CreateCollectionAccessRequest{Justification: " incident 42 ", DurationHours: 4})
+	require.NoError(t, err)
+	assert.Equal(t, domain.AccessRequestStatusPending, request.Status)
+	assert.Equal(t, "incident 42", request.Justification)
+	require.Len(t, f.sender.sent, 1)
+	assert.Equal(t, "lead@acme.test", f.sender.sent[0].To)
+
+	hours := 2
+	approved, err := f.svc.Approve(ctx, 1, request.ID, 102, &domain.ReviewCollectionAccessRequest{DurationHours: &hours})
+	require.NoError(t, err)
+	assert.Equal(t, domain.AccessRequestStatusApproved, approved.Status)
+	require.NotNil(t, approved.ExpiresAt)
+	assert.Equal(t, f.clock.Add(2*time.Hour), *approved.ExpiresAt)
+	assert.Equal(t, "oncall@acme.test", f.sender.sent[1].To)
+
+	grant, err := f.grants.GetByCollectionAndOrgUser(ctx, 5, 13)
+	require.NoError(t, err)
+	assert.True(t, grant.CanRead)
+	assert.False(t, grant.CanWrite)
+
+	// Nothing happens before the grant expires
+	require.NoError(t, f.svc.ProcessExpiredGrants(ctx))
+	assert.Equal(t, domain.AccessRequestStatusApproved, approved.Status)
+
+	f.clock = f.clock.Add(3 * time.Hour)
+	require.NoError(t, f.svc.ProcessExpiredGrants(ctx))
+	assert.Equal(t, domain.AccessRequestStatusExpired, approved.Status)
+	_, err = f.grants.GetByCollectionAndOrgUser(ctx, 5, 13)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Equal(t, []domain.ActivityType{domain.ActivityTypeCollectionAccessExpired}, f.activity.logged)
+	assert.Len(t, f.sender.sent, 4, "requester and approver are told about the expiry")
+}
+
+func TestCollectionAccessRequest_Rules(t *testing.T) {
+	ctx := context.Background()
+	f := newAccessRequestFixture(t)
+
+	_, err := f.svc.Create(ctx, 5, 103, &domain.CreateCollectionAccessRequest{Justification: "x", DurationHours: domain.MaxAccessRequestHours + 1})
+	assert.ErrorIs(t, err, ErrInvalidAccessDuration)
+
+	_, err = f.svc.Create(ctx, 5, 102, &domain.CreateCollectionAccessRequest{Justification: "x", DurationHours: 1})
+	assert.ErrorIs(t, err, ErrAccessAlreadyGranted)
+
+	request, err := f.svc.Create(ctx, 5, 103, &domain.CreateCollectionAccessRequest{Justification: "x", DurationHours: 1})
+	require.NoError(t, err)
+	_, err = f.svc.Create(ctx, 5, 103, &domain.CreateCollectionAccessRequest{Justification: "again", DurationHours: 1})
+	assert.ErrorIs(t, err, ErrAccessRequestDuplicate)
+
+	_, err = f.svc.Approve(ctx, 1, request.ID, 103, nil)
+	assert.ErrorIs(t, err, ErrCannotReviewOwnRequest)
+
+	_, err = f.svc.Cancel(ctx, 1, request.ID, 102)
+	assert.ErrorIs(t, err, repository.ErrForbidden, "only the requester can cancel")
+
+	denied, err := f.svc.Deny(ctx, 1, request.ID, 102, &domain.ReviewCollectionAccessRequest{Note: "use the runbook"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.AccessRequestStatusDenied, denied.Status)
+	assert.Equal(t, "use the runbook", denied.ReviewNote)
+
+	_, err = f.svc.Approve(ctx, 1, request.ID, 102, nil)
+	assert.ErrorIs(t, err, ErrAccessRequestNotPending)
+	assert.Equal(t, f.clock, *denied.ReviewedAt)
+}
+
+func TestCollectionAccessRequest_ManageCollectionsReviews(t *testing.T) {
+	ctx := context.Background()
+	f := newAccessRequestFixture(t)
+
+	// Member 14 holds manage_collections through a custom role but no grant on the collection
+	f.members.members[14] = &domain.OrganizationUser{
+		ID: 14, OrganizationID: 1, UserID: 104, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed,
+		User:       &domain.User{Email: "it@acme.test"},
+		CustomRole: &domain.OrganizationCustomRole{Name: "IT", Permissions: string(domain.OrgPermManageCollections)},
+	}
+
+	request, err := f.svc.Create(ctx, 5, 103, &domain.CreateCollectionAccessRequest{Justification: "x", DurationHours: 1})
+	require.NoError(t, err)
+	assert.Len(t, f.sender.sent, 2, "the collection manager and member 14 are asked to review")
+
+	visible, err := f.svc.List(ctx, 1, 104, nil)
+	require.NoError(t, err)
+	require.Len(t, visible, 1)
+
+	denied, err := f.svc.Deny(ctx, 1, request.ID, 104, nil)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AccessRequestStatusDenied, denied.Status)
+}
+
+func TestCollectionAccessRequest_PermanentGrantIsKept(t *testing.T) {
+	ctx := context.Background()
+	f := newAccessRequestFixture(t)
+
+	request, err := f.svc.Create(ctx, 5, 103, &domain.CreateCollectionAccessRequest{Justification: "deploy", DurationHours: 1})
+	require.NoError(t, err)
+	_, err = f.svc.Approve(ctx, 1, request.ID, 102, nil)
+	require.NoError(t, err)
+
+	// A manager makes the access permanent before it expires
+	grant, err := f.grants.GetByCollectionAndOrgUser(ctx, 5, 13)
+	require.NoError(t, err)
+	grant.ExpiresAt = nil
+
+	f.clock = f.clock.Add(2 * time.Hour)
+	require.NoError(t, f.svc.ProcessExpiredGrants(ctx))
+	assert.Equal(t, domain.AccessRequestStatusExpired, request.Status)
+	_, err = f.grants.GetByCollectionAndOrgUser(ctx, 5, 13)
+	assert.NoError(t, err, "the permanent grant survives")
+}
@@ -60,7 +60,7 @@ func (s *collectionService) Create(ctx context.Context, orgID uint, userID uint,
 		return nil, repository.ErrForbidden
 	}
 
-	if !orgUser.CanManageCollections() {
+	if !authz.HasPermission(orgUser, domain.OrgPermManageCollections) {
 		return nil, repository.ErrForbidden
 	}
 
@@ -80,8 +80,22 @@ func (s *collectionService) Create(ctx context.Context, orgID uint, userID uint,
 		return nil, fmt.Errorf("organization has reached max collections limit (%d)", maxCollections)
 	}
 
-	// Check if collection name already exists
-	existing, err := s.collectionRepo.GetByName(ctx, orgID, req.Name)
+	if req.ParentID != nil {
+		parent, err := s.collectionRepo.GetByID(ctx, *req.ParentID)
+		if err != nil || parent.OrganizationID != orgID {
+			return nil, fmt.Errorf("parent collection not found")
+		}
+		parentDepth, err := s.depth(ctx, parent.ID)
+		if err != nil {
+			return nil, err
+		}
+		if parentDepth+1 > domain.MaxCollectionDepth {
+			return nil, fmt.Errorf("collections cannot be nested more than %d levels deep", domain.MaxCollectionDepth)
+		}
+	}
+
+	// Check if collection name already exists among its siblings
+	existing, err := s.collectionRepo.GetByName(ctx, orgID, req.ParentID, req.Name)
 	if err == nil && existing != nil {
 		return nil, fmt.Errorf("collection with name '%s' already exists", req.Name)
 	}
@@ -89,6 +103,7 @@ func (s *collectionService) Create(ctx context.Context, orgID uint, userID uint,
 	// Create collection
 	collection := &domain.Collection{
 		OrganizationID: orgID,
+		ParentID:       req.ParentID,
 		Name:           req.Name,
 		Description:    req.Description,
 		IsPrivate:      req.IsPrivate,
@@ -150,7 +165,7 @@ func (s *collectionService) ListByOrganization(ctx context.Context, orgID uint,
 			s.logger.Error("failed to list collections", "org_id", orgID, "error", err)
 			return nil, fmt.Errorf("failed to list collections: %w", err)
 		}
-		return collections, nil
+		return domain.BuildCollectionTree(collections), nil
 	}
 
 	// Regular users only see collections they have access to
@@ -170,7 +185,7 @@ func (s *collectionService) ListForUser(ctx context.Context, orgID uint, userID
 		return nil, fmt.Errorf("failed to list collections: %w", err)
 	}
 
-	return collections, nil
+	return domain.BuildCollectionTree(collections), nil
 }
 
 func (s *collectionService) Update(ctx context.Context, id uint, userID uint, req *domain.UpdateCollectionRequest) (*domain.Collection, error) {
@@ -192,7 +207,7 @@ func (s *collectionService) Update(ctx context.Context, id uint, userID uint, re
 	// Update fields
 	if req.Name != nil {
 		// Check name conflict
-		existing, err := s.collectionRepo.GetByName(ctx, collection.OrganizationID, *req.Name)
+		existing, err := s.collectionRepo.GetByName(ctx, collection.OrganizationID, collection.ParentID, *req.Name)
 		if err == nil && existing != nil && existing.ID != id {
 			return nil, fmt.Errorf("collection with name '%s' already exists", *req.Name)
 		}
@@ -224,36 +239,155 @@ func (s *collectionService) Delete(ctx context.Context, id uint, userID uint) er
 		return fmt.Errorf("cannot delete default collection")
 	}
 
-	// Only org admins can delete collections
+	// Only members who manage collections can delete them
 	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, collection.OrganizationID, userID)
 	if err != nil {
 		return repository.ErrForbidden
 	}
 
-	if !orgUser.IsAdmin() {
+	if !authz.HasPermission(orgUser, domain.OrgPermManageCollections) {
 		return repository.ErrForbidden
 	}
 
-	// Enforce "no orphan items": move items to default collection first.
-	def, err := s.collectionRepo.GetDefaultByOrganization(ctx, collection.OrganizationID)
+	// Deleting a collection deletes its whole subtree
+	subtree, err := s.collectionRepo.ListSubtree(ctx, id)
 	if err != nil {
-		return fmt.Errorf("default collection not found")
+		return fmt.Errorf("failed to load child collections: %w", err)
+	}
+	ids := make([]uint, 0, len(subtree))
+	for _, c := range subtree {
+		if c.IsDefault {
+			return fmt.Errorf("cannot delete default collection")
+		}
+		ids = append(ids, c.ID)
 	}
 
-	if err := s.orgItemRepo.MoveItemsToCollection(ctx, id, def.ID); err != nil {
-		return fmt.Errorf("failed to move items to default collection: %w", err)
+	// Enforce "no orphan items": items left without a collection move up to the parent,
+	// or to the default collection when a top-level collection is deleted.
+	var fallbackID uint
+	if collection.ParentID != nil {
+		fallbackID = *collection.ParentID
+	} else {
+		def, err := s.collectionRepo.GetDefaultByOrganization(ctx, collection.OrganizationID)
+		if err != nil {
+			return fmt.Errorf("default collection not found")
+		}
+		fallbackID = def.ID
 	}
 
-	// Use soft delete
-	if err := s.collectionRepo.SoftDelete(ctx, id); err != nil {
+	// Use soft delete; items are moved in the same transaction
+	if err := s.collectionRepo.SoftDeleteMany(ctx, ids, fallbackID); err != nil {
 		s.logger.Error("failed to delete collection", "collection_id", id, "error", err)
 		return fmt.Errorf("failed to delete collection: %w", err)
 	}
 
-	s.logger.Info("collection deleted", "collection_id", id, "org_id", collection.OrganizationID, "deleted_by", userID)
+	s.logger.Info("collection deleted", "collection_id", id, "org_id", collection.OrganizationID, "subtree_size", len(ids), "deleted_by", userID)
 	return nil
 }
 
+// Move re-parents a collection together with its subtree. The member needs admin access
+// to the collection and to the new parent; moving to the top level requires manage_collections.
+func (s *collectionService) Move(ctx context.Context, id uint, userID uint, req *domain.MoveCollectionRequest) (*domain.Collection, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("collection not found: %w", err)
+	}
+
+	if collection.IsDefault {
+		return nil, fmt.Errorf("cannot move default collection")
+	}
+
+	canManage, err := s.checkCollectionManagePermission(ctx, collection.OrganizationID, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, repository.ErrForbidden
+	}
+
+	if req.ParentID == nil {
+		orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, collection.OrganizationID, userID)
+		if err != nil || !authz.HasPermission(orgUser, domain.OrgPermManageCollections) {
+			return nil, repository.ErrForbidden
+		}
+	} else {
+		parent, err := s.collectionRepo.GetByID(ctx, *req.ParentID)
+		if err != nil || parent.OrganizationID != collection.OrganizationID {
+			return nil, fmt.Errorf("parent collection not found")
+		}
+		canManageParent, err := s.checkCollectionManagePermission(ctx, parent.OrganizationID, parent.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !canManageParent {
+			return nil, repository.ErrForbidden
+		}
+	}
+
+	subtree, err := s.collectionRepo.ListSubtree(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load child collections: %w", err)
+	}
+
+	parentDepth := 0
+	if req.ParentID != nil {
+		for _, c := range subtree {
+			if c.ID == *req.ParentID {
+				return nil, fmt.Errorf("cannot move a collection into itself or one of its children")
+			}
+		}
+		parentDepth, err = s.depth(ctx, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if parentDepth+subtreeHeight(id, subtree) > domain.MaxCollectionDepth {
+		return nil, fmt.Errorf("collections cannot be nested more than %d levels deep", domain.MaxCollectionDepth)
+	}
+
+	existing, err := s.collectionRepo.GetByName(ctx, collection.OrganizationID, req.ParentID, collection.Name)
+	if err == nil && existing != nil && existing.ID != id {
+		return nil, fmt.Errorf("collection with name '%s' already exists", collection.Name)
+	}
+
+	collection.ParentID = req.ParentID
+	if err := s.collectionRepo.Update(ctx, collection); err != nil {
+		s.logger.Error("failed to move collection", "collection_id", id, "error", err)
+		return nil, fmt.Errorf("failed to move collection: %w", err)
+	}
+
+	s.logger.Info("collection moved", "collection_id", id, "org_id", collection.OrganizationID, "parent_id", req.ParentID, "moved_by", userID)
+	return collection, nil
+}
+
+// depth returns how many levels deep the collection sits, counting a root as 1
+func (s *collectionService) depth(ctx context.Context, collectionID uint) (int, error) {
+	ancestors, err := s.collectionRepo.ListAncestorIDs(ctx, collectionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load parent collections: %w", err)
+	}
+	return len(ancestors), nil
+}
+
+// subtreeHeight returns the number of levels in the subtree rooted at rootID
+func subtreeHeight(rootID uint, subtree []*domain.Collection) int {
+	levels := map[uint]int{rootID: 1}
+	height := 1
+	// ListSubtree returns parents before children
+	for _, c := range subtree {
+		if c.ParentID == nil {
+			continue
+		}
+		if parentLevel, ok := levels[*c.ParentID]; ok && c.ID != rootID {
+			levels[c.ID] = parentLevel + 1
+			if levels[c.ID] > height {
+				height = levels[c.ID]
+			}
+		}
+	}
+	return height
+}
+
 func (s *collectionService) GrantUserAccess(ctx context.Context, collectionID uint, orgUserID uint, requestingUserID uint, req *domain.GrantCollectionAccessRequest) error {
 	collection, err := s.collectionRepo.GetByID(ctx, collectionID)
 	if err != nil {
@@ -288,6 +422,7 @@ func (s *collectionService) GrantUserAccess(ctx context.Context, collectionID ui
 		existing.CanWrite = req.CanWrite
 		existing.CanAdmin = req.CanAdmin
 		existing.HidePasswords = req.HidePasswords
+		existing.ExpiresAt = nil // A manual grant makes temporary access permanent
 
 		if err := s.collectionUserRepo.Update(ctx, existing); err != nil {
 			s.logger.Error("failed to update collection user access", "collection_id", collectionID, "org_user_id", orgUserID, "error", err)
@@ -491,6 +626,7 @@ func (s *collectionService) checkCollectionAccess(ctx context.Context, orgID, co
 		ctx,
 		orgUser,
 		collectionID,
+		s.collectionRepo,
 		s.collectionUserRepo,
 		s.collectionTeamRepo,
 		s.teamUserRepo,
@@ -510,6 +646,7 @@ func (s *collectionService) checkCollectionManagePermission(ctx context.Context,
 		ctx,
 		orgUser,
 		collectionID,
+		s.collectionRepo,
 		s.collectionUserRepo,
 		s.collectionTeamRepo,
 		s.teamUserRepo,
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+)
+
+// DunningService follows up on past_due subscriptions: it reminds billing
+// contacts, downgrades the vault to read-only after the grace period and
+// finally expires the subscription.
+type DunningService interface {
+	// ProcessPastDue advances every past_due subscription through the dunning schedule
+	ProcessPastDue(ctx context.Context) error
+}
+
+type dunningService struct {
+	schedule domain.DunningSchedule
+	subRepo  interface {
+		ListByState(ctx context.Context, state domain.SubscriptionState) ([]*domain.Subscription, error)
+		Update(ctx context.Context, sub *domain.Subscription) error
+	}
+	subscriptionService SubscriptionService
+	orgRepo             interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	}
+	orgUserRepo interface {
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+	}
+	emailSender    email.Sender
+	emailBuilder   *email.EmailBuilder
+	activityLogger *ActivityLogger
+	logger         Logger
+	now            func() time.Time
+}
+
+// NewDunningService creates a new dunning service
+func NewDunningService(
+	schedule domain.DunningSchedule,
+	subRepo interface {
+		ListByState(ctx context.Context, state domain.SubscriptionState) ([]*domain.Subscription, error)
+		Update(ctx context.Context, sub *domain.Subscription) error
+	},
+	subscriptionService SubscriptionService,
+	orgRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	},
+	orgUserRepo interface {
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+	},
+	emailSender email.Sender,
+	emailBuilder *email.EmailBuilder,
+	activityService UserActivityService,
+	logger Logger,
+) DunningService {
+	return &dunningService{
+		schedule:            schedule,
+		subRepo:             subRepo,
+		subscriptionService: subscriptionService,
+		orgRepo:             orgRepo,
+		orgUserRepo:         orgUserRepo,
+		emailSender:         emailSender,
+		emailBuilder:        emailBuilder,
+		activityLogger:      NewActivityLogger(activityService),
+		logger:              logger,
+		now:                 time.Now,
+	}
+}
+
+// ProcessPastDue advances every past_due subscription through the dunning schedule
+func (s *dunningService) ProcessPastDue(ctx context.Context) error {
+	subs, err := s.subRepo.ListByState(ctx, domain.SubStatePastDue)
+	if err != nil {
+		return fmt.Errorf("failed to list past due subscriptions: %w", err)
+	}
+
+	now := s.now()
+	for _, sub := range subs {
+		if err := s.process(ctx, sub, now); err != nil {
+			// Log error but continue processing others
+			s.logger.Error("dunning step failed", "subscription_id", sub.ID, "org_id", sub.OrganizationID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (s *dunningService) process(ctx context.Context, sub *domain.Subscription, now time.Time) error {
+	stage := s.schedule.StageAt(sub, now)
+	details := ActivityDetails{}
+	var activityType domain.ActivityType
+
+	switch stage {
+	case domain.DunningStageExpired:
+		if err := s.subscriptionService.ExpireSubscription(ctx, sub.ID); err != nil {
+			return fmt.Errorf("failed to expire subscription: %w", err)
+		}
+		activityType = domain.ActivityTypeDunningExpired
+
+	case domain.DunningStageReadOnly:
+		if sub.ReadOnlySince != nil {
+			return nil
+		}
+		sub.ReadOnlySince = &now
+		if err := s.subRepo.Update(ctx, sub); err != nil {
+			return fmt.Errorf("failed to downgrade subscription to read-only: %w", err)
+		}
+		activityType = domain.ActivityTypeDunningReadOnly
+
+	case domain.DunningStageReminder:
+		// Catch up on missed runs with a single reminder
+		due := s.schedule.RemindersDue(sub, now)
+		if due <= sub.DunningRemindersSent {
+			return nil
+		}
+		sub.DunningRemindersSent = due
+		if err := s.subRepo.Update(ctx, sub); err != nil {
+			return fmt.Errorf("failed to record dunning reminder: %w", err)
+		}
+		activityType = domain.ActivityTypeDunningReminderSent
+		details["reminder"] = due
+
+	default:
+		return nil
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, sub.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("failed to get organization: %w", err)
+	}
+	members, err := s.orgUserRepo.ListByOrganization(ctx, sub.OrganizationID)
+	if err != nil {
+		s.logger.Warn("dunning: failed to list organization members", "org_id", sub.OrganizationID, "error", err)
+	}
+
+	sent := s.notify(ctx, org, members, sub, stage)
+
+	s.logger.Info("dunning step completed",
+		"subscription_id", sub.ID,
+		"org_id", org.ID,
+		"stage", stage,
+		"reminders_sent", sub.DunningRemindersSent,
+		"emails_sent", sent,
+	)
+
+	details[ActivityFieldOrganizationID] = org.ID
+	details[ActivityFieldOrganizationName] = org.Name
+	details[ActivityFieldSubscriptionID] = sub.ID
+	details[ActivityFieldStatus] = string(stage)
+	details["past_due_since"] = s.schedule.PastDueSince(sub)
+	details["emails_sent"] = sent
+	for _, m := range members {
+		if m.Role == domain.OrgRoleOwner {
+			s.activityLogger.LogCustomActivity(ctx, m.UserID, activityType, "system", "Subscription Worker", details)
+			break
+		}
+	}
+
+	return nil
+}
+
+// notify emails the organization's billing contacts and returns how many emails were sent
+func (s *dunningService) notify(ctx context.Context, org *domain.Organization, members []*domain.OrganizationUser, sub *domain.Subscription, stage domain.DunningStage) int {
+	if s.emailSender == nil || s.emailBuilder == nil {
+		return 0
+	}
+
+	readOnlyAt := s.schedule.ReadOnlyAt(sub)
+	expiresAt := s.schedule.ExpiresAt(sub)
+
+	sent := 0
+	for _, to := range billingContacts(org, members) {
+		msg, err := s.emailBuilder.BuildDunningEmail(to, org.ID, org.Name, string(stage), readOnlyAt, expiresAt)
+		if err != nil {
+			s.logger.Error("dunning: failed to build email", "org_id", org.ID, "error", err)
+			return sent
+		}
+		if err := s.emailSender.Send(ctx, msg); err != nil {
+			s.logger.Warn("dunning: failed to send email", "org_id", org.ID, "to", to, "error", err)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+// billingContacts returns the billing email and the owners/billing managers of an organization
+func billingContacts(org *domain.Organization, members []*domain.OrganizationUser) []string {
+	seen := make(map[string]bool)
+	var contacts []string
+	add := func(addr string) {
+		addr = strings.TrimSpace(addr)
+		key := strings.ToLower(addr)
+		if addr == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		contacts = append(contacts, addr)
+	}
+
+	add(org.BillingEmail)
+	for _, m := range members {
+		if m.User == nil || (m.Role != domain.OrgRoleOwner && m.Role != domain.OrgRoleBilling) {
+			continue
+		}
+		if m.Status != domain.OrgUserStatusAccepted && m.Status != domain.OrgUserStatusConfirmed {
+			continue
+		}
+		add(m.User.Email)
+	}
+	return contacts
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// billingMemberRepo lists a fixed set of organization members
+type billingMemberRepo struct {
+	repository.OrganizationUserRepository
+	members []*domain.OrganizationUser
+}
+
+func (f *billingMemberRepo) ListByOrganization(_ context.Context, _ uint) ([]*domain.OrganizationUser, error) {
+	return f.members, nil
+}
+
+type fakeEmailSender struct {
+	sent []*email.EmailMessage
+}
+
+func (f *fakeEmailSender) Send(_ context.Context, msg *email.EmailMessage) error {
+	f.sent = append(f.sent, msg)
+	return nil
+}
+func (f *fakeEmailSender) Provider() email.Provider { return "" }
+func (f *fakeEmailSender) Close() error             { return nil }
+
+type fakeActivityService struct {
+	UserActivityService
+	logged []domain.ActivityType
+}
+
+func (f *fakeActivityService) LogActivity(_ context.Context, req *domain.CreateActivityRequest) error {
+	f.logged = append(f.logged, req.ActivityType)
+	return nil
+}
+
+// fakeDunningSubRepo lists its subscriptions by state
+type fakeDunningSubRepo struct {
+	subs []*domain.Subscription
+}
+
+func (f *fakeDunningSubRepo) ListByState(_ context.Context, state domain.SubscriptionState) ([]*domain.Subscription, error) {
+	var out []*domain.Subscription
+	for _, sub := range f.subs {
+		if sub.State == state {
+			out = append(out, sub)
+		}
+	}
+	return out, nil
+}
+func (f *fakeDunningSubRepo) Update(context.Context, *domain.Subscription) error {
+	return nil
+}
+
+// fakeExpirer expires subscriptions like SubscriptionService.ExpireSubscription
+type fakeExpirer struct {
+	SubscriptionService
+	repo *fakeDunningSubRepo
+}
+
+func (f *fakeExpirer) ExpireSubscription(_ context.Context, subID uint) error {
+	for _, sub := range f.repo.subs {
+		if sub.ID == subID {
+			sub.State = domain.SubStateExpired
+		}
+	}
+	return nil
+}
+
+type dunningFixture struct {
+	svc      *dunningService
+	subs     *fakeDunningSubRepo
+	sender   *fakeEmailSender
+	activity *fakeActivityService
+	start    time.Time
+	clock    time.Time
+}
+
+// newDunningFixture sets up org 1 whose subscription went past_due at start, with
+// reminders on days 1, 3 and 7, read-only after 14 days and expiry after 30
+func newDunningFixture(t *testing.T) *dunningFixture {
+	t.Helper()
+
+	start := time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC)
+	f := &dunningFixture{
+		subs: &fakeDunningSubRepo{subs: []*domain.Subscription{
+			{ID: 7, OrganizationID: 1, State: domain.SubStatePastDue, PastDueSince: &start},
+		}},
+		sender:   &fakeEmailSender{},
+		activity: &fakeActivityService{},
+		start:    start,
+		clock:    start,
+	}
+	orgs := newFakeOrgRepo()
+	orgs.add(&domain.Organization{ID: 1, Name: "Acme", BillingEmail: "billing@acme.test"})
+	members := &billingMemberRepo{members: []*domain.OrganizationUser{
+		{OrganizationID: 1, UserID: 1, Role: domain.OrgRoleOwner, Status: domain.OrgUserStatusConfirmed, User: &domain.User{Email: "owner@acme.test"}},
+		{OrganizationID: 1, UserID: 2, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed, User: &domain.User{Email: "dev@acme.test"}},
+	}}
+	builder, err := email.NewEmailBuilder("https://vault.example.com", "noreply@example.com")
+	require.NoError(t, err)
+
+	f.svc = NewDunningService(
+		domain.NewDunningSchedule([]int{1, 3, 7}, 14, 30),
+		f.subs, &fakeExpirer{repo: f.subs}, orgs, members,
+		f.sender, builder, f.activity, noopLogger{},
+	).(*dunningService)
+	f.svc.now = func() time.Time { return f.clock }
+	return f
+}
+
+func (f *dunningFixture) runOnDay(t *testing.T, day int) {
+	t.Helper()
+	f.clock = f.start.AddDate(0, 0, day)
+	require.NoError(t, f.svc.ProcessPastDue(context.Background()))
+}
+
+func TestDunning_StageTransitions(t *testing.T) {
+	f := newDunningFixture(t)
+	sub := f.subs.subs[0]
+
+	// Nothing is due before the first reminder day
+	f.runOnDay(t, 0)
+	assert.Empty(t, f.sender.sent)
+	assert.Empty(t, f.activity.logged)
+
+	// Missed runs are caught up with a single reminder to the billing contacts
+	f.runOnDay(t, 4)
+	assert.Equal(t, 2, sub.DunningRemindersSent)
+	require.Len(t, f.sender.sent, 2)
+	assert.ElementsMatch(t, []string{"billing@acme.test", "owner@acme.test"}, []string{f.sender.sent[0].To, f.sender.sent[1].To})
+	assert.Equal(t, []domain.ActivityType{domain.ActivityTypeDunningReminderSent}, f.activity.logged)
+
+	f.runOnDay(t, 5)
+	assert.Len(t, f.sender.sent, 2, "no reminder until the next reminder day")
+
+	f.runOnDay(t, 7)
+	assert.Equal(t, 3, sub.DunningRemindersSent)
+	assert.Len(t, f.sender.sent, 4)
+
+	// The grace period ends: the vault goes read-only once
+	f.runOnDay(t, 14)
+	require.NotNil(t, sub.ReadOnlySince)
+	assert.Equal(t, f.clock, *sub.ReadOnlySince)
+	assert.True(t, sub.IsReadOnly())
+	assert.Len(t, f.sender.sent, 6)
+
+	f.runOnDay(t, 20)
+	assert.Equal(t, f.start.AddDate(0, 0, 14), *sub.ReadOnlySince)
+	assert.Len(t, f.sender.sent, 6)
+	assert.Equal(t, domain.SubStatePastDue, sub.State)
+
+	// Expiry happens at ExpireAfterDays and is logged on the owner
+	f.runOnDay(t, 30)
+	assert.Equal(t, domain.SubStateExpired, sub.State)
+	assert.Len(t, f.sender.sent, 8)
+	assert.Equal(t, []domain.ActivityType{
+		domain.ActivityTypeDunningReminderSent,
+		domain.ActivityTypeDunningReminderSent,
+		domain.ActivityTypeDunningReadOnly,
+		domain.ActivityTypeDunningExpired,
+	}, f.activity.logged)
+
+	// Expired subscriptions leave the dunning schedule
+	f.runOnDay(t, 31)
+	assert.Len(t, f.sender.sent, 8)
+	assert.Len(t, f.activity.logged, 4)
+}
+
+func TestDunning_GracePeriodEndOnlyDowngradesToReadOnly(t *testing.T) {
+	f := newDunningFixture(t)
+	sub := f.subs.subs[0]
+
+	// An app store grace period moves the read-only step, but not the expiry
+	graceEnds := f.start.AddDate(0, 0, 2)
+	sub.GracePeriodEndsAt = &graceEnds
+
+	f.runOnDay(t, 3)
+	assert.True(t, sub.IsReadOnly())
+	assert.False(t, sub.ShouldExpire())
+	assert.Equal(t, domain.SubStatePastDue, sub.State)
+	assert.Equal(t, []domain.ActivityType{domain.ActivityTypeDunningReadOnly}, f.activity.logged)
+
+	f.runOnDay(t, 29)
+	assert.Equal(t, domain.SubStatePastDue, sub.State)
+	f.runOnDay(t, 30)
+	assert.Equal(t, domain.SubStateExpired, sub.State)
+}
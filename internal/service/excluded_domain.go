@@ -7,23 +7,30 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
 )
 
 type excludedDomainService struct {
-	repo   repository.ExcludedDomainRepository
-	logger Logger
+	repo        repository.ExcludedDomainRepository
+	orgRepo     repository.OrganizationExcludedDomainRepository
+	orgUserRepo repository.OrganizationUserRepository
+	logger      Logger
 }
 
 // NewExcludedDomainService creates a new excluded domain service
 func NewExcludedDomainService(
 	repo repository.ExcludedDomainRepository,
+	orgRepo repository.OrganizationExcludedDomainRepository,
+	orgUserRepo repository.OrganizationUserRepository,
 	logger Logger,
 ) ExcludedDomainService {
 	return &excludedDomainService{
-		repo:   repo,
-		logger: logger,
+		repo:        repo,
+		orgRepo:     orgRepo,
+		orgUserRepo: orgUserRepo,
+		logger:      logger,
 	}
 }
 
@@ -95,20 +102,155 @@ func (s *excludedDomainService) DeleteByDomain(ctx context.Context, userID uint,
 }
 
 func (s *excludedDomainService) IsExcluded(ctx context.Context, userID uint, domain string) (bool, error) {
-	normalizedDomain, err := normalizeDomain(domain)
+	check, err := s.Check(ctx, userID, domain)
+	if err != nil {
+		return false, err
+	}
+	return check.IsExcluded, nil
+}
+
+// Check merges the user's own excluded domains with the blocklists of every
+// organization the user is an active member of. Personal entries win so the
+// response points at the list the user can edit.
+func (s *excludedDomainService) Check(ctx context.Context, userID uint, domainStr string) (*domain.ExcludedDomainCheck, error) {
+	normalizedDomain, err := normalizeDomain(domainStr)
 	if err != nil {
-		return false, nil // Invalid domain = not excluded
+		return &domain.ExcludedDomainCheck{}, nil // Invalid domain = not excluded
 	}
 
 	_, err = s.repo.GetByUserIDAndDomain(ctx, userID, normalizedDomain)
-	if err == repository.ErrNotFound {
-		return false, nil
+	if err == nil {
+		return &domain.ExcludedDomainCheck{
+			IsExcluded:    true,
+			Source:        domain.ExcludedDomainSourceUser,
+			MatchedDomain: normalizedDomain,
+		}, nil
 	}
+	if err != repository.ErrNotFound {
+		return nil, err
+	}
+
+	orgIDs, err := s.activeOrganizationIDs(ctx, userID)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+	orgDomains, err := s.orgRepo.ListByOrganizations(ctx, orgIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, ed := range orgDomains {
+		if ed.Matches(normalizedDomain) {
+			return &domain.ExcludedDomainCheck{
+				IsExcluded:     true,
+				Source:         domain.ExcludedDomainSourceOrganization,
+				MatchedDomain:  ed.Domain,
+				OrganizationID: ed.OrganizationID,
+				Reason:         ed.Reason,
+			}, nil
+		}
 	}
 
-	return true, nil
+	return &domain.ExcludedDomainCheck{}, nil
+}
+
+func (s *excludedDomainService) ListForOrganization(ctx context.Context, orgID, userID uint) ([]*domain.OrganizationExcludedDomain, error) {
+	if _, err := s.requireOrgMember(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.orgRepo.ListByOrganization(ctx, orgID)
+}
+
+func (s *excludedDomainService) CreateForOrganization(ctx context.Context, orgID, userID uint, req *domain.CreateOrganizationExcludedDomainRequest) (*domain.OrganizationExcludedDomain, error) {
+	if err := s.requirePolicyManager(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	pattern, err := normalizeDomainPattern(req.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrInvalidInput, err)
+	}
+
+	existing, err := s.orgRepo.GetByOrgAndDomain(ctx, orgID, pattern)
+	if err != nil && err != repository.ErrNotFound {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, repository.ErrAlreadyExists
+	}
+
+	excludedDomain := &domain.OrganizationExcludedDomain{
+		UUID:              uuid.New(),
+		OrganizationID:    orgID,
+		Domain:            pattern,
+		IncludeSubdomains: req.IncludeSubdomains && !strings.HasPrefix(pattern, "*."),
+		Reason:            strings.TrimSpace(req.Reason),
+		CreatedByUserID:   &userID,
+	}
+
+	if err := s.orgRepo.Create(ctx, excludedDomain); err != nil {
+		s.logger.Error("failed to create organization excluded domain", "org_id", orgID, "domain", pattern, "error", err)
+		return nil, err
+	}
+
+	s.logger.Info("organization excluded domain created", "org_id", orgID, "user_id", userID, "domain", pattern)
+	return excludedDomain, nil
+}
+
+func (s *excludedDomainService) DeleteForOrganization(ctx context.Context, orgID, userID, id uint) error {
+	if err := s.requirePolicyManager(ctx, orgID, userID); err != nil {
+		return err
+	}
+
+	if err := s.orgRepo.Delete(ctx, orgID, id); err != nil {
+		if err != repository.ErrNotFound {
+			s.logger.Error("failed to delete organization excluded domain", "org_id", orgID, "id", id, "error", err)
+		}
+		return err
+	}
+
+	s.logger.Info("organization excluded domain deleted", "org_id", orgID, "user_id", userID, "id", id)
+	return nil
+}
+
+func (s *excludedDomainService) activeOrganizationIDs(ctx context.Context, userID uint) ([]uint, error) {
+	memberships, err := s.orgUserRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	orgIDs := make([]uint, 0, len(memberships))
+	for _, membership := range memberships {
+		if membership == nil {
+			continue
+		}
+		if membership.Status != domain.OrgUserStatusAccepted && membership.Status != domain.OrgUserStatusConfirmed {
+			continue
+		}
+		orgIDs = append(orgIDs, membership.OrganizationID)
+	}
+	return orgIDs, nil
+}
+
+func (s *excludedDomainService) requireOrgMember(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error) {
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return nil, repository.ErrForbidden
+		}
+		return nil, err
+	}
+	return orgUser, nil
+}
+
+func (s *excludedDomainService) requirePolicyManager(ctx context.Context, orgID, userID uint) error {
+	orgUser, err := s.requireOrgMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !authz.HasPermission(orgUser, domain.OrgPermManagePolicies) {
+		return repository.ErrForbidden
+	}
+	return nil
 }
 
 // normalizeDomain extracts the hostname from a URL or domain string
@@ -149,3 +291,29 @@ func normalizeDomain(input string) (string, error) {
 
 	return input, nil
 }
+
+// normalizeDomainPattern normalizes an organization blocklist entry.
+// A leading "*." is kept as a subdomain wildcard; wildcards anywhere else are rejected.
+// Examples:
+//   - "https://Admin.Example.com/login" -> "admin.example.com"
+//   - "*.corp.example.com" -> "*.corp.example.com"
+func normalizeDomainPattern(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	wildcard := strings.HasPrefix(input, "*.")
+	if wildcard {
+		input = input[2:]
+	}
+
+	normalized, err := normalizeDomain(input)
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsAny(normalized, "*/?# ") {
+		return "", fmt.Errorf("invalid domain format")
+	}
+
+	if wildcard {
+		return "*." + normalized, nil
+	}
+	return normalized, nil
+}
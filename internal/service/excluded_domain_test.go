@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExcludedDomainRepo implements repository.ExcludedDomainRepository in memory
+type fakeExcludedDomainRepo struct {
+	repository.ExcludedDomainRepository
+	domains []*domain.ExcludedDomain
+}
+
+func (f *fakeExcludedDomainRepo) GetByUserIDAndDomain(_ context.Context, userID uint, domainStr string) (*domain.ExcludedDomain, error) {
+	for _, ed := range f.domains {
+		if ed.UserID == userID && ed.Domain == domainStr {
+			return ed, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+// fakeOrgExcludedDomainRepo implements repository.OrganizationExcludedDomainRepository in memory
+type fakeOrgExcludedDomainRepo struct {
+	domains []*domain.OrganizationExcludedDomain
+}
+
+func (f *fakeOrgExcludedDomainRepo) Create(_ context.Context, ed *domain.OrganizationExcludedDomain) error {
+	ed.ID = uint(len(f.domains) + 1)
+	f.domains = append(f.domains, ed)
+	return nil
+}
+
+func (f *fakeOrgExcludedDomainRepo) ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationExcludedDomain, error) {
+	return f.ListByOrganizations(ctx, []uint{orgID})
+}
+
+func (f *fakeOrgExcludedDomainRepo) ListByOrganizations(_ context.Context, orgIDs []uint) ([]*domain.OrganizationExcludedDomain, error) {
+	var out []*domain.OrganizationExcludedDomain
+	for _, ed := range f.domains {
+		for _, id := range orgIDs {
+			if ed.OrganizationID == id {
+				out = append(out, ed)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeOrgExcludedDomainRepo) GetByOrgAndDomain(_ context.Context, orgID uint, domainStr string) (*domain.OrganizationExcludedDomain, error) {
+	for _, ed := range f.domains {
+		if ed.OrganizationID == orgID && ed.Domain == domainStr {
+			return ed, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (f *fakeOrgExcludedDomainRepo) Delete(_ context.Context, orgID, id uint) error {
+	for i, ed := range f.domains {
+		if ed.OrganizationID == orgID && ed.ID == id {
+			f.domains = append(f.domains[:i], f.domains[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func TestNormalizeDomainPattern(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"https://Admin.Example.com:8443/login": "admin.example.com",
+		"*.Corp.Example.com":                   "*.corp.example.com",
+		"www.example.com":                      "example.com",
+	}
+	for input, want := range tests {
+		got, err := normalizeDomainPattern(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got)
+	}
+
+	for _, input := range []string{"", "*", "admin.*.example.com", "*.com*"} {
+		_, err := normalizeDomainPattern(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestExcludedDomainService_OrganizationBlocklist(t *testing.T) {
+	ctx := context.Background()
+	orgUsers := newFakeOrgUserRepo()
+	orgUsers.add(&domain.OrganizationUser{OrganizationID: 1, UserID: 10, Role: domain.OrgRoleAdmin, Status: domain.OrgUserStatusConfirmed})
+	orgUsers.add(&domain.OrganizationUser{OrganizationID: 1, UserID: 20, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusAccepted})
+	orgUsers.add(&domain.OrganizationUser{OrganizationID: 1, UserID: 30, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusInvited})
+	userDomains := &fakeExcludedDomainRepo{domains: []*domain.ExcludedDomain{{UserID: 20, Domain: "personal.example.com"}}}
+	orgDomains := &fakeOrgExcludedDomainRepo{}
+	svc := NewExcludedDomainService(userDomains, orgDomains, orgUsers, noopLogger{})
+
+	_, err := svc.CreateForOrganization(ctx, 1, 20, &domain.CreateOrganizationExcludedDomainRequest{Domain: "*.corp.example.com"})
+	assert.ErrorIs(t, err, repository.ErrForbidden, "members cannot manage the blocklist")
+
+	created, err := svc.CreateForOrganization(ctx, 1, 10, &domain.CreateOrganizationExcludedDomainRequest{
+		Domain: "*.corp.example.com",
+		Reason: "internal admin consoles",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "*.corp.example.com", created.Domain)
+
+	_, err = svc.CreateForOrganization(ctx, 1, 10, &domain.CreateOrganizationExcludedDomainRequest{Domain: "*.CORP.example.com"})
+	assert.ErrorIs(t, err, repository.ErrAlreadyExists)
+	_, err = svc.CreateForOrganization(ctx, 1, 10, &domain.CreateOrganizationExcludedDomainRequest{Domain: "nodot"})
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+
+	check, err := svc.Check(ctx, 20, "https://jenkins.corp.example.com/job/1")
+	require.NoError(t, err)
+	assert.True(t, check.IsExcluded)
+	assert.Equal(t, domain.ExcludedDomainSourceOrganization, check.Source)
+	assert.Equal(t, uint(1), check.OrganizationID)
+	assert.Equal(t, "internal admin consoles", check.Reason)
+
+	check, err = svc.Check(ctx, 20, "personal.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExcludedDomainSourceUser, check.Source)
+
+	excluded, err := svc.IsExcluded(ctx, 30, "jenkins.corp.example.com")
+	require.NoError(t, err)
+	assert.False(t, excluded, "pending invitations do not inherit the org blocklist")
+
+	require.NoError(t, svc.DeleteForOrganization(ctx, 1, 10, created.ID))
+	excluded, err = svc.IsExcluded(ctx, 20, "jenkins.corp.example.com")
+	require.NoError(t, err)
+	assert.False(t, excluded)
+}
@@ -12,6 +12,7 @@ type FeatureService interface {
 	CanCreateCollection(ctx context.Context, orgID uint) (bool, error)
 	CanInviteUser(ctx context.Context, orgID uint) (bool, error)
 	CanCreateItem(ctx context.Context, orgID uint) (bool, error)
+	CanCreateItems(ctx context.Context, orgID uint, count int) (bool, error)
 	CanUseTeams(ctx context.Context, orgID uint) (bool, error)
 	CanAccessAudit(ctx context.Context, orgID uint) (bool, error)
 	CanUseSSO(ctx context.Context, orgID uint) (bool, error)
@@ -20,6 +21,7 @@ type FeatureService interface {
 	CanUseSharedItems(ctx context.Context, orgID uint) (bool, error)
 	CanUseSecureSend(ctx context.Context, orgID uint) (bool, error)
 	CanUseEmergencyAccess(ctx context.Context, orgID uint) (bool, error)
+	CanUseSecurityInsights(ctx context.Context, orgID uint) (bool, error)
 	GetFeatures(ctx context.Context, orgID uint) (*domain.PlanFeatures, error)
 }
 
@@ -35,6 +37,10 @@ type featureService struct {
 	itemRepo interface {
 		CountByOrganizationID(ctx context.Context, orgID uint) (int, error)
 	}
+	// licenses is optional; when set, a valid offline license takes precedence over billing
+	licenses interface {
+		SubscriptionForOrg(ctx context.Context, orgID uint) (*domain.Subscription, bool)
+	}
 }
 
 // NewFeatureService creates a new feature service
@@ -50,11 +56,15 @@ func NewFeatureService(
 	itemRepo interface {
 		CountByOrganizationID(ctx context.Context, orgID uint) (int, error)
 	},
+	licenses interface {
+		SubscriptionForOrg(ctx context.Context, orgID uint) (*domain.Subscription, bool)
+	},
 ) FeatureService {
 	return &featureService{
 		orgService: orgService,
 		subRepo:    subRepo,
 		itemRepo:   itemRepo,
+		licenses:   licenses,
 	}
 }
 
@@ -66,6 +76,10 @@ var (
 
 // getSubscriptionWithPlan retrieves subscription with plan for an organization
 func (s *featureService) getSubscriptionWithPlan(ctx context.Context, orgID uint) (*domain.Subscription, error) {
+	if sub, ok := s.licensedSubscription(ctx, orgID); ok {
+		return sub, nil
+	}
+
 	sub, err := s.subRepo.GetByOrganizationID(ctx, orgID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get subscription: %w", err)
@@ -137,6 +151,11 @@ func (s *featureService) CanCreateCollection(ctx context.Context, orgID uint) (b
 
 // CanCreateItem checks if organization can create new items
 func (s *featureService) CanCreateItem(ctx context.Context, orgID uint) (bool, error) {
+	return s.CanCreateItems(ctx, orgID, 1)
+}
+
+// CanCreateItems checks if organization can create count new items at once
+func (s *featureService) CanCreateItems(ctx context.Context, orgID uint, count int) (bool, error) {
 	sub, err := s.getSubscriptionWithPlan(ctx, orgID)
 	if err != nil {
 		return false, err
@@ -149,7 +168,7 @@ func (s *featureService) CanCreateItem(ctx context.Context, orgID uint) (bool, e
 			return false, fmt.Errorf("failed to get item count: %w", err)
 		}
 
-		if currentItems >= *sub.Plan.MaxItems {
+		if currentItems+count > *sub.Plan.MaxItems {
 			return false, ErrPlanLimitReached
 		}
 	}
@@ -213,8 +232,19 @@ func (s *featureService) CanUseEmergencyAccess(ctx context.Context, orgID uint)
 	})
 }
 
+// CanUseSecurityInsights checks if organization can use security insights (vault health reports)
+func (s *featureService) CanUseSecurityInsights(ctx context.Context, orgID uint) (bool, error) {
+	return s.checkBooleanFeature(ctx, orgID, func(f domain.PlanFeatures) bool {
+		return f.SecurityInsights
+	})
+}
+
 // GetFeatures returns all features available to an organization
 func (s *featureService) GetFeatures(ctx context.Context, orgID uint) (*domain.PlanFeatures, error) {
+	if sub, ok := s.licensedSubscription(ctx, orgID); ok {
+		return &sub.Plan.Features, nil
+	}
+
 	sub, err := s.subRepo.GetByOrganizationID(ctx, orgID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get subscription: %w", err)
@@ -227,6 +257,15 @@ func (s *featureService) GetFeatures(ctx context.Context, orgID uint) (*domain.P
 	return &sub.Plan.Features, nil
 }
 
+// licensedSubscription returns license entitlements for the organization, if any.
+// Licenses in their grace period still apply; expired licenses fall back to billing.
+func (s *featureService) licensedSubscription(ctx context.Context, orgID uint) (*domain.Subscription, bool) {
+	if s.licenses == nil {
+		return nil, false
+	}
+	return s.licenses.SubscriptionForOrg(ctx, orgID)
+}
+
 func (s *featureService) checkBooleanFeature(
 	ctx context.Context,
 	orgID uint,
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/passwall/passwall-server/internal/authz"
@@ -54,6 +55,8 @@ type UserService interface {
 	GetByID(ctx context.Context, id uint) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	List(ctx context.Context) ([]*domain.User, error)
+	ListDirectory(ctx context.Context, filter repository.UserDirectoryFilter) ([]*domain.User, *repository.ListResult, error)
+	ExportDirectoryCSV(ctx context.Context, filter repository.UserDirectoryFilter, w io.Writer) (int, error)
 	Create(ctx context.Context, user *domain.User) error
 	CreateByAdmin(ctx context.Context, req *domain.CreateUserByAdminRequest) (*domain.User, error)
 	Update(ctx context.Context, id uint, user *domain.User) error
@@ -94,6 +97,12 @@ type ExcludedDomainService interface {
 	Delete(ctx context.Context, id uint, userID uint) error
 	DeleteByDomain(ctx context.Context, userID uint, domain string) error
 	IsExcluded(ctx context.Context, userID uint, domain string) (bool, error)
+	Check(ctx context.Context, userID uint, domain string) (*domain.ExcludedDomainCheck, error)
+
+	// Organization-wide blocklist (managed by org admins, applied to all members)
+	ListForOrganization(ctx context.Context, orgID, userID uint) ([]*domain.OrganizationExcludedDomain, error)
+	CreateForOrganization(ctx context.Context, orgID, userID uint, req *domain.CreateOrganizationExcludedDomainRequest) (*domain.OrganizationExcludedDomain, error)
+	DeleteForOrganization(ctx context.Context, orgID, userID, id uint) error
 }
 
 // CompatTelemetryService defines ingest logic for compatibility telemetry events.
@@ -126,11 +135,19 @@ type OrganizationService interface {
 	GetMembership(ctx context.Context, userID uint, orgID uint) (*domain.OrganizationUser, error)
 	UpdateMemberRole(ctx context.Context, orgID, orgUserID uint, requestingUserID uint, req *domain.UpdateOrgUserRoleRequest) error
 	RemoveMember(ctx context.Context, orgID, orgUserID uint, requestingUserID uint) error
+	RemoveSuspendedMember(ctx context.Context, orgID, orgUserID uint) error
 	AcceptInvitation(ctx context.Context, orgUserID uint, userID uint, encryptedOrgKey string) error
 	ConfirmProvisionedMember(ctx context.Context, orgID, orgUserID uint, requestingUserID uint, encryptedOrgKey string) error
 	AddExistingMember(ctx context.Context, orgUser *domain.OrganizationUser) error
 	DeclineInvitationForUser(ctx context.Context, orgID uint, userID uint) error
 
+	// Invitation lifecycle
+	ListInvitations(ctx context.Context, orgID uint, requestingUserID uint) ([]*domain.OrganizationInvitationDTO, error)
+	ResendInvitation(ctx context.Context, orgID, orgUserID uint, requestingUserID uint) (*domain.OrganizationInvitationDTO, error)
+	RevokeInvitation(ctx context.Context, orgID, orgUserID uint, requestingUserID uint) error
+	BulkInvite(ctx context.Context, orgID uint, requestingUserID uint, rows []domain.BulkInviteRow) (*domain.BulkInviteResponse, error)
+	RemoveExpiredInvitations(ctx context.Context) (int, error)
+
 	// Statistics
 	GetMemberCount(ctx context.Context, orgID uint) (int, error)
 	GetCollectionCount(ctx context.Context, orgID uint) (int, error)
@@ -155,10 +172,14 @@ type TeamService interface {
 type CollectionService interface {
 	Create(ctx context.Context, orgID uint, userID uint, req *domain.CreateCollectionRequest) (*domain.Collection, error)
 	GetByID(ctx context.Context, id uint, userID uint) (*domain.Collection, error)
+	// ListByOrganization and ListForUser return the visible collections as a tree of roots
 	ListByOrganization(ctx context.Context, orgID uint, userID uint) ([]*domain.Collection, error)
 	ListForUser(ctx context.Context, orgID uint, userID uint) ([]*domain.Collection, error)
 	Update(ctx context.Context, id uint, userID uint, req *domain.UpdateCollectionRequest) (*domain.Collection, error)
+	// Delete removes the collection and its whole subtree
 	Delete(ctx context.Context, id uint, userID uint) error
+	// Move re-parents the collection and its subtree; a nil parent moves it to the top level
+	Move(ctx context.Context, id uint, userID uint, req *domain.MoveCollectionRequest) (*domain.Collection, error)
 
 	// Access management
 	GrantUserAccess(ctx context.Context, collectionID uint, orgUserID uint, requestingUserID uint, req *domain.GrantCollectionAccessRequest) error
@@ -178,6 +199,8 @@ type OrganizationItemService interface {
 	Update(ctx context.Context, id, userID uint, req *UpdateOrgItemRequest) (*domain.OrganizationItem, error)
 	Delete(ctx context.Context, id, userID uint) (*domain.OrganizationItem, error)
 	GetCollectionAccess(ctx context.Context, orgID, userID, collectionID uint) (*authz.CollectionAccess, error)
+	// GetItemAccess returns the user's access to an item through any of its collections
+	GetItemAccess(ctx context.Context, item *domain.OrganizationItem, userID uint) (*authz.CollectionAccess, error)
 	GetAutofillSecret(ctx context.Context, itemID, userID uint) (*domain.OrganizationItem, error)
 }
 
@@ -191,13 +214,24 @@ type ItemShareService interface {
 	UpdateSharedItem(ctx context.Context, userID uint, shareUUID string, req *UpdateSharedItemRequest) (*domain.OrganizationItem, error)
 	UpdatePermissions(ctx context.Context, ownerID uint, shareUUID string, req *UpdateItemSharePermissionsRequest) (*ItemShareWithItem, error)
 	ReShare(ctx context.Context, userID uint, shareUUID string, req *CreateItemShareRequest) (*ItemShareWithItem, error)
+	// ListPending returns the owner's shares to emails that had no account yet
+	ListPending(ctx context.Context, ownerID uint) ([]*ItemShareWithItem, error)
+	// CompletePending stores the item key wrapped for the recipient who signed up
+	CompletePending(ctx context.Context, ownerID uint, shareUUID, encryptedKey string) (*ItemShareWithItem, error)
+	// NotifyExpiringShares emails owner and recipient of shares expiring within the window
+	NotifyExpiringShares(ctx context.Context, within time.Duration) error
+	// RevokeExpiredShares revokes expired shares and removes their EncryptedKey
+	RevokeExpiredShares(ctx context.Context) error
 }
 
 // PaymentService defines the business logic for Stripe payments (Organization level)
 type PaymentService interface {
 	// Checkout & Subscriptions
-	CreateCheckoutSession(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int, ipAddress, userAgent string) (string, error)
-	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+	CreateCheckoutSession(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int, promoCode, ipAddress, userAgent string) (string, error)
+
+	// Webhooks (verified and processed through the webhook inbox)
+	ParseWebhook(payload []byte, signature string) (eventID, eventType string, err error)
+	ProcessWebhookEvent(ctx context.Context, payload []byte) error
 
 	// Subscription Management
 	GetBillingInfo(ctx context.Context, orgID uint) (*domain.BillingInfo, error)
@@ -208,6 +242,9 @@ type PaymentService interface {
 	UpdateSubscriptionSeats(ctx context.Context, orgID, userID uint, seats int, ipAddress, userAgent string) error
 
 	// Plan change (inline subscription update for existing subscribers)
-	PreviewPlanChange(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int) (*domain.PlanChangePreview, error)
-	ChangePlan(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int, ipAddress, userAgent string) (*domain.PlanChangeResult, error)
+	PreviewPlanChange(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int, promoCode string) (*domain.PlanChangePreview, error)
+	ChangePlan(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int, promoCode, ipAddress, userAgent string) (*domain.PlanChangeResult, error)
+
+	// Promotions
+	ValidatePromoCode(ctx context.Context, orgID, userID uint, code, plan string) (*domain.DiscountDTO, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,12 +19,25 @@ type InvitationService interface {
 	GetSentInvitations(ctx context.Context, userID uint) ([]*domain.Invitation, error)
 	AcceptInvitation(ctx context.Context, invitationID uint, userID uint) error
 	DeclineInvitation(ctx context.Context, invitationID uint, userID uint) error
+
+	// GetOrganizationInvitation returns the newest unused organization invitation for an email, even if expired
+	GetOrganizationInvitation(ctx context.Context, orgID uint, email string) (*domain.Invitation, error)
+	// RenewInvitation issues a new code and expiry for an invitation and emails it again
+	RenewInvitation(ctx context.Context, invitation *domain.Invitation, inviterName string) error
+	// RevokeOrganizationInvitations deletes the unused organization invitations of an email
+	RevokeOrganizationInvitations(ctx context.Context, orgID uint, email string) error
+	// ListExpired returns unused invitations past their expiry
+	ListExpired(ctx context.Context) ([]*domain.Invitation, error)
+	DeleteInvitation(ctx context.Context, invitationID uint) error
 }
 
 type invitationService struct {
-	repo         repository.InvitationRepository
-	userRepo     repository.UserRepository
-	orgRepo      repository.OrganizationRepository
+	repo     repository.InvitationRepository
+	userRepo repository.UserRepository
+	orgRepo  repository.OrganizationRepository
+	prefRepo interface {
+		ListByOwner(ctx context.Context, ownerType string, ownerID uint, section string) ([]*domain.Preference, error)
+	}
 	emailSender  email.Sender
 	emailBuilder *email.EmailBuilder
 	logger       Logger
@@ -34,6 +48,9 @@ func NewInvitationService(
 	repo repository.InvitationRepository,
 	userRepo repository.UserRepository,
 	orgRepo repository.OrganizationRepository,
+	prefRepo interface {
+		ListByOwner(ctx context.Context, ownerType string, ownerID uint, section string) ([]*domain.Preference, error)
+	},
 	emailSender email.Sender,
 	emailBuilder *email.EmailBuilder,
 	logger Logger,
@@ -42,6 +59,7 @@ func NewInvitationService(
 		repo:         repo,
 		userRepo:     userRepo,
 		orgRepo:      orgRepo,
+		prefRepo:     prefRepo,
 		emailSender:  emailSender,
 		emailBuilder: emailBuilder,
 		logger:       logger,
@@ -94,12 +112,13 @@ func (s *invitationService) CreateInvitation(ctx context.Context, req *domain.Cr
 	}
 
 	// Create invitation
+	expiry := s.expiry(ctx, req.OrganizationID)
 	invitation := &domain.Invitation{
 		Email:           req.Email,
 		Code:            code,
 		RoleID:          req.RoleID,
 		CreatedBy:       createdBy,
-		ExpiresAt:       time.Now().Add(7 * 24 * time.Hour), // 7 days
+		ExpiresAt:       time.Now().Add(expiry),
 		OrganizationID:  req.OrganizationID,
 		OrgRole:         req.OrgRole,
 		EncryptedOrgKey: req.EncryptedOrgKey,
@@ -113,33 +132,7 @@ func (s *invitationService) CreateInvitation(ctx context.Context, req *domain.Cr
 
 	// Send invitation email (async)
 	go func() {
-		emailCtx := context.Background()
-		roleName := getRoleName(req.RoleID)
-
-		// Get organization name if this is an org invitation
-		orgName := ""
-		if req.OrganizationID != nil {
-			if org, err := s.orgRepo.GetByID(emailCtx, *req.OrganizationID); err == nil {
-				orgName = org.Name
-			}
-		}
-
-		// Build invitation email message
-		var message *email.EmailMessage
-		var err error
-		if orgName != "" {
-			message, err = s.emailBuilder.BuildInvitationWithOrgEmail(req.Email, inviterName, code, roleName, orgName)
-		} else {
-			message, err = s.emailBuilder.BuildInvitationEmail(req.Email, inviterName, code, roleName)
-		}
-
-		if err != nil {
-			s.logger.Error("failed to build invitation email", "email", req.Email, "error", err)
-			return
-		}
-
-		// Send email
-		if err := s.emailSender.Send(emailCtx, message); err != nil {
+		if err := s.sendInvitationEmail(context.Background(), invitation, inviterName, expiry); err != nil {
 			s.logger.Error("failed to send invitation email", "email", req.Email, "error", err)
 		}
 	}()
@@ -248,6 +241,108 @@ func (s *invitationService) DeclineInvitation(ctx context.Context, invitationID
 	return nil
 }
 
+func (s *invitationService) GetOrganizationInvitation(ctx context.Context, orgID uint, email string) (*domain.Invitation, error) {
+	return s.repo.GetLatestByOrgAndEmail(ctx, orgID, email)
+}
+
+func (s *invitationService) RenewInvitation(ctx context.Context, invitation *domain.Invitation, inviterName string) error {
+	if invitation.IsUsed() {
+		return fmt.Errorf("invitation already used")
+	}
+
+	// A new code invalidates links from earlier emails
+	code, err := generateInvitationCode()
+	if err != nil {
+		return fmt.Errorf("failed to generate invitation code: %w", err)
+	}
+	expiry := s.expiry(ctx, invitation.OrganizationID)
+	invitation.Code = code
+	invitation.ExpiresAt = time.Now().Add(expiry)
+
+	if err := s.repo.Update(ctx, invitation); err != nil {
+		return fmt.Errorf("failed to renew invitation: %w", err)
+	}
+	if err := s.sendInvitationEmail(ctx, invitation, inviterName, expiry); err != nil {
+		return fmt.Errorf("failed to send invitation email: %w", err)
+	}
+
+	s.logger.Info("invitation renewed",
+		"invitation_id", invitation.ID,
+		"email", invitation.Email,
+		"expires_at", invitation.ExpiresAt)
+
+	return nil
+}
+
+func (s *invitationService) RevokeOrganizationInvitations(ctx context.Context, orgID uint, email string) error {
+	if err := s.repo.DeleteByOrgAndEmail(ctx, orgID, email); err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	return nil
+}
+
+func (s *invitationService) ListExpired(ctx context.Context) ([]*domain.Invitation, error) {
+	invitations, err := s.repo.ListExpired(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (s *invitationService) DeleteInvitation(ctx context.Context, invitationID uint) error {
+	return s.repo.Delete(ctx, invitationID)
+}
+
+// expiry returns how long a new invitation stays valid. Organization invitations
+// follow the organization's invitation_expiry_days setting.
+func (s *invitationService) expiry(ctx context.Context, orgID *uint) time.Duration {
+	days := domain.DefaultInvitationExpiryDays
+	if orgID == nil || s.prefRepo == nil {
+		return time.Duration(days) * 24 * time.Hour
+	}
+
+	prefs, err := s.prefRepo.ListByOwner(ctx, domain.OrgSettingOwnerType, *orgID, domain.OrgSettingSectionMembers)
+	if err != nil {
+		s.logger.Warn("failed to load invitation settings, using defaults", "org_id", *orgID, "error", err)
+	}
+	for _, p := range prefs {
+		if p.Key != domain.OrgSettingKeyInvitationExpiryDays {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(p.Value)); err == nil && n > 0 {
+			days = n
+		}
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// sendInvitationEmail emails an invitation's code to the invitee
+func (s *invitationService) sendInvitationEmail(ctx context.Context, invitation *domain.Invitation, inviterName string, expiry time.Duration) error {
+	roleName := getRoleName(invitation.RoleID)
+
+	// Get organization name if this is an org invitation
+	orgName := ""
+	if invitation.OrganizationID != nil {
+		if org, err := s.orgRepo.GetByID(ctx, *invitation.OrganizationID); err == nil {
+			orgName = org.Name
+		}
+	}
+
+	// Build invitation email message
+	var message *email.EmailMessage
+	var err error
+	if orgName != "" {
+		message, err = s.emailBuilder.BuildInvitationWithOrgEmail(invitation.Email, inviterName, invitation.Code, roleName, orgName, expiry)
+	} else {
+		message, err = s.emailBuilder.BuildInvitationEmail(invitation.Email, inviterName, invitation.Code, roleName, expiry)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to build invitation email: %w", err)
+	}
+
+	return s.emailSender.Send(ctx, message)
+}
+
 // generateInvitationCode generates a secure random invitation code
 func generateInvitationCode() (string, error) {
 	const charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// fakeInvitationRepo implements the parts of repository.InvitationRepository used by renewals
+type fakeInvitationRepo struct {
+	repository.InvitationRepository
+	updated []*domain.Invitation
+}
+
+func (f *fakeInvitationRepo) Update(_ context.Context, inv *domain.Invitation) error {
+	f.updated = append(f.updated, inv)
+	return nil
+}
+
+func newTestInvitationService(t *testing.T, prefs []*domain.Preference) (*invitationService, *fakeInvitationRepo, *fakeEmailSender) {
+	t.Helper()
+
+	orgs := &fakeOrgRepo{orgs: map[uint]*domain.Organization{}}
+	orgs.add(&domain.Organization{ID: 1, Name: "Acme"})
+	repo := &fakeInvitationRepo{}
+	sender := &fakeEmailSender{}
+	builder, err := email.NewEmailBuilder("https://vault.example.com", "noreply@example.com")
+	require.NoError(t, err)
+
+	svc := NewInvitationService(repo, nil, orgs, &fakeMemberPrefRepo{prefs: prefs}, sender, builder, noopLogger{})
+	return svc.(*invitationService), repo, sender
+}
+
+func TestInvitationService_Expiry(t *testing.T) {
+	ctx := context.Background()
+	orgID := uint(1)
+
+	svc, _, _ := newTestInvitationService(t, nil)
+	assert.Equal(t, 7*24*time.Hour, svc.expiry(ctx, nil))
+	assert.Equal(t, 7*24*time.Hour, svc.expiry(ctx, &orgID))
+
+	svc, _, _ = newTestInvitationService(t, []*domain.Preference{{Key: domain.OrgSettingKeyInvitationExpiryDays, Value: "14"}})
+	assert.Equal(t, 14*24*time.Hour, svc.expiry(ctx, &orgID))
+	assert.Equal(t, 7*24*time.Hour, svc.expiry(ctx, nil), "platform invitations ignore org settings")
+
+	svc, _, _ = newTestInvitationService(t, []*domain.Preference{{Key: domain.OrgSettingKeyInvitationExpiryDays, Value: "0"}})
+	assert.Equal(t, 7*24*time.Hour, svc.expiry(ctx, &orgID), "invalid values fall back to the default")
+}
+
+func TestInvitationService_RenewInvitation(t *testing.T) {
+	ctx := context.Background()
+	orgID := uint(1)
+	svc, repo, sender := newTestInvitationService(t, []*domain.Preference{{Key: domain.OrgSettingKeyInvitationExpiryDays, Value: "3"}})
+
+	inv := &domain.Invitation{
+		ID:             5,
+		Email:          "bob@example.com",
+		Code:           "old-code",
+		RoleID:         2,
+		ExpiresAt:      time.Now().Add(-time.Hour),
+		OrganizationID: &orgID,
+	}
+	require.True(t, inv.IsExpired())
+
+	require.NoError(t, svc.RenewInvitation(ctx, inv, "Alice"))
+	assert.NotEqual(t, "old-code", inv.Code)
+	assert.False(t, inv.IsExpired())
+	assert.WithinDuration(t, time.Now().Add(3*24*time.Hour), inv.ExpiresAt, time.Minute)
+	require.Len(t, repo.updated, 1)
+
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "bob@example.com", sender.sent[0].To)
+	assert.Equal(t, "Join Acme on Passwall", sender.sent[0].Subject)
+	assert.Contains(t, sender.sent[0].Body, inv.Code)
+	assert.Contains(t, sender.sent[0].Body, "3 days")
+
+	usedAt := time.Now()
+	inv.UsedAt = &usedAt
+	assert.Error(t, svc.RenewInvitation(ctx, inv, "Alice"))
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckBatchSize(t *testing.T) {
+	t.Parallel()
+	assert.ErrorIs(t, checkBatchSize(0), ErrBulkEmpty)
+	assert.NoError(t, checkBatchSize(1))
+	assert.NoError(t, checkBatchSize(domain.MaxBulkItems))
+	assert.ErrorIs(t, checkBatchSize(domain.MaxBulkItems+1), ErrBulkTooManyItems)
+}
+
+func TestUniqueIDs_KeepsFirstOccurrence(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []uint{3, 1, 2}, uniqueIDs([]uint{3, 1, 3, 2, 1}))
+	assert.Empty(t, uniqueIDs(nil))
+}
+
+func TestIDResults_ReportsPerItemErrors(t *testing.T) {
+	t.Parallel()
+	results := idResults([]uint{10, 11, 12, 13}, map[uint]error{
+		11: repository.ErrNotFound,
+		12: errBulkSkipped,
+		13: errors.New("pq: duplicate key value violates unique constraint"),
+	})
+
+	resp := domain.NewBulkItemResponse(results)
+	assert.Equal(t, 1, resp.Succeeded)
+	assert.Equal(t, 3, resp.Failed)
+
+	assert.True(t, results[0].Success)
+	assert.Equal(t, uint(10), results[0].ID)
+	assert.Equal(t, "item not found", results[1].Error)
+	assert.Equal(t, "no change needed", results[2].Error)
+	// Driver errors must not leak to the client
+	assert.Equal(t, "failed to save item", results[3].Error)
+	assert.Equal(t, 3, results[3].Index)
+}
+
+func TestBulkErrorMessage_InvalidRequest(t *testing.T) {
+	t.Parallel()
+	err := fmt.Errorf("%w: collection not found", ErrInvalidBulkRequest)
+	assert.Equal(t, "invalid bulk request: collection not found", bulkErrorMessage(err))
+	assert.Equal(t, "access denied", bulkErrorMessage(repository.ErrForbidden))
+}
@@ -71,38 +71,9 @@ type ItemListResponse struct {
 
 // Create implements ItemService
 func (s *itemService) Create(ctx context.Context, schema string, req *CreateItemRequest) (*domain.Item, error) {
-	// Validate item type
-	if !req.ItemType.IsValid() {
-		return nil, fmt.Errorf("invalid item type: %d", req.ItemType)
-	}
-
-	// Validate metadata
-	if err := s.validateMetadata(req.Metadata); err != nil {
-		return nil, fmt.Errorf("invalid metadata: %w", err)
-	}
-
-	// Set defaults for auto-fill/auto-login
-	autoFill := true
-	autoLogin := false
-	if req.AutoFill != nil {
-		autoFill = *req.AutoFill
-	}
-	if req.AutoLogin != nil {
-		autoLogin = *req.AutoLogin
-	}
-
-	// Create item
-	item := &domain.Item{
-		UUID:       uuid.New(),
-		ItemType:   req.ItemType,
-		Data:       req.Data,
-		ItemKeyEnc: req.ItemKeyEnc,
-		Metadata:   req.Metadata,
-		IsFavorite: req.IsFavorite,
-		FolderID:   req.FolderID,
-		Reprompt:   req.Reprompt,
-		AutoFill:   autoFill,
-		AutoLogin:  autoLogin,
+	item, err := newItemFromRequest(req)
+	if err != nil {
+		return nil, err
 	}
 
 	// Store in repository
@@ -190,7 +161,7 @@ func (s *itemService) Update(ctx context.Context, schema string, id uint, req *U
 		item.ItemKeyEnc = req.ItemKeyEnc
 	}
 	if req.Metadata != nil {
-		if err := s.validateMetadata(*req.Metadata); err != nil {
+		if err := validateItemMetadata(*req.Metadata); err != nil {
 			return nil, fmt.Errorf("invalid metadata: %w", err)
 		}
 		item.Metadata = *req.Metadata
@@ -241,8 +212,47 @@ func (s *itemService) HardDelete(ctx context.Context, schema string, id uint) er
 	return nil
 }
 
-// validateMetadata validates metadata fields
-func (s *itemService) validateMetadata(metadata domain.ItemMetadata) error {
+// newItemFromRequest validates a create request and builds the item
+func newItemFromRequest(req *CreateItemRequest) (*domain.Item, error) {
+	// Validate item type
+	if !req.ItemType.IsValid() {
+		return nil, fmt.Errorf("invalid item type: %d", req.ItemType)
+	}
+
+	// Validate metadata
+	if err := validateItemMetadata(req.Metadata); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
+
+	// Set defaults for auto-fill/auto-login
+	autoFill := true
+	autoLogin := false
+	if req.AutoFill != nil {
+		autoFill = *req.AutoFill
+	}
+	if req.AutoLogin != nil {
+		autoLogin = *req.AutoLogin
+	}
+
+	// Create item
+	item := &domain.Item{
+		UUID:       uuid.New(),
+		ItemType:   req.ItemType,
+		Data:       req.Data,
+		ItemKeyEnc: req.ItemKeyEnc,
+		Metadata:   req.Metadata,
+		IsFavorite: req.IsFavorite,
+		FolderID:   req.FolderID,
+		Reprompt:   req.Reprompt,
+		AutoFill:   autoFill,
+		AutoLogin:  autoLogin,
+	}
+
+	return item, nil
+}
+
+// validateItemMetadata validates metadata fields
+func validateItemMetadata(metadata domain.ItemMetadata) error {
 	// Name is required
 	if metadata.Name == "" {
 		return fmt.Errorf("metadata name is required")
@@ -13,8 +13,18 @@ import (
 	"github.com/passwall/passwall-server/internal/repository"
 )
 
-// ErrShareInviteSent indicates a signup email was sent for non-registered recipient.
-var ErrShareInviteSent = errors.New("share invite email sent")
+var (
+	// ErrExternalSharingDisabled is returned when an organization policy of the owner
+	// prohibits sharing with people who have no account
+	ErrExternalSharingDisabled = errors.New("sharing with people outside the organization is disabled by policy")
+	// ErrSharePendingExists is returned when the item is already pending for the email
+	ErrSharePendingExists = errors.New("item is already shared with this email")
+	// ErrShareNotPending is returned when completing a share that is not pending
+	ErrShareNotPending = errors.New("share is not pending")
+	// ErrShareRecipientNotReady is returned when the pending recipient has not signed
+	// up, verified their email or published a public key yet
+	ErrShareRecipientNotReady = errors.New("recipient has not set up their account yet")
+)
 
 // CreateItemShareRequest represents a request to share an organization item.
 type CreateItemShareRequest struct {
@@ -47,32 +57,45 @@ type UpdateItemSharePermissionsRequest struct {
 type ItemShareWithItem struct {
 	Share *domain.ItemShare
 	Item  *domain.OrganizationItem
+	// Recipient is the account registered for a pending share's email, if any.
+	// Once it has an RSA public key the owner can complete the share.
+	Recipient *domain.User
 }
 
 type itemShareService struct {
-	shareRepo    repository.ItemShareRepository
-	orgItemRepo  repository.OrganizationItemRepository
-	userRepo     repository.UserRepository
-	emailSender  email.Sender
-	emailBuilder *email.EmailBuilder
-	logger       Logger
+	shareRepo   repository.ItemShareRepository
+	orgItemRepo repository.OrganizationItemRepository
+	userRepo    repository.UserRepository
+	orgUserRepo interface {
+		ListByUser(ctx context.Context, userID uint) ([]*domain.OrganizationUser, error)
+	}
+	policyEnforcement PolicyEnforcementService
+	emailSender       email.Sender
+	emailBuilder      *email.EmailBuilder
+	logger            Logger
 }
 
 func NewItemShareService(
 	shareRepo repository.ItemShareRepository,
 	orgItemRepo repository.OrganizationItemRepository,
 	userRepo repository.UserRepository,
+	orgUserRepo interface {
+		ListByUser(ctx context.Context, userID uint) ([]*domain.OrganizationUser, error)
+	},
+	policyEnforcement PolicyEnforcementService,
 	emailSender email.Sender,
 	emailBuilder *email.EmailBuilder,
 	logger Logger,
 ) ItemShareService {
 	return &itemShareService{
-		shareRepo:    shareRepo,
-		orgItemRepo:  orgItemRepo,
-		userRepo:     userRepo,
-		emailSender:  emailSender,
-		emailBuilder: emailBuilder,
-		logger:       logger,
+		shareRepo:         shareRepo,
+		orgItemRepo:       orgItemRepo,
+		userRepo:          userRepo,
+		orgUserRepo:       orgUserRepo,
+		policyEnforcement: policyEnforcement,
+		emailSender:       emailSender,
+		emailBuilder:      emailBuilder,
+		logger:            logger,
 	}
 }
 
@@ -92,12 +115,15 @@ func (s *itemShareService) Create(ctx context.Context, ownerID uint, req *Create
 		return nil, err
 	}
 
-	return s.createShareInternal(ctx, ownerID, item, req)
+	return s.createShareInternal(ctx, ownerID, ownerID, item, req)
 }
 
+// createShareInternal shares item on behalf of ownerID. actorID is the user making
+// the request, which differs from the owner for re-shares.
 func (s *itemShareService) createShareInternal(
 	ctx context.Context,
 	ownerID uint,
+	actorID uint,
 	item *domain.OrganizationItem,
 	req *CreateItemShareRequest,
 ) (*ItemShareWithItem, error) {
@@ -106,29 +132,7 @@ func (s *itemShareService) createShareInternal(
 	if sharedWithUserID == nil && req.SharedWithEmail != "" {
 		user, err := s.userRepo.GetByEmail(ctx, req.SharedWithEmail)
 		if err != nil || user == nil {
-			if s.emailSender == nil || s.emailBuilder == nil {
-				return nil, repository.ErrNotFound
-			}
-			owner, ownerErr := s.userRepo.GetByID(ctx, ownerID)
-			if ownerErr != nil || owner == nil {
-				return nil, repository.ErrNotFound
-			}
-			itemName := item.Metadata.Name
-			if strings.TrimSpace(itemName) == "" {
-				itemName = "Shared item"
-			}
-			message, buildErr := s.emailBuilder.BuildShareInviteEmail(
-				req.SharedWithEmail,
-				owner.Name,
-				itemName,
-			)
-			if buildErr != nil {
-				return nil, fmt.Errorf("failed to build share invite email: %w", buildErr)
-			}
-			if sendErr := s.emailSender.Send(ctx, message); sendErr != nil {
-				return nil, fmt.Errorf("failed to send share invite email: %w", sendErr)
-			}
-			return nil, ErrShareInviteSent
+			return s.createPendingShare(ctx, ownerID, actorID, item, req)
 		}
 		sharedWithUserID = &user.ID
 		sharedWithUser = user
@@ -194,6 +198,101 @@ func (s *itemShareService) createShareInternal(
 	return &ItemShareWithItem{Share: share, Item: item}, nil
 }
 
+// createPendingShare records a share for an email without an account and invites the
+// recipient to sign up. No key is stored until the owner completes the share.
+func (s *itemShareService) createPendingShare(
+	ctx context.Context,
+	ownerID uint,
+	actorID uint,
+	item *domain.OrganizationItem,
+	req *CreateItemShareRequest,
+) (*ItemShareWithItem, error) {
+	if s.emailSender == nil || s.emailBuilder == nil {
+		return nil, repository.ErrNotFound
+	}
+	pendingEmail := strings.ToLower(strings.TrimSpace(req.SharedWithEmail))
+	if !strings.Contains(pendingEmail, "@") {
+		return nil, repository.ErrInvalidInput
+	}
+
+	for _, userID := range []uint{actorID, ownerID} {
+		if err := s.checkExternalSharingAllowed(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	owner, err := s.userRepo.GetByID(ctx, ownerID)
+	if err != nil || owner == nil {
+		return nil, repository.ErrNotFound
+	}
+
+	existing, err := s.shareRepo.ListByItemUUID(ctx, item.UUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.IsPending() && !other.IsExpired() && other.PendingEmail == pendingEmail {
+			return nil, ErrSharePendingExists
+		}
+	}
+
+	share := &domain.ItemShare{
+		UUID:           uuid.New(),
+		ItemUUID:       item.UUID,
+		OrganizationID: item.OrganizationID,
+		OwnerID:        ownerID,
+		PendingEmail:   pendingEmail,
+		CanView:        true,
+		CanEdit:        req.CanEdit != nil && *req.CanEdit,
+		CanShare:       req.CanShare != nil && *req.CanShare,
+		ExpiresAt:      req.ExpiresAt,
+	}
+	if err := s.shareRepo.Create(ctx, share); err != nil {
+		s.logger.Error("failed to create pending item share", "error", err)
+		return nil, fmt.Errorf("failed to create item share: %w", err)
+	}
+
+	itemName := item.Metadata.Name
+	if strings.TrimSpace(itemName) == "" {
+		itemName = "Shared item"
+	}
+	message, err := s.emailBuilder.BuildShareInviteEmail(pendingEmail, owner.Name, itemName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build share invite email: %w", err)
+	}
+	if err := s.emailSender.Send(ctx, message); err != nil {
+		// The share stays pending; the owner can revoke and share again
+		s.logger.Error("failed to send share invite email", "share_id", share.ID, "error", err)
+	}
+
+	return &ItemShareWithItem{Share: share, Item: item}, nil
+}
+
+// checkExternalSharingAllowed applies disable_external_sharing of every organization
+// the user is a managed member of. Fails closed on errors, and when the service was
+// built without the dependencies to check the policy.
+func (s *itemShareService) checkExternalSharingAllowed(ctx context.Context, userID uint) error {
+	if s.orgUserRepo == nil || s.policyEnforcement == nil {
+		return fmt.Errorf("%w: sharing policy cannot be checked", ErrExternalSharingDisabled)
+	}
+	memberships, err := s.orgUserRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list memberships: %w", err)
+	}
+	for _, m := range memberships {
+		if m.Status == domain.OrgUserStatusInvited {
+			continue
+		}
+		if m.Organization != nil && m.Organization.IsPersonal {
+			continue
+		}
+		if err := s.policyEnforcement.CheckExternalSharingAllowed(ctx, m.OrganizationID); err != nil {
+			return fmt.Errorf("%w: %v", ErrExternalSharingDisabled, err)
+		}
+	}
+	return nil
+}
+
 func (s *itemShareService) sendShareNotificationEmail(ownerID uint, recipient *domain.User, item *domain.OrganizationItem) {
 	if recipient == nil || recipient.Email == "" {
 		return
@@ -233,6 +332,9 @@ func (s *itemShareService) ListOwned(ctx context.Context, ownerID uint) ([]*Item
 
 	results := make([]*ItemShareWithItem, 0, len(shares))
 	for _, share := range shares {
+		if share.IsExpired() {
+			continue
+		}
 		item, err := s.orgItemRepo.GetByUUID(ctx, share.ItemUUID.String())
 		if err != nil {
 			if err == repository.ErrNotFound {
@@ -372,13 +474,20 @@ func (s *itemShareService) ReShare(
 	if !share.CanShare {
 		return nil, repository.ErrForbidden
 	}
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		return nil, repository.ErrInvalidInput
+	}
+	// A re-share cannot outlive the share it was made from
+	if share.ExpiresAt != nil && (req.ExpiresAt == nil || req.ExpiresAt.After(*share.ExpiresAt)) {
+		req.ExpiresAt = share.ExpiresAt
+	}
 
 	item, err := s.orgItemRepo.GetByUUID(ctx, share.ItemUUID.String())
 	if err != nil {
 		return nil, err
 	}
 
-	return s.createShareInternal(ctx, share.OwnerID, item, req)
+	return s.createShareInternal(ctx, share.OwnerID, userID, item, req)
 }
 
 func (s *itemShareService) UpdatePermissions(
@@ -395,6 +504,10 @@ func (s *itemShareService) UpdatePermissions(
 	if err != nil {
 		return nil, err
 	}
+	// Expired shares cannot be revived; the owner has to share the item again
+	if share.IsExpired() {
+		return nil, repository.ErrNotFound
+	}
 	if share.OwnerID != ownerID {
 		return nil, repository.ErrForbidden
 	}
@@ -408,11 +521,14 @@ func (s *itemShareService) UpdatePermissions(
 		}
 		if req.ClearExpiresAt {
 			share.ExpiresAt = nil
+			share.ExpiryNoticeSentAt = nil
 		} else if req.ExpiresAt != nil {
 			if req.ExpiresAt.Before(time.Now()) {
 				return nil, repository.ErrInvalidInput
 			}
 			share.ExpiresAt = req.ExpiresAt
+			// A new expiry gets its own notice
+			share.ExpiryNoticeSentAt = nil
 		}
 	}
 
@@ -430,3 +546,184 @@ func (s *itemShareService) UpdatePermissions(
 
 	return &ItemShareWithItem{Share: share, Item: item}, nil
 }
+
+// NotifyExpiringShares emails the owner and the recipient of every share that expires
+// within the given window. Each share is notified once per expiry date.
+func (s *itemShareService) NotifyExpiringShares(ctx context.Context, within time.Duration) error {
+	now := time.Now()
+	shares, err := s.shareRepo.ListExpiring(ctx, now, now.Add(within))
+	if err != nil {
+		return fmt.Errorf("failed to list expiring shares: %w", err)
+	}
+
+	for _, share := range shares {
+		owner, recipient := share.Owner, share.SharedWithUser
+
+		itemName := "Shared item"
+		if item, err := s.orgItemRepo.GetByUUID(ctx, share.ItemUUID.String()); err == nil && strings.TrimSpace(item.Metadata.Name) != "" {
+			itemName = item.Metadata.Name
+		}
+
+		noticeAt := now
+		share.ExpiryNoticeSentAt = &noticeAt
+		if err := s.shareRepo.Update(ctx, share); err != nil {
+			// Log error but continue processing others
+			s.logger.Error("failed to mark share expiry notice", "share_id", share.ID, "error", err)
+			continue
+		}
+
+		if s.emailSender == nil || s.emailBuilder == nil {
+			continue
+		}
+		ownerName, recipientEmail := "", ""
+		if owner != nil {
+			ownerName = owner.Name
+		}
+		if recipient != nil {
+			recipientEmail = recipient.Email
+		}
+		if owner != nil {
+			s.sendExpiringEmail(ctx, share, owner.Email, "owner", ownerName, recipientEmail, itemName)
+		}
+		if recipient != nil {
+			s.sendExpiringEmail(ctx, share, recipient.Email, "recipient", ownerName, recipientEmail, itemName)
+		}
+	}
+
+	if len(shares) > 0 {
+		s.logger.Info("sent share expiry notices", "count", len(shares))
+	}
+	return nil
+}
+
+func (s *itemShareService) sendExpiringEmail(ctx context.Context, share *domain.ItemShare, to, audience, ownerName, recipientEmail, itemName string) {
+	message, err := s.emailBuilder.BuildShareExpiringEmail(to, audience, ownerName, recipientEmail, itemName, *share.ExpiresAt)
+	if err != nil {
+		s.logger.Error("failed to build share expiry email", "share_id", share.ID, "error", err)
+		return
+	}
+	if err := s.emailSender.Send(ctx, message); err != nil {
+		s.logger.Warn("failed to send share expiry email", "share_id", share.ID, "to", to, "error", err)
+	}
+}
+
+// RevokeExpiredShares revokes every share past its expiry and removes the item key
+// that was wrapped for the recipient, so the share can no longer be decrypted.
+func (s *itemShareService) RevokeExpiredShares(ctx context.Context) error {
+	now := time.Now()
+	shares, err := s.shareRepo.ListExpired(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to list expired shares: %w", err)
+	}
+
+	revoked := 0
+	for _, share := range shares {
+		revokedAt := now
+		share.EncryptedKey = ""
+		share.RevokedAt = &revokedAt
+		if err := s.shareRepo.Update(ctx, share); err != nil {
+			// Log error but continue processing others
+			s.logger.Error("failed to revoke expired share", "share_id", share.ID, "error", err)
+			continue
+		}
+		revoked++
+	}
+
+	if revoked > 0 {
+		s.logger.Info("revoked expired shares", "count", revoked)
+	}
+	return nil
+}
+
+// ListPending returns the owner's pending shares. Recipient is set once the invited
+// email has an account, so the owner's client can wrap the item key for it.
+func (s *itemShareService) ListPending(ctx context.Context, ownerID uint) ([]*ItemShareWithItem, error) {
+	shares, err := s.shareRepo.ListByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*ItemShareWithItem, 0)
+	for _, share := range shares {
+		if !share.IsPending() || share.IsExpired() {
+			continue
+		}
+		item, err := s.orgItemRepo.GetByUUID(ctx, share.ItemUUID.String())
+		if err != nil {
+			if err == repository.ErrNotFound {
+				continue
+			}
+			return nil, err
+		}
+		result := &ItemShareWithItem{Share: share, Item: item}
+		if user, err := s.pendingRecipient(ctx, share); err == nil {
+			result.Recipient = user
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// pendingRecipient returns the account that can receive a pending share. Whoever
+// registers the invited email must verify it before the item key is wrapped for them.
+func (s *itemShareService) pendingRecipient(ctx context.Context, share *domain.ItemShare) (*domain.User, error) {
+	user, err := s.userRepo.GetByEmail(ctx, share.PendingEmail)
+	if err != nil || user == nil || !user.IsVerified || user.RSAPublicKey == nil || *user.RSAPublicKey == "" {
+		return nil, ErrShareRecipientNotReady
+	}
+	return user, nil
+}
+
+// CompletePending attaches a pending share to the account that signed up with its email,
+// storing the item key the owner's client wrapped with the recipient's public key
+func (s *itemShareService) CompletePending(ctx context.Context, ownerID uint, shareUUID, encryptedKey string) (*ItemShareWithItem, error) {
+	if strings.TrimSpace(shareUUID) == "" || strings.TrimSpace(encryptedKey) == "" {
+		return nil, repository.ErrInvalidInput
+	}
+
+	share, err := s.shareRepo.GetByUUID(ctx, shareUUID)
+	if err != nil {
+		return nil, err
+	}
+	if share.IsExpired() {
+		return nil, repository.ErrNotFound
+	}
+	if share.OwnerID != ownerID {
+		return nil, repository.ErrForbidden
+	}
+	if !share.IsPending() {
+		return nil, ErrShareNotPending
+	}
+	// The policy may have been enabled after the share was created
+	if err := s.checkExternalSharingAllowed(ctx, ownerID); err != nil {
+		return nil, err
+	}
+
+	recipient, err := s.pendingRecipient(ctx, share)
+	if err != nil {
+		return nil, err
+	}
+	if recipient.ID == ownerID {
+		return nil, repository.ErrInvalidInput
+	}
+
+	share.SharedWithUserID = &recipient.ID
+	share.EncryptedKey = encryptedKey
+	share.PendingEmail = ""
+	if err := s.shareRepo.Update(ctx, share); err != nil {
+		return nil, fmt.Errorf("failed to complete item share: %w", err)
+	}
+	share.SharedWithUser = recipient
+
+	item, err := s.orgItemRepo.GetByUUID(ctx, share.ItemUUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if s.emailSender != nil && s.emailBuilder != nil {
+		go s.sendShareNotificationEmail(ownerID, recipient, item)
+	}
+
+	return &ItemShareWithItem{Share: share, Item: item}, nil
+}
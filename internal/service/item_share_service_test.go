@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// fakeItemShareRepo keeps shares by ID
+type fakeItemShareRepo struct {
+	repository.ItemShareRepository
+	shares map[uint]*domain.ItemShare
+}
+
+func (f *fakeItemShareRepo) Create(_ context.Context, share *domain.ItemShare) error {
+	share.ID = uint(len(f.shares) + 1)
+	f.shares[share.ID] = share
+	return nil
+}
+func (f *fakeItemShareRepo) ListByItemUUID(_ context.Context, itemUUID uuid.UUID) ([]*domain.ItemShare, error) {
+	var out []*domain.ItemShare
+	for _, s := range f.shares {
+		if s.ItemUUID == itemUUID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (f *fakeItemShareRepo) GetByUUID(_ context.Context, id string) (*domain.ItemShare, error) {
+	for _, s := range f.shares {
+		if s.UUID.String() == id {
+			return s, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeItemShareRepo) ListByOwner(_ context.Context, ownerID uint) ([]*domain.ItemShare, error) {
+	var out []*domain.ItemShare
+	for _, s := range f.shares {
+		if s.OwnerID == ownerID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (f *fakeItemShareRepo) ListExpiring(_ context.Context, after, before time.Time) ([]*domain.ItemShare, error) {
+	var out []*domain.ItemShare
+	for _, s := range f.shares {
+		if s.ExpiresAt != nil && s.ExpiresAt.After(after) && !s.ExpiresAt.After(before) && s.ExpiryNoticeSentAt == nil && s.RevokedAt == nil {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (f *fakeItemShareRepo) ListExpired(_ context.Context, now time.Time) ([]*domain.ItemShare, error) {
+	var out []*domain.ItemShare
+	for _, s := range f.shares {
+		if s.ExpiresAt != nil && !s.ExpiresAt.After(now) && s.RevokedAt == nil {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (f *fakeItemShareRepo) Update(_ context.Context, _ *domain.ItemShare) error { return nil }
+
+// fakeSharedItemRepo serves organization items by UUID
+type fakeSharedItemRepo struct {
+	repository.OrganizationItemRepository
+	items map[string]*domain.OrganizationItem
+}
+
+func (f *fakeSharedItemRepo) GetByUUID(_ context.Context, id string) (*domain.OrganizationItem, error) {
+	if item, ok := f.items[id]; ok {
+		return item, nil
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeSharedItemRepo) Update(_ context.Context, _ *domain.OrganizationItem) error { return nil }
+
+type itemShareFixture struct {
+	svc      *itemShareService
+	shares   *fakeItemShareRepo
+	item     *domain.OrganizationItem
+	users    *fakeUserRepo
+	orgUsers *fakeOrgUserRepo
+	policies *mockOrganizationPolicyService
+	sender   *fakeEmailSender
+}
+
+func newItemShareFixture(t *testing.T) *itemShareFixture {
+	t.Helper()
+
+	item := &domain.OrganizationItem{UUID: uuid.New(), OrganizationID: 1, Metadata: domain.ItemMetadata{Name: "Router admin"}}
+	f := &itemShareFixture{
+		shares:   &fakeItemShareRepo{shares: map[uint]*domain.ItemShare{}},
+		item:     item,
+		users:    newFakeUserRepo(),
+		orgUsers: newFakeOrgUserRepo(),
+		policies: &mockOrganizationPolicyService{enabledByType: map[domain.PolicyType]bool{}},
+		sender:   &fakeEmailSender{},
+	}
+	f.users.add(&domain.User{ID: 1, Name: "Ada", Email: "ada@example.com"})
+	f.orgUsers.add(&domain.OrganizationUser{OrganizationID: 1, UserID: 1, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed})
+	builder, err := email.NewEmailBuilder("https://vault.example.com", "noreply@example.com")
+	require.NoError(t, err)
+
+	f.svc = NewItemShareService(
+		f.shares,
+		&fakeSharedItemRepo{items: map[string]*domain.OrganizationItem{item.UUID.String(): item}},
+		f.users,
+		f.orgUsers,
+		NewPolicyEnforcementService(f.policies),
+		f.sender, builder, noopLogger{},
+	).(*itemShareService)
+	return f
+}
+
+// add stores a share from user 1 to user 2 that expires after the given duration
+func (f *itemShareFixture) add(id uint, expiresIn time.Duration) *domain.ItemShare {
+	recipientID := uint(2)
+	expiresAt := time.Now().Add(expiresIn)
+	share := &domain.ItemShare{
+		ID:               id,
+		UUID:             uuid.New(),
+		ItemUUID:         f.item.UUID,
+		OrganizationID:   1,
+		OwnerID:          1,
+		SharedWithUserID: &recipientID,
+		CanView:          true,
+		CanEdit:          true,
+		EncryptedKey:     "4.wrapped",
+		ExpiresAt:        &expiresAt,
+		Owner:            &domain.User{ID: 1, Name: "Ada", Email: "ada@example.com"},
+		SharedWithUser:   &domain.User{ID: 2, Email: "bob@example.com"},
+	}
+	f.shares.shares[id] = share
+	return share
+}
+
+func TestItemShare_ExpiredSharesAreUnusable(t *testing.T) {
+	ctx := context.Background()
+	f := newItemShareFixture(t)
+	expired := f.add(1, -time.Minute)
+	active := f.add(2, time.Hour)
+
+	owned, err := f.svc.ListOwned(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, owned, 1)
+	assert.Equal(t, active.ID, owned[0].Share.ID)
+
+	_, err = f.svc.UpdateSharedItem(ctx, 2, expired.UUID.String(), &UpdateSharedItemRequest{Data: "2.new", Metadata: domain.ItemMetadata{Name: "x"}})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	later := time.Now().Add(24 * time.Hour)
+	_, err = f.svc.UpdatePermissions(ctx, 1, expired.UUID.String(), &UpdateItemSharePermissionsRequest{ExpiresAt: &later})
+	assert.ErrorIs(t, err, repository.ErrNotFound, "expired shares cannot be extended")
+}
+
+func TestItemShare_NotifyAndRevoke(t *testing.T) {
+	ctx := context.Background()
+	f := newItemShareFixture(t)
+	expired := f.add(1, -time.Minute)
+	soon := f.add(2, 2*time.Hour)
+	later := f.add(3, 72*time.Hour)
+
+	require.NoError(t, f.svc.NotifyExpiringShares(ctx, 24*time.Hour))
+	require.Len(t, f.sender.sent, 2)
+	assert.Equal(t, "ada@example.com", f.sender.sent[0].To)
+	assert.Equal(t, "bob@example.com", f.sender.sent[1].To)
+	assert.NotNil(t, soon.ExpiryNoticeSentAt)
+	assert.Nil(t, later.ExpiryNoticeSentAt)
+
+	// Notices are sent once per expiry
+	require.NoError(t, f.svc.NotifyExpiringShares(ctx, 24*time.Hour))
+	assert.Len(t, f.sender.sent, 2)
+
+	require.NoError(t, f.svc.RevokeExpiredShares(ctx))
+	assert.NotNil(t, expired.RevokedAt)
+	assert.Empty(t, expired.EncryptedKey)
+	assert.Nil(t, soon.RevokedAt)
+	assert.Equal(t, "4.wrapped", soon.EncryptedKey)
+}
+
+func TestItemShare_PendingExternalShare(t *testing.T) {
+	ctx := context.Background()
+	f := newItemShareFixture(t)
+
+	canEdit := true
+	result, err := f.svc.Create(ctx, 1, &CreateItemShareRequest{ItemUUID: f.item.UUID.String(), SharedWithEmail: " Carol@Example.com ", CanEdit: &canEdit})
+	require.NoError(t, err)
+	share := result.Share
+	assert.True(t, share.IsPending())
+	assert.Equal(t, "carol@example.com", share.PendingEmail)
+	assert.Empty(t, share.EncryptedKey)
+	require.Len(t, f.sender.sent, 1)
+	assert.Equal(t, "carol@example.com", f.sender.sent[0].To)
+
+	_, err = f.svc.Create(ctx, 1, &CreateItemShareRequest{ItemUUID: f.item.UUID.String(), SharedWithEmail: "carol@example.com"})
+	assert.ErrorIs(t, err, ErrSharePendingExists)
+
+	// Not signed up yet
+	pending, err := f.svc.ListPending(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Nil(t, pending[0].Recipient)
+	_, err = f.svc.CompletePending(ctx, 1, share.UUID.String(), "4.wrapped")
+	assert.ErrorIs(t, err, ErrShareRecipientNotReady)
+
+	// Signed up but the email is not verified: the key is not wrapped for an unproven owner
+	publicKey := "-----BEGIN PUBLIC KEY-----"
+	carol := &domain.User{ID: 3, Email: "carol@example.com", RSAPublicKey: &publicKey}
+	f.users.add(carol)
+	pending, err = f.svc.ListPending(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, pending[0].Recipient)
+	_, err = f.svc.CompletePending(ctx, 1, share.UUID.String(), "4.wrapped")
+	assert.ErrorIs(t, err, ErrShareRecipientNotReady)
+
+	carol.IsVerified = true
+	pending, err = f.svc.ListPending(ctx, 1)
+	require.NoError(t, err)
+	require.NotNil(t, pending[0].Recipient)
+	assert.Equal(t, uint(3), pending[0].Recipient.ID)
+
+	_, err = f.svc.CompletePending(ctx, 2, share.UUID.String(), "4.wrapped")
+	assert.ErrorIs(t, err, repository.ErrForbidden, "only the owner completes a share")
+
+	completed, err := f.svc.CompletePending(ctx, 1, share.UUID.String(), "4.wrapped")
+	require.NoError(t, err)
+	assert.False(t, completed.Share.IsPending())
+	require.NotNil(t, completed.Share.SharedWithUserID)
+	assert.Equal(t, uint(3), *completed.Share.SharedWithUserID)
+	assert.Equal(t, "4.wrapped", completed.Share.EncryptedKey)
+	assert.True(t, completed.Share.CanEdit)
+
+	_, err = f.svc.CompletePending(ctx, 1, share.UUID.String(), "4.wrapped")
+	assert.ErrorIs(t, err, ErrShareNotPending)
+}
+
+func TestItemShare_ExternalSharingPolicy(t *testing.T) {
+	ctx := context.Background()
+	f := newItemShareFixture(t)
+	f.policies.enabledByType[domain.PolicyDisableExternalSharing] = true
+
+	_, err := f.svc.Create(ctx, 1, &CreateItemShareRequest{ItemUUID: f.item.UUID.String(), SharedWithEmail: "carol@example.com"})
+	assert.ErrorIs(t, err, ErrExternalSharingDisabled)
+	assert.Empty(t, f.shares.shares)
+	assert.Empty(t, f.sender.sent)
+}
+
+func TestItemShare_ExternalSharingFailsClosedWithoutPolicyEnforcement(t *testing.T) {
+	ctx := context.Background()
+	f := newItemShareFixture(t)
+	f.svc.policyEnforcement = nil
+
+	_, err := f.svc.Create(ctx, 1, &CreateItemShareRequest{ItemUUID: f.item.UUID.String(), SharedWithEmail: "carol@example.com"})
+	assert.ErrorIs(t, err, ErrExternalSharingDisabled)
+	assert.Empty(t, f.shares.shares)
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/config"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/pkg/license"
+)
+
+var (
+	ErrLicensingNotConfigured = errors.New("licensing is not configured (missing license public key)")
+	ErrInvalidLicense         = errors.New("invalid license file")
+	ErrLicenseExpired         = errors.New("license has expired")
+)
+
+// LicenseService verifies offline signed license files and exposes their
+// entitlements to feature gating.
+type LicenseService interface {
+	// Load reads and verifies the license file from disk. A missing file is not an error.
+	Load(ctx context.Context) error
+	// Upload verifies a license file, persists it and activates it.
+	Upload(ctx context.Context, data []byte) (*domain.LicenseStatusDTO, error)
+	// Status returns the current license status.
+	Status(ctx context.Context) *domain.LicenseStatusDTO
+	// SubscriptionForOrg returns a synthesized subscription when a license
+	// covers the organization and is active or within its grace period.
+	SubscriptionForOrg(ctx context.Context, orgID uint) (*domain.Subscription, bool)
+}
+
+type licenseService struct {
+	publicKey ed25519.PublicKey
+	keyErr    error
+	filePath  string
+	graceDays int
+
+	orgRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	}
+	logger Logger
+
+	mu      sync.RWMutex
+	current *domain.License
+	loadErr error
+	now     func() time.Time
+}
+
+// NewLicenseService creates a new license service
+func NewLicenseService(
+	cfg config.LicenseConfig,
+	orgRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	},
+	logger Logger,
+) LicenseService {
+	s := &licenseService{
+		filePath:  strings.TrimSpace(cfg.File),
+		graceDays: cfg.GraceDays,
+		orgRepo:   orgRepo,
+		logger:    logger,
+		now:       time.Now,
+	}
+	if s.graceDays <= 0 {
+		s.graceDays = domain.DefaultLicenseGraceDays
+	}
+
+	if strings.TrimSpace(cfg.PublicKey) == "" {
+		s.keyErr = ErrLicensingNotConfigured
+	} else if key, err := license.ParsePublicKey(cfg.PublicKey); err != nil {
+		s.keyErr = fmt.Errorf("%w: %v", ErrLicensingNotConfigured, err)
+	} else {
+		s.publicKey = key
+	}
+
+	return s
+}
+
+// Load reads and verifies the license file from disk
+func (s *licenseService) Load(ctx context.Context) error {
+	if s.keyErr != nil {
+		return s.keyErr
+	}
+	if s.filePath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read license file: %w", err)
+	}
+
+	lic, err := s.verify(data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.current = nil
+		s.loadErr = err
+		return err
+	}
+	s.current = lic
+	s.loadErr = nil
+
+	s.logger.Info("license loaded",
+		"license_id", lic.LicenseID,
+		"licensee", lic.Licensee,
+		"plan", lic.PlanCode,
+		"seats", lic.Seats,
+		"state", lic.StateAt(s.now(), s.graceDays),
+		"expires_at", lic.ExpiresAt,
+	)
+	return nil
+}
+
+// Upload verifies, persists and activates a new license file
+func (s *licenseService) Upload(ctx context.Context, data []byte) (*domain.LicenseStatusDTO, error) {
+	if s.keyErr != nil {
+		return nil, s.keyErr
+	}
+
+	lic, err := s.verify(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse licenses that would not grant anything anymore
+	if lic.StateAt(s.now(), s.graceDays) == domain.LicenseStateExpired {
+		return nil, ErrLicenseExpired
+	}
+
+	if s.filePath != "" {
+		if err := writeFileAtomic(s.filePath, data); err != nil {
+			return nil, fmt.Errorf("failed to save license file: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.current = lic
+	s.loadErr = nil
+	s.mu.Unlock()
+
+	s.logger.Info("license updated",
+		"license_id", lic.LicenseID,
+		"licensee", lic.Licensee,
+		"plan", lic.PlanCode,
+		"seats", lic.Seats,
+		"expires_at", lic.ExpiresAt,
+	)
+
+	return domain.ToLicenseStatusDTO(lic, s.now(), s.graceDays), nil
+}
+
+// Status returns the current license status
+func (s *licenseService) Status(ctx context.Context) *domain.LicenseStatusDTO {
+	if s.keyErr != nil {
+		return &domain.LicenseStatusDTO{State: domain.LicenseStateNone, Configured: false}
+	}
+
+	s.mu.RLock()
+	lic, loadErr := s.current, s.loadErr
+	s.mu.RUnlock()
+
+	if loadErr != nil {
+		return &domain.LicenseStatusDTO{
+			State:      domain.LicenseStateInvalid,
+			Configured: true,
+			Error:      loadErr.Error(),
+		}
+	}
+	return domain.ToLicenseStatusDTO(lic, s.now(), s.graceDays)
+}
+
+// SubscriptionForOrg returns the license entitlements for an organization
+func (s *licenseService) SubscriptionForOrg(ctx context.Context, orgID uint) (*domain.Subscription, bool) {
+	s.mu.RLock()
+	lic := s.current
+	s.mu.RUnlock()
+
+	if lic == nil {
+		return nil, false
+	}
+
+	now := s.now()
+	if lic.StateAt(now, s.graceDays) == domain.LicenseStateExpired {
+		return nil, false
+	}
+
+	if len(lic.Organizations) > 0 {
+		org, err := s.orgRepo.GetByID(ctx, orgID)
+		if err != nil || !lic.AppliesTo(org) {
+			return nil, false
+		}
+	}
+
+	return lic.ToSubscription(orgID, now, s.graceDays), true
+}
+
+func (s *licenseService) verify(data []byte) (*domain.License, error) {
+	payload, err := license.Verify(s.publicKey, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLicense, err)
+	}
+
+	var lic domain.License
+	if err := json.Unmarshal(payload, &lic); err != nil {
+		return nil, fmt.Errorf("%w: invalid payload: %v", ErrInvalidLicense, err)
+	}
+	if err := lic.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLicense, err)
+	}
+	return &lic, nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".license-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpName, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, path)
+}
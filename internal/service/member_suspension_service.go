@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/authz"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+var (
+	ErrCannotSuspendOwner   = errors.New("the organization owner cannot be suspended")
+	ErrCannotSuspendSelf    = errors.New("you cannot suspend yourself")
+	ErrMemberNotSuspendable = errors.New("only active members can be suspended")
+	ErrMemberNotSuspended   = errors.New("member is not suspended")
+)
+
+// suspendedRemovalNoticeDays is how long before the automatic removal admins are warned
+const suspendedRemovalNoticeDays = 3
+
+// MemberSuspensionService suspends and restores organization members. Suspension
+// keeps the member's data but revokes their access, escrowed key and sessions.
+// Members suspended longer than the organization's auto_delete_suspended_days
+// setting are removed after their admins were warned.
+type MemberSuspensionService interface {
+	Suspend(ctx context.Context, orgID, orgUserID, requestingUserID uint, req *domain.SuspendOrgUserRequest) (*domain.OrganizationUser, error)
+	Restore(ctx context.Context, orgID, orgUserID, requestingUserID uint) (*domain.OrganizationUser, error)
+	// ProcessSuspendedRemovals warns admins about upcoming removals and removes members past the deadline
+	ProcessSuspendedRemovals(ctx context.Context) error
+}
+
+type memberSuspensionService struct {
+	orgRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	}
+	orgUserRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.OrganizationUser, error)
+		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+		ListSuspended(ctx context.Context) ([]*domain.OrganizationUser, error)
+		Update(ctx context.Context, orgUser *domain.OrganizationUser) error
+	}
+	prefRepo interface {
+		ListByOwner(ctx context.Context, ownerType string, ownerID uint, section string) ([]*domain.Preference, error)
+	}
+	escrowRepo interface {
+		DeleteByUserAndOrg(ctx context.Context, userID, orgID uint) error
+	}
+	tokenRepo interface {
+		Delete(ctx context.Context, userID int) error
+	}
+	seatService         SeatService
+	organizationService interface {
+		RemoveSuspendedMember(ctx context.Context, orgID, orgUserID uint) error
+	}
+	emailSender    email.Sender
+	emailBuilder   *email.EmailBuilder
+	activityLogger *ActivityLogger
+	logger         Logger
+	now            func() time.Time
+}
+
+// NewMemberSuspensionService creates a new member suspension service
+func NewMemberSuspensionService(
+	orgRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	},
+	orgUserRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.OrganizationUser, error)
+		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+		ListSuspended(ctx context.Context) ([]*domain.OrganizationUser, error)
+		Update(ctx context.Context, orgUser *domain.OrganizationUser) error
+	},
+	prefRepo interface {
+		ListByOwner(ctx context.Context, ownerType string, ownerID uint, section string) ([]*domain.Preference, error)
+	},
+	escrowRepo interface {
+		DeleteByUserAndOrg(ctx context.Context, userID, orgID uint) error
+	},
+	tokenRepo interface {
+		Delete(ctx context.Context, userID int) error
+	},
+	seatService SeatService,
+	organizationService interface {
+		RemoveSuspendedMember(ctx context.Context, orgID, orgUserID uint) error
+	},
+	emailSender email.Sender,
+	emailBuilder *email.EmailBuilder,
+	activityService UserActivityService,
+	logger Logger,
+) MemberSuspensionService {
+	return &memberSuspensionService{
+		orgRepo:             orgRepo,
+		orgUserRepo:         orgUserRepo,
+		prefRepo:            prefRepo,
+		escrowRepo:          escrowRepo,
+		tokenRepo:           tokenRepo,
+		seatService:         seatService,
+		organizationService: organizationService,
+		emailSender:         emailSender,
+		emailBuilder:        emailBuilder,
+		activityLogger:      NewActivityLogger(activityService),
+		logger:              logger,
+		now:                 time.Now,
+	}
+}
+
+// markSuspended suspends a membership and restarts the auto-removal clock
+func markSuspended(orgUser *domain.OrganizationUser, reason string, now time.Time) {
+	orgUser.Status = domain.OrgUserStatusSuspended
+	orgUser.SuspendedAt = &now
+	orgUser.SuspensionReason = reason
+	orgUser.RemovalNoticeSentAt = nil
+}
+
+// clearSuspension reactivates a membership
+func clearSuspension(orgUser *domain.OrganizationUser) {
+	orgUser.Status = domain.OrgUserStatusConfirmed
+	orgUser.SuspendedAt = nil
+	orgUser.SuspensionReason = ""
+	orgUser.RemovalNoticeSentAt = nil
+}
+
+func (s *memberSuspensionService) Suspend(ctx context.Context, orgID, orgUserID, requestingUserID uint, req *domain.SuspendOrgUserRequest) (*domain.OrganizationUser, error) {
+	requester, target, err := s.authorize(ctx, orgID, orgUserID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if target.UserID == requester.UserID {
+		return nil, ErrCannotSuspendSelf
+	}
+	if target.IsOwner() {
+		return nil, ErrCannotSuspendOwner
+	}
+	if target.IsSuspended() {
+		return target, nil
+	}
+	if target.Status != domain.OrgUserStatusAccepted && target.Status != domain.OrgUserStatusConfirmed {
+		return nil, ErrMemberNotSuspendable
+	}
+
+	reason := ""
+	if req != nil {
+		reason = strings.TrimSpace(req.Reason)
+	}
+	markSuspended(target, reason, s.now())
+	if err := s.orgUserRepo.Update(ctx, target); err != nil {
+		return nil, fmt.Errorf("failed to suspend member: %w", err)
+	}
+
+	// Revoking the sessions drops the organization key clients already hold. After signing
+	// in again, SuspendedMemberMiddleware keeps the member out of this organization only.
+	if err := s.escrowRepo.DeleteByUserAndOrg(ctx, target.UserID, orgID); err != nil {
+		s.logger.Error("failed to revoke escrowed key of suspended member", "org_id", orgID, "user_id", target.UserID, "error", err)
+	}
+	if err := s.tokenRepo.Delete(ctx, int(target.UserID)); err != nil {
+		s.logger.Error("failed to revoke sessions of suspended member", "org_id", orgID, "user_id", target.UserID, "error", err)
+	}
+	if err := s.seatService.ReleaseSeats(ctx, orgID, SeatReasonMemberSuspended); err != nil {
+		s.logger.Error("failed to release seats after suspending member", "org_id", orgID, "error", err)
+	}
+
+	s.logger.Info("organization member suspended", "org_id", orgID, "org_user_id", target.ID, "by_user_id", requestingUserID)
+	return target, nil
+}
+
+func (s *memberSuspensionService) Restore(ctx context.Context, orgID, orgUserID, requestingUserID uint) (*domain.OrganizationUser, error) {
+	_, target, err := s.authorize(ctx, orgID, orgUserID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !target.IsSuspended() {
+		return nil, ErrMemberNotSuspended
+	}
+
+	err = s.seatService.WithSeat(ctx, orgID, SeatReasonMemberRestored, func() error {
+		clearSuspension(target)
+		return s.orgUserRepo.Update(ctx, target)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("organization member restored", "org_id", orgID, "org_user_id", target.ID, "by_user_id", requestingUserID)
+	return target, nil
+}
+
+// authorize loads the requester and target memberships and checks the requester may manage the target
+func (s *memberSuspensionService) authorize(ctx context.Context, orgID, orgUserID, requestingUserID uint) (*domain.OrganizationUser, *domain.OrganizationUser, error) {
+	requester, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, requestingUserID)
+	if err != nil {
+		return nil, nil, repository.ErrForbidden
+	}
+	if !authz.HasPermission(requester, domain.OrgPermManageMembers) {
+		return nil, nil, repository.ErrForbidden
+	}
+
+	target, err := s.orgUserRepo.GetByID(ctx, orgUserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if target.OrganizationID != orgID {
+		return nil, nil, repository.ErrNotFound
+	}
+	// Only owners can manage admins; delegated managers cannot act on members holding more than they do
+	if target.Role == domain.OrgRoleAdmin && !requester.IsOwner() {
+		return nil, nil, repository.ErrForbidden
+	}
+	if !authz.CanGrant(requester, authz.EffectivePermissions(target)) {
+		return nil, nil, repository.ErrForbidden
+	}
+	return requester, target, nil
+}
+
+// ProcessSuspendedRemovals warns admins about upcoming removals and removes members past the deadline
+func (s *memberSuspensionService) ProcessSuspendedRemovals(ctx context.Context) error {
+	suspended, err := s.orgUserRepo.ListSuspended(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list suspended members: %w", err)
+	}
+
+	byOrg := make(map[uint][]*domain.OrganizationUser)
+	var orgIDs []uint
+	for _, m := range suspended {
+		if _, ok := byOrg[m.OrganizationID]; !ok {
+			orgIDs = append(orgIDs, m.OrganizationID)
+		}
+		byOrg[m.OrganizationID] = append(byOrg[m.OrganizationID], m)
+	}
+
+	now := s.now()
+	for _, orgID := range orgIDs {
+		if err := s.processOrganization(ctx, orgID, byOrg[orgID], now); err != nil {
+			// Log error but continue processing others
+			s.logger.Error("suspended member cleanup failed", "org_id", orgID, "error", err)
+		}
+	}
+	return nil
+}
+
+func (s *memberSuspensionService) processOrganization(ctx context.Context, orgID uint, suspended []*domain.OrganizationUser, now time.Time) error {
+	days := s.autoDeleteDays(ctx, orgID)
+	if days <= 0 {
+		return nil
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to get organization: %w", err)
+	}
+	members, err := s.orgUserRepo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to list organization members: %w", err)
+	}
+
+	noticeDays := suspendedRemovalNoticeDays
+	if noticeDays > days {
+		noticeDays = days
+	}
+
+	for _, m := range suspended {
+		removeAt := m.SuspendedAt.AddDate(0, 0, days)
+
+		if m.RemovalNoticeSentAt == nil {
+			if now.Before(removeAt.AddDate(0, 0, -noticeDays)) {
+				continue
+			}
+			// Admins always get the full notice period, even after missed runs
+			sentAt := now
+			m.RemovalNoticeSentAt = &sentAt
+			if err := s.orgUserRepo.Update(ctx, m); err != nil {
+				s.logger.Error("failed to record suspended member removal notice", "org_user_id", m.ID, "error", err)
+				continue
+			}
+			sent := s.notifyAdmins(ctx, org, members, m, now.AddDate(0, 0, noticeDays))
+			s.logger.Info("suspended member removal scheduled", "org_id", orgID, "org_user_id", m.ID, "emails_sent", sent)
+			continue
+		}
+
+		if now.Before(removeAt) || now.Before(m.RemovalNoticeSentAt.AddDate(0, 0, noticeDays)) {
+			continue
+		}
+		if err := s.organizationService.RemoveSuspendedMember(ctx, orgID, m.ID); err != nil {
+			s.logger.Error("failed to remove suspended member", "org_id", orgID, "org_user_id", m.ID, "error", err)
+			continue
+		}
+		s.logger.Info("suspended member removed", "org_id", orgID, "org_user_id", m.ID, "suspended_at", m.SuspendedAt)
+
+		details := ActivityDetails{
+			ActivityFieldOrganizationID:   org.ID,
+			ActivityFieldOrganizationName: org.Name,
+			ActivityFieldUserID:           m.UserID,
+			ActivityFieldReason:           fmt.Sprintf("suspended for more than %d days", days),
+		}
+		if m.User != nil {
+			details[ActivityFieldUserEmail] = m.User.Email
+		}
+		for _, owner := range members {
+			if owner.Role == domain.OrgRoleOwner {
+				s.activityLogger.LogCustomActivity(ctx, owner.UserID, domain.ActivityTypeMemberRemoved, "system", "Suspended Member Worker", details)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// autoDeleteDays reads the organization's auto-delete setting; 0 disables removal
+func (s *memberSuspensionService) autoDeleteDays(ctx context.Context, orgID uint) int {
+	prefs, err := s.prefRepo.ListByOwner(ctx, domain.OrgSettingOwnerType, orgID, domain.OrgSettingSectionMembers)
+	if err != nil {
+		s.logger.Warn("failed to load member settings", "org_id", orgID, "error", err)
+		return 0
+	}
+	for _, p := range prefs {
+		if p.Key != domain.OrgSettingKeyAutoDeleteSuspended {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(p.Value)); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// notifyAdmins emails the members who manage members and returns how many emails were sent
+func (s *memberSuspensionService) notifyAdmins(ctx context.Context, org *domain.Organization, members []*domain.OrganizationUser, suspended *domain.OrganizationUser, removeAt time.Time) int {
+	if s.emailSender == nil || s.emailBuilder == nil {
+		return 0
+	}
+
+	memberEmail := ""
+	if suspended.User != nil {
+		memberEmail = suspended.User.Email
+	}
+
+	sent := 0
+	for _, m := range members {
+		if m.User == nil || !authz.HasPermission(m, domain.OrgPermManageMembers) {
+			continue
+		}
+		if m.Status != domain.OrgUserStatusAccepted && m.Status != domain.OrgUserStatusConfirmed {
+			continue
+		}
+		msg, err := s.emailBuilder.BuildSuspendedRemovalEmail(m.User.Email, org.ID, org.Name, memberEmail, *suspended.SuspendedAt, removeAt)
+		if err != nil {
+			s.logger.Error("suspended member cleanup: failed to build email", "org_id", org.ID, "error", err)
+			return sent
+		}
+		if err := s.emailSender.Send(ctx, msg); err != nil {
+			s.logger.Warn("suspended member cleanup: failed to send email", "org_id", org.ID, "to", m.User.Email, "error", err)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
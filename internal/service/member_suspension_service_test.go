@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// suspensionMemberRepo keeps memberships by ID
+type suspensionMemberRepo struct {
+	members map[uint]*domain.OrganizationUser
+}
+
+func (f *suspensionMemberRepo) GetByID(_ context.Context, id uint) (*domain.OrganizationUser, error) {
+	if m, ok := f.members[id]; ok {
+		return m, nil
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *suspensionMemberRepo) GetByOrgAndUser(_ context.Context, orgID, userID uint) (*domain.OrganizationUser, error) {
+	for _, m := range f.members {
+		if m.OrganizationID == orgID && m.UserID == userID {
+			return m, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *suspensionMemberRepo) ListByOrganization(_ context.Context, orgID uint) ([]*domain.OrganizationUser, error) {
+	var out []*domain.OrganizationUser
+	for _, m := range f.members {
+		if m.OrganizationID == orgID {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+func (f *suspensionMemberRepo) ListSuspended(_ context.Context) ([]*domain.OrganizationUser, error) {
+	var out []*domain.OrganizationUser
+	for _, m := range f.members {
+		if m.IsSuspended() && m.SuspendedAt != nil {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+func (f *suspensionMemberRepo) Update(_ context.Context, _ *domain.OrganizationUser) error {
+	return nil
+}
+
+type fakeMemberPrefRepo struct {
+	prefs []*domain.Preference
+}
+
+func (f *fakeMemberPrefRepo) ListByOwner(_ context.Context, _ string, _ uint, _ string) ([]*domain.Preference, error) {
+	return f.prefs, nil
+}
+
+type recordingRevoker struct {
+	escrowRevoked []uint
+	tokenRevoked  []int
+}
+
+func (f *recordingRevoker) DeleteByUserAndOrg(_ context.Context, userID, _ uint) error {
+	f.escrowRevoked = append(f.escrowRevoked, userID)
+	return nil
+}
+func (f *recordingRevoker) Delete(_ context.Context, userID int) error {
+	f.tokenRevoked = append(f.tokenRevoked, userID)
+	return nil
+}
+
+// fakeSeatService always has a seat available
+type fakeSeatService struct {
+	SeatService
+	released []string
+}
+
+func (f *fakeSeatService) WithSeat(_ context.Context, _ uint, _ string, join func() error) error {
+	return join()
+}
+func (f *fakeSeatService) ReleaseSeats(_ context.Context, _ uint, reason string) error {
+	f.released = append(f.released, reason)
+	return nil
+}
+
+type fakeSuspendedRemover struct {
+	repo    *suspensionMemberRepo
+	removed []uint
+}
+
+func (f *fakeSuspendedRemover) RemoveSuspendedMember(_ context.Context, _ uint, orgUserID uint) error {
+	f.removed = append(f.removed, orgUserID)
+	delete(f.repo.members, orgUserID)
+	return nil
+}
+
+type suspensionFixture struct {
+	svc      *memberSuspensionService
+	members  *suspensionMemberRepo
+	prefs    *fakeMemberPrefRepo
+	revoker  *recordingRevoker
+	seats    *fakeSeatService
+	remover  *fakeSuspendedRemover
+	sender   *fakeEmailSender
+	activity *fakeActivityService
+}
+
+func newSuspensionFixture(t *testing.T) *suspensionFixture {
+	t.Helper()
+
+	orgs := &fakeOrgRepo{orgs: map[uint]*domain.Organization{}}
+	orgs.add(&domain.Organization{ID: 1, Name: "Acme"})
+
+	members := &suspensionMemberRepo{members: map[uint]*domain.OrganizationUser{
+		10: {ID: 10, OrganizationID: 1, UserID: 100, Role: domain.OrgRoleOwner, Status: domain.OrgUserStatusConfirmed, User: &domain.User{Email: "owner@acme.test"}},
+		11: {ID: 11, OrganizationID: 1, UserID: 101, Role: domain.OrgRoleAdmin, Status: domain.OrgUserStatusConfirmed, User: &domain.User{Email: "admin@acme.test"}},
+		12: {ID: 12, OrganizationID: 1, UserID: 102, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed, User: &domain.User{Email: "member@acme.test"}},
+		13: {ID: 13, OrganizationID: 1, UserID: 103, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusInvited},
+	}}
+	f := &suspensionFixture{
+		members:  members,
+		prefs:    &fakeMemberPrefRepo{},
+		revoker:  &recordingRevoker{},
+		seats:    &fakeSeatService{},
+		remover:  &fakeSuspendedRemover{repo: members},
+		sender:   &fakeEmailSender{},
+		activity: &fakeActivityService{},
+	}
+	builder, err := email.NewEmailBuilder("https://vault.example.com", "noreply@example.com")
+	require.NoError(t, err)
+
+	f.svc = NewMemberSuspensionService(
+		orgs, members, f.prefs, f.revoker, f.revoker, f.seats, f.remover,
+		f.sender, builder, f.activity, noopLogger{},
+	).(*memberSuspensionService)
+	return f
+}
+
+func TestMemberSuspension_SuspendAndRestore(t *testing.T) {
+	ctx := context.Background()
+	f := newSuspensionFixture(t)
+
+	suspended, err := f.svc.Suspend(ctx, 1, 12, 101, &domain.SuspendOrgUserRequest{Reason: " on leave "})
+	require.NoError(t, err)
+	assert.True(t, suspended.IsSuspended())
+	assert.NotNil(t, suspended.SuspendedAt)
+	assert.Equal(t, "on leave", suspended.SuspensionReason)
+	assert.Equal(t, []uint{102}, f.revoker.escrowRevoked)
+	assert.Equal(t, []int{102}, f.revoker.tokenRevoked)
+	assert.Equal(t, []string{SeatReasonMemberSuspended}, f.seats.released)
+
+	restored, err := f.svc.Restore(ctx, 1, 12, 101)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrgUserStatusConfirmed, restored.Status)
+	assert.Nil(t, restored.SuspendedAt)
+	assert.Empty(t, restored.SuspensionReason)
+
+	_, err = f.svc.Restore(ctx, 1, 12, 101)
+	assert.ErrorIs(t, err, ErrMemberNotSuspended)
+}
+
+func TestMemberSuspension_Rules(t *testing.T) {
+	ctx := context.Background()
+	f := newSuspensionFixture(t)
+
+	_, err := f.svc.Suspend(ctx, 1, 10, 101, nil)
+	assert.ErrorIs(t, err, ErrCannotSuspendOwner)
+
+	_, err = f.svc.Suspend(ctx, 1, 11, 101, nil)
+	assert.ErrorIs(t, err, repository.ErrForbidden, "admins cannot suspend other admins")
+
+	_, err = f.svc.Suspend(ctx, 1, 11, 100, nil)
+	assert.NoError(t, err, "owners can suspend admins")
+
+	_, err = f.svc.Suspend(ctx, 1, 10, 100, nil)
+	assert.ErrorIs(t, err, ErrCannotSuspendSelf)
+
+	_, err = f.svc.Suspend(ctx, 1, 13, 100, nil)
+	assert.ErrorIs(t, err, ErrMemberNotSuspendable)
+
+	_, err = f.svc.Suspend(ctx, 1, 10, 102, nil)
+	assert.ErrorIs(t, err, repository.ErrForbidden, "members cannot suspend anyone")
+
+	_, err = f.svc.Suspend(ctx, 2, 12, 100, nil)
+	assert.ErrorIs(t, err, repository.ErrForbidden, "requester must belong to the organization")
+}
+
+func TestMemberSuspension_ProcessSuspendedRemovals(t *testing.T) {
+	ctx := context.Background()
+	f := newSuspensionFixture(t)
+	suspendedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	markSuspended(f.members.members[12], "", suspendedAt)
+
+	run := func(at time.Time) {
+		f.svc.now = func() time.Time { return at }
+		require.NoError(t, f.svc.ProcessSuspendedRemovals(ctx))
+	}
+
+	// Disabled by default
+	run(suspendedAt.AddDate(1, 0, 0))
+	assert.Empty(t, f.sender.sent)
+	assert.Empty(t, f.remover.removed)
+
+	f.prefs.prefs = []*domain.Preference{{Key: domain.OrgSettingKeyAutoDeleteSuspended, Value: "30"}}
+
+	// Too early for a notice
+	run(suspendedAt.AddDate(0, 0, 26))
+	assert.Empty(t, f.sender.sent)
+
+	// Owner and admin are warned three days ahead
+	run(suspendedAt.AddDate(0, 0, 27))
+	require.Len(t, f.sender.sent, 2)
+	assert.ElementsMatch(t, []string{"owner@acme.test", "admin@acme.test"}, []string{f.sender.sent[0].To, f.sender.sent[1].To})
+	assert.Contains(t, f.sender.sent[0].Subject, "member@acme.test")
+	assert.NotNil(t, f.members.members[12].RemovalNoticeSentAt)
+
+	// Notice is not repeated and removal waits for the deadline
+	run(suspendedAt.AddDate(0, 0, 29))
+	assert.Len(t, f.sender.sent, 2)
+	assert.Empty(t, f.remover.removed)
+
+	run(suspendedAt.AddDate(0, 0, 30))
+	assert.Equal(t, []uint{12}, f.remover.removed)
+	assert.Equal(t, []domain.ActivityType{domain.ActivityTypeMemberRemoved}, f.activity.logged)
+}
+
+func TestMemberSuspension_MissedNoticeDelaysRemoval(t *testing.T) {
+	ctx := context.Background()
+	f := newSuspensionFixture(t)
+	suspendedAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	markSuspended(f.members.members[12], "", suspendedAt)
+	f.prefs.prefs = []*domain.Preference{{Key: domain.OrgSettingKeyAutoDeleteSuspended, Value: "30"}}
+
+	// The setting was enabled long after the suspension: warn first, remove later
+	late := suspendedAt.AddDate(0, 0, 60)
+	f.svc.now = func() time.Time { return late }
+	require.NoError(t, f.svc.ProcessSuspendedRemovals(ctx))
+	assert.Len(t, f.sender.sent, 2)
+	assert.Empty(t, f.remover.removed)
+
+	f.svc.now = func() time.Time { return late.AddDate(0, 0, 3) }
+	require.NoError(t, f.svc.ProcessSuspendedRemovals(ctx))
+	assert.Equal(t, []uint{12}, f.remover.removed)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// notificationListLimit caps how many notifications one listing returns
+const notificationListLimit = 100
+
+// NotificationService manages in-app notifications
+type NotificationService interface {
+	// Notify stores a notification for n.UserID
+	Notify(ctx context.Context, n *domain.Notification) error
+	List(ctx context.Context, userID uint, unreadOnly bool) ([]*domain.Notification, error)
+	MarkRead(ctx context.Context, userID, notificationID uint) error
+	MarkAllRead(ctx context.Context, userID uint) error
+}
+
+type notificationService struct {
+	repo   repository.NotificationRepository
+	logger Logger
+	now    func() time.Time
+}
+
+// NewNotificationService creates a new in-app notification service
+func NewNotificationService(repo repository.NotificationRepository, logger Logger) NotificationService {
+	return &notificationService{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+func (s *notificationService) Notify(ctx context.Context, n *domain.Notification) error {
+	if n.UserID == 0 || n.Title == "" {
+		return repository.ErrInvalidInput
+	}
+	n.ReadAt = nil
+	return s.repo.Create(ctx, n)
+}
+
+func (s *notificationService) List(ctx context.Context, userID uint, unreadOnly bool) ([]*domain.Notification, error) {
+	return s.repo.ListByUser(ctx, userID, unreadOnly, notificationListLimit)
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, userID, notificationID uint) error {
+	n, err := s.repo.GetByID(ctx, notificationID)
+	if err != nil {
+		return err
+	}
+	// Other users' notifications look the same as missing ones
+	if n.UserID != userID {
+		return repository.ErrNotFound
+	}
+	if n.IsRead() {
+		return nil
+	}
+	now := s.now()
+	n.ReadAt = &now
+	return s.repo.Update(ctx, n)
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, userID uint) error {
+	return s.repo.MarkAllRead(ctx, userID, s.now())
+}
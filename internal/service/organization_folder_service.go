@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
 )
@@ -45,7 +46,7 @@ func (s *organizationFolderService) Create(ctx context.Context, orgID, userID ui
 		return nil, repository.ErrForbidden
 	}
 
-	if !orgUser.CanManageCollections() {
+	if !authz.HasPermission(orgUser, domain.OrgPermManageCollections) {
 		return nil, repository.ErrForbidden
 	}
 
@@ -74,7 +75,7 @@ func (s *organizationFolderService) Update(ctx context.Context, orgID, userID, i
 		return nil, repository.ErrForbidden
 	}
 
-	if !orgUser.CanManageCollections() {
+	if !authz.HasPermission(orgUser, domain.OrgPermManageCollections) {
 		return nil, repository.ErrForbidden
 	}
 
@@ -101,7 +102,7 @@ func (s *organizationFolderService) Delete(ctx context.Context, orgID, userID, i
 		return repository.ErrForbidden
 	}
 
-	if !orgUser.CanManageCollections() {
+	if !authz.HasPermission(orgUser, domain.OrgPermManageCollections) {
 		return repository.ErrForbidden
 	}
 
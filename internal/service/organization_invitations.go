@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+var (
+	ErrInvitationExpired    = errors.New("invitation has expired; ask an organization admin to resend it")
+	ErrNotPendingInvitation = errors.New("member has no pending invitation")
+)
+
+// ListInvitations returns the organization's pending invitations with their expiry
+func (s *organizationService) ListInvitations(ctx context.Context, orgID uint, requestingUserID uint) ([]*domain.OrganizationInvitationDTO, error) {
+	if err := s.checkPermission(ctx, orgID, requestingUserID, domain.OrgPermManageMembers); err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgUserRepo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %w", err)
+	}
+
+	invitations := make([]*domain.OrganizationInvitationDTO, 0)
+	for _, m := range members {
+		if m.Status != domain.OrgUserStatusInvited {
+			continue
+		}
+		invitations = append(invitations, s.toInvitationDTO(ctx, orgID, m))
+	}
+	return invitations, nil
+}
+
+// ResendInvitation emails a pending invitation again with a new code and a fresh expiry
+func (s *organizationService) ResendInvitation(ctx context.Context, orgID, orgUserID uint, requestingUserID uint) (*domain.OrganizationInvitationDTO, error) {
+	orgUser, err := s.pendingInvitation(ctx, orgID, orgUserID, requestingUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	inviter, err := s.userRepo.GetByID(ctx, requestingUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inviter info: %w", err)
+	}
+
+	invitation, err := s.invitationService.GetOrganizationInvitation(ctx, orgID, orgUser.User.Email)
+	switch {
+	case err == nil:
+		if err := s.invitationService.RenewInvitation(ctx, invitation, inviter.Name); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, repository.ErrNotFound):
+		// The invitation row was cleaned up or predates unified invitations
+		orgRoleStr := string(orgUser.Role)
+		accessAll := orgUser.AccessAll
+		encryptedOrgKey := orgUser.EncryptedOrgKey
+		invitationReq := &domain.CreateInvitationRequest{
+			Email:           orgUser.User.Email,
+			RoleID:          2,
+			OrganizationID:  &orgID,
+			OrgRole:         &orgRoleStr,
+			EncryptedOrgKey: &encryptedOrgKey,
+			AccessAll:       &accessAll,
+		}
+		if _, err := s.invitationService.CreateInvitation(ctx, invitationReq, requestingUserID, inviter.Name); err != nil {
+			return nil, fmt.Errorf("failed to create invitation: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	now := time.Now()
+	orgUser.InvitedAt = &now
+	if err := s.orgUserRepo.Update(ctx, orgUser); err != nil {
+		return nil, fmt.Errorf("failed to update invitation: %w", err)
+	}
+
+	s.logger.Info("organization invitation resent", "org_id", orgID, "org_user_id", orgUserID, "by_user_id", requestingUserID)
+	return s.toInvitationDTO(ctx, orgID, orgUser), nil
+}
+
+// RevokeInvitation withdraws a pending invitation and removes the invited membership
+func (s *organizationService) RevokeInvitation(ctx context.Context, orgID, orgUserID uint, requestingUserID uint) error {
+	orgUser, err := s.pendingInvitation(ctx, orgID, orgUserID, requestingUserID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.invitationService.RevokeOrganizationInvitations(ctx, orgID, orgUser.User.Email); err != nil {
+		return err
+	}
+	if err := s.deleteMembership(ctx, orgID, orgUser, SeatReasonInvitationRevoked); err != nil {
+		return err
+	}
+
+	s.logger.Info("organization invitation revoked", "org_id", orgID, "org_user_id", orgUserID, "by_user_id", requestingUserID)
+	return nil
+}
+
+// BulkInvite invites every row and reports per-row results. One failing row
+// does not stop the others.
+func (s *organizationService) BulkInvite(ctx context.Context, orgID uint, requestingUserID uint, rows []domain.BulkInviteRow) (*domain.BulkInviteResponse, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no invitations given", repository.ErrInvalidInput)
+	}
+	if len(rows) > domain.MaxBulkInviteRows {
+		return nil, fmt.Errorf("%w: at most %d invitations can be sent at once", repository.ErrInvalidInput, domain.MaxBulkInviteRows)
+	}
+	if err := s.checkPermission(ctx, orgID, requestingUserID, domain.OrgPermManageMembers); err != nil {
+		return nil, err
+	}
+
+	teams, err := s.teamRepo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	resp := &domain.BulkInviteResponse{Results: make([]domain.BulkInviteResult, 0, len(rows))}
+	seen := make(map[string]bool, len(rows))
+	for i, row := range rows {
+		email := strings.ToLower(strings.TrimSpace(row.Email))
+		result := domain.BulkInviteResult{Row: i + 1, Email: email}
+
+		orgUser, err := s.inviteRow(ctx, orgID, requestingUserID, row, email, teams, seen)
+		switch {
+		case errors.Is(err, errDuplicateBulkRow):
+			result.Status = domain.BulkInviteStatusSkipped
+			result.Error = err.Error()
+			resp.Skipped++
+		case orgUser == nil:
+			result.Status = domain.BulkInviteStatusFailed
+			result.Error = err.Error()
+			resp.Failed++
+		default:
+			// Team assignment errors are reported on an otherwise successful invite
+			result.Status = domain.BulkInviteStatusInvited
+			result.OrganizationUserID = orgUser.ID
+			if err != nil {
+				result.Error = err.Error()
+			}
+			resp.Invited++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	s.logger.Info("bulk invitation processed", "org_id", orgID, "invited", resp.Invited, "failed", resp.Failed, "skipped", resp.Skipped)
+	return resp, nil
+}
+
+var errDuplicateBulkRow = errors.New("email already appears in an earlier row")
+
+// inviteRow validates one bulk row, invites the user and assigns the requested teams.
+// A membership is returned whenever the invitation was created.
+func (s *organizationService) inviteRow(ctx context.Context, orgID, requestingUserID uint, row domain.BulkInviteRow, email string, teams []*domain.Team, seen map[string]bool) (*domain.OrganizationUser, error) {
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, errors.New("a valid email is required")
+	}
+	if seen[email] {
+		return nil, errDuplicateBulkRow
+	}
+	seen[email] = true
+
+	role := row.Role
+	if role == "" {
+		role = domain.OrgRoleMember
+	}
+	if !isSupportedOrgRole(role) {
+		return nil, fmt.Errorf("invalid organization role: %s", role)
+	}
+	if strings.TrimSpace(row.EncryptedOrgKey) == "" {
+		return nil, errors.New("encrypted_org_key is required")
+	}
+
+	// Resolve teams before inviting so a typo does not leave a half-configured member
+	assign := make([]*domain.Team, 0, len(row.Teams))
+	for _, ref := range row.Teams {
+		team := findTeam(teams, ref)
+		if team == nil {
+			return nil, fmt.Errorf("team not found: %s", ref)
+		}
+		if !team.IsDefault {
+			assign = append(assign, team)
+		}
+	}
+
+	orgUser, err := s.InviteUser(ctx, orgID, requestingUserID, &domain.InviteUserToOrgRequest{
+		Email:           email,
+		Role:            role,
+		EncryptedOrgKey: row.EncryptedOrgKey,
+		AccessAll:       row.AccessAll,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrForbidden) {
+			return nil, errors.New("not allowed to invite with this role")
+		}
+		return nil, err
+	}
+
+	for _, team := range assign {
+		if _, err := s.teamUserRepo.GetByTeamAndOrgUser(ctx, team.ID, orgUser.ID); err == nil {
+			continue
+		}
+		if err := s.teamUserRepo.Create(ctx, &domain.TeamUser{TeamID: team.ID, OrganizationUserID: orgUser.ID}); err != nil {
+			return orgUser, fmt.Errorf("failed to add to team %s: %w", team.Name, err)
+		}
+	}
+	return orgUser, nil
+}
+
+// findTeam matches a team by ID or case-insensitive name
+func findTeam(teams []*domain.Team, ref string) *domain.Team {
+	ref = strings.TrimSpace(ref)
+	if id, err := strconv.ParseUint(ref, 10, 64); err == nil {
+		for _, t := range teams {
+			if uint64(t.ID) == id {
+				return t
+			}
+		}
+	}
+	for _, t := range teams {
+		if strings.EqualFold(t.Name, ref) {
+			return t
+		}
+	}
+	return nil
+}
+
+// RemoveExpiredInvitations deletes expired invitations together with their pending
+// memberships and returns how many invitations were removed
+func (s *organizationService) RemoveExpiredInvitations(ctx context.Context) (int, error) {
+	expired, err := s.invitationService.ListExpired(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, inv := range expired {
+		if inv.OrganizationID != nil {
+			if err := s.removeExpiredMembership(ctx, *inv.OrganizationID, inv.Email); err != nil {
+				// Log error but continue processing others
+				s.logger.Error("failed to remove expired invitation membership", "invitation_id", inv.ID, "org_id", *inv.OrganizationID, "error", err)
+				continue
+			}
+		}
+		if err := s.invitationService.DeleteInvitation(ctx, inv.ID); err != nil {
+			s.logger.Error("failed to delete expired invitation", "invitation_id", inv.ID, "error", err)
+			continue
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func (s *organizationService) removeExpiredMembership(ctx context.Context, orgID uint, email string) error {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, user.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// A newer invitation may still be valid
+	if orgUser.Status != domain.OrgUserStatusInvited {
+		return nil
+	}
+	if latest, err := s.invitationService.GetOrganizationInvitation(ctx, orgID, email); err == nil && !latest.IsExpired() {
+		return nil
+	}
+
+	return s.deleteMembership(ctx, orgID, orgUser, SeatReasonInvitationExpired)
+}
+
+// pendingInvitation loads an invited membership the requester may manage
+func (s *organizationService) pendingInvitation(ctx context.Context, orgID, orgUserID uint, requestingUserID uint) (*domain.OrganizationUser, error) {
+	if err := s.checkPermission(ctx, orgID, requestingUserID, domain.OrgPermManageMembers); err != nil {
+		return nil, err
+	}
+
+	orgUser, err := s.orgUserRepo.GetByID(ctx, orgUserID)
+	if err != nil {
+		return nil, fmt.Errorf("member not found: %w", err)
+	}
+	if orgUser.OrganizationID != orgID {
+		return nil, repository.ErrForbidden
+	}
+	if orgUser.Status != domain.OrgUserStatusInvited || orgUser.User == nil {
+		return nil, ErrNotPendingInvitation
+	}
+	return orgUser, nil
+}
+
+func (s *organizationService) toInvitationDTO(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser) *domain.OrganizationInvitationDTO {
+	dto := &domain.OrganizationInvitationDTO{
+		OrganizationUserID: orgUser.ID,
+		Role:               orgUser.Role,
+		AccessAll:          orgUser.AccessAll,
+		InvitedAt:          orgUser.InvitedAt,
+	}
+	if orgUser.User == nil {
+		return dto
+	}
+	dto.Email = orgUser.User.Email
+
+	if inv, err := s.invitationService.GetOrganizationInvitation(ctx, orgID, orgUser.User.Email); err == nil {
+		expiresAt := inv.ExpiresAt
+		dto.ExpiresAt = &expiresAt
+		dto.IsExpired = inv.IsExpired()
+	}
+	return dto
+}
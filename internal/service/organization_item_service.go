@@ -43,27 +43,31 @@ func NewOrganizationItemService(
 
 // CreateOrgItemRequest for creating organization items
 type CreateOrgItemRequest struct {
-	CollectionID *uint               `json:"collection_id,omitempty"`
-	ItemType     domain.ItemType     `json:"item_type" validate:"required"`
-	Data         string              `json:"data" validate:"required"` // Encrypted with Org Key
-	Metadata     domain.ItemMetadata `json:"metadata" validate:"required"`
-	IsFavorite   bool                `json:"is_favorite"`
-	FolderID     *uint               `json:"folder_id,omitempty"`
-	Reprompt     bool                `json:"reprompt"`
-	AutoFill     *bool               `json:"auto_fill,omitempty"`
-	AutoLogin    *bool               `json:"auto_login,omitempty"`
+	CollectionID  *uint               `json:"collection_id,omitempty"`  // Single collection, for older clients
+	CollectionIDs []uint              `json:"collection_ids,omitempty"` // Every collection the item belongs to
+	ItemType      domain.ItemType     `json:"item_type" validate:"required"`
+	Data          string              `json:"data" validate:"required"` // Encrypted with Org Key
+	Metadata      domain.ItemMetadata `json:"metadata" validate:"required"`
+	IsFavorite    bool                `json:"is_favorite"`
+	FolderID      *uint               `json:"folder_id,omitempty"`
+	Reprompt      bool                `json:"reprompt"`
+	AutoFill      *bool               `json:"auto_fill,omitempty"`
+	AutoLogin     *bool               `json:"auto_login,omitempty"`
 }
 
 // UpdateOrgItemRequest for updating organization items
 type UpdateOrgItemRequest struct {
-	CollectionID *uint                `json:"collection_id,omitempty"`
-	Data         *string              `json:"data,omitempty"`
-	Metadata     *domain.ItemMetadata `json:"metadata,omitempty"`
-	IsFavorite   *bool                `json:"is_favorite,omitempty"`
-	FolderID     *uint                `json:"folder_id,omitempty"`
-	Reprompt     *bool                `json:"reprompt,omitempty"`
-	AutoFill     *bool                `json:"auto_fill,omitempty"`
-	AutoLogin    *bool                `json:"auto_login,omitempty"`
+	// CollectionID moves the item into this single collection unless it already belongs to it
+	CollectionID *uint `json:"collection_id,omitempty"`
+	// CollectionIDs replaces every collection the item belongs to; the encrypted data is untouched
+	CollectionIDs *[]uint              `json:"collection_ids,omitempty"`
+	Data          *string              `json:"data,omitempty"`
+	Metadata      *domain.ItemMetadata `json:"metadata,omitempty"`
+	IsFavorite    *bool                `json:"is_favorite,omitempty"`
+	FolderID      *uint                `json:"folder_id,omitempty"`
+	Reprompt      *bool                `json:"reprompt,omitempty"`
+	AutoFill      *bool                `json:"auto_fill,omitempty"`
+	AutoLogin     *bool                `json:"auto_login,omitempty"`
 }
 
 func (s *organizationItemService) Create(ctx context.Context, orgID, userID uint, req *CreateOrgItemRequest) (*domain.OrganizationItem, error) {
@@ -75,7 +79,12 @@ func (s *organizationItemService) Create(ctx context.Context, orgID, userID uint
 
 	// Enforce "no orphan items": always place items in a collection.
 	// If client doesn't specify a collection, we use the org's default collection.
-	if req.CollectionID == nil {
+	collectionIDs := req.CollectionIDs
+	if req.CollectionID != nil {
+		collectionIDs = append(collectionIDs, *req.CollectionID)
+	}
+	collectionIDs = uniqueIDs(collectionIDs)
+	if len(collectionIDs) == 0 {
 		def, err := s.collectionRepo.GetDefaultByOrganization(ctx, orgID)
 		if err != nil {
 			// Default collection is expected to exist (migration + org creation),
@@ -91,37 +100,12 @@ func (s *organizationItemService) Create(ctx context.Context, orgID, userID uint
 				return nil, fmt.Errorf("failed to ensure default collection: %w", createErr)
 			}
 		}
-		req.CollectionID = &def.ID
+		collectionIDs = []uint{def.ID}
 	}
 
-	// If collection specified, check access
-	if req.CollectionID != nil {
-		collection, err := s.collectionRepo.GetByID(ctx, *req.CollectionID)
-		if err != nil {
-			return nil, fmt.Errorf("collection not found: %w", err)
-		}
-
-		if collection.OrganizationID != orgID {
-			return nil, repository.ErrForbidden
-		}
-
-		// Check collection access (enforce write permission for non-admin users)
-		if !orgUser.IsAdmin() && !orgUser.AccessAll {
-			access, err := authz.ComputeCollectionAccess(
-				ctx,
-				orgUser,
-				*req.CollectionID,
-				s.collectionUserRepo,
-				s.collectionTeamRepo,
-				s.teamUserRepo,
-			)
-			if err != nil {
-				return nil, err
-			}
-			if !access.CanWrite && !access.CanAdmin {
-				return nil, repository.ErrForbidden
-			}
-		}
+	// Check collection access (enforce write permission for non-admin users)
+	if err := s.checkCollectionsWritable(ctx, orgUser, collectionIDs); err != nil {
+		return nil, err
 	}
 
 	// Validate item type
@@ -133,7 +117,7 @@ func (s *organizationItemService) Create(ctx context.Context, orgID, userID uint
 	item := &domain.OrganizationItem{
 		UUID:            uuid.New(),
 		OrganizationID:  orgID,
-		CollectionID:    req.CollectionID,
+		CollectionIDs:   collectionIDs,
 		ItemType:        req.ItemType,
 		Data:            req.Data, // Already encrypted with Org Key
 		Metadata:        req.Metadata,
@@ -174,22 +158,7 @@ func (s *organizationItemService) GetByID(ctx context.Context, id, userID uint)
 		return item, nil
 	}
 
-	// Legacy safety for orphaned items: only creator can access.
-	if item.CollectionID == nil {
-		if item.CreatedByUserID != userID {
-			return nil, repository.ErrForbidden
-		}
-		return item, nil
-	}
-
-	access, err := authz.ComputeCollectionAccess(
-		ctx,
-		orgUser,
-		*item.CollectionID,
-		s.collectionUserRepo,
-		s.collectionTeamRepo,
-		s.teamUserRepo,
-	)
+	access, err := s.itemAccess(ctx, orgUser, item)
 	if err != nil {
 		return nil, err
 	}
@@ -252,11 +221,11 @@ func (s *organizationItemService) ListByOrganization(ctx context.Context, orgID,
 	if restrictByCollections && filter.CollectionID == nil {
 		filtered := make([]*domain.OrganizationItem, 0, len(items))
 		for _, item := range items {
-			if item.CollectionID == nil {
-				continue
-			}
-			if _, ok := allowedCollectionIDs[*item.CollectionID]; ok {
-				filtered = append(filtered, item)
+			for _, cid := range item.CollectionIDs {
+				if _, ok := allowedCollectionIDs[cid]; ok {
+					filtered = append(filtered, item)
+					break
+				}
 			}
 		}
 		items = filtered
@@ -285,6 +254,7 @@ func (s *organizationItemService) ListByCollection(ctx context.Context, collecti
 			ctx,
 			orgUser,
 			collectionID,
+			s.collectionRepo,
 			s.collectionUserRepo,
 			s.collectionTeamRepo,
 			s.teamUserRepo,
@@ -319,62 +289,35 @@ func (s *organizationItemService) Update(ctx context.Context, id, userID uint, r
 		return nil, repository.ErrForbidden
 	}
 
-	// Non-admin users need write/admin on current collection.
+	// Non-admin users need write/admin through one of the item's collections.
 	if !orgUser.IsAdmin() && !orgUser.AccessAll {
-		// Legacy safety for orphaned items: only creator can edit.
-		if item.CollectionID == nil {
-			if item.CreatedByUserID != userID {
-				return nil, repository.ErrForbidden
-			}
-		} else {
-			access, err := authz.ComputeCollectionAccess(
-				ctx,
-				orgUser,
-				*item.CollectionID,
-				s.collectionUserRepo,
-				s.collectionTeamRepo,
-				s.teamUserRepo,
-			)
-			if err != nil {
-				return nil, err
-			}
-			if !access.CanWrite && !access.CanAdmin {
-				return nil, repository.ErrForbidden
-			}
-		}
-	}
-
-	// If moving to another collection, require write/admin on destination too.
-	if req.CollectionID != nil {
-		collection, err := s.collectionRepo.GetByID(ctx, *req.CollectionID)
+		access, err := s.itemAccess(ctx, orgUser, item)
 		if err != nil {
-			return nil, fmt.Errorf("collection not found: %w", err)
+			return nil, err
 		}
-		if collection.OrganizationID != item.OrganizationID {
+		if !access.CanWrite && !access.CanAdmin {
 			return nil, repository.ErrForbidden
 		}
-		if !orgUser.IsAdmin() && !orgUser.AccessAll {
-			access, err := authz.ComputeCollectionAccess(
-				ctx,
-				orgUser,
-				*req.CollectionID,
-				s.collectionUserRepo,
-				s.collectionTeamRepo,
-				s.teamUserRepo,
-			)
-			if err != nil {
-				return nil, err
-			}
-			if !access.CanWrite && !access.CanAdmin {
-				return nil, repository.ErrForbidden
-			}
+	}
+
+	// Changing collections requires write/admin on every collection added or removed.
+	var collectionIDs []uint
+	switch {
+	case req.CollectionIDs != nil:
+		collectionIDs = uniqueIDs(*req.CollectionIDs)
+		if len(collectionIDs) == 0 {
+			return nil, fmt.Errorf("%w: at least one collection is required", repository.ErrInvalidInput)
+		}
+	case req.CollectionID != nil && !item.InCollection(*req.CollectionID):
+		collectionIDs = []uint{*req.CollectionID}
+	}
+	if collectionIDs != nil {
+		if err := s.checkCollectionsWritable(ctx, orgUser, changedCollectionIDs(item.CollectionIDs, collectionIDs)); err != nil {
+			return nil, err
 		}
 	}
 
 	// Update fields
-	if req.CollectionID != nil {
-		item.CollectionID = req.CollectionID
-	}
 	if req.Data != nil {
 		item.Data = *req.Data
 	}
@@ -401,6 +344,13 @@ func (s *organizationItemService) Update(ctx context.Context, id, userID uint, r
 		s.logger.Error("failed to update organization item", "item_id", id, "error", err)
 		return nil, fmt.Errorf("failed to update item: %w", err)
 	}
+	if collectionIDs != nil {
+		if err := s.itemRepo.SetCollections(ctx, item.ID, collectionIDs); err != nil {
+			s.logger.Error("failed to update organization item collections", "item_id", id, "error", err)
+			return nil, fmt.Errorf("failed to update item collections: %w", err)
+		}
+		item.CollectionIDs = collectionIDs
+	}
 
 	s.logger.Info("organization item updated", "item_id", id, "user_id", userID)
 	return item, nil
@@ -421,26 +371,12 @@ func (s *organizationItemService) Delete(ctx context.Context, id, userID uint) (
 
 	// Only admins or creator can delete
 	if !orgUser.IsAdmin() && !orgUser.AccessAll {
-		// Legacy safety for orphaned items: only creator can delete.
-		if item.CollectionID == nil {
-			if item.CreatedByUserID != userID {
-				return nil, repository.ErrForbidden
-			}
-		} else {
-			access, err := authz.ComputeCollectionAccess(
-				ctx,
-				orgUser,
-				*item.CollectionID,
-				s.collectionUserRepo,
-				s.collectionTeamRepo,
-				s.teamUserRepo,
-			)
-			if err != nil {
-				return nil, err
-			}
-			if !access.CanWrite && !access.CanAdmin {
-				return nil, repository.ErrForbidden
-			}
+		access, err := s.itemAccess(ctx, orgUser, item)
+		if err != nil {
+			return nil, err
+		}
+		if !access.CanWrite && !access.CanAdmin {
+			return nil, repository.ErrForbidden
 		}
 	}
 
@@ -453,6 +389,15 @@ func (s *organizationItemService) Delete(ctx context.Context, id, userID uint) (
 	return item, nil
 }
 
+func (s *organizationItemService) GetItemAccess(ctx context.Context, item *domain.OrganizationItem, userID uint) (*authz.CollectionAccess, error) {
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, item.OrganizationID, userID)
+	if err != nil {
+		return nil, repository.ErrForbidden
+	}
+
+	return s.itemAccess(ctx, orgUser, item)
+}
+
 func (s *organizationItemService) GetCollectionAccess(ctx context.Context, orgID, userID, collectionID uint) (*authz.CollectionAccess, error) {
 	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
 	if err != nil {
@@ -463,6 +408,7 @@ func (s *organizationItemService) GetCollectionAccess(ctx context.Context, orgID
 		ctx,
 		orgUser,
 		collectionID,
+		s.collectionRepo,
 		s.collectionUserRepo,
 		s.collectionTeamRepo,
 		s.teamUserRepo,
@@ -486,27 +432,90 @@ func (s *organizationItemService) GetAutofillSecret(ctx context.Context, itemID,
 		return item, nil
 	}
 
-	if item.CollectionID == nil {
-		if item.CreatedByUserID != userID {
+	access, err := s.itemAccess(ctx, orgUser, item)
+	if err != nil {
+		return nil, err
+	}
+	if !access.CanRead {
+		return nil, repository.ErrForbidden
+	}
+
+	return item, nil
+}
+
+// itemAccess returns the member's access to the item through any of its collections.
+// Legacy items without a collection are only available to their creator.
+func (s *organizationItemService) itemAccess(ctx context.Context, orgUser *domain.OrganizationUser, item *domain.OrganizationItem) (*authz.CollectionAccess, error) {
+	if len(item.CollectionIDs) == 0 && !orgUser.IsAdmin() && !orgUser.AccessAll {
+		if orgUser.IsSuspended() || item.CreatedByUserID != orgUser.UserID {
 			return nil, repository.ErrForbidden
 		}
-		return item, nil
+		return &authz.CollectionAccess{CanRead: true, CanWrite: true}, nil
 	}
 
-	access, err := authz.ComputeCollectionAccess(
+	return authz.ComputeItemAccess(
 		ctx,
 		orgUser,
-		*item.CollectionID,
+		item.CollectionIDs,
+		s.collectionRepo,
 		s.collectionUserRepo,
 		s.collectionTeamRepo,
 		s.teamUserRepo,
 	)
-	if err != nil {
-		return nil, err
-	}
-	if !access.CanRead {
-		return nil, repository.ErrForbidden
+}
+
+// checkCollectionsWritable verifies every collection belongs to the member's organization
+// and, for non-admin members, that they may write items in it.
+func (s *organizationItemService) checkCollectionsWritable(ctx context.Context, orgUser *domain.OrganizationUser, collectionIDs []uint) error {
+	for _, collectionID := range collectionIDs {
+		collection, err := s.collectionRepo.GetByID(ctx, collectionID)
+		if err != nil {
+			return fmt.Errorf("collection not found: %w", err)
+		}
+		if collection.OrganizationID != orgUser.OrganizationID {
+			return repository.ErrForbidden
+		}
+
+		if orgUser.IsAdmin() || orgUser.AccessAll {
+			continue
+		}
+		access, err := authz.ComputeCollectionAccess(
+			ctx,
+			orgUser,
+			collectionID,
+			s.collectionRepo,
+			s.collectionUserRepo,
+			s.collectionTeamRepo,
+			s.teamUserRepo,
+		)
+		if err != nil {
+			return err
+		}
+		if !access.CanWrite && !access.CanAdmin {
+			return repository.ErrForbidden
+		}
 	}
+	return nil
+}
 
-	return item, nil
+// changedCollectionIDs returns the collections present in exactly one of the two sets
+func changedCollectionIDs(current, next []uint) []uint {
+	inCurrent := make(map[uint]bool, len(current))
+	for _, id := range current {
+		inCurrent[id] = true
+	}
+	inNext := make(map[uint]bool, len(next))
+	changed := make([]uint, 0)
+	for _, id := range next {
+		inNext[id] = true
+		if !inCurrent[id] {
+			changed = append(changed, id)
+		}
+	}
+	for _, id := range current {
+		if !inNext[id] {
+			changed = append(changed, id)
+		}
+	}
+	return changed
 }
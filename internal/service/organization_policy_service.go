@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
 )
@@ -31,7 +32,8 @@ type organizationPolicyService struct {
 	subRepo     interface {
 		GetByOrganizationID(ctx context.Context, orgID uint) (*domain.Subscription, error)
 	}
-	logger Logger
+	excludedDomainRepo repository.OrganizationExcludedDomainRepository
+	logger             Logger
 }
 
 // NewOrganizationPolicyService creates a new organization policy service
@@ -41,18 +43,20 @@ func NewOrganizationPolicyService(
 	subRepo interface {
 		GetByOrganizationID(ctx context.Context, orgID uint) (*domain.Subscription, error)
 	},
+	excludedDomainRepo repository.OrganizationExcludedDomainRepository,
 	logger Logger,
 ) OrganizationPolicyService {
 	return &organizationPolicyService{
-		policyRepo:  policyRepo,
-		orgUserRepo: orgUserRepo,
-		subRepo:     subRepo,
-		logger:      logger,
+		policyRepo:         policyRepo,
+		orgUserRepo:        orgUserRepo,
+		subRepo:            subRepo,
+		excludedDomainRepo: excludedDomainRepo,
+		logger:             logger,
 	}
 }
 
 func (s *organizationPolicyService) ListByOrganization(ctx context.Context, orgID, userID uint) ([]*domain.OrganizationPolicyDTO, error) {
-	if err := s.requireOrgAdmin(ctx, orgID, userID); err != nil {
+	if err := s.requirePermission(ctx, orgID, userID, domain.OrgPermManagePolicies); err != nil {
 		return nil, err
 	}
 
@@ -95,7 +99,7 @@ func (s *organizationPolicyService) ListByOrganization(ctx context.Context, orgI
 }
 
 func (s *organizationPolicyService) GetByType(ctx context.Context, orgID, userID uint, policyType domain.PolicyType) (*domain.OrganizationPolicyDTO, error) {
-	if err := s.requireOrgAdmin(ctx, orgID, userID); err != nil {
+	if err := s.requirePermission(ctx, orgID, userID, domain.OrgPermManagePolicies); err != nil {
 		return nil, err
 	}
 
@@ -120,7 +124,7 @@ func (s *organizationPolicyService) GetByType(ctx context.Context, orgID, userID
 }
 
 func (s *organizationPolicyService) UpdatePolicy(ctx context.Context, orgID, userID uint, policyType domain.PolicyType, req *domain.UpdateOrganizationPolicyRequest) (*domain.OrganizationPolicyDTO, error) {
-	if err := s.requireOrgAdmin(ctx, orgID, userID); err != nil {
+	if err := s.requirePermission(ctx, orgID, userID, domain.OrgPermManagePolicies); err != nil {
 		return nil, err
 	}
 
@@ -281,12 +285,30 @@ func (s *organizationPolicyService) GetActivePolicySummary(ctx context.Context,
 	for _, p := range policies {
 		summary[p.Type] = p.Data
 	}
+
+	// The org autofill blocklist travels with the policies so clients fetch it in one call
+	if s.excludedDomainRepo != nil {
+		excluded, err := s.excludedDomainRepo.ListByOrganization(ctx, orgID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list excluded domains: %w", err)
+		}
+		if len(excluded) > 0 {
+			entries := make([]interface{}, len(excluded))
+			for i, ed := range excluded {
+				entries[i] = map[string]interface{}{
+					"domain":             ed.Domain,
+					"include_subdomains": ed.IncludeSubdomains,
+				}
+			}
+			summary[domain.PolicyExcludedDomains] = domain.PolicyData{"domains": entries}
+		}
+	}
 	return summary, nil
 }
 
 // --- Helpers ---
 
-func (s *organizationPolicyService) requireOrgAdmin(ctx context.Context, orgID, userID uint) error {
+func (s *organizationPolicyService) requirePermission(ctx context.Context, orgID, userID uint, perm domain.OrgPermission) error {
 	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
@@ -294,7 +316,7 @@ func (s *organizationPolicyService) requireOrgAdmin(ctx context.Context, orgID,
 		}
 		return err
 	}
-	if !orgUser.IsAdmin() {
+	if !authz.HasPermission(orgUser, perm) {
 		return repository.ErrForbidden
 	}
 	return nil
@@ -170,7 +170,7 @@ func newPolicyTestSetup(plan string) *policyTestSetup {
 
 	subRepo.setOrgPlan(policyTestOrgID, plan)
 
-	svc := NewOrganizationPolicyService(policyRepo, orgUserRepo, subRepo, noopLogger{})
+	svc := NewOrganizationPolicyService(policyRepo, orgUserRepo, subRepo, nil, noopLogger{})
 
 	return &policyTestSetup{
 		service:     svc,
@@ -909,3 +909,27 @@ func TestListPolicies_PlanCodeWithYearlySuffix(t *testing.T) {
 	assert.Equal(t, len(domain.AllPolicyDefinitions()), len(policies),
 		"enterprise-yearly should resolve to enterprise tier and show all policies")
 }
+
+func TestGetActivePolicySummary_IncludesExcludedDomains(t *testing.T) {
+	t.Parallel()
+	policyRepo := newFakePolicyRepo()
+	orgUserRepo := newFakeOrgUserRepo()
+	orgUserRepo.add(&domain.OrganizationUser{
+		OrganizationID: policyTestOrgID,
+		UserID:         policyTestMemberID,
+		Role:           domain.OrgRoleMember,
+		Status:         domain.OrgUserStatusAccepted,
+	})
+	excluded := &fakeOrgExcludedDomainRepo{domains: []*domain.OrganizationExcludedDomain{
+		{OrganizationID: policyTestOrgID, Domain: "*.corp.example.com"},
+		{OrganizationID: 999, Domain: "other.example.com"},
+	}}
+	svc := NewOrganizationPolicyService(policyRepo, orgUserRepo, newFakeSubRepo(), excluded, noopLogger{})
+
+	summary, err := svc.GetActivePolicySummary(context.Background(), policyTestOrgID, policyTestMemberID)
+	require.NoError(t, err)
+	require.Contains(t, summary, domain.PolicyExcludedDomains)
+	entries := summary[domain.PolicyExcludedDomains]["domains"].([]interface{})
+	require.Len(t, entries, 1)
+	assert.Equal(t, "*.corp.example.com", entries[0].(map[string]interface{})["domain"])
+}
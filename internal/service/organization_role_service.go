@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/passwall/passwall-server/internal/authz"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// OrganizationRoleService manages organization-defined roles. A custom role grants its
+// permissions on top of the member's built-in role; the built-in roles act as presets.
+// Members managing roles can only hand out permissions they hold themselves.
+type OrganizationRoleService interface {
+	GetCatalog(ctx context.Context, orgID, userID uint) (*domain.OrgPermissionCatalogDTO, error)
+	GetMyPermissions(ctx context.Context, orgID, userID uint) (*domain.MyOrgPermissionsDTO, error)
+	List(ctx context.Context, orgID, userID uint) ([]*domain.OrganizationCustomRoleDTO, error)
+	Create(ctx context.Context, orgID, userID uint, req *domain.CreateOrganizationCustomRoleRequest) (*domain.OrganizationCustomRoleDTO, error)
+	Update(ctx context.Context, orgID, userID, roleID uint, req *domain.UpdateOrganizationCustomRoleRequest) (*domain.OrganizationCustomRoleDTO, error)
+	Delete(ctx context.Context, orgID, userID, roleID uint) error
+}
+
+type organizationRoleService struct {
+	roleRepo    repository.OrganizationCustomRoleRepository
+	orgUserRepo interface {
+		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+	}
+	logger Logger
+}
+
+// NewOrganizationRoleService creates a new organization role service
+func NewOrganizationRoleService(
+	roleRepo repository.OrganizationCustomRoleRepository,
+	orgUserRepo interface {
+		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+	},
+	logger Logger,
+) OrganizationRoleService {
+	return &organizationRoleService{
+		roleRepo:    roleRepo,
+		orgUserRepo: orgUserRepo,
+		logger:      logger,
+	}
+}
+
+func (s *organizationRoleService) GetCatalog(ctx context.Context, orgID, userID uint) (*domain.OrgPermissionCatalogDTO, error) {
+	if _, err := s.requireMember(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	presets := make([]domain.OrgRolePresetDTO, 0, 5)
+	for _, role := range []domain.OrganizationRole{
+		domain.OrgRoleOwner, domain.OrgRoleAdmin, domain.OrgRoleManager, domain.OrgRoleBilling, domain.OrgRoleMember,
+	} {
+		presets = append(presets, domain.OrgRolePresetDTO{
+			Role:        role,
+			Permissions: authz.EffectivePermissions(&domain.OrganizationUser{Role: role}),
+		})
+	}
+
+	return &domain.OrgPermissionCatalogDTO{
+		Permissions: domain.OrgPermissionCatalog,
+		Presets:     presets,
+	}, nil
+}
+
+func (s *organizationRoleService) GetMyPermissions(ctx context.Context, orgID, userID uint) (*domain.MyOrgPermissionsDTO, error) {
+	orgUser, err := s.requireMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MyOrgPermissionsDTO{
+		Role:         orgUser.Role,
+		CustomRoleID: orgUser.CustomRoleID,
+		Permissions:  authz.EffectivePermissions(orgUser),
+	}, nil
+}
+
+func (s *organizationRoleService) List(ctx context.Context, orgID, userID uint) ([]*domain.OrganizationCustomRoleDTO, error) {
+	if _, err := s.requireMember(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.roleRepo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom roles: %w", err)
+	}
+	counts, err := s.roleRepo.CountMembers(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count role members: %w", err)
+	}
+
+	dtos := make([]*domain.OrganizationCustomRoleDTO, len(roles))
+	for i, role := range roles {
+		dtos[i] = domain.ToOrganizationCustomRoleDTO(role)
+		dtos[i].MemberCount = counts[role.ID]
+	}
+	return dtos, nil
+}
+
+func (s *organizationRoleService) Create(ctx context.Context, orgID, userID uint, req *domain.CreateOrganizationCustomRoleRequest) (*domain.OrganizationCustomRoleDTO, error) {
+	manager, err := s.requireManager(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", repository.ErrInvalidInput)
+	}
+	if err := s.checkGrantable(manager, req.Permissions); err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameAvailable(ctx, orgID, name, 0); err != nil {
+		return nil, err
+	}
+
+	role := &domain.OrganizationCustomRole{
+		OrganizationID:  orgID,
+		Name:            name,
+		Description:     strings.TrimSpace(req.Description),
+		Permissions:     domain.JoinOrgPermissions(req.Permissions),
+		CreatedByUserID: &userID,
+	}
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		s.logger.Error("failed to create custom role", "org_id", orgID, "name", name, "error", err)
+		return nil, fmt.Errorf("failed to create custom role: %w", err)
+	}
+
+	s.logger.Info("custom role created", "org_id", orgID, "role_id", role.ID, "user_id", userID)
+	return domain.ToOrganizationCustomRoleDTO(role), nil
+}
+
+func (s *organizationRoleService) Update(ctx context.Context, orgID, userID, roleID uint, req *domain.UpdateOrganizationCustomRoleRequest) (*domain.OrganizationCustomRoleDTO, error) {
+	manager, err := s.requireManager(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, orgID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	// Editing a role that holds more than the editor would let them strip or reshape it
+	if !authz.CanGrant(manager, role.PermissionList()) {
+		return nil, repository.ErrForbidden
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name is required", repository.ErrInvalidInput)
+		}
+		if err := s.ensureNameAvailable(ctx, orgID, name, role.ID); err != nil {
+			return nil, err
+		}
+		role.Name = name
+	}
+	if req.Description != nil {
+		role.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Permissions != nil {
+		if err := s.checkGrantable(manager, *req.Permissions); err != nil {
+			return nil, err
+		}
+		role.Permissions = domain.JoinOrgPermissions(*req.Permissions)
+	}
+
+	if err := s.roleRepo.Update(ctx, role); err != nil {
+		s.logger.Error("failed to update custom role", "org_id", orgID, "role_id", roleID, "error", err)
+		return nil, fmt.Errorf("failed to update custom role: %w", err)
+	}
+
+	s.logger.Info("custom role updated", "org_id", orgID, "role_id", roleID, "user_id", userID)
+	return domain.ToOrganizationCustomRoleDTO(role), nil
+}
+
+func (s *organizationRoleService) Delete(ctx context.Context, orgID, userID, roleID uint) error {
+	manager, err := s.requireManager(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	role, err := s.roleRepo.GetByID(ctx, orgID, roleID)
+	if err != nil {
+		return err
+	}
+	if !authz.CanGrant(manager, role.PermissionList()) {
+		return repository.ErrForbidden
+	}
+
+	if err := s.roleRepo.Delete(ctx, orgID, roleID); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			s.logger.Error("failed to delete custom role", "org_id", orgID, "role_id", roleID, "error", err)
+		}
+		return err
+	}
+
+	s.logger.Info("custom role deleted", "org_id", orgID, "role_id", roleID, "user_id", userID)
+	return nil
+}
+
+func (s *organizationRoleService) checkGrantable(manager *domain.OrganizationUser, perms []domain.OrgPermission) error {
+	if err := validateOrgPermissions(perms); err != nil {
+		return err
+	}
+	if !authz.CanGrant(manager, perms) {
+		return repository.ErrForbidden
+	}
+	return nil
+}
+
+func (s *organizationRoleService) ensureNameAvailable(ctx context.Context, orgID uint, name string, exceptID uint) error {
+	existing, err := s.roleRepo.GetByName(ctx, orgID, name)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	if existing != nil && existing.ID != exceptID {
+		return repository.ErrAlreadyExists
+	}
+	return nil
+}
+
+func (s *organizationRoleService) requireMember(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error) {
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, repository.ErrForbidden
+		}
+		return nil, err
+	}
+	return orgUser, nil
+}
+
+// requireManager returns the requester's membership if they may manage members and roles
+func (s *organizationRoleService) requireManager(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error) {
+	orgUser, err := s.requireMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !authz.HasPermission(orgUser, domain.OrgPermManageMembers) {
+		return nil, repository.ErrForbidden
+	}
+	return orgUser, nil
+}
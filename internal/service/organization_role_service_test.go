@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// fakeCustomRoleRepo keeps custom roles by ID
+type fakeCustomRoleRepo struct {
+	repository.OrganizationCustomRoleRepository
+	roles  map[uint]*domain.OrganizationCustomRole
+	nextID uint
+}
+
+func (f *fakeCustomRoleRepo) Create(_ context.Context, role *domain.OrganizationCustomRole) error {
+	f.nextID++
+	role.ID = f.nextID
+	f.roles[role.ID] = role
+	return nil
+}
+func (f *fakeCustomRoleRepo) GetByID(_ context.Context, orgID, id uint) (*domain.OrganizationCustomRole, error) {
+	if r, ok := f.roles[id]; ok && r.OrganizationID == orgID {
+		return r, nil
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeCustomRoleRepo) GetByName(_ context.Context, orgID uint, name string) (*domain.OrganizationCustomRole, error) {
+	for _, r := range f.roles {
+		if r.OrganizationID == orgID && r.Name == name {
+			return r, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeCustomRoleRepo) Update(_ context.Context, role *domain.OrganizationCustomRole) error {
+	f.roles[role.ID] = role
+	return nil
+}
+func (f *fakeCustomRoleRepo) Delete(_ context.Context, orgID, id uint) error {
+	if _, err := f.GetByID(context.Background(), orgID, id); err != nil {
+		return err
+	}
+	delete(f.roles, id)
+	return nil
+}
+
+func newRoleServiceFixture() (*organizationRoleService, *fakeCustomRoleRepo) {
+	roles := &fakeCustomRoleRepo{roles: map[uint]*domain.OrganizationCustomRole{}}
+	delegate := &domain.OrganizationCustomRole{OrganizationID: 1, Name: "People ops", Permissions: "manage_members,view_members"}
+	_ = roles.Create(context.Background(), delegate)
+
+	members := &suspensionMemberRepo{members: map[uint]*domain.OrganizationUser{
+		10: {ID: 10, OrganizationID: 1, UserID: 100, Role: domain.OrgRoleAdmin, Status: domain.OrgUserStatusConfirmed},
+		11: {ID: 11, OrganizationID: 1, UserID: 101, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed, CustomRoleID: &delegate.ID, CustomRole: delegate},
+		12: {ID: 12, OrganizationID: 1, UserID: 102, Role: domain.OrgRoleManager, Status: domain.OrgUserStatusConfirmed},
+	}}
+	svc := NewOrganizationRoleService(roles, members, noopLogger{}).(*organizationRoleService)
+	return svc, roles
+}
+
+func TestOrganizationRole_CreateAndUpdate(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newRoleServiceFixture()
+
+	role, err := svc.Create(ctx, 1, 100, &domain.CreateOrganizationCustomRoleRequest{
+		Name:        " Security ",
+		Permissions: []domain.OrgPermission{domain.OrgPermViewAudit, domain.OrgPermManageSSO, domain.OrgPermViewAudit},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Security", role.Name)
+	assert.Equal(t, []domain.OrgPermission{domain.OrgPermManageSSO, domain.OrgPermViewAudit}, role.Permissions)
+
+	_, err = svc.Create(ctx, 1, 100, &domain.CreateOrganizationCustomRoleRequest{Name: "Security"})
+	assert.ErrorIs(t, err, repository.ErrAlreadyExists)
+
+	_, err = svc.Create(ctx, 1, 100, &domain.CreateOrganizationCustomRoleRequest{Name: "Bad", Permissions: []domain.OrgPermission{"root"}})
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+
+	perms := []domain.OrgPermission{domain.OrgPermApproveDevices}
+	updated, err := svc.Update(ctx, 1, 100, role.ID, &domain.UpdateOrganizationCustomRoleRequest{Permissions: &perms})
+	require.NoError(t, err)
+	assert.Equal(t, perms, updated.Permissions)
+}
+
+func TestOrganizationRole_NoEscalation(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newRoleServiceFixture()
+
+	// Delegated member managers can only hand out what they hold
+	_, err := svc.Create(ctx, 1, 101, &domain.CreateOrganizationCustomRoleRequest{Name: "Viewers", Permissions: []domain.OrgPermission{domain.OrgPermViewMembers}})
+	assert.NoError(t, err)
+	_, err = svc.Create(ctx, 1, 101, &domain.CreateOrganizationCustomRoleRequest{Name: "SSO", Permissions: []domain.OrgPermission{domain.OrgPermManageSSO}})
+	assert.ErrorIs(t, err, repository.ErrForbidden)
+
+	sso, err := svc.Create(ctx, 1, 100, &domain.CreateOrganizationCustomRoleRequest{Name: "SSO", Permissions: []domain.OrgPermission{domain.OrgPermManageSSO}})
+	require.NoError(t, err)
+	assert.ErrorIs(t, svc.Delete(ctx, 1, 101, sso.ID), repository.ErrForbidden, "cannot remove a role holding more than the requester")
+
+	// Managers do not manage members, so they cannot manage roles
+	_, err = svc.Create(ctx, 1, 102, &domain.CreateOrganizationCustomRoleRequest{Name: "Mine"})
+	assert.ErrorIs(t, err, repository.ErrForbidden)
+
+	mine, err := svc.GetMyPermissions(ctx, 1, 101)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.OrgPermission{domain.OrgPermManageMembers, domain.OrgPermViewMembers}, mine.Permissions)
+}
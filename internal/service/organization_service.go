@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
 	"gorm.io/gorm"
@@ -22,8 +23,10 @@ type organizationService struct {
 	collectionUserRepo repository.CollectionUserRepository
 	collectionTeamRepo repository.CollectionTeamRepository
 	policyRepo         repository.OrganizationPolicyRepository
+	customRoleRepo     repository.OrganizationCustomRoleRepository
 	paymentService     PaymentService
 	invitationService  InvitationService
+	seatService        SeatService
 	subRepo            interface {
 		Create(ctx context.Context, sub *domain.Subscription) error
 		GetByOrganizationID(ctx context.Context, orgID uint) (*domain.Subscription, error)
@@ -45,8 +48,10 @@ func NewOrganizationService(
 	collectionUserRepo repository.CollectionUserRepository,
 	collectionTeamRepo repository.CollectionTeamRepository,
 	policyRepo repository.OrganizationPolicyRepository,
+	customRoleRepo repository.OrganizationCustomRoleRepository,
 	paymentService PaymentService,
 	invitationService InvitationService,
+	seatService SeatService,
 	subRepo interface {
 		Create(ctx context.Context, sub *domain.Subscription) error
 		GetByOrganizationID(ctx context.Context, orgID uint) (*domain.Subscription, error)
@@ -66,8 +71,10 @@ func NewOrganizationService(
 		collectionUserRepo: collectionUserRepo,
 		collectionTeamRepo: collectionTeamRepo,
 		policyRepo:         policyRepo,
+		customRoleRepo:     customRoleRepo,
 		paymentService:     paymentService,
 		invitationService:  invitationService,
+		seatService:        seatService,
 		subRepo:            subRepo,
 		planRepo:           planRepo,
 		logger:             logger,
@@ -332,8 +339,7 @@ func (s *organizationService) List(ctx context.Context, userID uint) ([]*domain.
 }
 
 func (s *organizationService) Update(ctx context.Context, id uint, userID uint, req *domain.UpdateOrganizationRequest) (*domain.Organization, error) {
-	// Check if user is owner or admin
-	if err := s.checkPermission(ctx, id, userID, true); err != nil {
+	if err := s.checkPermission(ctx, id, userID, domain.OrgPermManageSettings); err != nil {
 		return nil, err
 	}
 
@@ -412,7 +418,7 @@ func (s *organizationService) InviteUser(ctx context.Context, orgID uint, invite
 	}
 
 	// Check if inviter can manage users
-	if err := s.checkPermission(ctx, orgID, inviterUserID, true); err != nil {
+	if err := s.checkPermission(ctx, orgID, inviterUserID, domain.OrgPermManageMembers); err != nil {
 		return nil, err
 	}
 
@@ -424,6 +430,9 @@ func (s *organizationService) InviteUser(ctx context.Context, orgID uint, invite
 	if req.Role == domain.OrgRoleOwner && inviterMembership.Role != domain.OrgRoleOwner {
 		return nil, repository.ErrForbidden
 	}
+	if !authz.CanGrant(inviterMembership, domain.OrgRolePresets[req.Role]) {
+		return nil, repository.ErrForbidden
+	}
 
 	// Check organization limits
 	memberCount, err := s.orgRepo.GetMemberCount(ctx, orgID)
@@ -438,7 +447,14 @@ func (s *organizationService) InviteUser(ctx context.Context, orgID uint, invite
 	}
 
 	if memberCount >= maxUsers {
-		return nil, fmt.Errorf("organization has reached max users limit (%d)", maxUsers)
+		// Automatic seat management buys the seat when the invitation is accepted
+		canAddSeat, err := s.seatService.CanAddSeat(ctx, orgID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check seats: %w", err)
+		}
+		if !canAddSeat {
+			return nil, fmt.Errorf("organization has reached max users limit (%d)", maxUsers)
+		}
 	}
 
 	// Get invitee user by email (must be already registered)
@@ -539,7 +555,7 @@ func (s *organizationService) UpdateMemberRole(ctx context.Context, orgID, orgUs
 	}
 
 	// Check if requesting user can manage users
-	if err := s.checkPermission(ctx, orgID, requestingUserID, true); err != nil {
+	if err := s.checkPermission(ctx, orgID, requestingUserID, domain.OrgPermManageMembers); err != nil {
 		return err
 	}
 
@@ -556,12 +572,49 @@ func (s *organizationService) UpdateMemberRole(ctx context.Context, orgID, orgUs
 	if err != nil {
 		return fmt.Errorf("member not found: %w", err)
 	}
+	if orgUser.OrganizationID != orgID {
+		return repository.ErrForbidden
+	}
 
 	// Cannot change owner role
 	if orgUser.Role == domain.OrgRoleOwner {
 		return fmt.Errorf("cannot change owner role")
 	}
 
+	// Members with delegated member management cannot touch members holding more
+	// than they do, nor hand out permissions they lack
+	if !authz.CanGrant(requesterMembership, authz.EffectivePermissions(orgUser)) ||
+		!authz.CanGrant(requesterMembership, domain.OrgRolePresets[req.Role]) {
+		return repository.ErrForbidden
+	}
+
+	if req.CustomRoleID != nil {
+		if *req.CustomRoleID == 0 {
+			orgUser.CustomRoleID = nil
+		} else {
+			role, err := s.customRoleRepo.GetByID(ctx, orgID, *req.CustomRoleID)
+			if err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					return fmt.Errorf("%w: custom role not found", repository.ErrInvalidInput)
+				}
+				return fmt.Errorf("failed to get custom role: %w", err)
+			}
+			if !authz.CanGrant(requesterMembership, role.PermissionList()) {
+				return repository.ErrForbidden
+			}
+			orgUser.CustomRoleID = &role.ID
+		}
+	}
+	if req.Permissions != nil {
+		if err := validateOrgPermissions(*req.Permissions); err != nil {
+			return err
+		}
+		if !authz.CanGrant(requesterMembership, *req.Permissions) {
+			return repository.ErrForbidden
+		}
+		orgUser.Permissions = domain.JoinOrgPermissions(*req.Permissions)
+	}
+
 	// Update role
 	orgUser.Role = req.Role
 	if req.AccessAll != nil {
@@ -573,13 +626,13 @@ func (s *organizationService) UpdateMemberRole(ctx context.Context, orgID, orgUs
 		return fmt.Errorf("failed to update member role: %w", err)
 	}
 
-	s.logger.Info("member role updated", "org_id", orgID, "org_user_id", orgUserID, "new_role", req.Role)
+	s.logger.Info("member role updated", "org_id", orgID, "org_user_id", orgUserID, "new_role", req.Role, "custom_role_id", orgUser.CustomRoleID)
 	return nil
 }
 
 func (s *organizationService) RemoveMember(ctx context.Context, orgID, orgUserID uint, requestingUserID uint) error {
 	// Check if requesting user can manage users
-	if err := s.checkPermission(ctx, orgID, requestingUserID, true); err != nil {
+	if err := s.checkPermission(ctx, orgID, requestingUserID, domain.OrgPermManageMembers); err != nil {
 		return err
 	}
 
@@ -598,6 +651,39 @@ func (s *organizationService) RemoveMember(ctx context.Context, orgID, orgUserID
 		return fmt.Errorf("cannot remove owner from organization")
 	}
 
+	requesterMembership, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, requestingUserID)
+	if err != nil {
+		return repository.ErrForbidden
+	}
+	if !authz.CanGrant(requesterMembership, authz.EffectivePermissions(orgUser)) {
+		return repository.ErrForbidden
+	}
+
+	return s.deleteMembership(ctx, orgID, orgUser, SeatReasonMemberRemoved)
+}
+
+// RemoveSuspendedMember removes a member that is still suspended. It is called by the
+// suspended-member cleanup worker, so there is no requesting user to authorize.
+func (s *organizationService) RemoveSuspendedMember(ctx context.Context, orgID, orgUserID uint) error {
+	orgUser, err := s.orgUserRepo.GetByID(ctx, orgUserID)
+	if err != nil {
+		return fmt.Errorf("member not found: %w", err)
+	}
+	if orgUser.OrganizationID != orgID {
+		return repository.ErrForbidden
+	}
+	// Restored in the meantime
+	if !orgUser.IsSuspended() || orgUser.Role == domain.OrgRoleOwner {
+		return repository.ErrInvalidInput
+	}
+
+	return s.deleteMembership(ctx, orgID, orgUser, SeatReasonSuspendedRemoved)
+}
+
+// deleteMembership removes team and collection memberships, then the membership itself
+func (s *organizationService) deleteMembership(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser, seatReason string) error {
+	orgUserID := orgUser.ID
+
 	// Cleanup team memberships before deleting org membership to avoid FK violations
 	// on environments where constraints may not cascade.
 	teamUsers, err := s.teamUserRepo.ListByOrgUser(ctx, orgUserID)
@@ -631,6 +717,10 @@ func (s *organizationService) RemoveMember(ctx context.Context, orgID, orgUserID
 	}
 
 	s.logger.Info("member removed from organization", "org_id", orgID, "org_user_id", orgUserID)
+
+	if err := s.seatService.ReleaseSeats(ctx, orgID, seatReason); err != nil {
+		s.logger.Error("failed to release seats after removing member", "org_id", orgID, "error", err)
+	}
 	return nil
 }
 
@@ -654,6 +744,14 @@ func (s *organizationService) AcceptInvitation(ctx context.Context, orgUserID ui
 		return fmt.Errorf("invitation already processed")
 	}
 
+	// Expiry follows the invitation record; memberships without one predate expiry tracking
+	if orgUser.User != nil {
+		inv, err := s.invitationService.GetOrganizationInvitation(ctx, orgUser.OrganizationID, orgUser.User.Email)
+		if err == nil && inv.IsExpired() {
+			return ErrInvitationExpired
+		}
+	}
+
 	// Re-check Single Organization policy at acceptance time
 	if err := s.checkSingleOrganizationPolicy(ctx, orgUser.OrganizationID, userID); err != nil {
 		return err
@@ -667,7 +765,13 @@ func (s *organizationService) AcceptInvitation(ctx context.Context, orgUserID ui
 	orgUser.Status = domain.OrgUserStatusAccepted
 	orgUser.AcceptedAt = &now
 
-	if err := s.orgUserRepo.Update(ctx, orgUser); err != nil {
+	err = s.seatService.WithSeat(ctx, orgUser.OrganizationID, SeatReasonInvitationAccepted, func() error {
+		return s.orgUserRepo.Update(ctx, orgUser)
+	})
+	if err != nil {
+		if errors.Is(err, ErrSeatLimitReached) || errors.Is(err, ErrSeatCapReached) {
+			return err
+		}
 		s.logger.Error("failed to accept invitation", "org_user_id", orgUserID, "error", err)
 		return fmt.Errorf("failed to accept invitation: %w", err)
 	}
@@ -708,7 +812,7 @@ func (s *organizationService) ConfirmProvisionedMember(ctx context.Context, orgI
 	}
 
 	// Check if requesting user can manage users (owner or admin)
-	if err := s.checkPermission(ctx, orgID, requestingUserID, true); err != nil {
+	if err := s.checkPermission(ctx, orgID, requestingUserID, domain.OrgPermManageMembers); err != nil {
 		return err
 	}
 
@@ -770,7 +874,13 @@ func (s *organizationService) AddExistingMember(ctx context.Context, orgUser *do
 			existing.AcceptedAt = &now
 			// Keep the org role / access_all from the existing record to avoid privilege escalation
 			// from any client-controlled invitation metadata.
-			if err := s.orgUserRepo.Update(ctx, existing); err != nil {
+			err := s.seatService.WithSeat(ctx, existing.OrganizationID, SeatReasonInvitationAccepted, func() error {
+				return s.orgUserRepo.Update(ctx, existing)
+			})
+			if err != nil {
+				if errors.Is(err, ErrSeatLimitReached) || errors.Is(err, ErrSeatCapReached) {
+					return err
+				}
 				return fmt.Errorf("failed to accept existing invitation: %w", err)
 			}
 
@@ -794,7 +904,13 @@ func (s *organizationService) AddExistingMember(ctx context.Context, orgUser *do
 	}
 
 	// Create organization user membership
-	if err := s.orgUserRepo.Create(ctx, orgUser); err != nil {
+	err = s.seatService.WithSeat(ctx, orgUser.OrganizationID, SeatReasonMemberAdded, func() error {
+		return s.orgUserRepo.Create(ctx, orgUser)
+	})
+	if err != nil {
+		if errors.Is(err, ErrSeatLimitReached) || errors.Is(err, ErrSeatCapReached) {
+			return err
+		}
 		s.logger.Error("failed to add existing member",
 			"org_id", orgUser.OrganizationID,
 			"user_id", orgUser.UserID,
@@ -876,7 +992,7 @@ func (s *organizationService) GetCollectionCount(ctx context.Context, orgID uint
 	return s.orgRepo.GetCollectionCount(ctx, orgID)
 }
 
-func (s *organizationService) checkPermission(ctx context.Context, orgID, userID uint, requireAdmin bool) error {
+func (s *organizationService) checkPermission(ctx context.Context, orgID, userID uint, perm domain.OrgPermission) error {
 	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
 	if err != nil {
 		if err == repository.ErrNotFound {
@@ -885,13 +1001,23 @@ func (s *organizationService) checkPermission(ctx context.Context, orgID, userID
 		return err
 	}
 
-	if requireAdmin && !orgUser.IsAdmin() {
+	if !authz.HasPermission(orgUser, perm) {
 		return repository.ErrForbidden
 	}
 
 	return nil
 }
 
+// validateOrgPermissions rejects permissions that are not in the catalog
+func validateOrgPermissions(perms []domain.OrgPermission) error {
+	for _, p := range perms {
+		if !domain.IsValidOrgPermission(p) {
+			return fmt.Errorf("%w: unknown permission %q", repository.ErrInvalidInput, p)
+		}
+	}
+	return nil
+}
+
 // getMaxUsers returns max users limit from subscription plan
 func (s *organizationService) getMaxUsers(ctx context.Context, orgID uint) (int, error) {
 	sub, err := s.subRepo.GetByOrganizationID(ctx, orgID)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
 )
@@ -54,7 +55,7 @@ func (s *organizationSettingsService) ListByOrganization(ctx context.Context, or
 }
 
 func (s *organizationSettingsService) UpsertForOrganization(ctx context.Context, orgID, userID uint, req *domain.UpsertPreferencesRequest) ([]*domain.PreferenceDTO, error) {
-	if err := s.requireSettingsAdmin(ctx, orgID, userID); err != nil {
+	if err := s.requireSettingsManager(ctx, orgID, userID); err != nil {
 		return nil, err
 	}
 
@@ -85,6 +86,19 @@ func (s *organizationSettingsService) UpsertForOrganization(ctx context.Context,
 		if err := validatePreferenceValue(typ, val); err != nil {
 			return nil, repository.ErrInvalidInput
 		}
+		if section == domain.OrgSettingSectionBilling {
+			switch key {
+			case domain.OrgSettingKeySeatManagementMode:
+				if !domain.IsValidSeatManagementMode(val) {
+					return nil, repository.ErrInvalidInput
+				}
+			case domain.OrgSettingKeySeatAutoMaxSeats:
+				// The cap bounds automatic seat purchases, so it needs manage_billing
+				if err := s.requireBillingManager(ctx, orgID, userID); err != nil {
+					return nil, err
+				}
+			}
+		}
 
 		prefs = append(prefs, &domain.Preference{
 			OwnerType: domain.OrgSettingOwnerType,
@@ -129,16 +143,27 @@ func (s *organizationSettingsService) requireSettingsAccess(ctx context.Context,
 		}
 		return err
 	}
-	// Owner, Admin, Manager can view settings
-	if orgUser.Role != domain.OrgRoleOwner &&
-		orgUser.Role != domain.OrgRoleAdmin &&
-		orgUser.Role != domain.OrgRoleManager {
+	if !authz.HasPermission(orgUser, domain.OrgPermViewSettings) {
+		return repository.ErrForbidden
+	}
+	return nil
+}
+
+func (s *organizationSettingsService) requireSettingsManager(ctx context.Context, orgID, userID uint) error {
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return repository.ErrForbidden
+		}
+		return err
+	}
+	if !authz.HasPermission(orgUser, domain.OrgPermManageSettings) {
 		return repository.ErrForbidden
 	}
 	return nil
 }
 
-func (s *organizationSettingsService) requireSettingsAdmin(ctx context.Context, orgID, userID uint) error {
+func (s *organizationSettingsService) requireBillingManager(ctx context.Context, orgID, userID uint) error {
 	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
@@ -146,7 +171,7 @@ func (s *organizationSettingsService) requireSettingsAdmin(ctx context.Context,
 		}
 		return err
 	}
-	if !orgUser.IsAdmin() {
+	if !authz.HasPermission(orgUser, domain.OrgPermManageBilling) {
 		return repository.ErrForbidden
 	}
 	return nil
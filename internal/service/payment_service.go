@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/config"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
@@ -27,6 +29,7 @@ type paymentService struct {
 	planRepo            interface {
 		GetByCode(ctx context.Context, code string) (*domain.Plan, error)
 	}
+	promotions     PromotionService
 	activityLogger *ActivityLogger
 	config         *config.Config
 	logger         Logger
@@ -42,6 +45,7 @@ func NewPaymentService(
 	planRepo interface {
 		GetByCode(ctx context.Context, code string) (*domain.Plan, error)
 	},
+	promotions PromotionService,
 	activityService UserActivityService,
 	config *config.Config,
 	logger Logger,
@@ -53,6 +57,7 @@ func NewPaymentService(
 		userRepo:            userRepo,
 		subscriptionService: subscriptionService,
 		planRepo:            planRepo,
+		promotions:          promotions,
 		activityLogger:      NewActivityLogger(activityService),
 		config:              config,
 		logger:              logger,
@@ -60,7 +65,7 @@ func NewPaymentService(
 }
 
 // CreateCheckoutSession creates a Stripe checkout session for an organization
-func (s *paymentService) CreateCheckoutSession(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int, ipAddress, userAgent string) (string, error) {
+func (s *paymentService) CreateCheckoutSession(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int, promoCode, ipAddress, userAgent string) (string, error) {
 	// Get organization
 	org, err := s.orgRepo.GetByID(ctx, orgID)
 	if err != nil {
@@ -98,6 +103,23 @@ func (s *paymentService) CreateCheckoutSession(ctx context.Context, orgID, userI
 		return "", err
 	}
 
+	// Validate promo code before touching Stripe
+	var promo *domain.PromoCode
+	if strings.TrimSpace(promoCode) != "" {
+		promo, err = s.promotions.ValidatePromoCode(ctx, orgID, promoCode, plan)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Admin-granted trial extensions are added on top of the plan trial
+	trialDays := planConfig.TrialDays
+	extensionDays, extensions, err := s.promotions.PendingTrialDays(ctx, orgID)
+	if err != nil {
+		s.logger.Warn("failed to load pending trial extensions", "org_id", orgID, "error", err)
+	}
+	trialDays += extensionDays
+
 	// Get or create Stripe customer
 	customerID := ""
 	if org.StripeCustomerID != nil && *org.StripeCustomerID != "" {
@@ -141,7 +163,7 @@ func (s *paymentService) CreateCheckoutSession(ctx context.Context, orgID, userI
 		quantity = 1
 	}
 
-	session, err := s.stripe.CreateCheckoutSession(stripeClient.CheckoutSessionParams{
+	checkoutParams := stripeClient.CheckoutSessionParams{
 		CustomerID:   customerID,
 		PriceID:      planConfig.StripePriceID,
 		Quantity:     quantity,
@@ -151,12 +173,30 @@ func (s *paymentService) CreateCheckoutSession(ctx context.Context, orgID, userI
 		OrgName:      org.Name,
 		Plan:         plan,
 		BillingCycle: billingCycle,
-		TrialDays:    planConfig.TrialDays, // Trial period from config
-	})
+		TrialDays:    trialDays, // Trial period from config plus pending extensions
+		Metadata:     map[string]string{},
+	}
+	if extensionDays > 0 {
+		checkoutParams.Metadata["trial_extension_days"] = fmt.Sprintf("%d", extensionDays)
+		checkoutParams.Metadata["trial_extension_ids"] = formatTrialExtensionIDs(extensions)
+	}
+	if promo != nil {
+		checkoutParams.CouponID = *promo.StripeCouponID
+		checkoutParams.Metadata["promo_code"] = promo.Code
+	}
+
+	session, err := s.stripe.CreateCheckoutSession(checkoutParams)
 	if err != nil {
 		return "", fmt.Errorf("failed to create checkout session: %w", err)
 	}
 
+	// Promo redemption is confirmed when Stripe reports the checkout as completed
+	if promo != nil {
+		if err := s.promotions.RecordPendingRedemption(ctx, promo, orgID, userID, plan+"-"+billingCycle, session.ID); err != nil {
+			s.logger.Error("failed to record pending promo redemption", "org_id", orgID, "code", promo.Code, "session_id", session.ID, "error", err)
+		}
+	}
+
 	s.logger.Info("created checkout session", "org_id", orgID, "plan", plan, "billing_cycle", billingCycle, "session_id", session.ID)
 
 	// Log activity
@@ -173,20 +213,8 @@ func (s *paymentService) PreviewSeatChange(ctx context.Context, orgID, userID ui
 		return nil, fmt.Errorf("invalid seats")
 	}
 
-	// Authorization
-	members, err := s.orgUserRepo.ListByOrganization(ctx, orgID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load organization members: %w", err)
-	}
-	var role domain.OrganizationRole
-	for _, m := range members {
-		if m.UserID == userID {
-			role = m.Role
-			break
-		}
-	}
-	if role == "" || (role != domain.OrgRoleOwner && role != domain.OrgRoleAdmin) {
-		return nil, fmt.Errorf("forbidden")
+	if _, err := s.authorizeBillingManager(ctx, orgID, userID); err != nil {
+		return nil, err
 	}
 
 	sub, err := s.subscriptionService.GetByOrganizationID(ctx, orgID)
@@ -197,37 +225,12 @@ func (s *paymentService) PreviewSeatChange(ctx context.Context, orgID, userID ui
 		return nil, fmt.Errorf("subscription not found or has no Stripe ID")
 	}
 
-	currentSeats := 0
-	if sub.SeatsPurchased != nil {
-		currentSeats = *sub.SeatsPurchased
-	}
-
 	inv, err := s.stripe.PreviewSeatChange(*sub.StripeSubscriptionID, int64(seats))
 	if err != nil {
 		return nil, err
 	}
 
-	nextBillingDate := ""
-	if sub.RenewAt != nil {
-		nextBillingDate = sub.RenewAt.Format("2006-01-02")
-	}
-
-	// Prorated amount is the difference between the upcoming total and the current subscription amount
-	proratedAmount := int64(0)
-	for _, line := range inv.Lines.Data {
-		if line.Proration {
-			proratedAmount += line.Amount
-		}
-	}
-
-	return &domain.SeatChangePreview{
-		CurrentSeats:      currentSeats,
-		RequestedSeats:    seats,
-		ProratedAmount:    proratedAmount,
-		Currency:          string(inv.Currency),
-		NextBillingDate:   nextBillingDate,
-		NextBillingAmount: inv.Total,
-	}, nil
+	return newSeatChangePreview(sub, seats, inv), nil
 }
 
 func (s *paymentService) UpdateSubscriptionSeats(ctx context.Context, orgID, userID uint, seats int, ipAddress, userAgent string) error {
@@ -235,24 +238,10 @@ func (s *paymentService) UpdateSubscriptionSeats(ctx context.Context, orgID, use
 		return fmt.Errorf("invalid seats")
 	}
 
-	// Basic authorization: require org membership and owner/admin role.
-	// (Payment routes are sensitive; keep this check here until a dedicated middleware exists.)
-	members, err := s.orgUserRepo.ListByOrganization(ctx, orgID)
+	// Payment routes are sensitive: check manage_billing here as well as in the handler.
+	members, err := s.authorizeBillingManager(ctx, orgID, userID)
 	if err != nil {
-		return fmt.Errorf("failed to load organization members: %w", err)
-	}
-	var role domain.OrganizationRole
-	for _, m := range members {
-		if m.UserID == userID {
-			role = m.Role
-			break
-		}
-	}
-	if role == "" {
-		return fmt.Errorf("forbidden")
-	}
-	if role != domain.OrgRoleOwner && role != domain.OrgRoleAdmin {
-		return fmt.Errorf("forbidden")
+		return err
 	}
 
 	// Enforce minimum seats: cannot set fewer seats than current members.
@@ -273,8 +262,7 @@ func (s *paymentService) UpdateSubscriptionSeats(ctx context.Context, orgID, use
 
 	// Only allow seat changes for seat-based plans (family/team/business).
 	// Pro/free are not seat-based in our pricing model.
-	base := strings.Split(sub.Plan.Code, "-")[0]
-	if base != "family" && base != "team" && base != "business" {
+	if !domain.IsSeatBasedPlan(sub.Plan.Code) {
 		return fmt.Errorf("plan does not support seat changes")
 	}
 
@@ -306,8 +294,8 @@ func (s *paymentService) UpdateSubscriptionSeats(ctx context.Context, orgID, use
 
 // PreviewPlanChange returns the prorated cost of switching to a different plan
 // without actually applying the change. Only works for existing Stripe subscribers.
-func (s *paymentService) PreviewPlanChange(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int) (*domain.PlanChangePreview, error) {
-	if err := s.authorizeOwnerOrAdmin(ctx, orgID, userID); err != nil {
+func (s *paymentService) PreviewPlanChange(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int, promoCode string) (*domain.PlanChangePreview, error) {
+	if _, err := s.authorizeBillingManager(ctx, orgID, userID); err != nil {
 		return nil, err
 	}
 
@@ -336,7 +324,17 @@ func (s *paymentService) PreviewPlanChange(ctx context.Context, orgID, userID ui
 		seats = 1
 	}
 
-	inv, err := s.stripe.PreviewPlanChange(*sub.StripeSubscriptionID, planConfig.StripePriceID, int64(seats))
+	var promo *domain.PromoCode
+	couponID := ""
+	if strings.TrimSpace(promoCode) != "" {
+		promo, err = s.promotions.ValidatePromoCode(ctx, orgID, promoCode, plan)
+		if err != nil {
+			return nil, err
+		}
+		couponID = *promo.StripeCouponID
+	}
+
+	inv, err := s.stripe.PreviewPlanChange(*sub.StripeSubscriptionID, planConfig.StripePriceID, int64(seats), couponID)
 	if err != nil {
 		return nil, err
 	}
@@ -366,6 +364,8 @@ func (s *paymentService) PreviewPlanChange(ctx context.Context, orgID, userID ui
 		NextBillingDate:   nextBillingDate,
 		NextBillingAmount: inv.Total,
 		ImmediateCharge:   proratedAmount > 0,
+		Discount:          domain.ToDiscountDTO(promo, nil),
+		DiscountAmount:    stripeClient.TotalDiscountAmount(inv),
 	}, nil
 }
 
@@ -373,8 +373,8 @@ func (s *paymentService) PreviewPlanChange(ctx context.Context, orgID, userID ui
 // This swaps the price item in-place with prorated billing — no new checkout
 // session is created. This is the industry-standard approach used by 1Password,
 // Bitwarden, and other SaaS products.
-func (s *paymentService) ChangePlan(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int, ipAddress, userAgent string) (*domain.PlanChangeResult, error) {
-	if err := s.authorizeOwnerOrAdmin(ctx, orgID, userID); err != nil {
+func (s *paymentService) ChangePlan(ctx context.Context, orgID, userID uint, plan, billingCycle string, seats int, promoCode, ipAddress, userAgent string) (*domain.PlanChangeResult, error) {
+	if _, err := s.authorizeBillingManager(ctx, orgID, userID); err != nil {
 		return nil, err
 	}
 
@@ -414,16 +414,45 @@ func (s *paymentService) ChangePlan(ctx context.Context, orgID, userID uint, pla
 		return nil, fmt.Errorf("requested seats (%d) exceed plan maximum (%d)", seats, *planConfig.MaxUsers)
 	}
 
+	var promo *domain.PromoCode
+	couponID := ""
+	if strings.TrimSpace(promoCode) != "" {
+		promo, err = s.promotions.ValidatePromoCode(ctx, orgID, promoCode, plan)
+		if err != nil {
+			return nil, err
+		}
+		couponID = *promo.StripeCouponID
+	}
+
 	metadata := map[string]string{
 		"plan":          plan,
 		"billing_cycle": billingCycle,
 	}
 
-	_, err = s.stripe.UpdateSubscriptionPlan(*sub.StripeSubscriptionID, planConfig.StripePriceID, int64(seats), metadata)
+	// Reserve the redemption before Stripe applies the coupon, so exhausted codes are refused
+	var redemption *domain.PromoRedemption
+	if promo != nil {
+		redemption, err = s.promotions.ReserveRedemption(ctx, promo, orgID, userID, domain.PromoRedemptionSourcePlanChange, plan+"-"+billingCycle)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = s.stripe.UpdateSubscriptionPlan(*sub.StripeSubscriptionID, planConfig.StripePriceID, int64(seats), couponID, metadata)
 	if err != nil {
+		if redemption != nil {
+			s.promotions.ReleaseRedemption(ctx, redemption)
+		}
 		return nil, fmt.Errorf("failed to change plan: %w", err)
 	}
 
+	if redemption != nil {
+		if err := s.promotions.ConfirmRedemption(ctx, redemption, sub.RenewAt); err != nil {
+			// Stripe already applied the coupon; keep the audit gap visible in logs
+			s.logger.Error("failed to confirm promo redemption (Stripe already updated)", "org_id", orgID, "code", promo.Code, "err", err)
+		}
+	}
+
 	// Sync DB immediately so the frontend gets fresh data on refetch.
 	// Look up the new plan record and update PlanID + seats in the subscription.
 	newPlanCode := plan + "-" + billingCycle
@@ -449,37 +478,42 @@ func (s *paymentService) ChangePlan(ctx context.Context, orgID, userID uint, pla
 	}, nil
 }
 
-// authorizeOwnerOrAdmin checks if the user is an owner or admin of the organization.
-func (s *paymentService) authorizeOwnerOrAdmin(ctx context.Context, orgID, userID uint) error {
+// authorizeBillingManager checks that the user holds manage_billing in the organization,
+// through their role, a custom role or a direct grant. Returns the organization's members.
+func (s *paymentService) authorizeBillingManager(ctx context.Context, orgID, userID uint) ([]*domain.OrganizationUser, error) {
 	members, err := s.orgUserRepo.ListByOrganization(ctx, orgID)
 	if err != nil {
-		return fmt.Errorf("failed to load organization members: %w", err)
+		return nil, fmt.Errorf("failed to load organization members: %w", err)
 	}
-	var role domain.OrganizationRole
 	for _, m := range members {
-		if m.UserID == userID {
-			role = m.Role
-			break
+		if m.UserID == userID && authz.HasPermission(m, domain.OrgPermManageBilling) {
+			return members, nil
 		}
 	}
-	if role == "" || (role != domain.OrgRoleOwner && role != domain.OrgRoleAdmin) {
-		return fmt.Errorf("forbidden")
-	}
-	return nil
+	return nil, fmt.Errorf("forbidden")
 }
 
-// HandleWebhook handles Stripe webhook events
-func (s *paymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+// ParseWebhook verifies a Stripe webhook signature and returns the event ID and type
+func (s *paymentService) ParseWebhook(payload []byte, signature string) (string, string, error) {
 	s.logger.Info("Stripe webhook received", "payload_size", len(payload))
 
 	// Verify webhook signature
 	event, err := s.stripe.ConstructWebhookEvent(payload, signature)
 	if err != nil {
 		s.logger.Error("Webhook signature verification failed", "error", err)
-		return fmt.Errorf("%w: %v", ErrInvalidStripeWebhookSignature, err)
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidStripeWebhookSignature, err)
 	}
 
 	s.logger.Info("Webhook signature verified", "event_type", event.Type, "event_id", event.ID)
+	return event.ID, string(event.Type), nil
+}
+
+// ProcessWebhookEvent handles a verified Stripe webhook event (called by the webhook inbox)
+func (s *paymentService) ProcessWebhookEvent(ctx context.Context, payload []byte) error {
+	var event stripe.Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("failed to parse webhook event: %w", err)
+	}
 
 	// Handle different event types
 	var handlerErr error
@@ -556,6 +590,31 @@ func (s *paymentService) handleCheckoutCompleted(ctx context.Context, event stri
 		return fmt.Errorf("failed to update organization: %w", err)
 	}
 
+	// Confirm promo code redemption (no-op when the session had no promo code)
+	if session.Metadata["promo_code"] != "" {
+		periodEnd := checkoutPeriodEnd(session.Metadata["billing_cycle"], s.checkoutTrialDays(session))
+		if _, err := s.promotions.ConfirmCheckoutRedemption(ctx, session.ID, &periodEnd); err != nil && !errors.Is(err, repository.ErrNotFound) {
+			s.logger.Error("Failed to confirm promo code redemption", "org_id", orgID, "session_id", session.ID, "error", err)
+		}
+	}
+
+	// Consume the trial extensions that were added to this checkout. Extensions granted
+	// after the session was created stay pending for the next checkout.
+	if applied := parseTrialExtensionIDs(session.Metadata["trial_extension_ids"]); len(applied) > 0 {
+		if _, pending, err := s.promotions.PendingTrialDays(ctx, orgID); err == nil {
+			var exts []*domain.TrialExtension
+			for _, ext := range pending {
+				if applied[ext.ID] {
+					exts = append(exts, ext)
+				}
+			}
+			if len(exts) > 0 {
+				trialEnd := time.Now().AddDate(0, 0, s.checkoutTrialDays(session))
+				s.promotions.MarkTrialExtensionsApplied(ctx, exts, &trialEnd)
+			}
+		}
+	}
+
 	s.logger.Info("✅ Checkout completed successfully", "org_id", orgID, "org_name", org.Name, "customer_id", customerID)
 
 	return nil
@@ -753,8 +812,17 @@ func (s *paymentService) handlePaymentFailed(ctx context.Context, event stripe.E
 	s.logger.Warn("⚠️  Payment failed", "invoice_id", invoice.ID, "customer_id", invoice.Customer.ID,
 		"amount", amount, "currency", currency, "attempt_count", invoice.AttemptCount)
 
-	// Payment failure handling is managed by SubscriptionService
-	// This webhook is logged for audit purposes
+	// Start dunning for the subscription (idempotent across Stripe retries)
+	if invoice.Subscription == nil || invoice.Subscription.ID == "" {
+		return nil
+	}
+	if err := s.subscriptionService.HandlePaymentFailed(ctx, invoice.Subscription.ID); err != nil {
+		if strings.Contains(err.Error(), "record not found") {
+			s.logger.Warn("Subscription not found for failed payment", "stripe_subscription_id", invoice.Subscription.ID)
+			return nil
+		}
+		return err
+	}
 
 	return nil
 }
@@ -932,6 +1000,15 @@ func (s *paymentService) GetBillingInfo(ctx context.Context, orgID uint) (*domai
 		}
 	}
 
+	// Promotions (best-effort)
+	billingInfo.Discount = s.promotions.GetActiveDiscount(ctx, orgID)
+	if exts, err := s.promotions.ListTrialExtensions(ctx, orgID); err == nil && len(exts) > 0 {
+		billingInfo.TrialExtensions = make([]*domain.TrialExtensionDTO, 0, len(exts))
+		for _, ext := range exts {
+			billingInfo.TrialExtensions = append(billingInfo.TrialExtensions, domain.ToTrialExtensionDTO(ext))
+		}
+	}
+
 	if subscription == nil {
 		// Return billing info without subscription
 		return billingInfo, nil
@@ -950,6 +1027,7 @@ func (s *paymentService) GetBillingInfo(ctx context.Context, orgID uint) (*domai
 	}
 	// Always include subscription info so UI can show history/status
 	billingInfo.Subscription = domain.ToSubscriptionDTO(subscription)
+	billingInfo.Dunning = s.subscriptionService.DunningStatus(subscription)
 
 	// Fetch invoices from Stripe if organization has Stripe customer ID
 	if org.StripeCustomerID != nil && *org.StripeCustomerID != "" {
@@ -1056,6 +1134,60 @@ func (s *paymentService) SyncSubscription(ctx context.Context, orgID uint) error
 	return nil
 }
 
+// ValidatePromoCode checks a promo code for an organization and plan before checkout or plan change
+func (s *paymentService) ValidatePromoCode(ctx context.Context, orgID, userID uint, code, plan string) (*domain.DiscountDTO, error) {
+	if _, err := s.authorizeBillingManager(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	promo, err := s.promotions.ValidatePromoCode(ctx, orgID, code, plan)
+	if err != nil {
+		return nil, err
+	}
+	return domain.ToDiscountDTO(promo, nil), nil
+}
+
+// checkoutTrialDays returns the trial days used for a checkout session (plan trial plus extensions)
+func (s *paymentService) checkoutTrialDays(session stripe.CheckoutSession) int {
+	days := 0
+	if cfg, err := s.getPlanConfig(session.Metadata["plan"], session.Metadata["billing_cycle"]); err == nil {
+		days = cfg.TrialDays
+	}
+	var ext int
+	if _, err := fmt.Sscanf(session.Metadata["trial_extension_days"], "%d", &ext); err == nil {
+		days += ext
+	}
+	return days
+}
+
+// formatTrialExtensionIDs lists trial extension IDs for checkout session metadata
+func formatTrialExtensionIDs(exts []*domain.TrialExtension) string {
+	ids := make([]string, 0, len(exts))
+	for _, ext := range exts {
+		ids = append(ids, strconv.FormatUint(uint64(ext.ID), 10))
+	}
+	return strings.Join(ids, ",")
+}
+
+// parseTrialExtensionIDs reads the trial extension IDs stored in checkout session metadata
+func parseTrialExtensionIDs(value string) map[uint]bool {
+	ids := make(map[uint]bool)
+	for _, part := range strings.Split(value, ",") {
+		if id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 64); err == nil && id > 0 {
+			ids[uint(id)] = true
+		}
+	}
+	return ids
+}
+
+// checkoutPeriodEnd estimates the end of the first paid billing period of a new subscription
+func checkoutPeriodEnd(billingCycle string, trialDays int) time.Time {
+	start := time.Now().AddDate(0, 0, trialDays)
+	if billingCycle == string(domain.BillingCycleYearly) {
+		return start.AddDate(1, 0, 0)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
 // getPlanConfig returns the plan config for a plan and billing cycle
 func (s *paymentService) getPlanConfig(plan, billingCycle string) (*config.PlanConfig, error) {
 	// Get plan config from config
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+)
+
+// fakePromotions accepts every promo code
+type fakePromotions struct {
+	PromotionService
+}
+
+func (fakePromotions) ValidatePromoCode(_ context.Context, _ uint, code, _ string) (*domain.PromoCode, error) {
+	return &domain.PromoCode{Code: code}, nil
+}
+
+func TestPaymentService_BillingPermission(t *testing.T) {
+	ctx := context.Background()
+	members := &billingMemberRepo{members: []*domain.OrganizationUser{
+		{OrganizationID: 1, UserID: 1, Role: domain.OrgRoleOwner, Status: domain.OrgUserStatusConfirmed},
+		{OrganizationID: 1, UserID: 2, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed},
+		{
+			OrganizationID: 1, UserID: 3, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed,
+			CustomRole: &domain.OrganizationCustomRole{Name: "Finance", Permissions: string(domain.OrgPermManageBilling)},
+		},
+		{OrganizationID: 1, UserID: 4, Role: domain.OrgRoleBilling, Status: domain.OrgUserStatusConfirmed},
+	}}
+	svc := NewPaymentService(nil, nil, members, nil, nil, nil, fakePromotions{}, nil, nil, noopLogger{})
+
+	for _, userID := range []uint{1, 3, 4} {
+		dto, err := svc.ValidatePromoCode(ctx, 1, userID, "SPRING", "team")
+		require.NoError(t, err, "user %d holds manage_billing", userID)
+		assert.Equal(t, "SPRING", dto.Code)
+	}
+
+	_, err := svc.ValidatePromoCode(ctx, 1, 2, "SPRING", "team")
+	assert.EqualError(t, err, "forbidden")
+	_, err = svc.ValidatePromoCode(ctx, 1, 99, "SPRING", "team")
+	assert.EqualError(t, err, "forbidden", "non-members are rejected")
+}
+
+func TestTrialExtensionIDsMetadata(t *testing.T) {
+	exts := []*domain.TrialExtension{{ID: 3}, {ID: 5}}
+	assert.Equal(t, "3,5", formatTrialExtensionIDs(exts))
+	assert.Equal(t, map[uint]bool{3: true, 5: true}, parseTrialExtensionIDs("3,5"))
+	assert.Empty(t, parseTrialExtensionIDs(""), "sessions without extensions apply none")
+}
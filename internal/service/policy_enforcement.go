@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 )
 
@@ -22,11 +23,11 @@ type PolicyEnforcementService interface {
 	// CheckExternalSharingAllowed returns an error if external sharing is disabled
 	CheckExternalSharingAllowed(ctx context.Context, orgID uint) error
 
-	// CheckPersonalExportAllowed returns an error if personal export is disabled
-	CheckPersonalExportAllowed(ctx context.Context, orgID uint, role domain.OrganizationRole) error
+	// CheckPersonalExportAllowed returns an error if personal export is disabled for the member
+	CheckPersonalExportAllowed(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser) error
 
-	// CheckSendAllowed returns an error if Send is disabled for non-admin members
-	CheckSendAllowed(ctx context.Context, orgID uint, role domain.OrganizationRole) error
+	// CheckSendAllowed returns an error if Send is disabled for members without manage_policies
+	CheckSendAllowed(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser) error
 
 	// GetSessionTimeoutPolicy returns session timeout config or nil if not enabled
 	GetSessionTimeoutPolicy(ctx context.Context, orgID uint) (*SessionTimeoutPolicy, error)
@@ -34,8 +35,8 @@ type PolicyEnforcementService interface {
 	// CheckCardTypeAllowed returns an error if card items are not allowed
 	CheckCardTypeAllowed(ctx context.Context, orgID uint) error
 
-	// CheckPersonalVaultAllowed returns an error if personal vault is disabled for the org
-	CheckPersonalVaultAllowed(ctx context.Context, orgID uint, role domain.OrganizationRole) error
+	// CheckPersonalVaultAllowed returns an error if personal vault is disabled for the member
+	CheckPersonalVaultAllowed(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser) error
 
 	// GetPasswordExpirationPolicy returns password expiration config or nil if not enabled
 	GetPasswordExpirationPolicy(ctx context.Context, orgID uint) (*PasswordExpirationPolicy, error)
@@ -126,8 +127,8 @@ func (s *policyEnforcementService) CheckExternalSharingAllowed(ctx context.Conte
 	return nil
 }
 
-func (s *policyEnforcementService) CheckPersonalExportAllowed(ctx context.Context, orgID uint, role domain.OrganizationRole) error {
-	if role == domain.OrgRoleOwner || role == domain.OrgRoleAdmin {
+func (s *policyEnforcementService) CheckPersonalExportAllowed(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser) error {
+	if exemptFromPolicies(orgUser) {
 		return nil
 	}
 	enabled, err := s.policyService.IsPolicyEnabled(ctx, orgID, domain.PolicyDisablePersonalExport)
@@ -140,8 +141,8 @@ func (s *policyEnforcementService) CheckPersonalExportAllowed(ctx context.Contex
 	return nil
 }
 
-func (s *policyEnforcementService) CheckSendAllowed(ctx context.Context, orgID uint, role domain.OrganizationRole) error {
-	if role == domain.OrgRoleOwner || role == domain.OrgRoleAdmin {
+func (s *policyEnforcementService) CheckSendAllowed(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser) error {
+	if exemptFromPolicies(orgUser) {
 		return nil
 	}
 	enabled, err := s.policyService.IsPolicyEnabled(ctx, orgID, domain.PolicyRemoveSend)
@@ -173,8 +174,8 @@ func (s *policyEnforcementService) CheckCardTypeAllowed(ctx context.Context, org
 	return nil
 }
 
-func (s *policyEnforcementService) CheckPersonalVaultAllowed(ctx context.Context, orgID uint, role domain.OrganizationRole) error {
-	if role == domain.OrgRoleOwner || role == domain.OrgRoleAdmin {
+func (s *policyEnforcementService) CheckPersonalVaultAllowed(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser) error {
+	if exemptFromPolicies(orgUser) {
 		return nil
 	}
 	enabled, err := s.policyService.IsPolicyEnabled(ctx, orgID, domain.PolicyDisablePersonalVault)
@@ -195,6 +196,12 @@ func (s *policyEnforcementService) GetPasswordExpirationPolicy(ctx context.Conte
 	return parsePasswordExpirationPolicy(data), nil
 }
 
+// exemptFromPolicies reports whether the member manages the organization's policies
+// and is therefore not restricted by them
+func exemptFromPolicies(orgUser *domain.OrganizationUser) bool {
+	return authz.HasPermission(orgUser, domain.OrgPermManagePolicies)
+}
+
 // --- Data parsers ---
 
 func parseMasterPasswordPolicy(data domain.PolicyData) *MasterPasswordPolicy {
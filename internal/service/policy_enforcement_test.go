@@ -97,7 +97,7 @@ func TestPolicyEnforcement_CheckPersonalVaultAllowed(t *testing.T) {
 			},
 		})
 
-		if err := svc.CheckPersonalVaultAllowed(ctx, orgID, domain.OrgRoleOwner); err != nil {
+		if err := svc.CheckPersonalVaultAllowed(ctx, orgID, &domain.OrganizationUser{Role: domain.OrgRoleOwner}); err != nil {
 			t.Fatalf("expected no error for owner, got %v", err)
 		}
 	})
@@ -110,11 +110,29 @@ func TestPolicyEnforcement_CheckPersonalVaultAllowed(t *testing.T) {
 			},
 		})
 
-		err := svc.CheckPersonalVaultAllowed(ctx, orgID, domain.OrgRoleMember)
+		err := svc.CheckPersonalVaultAllowed(ctx, orgID, &domain.OrganizationUser{Role: domain.OrgRoleMember})
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
 	})
+
+	t.Run("custom role with manage_policies bypasses policy", func(t *testing.T) {
+		t.Parallel()
+		svc := NewPolicyEnforcementService(&mockOrganizationPolicyService{
+			enabledByType: map[domain.PolicyType]bool{
+				domain.PolicyDisablePersonalVault: true,
+			},
+		})
+
+		orgUser := &domain.OrganizationUser{
+			Role:       domain.OrgRoleMember,
+			Status:     domain.OrgUserStatusConfirmed,
+			CustomRole: &domain.OrganizationCustomRole{Name: "Security", Permissions: string(domain.OrgPermManagePolicies)},
+		}
+		if err := svc.CheckPersonalVaultAllowed(ctx, orgID, orgUser); err != nil {
+			t.Fatalf("expected no error for manage_policies, got %v", err)
+		}
+	})
 }
 
 func TestPolicyEnforcement_GetPasswordExpirationPolicy(t *testing.T) {
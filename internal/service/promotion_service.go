@@ -0,0 +1,499 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	stripeClient "github.com/passwall/passwall-server/pkg/stripe"
+)
+
+var (
+	ErrPromoCodeNotFound        = errors.New("promo code not found")
+	ErrPromoCodeNotRedeemable   = errors.New("promo code is expired, inactive or fully redeemed")
+	ErrPromoCodeNotApplicable   = errors.New("promo code does not apply to the selected plan")
+	ErrPromoCodeAlreadyRedeemed = errors.New("promo code has already been redeemed by this organization")
+	ErrPromoCodeInvalid         = errors.New("invalid promo code definition")
+	ErrInvalidTrialExtension    = errors.New("invalid trial extension")
+)
+
+const maxTrialExtensionDays = 365
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]{2,63}$`)
+
+// PromotionService manages promo codes, their redemption audit trail and admin trial extensions
+type PromotionService interface {
+	// Admin management
+	CreatePromoCode(ctx context.Context, adminUserID uint, req *domain.CreatePromoCodeRequest) (*domain.PromoCode, error)
+	ListPromoCodes(ctx context.Context) ([]*domain.PromoCode, error)
+	DeactivatePromoCode(ctx context.Context, id uint) (*domain.PromoCode, error)
+	ListRedemptions(ctx context.Context, promoCodeID uint) ([]*domain.PromoRedemption, error)
+	GrantTrialExtension(ctx context.Context, orgID, adminUserID uint, req *domain.GrantTrialExtensionRequest) (*domain.TrialExtension, error)
+	ListTrialExtensions(ctx context.Context, orgID uint) ([]*domain.TrialExtension, error)
+
+	// Billing integration
+	ValidatePromoCode(ctx context.Context, orgID uint, code, plan string) (*domain.PromoCode, error)
+	RecordPendingRedemption(ctx context.Context, promo *domain.PromoCode, orgID, userID uint, planCode, sessionID string) error
+	ConfirmCheckoutRedemption(ctx context.Context, sessionID string, periodEnd *time.Time) (*domain.PromoRedemption, error)
+	ReserveRedemption(ctx context.Context, promo *domain.PromoCode, orgID, userID uint, source domain.PromoRedemptionSource, planCode string) (*domain.PromoRedemption, error)
+	ConfirmRedemption(ctx context.Context, redemption *domain.PromoRedemption, periodEnd *time.Time) error
+	ReleaseRedemption(ctx context.Context, redemption *domain.PromoRedemption)
+	GetActiveDiscount(ctx context.Context, orgID uint) *domain.DiscountDTO
+	PendingTrialDays(ctx context.Context, orgID uint) (int, []*domain.TrialExtension, error)
+	MarkTrialExtensionsApplied(ctx context.Context, exts []*domain.TrialExtension, trialEndsAt *time.Time)
+}
+
+type promotionService struct {
+	repo                repository.PromotionRepository
+	stripe              *stripeClient.Client
+	subscriptionService SubscriptionService
+	orgRepo             interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	}
+	activityLogger *ActivityLogger
+	logger         Logger
+}
+
+// NewPromotionService creates a new promotion service
+func NewPromotionService(
+	repo repository.PromotionRepository,
+	stripe *stripeClient.Client,
+	subscriptionService SubscriptionService,
+	orgRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	},
+	activityService UserActivityService,
+	logger Logger,
+) PromotionService {
+	return &promotionService{
+		repo:                repo,
+		stripe:              stripe,
+		subscriptionService: subscriptionService,
+		orgRepo:             orgRepo,
+		activityLogger:      NewActivityLogger(activityService),
+		logger:              logger,
+	}
+}
+
+// CreatePromoCode validates the definition, creates the backing Stripe coupon and stores the code
+func (s *promotionService) CreatePromoCode(ctx context.Context, adminUserID uint, req *domain.CreatePromoCodeRequest) (*domain.PromoCode, error) {
+	promo, err := buildPromoCode(req)
+	if err != nil {
+		return nil, err
+	}
+	promo.CreatedByUserID = &adminUserID
+
+	if _, err := s.repo.GetPromoCodeByCode(ctx, promo.Code); err == nil {
+		return nil, repository.ErrAlreadyExists
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check promo code: %w", err)
+	}
+
+	couponParams := stripeClient.CreateCouponParams{
+		Name:       promo.Code,
+		PercentOff: promo.PercentOff,
+		AmountOff:  promo.AmountOffCents,
+		Currency:   promo.Currency,
+		Duration:   string(promo.Duration),
+		RedeemBy:   promo.ExpiresAt,
+		Metadata:   map[string]string{"promo_code": promo.Code},
+	}
+	if promo.DurationInMonths != nil {
+		m := int64(*promo.DurationInMonths)
+		couponParams.DurationInMonths = &m
+	}
+	if promo.MaxRedemptions != nil {
+		m := int64(*promo.MaxRedemptions)
+		couponParams.MaxRedemptions = &m
+	}
+
+	coupon, err := s.stripe.CreateCoupon(couponParams)
+	if err != nil {
+		return nil, err
+	}
+	promo.StripeCouponID = &coupon.ID
+
+	if err := s.repo.CreatePromoCode(ctx, promo); err != nil {
+		// Best-effort rollback so we don't leave orphaned coupons behind
+		if delErr := s.stripe.DeleteCoupon(coupon.ID); delErr != nil {
+			s.logger.Warn("failed to delete orphaned stripe coupon", "coupon_id", coupon.ID, "error", delErr)
+		}
+		return nil, fmt.Errorf("failed to create promo code: %w", err)
+	}
+
+	s.logger.Info("promo code created", "code", promo.Code, "coupon_id", coupon.ID, "admin_user_id", adminUserID)
+	return promo, nil
+}
+
+// ListPromoCodes returns all promo codes
+func (s *promotionService) ListPromoCodes(ctx context.Context) ([]*domain.PromoCode, error) {
+	return s.repo.ListPromoCodes(ctx)
+}
+
+// DeactivatePromoCode stops a promo code from being redeemed.
+// Discounts already applied to subscriptions keep running.
+func (s *promotionService) DeactivatePromoCode(ctx context.Context, id uint) (*domain.PromoCode, error) {
+	promo, err := s.repo.GetPromoCodeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !promo.IsActive {
+		return promo, nil
+	}
+
+	promo.IsActive = false
+	if err := s.repo.UpdatePromoCode(ctx, promo); err != nil {
+		return nil, fmt.Errorf("failed to deactivate promo code: %w", err)
+	}
+
+	if promo.StripeCouponID != nil && *promo.StripeCouponID != "" {
+		if err := s.stripe.DeleteCoupon(*promo.StripeCouponID); err != nil {
+			s.logger.Warn("failed to delete stripe coupon for deactivated promo code", "code", promo.Code, "error", err)
+		}
+	}
+
+	s.logger.Info("promo code deactivated", "code", promo.Code)
+	return promo, nil
+}
+
+// ListRedemptions returns the redemption audit trail for a promo code
+func (s *promotionService) ListRedemptions(ctx context.Context, promoCodeID uint) ([]*domain.PromoRedemption, error) {
+	if _, err := s.repo.GetPromoCodeByID(ctx, promoCodeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRedemptionsByPromoCode(ctx, promoCodeID)
+}
+
+// GrantTrialExtension extends an organization's trial.
+// Trialing Stripe subscriptions are extended immediately; otherwise the
+// extension stays pending and is added to the next checkout trial period.
+func (s *promotionService) GrantTrialExtension(ctx context.Context, orgID, adminUserID uint, req *domain.GrantTrialExtensionRequest) (*domain.TrialExtension, error) {
+	if req.Days <= 0 || req.Days > maxTrialExtensionDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidTrialExtension, maxTrialExtensionDays)
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := &domain.TrialExtension{
+		OrganizationID:  orgID,
+		Days:            req.Days,
+		Reason:          strings.TrimSpace(req.Reason),
+		GrantedByUserID: adminUserID,
+	}
+
+	sub, subErr := s.subscriptionService.GetByOrganizationID(ctx, orgID)
+	hasStripeSub := subErr == nil && sub != nil && sub.StripeSubscriptionID != nil && *sub.StripeSubscriptionID != ""
+	if hasStripeSub && sub.State != domain.SubStateTrialing && sub.IsActive() {
+		return nil, fmt.Errorf("%w: organization already has a paid subscription", ErrInvalidTrialExtension)
+	}
+
+	if hasStripeSub && sub.State == domain.SubStateTrialing {
+		base := time.Now()
+		if sub.TrialEndsAt != nil && sub.TrialEndsAt.After(base) {
+			base = *sub.TrialEndsAt
+		}
+		newEnd := base.AddDate(0, 0, req.Days)
+
+		if _, err := s.stripe.UpdateTrialEnd(*sub.StripeSubscriptionID, newEnd); err != nil {
+			return nil, err
+		}
+
+		sub.TrialEndsAt = &newEnd
+		sub.RenewAt = &newEnd
+		if err := s.subscriptionService.Update(ctx, sub); err != nil {
+			s.logger.Error("failed to sync trial end to DB (Stripe already updated)", "org_id", orgID, "err", err)
+		}
+
+		now := time.Now()
+		ext.AppliedAt = &now
+		ext.TrialEndsAt = &newEnd
+	}
+
+	if err := s.repo.CreateTrialExtension(ctx, ext); err != nil {
+		return nil, fmt.Errorf("failed to save trial extension: %w", err)
+	}
+
+	s.activityLogger.LogCustomActivity(ctx, adminUserID, domain.ActivityTypeTrialExtended, "admin", "Admin Panel", ActivityDetails{
+		ActivityFieldOrganizationID:   orgID,
+		ActivityFieldOrganizationName: org.Name,
+		ActivityFieldReason:           ext.Reason,
+		"days":                        ext.Days,
+		"pending":                     ext.IsPending(),
+		"trial_ends_at":               ext.TrialEndsAt,
+	})
+
+	s.logger.Info("trial extension granted", "org_id", orgID, "days", req.Days, "pending", ext.IsPending())
+	return ext, nil
+}
+
+// ListTrialExtensions returns all trial extensions for an organization
+func (s *promotionService) ListTrialExtensions(ctx context.Context, orgID uint) ([]*domain.TrialExtension, error) {
+	return s.repo.ListTrialExtensionsByOrganization(ctx, orgID)
+}
+
+// ValidatePromoCode checks that a code exists, is redeemable, applies to the plan
+// and hasn't been redeemed by the organization before.
+func (s *promotionService) ValidatePromoCode(ctx context.Context, orgID uint, code, plan string) (*domain.PromoCode, error) {
+	promo, err := s.repo.GetPromoCodeByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPromoCodeNotFound
+		}
+		return nil, fmt.Errorf("failed to get promo code: %w", err)
+	}
+
+	if !promo.IsRedeemable(time.Now()) || promo.StripeCouponID == nil {
+		return nil, ErrPromoCodeNotRedeemable
+	}
+	if plan != "" && !promo.AppliesToPlan(plan) {
+		return nil, ErrPromoCodeNotApplicable
+	}
+
+	redeemed, err := s.repo.HasRedeemed(ctx, promo.ID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check promo code redemptions: %w", err)
+	}
+	if redeemed {
+		return nil, ErrPromoCodeAlreadyRedeemed
+	}
+
+	return promo, nil
+}
+
+// RecordPendingRedemption records a code attached to a checkout session.
+// It is confirmed once Stripe reports the checkout as completed.
+func (s *promotionService) RecordPendingRedemption(ctx context.Context, promo *domain.PromoCode, orgID, userID uint, planCode, sessionID string) error {
+	redemption := &domain.PromoRedemption{
+		PromoCodeID:           promo.ID,
+		OrganizationID:        orgID,
+		UserID:                userID,
+		Source:                domain.PromoRedemptionSourceCheckout,
+		Status:                domain.PromoRedemptionPending,
+		PlanCode:              planCode,
+		StripeCouponID:        promo.StripeCouponID,
+		StripeCheckoutSession: &sessionID,
+	}
+	return s.repo.CreateRedemption(ctx, redemption)
+}
+
+// ConfirmCheckoutRedemption marks the pending redemption of a completed checkout as redeemed.
+// Returns repository.ErrNotFound when the session had no promo code. Safe to call twice.
+func (s *promotionService) ConfirmCheckoutRedemption(ctx context.Context, sessionID string, periodEnd *time.Time) (*domain.PromoRedemption, error) {
+	redemption, err := s.repo.GetRedemptionByCheckoutSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if redemption.Status == domain.PromoRedemptionRedeemed {
+		return redemption, nil
+	}
+
+	// Stripe already accepted the coupon, so an exhausted local counter is only logged.
+	if ok, err := s.repo.IncrementTimesRedeemed(ctx, redemption.PromoCodeID); err != nil {
+		return nil, fmt.Errorf("failed to update promo code usage: %w", err)
+	} else if !ok {
+		s.logger.Warn("promo code redeemed past its local redemption limit", "promo_code_id", redemption.PromoCodeID, "session_id", sessionID)
+	}
+
+	now := time.Now()
+	redemption.Status = domain.PromoRedemptionRedeemed
+	redemption.RedeemedAt = &now
+	if redemption.PromoCode != nil {
+		redemption.EndsAt = redemption.PromoCode.DiscountEndsAt(now, periodEnd)
+	}
+	if err := s.repo.UpdateRedemption(ctx, redemption); err != nil {
+		return nil, fmt.Errorf("failed to update promo redemption: %w", err)
+	}
+
+	s.logRedemption(ctx, redemption)
+	return redemption, nil
+}
+
+// ReserveRedemption atomically takes one of the code's redemptions before it is applied
+// (e.g. inline plan change). Confirm it once Stripe accepted the coupon, or release it.
+func (s *promotionService) ReserveRedemption(ctx context.Context, promo *domain.PromoCode, orgID, userID uint, source domain.PromoRedemptionSource, planCode string) (*domain.PromoRedemption, error) {
+	ok, err := s.repo.IncrementTimesRedeemed(ctx, promo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update promo code usage: %w", err)
+	}
+	if !ok {
+		return nil, ErrPromoCodeNotRedeemable
+	}
+
+	redemption := &domain.PromoRedemption{
+		PromoCodeID:    promo.ID,
+		OrganizationID: orgID,
+		UserID:         userID,
+		Source:         source,
+		Status:         domain.PromoRedemptionPending,
+		PlanCode:       planCode,
+		StripeCouponID: promo.StripeCouponID,
+	}
+	if err := s.repo.CreateRedemption(ctx, redemption); err != nil {
+		if relErr := s.repo.DecrementTimesRedeemed(ctx, promo.ID); relErr != nil {
+			s.logger.Error("failed to release promo code reservation", "promo_code_id", promo.ID, "error", relErr)
+		}
+		return nil, fmt.Errorf("failed to record promo redemption: %w", err)
+	}
+
+	redemption.PromoCode = promo
+	return redemption, nil
+}
+
+// ConfirmRedemption marks a reserved redemption as redeemed
+func (s *promotionService) ConfirmRedemption(ctx context.Context, redemption *domain.PromoRedemption, periodEnd *time.Time) error {
+	now := time.Now()
+	redemption.Status = domain.PromoRedemptionRedeemed
+	redemption.RedeemedAt = &now
+	if redemption.PromoCode != nil {
+		redemption.EndsAt = redemption.PromoCode.DiscountEndsAt(now, periodEnd)
+	}
+	if err := s.repo.UpdateRedemption(ctx, redemption); err != nil {
+		return fmt.Errorf("failed to update promo redemption: %w", err)
+	}
+
+	s.logRedemption(ctx, redemption)
+	return nil
+}
+
+// ReleaseRedemption gives a reserved redemption back to the code (best-effort)
+func (s *promotionService) ReleaseRedemption(ctx context.Context, redemption *domain.PromoRedemption) {
+	if err := s.repo.DecrementTimesRedeemed(ctx, redemption.PromoCodeID); err != nil {
+		s.logger.Error("failed to release promo code reservation", "promo_code_id", redemption.PromoCodeID, "error", err)
+	}
+	redemption.Status = domain.PromoRedemptionReleased
+	if err := s.repo.UpdateRedemption(ctx, redemption); err != nil {
+		s.logger.Error("failed to mark promo redemption released", "redemption_id", redemption.ID, "error", err)
+	}
+}
+
+// GetActiveDiscount returns the discount currently applied to an organization, if any
+func (s *promotionService) GetActiveDiscount(ctx context.Context, orgID uint) *domain.DiscountDTO {
+	redemption, err := s.repo.GetLatestRedemptionByOrganization(ctx, orgID)
+	if err != nil || redemption.PromoCode == nil {
+		return nil
+	}
+	if redemption.EndsAt != nil && time.Now().After(*redemption.EndsAt) {
+		return nil
+	}
+	return domain.ToDiscountDTO(redemption.PromoCode, redemption)
+}
+
+// PendingTrialDays sums trial extensions that have not been applied yet
+func (s *promotionService) PendingTrialDays(ctx context.Context, orgID uint) (int, []*domain.TrialExtension, error) {
+	exts, err := s.repo.ListPendingTrialExtensions(ctx, orgID)
+	if err != nil {
+		return 0, nil, err
+	}
+	days := 0
+	for _, ext := range exts {
+		days += ext.Days
+	}
+	return days, exts, nil
+}
+
+// MarkTrialExtensionsApplied marks pending extensions as consumed (best-effort)
+func (s *promotionService) MarkTrialExtensionsApplied(ctx context.Context, exts []*domain.TrialExtension, trialEndsAt *time.Time) {
+	now := time.Now()
+	for _, ext := range exts {
+		ext.AppliedAt = &now
+		ext.TrialEndsAt = trialEndsAt
+		if err := s.repo.UpdateTrialExtension(ctx, ext); err != nil {
+			s.logger.Error("failed to mark trial extension applied", "trial_extension_id", ext.ID, "error", err)
+		}
+	}
+}
+
+func (s *promotionService) logRedemption(ctx context.Context, r *domain.PromoRedemption) {
+	code := ""
+	if r.PromoCode != nil {
+		code = r.PromoCode.Code
+	}
+	s.activityLogger.LogCustomActivity(ctx, r.UserID, domain.ActivityTypePromoCodeRedeemed, "", "", ActivityDetails{
+		ActivityFieldOrganizationID: r.OrganizationID,
+		ActivityFieldPromoCode:      code,
+		ActivityFieldPlan:           r.PlanCode,
+		"source":                    r.Source,
+		"ends_at":                   r.EndsAt,
+	})
+	s.logger.Info("promo code redeemed", "code", code, "org_id", r.OrganizationID, "source", r.Source)
+}
+
+// buildPromoCode validates an admin request and converts it to a PromoCode
+func buildPromoCode(req *domain.CreatePromoCodeRequest) (*domain.PromoCode, error) {
+	code := domain.NormalizePromoCode(req.Code)
+	if !promoCodePattern.MatchString(code) {
+		return nil, fmt.Errorf("%w: code must be 3-64 characters (A-Z, 0-9, _ or -)", ErrPromoCodeInvalid)
+	}
+
+	promo := &domain.PromoCode{
+		Code:           code,
+		Description:    strings.TrimSpace(req.Description),
+		Duration:       req.Duration,
+		MaxRedemptions: req.MaxRedemptions,
+		ExpiresAt:      req.ExpiresAt,
+		IsActive:       true,
+	}
+
+	switch {
+	case req.PercentOff != nil && req.AmountOffCents != nil:
+		return nil, fmt.Errorf("%w: set either percent_off or amount_off_cents, not both", ErrPromoCodeInvalid)
+	case req.PercentOff != nil:
+		if *req.PercentOff <= 0 || *req.PercentOff > 100 {
+			return nil, fmt.Errorf("%w: percent_off must be between 0 and 100", ErrPromoCodeInvalid)
+		}
+		promo.DiscountType = domain.DiscountTypePercent
+		promo.PercentOff = req.PercentOff
+	case req.AmountOffCents != nil:
+		if *req.AmountOffCents <= 0 {
+			return nil, fmt.Errorf("%w: amount_off_cents must be greater than 0", ErrPromoCodeInvalid)
+		}
+		currency := strings.ToLower(strings.TrimSpace(req.Currency))
+		if len(currency) != 3 {
+			return nil, fmt.Errorf("%w: currency is required with amount_off_cents", ErrPromoCodeInvalid)
+		}
+		promo.DiscountType = domain.DiscountTypeAmount
+		promo.AmountOffCents = req.AmountOffCents
+		promo.Currency = currency
+	default:
+		return nil, fmt.Errorf("%w: percent_off or amount_off_cents is required", ErrPromoCodeInvalid)
+	}
+
+	switch promo.Duration {
+	case "":
+		promo.Duration = domain.DiscountDurationOnce
+	case domain.DiscountDurationOnce, domain.DiscountDurationForever:
+	case domain.DiscountDurationRepeating:
+		if req.DurationInMonths == nil || *req.DurationInMonths <= 0 {
+			return nil, fmt.Errorf("%w: duration_in_months is required for repeating discounts", ErrPromoCodeInvalid)
+		}
+		promo.DurationInMonths = req.DurationInMonths
+	default:
+		return nil, fmt.Errorf("%w: duration must be once, repeating or forever", ErrPromoCodeInvalid)
+	}
+
+	if promo.MaxRedemptions != nil && *promo.MaxRedemptions <= 0 {
+		return nil, fmt.Errorf("%w: max_redemptions must be greater than 0", ErrPromoCodeInvalid)
+	}
+	if promo.ExpiresAt != nil && !promo.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrPromoCodeInvalid)
+	}
+
+	plans := make(domain.StringSliceJSON, 0, len(req.AppliesToPlans))
+	for _, p := range req.AppliesToPlans {
+		p = strings.ToLower(strings.TrimSpace(p))
+		if p != "" {
+			plans = append(plans, p)
+		}
+	}
+	promo.AppliesToPlans = plans
+
+	return promo, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// fakePromotionRepo tracks redemption counts and records in memory
+type fakePromotionRepo struct {
+	repository.PromotionRepository
+	code        *domain.PromoCode
+	redemptions []*domain.PromoRedemption
+}
+
+func (f *fakePromotionRepo) IncrementTimesRedeemed(_ context.Context, _ uint) (bool, error) {
+	if f.code.MaxRedemptions != nil && f.code.TimesRedeemed >= *f.code.MaxRedemptions {
+		return false, nil
+	}
+	f.code.TimesRedeemed++
+	return true, nil
+}
+
+func (f *fakePromotionRepo) DecrementTimesRedeemed(_ context.Context, _ uint) error {
+	if f.code.TimesRedeemed > 0 {
+		f.code.TimesRedeemed--
+	}
+	return nil
+}
+
+func (f *fakePromotionRepo) CreateRedemption(_ context.Context, r *domain.PromoRedemption) error {
+	r.ID = uint(len(f.redemptions) + 1)
+	f.redemptions = append(f.redemptions, r)
+	return nil
+}
+
+func (f *fakePromotionRepo) UpdateRedemption(context.Context, *domain.PromoRedemption) error {
+	return nil
+}
+
+func TestPromotionService_ReserveRedemption(t *testing.T) {
+	ctx := context.Background()
+	maxRedemptions := 1
+	repo := &fakePromotionRepo{code: &domain.PromoCode{ID: 1, Code: "SPRING", MaxRedemptions: &maxRedemptions}}
+	activity := &fakeActivityService{}
+	svc := NewPromotionService(repo, nil, nil, nil, activity, noopLogger{})
+
+	r, err := svc.ReserveRedemption(ctx, repo.code, 1, 1, domain.PromoRedemptionSourcePlanChange, "team-monthly")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PromoRedemptionPending, r.Status)
+	assert.Equal(t, 1, repo.code.TimesRedeemed)
+
+	// The reservation holds the last redemption until it is released
+	_, err = svc.ReserveRedemption(ctx, repo.code, 2, 2, domain.PromoRedemptionSourcePlanChange, "team-monthly")
+	assert.ErrorIs(t, err, ErrPromoCodeNotRedeemable)
+
+	svc.ReleaseRedemption(ctx, r)
+	assert.Equal(t, domain.PromoRedemptionReleased, r.Status)
+	assert.Equal(t, 0, repo.code.TimesRedeemed)
+	assert.Empty(t, activity.logged)
+
+	r, err = svc.ReserveRedemption(ctx, repo.code, 2, 2, domain.PromoRedemptionSourcePlanChange, "team-monthly")
+	require.NoError(t, err)
+	periodEnd := time.Now().AddDate(0, 1, 0)
+	require.NoError(t, svc.ConfirmRedemption(ctx, r, &periodEnd))
+	assert.Equal(t, domain.PromoRedemptionRedeemed, r.Status)
+	assert.NotNil(t, r.RedeemedAt)
+	assert.Equal(t, 1, repo.code.TimesRedeemed)
+	assert.Equal(t, []domain.ActivityType{domain.ActivityTypePromoCodeRedeemed}, activity.logged)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -24,7 +25,9 @@ var (
 // RevenueCatService handles RevenueCat webhook events for mobile subscriptions.
 // Mobile Pro purchases create org-level subscriptions on the user's default organization.
 type RevenueCatService interface {
-	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+	// Webhooks (verified and processed through the webhook inbox)
+	ParseWebhook(payload []byte, signature string) (eventID, eventType string, err error)
+	ProcessWebhookEvent(ctx context.Context, payload []byte) error
 }
 
 type revenueCatService struct {
@@ -72,18 +75,27 @@ func NewRevenueCatService(
 	}
 }
 
-// HandleWebhook processes RevenueCat webhook events
-func (s *revenueCatService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+// ParseWebhook verifies a RevenueCat webhook and returns the event ID and type
+func (s *revenueCatService) ParseWebhook(payload []byte, signature string) (string, string, error) {
 	s.logger.Info("RevenueCat webhook received", "payload_size", len(payload))
 
-	// Parse and verify webhook
 	webhook, err := s.client.ParseWebhook(payload, signature)
 	if err != nil {
 		if errors.Is(err, revenuecat.ErrInvalidSignature) {
 			s.logger.Error("RevenueCat webhook signature verification failed", "error", err)
-			return fmt.Errorf("%w: %v", ErrInvalidRevenueCatSignature, err)
+			return "", "", fmt.Errorf("%w: %v", ErrInvalidRevenueCatSignature, err)
 		}
 		s.logger.Error("RevenueCat webhook parse failed", "error", err)
+		return "", "", fmt.Errorf("failed to parse webhook: %w", err)
+	}
+
+	return webhook.Event.ID, string(webhook.Event.Type), nil
+}
+
+// ProcessWebhookEvent handles a verified RevenueCat webhook event (called by the webhook inbox)
+func (s *revenueCatService) ProcessWebhookEvent(ctx context.Context, payload []byte) error {
+	var webhook revenuecat.WebhookEvent
+	if err := json.Unmarshal(payload, &webhook); err != nil {
 		return fmt.Errorf("failed to parse webhook: %w", err)
 	}
 
@@ -526,7 +538,11 @@ func (s *revenueCatService) handleBillingIssue(ctx context.Context, event *reven
 		return fmt.Errorf("failed to get subscription: %w", err)
 	}
 
-	// Set subscription to past_due state
+	// Set subscription to past_due state (starts dunning on the first billing issue)
+	if sub.State != domain.SubStatePastDue || sub.PastDueSince == nil {
+		now := time.Now()
+		sub.PastDueSince = &now
+	}
 	sub.State = domain.SubStatePastDue
 
 	// Set grace period from RevenueCat or default to 14 days
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/authz"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// rotationMatchLimit caps how many items of one vault are matched per breach run
+const rotationMatchLimit = 500
+
+// RotationRecommendationService flags vault items whose sites appear in a data
+// breach of their owner's email address and tells the owner to rotate them.
+type RotationRecommendationService interface {
+	// FlagBreachedItems matches newly recorded breaches of a monitored email against
+	// the owner's personal items and the organization items they can read, flags the
+	// matches and notifies the owner. Returns how many items were newly flagged.
+	FlagBreachedItems(ctx context.Context, monitored *domain.MonitoredEmail, records []*domain.BreachRecord) (int, error)
+	List(ctx context.Context, userID uint) ([]*domain.RotationRecommendation, error)
+	Resolve(ctx context.Context, userID, recommendationID uint) error
+}
+
+type rotationRecommendationService struct {
+	repo     repository.RotationRecommendationRepository
+	userRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.User, error)
+		GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	}
+	orgUserRepo interface {
+		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+	}
+	itemRepo interface {
+		FindAll(ctx context.Context, schema string, filter repository.ItemFilter) ([]*domain.Item, int64, error)
+	}
+	orgItemRepo interface {
+		ListByOrganization(ctx context.Context, filter repository.OrganizationItemFilter) ([]*domain.OrganizationItem, int64, error)
+	}
+	itemAccess interface {
+		GetItemAccess(ctx context.Context, item *domain.OrganizationItem, userID uint) (*authz.CollectionAccess, error)
+	}
+	notifications interface {
+		Notify(ctx context.Context, n *domain.Notification) error
+	}
+	emailSender  email.Sender
+	emailBuilder *email.EmailBuilder
+	logger       Logger
+	now          func() time.Time
+}
+
+// NewRotationRecommendationService creates a new rotation recommendation service
+func NewRotationRecommendationService(
+	repo repository.RotationRecommendationRepository,
+	userRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.User, error)
+		GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	},
+	orgUserRepo interface {
+		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+	},
+	itemRepo interface {
+		FindAll(ctx context.Context, schema string, filter repository.ItemFilter) ([]*domain.Item, int64, error)
+	},
+	orgItemRepo interface {
+		ListByOrganization(ctx context.Context, filter repository.OrganizationItemFilter) ([]*domain.OrganizationItem, int64, error)
+	},
+	itemAccess interface {
+		GetItemAccess(ctx context.Context, item *domain.OrganizationItem, userID uint) (*authz.CollectionAccess, error)
+	},
+	notifications interface {
+		Notify(ctx context.Context, n *domain.Notification) error
+	},
+	emailSender email.Sender,
+	emailBuilder *email.EmailBuilder,
+	logger Logger,
+) RotationRecommendationService {
+	return &rotationRecommendationService{
+		repo:          repo,
+		userRepo:      userRepo,
+		orgUserRepo:   orgUserRepo,
+		itemRepo:      itemRepo,
+		orgItemRepo:   orgItemRepo,
+		itemAccess:    itemAccess,
+		notifications: notifications,
+		emailSender:   emailSender,
+		emailBuilder:  emailBuilder,
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+func (s *rotationRecommendationService) FlagBreachedItems(ctx context.Context, monitored *domain.MonitoredEmail, records []*domain.BreachRecord) (int, error) {
+	user, err := s.breachedUser(ctx, monitored)
+	if err != nil || user == nil {
+		return 0, err
+	}
+
+	var flagged []*domain.RotationRecommendation
+	var breachNames []string
+	for _, record := range records {
+		site := strings.ToLower(strings.TrimSpace(record.BreachDomain))
+		if site == "" {
+			continue
+		}
+		matches, err := s.matchItems(ctx, user, monitored.OrganizationID, site)
+		if err != nil {
+			return len(flagged), err
+		}
+
+		found := false
+		for _, rec := range matches {
+			rec.BreachRecordID = record.ID
+			rec.BreachName = record.BreachName
+			rec.BreachDomain = site
+			created, err := s.repo.Create(ctx, rec)
+			if err != nil {
+				return len(flagged), fmt.Errorf("failed to flag item: %w", err)
+			}
+			if created {
+				flagged = append(flagged, rec)
+				found = true
+			}
+		}
+		if found {
+			breachNames = append(breachNames, record.BreachName)
+		}
+	}
+
+	if len(flagged) > 0 {
+		s.notify(ctx, user, monitored.Email, breachNames, flagged)
+	}
+	return len(flagged), nil
+}
+
+func (s *rotationRecommendationService) List(ctx context.Context, userID uint) ([]*domain.RotationRecommendation, error) {
+	return s.repo.ListOpenByUser(ctx, userID)
+}
+
+func (s *rotationRecommendationService) Resolve(ctx context.Context, userID, recommendationID uint) error {
+	rec, err := s.repo.GetByID(ctx, recommendationID)
+	if err != nil {
+		return err
+	}
+	if rec.UserID != userID {
+		return repository.ErrNotFound
+	}
+	if rec.ResolvedAt != nil {
+		return nil
+	}
+	now := s.now()
+	rec.ResolvedAt = &now
+	return s.repo.Update(ctx, rec)
+}
+
+// breachedUser returns the active organization member who owns the monitored
+// address, or nil when nobody does
+func (s *rotationRecommendationService) breachedUser(ctx context.Context, monitored *domain.MonitoredEmail) (*domain.User, error) {
+	var user *domain.User
+	var err error
+	if monitored.UserID != nil {
+		user, err = s.userRepo.GetByID(ctx, *monitored.UserID)
+	} else {
+		user, err = s.userRepo.GetByEmail(ctx, strings.ToLower(monitored.Email))
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load breached user: %w", err)
+	}
+
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, monitored.OrganizationID, user.ID)
+	if err != nil || !isActiveMember(orgUser) {
+		return nil, nil
+	}
+	return user, nil
+}
+
+// matchItems returns unsaved recommendations for the user's personal items and the
+// organization items they can read whose uri_hint is the site or one of its subdomains
+func (s *rotationRecommendationService) matchItems(ctx context.Context, user *domain.User, orgID uint, site string) ([]*domain.RotationRecommendation, error) {
+	var matches []*domain.RotationRecommendation
+
+	items, _, err := s.itemRepo.FindAll(ctx, user.Schema, repository.ItemFilter{URIHints: []string{site}, Page: 1, PerPage: rotationMatchLimit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to match personal items: %w", err)
+	}
+	for _, item := range items {
+		matches = append(matches, &domain.RotationRecommendation{
+			UserID:   user.ID,
+			ItemUUID: item.UUID.String(),
+			ItemName: item.Metadata.Name,
+			URIHint:  item.Metadata.URIHint,
+		})
+	}
+
+	orgItems, _, err := s.orgItemRepo.ListByOrganization(ctx, repository.OrganizationItemFilter{OrganizationID: orgID, URIHints: []string{site}, Page: 1, PerPage: rotationMatchLimit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to match organization items: %w", err)
+	}
+	for _, item := range orgItems {
+		access, err := s.itemAccess.GetItemAccess(ctx, item, user.ID)
+		if err != nil || !access.CanRead {
+			continue
+		}
+		matches = append(matches, &domain.RotationRecommendation{
+			UserID:         user.ID,
+			OrganizationID: &item.OrganizationID,
+			ItemUUID:       item.UUID.String(),
+			ItemName:       item.Metadata.Name,
+			URIHint:        item.Metadata.URIHint,
+		})
+	}
+	return matches, nil
+}
+
+// notify sends the in-app notification and the email listing the flagged items.
+// Failures are logged: the flags are already stored.
+func (s *rotationRecommendationService) notify(ctx context.Context, user *domain.User, breachedEmail string, breachNames []string, flagged []*domain.RotationRecommendation) {
+	seen := make(map[string]bool, len(flagged))
+	var items []string
+	for _, rec := range flagged {
+		if seen[rec.ItemUUID] {
+			continue
+		}
+		seen[rec.ItemUUID] = true
+		items = append(items, fmt.Sprintf("%s (%s)", rec.ItemName, rec.URIHint))
+	}
+
+	err := s.notifications.Notify(ctx, &domain.Notification{
+		UserID:  user.ID,
+		Type:    domain.NotificationTypeBreachRotation,
+		Title:   fmt.Sprintf("%s was found in a data breach", breachedEmail),
+		Message: fmt.Sprintf("Change the passwords of these items: %s.", strings.Join(items, ", ")),
+		Link:    "/vault",
+	})
+	if err != nil {
+		s.logger.Error("failed to create breach rotation notification", "user_id", user.ID, "error", err)
+	}
+
+	if s.emailSender == nil || s.emailBuilder == nil {
+		return
+	}
+	msg, err := s.emailBuilder.BuildBreachRotationEmail(user.Email, breachedEmail, breachNames, items)
+	if err != nil {
+		s.logger.Error("failed to build breach rotation email", "user_id", user.ID, "error", err)
+		return
+	}
+	if err := s.emailSender.Send(ctx, msg); err != nil {
+		s.logger.Error("failed to send breach rotation email", "user_id", user.ID, "error", err)
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/authz"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// fakeRotationRepo keeps recommendations in memory, unique per user, item and breach
+type fakeRotationRepo struct {
+	recs map[uint]*domain.RotationRecommendation
+}
+
+func (f *fakeRotationRepo) Create(_ context.Context, rec *domain.RotationRecommendation) (bool, error) {
+	for _, r := range f.recs {
+		if r.UserID == rec.UserID && r.ItemUUID == rec.ItemUUID && r.BreachName == rec.BreachName {
+			return false, nil
+		}
+	}
+	rec.ID = uint(len(f.recs) + 1)
+	f.recs[rec.ID] = rec
+	return true, nil
+}
+func (f *fakeRotationRepo) GetByID(_ context.Context, id uint) (*domain.RotationRecommendation, error) {
+	if r, ok := f.recs[id]; ok {
+		return r, nil
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeRotationRepo) ListOpenByUser(_ context.Context, userID uint) ([]*domain.RotationRecommendation, error) {
+	var out []*domain.RotationRecommendation
+	for _, r := range f.recs {
+		if r.UserID == userID && r.ResolvedAt == nil {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+func (f *fakeRotationRepo) Update(_ context.Context, _ *domain.RotationRecommendation) error {
+	return nil
+}
+
+// hintMatches mirrors the repositories' uri_hint filter: the site or a subdomain of it
+func hintMatches(hint string, hints []string) bool {
+	for _, h := range hints {
+		if hint == h || strings.HasSuffix(hint, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeVaultItems serves personal items per schema
+type fakeVaultItems struct {
+	items map[string][]*domain.Item
+}
+
+func (f *fakeVaultItems) FindAll(_ context.Context, schema string, filter repository.ItemFilter) ([]*domain.Item, int64, error) {
+	var out []*domain.Item
+	for _, item := range f.items[schema] {
+		if hintMatches(item.Metadata.URIHint, filter.URIHints) {
+			out = append(out, item)
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+// fakeOrgVaultItems serves organization items and grants read access to a fixed set
+type fakeOrgVaultItems struct {
+	items    []*domain.OrganizationItem
+	readable map[uint]bool
+}
+
+func (f *fakeOrgVaultItems) ListByOrganization(_ context.Context, filter repository.OrganizationItemFilter) ([]*domain.OrganizationItem, int64, error) {
+	var out []*domain.OrganizationItem
+	for _, item := range f.items {
+		if item.OrganizationID == filter.OrganizationID && hintMatches(item.Metadata.URIHint, filter.URIHints) {
+			out = append(out, item)
+		}
+	}
+	return out, int64(len(out)), nil
+}
+func (f *fakeOrgVaultItems) GetItemAccess(_ context.Context, item *domain.OrganizationItem, _ uint) (*authz.CollectionAccess, error) {
+	return &authz.CollectionAccess{CanRead: f.readable[item.ID]}, nil
+}
+
+type fakeNotifier struct {
+	sent []*domain.Notification
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, n *domain.Notification) error {
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+func TestRotationRecommendation_FlagBreachedItems(t *testing.T) {
+	ctx := context.Background()
+
+	users := newFakeUserRepo()
+	users.add(&domain.User{ID: 7, Email: "ada@acme.test", Schema: "user_ada"})
+	orgUsers := newFakeOrgUserRepo()
+	orgUsers.add(&domain.OrganizationUser{OrganizationID: 1, UserID: 7, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed})
+
+	vault := &fakeVaultItems{items: map[string][]*domain.Item{"user_ada": {
+		{UUID: uuid.New(), Metadata: domain.ItemMetadata{Name: "Adobe", URIHint: "adobe.com"}},
+		{UUID: uuid.New(), Metadata: domain.ItemMetadata{Name: "Adobe ID", URIHint: "account.adobe.com"}},
+		{UUID: uuid.New(), Metadata: domain.ItemMetadata{Name: "Lookalike", URIHint: "notadobe.com"}},
+	}}}
+	orgVault := &fakeOrgVaultItems{
+		items: []*domain.OrganizationItem{
+			{ID: 1, UUID: uuid.New(), OrganizationID: 1, Metadata: domain.ItemMetadata{Name: "Team Adobe", URIHint: "adobe.com"}},
+			{ID: 2, UUID: uuid.New(), OrganizationID: 1, Metadata: domain.ItemMetadata{Name: "Finance Adobe", URIHint: "adobe.com"}},
+			{ID: 3, UUID: uuid.New(), OrganizationID: 2, Metadata: domain.ItemMetadata{Name: "Other org", URIHint: "adobe.com"}},
+		},
+		readable: map[uint]bool{1: true, 3: true},
+	}
+	repo := &fakeRotationRepo{recs: map[uint]*domain.RotationRecommendation{}}
+	notifier := &fakeNotifier{}
+	sender := &fakeEmailSender{}
+	builder, err := email.NewEmailBuilder("https://vault.example.com", "noreply@example.com")
+	require.NoError(t, err)
+
+	svc := NewRotationRecommendationService(repo, users, orgUsers, vault, orgVault, orgVault, notifier, sender, builder, noopLogger{})
+
+	userID := uint(7)
+	monitored := &domain.MonitoredEmail{OrganizationID: 1, UserID: &userID, Email: "ada@acme.test"}
+	records := []*domain.BreachRecord{
+		{ID: 10, BreachName: "Adobe", BreachDomain: "Adobe.com"},
+		{ID: 11, BreachName: "Collection1"},
+	}
+
+	flagged, err := svc.FlagBreachedItems(ctx, monitored, records)
+	require.NoError(t, err)
+	assert.Equal(t, 3, flagged, "exact and subdomain personal matches plus the readable org item")
+
+	recs, err := svc.List(ctx, 7)
+	require.NoError(t, err)
+	names := make([]string, 0, len(recs))
+	for _, r := range recs {
+		names = append(names, r.ItemName)
+		assert.Equal(t, "adobe.com", r.BreachDomain)
+	}
+	assert.ElementsMatch(t, []string{"Adobe", "Adobe ID", "Team Adobe"}, names)
+
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, domain.NotificationTypeBreachRotation, notifier.sent[0].Type)
+	assert.Contains(t, notifier.sent[0].Message, "Team Adobe (adobe.com)")
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "ada@acme.test", sender.sent[0].To)
+
+	// The same breach is never flagged or announced twice
+	flagged, err = svc.FlagBreachedItems(ctx, monitored, records)
+	require.NoError(t, err)
+	assert.Zero(t, flagged)
+	assert.Len(t, notifier.sent, 1)
+	assert.Len(t, sender.sent, 1)
+
+	// Only the owner can resolve a recommendation
+	assert.ErrorIs(t, svc.Resolve(ctx, 8, recs[0].ID), repository.ErrNotFound)
+	require.NoError(t, svc.Resolve(ctx, 7, recs[0].ID))
+	open, err := svc.List(ctx, 7)
+	require.NoError(t, err)
+	assert.Len(t, open, 2)
+
+	// Addresses that belong to nobody in the organization flag nothing
+	flagged, err = svc.FlagBreachedItems(ctx, &domain.MonitoredEmail{OrganizationID: 1, Email: "ghost@acme.test"}, records)
+	require.NoError(t, err)
+	assert.Zero(t, flagged)
+}
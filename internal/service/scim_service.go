@@ -55,6 +55,7 @@ type scimService struct {
 	orgUserRepo  repository.OrganizationUserRepository
 	teamRepo     repository.TeamRepository
 	teamUserRepo repository.TeamUserRepository
+	seatService  SeatService
 	logger       Logger
 	baseURL      string
 }
@@ -66,6 +67,7 @@ func NewSCIMService(
 	orgUserRepo repository.OrganizationUserRepository,
 	teamRepo repository.TeamRepository,
 	teamUserRepo repository.TeamUserRepository,
+	seatService SeatService,
 	logger Logger,
 	baseURL string,
 ) SCIMService {
@@ -75,6 +77,7 @@ func NewSCIMService(
 		orgUserRepo:  orgUserRepo,
 		teamRepo:     teamRepo,
 		teamUserRepo: teamUserRepo,
+		seatService:  seatService,
 		logger:       logger,
 		baseURL:      baseURL,
 	}
@@ -255,7 +258,13 @@ func (s *scimService) CreateUser(ctx context.Context, orgID uint, scimUser *doma
 		orgUser.ExternalID = ptrString(scimUser.ExternalID)
 	}
 
-	if err := s.orgUserRepo.Create(ctx, orgUser); err != nil {
+	err = s.seatService.WithSeat(ctx, orgID, SeatReasonSCIMProvisioned, func() error {
+		return s.orgUserRepo.Create(ctx, orgUser)
+	})
+	if err != nil {
+		if errors.Is(err, ErrSeatLimitReached) || errors.Is(err, ErrSeatCapReached) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to provision SCIM user: %w", err)
 	}
 
@@ -275,6 +284,7 @@ func (s *scimService) UpdateUser(ctx context.Context, orgID uint, userID string,
 	if err != nil {
 		return nil, ErrSCIMUserNotFound
 	}
+	wasOccupyingSeat := orgUser.OccupiesSeat()
 
 	// Update external ID if changed
 	if scimUser.ExternalID != "" {
@@ -283,12 +293,15 @@ func (s *scimService) UpdateUser(ctx context.Context, orgID uint, userID string,
 
 	// Handle active/inactive (suspend/reactivate)
 	if !scimUser.Active && orgUser.Status != domain.OrgUserStatusSuspended {
-		orgUser.Status = domain.OrgUserStatusSuspended
+		markSuspended(orgUser, "deactivated by SCIM", time.Now())
 	} else if scimUser.Active && orgUser.Status == domain.OrgUserStatusSuspended {
-		orgUser.Status = domain.OrgUserStatusConfirmed
+		clearSuspension(orgUser)
 	}
 
-	if err := s.orgUserRepo.Update(ctx, orgUser); err != nil {
+	if err := s.saveWithSeats(ctx, orgID, orgUser, wasOccupyingSeat); err != nil {
+		if errors.Is(err, ErrSeatLimitReached) || errors.Is(err, ErrSeatCapReached) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to update SCIM user: %w", err)
 	}
 
@@ -305,6 +318,7 @@ func (s *scimService) PatchUser(ctx context.Context, orgID uint, userID string,
 	if err != nil {
 		return nil, ErrSCIMUserNotFound
 	}
+	wasOccupyingSeat := orgUser.OccupiesSeat()
 
 	for _, op := range patch.Operations {
 		switch strings.ToLower(op.Op) {
@@ -312,9 +326,11 @@ func (s *scimService) PatchUser(ctx context.Context, orgID uint, userID string,
 			if op.Path == "active" || op.Path == "" {
 				active := parseBoolValue(op.Value)
 				if !active {
-					orgUser.Status = domain.OrgUserStatusSuspended
+					if !orgUser.IsSuspended() {
+						markSuspended(orgUser, "deactivated by SCIM", time.Now())
+					}
 				} else {
-					orgUser.Status = domain.OrgUserStatusConfirmed
+					clearSuspension(orgUser)
 				}
 			}
 			if op.Path == "externalId" {
@@ -325,7 +341,10 @@ func (s *scimService) PatchUser(ctx context.Context, orgID uint, userID string,
 		}
 	}
 
-	if err := s.orgUserRepo.Update(ctx, orgUser); err != nil {
+	if err := s.saveWithSeats(ctx, orgID, orgUser, wasOccupyingSeat); err != nil {
+		if errors.Is(err, ErrSeatLimitReached) || errors.Is(err, ErrSeatCapReached) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to patch SCIM user: %w", err)
 	}
 
@@ -344,7 +363,35 @@ func (s *scimService) DeleteUser(ctx context.Context, orgID uint, userID string)
 	}
 
 	s.logger.Info("SCIM deprovisioning user from org", "user_id", id, "org_id", orgID)
-	return s.orgUserRepo.Delete(ctx, orgUser.ID)
+	if err := s.orgUserRepo.Delete(ctx, orgUser.ID); err != nil {
+		return err
+	}
+
+	if err := s.seatService.ReleaseSeats(ctx, orgID, SeatReasonSCIMDeprovisioned); err != nil {
+		s.logger.Error("failed to release seats after SCIM deprovisioning", "org_id", orgID, "error", err)
+	}
+	return nil
+}
+
+// saveWithSeats persists a SCIM user update. Reactivation takes a seat;
+// suspension releases it.
+func (s *scimService) saveWithSeats(ctx context.Context, orgID uint, orgUser *domain.OrganizationUser, wasOccupyingSeat bool) error {
+	occupiesSeat := orgUser.OccupiesSeat()
+	if occupiesSeat && !wasOccupyingSeat {
+		return s.seatService.WithSeat(ctx, orgID, SeatReasonSCIMReactivated, func() error {
+			return s.orgUserRepo.Update(ctx, orgUser)
+		})
+	}
+
+	if err := s.orgUserRepo.Update(ctx, orgUser); err != nil {
+		return err
+	}
+	if wasOccupyingSeat && !occupiesSeat {
+		if err := s.seatService.ReleaseSeats(ctx, orgID, SeatReasonSCIMSuspended); err != nil {
+			s.logger.Error("failed to release seats after SCIM suspension", "org_id", orgID, "error", err)
+		}
+	}
+	return nil
 }
 
 // --- SCIM Group Operations ---
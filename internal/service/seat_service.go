@@ -0,0 +1,445 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	stripeClient "github.com/passwall/passwall-server/pkg/stripe"
+	"github.com/stripe/stripe-go/v81"
+)
+
+// seatChangeAttempts bounds how often a seat change is retried when seats changed
+// while Stripe was being updated
+const seatChangeAttempts = 3
+
+var (
+	ErrSeatLimitReached = errors.New("all purchased seats are in use; add seats in billing settings or remove a member first")
+	ErrSeatCapReached   = errors.New("automatic seat management reached the seat cap set by the organization owner; raise the cap or add seats in billing settings")
+	errSeatsChanging    = errors.New("seats changed concurrently; try again")
+)
+
+// Reasons recorded with seat activities
+const (
+	SeatReasonInvitationAccepted = "invitation_accepted"
+	SeatReasonMemberAdded        = "member_added"
+	SeatReasonMemberRemoved      = "member_removed"
+	SeatReasonSCIMProvisioned    = "scim_provisioned"
+	SeatReasonSCIMReactivated    = "scim_reactivated"
+	SeatReasonSCIMSuspended      = "scim_suspended"
+	SeatReasonSCIMDeprovisioned  = "scim_deprovisioned"
+	SeatReasonMemberSuspended    = "member_suspended"
+	SeatReasonMemberRestored     = "member_restored"
+	SeatReasonSuspendedRemoved   = "suspended_member_removed"
+	SeatReasonInvitationRevoked  = "invitation_revoked"
+	SeatReasonInvitationExpired  = "invitation_expired"
+)
+
+// SeatService reconciles organization membership with billed seats.
+//
+// In automatic mode the Stripe quantity follows membership: a member taking a
+// seat buys one (up to the owner's cap) and removals release unused seats.
+// In manual mode members are blocked once every purchased seat is taken.
+// Organizations without a seat-based Stripe subscription are left to the plan limits.
+type SeatService interface {
+	// WithSeat makes sure a seat is available and then runs join, which must
+	// persist the membership that takes the seat.
+	WithSeat(ctx context.Context, orgID uint, reason string, join func() error) error
+	// ReleaseSeats lowers the seat quantity to current usage after members left (automatic mode)
+	ReleaseSeats(ctx context.Context, orgID uint, reason string) error
+	// CanAddSeat reports whether automatic mode can buy a seat for one more member
+	CanAddSeat(ctx context.Context, orgID uint) (bool, error)
+	// Status returns seat usage and the proration preview for the next seat
+	Status(ctx context.Context, orgID uint) (*domain.SeatStatusDTO, error)
+}
+
+type seatService struct {
+	stripe interface {
+		PreviewSeatChange(subscriptionID string, newQuantity int64) (*stripe.Invoice, error)
+		UpdateSubscriptionQuantity(subscriptionID string, quantity int64) (*stripe.Subscription, error)
+	}
+	subRepo interface {
+		GetByOrganizationID(ctx context.Context, orgID uint) (*domain.Subscription, error)
+		Update(ctx context.Context, sub *domain.Subscription) error
+		// WithSeatLock serializes seat changes of an organization across server instances
+		WithSeatLock(ctx context.Context, orgID uint, fn func() error) error
+	}
+	orgRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	}
+	orgUserRepo interface {
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+	}
+	prefRepo       repository.PreferencesRepository
+	activityLogger *ActivityLogger
+	logger         Logger
+}
+
+// NewSeatService creates a new seat service
+func NewSeatService(
+	stripe interface {
+		PreviewSeatChange(subscriptionID string, newQuantity int64) (*stripe.Invoice, error)
+		UpdateSubscriptionQuantity(subscriptionID string, quantity int64) (*stripe.Subscription, error)
+	},
+	subRepo interface {
+		GetByOrganizationID(ctx context.Context, orgID uint) (*domain.Subscription, error)
+		Update(ctx context.Context, sub *domain.Subscription) error
+		WithSeatLock(ctx context.Context, orgID uint, fn func() error) error
+	},
+	orgRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+	},
+	orgUserRepo interface {
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+	},
+	prefRepo repository.PreferencesRepository,
+	activityService UserActivityService,
+	logger Logger,
+) SeatService {
+	return &seatService{
+		stripe:         stripe,
+		subRepo:        subRepo,
+		orgRepo:        orgRepo,
+		orgUserRepo:    orgUserRepo,
+		prefRepo:       prefRepo,
+		activityLogger: NewActivityLogger(activityService),
+		logger:         logger,
+	}
+}
+
+// seatState is a snapshot of an organization's seats
+type seatState struct {
+	org     *domain.Organization
+	sub     *domain.Subscription
+	members []*domain.OrganizationUser
+	mode    domain.SeatManagementMode
+	seatCap *int
+
+	used    int
+	pending int
+	seats   int
+
+	// managed is true for seat-based subscriptions with purchased seats
+	managed bool
+	// autoAdjust is true when the Stripe quantity may be changed automatically
+	autoAdjust bool
+}
+
+// WithSeat makes sure a seat is available and then runs join. The seat check and join
+// run under the organization's seat lock so concurrent joins, on any server instance,
+// cannot both claim the last seat. Buying a seat calls Stripe outside the lock, after
+// which the check is repeated against the new quantity.
+func (s *seatService) WithSeat(ctx context.Context, orgID uint, reason string, join func() error) error {
+	bought := false
+	for attempt := 0; attempt < seatChangeAttempts; attempt++ {
+		var st *seatState
+		joined := false
+		err := s.subRepo.WithSeatLock(ctx, orgID, func() error {
+			var err error
+			st, err = s.load(ctx, orgID)
+			if err != nil {
+				return err
+			}
+			if !st.managed || st.used+1 <= st.seats {
+				joined = true
+				return join()
+			}
+			return s.checkSeatPurchase(ctx, st, reason)
+		})
+		if joined && err != nil && bought {
+			// Give the seat back; the member never took it
+			if releaseErr := s.ReleaseSeats(ctx, orgID, reason); releaseErr != nil {
+				s.logger.Error("failed to release seat after failed join", "org_id", orgID, "error", releaseErr)
+			}
+		}
+		if joined || err != nil {
+			return err
+		}
+
+		if err := s.setSeats(ctx, st, st.used+1, reason); err != nil {
+			return err
+		}
+		bought = true
+	}
+	return errSeatsChanging
+}
+
+// checkSeatPurchase reports whether one more seat may be bought for a full organization
+func (s *seatService) checkSeatPurchase(ctx context.Context, st *seatState, reason string) error {
+	if !st.autoAdjust {
+		s.logActivity(ctx, st, domain.ActivityTypeSeatLimitReached, reason, ActivityDetails{
+			"seats_purchased": st.seats,
+			"seats_used":      st.used,
+		})
+		return ErrSeatLimitReached
+	}
+	if st.seatCap != nil && st.used+1 > *st.seatCap {
+		s.logActivity(ctx, st, domain.ActivityTypeSeatLimitReached, reason, ActivityDetails{
+			"seats_purchased": st.seats,
+			"seats_used":      st.used,
+			"seat_cap":        *st.seatCap,
+		})
+		return ErrSeatCapReached
+	}
+	return nil
+}
+
+// ReleaseSeats lowers the seat quantity to current usage after members left. Usage is
+// read under the seat lock and Stripe is called outside it; if members joined in the
+// meantime the quantity is raised back to usage.
+func (s *seatService) ReleaseSeats(ctx context.Context, orgID uint, reason string) error {
+	lowered := false
+	for attempt := 0; attempt < seatChangeAttempts; attempt++ {
+		var st *seatState
+		target := 0
+		err := s.subRepo.WithSeatLock(ctx, orgID, func() error {
+			var err error
+			st, err = s.load(ctx, orgID)
+			if err != nil || !st.managed {
+				return err
+			}
+
+			seats := st.used
+			if seats < 1 {
+				seats = 1
+			}
+			if seats == st.seats || (seats > st.seats && !lowered) {
+				return nil
+			}
+			if !st.autoAdjust {
+				// Manual mode keeps purchased seats; record that one is free again
+				if seats < st.seats {
+					s.logActivity(ctx, st, domain.ActivityTypeSeatReleased, reason, ActivityDetails{
+						"seats_purchased": st.seats,
+						"seats_used":      st.used,
+					})
+				}
+				return nil
+			}
+			target = seats
+			return nil
+		})
+		if err != nil || target == 0 {
+			return err
+		}
+
+		if err := s.setSeats(ctx, st, target, reason); err != nil {
+			return err
+		}
+		lowered = true
+	}
+	return errSeatsChanging
+}
+
+// CanAddSeat reports whether automatic mode can buy a seat for one more member
+func (s *seatService) CanAddSeat(ctx context.Context, orgID uint) (bool, error) {
+	st, err := s.load(ctx, orgID)
+	if err != nil {
+		return false, err
+	}
+	if !st.managed || !st.autoAdjust {
+		return false, nil
+	}
+	return st.seatCap == nil || st.used+1 <= *st.seatCap, nil
+}
+
+// Status returns seat usage and the proration preview for the next seat
+func (s *seatService) Status(ctx context.Context, orgID uint) (*domain.SeatStatusDTO, error) {
+	st, err := s.load(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.SeatStatusDTO{
+		Mode:               st.mode,
+		SeatsPurchased:     st.seats,
+		SeatsUsed:          st.used,
+		PendingInvitations: st.pending,
+		SeatCap:            st.seatCap,
+		AutoAdjust:         st.autoAdjust,
+	}
+
+	next := st.used + 1
+	if st.autoAdjust && next > st.seats && (st.seatCap == nil || next <= *st.seatCap) {
+		inv, err := s.stripe.PreviewSeatChange(*st.sub.StripeSubscriptionID, int64(next))
+		if err != nil {
+			// The preview is informational; report usage without it
+			s.logger.Warn("failed to preview next seat", "org_id", orgID, "error", err)
+		} else {
+			status.NextSeatPreview = newSeatChangePreview(st.sub, next, inv)
+		}
+	}
+
+	return status, nil
+}
+
+// load builds a seat snapshot for an organization
+func (s *seatService) load(ctx context.Context, orgID uint) (*seatState, error) {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("organization not found: %w", err)
+	}
+
+	members, err := s.orgUserRepo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load organization members: %w", err)
+	}
+
+	st := &seatState{
+		org:     org,
+		members: members,
+		used:    domain.CountOccupiedSeats(members),
+	}
+	for _, m := range members {
+		if m.Status == domain.OrgUserStatusInvited {
+			st.pending++
+		}
+	}
+
+	st.mode, st.seatCap = s.settings(ctx, orgID)
+
+	sub, err := s.subRepo.GetByOrganizationID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return st, nil
+		}
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	st.sub = sub
+
+	if org.IsPersonal || sub.Plan == nil || !domain.IsSeatBasedPlan(sub.Plan.Code) ||
+		sub.SeatsPurchased == nil || *sub.SeatsPurchased <= 0 {
+		return st, nil
+	}
+	st.managed = true
+	st.seats = *sub.SeatsPurchased
+
+	// The plan limit always bounds automatic purchases; the owner cap can only lower it
+	if sub.Plan.MaxUsers != nil && (st.seatCap == nil || *sub.Plan.MaxUsers < *st.seatCap) {
+		planMax := *sub.Plan.MaxUsers
+		st.seatCap = &planMax
+	}
+
+	// Only healthy Stripe subscriptions are adjusted; past_due or canceled ones keep their seats
+	st.autoAdjust = st.mode == domain.SeatModeAutomatic &&
+		sub.StripeSubscriptionID != nil && *sub.StripeSubscriptionID != "" &&
+		(sub.State == domain.SubStateActive || sub.State == domain.SubStateTrialing)
+
+	return st, nil
+}
+
+// settings reads the seat mode and owner cap from organization settings
+func (s *seatService) settings(ctx context.Context, orgID uint) (domain.SeatManagementMode, *int) {
+	mode := domain.SeatModeAutomatic
+	var seatCap *int
+
+	prefs, err := s.prefRepo.ListByOwner(ctx, domain.OrgSettingOwnerType, orgID, domain.OrgSettingSectionBilling)
+	if err != nil {
+		s.logger.Warn("failed to load seat settings, using defaults", "org_id", orgID, "error", err)
+		return mode, nil
+	}
+	for _, p := range prefs {
+		switch p.Key {
+		case domain.OrgSettingKeySeatManagementMode:
+			mode = domain.ParseSeatManagementMode(p.Value)
+		case domain.OrgSettingKeySeatAutoMaxSeats:
+			if n, err := strconv.Atoi(strings.TrimSpace(p.Value)); err == nil && n > 0 {
+				seatCap = &n
+			}
+		}
+	}
+	return mode, seatCap
+}
+
+// setSeats changes the Stripe quantity (with proration) and records the change.
+// It must be called without holding the seat lock.
+func (s *seatService) setSeats(ctx context.Context, st *seatState, seats int, reason string) error {
+	subID := *st.sub.StripeSubscriptionID
+
+	details := ActivityDetails{
+		"old_seats": st.seats,
+		"new_seats": seats,
+	}
+	if inv, err := s.stripe.PreviewSeatChange(subID, int64(seats)); err != nil {
+		s.logger.Warn("failed to preview seat change", "org_id", st.org.ID, "seats", seats, "error", err)
+	} else {
+		preview := newSeatChangePreview(st.sub, seats, inv)
+		details[ActivityFieldAmount] = preview.ProratedAmount
+		details[ActivityFieldCurrency] = preview.Currency
+	}
+
+	if _, err := s.stripe.UpdateSubscriptionQuantity(subID, int64(seats)); err != nil {
+		return fmt.Errorf("failed to update seats: %w", err)
+	}
+
+	// Record the quantity under the lock on a fresh copy, so fields changed meanwhile are kept
+	err := s.subRepo.WithSeatLock(ctx, st.org.ID, func() error {
+		sub, err := s.subRepo.GetByOrganizationID(ctx, st.org.ID)
+		if err != nil {
+			return err
+		}
+		sub.SeatsPurchased = &seats
+		return s.subRepo.Update(ctx, sub)
+	})
+	if err != nil {
+		// Stripe is the source of truth; the subscription webhook will catch up
+		s.logger.Error("failed to sync seats_purchased to DB (Stripe already updated)", "org_id", st.org.ID, "seats", seats, "error", err)
+	}
+
+	s.logger.Info("seats adjusted automatically", "org_id", st.org.ID, "old_seats", st.seats, "new_seats", seats, "reason", reason)
+	s.logActivity(ctx, st, domain.ActivityTypeSeatsAdjusted, reason, details)
+	return nil
+}
+
+// logActivity records a billing activity on the organization owner
+func (s *seatService) logActivity(ctx context.Context, st *seatState, activityType domain.ActivityType, reason string, details ActivityDetails) {
+	details[ActivityFieldOrganizationID] = st.org.ID
+	details[ActivityFieldOrganizationName] = st.org.Name
+	details[ActivityFieldReason] = reason
+	details["mode"] = string(st.mode)
+	if st.sub != nil {
+		details[ActivityFieldSubscriptionID] = st.sub.ID
+	}
+
+	for _, m := range st.members {
+		if m.Role == domain.OrgRoleOwner {
+			s.activityLogger.LogCustomActivity(ctx, m.UserID, activityType, "system", "Seat Management", details)
+			return
+		}
+	}
+}
+
+// newSeatChangePreview summarizes a Stripe upcoming invoice for a seat change
+func newSeatChangePreview(sub *domain.Subscription, seats int, inv *stripe.Invoice) *domain.SeatChangePreview {
+	currentSeats := 0
+	if sub.SeatsPurchased != nil {
+		currentSeats = *sub.SeatsPurchased
+	}
+
+	nextBillingDate := ""
+	if sub.RenewAt != nil {
+		nextBillingDate = sub.RenewAt.Format("2006-01-02")
+	}
+
+	// Prorated amount is the difference between the upcoming total and the current subscription amount
+	proratedAmount := int64(0)
+	for _, line := range inv.Lines.Data {
+		if line.Proration {
+			proratedAmount += line.Amount
+		}
+	}
+
+	return &domain.SeatChangePreview{
+		CurrentSeats:      currentSeats,
+		RequestedSeats:    seats,
+		ProratedAmount:    proratedAmount,
+		Currency:          string(inv.Currency),
+		NextBillingDate:   nextBillingDate,
+		NextBillingAmount: inv.Total,
+		DiscountAmount:    stripeClient.TotalDiscountAmount(inv),
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v81"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// fakeSeatStripe records every quantity sent to Stripe and whether the seat lock was held
+type fakeSeatStripe struct {
+	subs         *fakeSeatSubRepo
+	quantities   []int64
+	calledLocked bool
+	onUpdate     func()
+}
+
+func (f *fakeSeatStripe) PreviewSeatChange(string, int64) (*stripe.Invoice, error) {
+	return &stripe.Invoice{Currency: "usd", Lines: &stripe.InvoiceLineItemList{}}, nil
+}
+func (f *fakeSeatStripe) UpdateSubscriptionQuantity(_ string, quantity int64) (*stripe.Subscription, error) {
+	f.calledLocked = f.calledLocked || f.subs.held
+	f.quantities = append(f.quantities, quantity)
+	if f.onUpdate != nil {
+		f.onUpdate()
+		f.onUpdate = nil
+	}
+	return &stripe.Subscription{}, nil
+}
+
+// fakeSeatSubRepo holds one subscription and counts how often the seat lock is taken
+type fakeSeatSubRepo struct {
+	sub   *domain.Subscription
+	locks int
+	held  bool
+}
+
+func (f *fakeSeatSubRepo) GetByOrganizationID(context.Context, uint) (*domain.Subscription, error) {
+	if f.sub == nil {
+		return nil, repository.ErrNotFound
+	}
+	return f.sub, nil
+}
+func (f *fakeSeatSubRepo) Update(_ context.Context, sub *domain.Subscription) error {
+	f.sub = sub
+	return nil
+}
+func (f *fakeSeatSubRepo) WithSeatLock(_ context.Context, _ uint, fn func() error) error {
+	f.locks++
+	f.held = true
+	defer func() { f.held = false }()
+	return fn()
+}
+
+type fakeSeatPrefRepo struct {
+	repository.PreferencesRepository
+	prefs []*domain.Preference
+}
+
+func (f *fakeSeatPrefRepo) ListByOwner(context.Context, string, uint, string) ([]*domain.Preference, error) {
+	return f.prefs, nil
+}
+
+type seatFixture struct {
+	svc      SeatService
+	stripe   *fakeSeatStripe
+	subs     *fakeSeatSubRepo
+	members  *suspensionMemberRepo
+	prefs    *fakeSeatPrefRepo
+	activity *fakeActivityService
+}
+
+// newSeatFixture sets up org 1 on an active Team subscription with 2 purchased seats,
+// both taken by the owner (user 1) and a member (user 2)
+func newSeatFixture(t *testing.T) *seatFixture {
+	t.Helper()
+
+	seats, maxUsers, subID := 2, 10, "sub_123"
+	f := &seatFixture{
+		subs: &fakeSeatSubRepo{sub: &domain.Subscription{
+			ID:                   7,
+			OrganizationID:       1,
+			State:                domain.SubStateActive,
+			Plan:                 &domain.Plan{Code: "team-monthly", MaxUsers: &maxUsers},
+			SeatsPurchased:       &seats,
+			StripeSubscriptionID: &subID,
+		}},
+		members: &suspensionMemberRepo{members: map[uint]*domain.OrganizationUser{
+			1: {ID: 1, OrganizationID: 1, UserID: 1, Role: domain.OrgRoleOwner, Status: domain.OrgUserStatusConfirmed},
+			2: {ID: 2, OrganizationID: 1, UserID: 2, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed},
+		}},
+		prefs:    &fakeSeatPrefRepo{},
+		activity: &fakeActivityService{},
+	}
+	f.stripe = &fakeSeatStripe{subs: f.subs}
+	orgs := newFakeOrgRepo()
+	orgs.add(&domain.Organization{ID: 1, Name: "Acme"})
+
+	f.svc = NewSeatService(f.stripe, f.subs, orgs, f.members, f.prefs, f.activity, noopLogger{})
+	return f
+}
+
+// join returns a join function that adds a confirmed member, like accepting an invitation
+func (f *seatFixture) join(id uint) func() error {
+	return func() error {
+		f.members.members[id] = &domain.OrganizationUser{ID: id, OrganizationID: 1, UserID: id, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed}
+		return nil
+	}
+}
+
+func (f *seatFixture) setting(key, value string) {
+	f.prefs.prefs = append(f.prefs.prefs, &domain.Preference{Key: key, Value: value})
+}
+
+func TestSeatService_ManualModeBlocksWhenFull(t *testing.T) {
+	ctx := context.Background()
+	f := newSeatFixture(t)
+	f.setting(domain.OrgSettingKeySeatManagementMode, string(domain.SeatModeManual))
+
+	err := f.svc.WithSeat(ctx, 1, SeatReasonInvitationAccepted, f.join(3))
+	assert.ErrorIs(t, err, ErrSeatLimitReached)
+	assert.NotContains(t, f.members.members, uint(3), "join is not run")
+	assert.Empty(t, f.stripe.quantities, "manual mode never buys seats")
+	assert.Equal(t, []domain.ActivityType{domain.ActivityTypeSeatLimitReached}, f.activity.logged)
+
+	// A free seat lets the member in without touching Stripe
+	delete(f.members.members, 2)
+	require.NoError(t, f.svc.WithSeat(ctx, 1, SeatReasonInvitationAccepted, f.join(3)))
+	assert.Contains(t, f.members.members, uint(3))
+	assert.Empty(t, f.stripe.quantities)
+	assert.Equal(t, 2, f.subs.locks, "every seat change runs under the seat lock")
+}
+
+func TestSeatService_AutomaticModeRespectsCap(t *testing.T) {
+	ctx := context.Background()
+	f := newSeatFixture(t)
+	f.setting(domain.OrgSettingKeySeatAutoMaxSeats, "3")
+
+	require.NoError(t, f.svc.WithSeat(ctx, 1, SeatReasonInvitationAccepted, f.join(3)))
+	assert.Equal(t, []int64{3}, f.stripe.quantities)
+	assert.Equal(t, 3, *f.subs.sub.SeatsPurchased)
+	assert.Contains(t, f.members.members, uint(3))
+	assert.False(t, f.stripe.calledLocked, "Stripe is called outside the seat lock")
+
+	err := f.svc.WithSeat(ctx, 1, SeatReasonInvitationAccepted, f.join(4))
+	assert.ErrorIs(t, err, ErrSeatCapReached)
+	assert.NotContains(t, f.members.members, uint(4))
+	assert.Equal(t, []int64{3}, f.stripe.quantities, "no seat is bought beyond the cap")
+
+	canAdd, err := f.svc.CanAddSeat(ctx, 1)
+	require.NoError(t, err)
+	assert.False(t, canAdd)
+}
+
+func TestSeatService_FailedJoinGivesTheSeatBack(t *testing.T) {
+	ctx := context.Background()
+	f := newSeatFixture(t)
+
+	joinErr := errors.New("invitation already used")
+	err := f.svc.WithSeat(ctx, 1, SeatReasonInvitationAccepted, func() error { return joinErr })
+	assert.ErrorIs(t, err, joinErr)
+	assert.Equal(t, []int64{3, 2}, f.stripe.quantities, "the seat bought for the join is released")
+	assert.Equal(t, 2, *f.subs.sub.SeatsPurchased)
+	assert.Equal(t, []domain.ActivityType{domain.ActivityTypeSeatsAdjusted, domain.ActivityTypeSeatsAdjusted}, f.activity.logged)
+	assert.False(t, f.stripe.calledLocked)
+}
+
+func TestSeatService_ReleaseRaisesSeatsForMembersWhoJoinedMeanwhile(t *testing.T) {
+	ctx := context.Background()
+	f := newSeatFixture(t)
+	seats := 4
+	f.subs.sub.SeatsPurchased = &seats
+
+	// A member joins on another instance while Stripe lowers the quantity to 2
+	f.stripe.onUpdate = func() { _ = f.join(3)() }
+
+	require.NoError(t, f.svc.ReleaseSeats(ctx, 1, SeatReasonMemberRemoved))
+	assert.Equal(t, []int64{2, 3}, f.stripe.quantities)
+	assert.Equal(t, 3, *f.subs.sub.SeatsPurchased)
+}
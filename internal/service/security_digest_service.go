@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/authz"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+)
+
+const (
+	// securityDigestPeriod is how often an organization's admins receive the digest
+	securityDigestPeriod = 7 * 24 * time.Hour
+	// failedLoginSpikeMin is the fewest failed sign-ins in a week that can count as a spike
+	failedLoginSpikeMin = 10
+)
+
+// SecurityDigestService sends the weekly security digest to the admins of
+// organizations that enabled the weekly security report setting.
+type SecurityDigestService interface {
+	// SendWeeklyDigests emails every organization due for a digest and returns how many emails were sent
+	SendWeeklyDigests(ctx context.Context) (int, error)
+}
+
+type securityDigestService struct {
+	prefRepo interface {
+		ListByOwner(ctx context.Context, ownerType string, ownerID uint, section string) ([]*domain.Preference, error)
+		ListOwnerIDs(ctx context.Context, ownerType, section, key, value string) ([]uint, error)
+	}
+	orgRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+		Update(ctx context.Context, org *domain.Organization) error
+	}
+	orgUserRepo interface {
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+	}
+	twoFactorReport interface {
+		GetTwoFactorCompliance(ctx context.Context, requesterUserID uint, orgID uint) (*domain.TwoFactorComplianceResponse, error)
+	}
+	breachRepo interface {
+		ListBreachesByOrganization(ctx context.Context, orgID uint) ([]*domain.BreachRecord, error)
+		GetEmailByID(ctx context.Context, id uint) (*domain.MonitoredEmail, error)
+	}
+	activityRepo interface {
+		CountByUserIDs(ctx context.Context, userIDs []uint, activityType domain.ActivityType, since, until time.Time) (int64, error)
+	}
+	policyRepo interface {
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationPolicy, error)
+	}
+	shareRepo interface {
+		ListExpiringByOrganization(ctx context.Context, orgID uint, after, before time.Time) ([]*domain.ItemShare, error)
+	}
+	notificationPrefs interface {
+		GetForUser(ctx context.Context, userID uint) (*domain.UserNotificationPreferences, error)
+	}
+	emailSender  email.Sender
+	emailBuilder *email.EmailBuilder
+	logger       Logger
+	now          func() time.Time
+}
+
+// NewSecurityDigestService creates a new security digest service
+func NewSecurityDigestService(
+	prefRepo interface {
+		ListByOwner(ctx context.Context, ownerType string, ownerID uint, section string) ([]*domain.Preference, error)
+		ListOwnerIDs(ctx context.Context, ownerType, section, key, value string) ([]uint, error)
+	},
+	orgRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Organization, error)
+		Update(ctx context.Context, org *domain.Organization) error
+	},
+	orgUserRepo interface {
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationUser, error)
+	},
+	twoFactorReport interface {
+		GetTwoFactorCompliance(ctx context.Context, requesterUserID uint, orgID uint) (*domain.TwoFactorComplianceResponse, error)
+	},
+	breachRepo interface {
+		ListBreachesByOrganization(ctx context.Context, orgID uint) ([]*domain.BreachRecord, error)
+		GetEmailByID(ctx context.Context, id uint) (*domain.MonitoredEmail, error)
+	},
+	activityRepo interface {
+		CountByUserIDs(ctx context.Context, userIDs []uint, activityType domain.ActivityType, since, until time.Time) (int64, error)
+	},
+	policyRepo interface {
+		ListByOrganization(ctx context.Context, orgID uint) ([]*domain.OrganizationPolicy, error)
+	},
+	shareRepo interface {
+		ListExpiringByOrganization(ctx context.Context, orgID uint, after, before time.Time) ([]*domain.ItemShare, error)
+	},
+	notificationPrefs interface {
+		GetForUser(ctx context.Context, userID uint) (*domain.UserNotificationPreferences, error)
+	},
+	emailSender email.Sender,
+	emailBuilder *email.EmailBuilder,
+	logger Logger,
+) SecurityDigestService {
+	return &securityDigestService{
+		prefRepo:          prefRepo,
+		orgRepo:           orgRepo,
+		orgUserRepo:       orgUserRepo,
+		twoFactorReport:   twoFactorReport,
+		breachRepo:        breachRepo,
+		activityRepo:      activityRepo,
+		policyRepo:        policyRepo,
+		shareRepo:         shareRepo,
+		notificationPrefs: notificationPrefs,
+		emailSender:       emailSender,
+		emailBuilder:      emailBuilder,
+		logger:            logger,
+		now:               time.Now,
+	}
+}
+
+func (s *securityDigestService) SendWeeklyDigests(ctx context.Context) (int, error) {
+	if s.emailSender == nil || s.emailBuilder == nil {
+		return 0, nil
+	}
+
+	orgIDs, err := s.prefRepo.ListOwnerIDs(ctx, domain.OrgSettingOwnerType, domain.OrgSettingSectionNotifications, domain.OrgSettingKeyWeeklySecurityReport, "true")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list organizations with weekly security reports: %w", err)
+	}
+
+	now := s.now()
+	sent := 0
+	for _, orgID := range orgIDs {
+		org, err := s.orgRepo.GetByID(ctx, orgID)
+		if err != nil {
+			s.logger.Warn("failed to load organization for security digest", "org_id", orgID, "error", err)
+			continue
+		}
+		if org.IsPersonal || !org.IsActive || org.DeletedAt != nil {
+			continue
+		}
+		if org.SecurityDigestSentAt != nil && now.Sub(*org.SecurityDigestSentAt) < securityDigestPeriod {
+			continue
+		}
+		if !s.adminNotificationsEnabled(ctx, orgID) {
+			continue
+		}
+
+		n, err := s.sendDigest(ctx, org, now)
+		if err != nil {
+			s.logger.Error("failed to send security digest", "org_id", orgID, "error", err)
+			continue
+		}
+
+		org.SecurityDigestSentAt = &now
+		if err := s.orgRepo.Update(ctx, org); err != nil {
+			s.logger.Error("failed to record security digest", "org_id", orgID, "error", err)
+		}
+		sent += n
+	}
+
+	if sent > 0 {
+		s.logger.Info("weekly security digests sent", "emails", sent)
+	}
+	return sent, nil
+}
+
+// sendDigest builds the organization's digest and emails it to each subscribed member with view_audit
+func (s *securityDigestService) sendDigest(ctx context.Context, org *domain.Organization, now time.Time) (int, error) {
+	members, err := s.orgUserRepo.ListByOrganization(ctx, org.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	var admins []*domain.OrganizationUser
+	for _, m := range members {
+		if m.User != nil && isActiveMember(m) && authz.HasPermission(m, domain.OrgPermViewAudit) {
+			admins = append(admins, m)
+		}
+	}
+	if len(admins) == 0 {
+		return 0, nil
+	}
+
+	digest, err := s.buildDigest(ctx, org.ID, admins[0].UserID, members, now)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, admin := range admins {
+		prefs, err := s.notificationPrefs.GetForUser(ctx, admin.UserID)
+		if err != nil {
+			s.logger.Warn("failed to load notification preferences", "user_id", admin.UserID, "error", err)
+			continue
+		}
+		if !prefs.SecurityDigestEmails {
+			continue
+		}
+
+		msg, err := s.emailBuilder.BuildSecurityDigestEmail(admin.User.Email, org.ID, org.Name, digest)
+		if err != nil {
+			s.logger.Error("failed to build security digest email", "org_id", org.ID, "error", err)
+			continue
+		}
+		if err := s.emailSender.Send(ctx, msg); err != nil {
+			s.logger.Error("failed to send security digest email", "org_id", org.ID, "user_id", admin.UserID, "error", err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// buildDigest collects the organization's security events of the past week
+func (s *securityDigestService) buildDigest(ctx context.Context, orgID, adminUserID uint, members []*domain.OrganizationUser, now time.Time) (*email.SecurityDigest, error) {
+	since := now.Add(-securityDigestPeriod)
+	digest := &email.SecurityDigest{
+		PeriodStart: since.UTC().Format("January 2, 2006"),
+		PeriodEnd:   now.UTC().Format("January 2, 2006"),
+	}
+
+	var userIDs []uint
+	for _, m := range members {
+		if !isActiveMember(m) {
+			continue
+		}
+		userIDs = append(userIDs, m.UserID)
+		joinedAt := m.CreatedAt
+		if m.AcceptedAt != nil {
+			joinedAt = *m.AcceptedAt
+		}
+		if !joinedAt.Before(since) && m.User != nil {
+			digest.NewMembers = append(digest.NewMembers, m.User.Email)
+		}
+	}
+
+	compliance, err := s.twoFactorReport.GetTwoFactorCompliance(ctx, adminUserID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load 2FA compliance: %w", err)
+	}
+	digest.TwoFactorTotal = compliance.TotalMembers
+	digest.TwoFactorCompliant = compliance.CompliantCount
+	for _, m := range compliance.Members {
+		if !m.TwoFactorEnabled {
+			digest.TwoFactorMissing = append(digest.TwoFactorMissing, m.Email)
+		}
+	}
+
+	breaches, err := s.breachRepo.ListBreachesByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list breaches: %w", err)
+	}
+	monitored := make(map[uint]string)
+	for _, b := range breaches {
+		if b.IsDismissed || b.DiscoveredAt.Before(since) {
+			continue
+		}
+		address, ok := monitored[b.MonitoredEmailID]
+		if !ok {
+			if m, err := s.breachRepo.GetEmailByID(ctx, b.MonitoredEmailID); err == nil {
+				address = m.Email
+			}
+			monitored[b.MonitoredEmailID] = address
+		}
+		entry := b.BreachName
+		if address != "" {
+			entry = fmt.Sprintf("%s (%s)", b.BreachName, address)
+		}
+		digest.NewBreaches = append(digest.NewBreaches, entry)
+	}
+
+	failed, err := s.activityRepo.CountByUserIDs(ctx, userIDs, domain.ActivityTypeFailedSignIn, since, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count failed sign-ins: %w", err)
+	}
+	previous, err := s.activityRepo.CountByUserIDs(ctx, userIDs, domain.ActivityTypeFailedSignIn, since.Add(-securityDigestPeriod), since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count failed sign-ins: %w", err)
+	}
+	digest.FailedLogins = int(failed)
+	digest.FailedLoginsPrevious = int(previous)
+	digest.FailedLoginSpike = failed >= failedLoginSpikeMin && failed >= 2*previous
+
+	policies, err := s.policyRepo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policies: %w", err)
+	}
+	names := make(map[domain.PolicyType]string)
+	for _, def := range domain.AllPolicyDefinitions() {
+		names[def.Type] = def.Name
+	}
+	for _, p := range policies {
+		if p.UpdatedAt.Before(since) {
+			continue
+		}
+		name := names[p.Type]
+		if name == "" {
+			name = string(p.Type)
+		}
+		state := "disabled"
+		if p.Enabled {
+			state = "enabled"
+		}
+		digest.PolicyChanges = append(digest.PolicyChanges, fmt.Sprintf("%s: %s", name, state))
+	}
+
+	shares, err := s.shareRepo.ListExpiringByOrganization(ctx, orgID, now, now.Add(securityDigestPeriod))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expiring shares: %w", err)
+	}
+	digest.ExpiringShares = len(shares)
+
+	return digest, nil
+}
+
+// adminNotificationsEnabled reads the organization's admin notification setting, which defaults to on
+func (s *securityDigestService) adminNotificationsEnabled(ctx context.Context, orgID uint) bool {
+	prefs, err := s.prefRepo.ListByOwner(ctx, domain.OrgSettingOwnerType, orgID, domain.OrgSettingSectionNotifications)
+	if err != nil {
+		s.logger.Warn("failed to load notification settings", "org_id", orgID, "error", err)
+		return false
+	}
+	for _, p := range prefs {
+		if p.Key != domain.OrgSettingKeyAdminNotifications {
+			continue
+		}
+		if enabled, err := strconv.ParseBool(strings.TrimSpace(p.Value)); err == nil {
+			return enabled
+		}
+	}
+	return true
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+)
+
+// fakeDigestPrefRepo serves organization settings by owner
+type fakeDigestPrefRepo struct {
+	prefs map[uint][]*domain.Preference
+}
+
+func (f *fakeDigestPrefRepo) ListByOwner(_ context.Context, _ string, ownerID uint, _ string) ([]*domain.Preference, error) {
+	return f.prefs[ownerID], nil
+}
+func (f *fakeDigestPrefRepo) ListOwnerIDs(_ context.Context, _, _, key, value string) ([]uint, error) {
+	var ids []uint
+	for id, prefs := range f.prefs {
+		for _, p := range prefs {
+			if p.Key == key && p.Value == value {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+type fakeTwoFactorReport struct{}
+
+func (fakeTwoFactorReport) GetTwoFactorCompliance(_ context.Context, _ uint, _ uint) (*domain.TwoFactorComplianceResponse, error) {
+	return &domain.TwoFactorComplianceResponse{
+		TotalMembers:      2,
+		CompliantCount:    1,
+		NonCompliantCount: 1,
+		Members: []domain.TwoFactorComplianceMember{
+			{Email: "admin@acme.test", TwoFactorEnabled: true},
+			{Email: "new@acme.test"},
+		},
+	}, nil
+}
+
+type fakeDigestBreaches struct {
+	records []*domain.BreachRecord
+}
+
+func (f *fakeDigestBreaches) ListBreachesByOrganization(context.Context, uint) ([]*domain.BreachRecord, error) {
+	return f.records, nil
+}
+func (f *fakeDigestBreaches) GetEmailByID(_ context.Context, id uint) (*domain.MonitoredEmail, error) {
+	return &domain.MonitoredEmail{ID: id, Email: "ops@acme.test"}, nil
+}
+
+// fakeFailedLogins holds failed sign-in times
+type fakeFailedLogins struct {
+	at []time.Time
+}
+
+func (f *fakeFailedLogins) CountByUserIDs(_ context.Context, _ []uint, _ domain.ActivityType, since, until time.Time) (int64, error) {
+	var n int64
+	for _, t := range f.at {
+		if !t.Before(since) && t.Before(until) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+type fakeDigestPolicies struct {
+	policies []*domain.OrganizationPolicy
+}
+
+func (f *fakeDigestPolicies) ListByOrganization(context.Context, uint) ([]*domain.OrganizationPolicy, error) {
+	return f.policies, nil
+}
+
+type fakeDigestShares struct {
+	shares []*domain.ItemShare
+}
+
+func (f *fakeDigestShares) ListExpiringByOrganization(_ context.Context, _ uint, after, before time.Time) ([]*domain.ItemShare, error) {
+	var out []*domain.ItemShare
+	for _, s := range f.shares {
+		if s.ExpiresAt.After(after) && !s.ExpiresAt.After(before) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// fakeNotificationPrefs lets users unsubscribe from the digest
+type fakeNotificationPrefs struct {
+	unsubscribed map[uint]bool
+}
+
+func (f *fakeNotificationPrefs) GetForUser(_ context.Context, userID uint) (*domain.UserNotificationPreferences, error) {
+	return &domain.UserNotificationPreferences{UserID: userID, SecurityEmails: true, SecurityDigestEmails: !f.unsubscribed[userID]}, nil
+}
+
+type securityDigestFixture struct {
+	svc    *securityDigestService
+	prefs  *fakeDigestPrefRepo
+	org    *domain.Organization
+	unsub  *fakeNotificationPrefs
+	sender *fakeEmailSender
+	clock  time.Time
+}
+
+// newSecurityDigestFixture sets up org 1 with the weekly report enabled, an owner
+// (user 101), an admin (user 102) and a member (user 103) who joined this week.
+func newSecurityDigestFixture(t *testing.T) *securityDigestFixture {
+	t.Helper()
+
+	clock := time.Date(2026, 6, 1, 8, 0, 0, 0, time.UTC)
+	joined := clock.Add(-48 * time.Hour)
+	f := &securityDigestFixture{
+		prefs: &fakeDigestPrefRepo{prefs: map[uint][]*domain.Preference{
+			1: {{Key: domain.OrgSettingKeyWeeklySecurityReport, Value: "true"}},
+		}},
+		org:    &domain.Organization{ID: 1, Name: "Acme", IsActive: true},
+		unsub:  &fakeNotificationPrefs{unsubscribed: map[uint]bool{}},
+		sender: &fakeEmailSender{},
+		clock:  clock,
+	}
+
+	orgs := &fakeOrgRepo{orgs: map[uint]*domain.Organization{}}
+	orgs.add(f.org)
+	members := &suspensionMemberRepo{members: map[uint]*domain.OrganizationUser{
+		11: {ID: 11, OrganizationID: 1, UserID: 101, Role: domain.OrgRoleOwner, Status: domain.OrgUserStatusConfirmed, CreatedAt: clock.AddDate(-1, 0, 0), User: &domain.User{Email: "owner@acme.test"}},
+		12: {ID: 12, OrganizationID: 1, UserID: 102, Role: domain.OrgRoleAdmin, Status: domain.OrgUserStatusConfirmed, CreatedAt: clock.AddDate(-1, 0, 0), User: &domain.User{Email: "admin@acme.test"}},
+		13: {ID: 13, OrganizationID: 1, UserID: 103, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed, CreatedAt: joined.Add(-time.Hour), AcceptedAt: &joined, User: &domain.User{Email: "new@acme.test"}},
+	}}
+
+	var failed []time.Time
+	for i := 0; i < 12; i++ {
+		failed = append(failed, clock.Add(-time.Duration(i+1)*time.Hour))
+	}
+	failed = append(failed, clock.AddDate(0, 0, -10))
+	expiresSoon := clock.Add(72 * time.Hour)
+	expiresLater := clock.AddDate(0, 1, 0)
+	builder, err := email.NewEmailBuilder("https://vault.example.com", "noreply@example.com")
+	require.NoError(t, err)
+
+	f.svc = NewSecurityDigestService(
+		f.prefs, orgs, members, fakeTwoFactorReport{},
+		&fakeDigestBreaches{records: []*domain.BreachRecord{
+			{MonitoredEmailID: 7, BreachName: "Adobe", DiscoveredAt: clock.Add(-24 * time.Hour)},
+			{MonitoredEmailID: 7, BreachName: "LinkedIn", DiscoveredAt: clock.AddDate(0, -2, 0)},
+			{MonitoredEmailID: 7, BreachName: "Dropbox", DiscoveredAt: clock.Add(-time.Hour), IsDismissed: true},
+		}},
+		&fakeFailedLogins{at: failed},
+		&fakeDigestPolicies{policies: []*domain.OrganizationPolicy{
+			{Type: domain.PolicyRequireTwoFactor, Enabled: true, UpdatedAt: clock.Add(-72 * time.Hour)},
+			{Type: domain.PolicySessionTimeout, Enabled: true, UpdatedAt: clock.AddDate(0, -1, 0)},
+		}},
+		&fakeDigestShares{shares: []*domain.ItemShare{{ExpiresAt: &expiresSoon}, {ExpiresAt: &expiresLater}}},
+		f.unsub, f.sender, builder, noopLogger{},
+	).(*securityDigestService)
+	f.svc.now = func() time.Time { return f.clock }
+	return f
+}
+
+func TestSecurityDigest_SendsWeekly(t *testing.T) {
+	ctx := context.Background()
+	f := newSecurityDigestFixture(t)
+
+	sent, err := f.svc.SendWeeklyDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	require.Len(t, f.sender.sent, 2)
+	require.NotNil(t, f.org.SecurityDigestSentAt)
+
+	members, err := f.svc.orgUserRepo.ListByOrganization(ctx, 1)
+	require.NoError(t, err)
+	digest, err := f.svc.buildDigest(ctx, 1, 101, members, f.clock)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new@acme.test"}, digest.NewMembers)
+	assert.Equal(t, []string{"new@acme.test"}, digest.TwoFactorMissing)
+	assert.Equal(t, []string{"Adobe (ops@acme.test)"}, digest.NewBreaches)
+	assert.Equal(t, 12, digest.FailedLogins)
+	assert.Equal(t, 1, digest.FailedLoginsPrevious)
+	assert.True(t, digest.FailedLoginSpike)
+	assert.Equal(t, []string{"Require Two-Factor Authentication: enabled"}, digest.PolicyChanges)
+	assert.Equal(t, 1, digest.ExpiringShares)
+	assert.True(t, strings.Contains(f.sender.sent[0].Body, "Adobe (ops@acme.test)"))
+
+	// Nothing more until a week has passed
+	f.clock = f.clock.Add(6 * 24 * time.Hour)
+	sent, err = f.svc.SendWeeklyDigests(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	f.clock = f.clock.Add(24 * time.Hour)
+	sent, err = f.svc.SendWeeklyDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+}
+
+func TestSecurityDigest_OptOuts(t *testing.T) {
+	ctx := context.Background()
+	f := newSecurityDigestFixture(t)
+
+	f.unsub.unsubscribed[102] = true
+	sent, err := f.svc.SendWeeklyDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, "owner@acme.test", f.sender.sent[0].To)
+
+	// Turning off admin notifications silences the digest too
+	f.org.SecurityDigestSentAt = nil
+	f.prefs.prefs[1] = append(f.prefs.prefs[1], &domain.Preference{Key: domain.OrgSettingKeyAdminNotifications, Value: "false"})
+	sent, err = f.svc.SendWeeklyDigests(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Nil(t, f.org.SecurityDigestSentAt)
+}
+
+func TestSecurityDigest_SentToMembersWithViewAudit(t *testing.T) {
+	ctx := context.Background()
+	f := newSecurityDigestFixture(t)
+
+	members := f.svc.orgUserRepo.(*suspensionMemberRepo)
+	members.members[13].CustomRole = &domain.OrganizationCustomRole{Name: "Auditor", Permissions: string(domain.OrgPermViewAudit)}
+
+	sent, err := f.svc.SendWeeklyDigests(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sent)
+	recipients := make([]string, 0, len(f.sender.sent))
+	for _, msg := range f.sender.sent {
+		recipients = append(recipients, msg.To)
+	}
+	assert.ElementsMatch(t, []string{"owner@acme.test", "admin@acme.test", "new@acme.test"}, recipients)
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+const (
+	sendAccessCodeLength         = 6
+	sendAccessCodeExpiry         = 10 * time.Minute
+	sendAccessCodeResendInterval = time.Minute
+	sendAccessCodeMaxAttempts    = 5
+	sendAccessTokenExpiry        = time.Hour
+	sendAccessEventsLimit        = 200
+)
+
+var (
+	ErrSendVerificationRequired = errors.New("send is restricted to verified recipients")
+)
+
+// normalizeSendRecipients lowercases, validates and de-duplicates recipient emails
+func normalizeSendRecipients(emails []string) ([]string, error) {
+	if len(emails) > domain.MaxSendRecipients {
+		return nil, fmt.Errorf("%w: at most %d recipients", repository.ErrInvalidInput, domain.MaxSendRecipients)
+	}
+
+	seen := make(map[string]struct{}, len(emails))
+	normalized := make([]string, 0, len(emails))
+	for _, raw := range emails {
+		email := strings.ToLower(strings.TrimSpace(raw))
+		if email == "" {
+			continue
+		}
+		addr, err := mail.ParseAddress(email)
+		if err != nil || addr.Address != email {
+			return nil, fmt.Errorf("%w: invalid recipient email %q", repository.ErrInvalidInput, raw)
+		}
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+		normalized = append(normalized, email)
+	}
+	return normalized, nil
+}
+
+// hashSendSecret hashes a one-time code or access token for storage
+func hashSendSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func sendSecretMatches(hash *string, secret string) bool {
+	if hash == nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(*hash), []byte(hashSendSecret(secret))) == 1
+}
+
+func (s *sendService) isRestricted(ctx context.Context, send *domain.Send) (bool, error) {
+	count, err := s.recipientRepo.CountBySend(ctx, send.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check send recipients: %w", err)
+	}
+	return count > 0, nil
+}
+
+// checkRecipientAccess allows the recipient verification flow only on available, restricted sends.
+// The password is checked after the code so it cannot be probed without one.
+func (s *sendService) checkRecipientAccess(ctx context.Context, send *domain.Send) error {
+	if err := checkSendAccess(send, ""); err != nil && !errors.Is(err, repository.ErrForbidden) {
+		return err
+	}
+	restricted, err := s.isRestricted(ctx, send)
+	if err != nil {
+		return err
+	}
+	if !restricted {
+		return repository.ErrInvalidInput
+	}
+	return nil
+}
+
+// recipientByToken resolves the recipient that verified with the given access token
+func (s *sendService) recipientByToken(ctx context.Context, send *domain.Send, token string) (*domain.SendRecipient, error) {
+	if token == "" {
+		return nil, ErrSendVerificationRequired
+	}
+
+	recipient, err := s.recipientRepo.GetBySendAndTokenHash(ctx, send.ID, hashSendSecret(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSendVerificationRequired
+		}
+		return nil, err
+	}
+	if !recipient.HasValidToken() {
+		return nil, ErrSendVerificationRequired
+	}
+	return recipient, nil
+}
+
+func (s *sendService) RequestRecipientCode(ctx context.Context, accessID string, req *domain.SendAccessRequest) error {
+	send, err := s.sendRepo.GetByAccessID(ctx, accessID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkRecipientAccess(ctx, send); err != nil {
+		return err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	recipient, err := s.recipientRepo.GetBySendAndEmail(ctx, send.ID, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			// Do not reveal who the recipients are; the attempt is still shown to the creator
+			s.recordAccessEvent(ctx, send, nil, email, domain.SendAccessUnknownRecipient, req)
+			return nil
+		}
+		return err
+	}
+
+	// Throttle resends; the previous code stays valid until its attempts are used up
+	if recipient.HasValidCode() && recipient.CodeAttempts < sendAccessCodeMaxAttempts &&
+		recipient.CodeSentAt != nil && time.Since(*recipient.CodeSentAt) < sendAccessCodeResendInterval {
+		return nil
+	}
+
+	code, err := generateRandomCode(sendAccessCodeLength)
+	if err != nil {
+		return fmt.Errorf("failed to generate access code: %w", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(sendAccessCodeExpiry)
+	codeHash := hashSendSecret(code)
+	recipient.CodeHash = &codeHash
+	recipient.CodeSentAt = &now
+	recipient.CodeExpiresAt = &expiresAt
+	recipient.CodeAttempts = 0
+	if err := s.recipientRepo.Update(ctx, recipient); err != nil {
+		return fmt.Errorf("failed to save access code: %w", err)
+	}
+
+	senderName := ""
+	if !send.HideEmail && send.Creator != nil {
+		senderName = send.Creator.Email
+		if send.Creator.Name != "" {
+			senderName = send.Creator.Name
+		}
+	}
+
+	msg, err := s.emailBuilder.BuildSecureSendAccessCodeEmail(recipient.Email, senderName, send.Name, code, sendAccessCodeExpiry)
+	if err != nil {
+		return fmt.Errorf("failed to build access code email: %w", err)
+	}
+	if err := s.emailSender.Send(ctx, msg); err != nil {
+		s.logger.Error("failed to send secure send access code", "send_id", send.ID, "error", err)
+		return fmt.Errorf("failed to send access code email: %w", err)
+	}
+
+	s.recordAccessEvent(ctx, send, recipient, recipient.Email, domain.SendAccessCodeSent, req)
+	return nil
+}
+
+func (s *sendService) VerifyRecipient(ctx context.Context, accessID string, req *domain.SendAccessRequest) (*domain.SendAccessDTO, error) {
+	send, err := s.sendRepo.GetByAccessID(ctx, accessID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRecipientAccess(ctx, send); err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	recipient, err := s.recipientRepo.GetBySendAndEmail(ctx, send.ID, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			s.recordAccessEvent(ctx, send, nil, email, domain.SendAccessUnknownRecipient, req)
+			return nil, ErrCodeInvalid
+		}
+		return nil, err
+	}
+
+	if !recipient.HasValidCode() {
+		s.recordAccessEvent(ctx, send, recipient, email, domain.SendAccessInvalidCode, req)
+		return nil, ErrCodeExpired
+	}
+
+	// Every guess is counted in the database before the code is compared, so parallel
+	// guesses cannot exceed the attempt limit
+	counted, err := s.recipientRepo.TryIncrementCodeAttempts(ctx, recipient.ID, sendAccessCodeMaxAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count code attempt: %w", err)
+	}
+	if !counted {
+		s.recordAccessEvent(ctx, send, recipient, email, domain.SendAccessInvalidCode, req)
+		return nil, ErrCodeExpired
+	}
+
+	if !sendSecretMatches(recipient.CodeHash, strings.ToUpper(strings.TrimSpace(req.Code))) {
+		s.recordAccessEvent(ctx, send, recipient, email, domain.SendAccessInvalidCode, req)
+		return nil, ErrCodeInvalid
+	}
+
+	// The code stays valid on a wrong password so the recipient can retry (within the attempt limit)
+	if err := checkSendAccess(send, req.Password); err != nil {
+		if errors.Is(err, repository.ErrForbidden) {
+			s.recordAccessEvent(ctx, send, recipient, email, domain.SendAccessWrongPassword, req)
+		}
+		return nil, err
+	}
+
+	// Text sends are consumed now; file sends count the download
+	if !send.IsFile() {
+		counted, err := s.sendRepo.TryIncrementAccessCount(ctx, send.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count send access: %w", err)
+		}
+		if !counted {
+			return nil, repository.ErrNotFound
+		}
+	}
+
+	token, err := generateSendAccessToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+	now := time.Now()
+	tokenHash := hashSendSecret(token)
+	tokenExpiresAt := now.Add(sendAccessTokenExpiry)
+	recipient.CodeHash = nil
+	recipient.CodeExpiresAt = nil
+	recipient.CodeAttempts = 0
+	recipient.TokenHash = &tokenHash
+	recipient.TokenExpiresAt = &tokenExpiresAt
+	if recipient.VerifiedAt == nil {
+		recipient.VerifiedAt = &now
+	}
+
+	if send.IsFile() {
+		// The download records the access; only the token is persisted here
+		if err := s.recipientRepo.Update(ctx, recipient); err != nil {
+			return nil, fmt.Errorf("failed to update send recipient: %w", err)
+		}
+		s.recordAccessEvent(ctx, send, recipient, email, domain.SendAccessGranted, req)
+	} else {
+		s.recordRecipientAccess(ctx, send, recipient, domain.SendAccessGranted, req)
+	}
+
+	dto := &domain.SendAccessDTO{
+		AccessID:       send.AccessID,
+		Name:           send.Name,
+		Type:           send.Type,
+		Data:           send.Data,
+		Notes:          send.Notes,
+		FileSize:       send.FileSize,
+		HasPassword:    send.HasPassword(),
+		ExpirationDate: send.ExpirationDate,
+		AccessToken:    token,
+	}
+	if !send.HideEmail && send.Creator != nil {
+		dto.CreatorEmail = send.Creator.Email
+	}
+
+	return dto, nil
+}
+
+func (s *sendService) GetDetail(ctx context.Context, creatorID uint, sendUUID string) (*domain.SendDTO, error) {
+	send, err := s.GetByUUID(ctx, creatorID, sendUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, err := s.recipientRepo.ListBySend(ctx, send.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list send recipients: %w", err)
+	}
+	events, err := s.recipientRepo.ListEventsBySend(ctx, send.ID, sendAccessEventsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list send access events: %w", err)
+	}
+
+	dto := domain.ToSendDTO(send)
+	for _, r := range recipients {
+		dto.Recipients = append(dto.Recipients, domain.ToSendRecipientDTO(r))
+	}
+	for _, e := range events {
+		dto.AccessEvents = append(dto.AccessEvents, domain.ToSendAccessEventDTO(e))
+	}
+	return dto, nil
+}
+
+// recordRecipientAccess updates the recipient's access counters and records the event
+func (s *sendService) recordRecipientAccess(ctx context.Context, send *domain.Send, recipient *domain.SendRecipient, event domain.SendAccessEventType, req *domain.SendAccessRequest) {
+	now := time.Now()
+	recipient.AccessCount++
+	recipient.LastAccessAt = &now
+	if err := s.recipientRepo.Update(ctx, recipient); err != nil {
+		s.logger.Error("failed to update send recipient", "send_id", send.ID, "error", err)
+	}
+	s.recordAccessEvent(ctx, send, recipient, recipient.Email, event, req)
+}
+
+// recordAccessEvent stores an access attempt for the creator's detail view; failures are only logged
+func (s *sendService) recordAccessEvent(ctx context.Context, send *domain.Send, recipient *domain.SendRecipient, email string, event domain.SendAccessEventType, req *domain.SendAccessRequest) {
+	entry := &domain.SendAccessEvent{
+		SendID:    send.ID,
+		Email:     email,
+		Event:     event,
+		IPAddress: req.IPAddress,
+		UserAgent: truncate(req.UserAgent, 512),
+	}
+	if recipient != nil {
+		entry.RecipientID = &recipient.ID
+	}
+	if err := s.recipientRepo.CreateEvent(ctx, entry); err != nil {
+		s.logger.Error("failed to record send access event", "send_id", send.ID, "event", event, "error", err)
+	}
+}
+
+func generateSendAccessToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
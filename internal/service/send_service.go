@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/email"
 	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/pkg/blobstore"
 	"github.com/passwall/passwall-server/pkg/constants"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -26,14 +29,41 @@ type SendService interface {
 	Update(ctx context.Context, creatorID uint, sendUUID string, req *domain.UpdateSendRequest) (*domain.Send, error)
 	Delete(ctx context.Context, creatorID uint, sendUUID string) error
 	NotifyRecipient(ctx context.Context, creatorID uint, sendUUID string, recipientEmail string, sendURL string) error
+	// UploadFile stores the encrypted content of a file send (once, by its creator)
+	UploadFile(ctx context.Context, creatorID uint, sendUUID string, content io.Reader) (*domain.Send, error)
+	// OpenFile counts one access and returns the encrypted content of a file send; the caller closes it
+	OpenFile(ctx context.Context, accessID string, req *domain.SendAccessRequest) (*domain.Send, io.ReadCloser, error)
+	// GetDetail returns the creator's view of a send including recipients and their access attempts
+	GetDetail(ctx context.Context, creatorID uint, sendUUID string) (*domain.SendDTO, error)
+	// RequestRecipientCode emails a one-time code if req.Email is a recipient of the send
+	RequestRecipientCode(ctx context.Context, accessID string, req *domain.SendAccessRequest) error
+	// VerifyRecipient checks the one-time code (and password) and releases the send to the recipient
+	VerifyRecipient(ctx context.Context, accessID string, req *domain.SendAccessRequest) (*domain.SendAccessDTO, error)
 	CleanupExpired(ctx context.Context) (int64, error)
 }
 
+var (
+	ErrSendFileTooLarge        = errors.New("send file exceeds the plan size limit")
+	ErrSendFileAlreadyUploaded = errors.New("send file already uploaded")
+	ErrSendFileSizeMismatch    = errors.New("uploaded file size does not match the declared size")
+)
+
+// SendFileOptions configures storage for file sends
+type SendFileOptions struct {
+	Store        blobstore.Store // nil disables file sends
+	DefaultMaxMB int             // Size limit for plans that do not set features.send_file_max_mb
+}
+
 type sendService struct {
-	sendRepo     repository.SendRepository
-	userRepo     repository.UserRepository
-	orgUserRepo  repository.OrganizationUserRepository
-	policyRepo   repository.OrganizationPolicyRepository
+	sendRepo      repository.SendRepository
+	recipientRepo repository.SendRecipientRepository
+	userRepo      repository.UserRepository
+	orgUserRepo   repository.OrganizationUserRepository
+	policyRepo    repository.OrganizationPolicyRepository
+	features      interface {
+		GetFeatures(ctx context.Context, orgID uint) (*domain.PlanFeatures, error)
+	}
+	files        SendFileOptions
 	emailSender  email.Sender
 	emailBuilder *email.EmailBuilder
 	logger       Logger
@@ -41,21 +71,29 @@ type sendService struct {
 
 func NewSendService(
 	sendRepo repository.SendRepository,
+	recipientRepo repository.SendRecipientRepository,
 	userRepo repository.UserRepository,
 	orgUserRepo repository.OrganizationUserRepository,
 	policyRepo repository.OrganizationPolicyRepository,
+	features interface {
+		GetFeatures(ctx context.Context, orgID uint) (*domain.PlanFeatures, error)
+	},
+	files SendFileOptions,
 	emailSender email.Sender,
 	emailBuilder *email.EmailBuilder,
 	logger Logger,
 ) SendService {
 	return &sendService{
-		sendRepo:     sendRepo,
-		userRepo:     userRepo,
-		orgUserRepo:  orgUserRepo,
-		policyRepo:   policyRepo,
-		emailSender:  emailSender,
-		emailBuilder: emailBuilder,
-		logger:       logger,
+		sendRepo:      sendRepo,
+		recipientRepo: recipientRepo,
+		userRepo:      userRepo,
+		orgUserRepo:   orgUserRepo,
+		policyRepo:    policyRepo,
+		features:      features,
+		files:         files,
+		emailSender:   emailSender,
+		emailBuilder:  emailBuilder,
+		logger:        logger,
 	}
 }
 
@@ -74,6 +112,12 @@ func (s *sendService) Create(ctx context.Context, creatorID uint, req *domain.Cr
 	if strings.TrimSpace(req.Data) == "" {
 		return nil, repository.ErrInvalidInput
 	}
+	if req.Type == "" {
+		req.Type = domain.SendTypeText
+	}
+	if !req.Type.IsValid() {
+		return nil, repository.ErrInvalidInput
+	}
 
 	// Check RemoveSend policy: if enabled, non-admin members cannot create sends
 	if req.OrganizationID > 0 {
@@ -82,6 +126,25 @@ func (s *sendService) Create(ctx context.Context, creatorID uint, req *domain.Cr
 		}
 	}
 
+	recipients, err := normalizeSendRecipients(req.Recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	// File sends declare the encrypted size up front so the plan limit is enforced before upload
+	if req.Type == domain.SendTypeFile {
+		if req.FileSize <= 0 {
+			return nil, repository.ErrInvalidInput
+		}
+		limit, err := s.fileSizeLimit(ctx, req.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		if req.FileSize > limit {
+			return nil, ErrSendFileTooLarge
+		}
+	}
+
 	accessID, err := generateAccessID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access ID: %w", err)
@@ -107,6 +170,13 @@ func (s *sendService) Create(ctx context.Context, creatorID uint, req *domain.Cr
 		DeletionDate:   deletionDate,
 		HideEmail:      req.HideEmail,
 	}
+	if send.IsFile() {
+		send.FileSize = req.FileSize
+	}
+	// Recipients are inserted with the send so a restricted send is never briefly open
+	for _, email := range recipients {
+		send.Recipients = append(send.Recipients, domain.SendRecipient{Email: email})
+	}
 
 	if req.Password != nil && *req.Password != "" {
 		hashed, err := bcrypt.GenerateFromPassword([]byte(*req.Password), constants.BcryptCost)
@@ -117,10 +187,6 @@ func (s *sendService) Create(ctx context.Context, creatorID uint, req *domain.Cr
 		send.Password = &hashedStr
 	}
 
-	if send.Type == "" {
-		send.Type = domain.SendTypeText
-	}
-
 	if err := s.sendRepo.Create(ctx, send); err != nil {
 		return nil, fmt.Errorf("failed to create send: %w", err)
 	}
@@ -143,11 +209,15 @@ func (s *sendService) GetByAccessID(ctx context.Context, accessID string) (*doma
 	if send.IsAccessLimitReached() {
 		return nil, repository.ErrNotFound
 	}
+	if send.IsFile() && !send.FileUploaded {
+		return nil, repository.ErrNotFound
+	}
 
 	dto := &domain.SendAccessDTO{
 		AccessID:       send.AccessID,
 		Name:           send.Name,
 		Type:           send.Type,
+		FileSize:       send.FileSize,
 		HasPassword:    send.HasPassword(),
 		ExpirationDate: send.ExpirationDate,
 	}
@@ -156,12 +226,25 @@ func (s *sendService) GetByAccessID(ctx context.Context, accessID string) (*doma
 		dto.CreatorEmail = send.Creator.Email
 	}
 
-	// Withhold encrypted payload until password is verified
+	// Restricted sends are released only by VerifyRecipient
+	restricted, err := s.isRestricted(ctx, send)
+	if err != nil {
+		return nil, err
+	}
+	if restricted {
+		dto.RequiresVerification = true
+		return dto, nil
+	}
+
+	// Withhold encrypted payload until password is verified.
+	// File sends count the download instead of this metadata view.
 	if !send.HasPassword() {
 		dto.Data = send.Data
 		dto.Notes = send.Notes
-		if err := s.sendRepo.IncrementAccessCount(ctx, send.ID); err != nil {
-			s.logger.Error("failed to increment send access count", "send_id", send.ID, "error", err)
+		if !send.IsFile() {
+			if err := s.sendRepo.IncrementAccessCount(ctx, send.ID); err != nil {
+				s.logger.Error("failed to increment send access count", "send_id", send.ID, "error", err)
+			}
 		}
 	}
 
@@ -174,18 +257,22 @@ func (s *sendService) VerifySendPassword(ctx context.Context, accessID string, p
 		return nil, err
 	}
 
-	if send.Disabled || send.IsExpired() || send.IsAccessLimitReached() {
-		return nil, repository.ErrNotFound
+	if err := checkSendAccess(send, password); err != nil {
+		return nil, err
 	}
 
-	if send.HasPassword() {
-		if err := bcrypt.CompareHashAndPassword([]byte(*send.Password), []byte(password)); err != nil {
-			return nil, repository.ErrForbidden
-		}
+	restricted, err := s.isRestricted(ctx, send)
+	if err != nil {
+		return nil, err
+	}
+	if restricted {
+		return nil, ErrSendVerificationRequired
 	}
 
-	if err := s.sendRepo.IncrementAccessCount(ctx, send.ID); err != nil {
-		s.logger.Error("failed to increment send access count", "send_id", send.ID, "error", err)
+	if !send.IsFile() {
+		if err := s.sendRepo.IncrementAccessCount(ctx, send.ID); err != nil {
+			s.logger.Error("failed to increment send access count", "send_id", send.ID, "error", err)
+		}
 	}
 
 	dto := &domain.SendAccessDTO{
@@ -194,6 +281,7 @@ func (s *sendService) VerifySendPassword(ctx context.Context, accessID string, p
 		Type:           send.Type,
 		Data:           send.Data,
 		Notes:          send.Notes,
+		FileSize:       send.FileSize,
 		HasPassword:    send.HasPassword(),
 		ExpirationDate: send.ExpirationDate,
 	}
@@ -232,6 +320,16 @@ func (s *sendService) Update(ctx context.Context, creatorID uint, sendUUID strin
 		return nil, repository.ErrForbidden
 	}
 
+	if req.Recipients != nil {
+		recipients, err := normalizeSendRecipients(*req.Recipients)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.recipientRepo.ReplaceForSend(ctx, send.ID, recipients); err != nil {
+			return nil, fmt.Errorf("failed to update send recipients: %w", err)
+		}
+	}
+
 	if req.Name != nil {
 		send.Name = *req.Name
 	}
@@ -286,9 +384,113 @@ func (s *sendService) Delete(ctx context.Context, creatorID uint, sendUUID strin
 		return repository.ErrForbidden
 	}
 
+	if send.IsFile() && s.files.Store != nil {
+		if err := s.files.Store.Delete(ctx, send.BlobKey()); err != nil {
+			return fmt.Errorf("failed to delete send file: %w", err)
+		}
+	}
+
 	return s.sendRepo.SoftDelete(ctx, send.ID)
 }
 
+func (s *sendService) UploadFile(ctx context.Context, creatorID uint, sendUUID string, content io.Reader) (*domain.Send, error) {
+	send, err := s.sendRepo.GetByUUID(ctx, sendUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if send.CreatorID != creatorID {
+		return nil, repository.ErrForbidden
+	}
+	if !send.IsFile() {
+		return nil, repository.ErrInvalidInput
+	}
+	if send.FileUploaded {
+		return nil, ErrSendFileAlreadyUploaded
+	}
+
+	// The plan may have changed since creation; never accept more than either limit
+	limit, err := s.fileSizeLimit(ctx, send.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if send.FileSize > limit {
+		return nil, ErrSendFileTooLarge
+	}
+
+	written, err := s.files.Store.Put(ctx, send.BlobKey(), content, send.FileSize)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrTooLarge) {
+			return nil, ErrSendFileSizeMismatch
+		}
+		return nil, fmt.Errorf("failed to store send file: %w", err)
+	}
+	if written != send.FileSize {
+		if err := s.files.Store.Delete(ctx, send.BlobKey()); err != nil {
+			s.logger.Error("failed to delete incomplete send file", "send_uuid", sendUUID, "error", err)
+		}
+		return nil, ErrSendFileSizeMismatch
+	}
+
+	if err := s.sendRepo.MarkFileUploaded(ctx, send.ID); err != nil {
+		return nil, fmt.Errorf("failed to update send: %w", err)
+	}
+	send.FileUploaded = true
+
+	return send, nil
+}
+
+func (s *sendService) OpenFile(ctx context.Context, accessID string, req *domain.SendAccessRequest) (*domain.Send, io.ReadCloser, error) {
+	send, err := s.sendRepo.GetByAccessID(ctx, accessID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !send.IsFile() || s.files.Store == nil {
+		return nil, nil, repository.ErrNotFound
+	}
+	if err := checkSendAccess(send, req.Password); err != nil {
+		return nil, nil, err
+	}
+
+	// Restricted sends require the access token issued by VerifyRecipient
+	restricted, err := s.isRestricted(ctx, send)
+	if err != nil {
+		return nil, nil, err
+	}
+	var recipient *domain.SendRecipient
+	if restricted {
+		if recipient, err = s.recipientByToken(ctx, send, req.AccessToken); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Open the blob first so a missing or unreadable file does not use up an access
+	content, _, err := s.files.Store.Open(ctx, send.BlobKey())
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, nil, repository.ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to open send file: %w", err)
+	}
+
+	// Count the download atomically so concurrent requests cannot exceed MaxAccessCount
+	counted, err := s.sendRepo.TryIncrementAccessCount(ctx, send.ID)
+	if err != nil {
+		_ = content.Close()
+		return nil, nil, fmt.Errorf("failed to count send access: %w", err)
+	}
+	if !counted {
+		_ = content.Close()
+		return nil, nil, repository.ErrNotFound
+	}
+	if recipient != nil {
+		s.recordRecipientAccess(ctx, send, recipient, domain.SendAccessDownloaded, req)
+	}
+
+	return send, content, nil
+}
+
 func (s *sendService) CleanupExpired(ctx context.Context) (int64, error) {
 	return s.sendRepo.DeleteExpired(ctx)
 }
@@ -335,6 +537,43 @@ func (s *sendService) NotifyRecipient(ctx context.Context, creatorID uint, sendU
 	return nil
 }
 
+// checkSendAccess rejects unavailable sends and verifies the password of protected ones
+func checkSendAccess(send *domain.Send, password string) error {
+	if send.Disabled || send.IsExpired() || send.IsAccessLimitReached() {
+		return repository.ErrNotFound
+	}
+	if send.IsFile() && !send.FileUploaded {
+		return repository.ErrNotFound
+	}
+
+	if send.HasPassword() {
+		if err := bcrypt.CompareHashAndPassword([]byte(*send.Password), []byte(password)); err != nil {
+			return repository.ErrForbidden
+		}
+	}
+	return nil
+}
+
+// fileSizeLimit returns the maximum encrypted file size in bytes for the organization's plan.
+// Organizations without a subscription (e.g. self-hosted) use the configured default.
+func (s *sendService) fileSizeLimit(ctx context.Context, orgID uint) (int64, error) {
+	if s.files.Store == nil {
+		return 0, ErrFeatureNotAvailable
+	}
+
+	maxMB := s.files.DefaultMaxMB
+	if s.features != nil {
+		if features, err := s.features.GetFeatures(ctx, orgID); err == nil && features.SendFileMaxMB != nil {
+			maxMB = *features.SendFileMaxMB
+		}
+	}
+	if maxMB <= 0 {
+		return 0, ErrFeatureNotAvailable
+	}
+
+	return int64(maxMB) << 20, nil
+}
+
 // checkRemoveSendPolicy checks if the RemoveSend policy blocks this user
 func (s *sendService) checkRemoveSendPolicy(ctx context.Context, orgID, userID uint) error {
 	policy, err := s.policyRepo.GetByOrgAndType(ctx, orgID, domain.PolicyRemoveSend)
@@ -345,13 +584,13 @@ func (s *sendService) checkRemoveSendPolicy(ctx context.Context, orgID, userID u
 		return nil
 	}
 
-	// Check if user is admin/owner in org (admins are exempt)
+	// Members who manage policies are exempt
 	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
 	if err != nil {
 		return repository.ErrForbidden
 	}
 
-	if orgUser.Role == domain.OrgRoleOwner || orgUser.Role == domain.OrgRoleAdmin {
+	if exemptFromPolicies(orgUser) {
 		return nil
 	}
 
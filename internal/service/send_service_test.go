@@ -0,0 +1,459 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/email"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/passwall/passwall-server/pkg/blobstore"
+)
+
+// fakeSendRepo implements the parts of repository.SendRepository used by file sends
+type fakeSendRepo struct {
+	repository.SendRepository
+	sends      map[uint]*domain.Send
+	recipients *fakeSendRecipientRepo
+}
+
+func newFakeSendRepo() *fakeSendRepo {
+	return &fakeSendRepo{sends: make(map[uint]*domain.Send)}
+}
+
+func (f *fakeSendRepo) GetByUUID(_ context.Context, uuid string) (*domain.Send, error) {
+	for _, s := range f.sends {
+		if s.UUID.String() == uuid {
+			copied := *s
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (f *fakeSendRepo) GetByAccessID(_ context.Context, accessID string) (*domain.Send, error) {
+	for _, s := range f.sends {
+		if s.AccessID == accessID {
+			copied := *s
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (f *fakeSendRepo) Create(_ context.Context, send *domain.Send) error {
+	send.ID = uint(len(f.sends) + 1)
+	f.sends[send.ID] = send
+	for i := range send.Recipients {
+		recipient := send.Recipients[i]
+		recipient.ID = uint(len(f.recipients.recipients) + 1)
+		recipient.SendID = send.ID
+		f.recipients.recipients = append(f.recipients.recipients, &recipient)
+	}
+	send.Recipients = nil
+	return nil
+}
+
+func (f *fakeSendRepo) IncrementAccessCount(_ context.Context, id uint) error {
+	f.sends[id].AccessCount++
+	return nil
+}
+
+func (f *fakeSendRepo) TryIncrementAccessCount(_ context.Context, id uint) (bool, error) {
+	s := f.sends[id]
+	if s.IsAccessLimitReached() {
+		return false, nil
+	}
+	s.AccessCount++
+	return true, nil
+}
+
+func (f *fakeSendRepo) MarkFileUploaded(_ context.Context, id uint) error {
+	f.sends[id].FileUploaded = true
+	return nil
+}
+
+// fakeSendRecipientRepo implements repository.SendRecipientRepository in memory.
+// It is safe for concurrent use so parallel verification attempts can be tested.
+type fakeSendRecipientRepo struct {
+	mu         sync.Mutex
+	recipients []*domain.SendRecipient
+	events     []*domain.SendAccessEvent
+}
+
+func newFakeSendRecipientRepo(sends *fakeSendRepo) *fakeSendRecipientRepo {
+	sends.recipients = &fakeSendRecipientRepo{}
+	return sends.recipients
+}
+
+func (f *fakeSendRecipientRepo) ListBySend(_ context.Context, sendID uint) ([]*domain.SendRecipient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []*domain.SendRecipient
+	for _, r := range f.recipients {
+		if r.SendID == sendID {
+			copied := *r
+			out = append(out, &copied)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeSendRecipientRepo) CountBySend(ctx context.Context, sendID uint) (int64, error) {
+	list, _ := f.ListBySend(ctx, sendID)
+	return int64(len(list)), nil
+}
+
+func (f *fakeSendRecipientRepo) GetBySendAndEmail(_ context.Context, sendID uint, email string) (*domain.SendRecipient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range f.recipients {
+		if r.SendID == sendID && r.Email == email {
+			copied := *r
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (f *fakeSendRecipientRepo) GetBySendAndTokenHash(_ context.Context, sendID uint, tokenHash string) (*domain.SendRecipient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range f.recipients {
+		if r.SendID == sendID && r.TokenHash != nil && *r.TokenHash == tokenHash {
+			copied := *r
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (f *fakeSendRecipientRepo) ReplaceForSend(context.Context, uint, []string) error {
+	return nil
+}
+
+func (f *fakeSendRecipientRepo) TryIncrementCodeAttempts(_ context.Context, id uint, maxAttempts int) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range f.recipients {
+		if r.ID == id {
+			if r.CodeAttempts >= maxAttempts {
+				return false, nil
+			}
+			r.CodeAttempts++
+			return true, nil
+		}
+	}
+	return false, repository.ErrNotFound
+}
+
+func (f *fakeSendRecipientRepo) Update(_ context.Context, recipient *domain.SendRecipient) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, r := range f.recipients {
+		if r.ID == recipient.ID {
+			copied := *recipient
+			f.recipients[i] = &copied
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (f *fakeSendRecipientRepo) CreateEvent(_ context.Context, event *domain.SendAccessEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, event)
+	return nil
+}
+
+func (f *fakeSendRecipientRepo) ListEventsBySend(_ context.Context, sendID uint, _ int) ([]*domain.SendAccessEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []*domain.SendAccessEvent
+	for _, e := range f.events {
+		if e.SendID == sendID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// fakePlanFeatures returns fixed plan features
+type fakePlanFeatures struct {
+	features *domain.PlanFeatures
+}
+
+func (f fakePlanFeatures) GetFeatures(context.Context, uint) (*domain.PlanFeatures, error) {
+	if f.features == nil {
+		return nil, repository.ErrNotFound
+	}
+	return f.features, nil
+}
+
+func newFileSendService(t *testing.T, features *domain.PlanFeatures) (*sendService, *fakeSendRepo) {
+	t.Helper()
+	store, err := blobstore.NewFS(t.TempDir())
+	require.NoError(t, err)
+
+	repo := newFakeSendRepo()
+	svc := NewSendService(repo, newFakeSendRecipientRepo(repo), nil, nil, nil, fakePlanFeatures{features: features},
+		SendFileOptions{Store: store, DefaultMaxMB: 1}, nil, nil, noopLogger{})
+	return svc.(*sendService), repo
+}
+
+func createFileSend(t *testing.T, svc *sendService, size int64, maxAccess *int) *domain.Send {
+	t.Helper()
+	send, err := svc.Create(context.Background(), 1, &domain.CreateSendRequest{
+		Name:           "config bundle",
+		Type:           domain.SendTypeFile,
+		Data:           "2.encrypted-file-name",
+		FileSize:       size,
+		MaxAccessCount: maxAccess,
+	})
+	require.NoError(t, err)
+	return send
+}
+
+func TestSendService_FileUploadAndDownload(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newFileSendService(t, nil)
+	maxAccess := 1
+	send := createFileSend(t, svc, 10, &maxAccess)
+
+	// Not accessible until the content is uploaded
+	_, err := svc.GetByAccessID(ctx, send.AccessID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	_, err = svc.UploadFile(ctx, 2, send.UUID.String(), strings.NewReader("ciphertext"))
+	assert.ErrorIs(t, err, repository.ErrForbidden)
+
+	uploaded, err := svc.UploadFile(ctx, 1, send.UUID.String(), strings.NewReader("ciphertext"))
+	require.NoError(t, err)
+	assert.True(t, uploaded.FileUploaded)
+
+	_, err = svc.UploadFile(ctx, 1, send.UUID.String(), strings.NewReader("ciphertext"))
+	assert.ErrorIs(t, err, ErrSendFileAlreadyUploaded)
+
+	// Viewing metadata does not count as an access
+	dto, err := svc.GetByAccessID(ctx, send.AccessID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), dto.FileSize)
+	assert.Zero(t, repo.sends[send.ID].AccessCount)
+
+	_, content, err := svc.OpenFile(ctx, send.AccessID, &domain.SendAccessRequest{})
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	require.NoError(t, content.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "ciphertext", string(data))
+
+	// The download used up the only allowed access
+	_, _, err = svc.OpenFile(ctx, send.AccessID, &domain.SendAccessRequest{})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestSendService_MissingFileDoesNotUseAnAccess(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newFileSendService(t, nil)
+	maxAccess := 1
+	send := createFileSend(t, svc, 10, &maxAccess)
+	_, err := svc.UploadFile(ctx, 1, send.UUID.String(), strings.NewReader("ciphertext"))
+	require.NoError(t, err)
+
+	require.NoError(t, svc.files.Store.Delete(ctx, send.BlobKey()))
+	_, _, err = svc.OpenFile(ctx, send.AccessID, &domain.SendAccessRequest{})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Zero(t, repo.sends[send.ID].AccessCount)
+}
+
+func TestSendService_FileSizeLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("default limit applies without plan override", func(t *testing.T) {
+		svc, _ := newFileSendService(t, nil)
+		_, err := svc.Create(ctx, 1, &domain.CreateSendRequest{
+			Name: "big", Type: domain.SendTypeFile, Data: "2.name", FileSize: 1<<20 + 1,
+		})
+		assert.ErrorIs(t, err, ErrSendFileTooLarge)
+	})
+
+	t.Run("plan limit overrides default", func(t *testing.T) {
+		limit := 5
+		svc, _ := newFileSendService(t, &domain.PlanFeatures{SendFileMaxMB: &limit})
+		send := createFileSend(t, svc, 2<<20, nil)
+		assert.Equal(t, int64(2<<20), send.FileSize)
+	})
+
+	t.Run("zero disables file sends", func(t *testing.T) {
+		disabled := 0
+		svc, _ := newFileSendService(t, &domain.PlanFeatures{SendFileMaxMB: &disabled})
+		_, err := svc.Create(ctx, 1, &domain.CreateSendRequest{
+			Name: "file", Type: domain.SendTypeFile, Data: "2.name", FileSize: 1,
+		})
+		assert.ErrorIs(t, err, ErrFeatureNotAvailable)
+	})
+
+	t.Run("upload must match declared size", func(t *testing.T) {
+		svc, _ := newFileSendService(t, nil)
+		send := createFileSend(t, svc, 4, nil)
+
+		_, err := svc.UploadFile(ctx, 1, send.UUID.String(), strings.NewReader("too long"))
+		assert.ErrorIs(t, err, ErrSendFileSizeMismatch)
+		_, err = svc.UploadFile(ctx, 1, send.UUID.String(), strings.NewReader("ab"))
+		assert.ErrorIs(t, err, ErrSendFileSizeMismatch)
+
+		_, err = svc.UploadFile(ctx, 1, send.UUID.String(), strings.NewReader("abcd"))
+		assert.NoError(t, err)
+	})
+}
+
+func TestNormalizeSendRecipients(t *testing.T) {
+	t.Parallel()
+	got, err := normalizeSendRecipients([]string{" Alice@Example.com ", "bob@example.com", "alice@example.com", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, got)
+
+	_, err = normalizeSendRecipients([]string{"not-an-email"})
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	_, err = normalizeSendRecipients([]string{"Alice <alice@example.com>"})
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+}
+
+func TestSendService_RecipientVerification(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeSendRepo()
+	recipients := newFakeSendRecipientRepo(repo)
+	sender := &fakeEmailSender{}
+	builder, err := email.NewEmailBuilder("https://vault.example.com", "noreply@example.com")
+	require.NoError(t, err)
+	svc := NewSendService(repo, recipients, nil, nil, nil, nil, SendFileOptions{}, sender, builder, noopLogger{})
+
+	send, err := svc.Create(ctx, 1, &domain.CreateSendRequest{
+		Name:       "contract",
+		Type:       domain.SendTypeText,
+		Data:       "2.secret",
+		Recipients: []string{"Contractor@Example.com"},
+	})
+	require.NoError(t, err)
+	req := &domain.SendAccessRequest{Email: "contractor@example.com", IPAddress: "203.0.113.7"}
+
+	// Data is withheld from anyone holding the link
+	dto, err := svc.GetByAccessID(ctx, send.AccessID)
+	require.NoError(t, err)
+	assert.True(t, dto.RequiresVerification)
+	assert.Empty(t, dto.Data)
+	_, err = svc.VerifySendPassword(ctx, send.AccessID, "")
+	assert.ErrorIs(t, err, ErrSendVerificationRequired)
+
+	// Unknown addresses get the same response but no email
+	require.NoError(t, svc.RequestRecipientCode(ctx, send.AccessID, &domain.SendAccessRequest{Email: "eve@example.com"}))
+	assert.Empty(t, sender.sent)
+
+	require.NoError(t, svc.RequestRecipientCode(ctx, send.AccessID, req))
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "contractor@example.com", sender.sent[0].To)
+	code := sender.sent[0].Subject[strings.LastIndex(sender.sent[0].Subject, " ")+1:]
+
+	req.Code = "WRONG1"
+	_, err = svc.VerifyRecipient(ctx, send.AccessID, req)
+	assert.ErrorIs(t, err, ErrCodeInvalid)
+
+	req.Code = strings.ToLower(code)
+	dto, err = svc.VerifyRecipient(ctx, send.AccessID, req)
+	require.NoError(t, err)
+	assert.Equal(t, "2.secret", dto.Data)
+	assert.NotEmpty(t, dto.AccessToken)
+	assert.Equal(t, 1, repo.sends[send.ID].AccessCount)
+
+	// The code is single use
+	_, err = svc.VerifyRecipient(ctx, send.AccessID, req)
+	assert.ErrorIs(t, err, ErrCodeExpired)
+
+	detail, err := svc.GetDetail(ctx, 1, send.UUID.String())
+	require.NoError(t, err)
+	require.Len(t, detail.Recipients, 1)
+	assert.Equal(t, 1, detail.Recipients[0].AccessCount)
+	assert.NotNil(t, detail.Recipients[0].VerifiedAt)
+
+	var events []domain.SendAccessEventType
+	for _, e := range detail.AccessEvents {
+		events = append(events, e.Event)
+	}
+	assert.Equal(t, []domain.SendAccessEventType{
+		domain.SendAccessUnknownRecipient,
+		domain.SendAccessCodeSent,
+		domain.SendAccessInvalidCode,
+		domain.SendAccessGranted,
+		domain.SendAccessInvalidCode,
+	}, events)
+}
+
+func TestSendService_RecipientCodeAttemptLimit(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeSendRepo()
+	recipients := newFakeSendRecipientRepo(repo)
+	sender := &fakeEmailSender{}
+	builder, err := email.NewEmailBuilder("https://vault.example.com", "noreply@example.com")
+	require.NoError(t, err)
+	svc := NewSendService(repo, recipients, nil, nil, nil, nil, SendFileOptions{}, sender, builder, noopLogger{})
+
+	send, err := svc.Create(ctx, 1, &domain.CreateSendRequest{
+		Name:       "contract",
+		Type:       domain.SendTypeText,
+		Data:       "2.secret",
+		Recipients: []string{"contractor@example.com"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.RequestRecipientCode(ctx, send.AccessID, &domain.SendAccessRequest{Email: "contractor@example.com"}))
+	require.Len(t, sender.sent, 1)
+	code := sender.sent[0].Subject[strings.LastIndex(sender.sent[0].Subject, " ")+1:]
+
+	// Parallel guesses all read the same attempt count; only the limit is compared
+	const guesses = 20
+	results := make(chan error, guesses)
+	var wg sync.WaitGroup
+	for i := 0; i < guesses; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.VerifyRecipient(ctx, send.AccessID, &domain.SendAccessRequest{Email: "contractor@example.com", Code: "WRONG1"})
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	invalid, expired := 0, 0
+	for err := range results {
+		switch {
+		case errors.Is(err, ErrCodeInvalid):
+			invalid++
+		case errors.Is(err, ErrCodeExpired):
+			expired++
+		default:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	assert.Equal(t, sendAccessCodeMaxAttempts, invalid)
+	assert.Equal(t, guesses-sendAccessCodeMaxAttempts, expired)
+
+	// The right code no longer works once the attempts are used up
+	_, err = svc.VerifyRecipient(ctx, send.AccessID, &domain.SendAccessRequest{Email: "contractor@example.com", Code: code})
+	assert.ErrorIs(t, err, ErrCodeExpired)
+
+	// A new code can be requested right away
+	require.NoError(t, svc.RequestRecipientCode(ctx, send.AccessID, &domain.SendAccessRequest{Email: "contractor@example.com"}))
+	require.Len(t, sender.sent, 2)
+	code = sender.sent[1].Subject[strings.LastIndex(sender.sent[1].Subject, " ")+1:]
+	dto, err := svc.VerifyRecipient(ctx, send.AccessID, &domain.SendAccessRequest{Email: "contractor@example.com", Code: code})
+	require.NoError(t, err)
+	assert.Equal(t, "2.secret", dto.Data)
+}
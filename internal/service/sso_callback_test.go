@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"sort"
 	"testing"
 	"time"
 
@@ -177,6 +178,19 @@ func (f *fakeUserRepo) GetBySchema(_ context.Context, _ string) (*domain.User, e
 func (f *fakeUserRepo) List(_ context.Context, _ repository.ListFilter) ([]*domain.User, *repository.ListResult, error) {
 	return nil, nil, nil
 }
+func (f *fakeUserRepo) ListDirectory(_ context.Context, filter repository.UserDirectoryFilter) ([]*domain.User, *repository.ListResult, error) {
+	users := make([]*domain.User, 0, len(f.users))
+	for _, u := range f.users {
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	total := int64(len(users))
+	users = users[min(filter.Offset, len(users)):]
+	if filter.Limit > 0 {
+		users = users[:min(filter.Limit, len(users))]
+	}
+	return users, &repository.ListResult{Total: total, Filtered: total}, nil
+}
 func (f *fakeUserRepo) GetItemCount(_ context.Context, _ string) (int, error) { return 0, nil }
 func (f *fakeUserRepo) Create(_ context.Context, u *domain.User) error {
 	f.users[u.Email] = u
@@ -221,8 +235,14 @@ func (f *fakeOrgUserRepo) GetByOrgAndUser(_ context.Context, orgID, userID uint)
 func (f *fakeOrgUserRepo) ListByOrganization(_ context.Context, _ uint) ([]*domain.OrganizationUser, error) {
 	return nil, nil
 }
-func (f *fakeOrgUserRepo) ListByUser(_ context.Context, _ uint) ([]*domain.OrganizationUser, error) {
-	return nil, nil
+func (f *fakeOrgUserRepo) ListByUser(_ context.Context, userID uint) ([]*domain.OrganizationUser, error) {
+	var out []*domain.OrganizationUser
+	for _, ou := range f.members {
+		if ou.UserID == userID {
+			out = append(out, ou)
+		}
+	}
+	return out, nil
 }
 func (f *fakeOrgUserRepo) Update(_ context.Context, _ *domain.OrganizationUser) error { return nil }
 func (f *fakeOrgUserRepo) Delete(_ context.Context, _ uint) error                     { return nil }
@@ -230,6 +250,15 @@ func (f *fakeOrgUserRepo) CountInvited(_ context.Context, _ uint) (int, error)
 func (f *fakeOrgUserRepo) ListPendingInvitations(_ context.Context, _ string) ([]*domain.OrganizationUser, error) {
 	return nil, nil
 }
+func (f *fakeOrgUserRepo) ListSuspended(_ context.Context) ([]*domain.OrganizationUser, error) {
+	var out []*domain.OrganizationUser
+	for _, ou := range f.members {
+		if ou.IsSuspended() && ou.SuspendedAt != nil {
+			out = append(out, ou)
+		}
+	}
+	return out, nil
+}
 
 // fakeOrgRepo implements repository.OrganizationRepository (minimal)
 type fakeOrgRepo struct {
@@ -27,6 +27,7 @@ type SubscriptionService interface {
 	GetByStripeSubscriptionID(ctx context.Context, stripeSubID string) (*domain.Subscription, error)
 	ExpireSubscription(ctx context.Context, subID uint) error
 	CheckExpiredSubscriptions(ctx context.Context) error
+	DunningStatus(sub *domain.Subscription) *domain.DunningStatusDTO
 }
 
 type subscriptionService struct {
@@ -37,7 +38,6 @@ type subscriptionService struct {
 		GetByOrganizationID(ctx context.Context, orgID uint) (*domain.Subscription, error)
 		GetByStripeSubscriptionID(ctx context.Context, stripeSubID string) (*domain.Subscription, error)
 		Update(ctx context.Context, sub *domain.Subscription) error
-		ListPastDueExpired(ctx context.Context) ([]*domain.Subscription, error)
 		ListCanceledExpired(ctx context.Context) ([]*domain.Subscription, error)
 		ListManualExpired(ctx context.Context) ([]*domain.Subscription, error)
 	}
@@ -56,6 +56,8 @@ type subscriptionService struct {
 	}
 	// stripe client for cancel/reactivate operations
 	stripe any
+	// dunning schedule for past_due subscriptions
+	dunning domain.DunningSchedule
 	// logger for structured logging
 	logger Logger
 }
@@ -69,7 +71,6 @@ func NewSubscriptionService(
 		GetByOrganizationID(ctx context.Context, orgID uint) (*domain.Subscription, error)
 		GetByStripeSubscriptionID(ctx context.Context, stripeSubID string) (*domain.Subscription, error)
 		Update(ctx context.Context, sub *domain.Subscription) error
-		ListPastDueExpired(ctx context.Context) ([]*domain.Subscription, error)
 		ListCanceledExpired(ctx context.Context) ([]*domain.Subscription, error)
 		ListManualExpired(ctx context.Context) ([]*domain.Subscription, error)
 	},
@@ -86,6 +87,7 @@ func NewSubscriptionService(
 		SendSubscriptionExpiredEmail(ctx context.Context, sub *domain.Subscription) error
 	},
 	stripe any,
+	dunning domain.DunningSchedule,
 	logger Logger,
 ) SubscriptionService {
 	return &subscriptionService{
@@ -95,6 +97,7 @@ func NewSubscriptionService(
 		orgService:   orgService,
 		emailService: emailService,
 		stripe:       stripe,
+		dunning:      dunning,
 		logger:       logger,
 	}
 }
@@ -506,9 +509,9 @@ func (s *subscriptionService) HandlePaymentSuccess(ctx context.Context, stripeSu
 		sub.StartedAt = &now
 
 	case domain.SubStatePastDue:
-		// Payment retry succeeded - restore to active
+		// Payment retry succeeded - restore to active and stop dunning
 		sub.State = domain.SubStateActive
-		sub.GracePeriodEndsAt = nil
+		sub.ClearDunning()
 
 	case domain.SubStateExpired:
 		// Reactivation payment succeeded
@@ -524,18 +527,37 @@ func (s *subscriptionService) HandlePaymentSuccess(ctx context.Context, stripeSu
 	return s.subRepo.Update(ctx, sub)
 }
 
-// HandlePaymentFailed handles failed payment webhook
+// HandlePaymentFailed handles failed payment webhook.
+// Stripe reports every failed retry, so only the first failure starts the dunning schedule.
 func (s *subscriptionService) HandlePaymentFailed(ctx context.Context, stripeSubID string) error {
 	sub, err := s.subRepo.GetByStripeSubscriptionID(ctx, stripeSubID)
 	if err != nil {
 		return fmt.Errorf("failed to get subscription: %w", err)
 	}
 
-	// Move to past_due state with grace period
+	if sub.State == domain.SubStatePastDue || sub.State == domain.SubStateExpired {
+		return nil
+	}
+
+	// Move to past_due state; the grace period ends with the read-only downgrade
+	now := time.Now()
 	sub.State = domain.SubStatePastDue
-	gracePeriod := time.Now().AddDate(0, 0, 14) // 14 days grace period
+	sub.PastDueSince = &now
+	sub.ReadOnlySince = nil
+	sub.DunningRemindersSent = 0
+	gracePeriod := now.AddDate(0, 0, s.dunning.GraceDays)
 	sub.GracePeriodEndsAt = &gracePeriod
 
+	if err := s.subRepo.Update(ctx, sub); err != nil {
+		return err
+	}
+
+	s.logger.Info("subscription moved to past_due",
+		"subscription_id", sub.ID,
+		"org_id", sub.OrganizationID,
+		"grace_period_ends_at", gracePeriod,
+	)
+
 	// Send notification email
 	if s.emailService != nil {
 		go func() {
@@ -543,7 +565,7 @@ func (s *subscriptionService) HandlePaymentFailed(ctx context.Context, stripeSub
 		}()
 	}
 
-	return s.subRepo.Update(ctx, sub)
+	return nil
 }
 
 // ExpireSubscription expires a subscription
@@ -567,21 +589,9 @@ func (s *subscriptionService) ExpireSubscription(ctx context.Context, subID uint
 	return s.subRepo.Update(ctx, sub)
 }
 
-// CheckExpiredSubscriptions checks and expires subscriptions that should be expired
+// CheckExpiredSubscriptions checks and expires subscriptions that should be expired.
+// Past-due subscriptions are expired by the dunning schedule (see DunningService).
 func (s *subscriptionService) CheckExpiredSubscriptions(ctx context.Context) error {
-	// Find past_due subscriptions with expired grace periods
-	pastDueSubs, err := s.subRepo.ListPastDueExpired(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to list past due subscriptions: %w", err)
-	}
-
-	for _, sub := range pastDueSubs {
-		if err := s.ExpireSubscription(ctx, sub.ID); err != nil {
-			// Log error but continue processing others
-			continue
-		}
-	}
-
 	// Find canceled subscriptions with expired periods
 	canceledSubs, err := s.subRepo.ListCanceledExpired(ctx)
 	if err != nil {
@@ -609,6 +619,11 @@ func (s *subscriptionService) CheckExpiredSubscriptions(ctx context.Context) err
 	return nil
 }
 
+// DunningStatus returns where a past_due subscription is in the dunning schedule (nil otherwise)
+func (s *subscriptionService) DunningStatus(sub *domain.Subscription) *domain.DunningStatusDTO {
+	return domain.ToDunningStatusDTO(sub, s.dunning, time.Now())
+}
+
 // calculateNextRenewal calculates the next renewal date based on billing cycle
 func (s *subscriptionService) calculateNextRenewal(sub *domain.Subscription) time.Time {
 	if sub.Plan == nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/passwall/passwall-server/internal/authz"
 	"github.com/passwall/passwall-server/internal/domain"
 	"github.com/passwall/passwall-server/internal/repository"
 )
@@ -34,13 +35,13 @@ func NewTeamService(
 }
 
 func (s *teamService) Create(ctx context.Context, orgID uint, userID uint, req *domain.CreateTeamRequest) (*domain.Team, error) {
-	// Check if user can manage teams (admin or manager)
+	// Check if user can manage teams
 	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
 	if err != nil {
 		return nil, repository.ErrForbidden
 	}
 
-	if !orgUser.CanManageCollections() {
+	if !authz.HasPermission(orgUser, domain.OrgPermManageTeams) {
 		return nil, repository.ErrForbidden
 	}
 
@@ -303,7 +304,7 @@ func (s *teamService) checkTeamManagePermission(ctx context.Context, orgID, user
 		return repository.ErrForbidden
 	}
 
-	if !orgUser.CanManageCollections() {
+	if !authz.HasPermission(orgUser, domain.OrgPermManageTeams) {
 		return repository.ErrForbidden
 	}
 
@@ -311,13 +312,13 @@ func (s *teamService) checkTeamManagePermission(ctx context.Context, orgID, user
 }
 
 func (s *teamService) checkTeamMemberManagePermission(ctx context.Context, orgID, teamID, userID uint) error {
-	// Organization admins can always manage
+	// Members who manage teams can always manage
 	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
 	if err != nil {
 		return repository.ErrForbidden
 	}
 
-	if orgUser.IsAdmin() {
+	if authz.HasPermission(orgUser, domain.OrgPermManageTeams) {
 		return nil
 	}
 
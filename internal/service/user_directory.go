@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+const (
+	userDirectoryDefaultLimit = 50
+	userDirectoryMaxLimit     = 200
+	userDirectoryExportBatch  = 500
+)
+
+var userDirectoryCSVHeader = []string{
+	"id", "uuid", "email", "name", "role", "is_verified", "two_factor_enabled",
+	"signup_source", "created_at", "last_sign_in_at",
+}
+
+func (s *userService) ListDirectory(ctx context.Context, filter repository.UserDirectoryFilter) ([]*domain.User, *repository.ListResult, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = userDirectoryDefaultLimit
+	}
+	if filter.Limit > userDirectoryMaxLimit {
+		filter.Limit = userDirectoryMaxLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	users, result, err := s.repo.ListDirectory(ctx, filter)
+	if err != nil {
+		s.logger.Error("failed to list user directory", "error", err)
+		return nil, nil, err
+	}
+
+	// Item counts are per-schema queries, so only the current page pays for them
+	for _, user := range users {
+		if user == nil || user.Schema == "" {
+			continue
+		}
+		count, err := s.repo.GetItemCount(ctx, user.Schema)
+		if err != nil {
+			s.logger.Debug("failed to get user item count", "user_id", user.ID, "error", err)
+			continue
+		}
+		user.ItemCount = &count
+	}
+
+	return users, result, nil
+}
+
+// ExportDirectoryCSV streams every user matching the filter as CSV, ignoring Limit and Offset
+func (s *userService) ExportDirectoryCSV(ctx context.Context, filter repository.UserDirectoryFilter, w io.Writer) (int, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(userDirectoryCSVHeader); err != nil {
+		return 0, err
+	}
+
+	filter.Limit = userDirectoryExportBatch
+	written := 0
+	for offset := 0; ; offset += userDirectoryExportBatch {
+		filter.Offset = offset
+		users, _, err := s.repo.ListDirectory(ctx, filter)
+		if err != nil {
+			s.logger.Error("failed to export user directory", "offset", offset, "error", err)
+			return written, err
+		}
+
+		for _, u := range users {
+			if err := cw.Write(userDirectoryCSVRow(u)); err != nil {
+				return written, err
+			}
+			written++
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return written, err
+		}
+
+		if len(users) < userDirectoryExportBatch {
+			return written, nil
+		}
+	}
+}
+
+func userDirectoryCSVRow(u *domain.User) []string {
+	lastSignIn := ""
+	if u.LastSignInAt != nil {
+		lastSignIn = u.LastSignInAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		strconv.FormatUint(uint64(u.ID), 10),
+		u.UUID.String(),
+		csvSafe(u.Email),
+		csvSafe(u.Name),
+		u.GetRoleName(),
+		strconv.FormatBool(u.IsVerified),
+		strconv.FormatBool(u.TwoFactorEnabled),
+		csvSafe(u.SignupSource),
+		u.CreatedAt.UTC().Format(time.RFC3339),
+		lastSignIn,
+	}
+}
+
+// csvSafe neutralizes values that spreadsheet apps would evaluate as formulas
+func csvSafe(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserService_ListDirectoryClampsLimit(t *testing.T) {
+	t.Parallel()
+	repo := newFakeUserRepo()
+	for i := 1; i <= 3; i++ {
+		repo.add(&domain.User{ID: uint(i), Email: fmt.Sprintf("user%d@example.com", i)})
+	}
+	svc := &userService{repo: repo, logger: noopLogger{}}
+
+	users, result, err := svc.ListDirectory(context.Background(), repository.UserDirectoryFilter{Limit: 10_000})
+	require.NoError(t, err)
+	assert.Len(t, users, 3)
+	assert.Equal(t, int64(3), result.Total)
+}
+
+func TestUserService_ExportDirectoryCSV(t *testing.T) {
+	t.Parallel()
+	repo := newFakeUserRepo()
+	lastSignIn := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	count := userDirectoryExportBatch + 3
+	for i := 1; i <= count; i++ {
+		repo.add(&domain.User{ID: uint(i), UUID: uuid.New(), Email: fmt.Sprintf("user%d@example.com", i), SignupSource: "vault"})
+	}
+	repo.add(&domain.User{
+		ID: uint(count + 1), UUID: uuid.New(), Email: "evil@example.com", Name: "=HYPERLINK(\"x\")",
+		IsVerified: true, TwoFactorEnabled: true, LastSignInAt: &lastSignIn,
+	})
+	svc := &userService{repo: repo, logger: noopLogger{}}
+
+	var buf bytes.Buffer
+	written, err := svc.ExportDirectoryCSV(context.Background(), repository.UserDirectoryFilter{Limit: 1, Offset: 7}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, count+1, written, "export ignores paging and walks every batch")
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, count+2)
+	assert.Equal(t, userDirectoryCSVHeader, rows[0])
+
+	last := rows[len(rows)-1]
+	assert.Equal(t, "evil@example.com", last[2])
+	assert.Equal(t, "'=HYPERLINK(\"x\")", last[3], "formula cells are neutralized")
+	assert.Equal(t, "true", last[5])
+	assert.Equal(t, "true", last[6])
+	assert.Equal(t, "2026-03-01T12:00:00Z", last[9])
+}
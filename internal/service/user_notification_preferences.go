@@ -26,11 +26,12 @@ func NewUserNotificationPreferencesService(
 func (s *userNotificationPreferencesService) GetForUser(ctx context.Context, userID uint) (*domain.UserNotificationPreferences, error) {
 	// Defaults for new users (not persisted until first update).
 	out := &domain.UserNotificationPreferences{
-		UserID:              userID,
-		CommunicationEmails: false,
-		MarketingEmails:     false,
-		SocialEmails:        false,
-		SecurityEmails:      true,
+		UserID:               userID,
+		CommunicationEmails:  false,
+		MarketingEmails:      false,
+		SocialEmails:         false,
+		SecurityEmails:       true,
+		SecurityDigestEmails: true,
 	}
 
 	rows, err := s.prefs.ListByOwner(ctx, PreferenceOwnerUser, userID, "notifications")
@@ -54,6 +55,8 @@ func (s *userNotificationPreferencesService) GetForUser(ctx context.Context, use
 			out.MarketingEmails = v
 		case "social_emails":
 			out.SocialEmails = v
+		case "security_digest_emails":
+			out.SecurityDigestEmails = v
 		}
 	}
 
@@ -72,7 +75,7 @@ func (s *userNotificationPreferencesService) UpdateForUser(ctx context.Context,
 		return nil, err
 	}
 
-	updates := make([]*domain.Preference, 0, 4)
+	updates := make([]*domain.Preference, 0, 5)
 
 	if req.CommunicationEmails != nil {
 		current.CommunicationEmails = *req.CommunicationEmails
@@ -108,6 +111,18 @@ func (s *userNotificationPreferencesService) UpdateForUser(ctx context.Context,
 		})
 	}
 
+	if req.SecurityDigestEmails != nil {
+		current.SecurityDigestEmails = *req.SecurityDigestEmails
+		updates = append(updates, &domain.Preference{
+			OwnerType: PreferenceOwnerUser,
+			OwnerID:   userID,
+			Section:   "notifications",
+			Key:       "security_digest_emails",
+			Type:      "boolean",
+			Value:     strconv.FormatBool(*req.SecurityDigestEmails),
+		})
+	}
+
 	// Security emails are mandatory; ignore any client attempt to disable.
 	current.SecurityEmails = true
 
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+var (
+	ErrVaultExportDisabled   = errors.New("vault export is disabled by organization policy")
+	ErrInvalidExportPassword = errors.New("invalid export password key")
+)
+
+// vaultExportPageSize is how many items are loaded per query while streaming
+const vaultExportPageSize = 500
+
+// VaultExportService streams a user's vault as a versioned, still-encrypted bundle
+type VaultExportService interface {
+	// CheckAllowed returns ErrVaultExportDisabled if any organization of the user prohibits export
+	CheckAllowed(ctx context.Context, userID uint) error
+	// Export writes the bundle (see domain.VaultExport) to w. Nothing is written
+	// if the export is not allowed. passwordKey is optional.
+	Export(ctx context.Context, userID uint, passwordKey *domain.VaultExportPasswordKey, w io.Writer) (*domain.VaultExportSummary, error)
+}
+
+type vaultExportService struct {
+	userRepo          repository.UserRepository
+	itemRepo          repository.ItemRepository
+	orgUserRepo       repository.OrganizationUserRepository
+	orgItemRepo       repository.OrganizationItemRepository
+	orgFolderRepo     repository.OrganizationFolderRepository
+	collectionRepo    repository.CollectionRepository
+	orgItemService    OrganizationItemService
+	policyEnforcement PolicyEnforcementService
+	logger            Logger
+}
+
+// NewVaultExportService creates a new vault export service
+func NewVaultExportService(
+	userRepo repository.UserRepository,
+	itemRepo repository.ItemRepository,
+	orgUserRepo repository.OrganizationUserRepository,
+	orgItemRepo repository.OrganizationItemRepository,
+	orgFolderRepo repository.OrganizationFolderRepository,
+	collectionRepo repository.CollectionRepository,
+	orgItemService OrganizationItemService,
+	policyEnforcement PolicyEnforcementService,
+	logger Logger,
+) VaultExportService {
+	return &vaultExportService{
+		userRepo:          userRepo,
+		itemRepo:          itemRepo,
+		orgUserRepo:       orgUserRepo,
+		orgItemRepo:       orgItemRepo,
+		orgFolderRepo:     orgFolderRepo,
+		collectionRepo:    collectionRepo,
+		orgItemService:    orgItemService,
+		policyEnforcement: policyEnforcement,
+		logger:            logger,
+	}
+}
+
+func (s *vaultExportService) CheckAllowed(ctx context.Context, userID uint) error {
+	memberships, err := s.orgUserRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list memberships: %w", err)
+	}
+	return s.checkPolicies(ctx, memberships)
+}
+
+// checkPolicies applies disable_personal_export of every organization the user belongs to.
+// Members with manage_policies are exempt (handled by the policy check). Fails closed on errors.
+func (s *vaultExportService) checkPolicies(ctx context.Context, memberships []*domain.OrganizationUser) error {
+	for _, m := range memberships {
+		if m.Status == domain.OrgUserStatusInvited {
+			continue
+		}
+		if m.Organization != nil && m.Organization.IsPersonal {
+			continue
+		}
+		if err := s.policyEnforcement.CheckPersonalExportAllowed(ctx, m.OrganizationID, m); err != nil {
+			return fmt.Errorf("%w: %v", ErrVaultExportDisabled, err)
+		}
+	}
+	return nil
+}
+
+func (s *vaultExportService) Export(ctx context.Context, userID uint, passwordKey *domain.VaultExportPasswordKey, w io.Writer) (*domain.VaultExportSummary, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.orgUserRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memberships: %w", err)
+	}
+	if err := s.checkPolicies(ctx, memberships); err != nil {
+		return nil, err
+	}
+
+	encryption := domain.VaultExportEncryption{
+		Mode:                domain.VaultExportModeAccount,
+		KdfType:             user.KdfType,
+		KdfIterations:       user.KdfIterations,
+		KdfMemory:           user.KdfMemory,
+		KdfParallelism:      user.KdfParallelism,
+		KdfSalt:             user.KdfSalt,
+		EncryptedUserKey:    user.ProtectedUserKey,
+		EncryptedPrivateKey: user.RSAPrivateKeyEnc,
+	}
+	if passwordKey != nil {
+		if err := passwordKey.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidExportPassword, err)
+		}
+		encryption = domain.VaultExportEncryption{
+			Mode:                domain.VaultExportModePassword,
+			KdfType:             passwordKey.KdfType,
+			KdfIterations:       passwordKey.KdfIterations,
+			KdfMemory:           passwordKey.KdfMemory,
+			KdfParallelism:      passwordKey.KdfParallelism,
+			KdfSalt:             passwordKey.KdfSalt,
+			EncryptedUserKey:    passwordKey.EncryptedUserKey,
+			EncryptedPrivateKey: user.RSAPrivateKeyEnc,
+		}
+	}
+
+	summary := &domain.VaultExportSummary{Mode: encryption.Mode}
+	out := &jsonStream{w: w}
+
+	out.raw(`{"version":`)
+	out.value(domain.VaultExportVersion)
+	out.raw(`,"exported_at":`)
+	out.value(time.Now().UTC())
+	out.raw(`,"encryption":`)
+	out.value(encryption)
+
+	// Personal vault (user schema)
+	out.raw(`,"items":[`)
+	if user.Schema != "" {
+		if err := s.writePersonalItems(ctx, user.Schema, out, summary); err != nil {
+			return summary, err
+		}
+	}
+	out.raw(`],"organizations":[`)
+
+	first := true
+	for _, m := range memberships {
+		if !exportableMembership(m) {
+			continue
+		}
+		if !first {
+			out.raw(",")
+		}
+		first = false
+		if err := s.writeOrganization(ctx, m, out, summary); err != nil {
+			return summary, err
+		}
+		summary.Organizations++
+	}
+	out.raw("]}\n")
+
+	if out.err != nil {
+		return summary, out.err
+	}
+
+	s.logger.Info("vault exported",
+		"user_id", userID,
+		"mode", summary.Mode,
+		"personal_items", summary.PersonalItems,
+		"organizations", summary.Organizations,
+		"organization_items", summary.OrganizationItems)
+	return summary, nil
+}
+
+// exportableMembership reports whether the user holds a usable key for the organization
+func exportableMembership(m *domain.OrganizationUser) bool {
+	if m.EncryptedOrgKey == "" || m.Organization == nil {
+		return false
+	}
+	if m.Status != domain.OrgUserStatusAccepted && m.Status != domain.OrgUserStatusConfirmed {
+		return false
+	}
+	return m.Organization.Status != domain.OrgStatusDeleted
+}
+
+func (s *vaultExportService) writePersonalItems(ctx context.Context, schema string, out *jsonStream, summary *domain.VaultExportSummary) error {
+	for page := 1; ; page++ {
+		items, total, err := s.itemRepo.FindAll(ctx, schema, repository.ItemFilter{Page: page, PerPage: vaultExportPageSize})
+		if err != nil {
+			return fmt.Errorf("failed to list personal items: %w", err)
+		}
+		for _, item := range items {
+			if summary.PersonalItems > 0 {
+				out.raw(",")
+			}
+			out.value(domain.ToVaultExportItem(item))
+			summary.PersonalItems++
+		}
+		if out.err != nil {
+			return out.err
+		}
+		if len(items) < vaultExportPageSize || int64(page*vaultExportPageSize) >= total {
+			return nil
+		}
+	}
+}
+
+func (s *vaultExportService) writeOrganization(ctx context.Context, m *domain.OrganizationUser, out *jsonStream, summary *domain.VaultExportSummary) error {
+	org := m.Organization
+	folders, err := s.orgFolderRepo.GetByOrganization(ctx, org.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list folders of organization %d: %w", org.ID, err)
+	}
+
+	// Members without access_all only export items of their collections
+	var allowed map[uint]bool
+	if !m.IsAdmin() && !m.AccessAll {
+		collections, err := s.collectionRepo.ListForUser(ctx, org.ID, m.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to list collections of organization %d: %w", org.ID, err)
+		}
+		allowed = make(map[uint]bool, len(collections))
+		for _, c := range collections {
+			allowed[c.ID] = true
+		}
+	}
+
+	out.raw(`{"organization":`)
+	out.value(domain.VaultExportOrganizationInfo{
+		ID:              org.ID,
+		UUID:            org.UUID,
+		Name:            org.Name,
+		IsPersonal:      org.IsPersonal,
+		Role:            string(m.Role),
+		EncryptedOrgKey: m.EncryptedOrgKey,
+	})
+	out.raw(`,"folders":`)
+	out.value(domain.ToOrganizationFolderDTOs(folders))
+	out.raw(`,"items":[`)
+
+	// Items only reachable through hide_passwords collections are left out: the member cannot see their secrets
+	hidden := make(map[uint]bool)
+	written := 0
+	for page := 1; ; page++ {
+		items, total, err := s.orgItemRepo.ListByOrganization(ctx, repository.OrganizationItemFilter{
+			OrganizationID: org.ID,
+			Page:           page,
+			PerPage:        vaultExportPageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list items of organization %d: %w", org.ID, err)
+		}
+		for _, item := range items {
+			if allowed != nil {
+				// The item is exported when any collection the member can see reveals its secrets
+				reachable, revealed := false, false
+				for _, cid := range item.CollectionIDs {
+					if !allowed[cid] {
+						continue
+					}
+					reachable = true
+					hide, ok := hidden[cid]
+					if !ok {
+						access, err := s.orgItemService.GetCollectionAccess(ctx, org.ID, m.UserID, cid)
+						hide = err != nil || access.HidePasswords
+						hidden[cid] = hide
+					}
+					if !hide {
+						revealed = true
+						break
+					}
+				}
+				if !reachable {
+					continue
+				}
+				if !revealed {
+					summary.HiddenItems++
+					continue
+				}
+			}
+			if written > 0 {
+				out.raw(",")
+			}
+			out.value(domain.ToVaultExportOrgItem(item))
+			written++
+		}
+		if out.err != nil {
+			return out.err
+		}
+		if len(items) < vaultExportPageSize || int64(page*vaultExportPageSize) >= total {
+			break
+		}
+	}
+	out.raw("]}")
+	summary.OrganizationItems += written
+	return out.err
+}
+
+// jsonStream writes a JSON document piece by piece, keeping the first error
+type jsonStream struct {
+	w   io.Writer
+	err error
+}
+
+func (s *jsonStream) raw(str string) {
+	if s.err != nil {
+		return
+	}
+	_, s.err = io.WriteString(s.w, str)
+}
+
+func (s *jsonStream) value(v interface{}) {
+	if s.err != nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		s.err = err
+		return
+	}
+	_, s.err = s.w.Write(data)
+}
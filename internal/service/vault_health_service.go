@@ -0,0 +1,385 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/authz"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+var (
+	ErrVaultHealthReportsDisabled = errors.New("vault health reports are disabled for this organization")
+)
+
+// VaultHealthService builds organization and collection health reports from the
+// security signals clients compute for each item. Clients never send passwords:
+// they submit a keyed password hash for reuse detection, a strength score, the
+// exposure flag returned by the compromised-check endpoint and the item's 2FA state.
+type VaultHealthService interface {
+	// SubmitSignals stores the signals for items the member can read and refreshes
+	// today's snapshots. It returns the number of items accepted.
+	SubmitSignals(ctx context.Context, orgID, userID uint, req *domain.SubmitItemHealthSignalsRequest) (int, error)
+	// GetOrganizationReport returns the organization-wide report; admins only
+	GetOrganizationReport(ctx context.Context, orgID, userID uint) (*domain.VaultHealthReportDTO, error)
+	// GetCollectionReport returns the report of a collection; org admins and collection managers only
+	GetCollectionReport(ctx context.Context, orgID, collectionID, userID uint) (*domain.VaultHealthReportDTO, error)
+}
+
+type vaultHealthService struct {
+	repo     repository.VaultHealthRepository
+	itemRepo interface {
+		GetByUUID(ctx context.Context, uuid string) (*domain.OrganizationItem, error)
+		ListByOrganization(ctx context.Context, filter repository.OrganizationItemFilter) ([]*domain.OrganizationItem, int64, error)
+	}
+	itemAccess interface {
+		GetItemAccess(ctx context.Context, item *domain.OrganizationItem, userID uint) (*authz.CollectionAccess, error)
+		GetCollectionAccess(ctx context.Context, orgID, userID, collectionID uint) (*authz.CollectionAccess, error)
+	}
+	collectionRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Collection, error)
+	}
+	orgUserRepo interface {
+		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+	}
+	prefRepo interface {
+		ListByOwner(ctx context.Context, ownerType string, ownerID uint, section string) ([]*domain.Preference, error)
+	}
+	featureSvc FeatureService
+	logger     Logger
+	now        func() time.Time
+}
+
+// NewVaultHealthService creates a new vault health service
+func NewVaultHealthService(
+	repo repository.VaultHealthRepository,
+	itemRepo interface {
+		GetByUUID(ctx context.Context, uuid string) (*domain.OrganizationItem, error)
+		ListByOrganization(ctx context.Context, filter repository.OrganizationItemFilter) ([]*domain.OrganizationItem, int64, error)
+	},
+	itemAccess interface {
+		GetItemAccess(ctx context.Context, item *domain.OrganizationItem, userID uint) (*authz.CollectionAccess, error)
+		GetCollectionAccess(ctx context.Context, orgID, userID, collectionID uint) (*authz.CollectionAccess, error)
+	},
+	collectionRepo interface {
+		GetByID(ctx context.Context, id uint) (*domain.Collection, error)
+	},
+	orgUserRepo interface {
+		GetByOrgAndUser(ctx context.Context, orgID, userID uint) (*domain.OrganizationUser, error)
+	},
+	prefRepo interface {
+		ListByOwner(ctx context.Context, ownerType string, ownerID uint, section string) ([]*domain.Preference, error)
+	},
+	featureSvc FeatureService,
+	logger Logger,
+) VaultHealthService {
+	return &vaultHealthService{
+		repo:           repo,
+		itemRepo:       itemRepo,
+		itemAccess:     itemAccess,
+		collectionRepo: collectionRepo,
+		orgUserRepo:    orgUserRepo,
+		prefRepo:       prefRepo,
+		featureSvc:     featureSvc,
+		logger:         logger,
+		now:            time.Now,
+	}
+}
+
+func (s *vaultHealthService) SubmitSignals(ctx context.Context, orgID, userID uint, req *domain.SubmitItemHealthSignalsRequest) (int, error) {
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil || !isActiveMember(orgUser) {
+		return 0, repository.ErrForbidden
+	}
+	if err := s.checkEnabled(ctx, orgID); err != nil {
+		return 0, err
+	}
+
+	signals := make([]*domain.ItemHealthSignal, 0, len(req.Items))
+	for _, in := range req.Items {
+		hash := strings.TrimSpace(in.PasswordHash)
+		if hash == "" {
+			return 0, fmt.Errorf("%w: password_hash is required", repository.ErrInvalidInput)
+		}
+		if in.StrengthScore < 0 || in.StrengthScore > 4 {
+			return 0, fmt.Errorf("%w: strength_score must be between 0 and 4", repository.ErrInvalidInput)
+		}
+
+		item, err := s.itemRepo.GetByUUID(ctx, in.ItemUUID)
+		if err != nil || item.OrganizationID != orgID {
+			// Items deleted since the client computed its signals are skipped
+			continue
+		}
+		access, err := s.itemAccess.GetItemAccess(ctx, item, userID)
+		if err != nil || !access.CanRead {
+			continue
+		}
+
+		signals = append(signals, &domain.ItemHealthSignal{
+			OrganizationID:     orgID,
+			OrganizationItemID: item.ID,
+			ReportedByUserID:   userID,
+			PasswordHash:       hash,
+			StrengthScore:      in.StrengthScore,
+			Exposed:            in.Exposed,
+			TwoFactorAvailable: in.TwoFactorAvailable,
+			TwoFactorEnabled:   in.TwoFactorAvailable && in.TwoFactorEnabled,
+			PasswordChangedAt:  in.PasswordChangedAt,
+		})
+	}
+
+	if err := s.repo.UpsertSignals(ctx, signals); err != nil {
+		return 0, fmt.Errorf("failed to store health signals: %w", err)
+	}
+	if len(signals) > 0 {
+		if err := s.refreshSnapshots(ctx, orgID); err != nil {
+			// The signals are stored; the next submission refreshes the trend
+			s.logger.Warn("failed to refresh vault health snapshots", "org_id", orgID, "error", err)
+		}
+	}
+
+	s.logger.Info("vault health signals submitted", "org_id", orgID, "user_id", userID, "accepted", len(signals), "submitted", len(req.Items))
+	return len(signals), nil
+}
+
+func (s *vaultHealthService) GetOrganizationReport(ctx context.Context, orgID, userID uint) (*domain.VaultHealthReportDTO, error) {
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil || !isActiveMember(orgUser) || !authz.HasPermission(orgUser, domain.OrgPermViewAudit) {
+		return nil, repository.ErrForbidden
+	}
+	if err := s.checkEnabled(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.report(ctx, orgID, 0)
+}
+
+func (s *vaultHealthService) GetCollectionReport(ctx context.Context, orgID, collectionID, userID uint) (*domain.VaultHealthReportDTO, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	if collection.OrganizationID != orgID {
+		return nil, repository.ErrNotFound
+	}
+
+	orgUser, err := s.orgUserRepo.GetByOrgAndUser(ctx, orgID, userID)
+	if err != nil || !isActiveMember(orgUser) {
+		return nil, repository.ErrForbidden
+	}
+	// Security report viewers see every collection; others need admin access on this one
+	if !authz.HasPermission(orgUser, domain.OrgPermViewAudit) {
+		access, err := s.itemAccess.GetCollectionAccess(ctx, orgID, userID, collectionID)
+		if err != nil || !access.CanAdmin {
+			return nil, repository.ErrForbidden
+		}
+	}
+	if err := s.checkEnabled(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.report(ctx, orgID, collectionID)
+}
+
+// report computes the current report of the organization (collectionID 0) or a collection
+func (s *vaultHealthService) report(ctx context.Context, orgID, collectionID uint) (*domain.VaultHealthReportDTO, error) {
+	items, signals, err := s.load(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	counts, issues := computeVaultHealth(items, signals, collectionID, now)
+
+	trend, err := s.repo.ListSnapshots(ctx, orgID, collectionID, dayOf(now).AddDate(0, 0, -domain.VaultHealthTrendDays))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load vault health trend: %w", err)
+	}
+
+	report := &domain.VaultHealthReportDTO{
+		OrganizationID:    orgID,
+		VaultHealthCounts: counts,
+		Items:             issues,
+		Trend:             make([]domain.VaultHealthSnapshot, 0, len(trend)),
+		GeneratedAt:       now,
+	}
+	if collectionID != 0 {
+		report.CollectionID = &collectionID
+	}
+	for _, snapshot := range trend {
+		report.Trend = append(report.Trend, *snapshot)
+	}
+	return report, nil
+}
+
+// refreshSnapshots stores today's organization snapshot and one for every collection holding items
+func (s *vaultHealthService) refreshSnapshots(ctx context.Context, orgID uint) error {
+	items, signals, err := s.load(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	collectionIDs := map[uint]struct{}{0: {}}
+	for _, item := range items {
+		for _, id := range item.CollectionIDs {
+			collectionIDs[id] = struct{}{}
+		}
+	}
+
+	snapshots := make([]*domain.VaultHealthSnapshot, 0, len(collectionIDs))
+	for id := range collectionIDs {
+		counts, _ := computeVaultHealth(items, signals, id, now)
+		snapshots = append(snapshots, &domain.VaultHealthSnapshot{
+			OrganizationID:    orgID,
+			CollectionID:      id,
+			Date:              dayOf(now),
+			VaultHealthCounts: counts,
+		})
+	}
+	return s.repo.UpsertSnapshots(ctx, snapshots)
+}
+
+// load returns the organization's live items and their signals keyed by item ID
+func (s *vaultHealthService) load(ctx context.Context, orgID uint) ([]*domain.OrganizationItem, map[uint]*domain.ItemHealthSignal, error) {
+	const perPage = 5000
+
+	var items []*domain.OrganizationItem
+	for page := 1; ; page++ {
+		batch, _, err := s.itemRepo.ListByOrganization(ctx, repository.OrganizationItemFilter{
+			OrganizationID: orgID,
+			Page:           page,
+			PerPage:        perPage,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list organization items: %w", err)
+		}
+		items = append(items, batch...)
+		if len(batch) < perPage {
+			break
+		}
+	}
+
+	list, err := s.repo.ListSignalsByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list health signals: %w", err)
+	}
+	signals := make(map[uint]*domain.ItemHealthSignal, len(list))
+	for _, signal := range list {
+		signals[signal.OrganizationItemID] = signal
+	}
+	return items, signals, nil
+}
+
+// computeVaultHealth aggregates the signals of the items in a collection (0 = all items).
+// Password items without signals count as unreported. Reuse is detected across the whole
+// organization, so an item is reused even if the other copy lives in another collection.
+func computeVaultHealth(items []*domain.OrganizationItem, signals map[uint]*domain.ItemHealthSignal, collectionID uint, now time.Time) (domain.VaultHealthCounts, []domain.VaultHealthIssueDTO) {
+	hashCounts := make(map[string]int)
+	for _, item := range items {
+		if signal, ok := signals[item.ID]; ok {
+			hashCounts[signal.PasswordHash]++
+		}
+	}
+
+	staleBefore := now.AddDate(0, 0, -domain.StalePasswordDays)
+	counts := domain.VaultHealthCounts{}
+	issues := []domain.VaultHealthIssueDTO{}
+	for _, item := range items {
+		if collectionID != 0 && !containsUint(item.CollectionIDs, collectionID) {
+			continue
+		}
+		signal, ok := signals[item.ID]
+		if !ok {
+			if item.ItemType == domain.ItemTypePassword {
+				counts.TotalItems++
+			}
+			continue
+		}
+		counts.TotalItems++
+		counts.ReportedItems++
+
+		var found []string
+		if signal.IsWeak() {
+			counts.Weak++
+			found = append(found, "weak")
+		}
+		if hashCounts[signal.PasswordHash] > 1 {
+			counts.Reused++
+			found = append(found, "reused")
+		}
+		if signal.Exposed {
+			counts.Exposed++
+			found = append(found, "exposed")
+		}
+		changedAt := item.UpdatedAt
+		if signal.PasswordChangedAt != nil {
+			changedAt = *signal.PasswordChangedAt
+		}
+		if changedAt.Before(staleBefore) {
+			counts.Stale++
+			found = append(found, "stale")
+		}
+		if signal.TwoFactorAvailable && !signal.TwoFactorEnabled {
+			counts.Missing2FA++
+			found = append(found, "missing_2fa")
+		}
+
+		if len(found) > 0 {
+			issues = append(issues, domain.VaultHealthIssueDTO{
+				ItemUUID:      item.UUID.String(),
+				CollectionIDs: item.CollectionIDs,
+				Issues:        found,
+			})
+		}
+	}
+
+	// Items with the most issues first
+	sort.SliceStable(issues, func(i, j int) bool { return len(issues[i].Issues) > len(issues[j].Issues) })
+	return counts, issues
+}
+
+// checkEnabled requires the Security Insights plan feature and the organization's vault health setting
+func (s *vaultHealthService) checkEnabled(ctx context.Context, orgID uint) error {
+	ok, err := s.featureSvc.CanUseSecurityInsights(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrFeatureNotAvailable
+	}
+
+	prefs, err := s.prefRepo.ListByOwner(ctx, domain.OrgSettingOwnerType, orgID, domain.OrgSettingSectionSecurity)
+	if err != nil {
+		return fmt.Errorf("failed to load security settings: %w", err)
+	}
+	for _, p := range prefs {
+		if p.Key != domain.OrgSettingKeyVaultHealthReports {
+			continue
+		}
+		if enabled, err := strconv.ParseBool(strings.TrimSpace(p.Value)); err == nil && enabled {
+			return nil
+		}
+	}
+	return ErrVaultHealthReportsDisabled
+}
+
+func dayOf(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func containsUint(values []uint, v uint) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/authz"
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// fakeVaultHealthRepo keeps signals by item and snapshots by collection and day
+type fakeVaultHealthRepo struct {
+	signals   map[uint]*domain.ItemHealthSignal
+	snapshots map[uint]map[time.Time]*domain.VaultHealthSnapshot
+}
+
+func (f *fakeVaultHealthRepo) UpsertSignals(_ context.Context, signals []*domain.ItemHealthSignal) error {
+	for _, s := range signals {
+		f.signals[s.OrganizationItemID] = s
+	}
+	return nil
+}
+func (f *fakeVaultHealthRepo) ListSignalsByOrganization(_ context.Context, orgID uint) ([]*domain.ItemHealthSignal, error) {
+	var out []*domain.ItemHealthSignal
+	for _, s := range f.signals {
+		if s.OrganizationID == orgID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+func (f *fakeVaultHealthRepo) UpsertSnapshots(_ context.Context, snapshots []*domain.VaultHealthSnapshot) error {
+	for _, s := range snapshots {
+		if f.snapshots[s.CollectionID] == nil {
+			f.snapshots[s.CollectionID] = map[time.Time]*domain.VaultHealthSnapshot{}
+		}
+		f.snapshots[s.CollectionID][s.Date] = s
+	}
+	return nil
+}
+func (f *fakeVaultHealthRepo) ListSnapshots(_ context.Context, _ uint, collectionID uint, since time.Time) ([]*domain.VaultHealthSnapshot, error) {
+	var out []*domain.VaultHealthSnapshot
+	for day, s := range f.snapshots[collectionID] {
+		if !day.Before(since) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// fakeHealthItemRepo serves a fixed set of organization items
+type fakeHealthItemRepo struct {
+	items []*domain.OrganizationItem
+}
+
+func (f *fakeHealthItemRepo) GetByUUID(_ context.Context, id string) (*domain.OrganizationItem, error) {
+	for _, item := range f.items {
+		if item.UUID.String() == id {
+			return item, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeHealthItemRepo) ListByOrganization(_ context.Context, filter repository.OrganizationItemFilter) ([]*domain.OrganizationItem, int64, error) {
+	if filter.Page > 1 {
+		return nil, int64(len(f.items)), nil
+	}
+	return f.items, int64(len(f.items)), nil
+}
+
+// fakeHealthItemAccess grants admins everything, and members read access to the
+// items of readable collections and admin access to managed collections
+type fakeHealthItemAccess struct {
+	admins   map[uint]bool
+	readable map[uint]bool
+	managed  map[uint]bool
+}
+
+func (f *fakeHealthItemAccess) GetItemAccess(_ context.Context, item *domain.OrganizationItem, userID uint) (*authz.CollectionAccess, error) {
+	if f.admins[userID] {
+		return &authz.CollectionAccess{CanRead: true, CanWrite: true, CanAdmin: true}, nil
+	}
+	for _, id := range item.CollectionIDs {
+		if f.readable[id] {
+			return &authz.CollectionAccess{CanRead: true}, nil
+		}
+	}
+	return &authz.CollectionAccess{}, nil
+}
+func (f *fakeHealthItemAccess) GetCollectionAccess(_ context.Context, _, _, collectionID uint) (*authz.CollectionAccess, error) {
+	return &authz.CollectionAccess{CanRead: f.readable[collectionID], CanAdmin: f.managed[collectionID]}, nil
+}
+
+// fakeInsightsFeatures toggles the Security Insights plan feature
+type fakeInsightsFeatures struct {
+	FeatureService
+	enabled bool
+}
+
+func (f *fakeInsightsFeatures) CanUseSecurityInsights(context.Context, uint) (bool, error) {
+	return f.enabled, nil
+}
+
+type vaultHealthFixture struct {
+	svc      *vaultHealthService
+	repo     *fakeVaultHealthRepo
+	items    map[string]*domain.OrganizationItem
+	prefs    *fakeMemberPrefRepo
+	features *fakeInsightsFeatures
+	clock    time.Time
+}
+
+// newVaultHealthFixture sets up org 1 with an admin (user 101) and a member (user 102)
+// who can read collection 5 only. Items "a" and "d" live in collection 5, "b" in
+// collection 6 and "c" in both.
+func newVaultHealthFixture(t *testing.T) *vaultHealthFixture {
+	t.Helper()
+
+	f := &vaultHealthFixture{
+		repo:     &fakeVaultHealthRepo{signals: map[uint]*domain.ItemHealthSignal{}, snapshots: map[uint]map[time.Time]*domain.VaultHealthSnapshot{}},
+		items:    map[string]*domain.OrganizationItem{},
+		prefs:    &fakeMemberPrefRepo{prefs: []*domain.Preference{{Key: domain.OrgSettingKeyVaultHealthReports, Value: "true"}}},
+		features: &fakeInsightsFeatures{enabled: true},
+		clock:    time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC),
+	}
+
+	itemRepo := &fakeHealthItemRepo{}
+	for i, spec := range []struct {
+		name        string
+		collections []uint
+	}{{"a", []uint{5}}, {"b", []uint{6}}, {"c", []uint{5, 6}}, {"d", []uint{5}}} {
+		item := &domain.OrganizationItem{
+			ID:             uint(i + 1),
+			UUID:           uuid.New(),
+			OrganizationID: 1,
+			CollectionIDs:  spec.collections,
+			ItemType:       domain.ItemTypePassword,
+			UpdatedAt:      f.clock.AddDate(0, -1, 0),
+		}
+		f.items[spec.name] = item
+		itemRepo.items = append(itemRepo.items, item)
+	}
+
+	collections := &fakeFlatCollectionRepo{collections: map[uint]*domain.Collection{
+		5: {ID: 5, OrganizationID: 1, Name: "Production"},
+		6: {ID: 6, OrganizationID: 1, Name: "Marketing"},
+	}}
+	members := &suspensionMemberRepo{members: map[uint]*domain.OrganizationUser{
+		11: {ID: 11, OrganizationID: 1, UserID: 101, Role: domain.OrgRoleAdmin, Status: domain.OrgUserStatusConfirmed},
+		12: {ID: 12, OrganizationID: 1, UserID: 102, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed},
+		13: {
+			ID: 13, OrganizationID: 1, UserID: 103, Role: domain.OrgRoleMember, Status: domain.OrgUserStatusConfirmed,
+			CustomRole: &domain.OrganizationCustomRole{Name: "Auditor", Permissions: string(domain.OrgPermViewAudit)},
+		},
+	}}
+	access := &fakeHealthItemAccess{admins: map[uint]bool{101: true}, readable: map[uint]bool{5: true}, managed: map[uint]bool{}}
+
+	f.svc = NewVaultHealthService(f.repo, itemRepo, access, collections, members, f.prefs, f.features, noopLogger{}).(*vaultHealthService)
+	f.svc.now = func() time.Time { return f.clock }
+	return f
+}
+
+func (f *vaultHealthFixture) signal(name, hash string, score int) domain.ItemHealthSignalInput {
+	return domain.ItemHealthSignalInput{ItemUUID: f.items[name].UUID.String(), PasswordHash: hash, StrengthScore: score}
+}
+
+func TestVaultHealth_Report(t *testing.T) {
+	ctx := context.Background()
+	f := newVaultHealthFixture(t)
+
+	changed := f.clock.AddDate(-2, 0, 0)
+	stale := f.signal("c", "h-c", 4)
+	stale.Exposed = true
+	stale.PasswordChangedAt = &changed
+	twoFactor := f.signal("a", "h-shared", 1)
+	twoFactor.TwoFactorAvailable = true
+
+	accepted, err := f.svc.SubmitSignals(ctx, 1, 101, &domain.SubmitItemHealthSignalsRequest{Items: []domain.ItemHealthSignalInput{
+		twoFactor, f.signal("b", "h-shared", 4), stale,
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, 3, accepted)
+
+	report, err := f.svc.GetOrganizationReport(ctx, 1, 101)
+	require.NoError(t, err)
+	assert.Equal(t, domain.VaultHealthCounts{TotalItems: 4, ReportedItems: 3, Weak: 1, Reused: 2, Exposed: 1, Stale: 1, Missing2FA: 1}, report.VaultHealthCounts)
+	require.Len(t, report.Items, 3)
+	assert.Equal(t, []string{"weak", "reused", "missing_2fa"}, report.Items[0].Issues)
+	require.Len(t, report.Trend, 1)
+	assert.Equal(t, report.VaultHealthCounts, report.Trend[0].VaultHealthCounts)
+
+	// Reuse is detected across collections
+	collection, err := f.svc.GetCollectionReport(ctx, 1, 6, 101)
+	require.NoError(t, err)
+	assert.Equal(t, domain.VaultHealthCounts{TotalItems: 2, ReportedItems: 2, Reused: 1, Exposed: 1, Stale: 1}, collection.VaultHealthCounts)
+	require.Len(t, collection.Trend, 1)
+	assert.Equal(t, 2, collection.Trend[0].TotalItems)
+
+	// A later submission replaces the item's signals and today's snapshot
+	_, err = f.svc.SubmitSignals(ctx, 1, 101, &domain.SubmitItemHealthSignalsRequest{Items: []domain.ItemHealthSignalInput{f.signal("a", "h-a", 4)}})
+	require.NoError(t, err)
+	report, err = f.svc.GetOrganizationReport(ctx, 1, 101)
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Reused)
+	require.Len(t, report.Trend, 1)
+	assert.Equal(t, 0, report.Trend[0].Weak)
+}
+
+func TestVaultHealth_Access(t *testing.T) {
+	ctx := context.Background()
+	f := newVaultHealthFixture(t)
+
+	// Members only report items they can read
+	accepted, err := f.svc.SubmitSignals(ctx, 1, 102, &domain.SubmitItemHealthSignalsRequest{Items: []domain.ItemHealthSignalInput{
+		f.signal("a", "h-a", 3), f.signal("b", "h-b", 3),
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, accepted)
+	assert.Contains(t, f.repo.signals, f.items["a"].ID)
+	assert.NotContains(t, f.repo.signals, f.items["b"].ID)
+
+	_, err = f.svc.GetOrganizationReport(ctx, 1, 102)
+	assert.ErrorIs(t, err, repository.ErrForbidden)
+	_, err = f.svc.GetCollectionReport(ctx, 1, 5, 102)
+	assert.ErrorIs(t, err, repository.ErrForbidden, "reading a collection is not enough")
+
+	f.svc.itemAccess.(*fakeHealthItemAccess).managed[5] = true
+	_, err = f.svc.GetCollectionReport(ctx, 1, 5, 102)
+	assert.NoError(t, err, "collection managers see their collection's report")
+
+	// view_audit grants every report, whatever the role
+	_, err = f.svc.GetOrganizationReport(ctx, 1, 103)
+	assert.NoError(t, err)
+	_, err = f.svc.GetCollectionReport(ctx, 1, 6, 103)
+	assert.NoError(t, err)
+
+	f.prefs.prefs[0].Value = "false"
+	_, err = f.svc.GetOrganizationReport(ctx, 1, 101)
+	assert.ErrorIs(t, err, ErrVaultHealthReportsDisabled)
+
+	f.prefs.prefs[0].Value = "true"
+	f.features.enabled = false
+	_, err = f.svc.SubmitSignals(ctx, 1, 101, &domain.SubmitItemHealthSignalsRequest{Items: []domain.ItemHealthSignalInput{f.signal("a", "h-a", 3)}})
+	assert.ErrorIs(t, err, ErrFeatureNotAvailable)
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+var (
+	ErrUnknownWebhookProvider = errors.New("unknown webhook provider")
+	ErrWebhookEventBusy       = errors.New("webhook event is being processed")
+)
+
+// webhookRetryBatchSize bounds how many due events are retried per run
+const webhookRetryBatchSize = 100
+
+// WebhookProcessor verifies and processes webhooks of one provider.
+// ProcessWebhookEvent must be idempotent: events can be redelivered, retried and replayed.
+type WebhookProcessor interface {
+	// ParseWebhook verifies a raw webhook and returns its event ID and type
+	ParseWebhook(payload []byte, signature string) (eventID, eventType string, err error)
+	// ProcessWebhookEvent processes a verified webhook payload
+	ProcessWebhookEvent(ctx context.Context, payload []byte) error
+}
+
+// WebhookInboxService stores every verified Stripe and RevenueCat webhook in an
+// inbox, processes each event once, and retries failed events with backoff.
+type WebhookInboxService interface {
+	// Receive verifies, stores and processes a webhook. Once the event is stored,
+	// processing failures are retried by the inbox instead of the provider.
+	Receive(ctx context.Context, provider domain.WebhookProvider, payload []byte, signature string) error
+	// ProcessDue retries events whose backoff has elapsed
+	ProcessDue(ctx context.Context) error
+
+	// Admin
+	List(ctx context.Context, filter repository.WebhookEventFilter) ([]*domain.WebhookEventDTO, int64, int64, error)
+	GetByID(ctx context.Context, id uint) (*domain.WebhookEvent, error)
+	Replay(ctx context.Context, id uint) (*domain.WebhookEvent, error)
+}
+
+type webhookInboxService struct {
+	repo       repository.WebhookEventRepository
+	processors map[domain.WebhookProvider]WebhookProcessor
+	logger     Logger
+	now        func() time.Time
+}
+
+// NewWebhookInboxService creates a new webhook inbox service
+func NewWebhookInboxService(
+	repo repository.WebhookEventRepository,
+	processors map[domain.WebhookProvider]WebhookProcessor,
+	logger Logger,
+) WebhookInboxService {
+	return &webhookInboxService{
+		repo:       repo,
+		processors: processors,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Receive verifies, stores and processes a webhook
+func (s *webhookInboxService) Receive(ctx context.Context, provider domain.WebhookProvider, payload []byte, signature string) error {
+	processor, ok := s.processors[provider]
+	if !ok || processor == nil {
+		return ErrUnknownWebhookProvider
+	}
+
+	eventID, eventType, err := processor.ParseWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+	if eventID == "" {
+		// Fall back to a content hash so redeliveries are still recognized
+		sum := sha256.Sum256(payload)
+		eventID = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	event := &domain.WebhookEvent{
+		Provider:  provider,
+		EventID:   eventID,
+		EventType: eventType,
+		Payload:   domain.WebhookPayload(payload),
+		Status:    domain.WebhookStatusPending,
+	}
+
+	if err := s.repo.Create(ctx, event); err != nil {
+		if !errors.Is(err, repository.ErrAlreadyExists) {
+			// Not stored: let the provider retry the delivery
+			return fmt.Errorf("failed to store webhook event: %w", err)
+		}
+
+		existing, getErr := s.repo.GetByEventID(ctx, provider, eventID)
+		if getErr != nil {
+			return fmt.Errorf("failed to load webhook event: %w", getErr)
+		}
+		if existing.IsProcessed() {
+			s.logger.Info("duplicate webhook event ignored", "provider", provider, "event_id", eventID, "event_type", eventType)
+			return nil
+		}
+		event = existing
+	}
+
+	if err := s.process(ctx, event, false); err != nil && !errors.Is(err, ErrWebhookEventBusy) {
+		s.logger.Warn("webhook event failed, will be retried",
+			"provider", provider,
+			"event_id", eventID,
+			"event_type", eventType,
+			"error", err,
+		)
+	}
+	return nil
+}
+
+// ProcessDue retries events whose backoff has elapsed
+func (s *webhookInboxService) ProcessDue(ctx context.Context) error {
+	events, err := s.repo.ListDue(ctx, s.now(), webhookRetryBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list due webhook events: %w", err)
+	}
+
+	for _, event := range events {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.process(ctx, event, false); err != nil && !errors.Is(err, ErrWebhookEventBusy) {
+			s.logger.Warn("webhook retry failed",
+				"provider", event.Provider,
+				"event_id", event.EventID,
+				"event_type", event.EventType,
+				"attempts", event.Attempts,
+				"error", err,
+			)
+		}
+	}
+	return nil
+}
+
+// List returns inbox events for admins (without payloads)
+func (s *webhookInboxService) List(ctx context.Context, filter repository.WebhookEventFilter) ([]*domain.WebhookEventDTO, int64, int64, error) {
+	events, total, filtered, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	dtos := make([]*domain.WebhookEventDTO, 0, len(events))
+	for _, event := range events {
+		dtos = append(dtos, domain.ToWebhookEventDTO(event))
+	}
+	return dtos, total, filtered, nil
+}
+
+// GetByID returns an inbox event including its payload
+func (s *webhookInboxService) GetByID(ctx context.Context, id uint) (*domain.WebhookEvent, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// Replay processes an event again regardless of its status (unless it is being processed right now)
+func (s *webhookInboxService) Replay(ctx context.Context, id uint) (*domain.WebhookEvent, error) {
+	event, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.process(ctx, event, true); err != nil {
+		if errors.Is(err, ErrWebhookEventBusy) {
+			return nil, err
+		}
+		s.logger.Warn("webhook replay failed", "provider", event.Provider, "event_id", event.EventID, "error", err)
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// process claims an event, runs its provider processor and records the outcome
+func (s *webhookInboxService) process(ctx context.Context, event *domain.WebhookEvent, force bool) error {
+	processor, ok := s.processors[event.Provider]
+	if !ok || processor == nil {
+		return ErrUnknownWebhookProvider
+	}
+
+	now := s.now()
+	claimed, err := s.repo.Claim(ctx, event.ID, now, now.Add(domain.WebhookProcessingLease), force)
+	if err != nil {
+		return fmt.Errorf("failed to claim webhook event: %w", err)
+	}
+	if !claimed {
+		return ErrWebhookEventBusy
+	}
+
+	// Reload to pick up the attempt counted by the claim (and the payload, if listed without it)
+	event, err = s.repo.GetByID(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to reload webhook event: %w", err)
+	}
+
+	procErr := processor.ProcessWebhookEvent(ctx, event.Payload)
+	if procErr != nil {
+		event.MarkFailed(procErr, s.now())
+	} else {
+		event.MarkProcessed(s.now())
+	}
+
+	if err := s.repo.Update(ctx, event); err != nil {
+		return fmt.Errorf("failed to record webhook event result: %w", err)
+	}
+
+	if procErr == nil {
+		s.logger.Info("webhook event processed",
+			"provider", event.Provider,
+			"event_id", event.EventID,
+			"event_type", event.EventType,
+			"attempts", event.Attempts,
+		)
+	} else if event.Status == domain.WebhookStatusDead {
+		s.logger.Error("webhook event gave up after max attempts",
+			"provider", event.Provider,
+			"event_id", event.EventID,
+			"event_type", event.EventType,
+			"attempts", event.Attempts,
+			"error", procErr,
+		)
+	}
+	return procErr
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/passwall/passwall-server/internal/domain"
+	"github.com/passwall/passwall-server/internal/repository"
+)
+
+// fakeWebhookEventRepo keeps events in memory and claims them like the gorm repository
+type fakeWebhookEventRepo struct {
+	events map[uint]*domain.WebhookEvent
+}
+
+func (f *fakeWebhookEventRepo) Create(_ context.Context, event *domain.WebhookEvent) error {
+	for _, e := range f.events {
+		if e.Provider == event.Provider && e.EventID == event.EventID {
+			return repository.ErrAlreadyExists
+		}
+	}
+	event.ID = uint(len(f.events) + 1)
+	stored := *event
+	f.events[event.ID] = &stored
+	return nil
+}
+func (f *fakeWebhookEventRepo) GetByID(_ context.Context, id uint) (*domain.WebhookEvent, error) {
+	if e, ok := f.events[id]; ok {
+		event := *e
+		return &event, nil
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeWebhookEventRepo) GetByEventID(ctx context.Context, provider domain.WebhookProvider, eventID string) (*domain.WebhookEvent, error) {
+	for _, e := range f.events {
+		if e.Provider == provider && e.EventID == eventID {
+			return f.GetByID(ctx, e.ID)
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+func (f *fakeWebhookEventRepo) Update(_ context.Context, event *domain.WebhookEvent) error {
+	stored := *event
+	f.events[event.ID] = &stored
+	return nil
+}
+func (f *fakeWebhookEventRepo) Claim(_ context.Context, id uint, now, leaseUntil time.Time, force bool) (bool, error) {
+	e, ok := f.events[id]
+	if !ok {
+		return false, nil
+	}
+	due := e.NextAttemptAt == nil || !e.NextAttemptAt.After(now)
+	switch {
+	case force && e.Status == domain.WebhookStatusProcessing && !due:
+		return false, nil
+	case !force && (e.Status == domain.WebhookStatusProcessed || e.Status == domain.WebhookStatusDead || !due):
+		return false, nil
+	}
+	e.Status = domain.WebhookStatusProcessing
+	e.Attempts++
+	e.LastAttemptAt = &now
+	e.NextAttemptAt = &leaseUntil
+	return true, nil
+}
+func (f *fakeWebhookEventRepo) ListDue(_ context.Context, now time.Time, _ int) ([]*domain.WebhookEvent, error) {
+	var out []*domain.WebhookEvent
+	for _, e := range f.events {
+		switch e.Status {
+		case domain.WebhookStatusPending, domain.WebhookStatusFailed, domain.WebhookStatusProcessing:
+			if e.NextAttemptAt == nil || !e.NextAttemptAt.After(now) {
+				event := *e
+				out = append(out, &event)
+			}
+		}
+	}
+	return out, nil
+}
+func (f *fakeWebhookEventRepo) List(context.Context, repository.WebhookEventFilter) ([]*domain.WebhookEvent, int64, int64, error) {
+	return nil, 0, 0, nil
+}
+
+// fakeWebhookProcessor reads the event ID from the payload and fails while err is set.
+// during runs inside ProcessWebhookEvent, while the event is claimed.
+type fakeWebhookProcessor struct {
+	err    error
+	calls  int
+	during func()
+}
+
+func (f *fakeWebhookProcessor) ParseWebhook(payload []byte, signature string) (string, string, error) {
+	if signature != "valid" {
+		return "", "", errors.New("invalid signature")
+	}
+	var event struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return "", "", err
+	}
+	return event.ID, event.Type, nil
+}
+func (f *fakeWebhookProcessor) ProcessWebhookEvent(context.Context, []byte) error {
+	f.calls++
+	if f.during != nil {
+		during := f.during
+		f.during = nil
+		during()
+	}
+	return f.err
+}
+
+type webhookInboxFixture struct {
+	svc       *webhookInboxService
+	repo      *fakeWebhookEventRepo
+	processor *fakeWebhookProcessor
+	clock     time.Time
+}
+
+func newWebhookInboxFixture(t *testing.T) *webhookInboxFixture {
+	t.Helper()
+
+	f := &webhookInboxFixture{
+		repo:      &fakeWebhookEventRepo{events: map[uint]*domain.WebhookEvent{}},
+		processor: &fakeWebhookProcessor{},
+		clock:     time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.svc = NewWebhookInboxService(f.repo, map[domain.WebhookProvider]WebhookProcessor{
+		domain.WebhookProviderStripe: f.processor,
+	}, noopLogger{}).(*webhookInboxService)
+	f.svc.now = func() time.Time { return f.clock }
+	return f
+}
+
+var invoicePaid = []byte(`{"id":"evt_1","type":"invoice.paid"}`)
+
+func (f *webhookInboxFixture) event(t *testing.T) *domain.WebhookEvent {
+	t.Helper()
+	event, err := f.repo.GetByEventID(context.Background(), domain.WebhookProviderStripe, "evt_1")
+	require.NoError(t, err)
+	return event
+}
+
+func TestWebhookInbox_DuplicateOfProcessedEventIsIgnored(t *testing.T) {
+	ctx := context.Background()
+	f := newWebhookInboxFixture(t)
+
+	require.NoError(t, f.svc.Receive(ctx, domain.WebhookProviderStripe, invoicePaid, "valid"))
+	event := f.event(t)
+	assert.Equal(t, domain.WebhookStatusProcessed, event.Status)
+	assert.Equal(t, 1, event.Attempts)
+
+	// A redelivery is acknowledged without processing the event again
+	require.NoError(t, f.svc.Receive(ctx, domain.WebhookProviderStripe, invoicePaid, "valid"))
+	assert.Equal(t, 1, f.processor.calls)
+	assert.Len(t, f.repo.events, 1)
+	assert.Equal(t, 1, f.event(t).Attempts)
+
+	// Unverified webhooks are never stored
+	assert.Error(t, f.svc.Receive(ctx, domain.WebhookProviderStripe, []byte(`{"id":"evt_2"}`), "forged"))
+	assert.Len(t, f.repo.events, 1)
+	assert.ErrorIs(t, f.svc.Receive(ctx, "paypal", invoicePaid, "valid"), ErrUnknownWebhookProvider)
+}
+
+func TestWebhookInbox_FailedEventIsRetriedAfterBackoff(t *testing.T) {
+	ctx := context.Background()
+	f := newWebhookInboxFixture(t)
+	f.processor.err = errors.New("database unavailable")
+
+	// The failure is the inbox's to retry: the provider gets a success
+	require.NoError(t, f.svc.Receive(ctx, domain.WebhookProviderStripe, invoicePaid, "valid"))
+	event := f.event(t)
+	assert.Equal(t, domain.WebhookStatusFailed, event.Status)
+	require.NotNil(t, event.Error)
+	assert.Equal(t, "database unavailable", *event.Error)
+	require.NotNil(t, event.NextAttemptAt)
+	assert.Equal(t, f.clock.Add(domain.WebhookRetryDelay(1)), *event.NextAttemptAt)
+
+	// Nothing is retried before the backoff elapses, not even by a redelivery
+	require.NoError(t, f.svc.ProcessDue(ctx))
+	require.NoError(t, f.svc.Receive(ctx, domain.WebhookProviderStripe, invoicePaid, "valid"))
+	assert.Equal(t, 1, f.processor.calls)
+
+	f.processor.err = nil
+	f.clock = f.clock.Add(domain.WebhookRetryDelay(1))
+	require.NoError(t, f.svc.ProcessDue(ctx))
+	assert.Equal(t, 2, f.processor.calls)
+	event = f.event(t)
+	assert.Equal(t, domain.WebhookStatusProcessed, event.Status)
+	assert.Equal(t, 2, event.Attempts)
+	assert.Nil(t, event.Error)
+	assert.Nil(t, event.NextAttemptAt)
+}
+
+func TestWebhookInbox_BusyEventIsNotClaimedTwice(t *testing.T) {
+	ctx := context.Background()
+	f := newWebhookInboxFixture(t)
+
+	// While the first delivery is being processed, a redelivery, the retry job and a
+	// replay all find the event claimed
+	var redeliveryErr, dueErr, replayErr error
+	f.processor.during = func() {
+		redeliveryErr = f.svc.Receive(ctx, domain.WebhookProviderStripe, invoicePaid, "valid")
+		dueErr = f.svc.ProcessDue(ctx)
+		_, replayErr = f.svc.Replay(ctx, f.event(t).ID)
+	}
+
+	require.NoError(t, f.svc.Receive(ctx, domain.WebhookProviderStripe, invoicePaid, "valid"))
+	assert.NoError(t, redeliveryErr)
+	assert.NoError(t, dueErr)
+	assert.ErrorIs(t, replayErr, ErrWebhookEventBusy)
+	assert.Equal(t, 1, f.processor.calls)
+	assert.Equal(t, 1, f.event(t).Attempts)
+
+	// A processor that died mid-event gives the event up once its lease expires
+	stuck := f.event(t)
+	lease := f.clock.Add(domain.WebhookProcessingLease)
+	stuck.Status = domain.WebhookStatusProcessing
+	stuck.NextAttemptAt = &lease
+	require.NoError(t, f.repo.Update(ctx, stuck))
+
+	require.NoError(t, f.svc.ProcessDue(ctx))
+	assert.Equal(t, 1, f.processor.calls)
+	f.clock = lease
+	require.NoError(t, f.svc.ProcessDue(ctx))
+	assert.Equal(t, 2, f.processor.calls)
+	assert.Equal(t, domain.WebhookStatusProcessed, f.event(t).Status)
+}
+
+func TestWebhookInbox_ReplayProcessedEvent(t *testing.T) {
+	ctx := context.Background()
+	f := newWebhookInboxFixture(t)
+
+	require.NoError(t, f.svc.Receive(ctx, domain.WebhookProviderStripe, invoicePaid, "valid"))
+	id := f.event(t).ID
+
+	f.clock = f.clock.Add(time.Hour)
+	replayed, err := f.svc.Replay(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, 2, f.processor.calls)
+	assert.Equal(t, domain.WebhookStatusProcessed, replayed.Status)
+	assert.Equal(t, 2, replayed.Attempts)
+	require.NotNil(t, replayed.ProcessedAt)
+	assert.Equal(t, f.clock, *replayed.ProcessedAt)
+
+	// A failed replay is recorded on the event rather than returned
+	f.processor.err = errors.New("customer not found")
+	replayed, err = f.svc.Replay(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookStatusFailed, replayed.Status)
+	require.NotNil(t, replayed.Error)
+	assert.Equal(t, "customer not found", *replayed.Error)
+
+	_, err = f.svc.Replay(ctx, 99)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
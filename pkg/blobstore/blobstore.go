@@ -0,0 +1,135 @@
+// Package blobstore stores opaque binary objects (already encrypted by the
+// client) under string keys. The server never interprets blob contents.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var (
+	ErrNotFound   = errors.New("blobstore: blob not found")
+	ErrTooLarge   = errors.New("blobstore: blob exceeds size limit")
+	ErrInvalidKey = errors.New("blobstore: invalid key")
+)
+
+// keyPattern restricts keys to a flat, path-safe alphabet
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+// Store persists blobs under string keys
+type Store interface {
+	// Put streams r into the blob key and returns the number of bytes written.
+	// Writing more than maxBytes fails with ErrTooLarge and leaves no blob behind.
+	Put(ctx context.Context, key string, r io.Reader, maxBytes int64) (int64, error)
+	// Open returns a reader for the blob and its size
+	Open(ctx context.Context, key string) (io.ReadCloser, int64, error)
+	// Delete removes the blob; deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// FS stores each blob as a file in a single directory
+type FS struct {
+	dir string
+}
+
+// NewFS creates a filesystem store rooted at dir, creating the directory if needed
+func NewFS(dir string) (*FS, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("blobstore: directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("blobstore: failed to create directory: %w", err)
+	}
+	return &FS{dir: dir}, nil
+}
+
+func (s *FS) path(key string) (string, error) {
+	if !keyPattern.MatchString(key) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.dir, key), nil
+}
+
+// Put writes to a temporary file first so readers never see a partial blob
+func (s *FS) Put(ctx context.Context, key string, r io.Reader, maxBytes int64) (int64, error) {
+	dst, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("blobstore: failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	// Read one byte past the limit to detect oversized input
+	n, err := io.Copy(tmp, io.LimitReader(contextReader{ctx: ctx, r: r}, maxBytes+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("blobstore: failed to write blob: %w", err)
+	}
+	if n > maxBytes {
+		return 0, ErrTooLarge
+	}
+
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return 0, fmt.Errorf("blobstore: failed to store blob: %w", err)
+	}
+	return n, nil
+}
+
+// Open opens the blob for reading
+func (s *FS) Open(_ context.Context, key string) (io.ReadCloser, int64, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, 0, ErrNotFound
+		}
+		return nil, 0, fmt.Errorf("blobstore: failed to open blob: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, fmt.Errorf("blobstore: failed to stat blob: %w", err)
+	}
+	return f, info.Size(), nil
+}
+
+// Delete removes the blob file
+func (s *FS) Delete(_ context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("blobstore: failed to delete blob: %w", err)
+	}
+	return nil
+}
+
+// contextReader stops a long upload once the request is cancelled
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,76 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFS_PutOpenDelete(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFS(t.TempDir())
+	require.NoError(t, err)
+
+	n, err := store.Put(ctx, "send-1", strings.NewReader("ciphertext"), 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), n)
+
+	rc, size, err := store.Open(ctx, "send-1")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, rc.Close())
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), size)
+	assert.Equal(t, "ciphertext", string(data))
+
+	require.NoError(t, store.Delete(ctx, "send-1"))
+	_, _, err = store.Open(ctx, "send-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Deleting twice is fine
+	assert.NoError(t, store.Delete(ctx, "send-1"))
+}
+
+func TestFS_PutTooLargeLeavesNothing(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := NewFS(dir)
+	require.NoError(t, err)
+
+	_, err = store.Put(ctx, "big", bytes.NewReader(make([]byte, 11)), 10)
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	_, _, err = store.Open(ctx, "big")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "temporary upload files must be removed")
+}
+
+func TestFS_RejectsUnsafeKeys(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFS(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "../etc/passwd", "a/b", ".hidden"} {
+		_, err := store.Put(ctx, key, strings.NewReader("x"), 1)
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
+
+func TestFS_PutStopsOnCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	store, err := NewFS(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = store.Put(ctx, "cancelled", strings.NewReader("data"), 10)
+	assert.ErrorIs(t, err, context.Canceled)
+}
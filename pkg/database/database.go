@@ -27,6 +27,8 @@ type Database interface {
 
 // Config holds database configuration
 type Config struct {
+	Driver          string // postgres (default) or sqlite
+	Path            string // SQLite database file (sqlite only)
 	Host            string
 	Port            string
 	Username        string
@@ -42,6 +44,7 @@ type Config struct {
 // DefaultConfig returns default database configuration
 func DefaultConfig() *Config {
 	return &Config{
+		Driver:          string(DialectPostgres),
 		Host:            "localhost",
 		Port:            "5432",
 		Username:        "postgres",
@@ -0,0 +1,64 @@
+// Package dbtest opens test databases for every supported dialect so repository
+// tests run against both SQLite and PostgreSQL.
+package dbtest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/passwall/passwall-server/pkg/database"
+	"github.com/passwall/passwall-server/pkg/database/postgres"
+	"github.com/passwall/passwall-server/pkg/database/sqlite"
+	"gorm.io/gorm"
+)
+
+// Run calls fn once per available dialect as a subtest.
+// SQLite always runs on a fresh temporary file. PostgreSQL runs when PW_DB_HOST is set
+// (as in CI) and connects with the PW_DB_* variables; tests must clean up what they create.
+func Run(t *testing.T, fn func(t *testing.T, db *gorm.DB)) {
+	t.Helper()
+
+	t.Run(string(database.DialectSQLite), func(t *testing.T) {
+		db, err := sqlite.New(&database.Config{
+			Driver:       string(database.DialectSQLite),
+			Path:         filepath.Join(t.TempDir(), "passwall.db"),
+			MaxIdleConns: 2,
+			MaxOpenConns: 4,
+		})
+		if err != nil {
+			t.Fatalf("open sqlite: %v", err)
+		}
+		t.Cleanup(func() { _ = db.Close() })
+		fn(t, db.DB())
+	})
+
+	t.Run(string(database.DialectPostgres), func(t *testing.T) {
+		host := os.Getenv("PW_DB_HOST")
+		if host == "" {
+			t.Skip("PW_DB_HOST not set; skipping PostgreSQL")
+		}
+		cfg := database.DefaultConfig()
+		cfg.Host = host
+		cfg.Port = envOr("PW_DB_PORT", cfg.Port)
+		cfg.Database = envOr("PW_DB_NAME", cfg.Database)
+		cfg.Username = envOr("PW_DB_USERNAME", cfg.Username)
+		cfg.Password = envOr("PW_DB_PASSWORD", cfg.Password)
+		cfg.SSLMode = envOr("PW_DB_SSL_MODE", cfg.SSLMode)
+		cfg.MaxOpenConns = 4
+
+		db, err := postgres.New(cfg)
+		if err != nil {
+			t.Fatalf("open postgres: %v", err)
+		}
+		t.Cleanup(func() { _ = db.Close() })
+		fn(t, db.DB())
+	})
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Dialect identifies the SQL database behind a *gorm.DB
+type Dialect string
+
+const (
+	// DialectPostgres keeps every user vault in its own schema (selected with SET LOCAL search_path)
+	DialectPostgres Dialect = "postgres"
+	// DialectSQLite has no schemas; a user vault is a set of tables prefixed with the schema name
+	DialectSQLite Dialect = "sqlite"
+)
+
+// ParseDialect parses a configured driver name ("" defaults to postgres)
+func ParseDialect(name string) (Dialect, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "postgres", "postgresql", "pgx":
+		return DialectPostgres, nil
+	case "sqlite", "sqlite3":
+		return DialectSQLite, nil
+	default:
+		return "", fmt.Errorf("unsupported database driver: %q (allowed: postgres, sqlite)", name)
+	}
+}
+
+// DialectOf returns the dialect of an open connection
+func DialectOf(db *gorm.DB) Dialect {
+	if db != nil && db.Dialector != nil && db.Dialector.Name() == "sqlite" {
+		return DialectSQLite
+	}
+	return DialectPostgres
+}
+
+// IsSQLite reports whether the dialect is SQLite
+func (d Dialect) IsSQLite() bool {
+	return d == DialectSQLite
+}
+
+// ILike returns the case-insensitive LIKE operator.
+// SQLite's LIKE is already case-insensitive for ASCII.
+func (d Dialect) ILike() string {
+	if d.IsSQLite() {
+		return "LIKE"
+	}
+	return "ILIKE"
+}
+
+// JSONText returns an expression that extracts a top-level JSON field as text.
+// column and key must be trusted identifiers; they are not escaped.
+func (d Dialect) JSONText(column, key string) string {
+	if d.IsSQLite() {
+		return fmt.Sprintf("json_extract(%s, '$.%s')", column, key)
+	}
+	return fmt.Sprintf("%s->>'%s'", column, key)
+}
+
+// JSONArrayContains returns a condition (with one placeholder) and its argument
+// that match rows whose JSON array field key contains value.
+func (d Dialect) JSONArrayContains(column, key, value string) (string, interface{}) {
+	if d.IsSQLite() {
+		return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s, '$.%s') WHERE json_each.value = ?)", column, key), value
+	}
+	arr, _ := json.Marshal([]string{value})
+	return fmt.Sprintf("%s->'%s' @> ?", column, key), string(arr)
+}
+
+// UserTableName returns the unquoted name of a per-user vault table.
+// On PostgreSQL this is the bare table name (the schema is selected via search_path);
+// on SQLite the schema name becomes a table prefix ("u_abc" + "items" = "u_abc_items").
+func (d Dialect) UserTableName(schema, table string) (string, error) {
+	if err := ValidateSchemaName(schema); err != nil {
+		return "", err
+	}
+	if err := ValidateTableName(table); err != nil {
+		return "", err
+	}
+	if d.IsSQLite() {
+		return schema + "_" + table, nil
+	}
+	return table, nil
+}
+
+// QualifiedUserTableName returns the quoted name of a per-user vault table
+// that can be used without search_path ("schema"."items" or "schema_items").
+func (d Dialect) QualifiedUserTableName(schema, table string) (string, error) {
+	if d.IsSQLite() {
+		name, err := d.UserTableName(schema, table)
+		if err != nil {
+			return "", err
+		}
+		return SanitizeIdentifier(name), nil
+	}
+	return BuildQualifiedTableName(schema, table)
+}
@@ -0,0 +1,134 @@
+// Package migrate applies versioned SQL migrations to the public schema and to
+// every per-user vault schema.
+//
+// Each schema keeps its own schema_migrations table. Every migration runs in
+// its own transaction together with its version row, so a failed migration
+// leaves the schema at the last successful version and the next run resumes
+// from there.
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/passwall/passwall-server/pkg/database/migrations"
+)
+
+var (
+	// ErrIrreversible is returned when rolling back a migration without a down file
+	ErrIrreversible = errors.New("migration has no down migration")
+	// ErrUnknownVersion is returned when a schema has a version that is not in the migration set
+	ErrUnknownVersion = errors.New("applied migration is not in the migration set")
+)
+
+// fileNameRegex matches NNN_description.sql and NNN_description.down.sql
+var fileNameRegex = regexp.MustCompile(`^(\d+)_([a-z0-9_]+?)(\.down)?\.sql$`)
+
+// Migration is a single versioned migration
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Reversible reports whether the migration can be rolled back
+func (m Migration) Reversible() bool {
+	return m.Down != ""
+}
+
+// String returns the migration file stem, e.g. 001_add_email_verification
+func (m Migration) String() string {
+	return fmt.Sprintf("%03d_%s", m.Version, m.Name)
+}
+
+// Load reads migrations from dir in fsys, ordered by version
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileNameRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %d (%s, %s)", version, m.Name, match[2])
+		}
+
+		isDown := match[3] != ""
+		if isDown {
+			m.Down = string(content)
+		} else {
+			if m.Up != "" {
+				return nil, fmt.Errorf("duplicate migration version %d", version)
+			}
+			m.Up = string(content)
+		}
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %s has a down file but no up file", m)
+		}
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+// PublicMigrations returns the embedded migrations for the public schema
+func PublicMigrations() ([]Migration, error) {
+	return Load(migrations.Public, ".")
+}
+
+// UserMigrations returns the embedded migrations for per-user schemas
+func UserMigrations() ([]Migration, error) {
+	return Load(migrations.User, "user")
+}
+
+// Pending returns the migrations not yet applied, in order
+func Pending(all []Migration, applied map[int64]bool) []Migration {
+	var pending []Migration
+	for _, m := range all {
+		if !applied[m.Version] {
+			pending = append(pending, m)
+		}
+	}
+	return pending
+}
+
+// Latest returns the highest version in a migration set (0 if empty)
+func Latest(all []Migration) int64 {
+	if len(all) == 0 {
+		return 0
+	}
+	return all[len(all)-1].Version
+}
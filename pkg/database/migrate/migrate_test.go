@@ -0,0 +1,68 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"002_add_b.sql":      {Data: []byte("B")},
+		"002_add_b.down.sql": {Data: []byte("-B")},
+		"001_add_a.sql":      {Data: []byte("A")},
+		"README.md":          {Data: []byte("ignored")},
+		"embed.go":           {Data: []byte("ignored")},
+	}
+
+	migrations, err := Load(fsys, ".")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "001_add_a", migrations[0].String())
+	assert.False(t, migrations[0].Reversible())
+
+	assert.Equal(t, "B", migrations[1].Up)
+	assert.Equal(t, "-B", migrations[1].Down)
+	assert.True(t, migrations[1].Reversible())
+	assert.Equal(t, int64(2), Latest(migrations))
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"duplicate version", fstest.MapFS{"001_a.sql": {}, "001_b.sql": {}}},
+		{"down without up", fstest.MapFS{"001_a.down.sql": {}}},
+		{"zero version", fstest.MapFS{"000_a.sql": {}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys, ".")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPending(t *testing.T) {
+	all := []Migration{{Version: 1}, {Version: 2}, {Version: 3}}
+
+	pending := Pending(all, map[int64]bool{1: true, 3: true})
+	require.Len(t, pending, 1)
+	assert.Equal(t, int64(2), pending[0].Version)
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	public, err := PublicMigrations()
+	require.NoError(t, err)
+	assert.NotEmpty(t, public)
+
+	user, err := UserMigrations()
+	require.NoError(t, err)
+	assert.NotEmpty(t, user)
+}
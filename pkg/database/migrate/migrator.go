@@ -0,0 +1,337 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/passwall/passwall-server/pkg/database"
+	"github.com/passwall/passwall-server/pkg/logger"
+	"gorm.io/gorm"
+)
+
+const (
+	// PublicSchema is the shared schema; every other schema is a per-user vault
+	PublicSchema = "public"
+
+	// DefaultConcurrency bounds how many user schemas are migrated at once
+	DefaultConcurrency = 4
+
+	versionTable = "schema_migrations"
+)
+
+// Migrator applies migrations to the public schema and to per-user schemas
+type Migrator struct {
+	db          *gorm.DB
+	public      []Migration
+	user        []Migration
+	concurrency int
+}
+
+// New creates a migrator. concurrency bounds the user schema fan-out (<= 0 uses DefaultConcurrency).
+func New(db *gorm.DB, public, user []Migration, concurrency int) *Migrator {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	return &Migrator{
+		db:          db,
+		public:      public,
+		user:        user,
+		concurrency: concurrency,
+	}
+}
+
+// SchemaStatus describes the migration state of one schema
+type SchemaStatus struct {
+	Schema  string
+	Current int64 // highest applied version (0 if none)
+	Latest  int64 // highest available version
+	Applied []int64
+	Pending []Migration
+}
+
+// UpToDate reports whether the schema has no pending migrations
+func (s *SchemaStatus) UpToDate() bool {
+	return len(s.Pending) == 0
+}
+
+// SchemaResult is the outcome of a migration run on one schema
+type SchemaResult struct {
+	Schema string
+	Count  int // migrations applied or rolled back
+	Status *SchemaStatus
+	Err    error
+}
+
+// migrationsFor returns the migration set for a schema
+func (m *Migrator) migrationsFor(schema string) []Migration {
+	if schema == PublicSchema {
+		return m.public
+	}
+	return m.user
+}
+
+// HasVersionTable reports whether the schema already tracks migrations
+func (m *Migrator) HasVersionTable(ctx context.Context, schema string) (bool, error) {
+	var exists bool
+	err := m.db.WithContext(ctx).
+		Raw("SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = ? AND table_name = ?)", schema, versionTable).
+		Scan(&exists).Error
+	return exists, err
+}
+
+// Status returns the applied and pending migrations of a schema
+func (m *Migrator) Status(ctx context.Context, schema string) (*SchemaStatus, error) {
+	all := m.migrationsFor(schema)
+	applied, err := m.appliedVersions(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &SchemaStatus{
+		Schema:  schema,
+		Latest:  Latest(all),
+		Pending: Pending(all, applied),
+	}
+	for v := range applied {
+		status.Applied = append(status.Applied, v)
+		if v > status.Current {
+			status.Current = v
+		}
+	}
+	sort.Slice(status.Applied, func(i, j int) bool { return status.Applied[i] < status.Applied[j] })
+	return status, nil
+}
+
+// Up applies all pending migrations to a schema in order and returns how many ran.
+// It stops at the first failure; applied migrations stay recorded.
+func (m *Migrator) Up(ctx context.Context, schema string) (int, error) {
+	status, err := m.Status(ctx, schema)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, mig := range status.Pending {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		ran, err := m.apply(ctx, schema, mig)
+		if err != nil {
+			return count, err
+		}
+		if ran {
+			count++
+			logger.Infof("migrate: applied %s on %s", mig, schema)
+		}
+	}
+	return count, nil
+}
+
+// Down rolls back the last steps applied migrations of a schema and returns how many were rolled back
+func (m *Migrator) Down(ctx context.Context, schema string, steps int) (int, error) {
+	status, err := m.Status(ctx, schema)
+	if err != nil {
+		return 0, err
+	}
+
+	byVersion := make(map[int64]Migration)
+	for _, mig := range m.migrationsFor(schema) {
+		byVersion[mig.Version] = mig
+	}
+
+	count := 0
+	for i := len(status.Applied) - 1; i >= 0 && count < steps; i-- {
+		version := status.Applied[i]
+		mig, ok := byVersion[version]
+		if !ok {
+			return count, fmt.Errorf("%w: version %d on %s", ErrUnknownVersion, version, schema)
+		}
+		if !mig.Reversible() {
+			return count, fmt.Errorf("%w: %s", ErrIrreversible, mig)
+		}
+		if err := m.rollback(ctx, schema, mig); err != nil {
+			return count, err
+		}
+		count++
+		logger.Infof("migrate: rolled back %s on %s", mig, schema)
+	}
+	return count, nil
+}
+
+// Stamp records every migration up to and including version as applied without running it.
+// Used for schemas whose structure was created directly (e.g. by GORM AutoMigrate).
+func (m *Migrator) Stamp(ctx context.Context, schema string, version int64) error {
+	return m.inSchema(ctx, schema, func(tx *gorm.DB) error {
+		now := time.Now()
+		for _, mig := range m.migrationsFor(schema) {
+			if mig.Version > version {
+				break
+			}
+			err := tx.Exec("INSERT INTO "+versionTable+" (version, name, applied_at) VALUES (?, ?, ?) ON CONFLICT (version) DO NOTHING",
+				mig.Version, mig.Name, now).Error
+			if err != nil {
+				return fmt.Errorf("failed to stamp %s on %s: %w", mig, schema, err)
+			}
+		}
+		return nil
+	})
+}
+
+// UpSchemas applies pending migrations to each schema with bounded concurrency.
+// A failing schema does not stop the others; rerunning resumes where each schema stopped.
+func (m *Migrator) UpSchemas(ctx context.Context, schemas []string) []SchemaResult {
+	return m.forEach(ctx, schemas, func(ctx context.Context, schema string) SchemaResult {
+		n, err := m.Up(ctx, schema)
+		if err != nil {
+			logger.Errorf("migrate: %s failed after %d migration(s): %v", schema, n, err)
+		}
+		return SchemaResult{Schema: schema, Count: n, Err: err}
+	})
+}
+
+// DownSchemas rolls back the last steps migrations of each schema with bounded concurrency
+func (m *Migrator) DownSchemas(ctx context.Context, schemas []string, steps int) []SchemaResult {
+	return m.forEach(ctx, schemas, func(ctx context.Context, schema string) SchemaResult {
+		n, err := m.Down(ctx, schema, steps)
+		if err != nil {
+			logger.Errorf("migrate: rollback of %s failed after %d migration(s): %v", schema, n, err)
+		}
+		return SchemaResult{Schema: schema, Count: n, Err: err}
+	})
+}
+
+// StatusSchemas returns the status of each schema with bounded concurrency
+func (m *Migrator) StatusSchemas(ctx context.Context, schemas []string) []SchemaResult {
+	return m.forEach(ctx, schemas, func(ctx context.Context, schema string) SchemaResult {
+		status, err := m.Status(ctx, schema)
+		return SchemaResult{Schema: schema, Status: status, Err: err}
+	})
+}
+
+// forEach runs fn for every schema using at most m.concurrency workers.
+// Results keep the order of schemas; schemas not started before ctx is canceled report ctx.Err().
+func (m *Migrator) forEach(ctx context.Context, schemas []string, fn func(ctx context.Context, schema string) SchemaResult) []SchemaResult {
+	results := make([]SchemaResult, len(schemas))
+	sem := make(chan struct{}, m.concurrency)
+	var wg sync.WaitGroup
+
+	for i, schema := range schemas {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(schemas); j++ {
+				results[j] = SchemaResult{Schema: schemas[j], Err: ctx.Err()}
+			}
+			wg.Wait()
+			return results
+		}
+
+		wg.Add(1)
+		go func(i int, schema string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = fn(ctx, schema)
+		}(i, schema)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// apply runs one up migration and records its version. It returns false if
+// another process applied the migration first.
+func (m *Migrator) apply(ctx context.Context, schema string, mig Migration) (bool, error) {
+	ran := false
+	err := m.inSchema(ctx, schema, func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Raw("SELECT COUNT(*) FROM "+versionTable+" WHERE version = ?", mig.Version).Scan(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Exec(mig.Up).Error; err != nil {
+			return fmt.Errorf("migration %s failed on %s: %w", mig, schema, err)
+		}
+		if err := tx.Exec("INSERT INTO "+versionTable+" (version, name, applied_at) VALUES (?, ?, ?)", mig.Version, mig.Name, time.Now()).Error; err != nil {
+			return fmt.Errorf("failed to record migration %s on %s: %w", mig, schema, err)
+		}
+		ran = true
+		return nil
+	})
+	return ran, err
+}
+
+// rollback runs one down migration and removes its version
+func (m *Migrator) rollback(ctx context.Context, schema string, mig Migration) error {
+	return m.inSchema(ctx, schema, func(tx *gorm.DB) error {
+		result := tx.Exec("DELETE FROM "+versionTable+" WHERE version = ?", mig.Version)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Rolled back by another process
+			return nil
+		}
+		if err := tx.Exec(mig.Down).Error; err != nil {
+			return fmt.Errorf("rollback of %s failed on %s: %w", mig, schema, err)
+		}
+		return nil
+	})
+}
+
+// inSchema runs fn in a transaction scoped to schema, holding the schema's
+// migration lock and with the version table in place.
+func (m *Migrator) inSchema(ctx context.Context, schema string, fn func(tx *gorm.DB) error) error {
+	if err := database.ValidateSchemaName(schema); err != nil {
+		return fmt.Errorf("invalid schema name: %w", err)
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL search_path TO " + database.SanitizeIdentifier(schema)).Error; err != nil {
+			return fmt.Errorf("failed to set search path: %w", err)
+		}
+		// Serialize migrations per schema across server instances
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "passwall_migrate:"+schema).Error; err != nil {
+			return fmt.Errorf("failed to lock schema %s: %w", schema, err)
+		}
+		if err := tx.Exec(`CREATE TABLE IF NOT EXISTS ` + versionTable + ` (
+			version    BIGINT PRIMARY KEY,
+			name       VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`).Error; err != nil {
+			return fmt.Errorf("failed to create %s: %w", versionTable, err)
+		}
+		return fn(tx)
+	})
+}
+
+// appliedVersions returns the versions recorded in a schema (empty if not tracked yet)
+func (m *Migrator) appliedVersions(ctx context.Context, schema string) (map[int64]bool, error) {
+	applied := make(map[int64]bool)
+
+	exists, err := m.HasVersionTable(ctx, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %s on %s: %w", versionTable, schema, err)
+	}
+	if !exists {
+		return applied, nil
+	}
+
+	table, err := database.BuildQualifiedTableName(schema, versionTable)
+	if err != nil {
+		return nil, err
+	}
+	var versions []int64
+	if err := m.db.WithContext(ctx).Raw("SELECT version FROM " + table).Scan(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to read %s on %s: %w", versionTable, schema, err)
+	}
+	for _, v := range versions {
+		applied[v] = true
+	}
+	return applied, nil
+}
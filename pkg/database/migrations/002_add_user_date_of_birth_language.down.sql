@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_language;
+ALTER TABLE users DROP COLUMN IF EXISTS language;
+ALTER TABLE users DROP COLUMN IF EXISTS date_of_birth;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS breach_records;
+DROP TABLE IF EXISTS monitored_emails;
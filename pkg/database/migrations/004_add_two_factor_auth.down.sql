@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS two_factor_recovery_codes;
+ALTER TABLE users DROP COLUMN IF EXISTS two_factor_secret;
+ALTER TABLE users DROP COLUMN IF EXISTS two_factor_enabled;
@@ -0,0 +1,60 @@
+-- Promotions: promo codes (backed by Stripe coupons), redemption audit trail
+-- and admin-granted trial extensions.
+
+CREATE TABLE IF NOT EXISTS promo_codes (
+    id                 BIGSERIAL PRIMARY KEY,
+    created_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    code               VARCHAR(64)  NOT NULL,
+    description        VARCHAR(255) NOT NULL DEFAULT '',
+    discount_type      VARCHAR(20)  NOT NULL,
+    percent_off        NUMERIC,
+    amount_off_cents   BIGINT,
+    currency           VARCHAR(3)   NOT NULL DEFAULT '',
+    duration           VARCHAR(20)  NOT NULL DEFAULT 'once',
+    duration_in_months INT,
+    max_redemptions    INT,
+    times_redeemed     INT          NOT NULL DEFAULT 0,
+    expires_at         TIMESTAMPTZ,
+    applies_to_plans   JSONB        NOT NULL DEFAULT '[]',
+    is_active          BOOLEAN      NOT NULL DEFAULT TRUE,
+    stripe_coupon_id   VARCHAR(255),
+    created_by_user_id BIGINT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promo_codes_code ON promo_codes (code);
+
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+    id                      BIGSERIAL PRIMARY KEY,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    promo_code_id           BIGINT      NOT NULL REFERENCES promo_codes(id),
+    organization_id         BIGINT      NOT NULL,
+    user_id                 BIGINT      NOT NULL,
+    source                  VARCHAR(20) NOT NULL,
+    status                  VARCHAR(20) NOT NULL,
+    plan_code               VARCHAR(50) NOT NULL DEFAULT '',
+    stripe_coupon_id        VARCHAR(255),
+    stripe_checkout_session VARCHAR(255),
+    redeemed_at             TIMESTAMPTZ,
+    ends_at                 TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_promo_code_id ON promo_redemptions (promo_code_id);
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_organization_id ON promo_redemptions (organization_id);
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_status ON promo_redemptions (status);
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_stripe_checkout_session ON promo_redemptions (stripe_checkout_session);
+
+CREATE TABLE IF NOT EXISTS trial_extensions (
+    id                 BIGSERIAL PRIMARY KEY,
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    organization_id    BIGINT      NOT NULL,
+    days               INT         NOT NULL,
+    reason             TEXT        NOT NULL DEFAULT '',
+    granted_by_user_id BIGINT      NOT NULL,
+    applied_at         TIMESTAMPTZ,
+    trial_ends_at      TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_trial_extensions_organization_id ON trial_extensions (organization_id);
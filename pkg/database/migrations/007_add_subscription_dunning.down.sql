@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS dunning_reminders_sent;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS read_only_since;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS past_due_since;
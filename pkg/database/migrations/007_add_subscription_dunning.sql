@@ -0,0 +1,4 @@
+-- Dunning state for past_due subscriptions (reminders, read-only downgrade, final expiry)
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS past_due_since TIMESTAMPTZ NULL;
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS read_only_since TIMESTAMPTZ NULL;
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS dunning_reminders_sent INT NOT NULL DEFAULT 0;
@@ -0,0 +1,16 @@
+-- RevenueCat events did not exist before the inbox; drop them so event IDs stay unique
+DELETE FROM webhook_events WHERE provider <> 'stripe';
+
+DROP INDEX IF EXISTS idx_webhook_events_next_attempt_at;
+DROP INDEX IF EXISTS idx_webhook_events_status;
+DROP INDEX IF EXISTS idx_webhook_events_provider_event;
+
+ALTER TABLE webhook_events DROP COLUMN IF EXISTS next_attempt_at;
+ALTER TABLE webhook_events DROP COLUMN IF EXISTS last_attempt_at;
+ALTER TABLE webhook_events DROP COLUMN IF EXISTS attempts;
+ALTER TABLE webhook_events DROP COLUMN IF EXISTS status;
+ALTER TABLE webhook_events DROP COLUMN IF EXISTS provider;
+ALTER TABLE webhook_events DROP COLUMN IF EXISTS updated_at;
+
+ALTER TABLE webhook_events RENAME COLUMN event_id TO stripe_event_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_events_stripe_event_id ON webhook_events (stripe_event_id);
@@ -0,0 +1,27 @@
+-- Unified webhook inbox for Stripe and RevenueCat with retry state
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_name = 'webhook_events' AND column_name = 'stripe_event_id') THEN
+        ALTER TABLE webhook_events RENAME COLUMN stripe_event_id TO event_id;
+    END IF;
+END $$;
+
+ALTER TABLE webhook_events ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NULL;
+ALTER TABLE webhook_events ADD COLUMN IF NOT EXISTS provider VARCHAR(20) NOT NULL DEFAULT 'stripe';
+ALTER TABLE webhook_events ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'pending';
+ALTER TABLE webhook_events ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE webhook_events ADD COLUMN IF NOT EXISTS last_attempt_at TIMESTAMPTZ NULL;
+ALTER TABLE webhook_events ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ NULL;
+
+DROP INDEX IF EXISTS idx_webhook_events_stripe_event_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_events_provider_event ON webhook_events (provider, event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_events_event_type ON webhook_events (event_type);
+CREATE INDEX IF NOT EXISTS idx_webhook_events_status ON webhook_events (status);
+CREATE INDEX IF NOT EXISTS idx_webhook_events_next_attempt_at ON webhook_events (next_attempt_at);
+
+-- Existing rows were processed inline; keep failures visible in the inbox without retrying them
+UPDATE webhook_events SET status = 'processed', attempts = 1, updated_at = processed_at
+WHERE processed_at IS NOT NULL AND status = 'pending';
+UPDATE webhook_events SET status = 'dead', attempts = 1, updated_at = created_at
+WHERE processed_at IS NULL AND error IS NOT NULL AND status = 'pending';
@@ -0,0 +1,14 @@
+ALTER TABLE organization_items ADD COLUMN IF NOT EXISTS collection_id BIGINT NULL;
+CREATE INDEX IF NOT EXISTS idx_organization_items_collection_id ON organization_items (collection_id);
+
+-- Items in several collections keep the one they were first assigned to
+UPDATE organization_items oi
+SET collection_id = (
+    SELECT oic.collection_id
+    FROM organization_item_collections oic
+    WHERE oic.organization_item_id = oi.id
+    ORDER BY oic.created_at ASC, oic.collection_id ASC
+    LIMIT 1
+);
+
+DROP TABLE IF EXISTS organization_item_collections;
@@ -0,0 +1,28 @@
+-- Organization items can belong to several collections.
+-- The single organization_items.collection_id column becomes a join table.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'organization_items') THEN
+        RETURN;
+    END IF;
+
+    CREATE TABLE IF NOT EXISTS organization_item_collections (
+        organization_item_id BIGINT NOT NULL REFERENCES organization_items (id) ON DELETE CASCADE,
+        collection_id        BIGINT NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+        created_at           TIMESTAMPTZ NULL,
+        PRIMARY KEY (organization_item_id, collection_id)
+    );
+    CREATE INDEX IF NOT EXISTS idx_organization_item_collections_collection_id
+        ON organization_item_collections (collection_id);
+
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_name = 'organization_items' AND column_name = 'collection_id') THEN
+        INSERT INTO organization_item_collections (organization_item_id, collection_id, created_at)
+        SELECT oi.id, oi.collection_id, oi.created_at
+        FROM organization_items oi
+        JOIN collections c ON c.id = oi.collection_id
+        ON CONFLICT DO NOTHING;
+
+        ALTER TABLE organization_items DROP COLUMN collection_id;
+    END IF;
+END $$;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_collections_parent_id;
+ALTER TABLE collections DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,11 @@
+-- Nested collections: a collection may have a parent collection in the same
+-- organization. Grants on a parent are inherited by its children.
+-- On a fresh database the collections table is created later by AutoMigrate.
+
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'collections') THEN
+        ALTER TABLE collections ADD COLUMN IF NOT EXISTS parent_id BIGINT NULL;
+        CREATE INDEX IF NOT EXISTS idx_collections_parent_id ON collections (parent_id);
+    END IF;
+END $$;
@@ -0,0 +1,18 @@
+// Package migrations embeds the versioned SQL migrations.
+//
+// Files are named NNN_description.sql (up) with an optional
+// NNN_description.down.sql (down). Migrations in this directory run on the
+// public schema; migrations in user/ run on every per-user vault schema.
+package migrations
+
+import "embed"
+
+// Public holds the migrations for the public schema
+//
+//go:embed *.sql
+var Public embed.FS
+
+// User holds the migrations for per-user vault schemas
+//
+//go:embed user/*.sql
+var User embed.FS
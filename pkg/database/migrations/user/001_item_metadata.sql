@@ -0,0 +1,38 @@
+-- Baseline for per-user vault schemas: the items table itself is created by
+-- GORM at signup; this adds the sequences, trigger and indexes it relies on.
+
+CREATE SEQUENCE IF NOT EXISTS items_revision_seq;
+
+CREATE SEQUENCE IF NOT EXISTS items_support_id_seq
+    START WITH 1000000000000000000
+    INCREMENT BY 1
+    NO MAXVALUE
+    CACHE 100;
+
+CREATE OR REPLACE FUNCTION update_item_metadata()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        NEW.support_id = nextval('items_support_id_seq');
+    END IF;
+
+    NEW.revision = nextval('items_revision_seq');
+    NEW.updated_at = NOW();
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS item_metadata_trigger ON items;
+
+CREATE TRIGGER item_metadata_trigger
+    BEFORE INSERT OR UPDATE ON items
+    FOR EACH ROW
+    EXECUTE FUNCTION update_item_metadata();
+
+CREATE INDEX IF NOT EXISTS idx_items_support_id ON items(support_id);
+CREATE INDEX IF NOT EXISTS idx_items_revision ON items(revision DESC);
+CREATE INDEX IF NOT EXISTS idx_items_type ON items(item_type) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_items_favorite ON items(is_favorite) WHERE deleted_at IS NULL AND is_favorite = true;
+CREATE INDEX IF NOT EXISTS idx_items_autofill ON items(auto_fill) WHERE item_type = 1 AND auto_fill = true AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_items_metadata_gin ON items USING gin(metadata jsonb_path_ops);
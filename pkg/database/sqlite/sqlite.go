@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/passwall/passwall-server/pkg/database"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// DefaultPath is the database file used when no path is configured
+const DefaultPath = "passwall.db"
+
+// sqliteDB implements the Database interface for SQLite (single-node, self-hosted deployments)
+type sqliteDB struct {
+	db *gorm.DB
+}
+
+// New opens (or creates) a SQLite database file
+func New(cfg *database.Config) (database.Database, error) {
+	if cfg == nil {
+		cfg = database.DefaultConfig()
+	}
+
+	path := cfg.Path
+	if path == "" {
+		path = DefaultPath
+	}
+	if path != ":memory:" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
+	}
+
+	// WAL lets readers run next to the single writer; immediate transactions take the
+	// write lock up front so concurrent writers wait (busy_timeout) instead of failing
+	// half-way with SQLITE_BUSY. Foreign keys are off by default in SQLite.
+	params := url.Values{}
+	params.Set("_foreign_keys", "on")
+	params.Set("_journal_mode", "WAL")
+	params.Set("_busy_timeout", "5000")
+	params.Set("_txlock", "immediate")
+	dsn := fmt.Sprintf("file:%s?%s", path, params.Encode())
+
+	// Configure GORM logger
+	logLevel := logger.Silent
+	if cfg.LogMode {
+		logLevel = logger.Info
+	}
+
+	gormConfig := &gorm.Config{
+		Logger: logger.Default.LogMode(logLevel),
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
+		// See postgres.New: associations are saved explicitly
+		FullSaveAssociations: false,
+	}
+
+	db, err := gorm.Open(sqlite.Open(dsn), gormConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+
+	// An in-memory database only lives as long as its connection
+	if path == ":memory:" {
+		sqlDB.SetMaxOpenConns(1)
+	} else if cfg.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &sqliteDB{db: db}, nil
+}
+
+// DB returns the underlying *gorm.DB instance
+func (s *sqliteDB) DB() *gorm.DB {
+	return s.db
+}
+
+// Close closes the database connection
+func (s *sqliteDB) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+	return sqlDB.Close()
+}
+
+// Ping checks if the database is reachable
+func (s *sqliteDB) Ping(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// AutoMigrate runs auto migration for given models
+func (s *sqliteDB) AutoMigrate(dst ...interface{}) error {
+	return s.db.AutoMigrate(dst...)
+}
+
+// Transaction executes a function within a transaction
+func (s *sqliteDB) Transaction(ctx context.Context, fn func(*gorm.DB) error) error {
+	return s.db.WithContext(ctx).Transaction(fn)
+}
@@ -72,12 +72,57 @@ func (c *Client) CheckBreachedAccount(email string) ([]Breach, error) {
 	endpoint := fmt.Sprintf("%s/breachedaccount/%s?truncateResponse=false",
 		baseURL, url.PathEscape(email))
 
+	var breaches []Breach
+	found, err := c.get(endpoint, &breaches)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return []Breach{}, nil
+	}
+	return breaches, nil
+}
+
+// BreachedDomain runs a domain search and returns the breach names for every
+// breached alias (the part of the address before the @) on the domain.
+// The domain must be verified for the API key on haveibeenpwned.com.
+// Returns an empty map (not an error) if no aliases are breached (HTTP 404).
+func (c *Client) BreachedDomain(domain string) (map[string][]string, error) {
+	endpoint := fmt.Sprintf("%s/breacheddomain/%s", baseURL, url.PathEscape(domain))
+
+	aliases := map[string][]string{}
+	found, err := c.get(endpoint, &aliases)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return map[string][]string{}, nil
+	}
+	return aliases, nil
+}
+
+// GetBreach returns the details of a single breach by name.
+// Returns nil (not an error) if HIBP does not know the breach (HTTP 404).
+func (c *Client) GetBreach(name string) (*Breach, error) {
+	endpoint := fmt.Sprintf("%s/breach/%s", baseURL, url.PathEscape(name))
+
+	var breach Breach
+	found, err := c.get(endpoint, &breach)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &breach, nil
+}
+
+// get performs a rate-limited GET with retries and decodes the JSON body into out.
+// found is false when HIBP answers 404, which its API uses for "no results".
+func (c *Client) get(endpoint string, out interface{}) (found bool, err error) {
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
 		c.rateLimit()
 
 		req, err := http.NewRequest(http.MethodGet, endpoint, nil)
 		if err != nil {
-			return nil, fmt.Errorf("hibp: failed to create request: %w", err)
+			return false, fmt.Errorf("hibp: failed to create request: %w", err)
 		}
 		req.Header.Set("hibp-api-key", c.apiKey)
 		req.Header.Set("User-Agent", userAgent)
@@ -85,7 +130,7 @@ func (c *Client) CheckBreachedAccount(email string) ([]Breach, error) {
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			if attempt == c.maxRetries {
-				return nil, fmt.Errorf("hibp: request failed after retries: %w", err)
+				return false, fmt.Errorf("hibp: request failed after retries: %w", err)
 			}
 			time.Sleep(backoffDelay(attempt))
 			continue
@@ -93,27 +138,29 @@ func (c *Client) CheckBreachedAccount(email string) ([]Breach, error) {
 
 		switch resp.StatusCode {
 		case http.StatusOK:
-			var breaches []Breach
-			decodeErr := json.NewDecoder(resp.Body).Decode(&breaches)
+			decodeErr := json.NewDecoder(resp.Body).Decode(out)
 			resp.Body.Close()
 			if decodeErr != nil {
-				return nil, fmt.Errorf("hibp: failed to decode response: %w", decodeErr)
+				return false, fmt.Errorf("hibp: failed to decode response: %w", decodeErr)
 			}
-			return breaches, nil
+			return true, nil
 		case http.StatusNotFound:
 			resp.Body.Close()
-			return []Breach{}, nil
+			return false, nil
 		case http.StatusTooManyRequests:
 			waitFor := parseRetryAfter(resp.Header.Get("Retry-After"), backoffDelay(attempt))
 			resp.Body.Close()
 			if attempt == c.maxRetries {
-				return nil, fmt.Errorf("hibp: rate limited after retries (retry-after: %s)", waitFor.String())
+				return false, fmt.Errorf("hibp: rate limited after retries (retry-after: %s)", waitFor.String())
 			}
 			time.Sleep(waitFor)
 			continue
 		case http.StatusUnauthorized:
 			resp.Body.Close()
-			return nil, fmt.Errorf("hibp: unauthorized — check API key")
+			return false, fmt.Errorf("hibp: unauthorized — check API key")
+		case http.StatusForbidden:
+			resp.Body.Close()
+			return false, fmt.Errorf("hibp: forbidden — the domain may not be verified for this API key")
 		default:
 			body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
 			resp.Body.Close()
@@ -121,11 +168,11 @@ func (c *Client) CheckBreachedAccount(email string) ([]Breach, error) {
 				time.Sleep(backoffDelay(attempt))
 				continue
 			}
-			return nil, fmt.Errorf("hibp: unexpected status %d: %s", resp.StatusCode, string(body))
+			return false, fmt.Errorf("hibp: unexpected status %d: %s", resp.StatusCode, string(body))
 		}
 	}
 
-	return nil, fmt.Errorf("hibp: exhausted retries")
+	return false, fmt.Errorf("hibp: exhausted retries")
 }
 
 // Enabled reports whether the client has an API key configured.
@@ -0,0 +1,133 @@
+// Package license implements the signed envelope used for offline license files.
+//
+// A license file is a small JSON document carrying an opaque payload and an
+// Ed25519 signature over that payload. The payload itself is interpreted by the
+// caller; this package only knows how to sign, encode and verify it, so it can
+// be used both by the server (verification) and by tooling that issues licenses.
+package license
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FormatVersion is the current envelope version.
+const FormatVersion = 1
+
+var (
+	ErrMalformed          = errors.New("license: malformed license file")
+	ErrUnsupportedVersion = errors.New("license: unsupported license format version")
+	ErrInvalidSignature   = errors.New("license: signature verification failed")
+	ErrInvalidPublicKey   = errors.New("license: invalid Ed25519 public key")
+	ErrInvalidPrivateKey  = errors.New("license: invalid Ed25519 private key")
+)
+
+// Envelope is the on-disk representation of a license file.
+type Envelope struct {
+	Version   int    `json:"version"`
+	Payload   string `json:"payload"`   // base64url (no padding) encoded payload
+	Signature string `json:"signature"` // base64url (no padding) Ed25519 signature over the raw payload
+}
+
+// Sign signs payload with the given private key and returns the encoded license file.
+func Sign(privateKey ed25519.PrivateKey, payload []byte) ([]byte, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, ErrInvalidPrivateKey
+	}
+	if len(payload) == 0 {
+		return nil, fmt.Errorf("%w: empty payload", ErrMalformed)
+	}
+
+	sig := ed25519.Sign(privateKey, payload)
+	env := Envelope{
+		Version:   FormatVersion,
+		Payload:   base64.RawURLEncoding.EncodeToString(payload),
+		Signature: base64.RawURLEncoding.EncodeToString(sig),
+	}
+	return json.MarshalIndent(env, "", "  ")
+}
+
+// Verify decodes a license file, checks its signature against publicKey and
+// returns the raw payload. The payload is only returned when the signature is valid.
+func Verify(publicKey ed25519.PublicKey, data []byte) ([]byte, error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if env.Version != FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, env.Version)
+	}
+
+	payload, err := decodeSegment(env.Payload)
+	if err != nil || len(payload) == 0 {
+		return nil, fmt.Errorf("%w: invalid payload encoding", ErrMalformed)
+	}
+	sig, err := decodeSegment(env.Signature)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: invalid signature encoding", ErrMalformed)
+	}
+
+	if !ed25519.Verify(publicKey, payload, sig) {
+		return nil, ErrInvalidSignature
+	}
+	return payload, nil
+}
+
+// ParsePublicKey parses an Ed25519 public key given as hex or base64 (std or url).
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	raw, err := decodeKey(s, ed25519.PublicKeySize)
+	if err != nil {
+		return nil, ErrInvalidPublicKey
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// ParsePrivateKey parses an Ed25519 private key (64-byte key or 32-byte seed)
+// given as hex or base64 (std or url).
+func ParsePrivateKey(s string) (ed25519.PrivateKey, error) {
+	if raw, err := decodeKey(s, ed25519.PrivateKeySize); err == nil {
+		return ed25519.PrivateKey(raw), nil
+	}
+	seed, err := decodeKey(s, ed25519.SeedSize)
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+func decodeSegment(s string) ([]byte, error) {
+	s = strings.TrimRight(strings.TrimSpace(s), "=")
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// decodeKey accepts hex, std base64 or url base64 and returns the decoded
+// bytes only when they have the expected length.
+func decodeKey(s string, size int) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("empty key")
+	}
+
+	decoders := []func(string) ([]byte, error){
+		hex.DecodeString,
+		base64.StdEncoding.DecodeString,
+		base64.RawStdEncoding.DecodeString,
+		base64.URLEncoding.DecodeString,
+		base64.RawURLEncoding.DecodeString,
+	}
+	for _, decode := range decoders {
+		if raw, err := decode(s); err == nil && len(raw) == size {
+			return raw, nil
+		}
+	}
+	return nil, fmt.Errorf("key must decode to %d bytes", size)
+}
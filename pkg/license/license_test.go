@@ -0,0 +1,107 @@
+package license
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKeyPair(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return pub, priv
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	pub, priv := newKeyPair(t)
+	payload := []byte(`{"license_id":"lic_1","seats":25}`)
+
+	data, err := Sign(priv, payload)
+	require.NoError(t, err)
+
+	got, err := Verify(pub, data)
+	require.NoError(t, err)
+	assert.Equal(t, payload, got)
+}
+
+func TestVerifyRejectsTamperedPayload(t *testing.T) {
+	pub, priv := newKeyPair(t)
+
+	data, err := Sign(priv, []byte(`{"seats":5}`))
+	require.NoError(t, err)
+
+	var env Envelope
+	require.NoError(t, json.Unmarshal(data, &env))
+	env.Payload = base64.RawURLEncoding.EncodeToString([]byte(`{"seats":500}`))
+	tampered, err := json.Marshal(env)
+	require.NoError(t, err)
+
+	_, err = Verify(pub, tampered)
+	assert.True(t, errors.Is(err, ErrInvalidSignature), "got %v", err)
+}
+
+func TestVerifyRejectsOtherKey(t *testing.T) {
+	_, priv := newKeyPair(t)
+	otherPub, _ := newKeyPair(t)
+
+	data, err := Sign(priv, []byte(`{"seats":5}`))
+	require.NoError(t, err)
+
+	_, err = Verify(otherPub, data)
+	assert.True(t, errors.Is(err, ErrInvalidSignature), "got %v", err)
+}
+
+func TestVerifyRejectsMalformedInput(t *testing.T) {
+	pub, _ := newKeyPair(t)
+
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{name: "not json", data: "hello", want: ErrMalformed},
+		{name: "wrong version", data: `{"version":2,"payload":"e30","signature":"AA"}`, want: ErrUnsupportedVersion},
+		{name: "bad payload", data: `{"version":1,"payload":"!!","signature":"AA"}`, want: ErrMalformed},
+		{name: "short signature", data: `{"version":1,"payload":"e30","signature":"AA"}`, want: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Verify(pub, []byte(tt.data))
+			assert.True(t, errors.Is(err, tt.want), "got %v, want %v", err, tt.want)
+		})
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	pub, priv := newKeyPair(t)
+
+	for _, encoded := range []string{
+		hex.EncodeToString(pub),
+		base64.StdEncoding.EncodeToString(pub),
+		base64.RawURLEncoding.EncodeToString(pub),
+	} {
+		got, err := ParsePublicKey(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, pub, got)
+	}
+
+	_, err := ParsePublicKey("deadbeef")
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+
+	fromSeed, err := ParsePrivateKey(hex.EncodeToString(priv.Seed()))
+	require.NoError(t, err)
+	assert.Equal(t, priv, fromSeed)
+
+	fromFull, err := ParsePrivateKey(base64.StdEncoding.EncodeToString(priv))
+	require.NoError(t, err)
+	assert.Equal(t, priv, fromFull)
+}
@@ -3,10 +3,12 @@ package stripe
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/passwall/passwall-server/pkg/logger"
 	"github.com/stripe/stripe-go/v81"
 	"github.com/stripe/stripe-go/v81/checkout/session"
+	"github.com/stripe/stripe-go/v81/coupon"
 	"github.com/stripe/stripe-go/v81/customer"
 	"github.com/stripe/stripe-go/v81/invoice"
 	"github.com/stripe/stripe-go/v81/subscription"
@@ -100,6 +102,7 @@ type CheckoutSessionParams struct {
 	Plan         string
 	BillingCycle string
 	TrialDays    int               // Trial period in days (0 = no trial)
+	CouponID     string            // Coupon to apply (disables customer-entered promotion codes)
 	Metadata     map[string]string // Additional metadata for user-level subscriptions
 }
 
@@ -161,8 +164,16 @@ func (c *Client) CreateCheckoutSession(params CheckoutSessionParams) (*stripe.Ch
 	}
 	checkoutParams.AddMetadata("seats", fmt.Sprintf("%d", quantity))
 
-	// Allow promotion codes
-	checkoutParams.AllowPromotionCodes = stripe.Bool(true)
+	// Apply a pre-validated coupon, otherwise let the customer enter promotion codes.
+	// Stripe does not allow both at the same time.
+	if params.CouponID != "" {
+		checkoutParams.Discounts = []*stripe.CheckoutSessionDiscountParams{
+			{Coupon: stripe.String(params.CouponID)},
+		}
+		checkoutParams.AddMetadata("coupon_id", params.CouponID)
+	} else {
+		checkoutParams.AllowPromotionCodes = stripe.Bool(true)
+	}
 
 	sess, err := session.New(checkoutParams)
 	if err != nil {
@@ -368,7 +379,8 @@ func (c *Client) PreviewSeatChange(subscriptionID string, newQuantity int64) (*s
 // This is the industry-standard approach for changing plans when the customer already
 // has an active subscription with a payment method on file. Stripe handles proration
 // automatically — the customer is NOT redirected to a new checkout page.
-func (c *Client) UpdateSubscriptionPlan(subscriptionID string, newPriceID string, newQuantity int64, metadata map[string]string) (*stripe.Subscription, error) {
+// If couponID is set, the coupon replaces any existing discount on the subscription.
+func (c *Client) UpdateSubscriptionPlan(subscriptionID string, newPriceID string, newQuantity int64, couponID string, metadata map[string]string) (*stripe.Subscription, error) {
 	if subscriptionID == "" {
 		return nil, fmt.Errorf("subscriptionID is required")
 	}
@@ -403,6 +415,11 @@ func (c *Client) UpdateSubscriptionPlan(subscriptionID string, newPriceID string
 		// Immediately invoice the prorated amount for plan changes
 		ProrationBehavior: stripe.String("always_invoice"),
 	}
+	if couponID != "" {
+		params.Discounts = []*stripe.SubscriptionDiscountParams{
+			{Coupon: stripe.String(couponID)},
+		}
+	}
 
 	// Update metadata (plan, billing_cycle, etc.)
 	for k, v := range metadata {
@@ -421,7 +438,8 @@ func (c *Client) UpdateSubscriptionPlan(subscriptionID string, newPriceID string
 
 // PreviewPlanChange previews the cost of switching to a different plan/price.
 // Uses the same upcoming invoice API as seat changes but with a different price ID.
-func (c *Client) PreviewPlanChange(subscriptionID string, newPriceID string, newQuantity int64) (*stripe.Invoice, error) {
+// If couponID is set, the preview includes the coupon discount.
+func (c *Client) PreviewPlanChange(subscriptionID string, newPriceID string, newQuantity int64, couponID string) (*stripe.Invoice, error) {
 	if subscriptionID == "" {
 		return nil, fmt.Errorf("subscriptionID is required")
 	}
@@ -449,6 +467,11 @@ func (c *Client) PreviewPlanChange(subscriptionID string, newPriceID string, new
 		},
 		SubscriptionProrationBehavior: stripe.String("always_invoice"),
 	}
+	if couponID != "" {
+		params.Discounts = []*stripe.InvoiceDiscountParams{
+			{Coupon: stripe.String(couponID)},
+		}
+	}
 
 	inv, err := invoice.Upcoming(params)
 	if err != nil {
@@ -459,6 +482,95 @@ func (c *Client) PreviewPlanChange(subscriptionID string, newPriceID string, new
 	return inv, nil
 }
 
+// CreateCouponParams contains parameters for creating a coupon
+type CreateCouponParams struct {
+	Name             string
+	PercentOff       *float64
+	AmountOff        *int64 // cents
+	Currency         string // required with AmountOff
+	Duration         string // once, repeating, forever
+	DurationInMonths *int64
+	MaxRedemptions   *int64
+	RedeemBy         *time.Time
+	Metadata         map[string]string
+}
+
+// CreateCoupon creates a Stripe coupon
+func (c *Client) CreateCoupon(params CreateCouponParams) (*stripe.Coupon, error) {
+	logger.Infof("stripe.CreateCoupon name=%s duration=%s", params.Name, params.Duration)
+
+	couponParams := &stripe.CouponParams{
+		Name:             stripe.String(params.Name),
+		PercentOff:       params.PercentOff,
+		AmountOff:        params.AmountOff,
+		Duration:         stripe.String(params.Duration),
+		DurationInMonths: params.DurationInMonths,
+		MaxRedemptions:   params.MaxRedemptions,
+	}
+	if params.AmountOff != nil && params.Currency != "" {
+		couponParams.Currency = stripe.String(strings.ToLower(params.Currency))
+	}
+	if params.RedeemBy != nil {
+		couponParams.RedeemBy = stripe.Int64(params.RedeemBy.Unix())
+	}
+	for k, v := range params.Metadata {
+		couponParams.AddMetadata(k, v)
+	}
+
+	cp, err := coupon.New(couponParams)
+	if err != nil {
+		logger.Errorf("stripe.CreateCoupon failed name=%s err=%v", params.Name, err)
+		return nil, fmt.Errorf("failed to create coupon: %w", err)
+	}
+
+	logger.Infof("stripe.CreateCoupon ok coupon_id=%s", cp.ID)
+	return cp, nil
+}
+
+// DeleteCoupon deletes a Stripe coupon. Existing discounts on subscriptions are not affected.
+func (c *Client) DeleteCoupon(couponID string) error {
+	logger.Infof("stripe.DeleteCoupon coupon_id=%s", couponID)
+	if _, err := coupon.Del(couponID, nil); err != nil {
+		logger.Errorf("stripe.DeleteCoupon failed coupon_id=%s err=%v", couponID, err)
+		return fmt.Errorf("failed to delete coupon: %w", err)
+	}
+	return nil
+}
+
+// UpdateTrialEnd moves the trial end of a trialing subscription without prorating
+func (c *Client) UpdateTrialEnd(subscriptionID string, trialEnd time.Time) (*stripe.Subscription, error) {
+	if subscriptionID == "" {
+		return nil, fmt.Errorf("subscriptionID is required")
+	}
+
+	logger.Infof("stripe.UpdateTrialEnd subscription_id=%s trial_end=%s", subscriptionID, trialEnd.Format(time.RFC3339))
+	params := &stripe.SubscriptionParams{
+		TrialEnd:          stripe.Int64(trialEnd.Unix()),
+		ProrationBehavior: stripe.String("none"),
+	}
+
+	updated, err := subscription.Update(subscriptionID, params)
+	if err != nil {
+		logger.Errorf("stripe.UpdateTrialEnd failed subscription_id=%s err=%v", subscriptionID, err)
+		return nil, fmt.Errorf("failed to update trial end: %w", err)
+	}
+	return updated, nil
+}
+
+// TotalDiscountAmount sums all discounts applied to an invoice (cents)
+func TotalDiscountAmount(inv *stripe.Invoice) int64 {
+	if inv == nil {
+		return 0
+	}
+	total := int64(0)
+	for _, d := range inv.TotalDiscountAmounts {
+		if d != nil {
+			total += d.Amount
+		}
+	}
+	return total
+}
+
 // ConstructWebhookEvent constructs and verifies a webhook event
 func (c *Client) ConstructWebhookEvent(payload []byte, signature string) (stripe.Event, error) {
 	logger.Infof("stripe.ConstructWebhookEvent payload_size=%d signature_present=%t", len(payload), signature != "")